@@ -27,7 +27,9 @@
 // context, enhancing the client.Info with an implementation of client.AuthData,
 // and storing a new client.Info into the context that it passes down. The
 // attribute names should be documented with their return types and considered
-// part of the public API for the authenticator.
+// part of the public API for the authenticator. The authenticators knowing the
+// subject, the scopes or the tenant of the client should expose them as
+// structured claims, with configauth.ContextWithClaims.
 //
 // # Consumers
 //
@@ -42,6 +44,9 @@
 //
 // - rate limit client calls based on IP addresses
 //
+// - route data points or enforce quotas based on the claims of the client,
+// read with Info.Claims or ClaimsFromContext
+//
 // Processors and exporters relying on the existence of data from the
 // client.Info, especially client.AuthData, should clearly document this as part
 // of the component's README file. The expected pattern for consuming data is to
@@ -114,6 +119,58 @@ type AuthData interface {
 	GetAttributeNames() []string
 }
 
+// The names of the AuthData attributes holding the claims of the authenticated client, as set by
+// configauth.ContextWithClaims. The subject and the tenant are strings, the scopes a list of strings.
+const (
+	ClaimSubject = "subject"
+	ClaimScopes  = "scopes"
+	ClaimTenant  = "tenant"
+)
+
+// Claims are the structured claims of an authenticated client. Processors and exporters can
+// use them to route the data or to enforce quotas per subject or tenant.
+type Claims struct {
+	// Subject is the principal the request was authenticated as, e.g. a username.
+	Subject string
+
+	// Scopes are the scopes or permissions granted to the subject.
+	Scopes []string
+
+	// Tenant is the tenant the subject belongs to, if any.
+	Tenant string
+}
+
+// HasScope returns whether the claims grant the given scope.
+func (c Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims returns the claims found in the authentication data of the client. The claims not set by the
+// authenticator, or not of the expected type, are left empty.
+func (i Info) Claims() Claims {
+	var c Claims
+	if i.Auth == nil {
+		return c
+	}
+	c.Subject, _ = i.Auth.GetAttribute(ClaimSubject).(string)
+	c.Tenant, _ = i.Auth.GetAttribute(ClaimTenant).(string)
+	if scopes, ok := i.Auth.GetAttribute(ClaimScopes).([]string); ok {
+		c.Scopes = make([]string, len(scopes))
+		copy(c.Scopes, scopes)
+	}
+	return c
+}
+
+// ClaimsFromContext returns the claims of the client.Info of the context, see Info.Claims.
+func ClaimsFromContext(ctx context.Context) Claims {
+	return FromContext(ctx).Claims()
+}
+
 const MetadataHostName = "Host"
 
 // NewContext takes an existing context and derives a new context with the
@@ -95,3 +95,35 @@ func TestUninstantiatedMetadata(t *testing.T) {
 	i := Info{}
 	assert.Empty(t, i.Metadata.Get("test"))
 }
+
+type mapAuthData map[string]any
+
+func (m mapAuthData) GetAttribute(name string) any {
+	return m[name]
+}
+
+func (m mapAuthData) GetAttributeNames() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestClaims(t *testing.T) {
+	assert.Equal(t, Claims{}, ClaimsFromContext(context.Background()))
+
+	ctx := NewContext(context.Background(), Info{Auth: mapAuthData{
+		ClaimSubject: "alice",
+		ClaimScopes:  []string{"metrics:write", "logs:write"},
+		ClaimTenant:  "acme",
+	}})
+	claims := ClaimsFromContext(ctx)
+	assert.Equal(t, Claims{Subject: "alice", Scopes: []string{"metrics:write", "logs:write"}, Tenant: "acme"}, claims)
+	assert.True(t, claims.HasScope("logs:write"))
+	assert.False(t, claims.HasScope("traces:write"))
+
+	// The attributes of unexpected types are ignored.
+	info := Info{Auth: mapAuthData{ClaimSubject: 42, ClaimScopes: "metrics:write"}}
+	assert.Equal(t, Claims{}, info.Claims())
+}
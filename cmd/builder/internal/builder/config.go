@@ -15,6 +15,8 @@ import (
 	"github.com/hashicorp/go-version"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
 )
 
 const defaultOtelColVersion = "0.107.0"
@@ -24,8 +26,17 @@ var (
 	ErrMissingGoMod = errors.New("missing gomod specification for module")
 	// ErrIncompatibleConfigurationValues indicates that there is configuration that cannot be combined
 	ErrIncompatibleConfigurationValues = errors.New("cannot combine configuration values")
+	// ErrInvalidReplace indicates a replace directive pointing to a local path which is not the replaced module
+	ErrInvalidReplace = errors.New("invalid replace directive")
 )
 
+// coreAPIModulePrefixes are the prefixes of the modules released together with the core collector API,
+// whose v0 minor versions must match the one of the core collector.
+var coreAPIModulePrefixes = []string{
+	"go.opentelemetry.io/collector",
+	"github.com/open-telemetry/opentelemetry-collector-contrib",
+}
+
 // Config holds the builder's configuration
 type Config struct {
 	Logger *zap.Logger
@@ -37,6 +48,7 @@ type Config struct {
 	SkipStrictVersioning bool   `mapstructure:"-"`
 	LDFlags              string `mapstructure:"-"`
 	Verbose              bool   `mapstructure:"-"`
+	DependencyReport     string `mapstructure:"-"`
 
 	Distribution Distribution `mapstructure:"dist"`
 	Exporters    []Module     `mapstructure:"exporters"`
@@ -253,6 +265,97 @@ func (c *Config) validateFlags() error {
 	return nil
 }
 
+// validateVersions checks that the components released with the core collector API require the same minor version
+// of it as the distribution, so the incompatibilities are reported before the distribution is generated.
+// The components replaced by a local path or by a replace directive are not checked, their version being ignored.
+// When the distribution is part of an existing module, the versions are resolved with it and checked once go.mod is updated.
+func (c *Config) validateVersions() error {
+	if c.SkipStrictVersioning || c.SkipNewGoModule {
+		return nil
+	}
+	coreVersion := "v" + c.Distribution.OtelColVersion
+	if !semver.IsValid(coreVersion) {
+		return fmt.Errorf("invalid otelcol_version %q", c.Distribution.OtelColVersion)
+	}
+	replaced := map[string]bool{}
+	for _, r := range c.Replaces {
+		if old, _, ok := strings.Cut(r, "=>"); ok {
+			mod, _, _ := strings.Cut(strings.TrimSpace(old), " ")
+			replaced[mod] = true
+		}
+	}
+
+	var errs error
+	for _, mod := range c.allComponents() {
+		if mod.GoMod == "" {
+			// Reported by validateModules.
+			continue
+		}
+		path, ver, _ := strings.Cut(mod.GoMod, " ")
+		// Malformed versions are reported by the go toolchain.
+		if !semver.IsValid(ver) || mod.Path != "" || replaced[path] || semver.Major(ver) != "v0" || !isCoreAPIModule(path) {
+			continue
+		}
+		if semver.MajorMinor(ver) != semver.MajorMinor(coreVersion) {
+			errs = multierr.Append(errs, fmt.Errorf(
+				"%w: component %q version %q is not compatible with the configured otelcol_version %q. %s",
+				ErrVersionMismatch, path, ver, c.Distribution.OtelColVersion, skipStrictMsg))
+		}
+	}
+	return errs
+}
+
+func isCoreAPIModule(path string) bool {
+	for _, prefix := range coreAPIModulePrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateReplaces checks that the local paths the modules are replaced with contain the replaced modules.
+// The relative paths of the replace directives are relative to the output path, where the go.mod file is generated.
+func (c *Config) validateReplaces() error {
+	var errs error
+	for _, r := range c.Replaces {
+		old, replacement, ok := strings.Cut(r, "=>")
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("%w %q: missing '=>' separator", ErrInvalidReplace, r))
+			continue
+		}
+		oldPath, _, _ := strings.Cut(strings.TrimSpace(old), " ")
+		replacement = strings.TrimSpace(replacement)
+		if !modfile.IsDirectoryPath(replacement) {
+			continue
+		}
+		if !filepath.IsAbs(replacement) {
+			replacement = filepath.Join(c.Distribution.OutputPath, replacement)
+		}
+		errs = multierr.Append(errs, checkLocalModule(oldPath, replacement))
+	}
+	for _, mod := range c.allComponents() {
+		if mod.Path != "" {
+			path, _, _ := strings.Cut(mod.GoMod, " ")
+			errs = multierr.Append(errs, checkLocalModule(path, mod.Path))
+		}
+	}
+	return errs
+}
+
+// checkLocalModule checks that dir contains the module with the given path.
+func checkLocalModule(modulePath string, dir string) error {
+	gomod := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(filepath.Clean(gomod))
+	if err != nil {
+		return fmt.Errorf("%w for %q: %w", ErrInvalidReplace, modulePath, err)
+	}
+	if got := modfile.ModulePath(data); got != modulePath {
+		return fmt.Errorf("%w for %q: %q is the module %q", ErrInvalidReplace, modulePath, dir, got)
+	}
+	return nil
+}
+
 func (c *Config) validateModules(name string, mods []Module) error {
 	for i, mod := range mods {
 		if mod.GoMod == "" {
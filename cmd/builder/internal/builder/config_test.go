@@ -5,6 +5,7 @@ package builder
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -350,3 +351,101 @@ func TestSkipsNilFieldValidation(t *testing.T) {
 	cfg.Providers = nil
 	assert.NoError(t, cfg.Validate())
 }
+
+func TestValidateVersions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name: "matching versions",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "go.opentelemetry.io/collector/receiver/otlpreceiver v0.107.1"}}
+				cfg.Exporters = []Module{{GoMod: "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter v0.107.0"}}
+				cfg.Extensions = []Module{{GoMod: "github.com/org/repo v0.1.2"}}
+			},
+		},
+		{
+			name: "stable module",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "go.opentelemetry.io/collector/pdata v1.13.0"}}
+			},
+		},
+		{
+			name: "mismatch",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []Module{{GoMod: "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter v0.100.0"}}
+			},
+			expectedErr: `component "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter" version "v0.100.0" is not compatible with the configured otelcol_version "0.107.0"`,
+		},
+		{
+			name: "replaced module",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []Module{{GoMod: "go.opentelemetry.io/collector/exporter/otlpexporter v0.100.0"}}
+				cfg.Replaces = []string{"go.opentelemetry.io/collector/exporter/otlpexporter => ../otlpexporter"}
+			},
+		},
+		{
+			name: "invalid otelcol version",
+			modify: func(cfg *Config) {
+				cfg.Distribution.OtelColVersion = "latest"
+			},
+			expectedErr: `invalid otelcol_version "latest"`,
+		},
+		{
+			name: "existing module",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []Module{{GoMod: "go.opentelemetry.io/collector/exporter/otlpexporter v0.100.0"}}
+				cfg.SkipNewGoModule = true
+			},
+		},
+		{
+			name: "skip strict versioning",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []Module{{GoMod: "go.opentelemetry.io/collector/exporter/otlpexporter v0.100.0"}}
+				cfg.SkipStrictVersioning = true
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tc.modify(&cfg)
+			err := cfg.validateVersions()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateReplaces(t *testing.T) {
+	outputDir := t.TempDir()
+	moduleDir := filepath.Join(outputDir, "mymodule")
+	require.NoError(t, os.Mkdir(moduleDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module github.com/org/mymodule\n"), 0600))
+
+	cfg := NewDefaultConfig()
+	cfg.Distribution.OutputPath = outputDir
+	cfg.Replaces = []string{
+		"github.com/org/mymodule => ./mymodule",
+		"github.com/org/mymodule v0.1.0 => " + moduleDir,
+		"github.com/org/other => github.com/fork/other v0.2.0",
+	}
+	cfg.Exporters = []Module{{GoMod: "github.com/org/mymodule v0.1.0", Path: moduleDir}}
+	require.NoError(t, cfg.validateReplaces())
+
+	cfg.Replaces = []string{"github.com/org/othermodule => ./mymodule"}
+	err := cfg.validateReplaces()
+	require.ErrorIs(t, err, ErrInvalidReplace)
+	assert.ErrorContains(t, err, `is the module "github.com/org/mymodule"`)
+
+	cfg.Replaces = []string{"github.com/org/mymodule => ../missing"}
+	require.ErrorIs(t, cfg.validateReplaces(), ErrInvalidReplace)
+
+	cfg.Replaces = []string{"github.com/org/mymodule ./mymodule"}
+	assert.ErrorContains(t, cfg.validateReplaces(), "missing '=>' separator")
+}
@@ -5,6 +5,7 @@ package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/bu
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"strings"
 	"text/template"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
@@ -84,6 +86,10 @@ func Generate(cfg Config) error {
 		return fmt.Errorf("failed to create output path: %w", err)
 	}
 
+	if err := multierr.Combine(cfg.validateVersions(), cfg.validateReplaces()); err != nil {
+		return err
+	}
+
 	allTemplates := []*template.Template{
 		mainTemplate,
 		mainOthersTemplate,
@@ -154,6 +160,12 @@ func GetModules(cfg Config) error {
 		return fmt.Errorf("failed to update go.mod: %w", err)
 	}
 
+	if cfg.DependencyReport != "" {
+		if err := writeDependencyReport(cfg); err != nil {
+			return fmt.Errorf("failed to write the dependency report: %w", err)
+		}
+	}
+
 	if cfg.SkipStrictVersioning {
 		return nil
 	}
@@ -217,8 +229,12 @@ func (c *Config) coreModuleAndVersion() (string, string) {
 }
 
 func (c *Config) allComponents() []Module {
+	var providers []Module
+	if c.Providers != nil {
+		providers = *c.Providers
+	}
 	return slices.Concat[[]Module](c.Exporters, c.Receivers, c.Processors,
-		c.Extensions, c.Connectors, *c.Providers)
+		c.Extensions, c.Connectors, providers)
 }
 
 func (c *Config) updateModules() error {
@@ -283,13 +299,71 @@ func (c *Config) updateGoModule(modspec string) error {
 	return nil
 }
 
-func (c *Config) readGoModFile() (string, map[string]string, error) {
-	var modPath string
+// DependencyReport describes the versions of the modules resolved for a distribution.
+type DependencyReport struct {
+	Core       ModuleReport   `json:"core"`
+	Components []ModuleReport `json:"components"`
+}
+
+// ModuleReport describes the version of a module resolved for a distribution.
+type ModuleReport struct {
+	Module string `json:"module"`
+	// Configured is the version set in the build configuration.
+	Configured string `json:"configured"`
+	// Resolved is the version selected by the Go toolchain, considering all the components.
+	Resolved string `json:"resolved,omitempty"`
+	// Replacement is the module or local path the module is replaced with, if any.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// writeDependencyReport writes the versions of the core collector and components resolved in the go.mod file,
+// in JSON format.
+func writeDependencyReport(cfg Config) error {
+	file, err := cfg.readGoMod()
+	if err != nil {
+		return err
+	}
+	resolved := map[string]string{}
+	for _, req := range file.Require {
+		resolved[req.Mod.Path] = req.Mod.Version
+	}
+	replacements := map[string]string{}
+	for _, rep := range file.Replace {
+		replacements[rep.Old.Path] = strings.TrimSpace(rep.New.Path + " " + rep.New.Version)
+	}
+	newReport := func(module, configured string) ModuleReport {
+		return ModuleReport{Module: module, Configured: configured, Resolved: resolved[module], Replacement: replacements[module]}
+	}
+
+	coreModule, coreVersion := cfg.coreModuleAndVersion()
+	report := DependencyReport{Core: newReport(coreModule, coreVersion)}
+	for _, mod := range cfg.allComponents() {
+		module, version, _ := strings.Cut(mod.GoMod, " ")
+		report.Components = append(report.Components, newReport(module, version))
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(cfg.DependencyReport, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	cfg.Logger.Info("Dependency report written", zap.String("path", cfg.DependencyReport))
+	return nil
+}
+
+func (c *Config) readGoMod() (*modfile.File, error) {
 	stdout, err := runGoCommand(*c, "mod", "edit", "-print")
 	if err != nil {
-		return modPath, nil, err
+		return nil, err
 	}
-	parsedFile, err := modfile.Parse("go.mod", stdout, nil)
+	return modfile.Parse("go.mod", stdout, nil)
+}
+
+func (c *Config) readGoModFile() (string, map[string]string, error) {
+	var modPath string
+	parsedFile, err := c.readGoMod()
 	if err != nil {
 		return modPath, nil, err
 	}
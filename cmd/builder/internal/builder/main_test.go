@@ -4,6 +4,7 @@
 package builder
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -25,6 +26,7 @@ var (
 	replaceModules = []string{
 		"",
 		"/component",
+		"/component/componententities",
 		"/component/componentprofiles",
 		"/component/componentstatus",
 		"/client",
@@ -39,35 +41,42 @@ var (
 		"/config/configtls",
 		"/config/internal",
 		"/confmap",
+		"/confmap/converter/redactconverter",
 		"/confmap/provider/envprovider",
 		"/confmap/provider/fileprovider",
 		"/confmap/provider/httpprovider",
 		"/confmap/provider/httpsprovider",
 		"/confmap/provider/yamlprovider",
 		"/consumer",
+		"/consumer/consumerentities",
 		"/consumer/consumerprofiles",
 		"/consumer/consumertest",
 		"/connector",
 		"/exporter",
 		"/exporter/debugexporter",
+		"/exporter/exporterentities",
 		"/exporter/nopexporter",
 		"/exporter/otlpexporter",
 		"/exporter/otlphttpexporter",
 		"/extension",
 		"/extension/auth",
+		"/extension/middleware",
 		"/extension/zpagesextension",
 		"/featuregate",
 		"/internal/globalgates",
 		"/processor",
 		"/processor/batchprocessor",
+		"/processor/processorentities",
 		"/processor/memorylimiterprocessor",
 		"/receiver",
 		"/receiver/nopreceiver",
+		"/receiver/receiverentities",
 		"/receiver/otlpreceiver",
 		"/otelcol",
 		"/pdata",
 		"/pdata/testdata",
 		"/pdata/pprofile",
+		"/pdata/pentity",
 		"/semconv",
 		"/service",
 	}
@@ -805,3 +814,30 @@ func getWorkspaceDir() string {
 	_, thisFile, _, _ := runtime.Caller(0)
 	return filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(thisFile)))))
 }
+
+func TestWriteDependencyReport(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Distribution.Go = "go"
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.OtelColVersion = "0.106.0"
+	cfg.Distribution.RequireOtelColModule = true
+	require.NoError(t, makeModule(cfg.Distribution.OutputPath, []byte(goModTestFile+
+		"\nreplace go.opentelemetry.io/collector/exporter/otlpexporter => ../otlpexporter\n")))
+	cfg.Exporters = []Module{{GoMod: "go.opentelemetry.io/collector/exporter/otlpexporter v0.106.0"}}
+	cfg.Receivers = []Module{{GoMod: "github.com/org/receiver v0.1.0"}}
+	cfg.Providers = &[]Module{}
+	cfg.DependencyReport = filepath.Join(t.TempDir(), "report.json")
+
+	require.NoError(t, writeDependencyReport(cfg))
+	data, err := os.ReadFile(cfg.DependencyReport)
+	require.NoError(t, err)
+	var report DependencyReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, DependencyReport{
+		Core: ModuleReport{Module: "go.opentelemetry.io/collector/otelcol", Configured: "v0.106.0", Resolved: "v0.106.0"},
+		Components: []ModuleReport{
+			{Module: "go.opentelemetry.io/collector/exporter/otlpexporter", Configured: "v0.106.0", Resolved: "v0.106.0", Replacement: "../otlpexporter"},
+			{Module: "github.com/org/receiver", Configured: "v0.1.0"},
+		},
+	}, report)
+}
@@ -34,6 +34,7 @@ const (
 	distributionGoFlag             = "go"
 	distributionModuleFlag         = "module"
 	verboseFlag                    = "verbose"
+	dependencyReportFlag           = "dependency-report"
 )
 
 var (
@@ -88,6 +89,7 @@ configuration is provided, ocb will generate a default Collector.
 	cmd.Flags().BoolVar(&cfg.SkipNewGoModule, skipNewGoModuleFlag, false, "Whether builder should skip generating a new go.mod file, using the enclosing Go module instead (default false)")
 	cmd.Flags().BoolVar(&cfg.SkipStrictVersioning, skipStrictVersioningFlag, false, "Whether builder should skip strictly checking the calculated versions following dependency resolution")
 	cmd.Flags().BoolVar(&cfg.Verbose, verboseFlag, false, "Whether builder should print verbose output (default false)")
+	cmd.Flags().StringVar(&cfg.DependencyReport, dependencyReportFlag, "", "Path of the file where the builder writes the versions of the modules resolved for the distribution, in JSON format")
 	cmd.Flags().StringVar(&cfg.LDFlags, ldflagsFlag, "", `ldflags to include in the "go build" command`)
 	cmd.Flags().StringVar(&cfg.Distribution.Name, distributionNameFlag, "otelcol-custom", "The executable name for the OpenTelemetry Collector distribution")
 	if err := cmd.Flags().MarkDeprecated(distributionNameFlag, "use config distribution::name"); err != nil {
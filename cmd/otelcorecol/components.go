@@ -5,24 +5,43 @@ package main
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
+	countconnector "go.opentelemetry.io/collector/connector/countconnector"
 	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
+	thresholdconnector "go.opentelemetry.io/collector/connector/thresholdconnector"
 	"go.opentelemetry.io/collector/exporter"
 	debugexporter "go.opentelemetry.io/collector/exporter/debugexporter"
 	loggingexporter "go.opentelemetry.io/collector/exporter/loggingexporter"
 	nopexporter "go.opentelemetry.io/collector/exporter/nopexporter"
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	pluginexporter "go.opentelemetry.io/collector/exporter/pluginexporter"
 	"go.opentelemetry.io/collector/extension"
+	ackextension "go.opentelemetry.io/collector/extension/ackextension"
+	apikeyauthextension "go.opentelemetry.io/collector/extension/apikeyauthextension"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	bearertokenauthextension "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+	k8sobserverextension "go.opentelemetry.io/collector/extension/k8sobserverextension"
 	memorylimiterextension "go.opentelemetry.io/collector/extension/memorylimiterextension"
+	oauth2clientauthextension "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+	opampextension "go.opentelemetry.io/collector/extension/opampextension"
+	pprofextension "go.opentelemetry.io/collector/extension/pprofextension"
+	receivercontrolextension "go.opentelemetry.io/collector/extension/receivercontrolextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
+	aggregationprocessor "go.opentelemetry.io/collector/processor/aggregationprocessor"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
+	deduplicationprocessor "go.opentelemetry.io/collector/processor/deduplicationprocessor"
+	filterprocessor "go.opentelemetry.io/collector/processor/filterprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	pluginprocessor "go.opentelemetry.io/collector/processor/pluginprocessor"
+	probabilisticsamplerprocessor "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+	temporalityprocessor "go.opentelemetry.io/collector/processor/temporalityprocessor"
 	"go.opentelemetry.io/collector/receiver"
+	loadgenreceiver "go.opentelemetry.io/collector/receiver/loadgenreceiver"
 	nopreceiver "go.opentelemetry.io/collector/receiver/nopreceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
+	pluginreceiver "go.opentelemetry.io/collector/receiver/pluginreceiver"
 )
 
 func components() (otelcol.Factories, error) {
@@ -32,6 +51,14 @@ func components() (otelcol.Factories, error) {
 	factories.Extensions, err = extension.MakeFactoryMap(
 		ballastextension.NewFactory(),
 		memorylimiterextension.NewFactory(),
+		oauth2clientauthextension.NewFactory(),
+		bearertokenauthextension.NewFactory(),
+		apikeyauthextension.NewFactory(),
+		k8sobserverextension.NewFactory(),
+		ackextension.NewFactory(),
+		opampextension.NewFactory(),
+		pprofextension.NewFactory(),
+		receivercontrolextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
@@ -40,18 +67,30 @@ func components() (otelcol.Factories, error) {
 	factories.ExtensionModules = make(map[component.Type]string, len(factories.Extensions))
 	factories.ExtensionModules[ballastextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/ballastextension v0.107.0"
 	factories.ExtensionModules[memorylimiterextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/memorylimiterextension v0.107.0"
+	factories.ExtensionModules[oauth2clientauthextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/oauth2clientauthextension v0.107.0"
+	factories.ExtensionModules[bearertokenauthextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/bearertokenauthextension v0.107.0"
+	factories.ExtensionModules[apikeyauthextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/apikeyauthextension v0.107.0"
+	factories.ExtensionModules[k8sobserverextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/k8sobserverextension v0.107.0"
+	factories.ExtensionModules[ackextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/ackextension v0.107.0"
+	factories.ExtensionModules[opampextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/opampextension v0.107.0"
+	factories.ExtensionModules[pprofextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/pprofextension v0.107.0"
+	factories.ExtensionModules[receivercontrolextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/receivercontrolextension v0.107.0"
 	factories.ExtensionModules[zpagesextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/zpagesextension v0.107.0"
 
 	factories.Receivers, err = receiver.MakeFactoryMap(
 		nopreceiver.NewFactory(),
+		loadgenreceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
+		pluginreceiver.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ReceiverModules = make(map[component.Type]string, len(factories.Receivers))
 	factories.ReceiverModules[nopreceiver.NewFactory().Type()] = "go.opentelemetry.io/collector/receiver/nopreceiver v0.107.0"
+	factories.ReceiverModules[loadgenreceiver.NewFactory().Type()] = "go.opentelemetry.io/collector/receiver/loadgenreceiver v0.107.0"
 	factories.ReceiverModules[otlpreceiver.NewFactory().Type()] = "go.opentelemetry.io/collector/receiver/otlpreceiver v0.107.0"
+	factories.ReceiverModules[pluginreceiver.NewFactory().Type()] = "go.opentelemetry.io/collector/receiver/pluginreceiver v0.107.0"
 
 	factories.Exporters, err = exporter.MakeFactoryMap(
 		debugexporter.NewFactory(),
@@ -59,6 +98,7 @@ func components() (otelcol.Factories, error) {
 		nopexporter.NewFactory(),
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
+		pluginexporter.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
@@ -69,26 +109,43 @@ func components() (otelcol.Factories, error) {
 	factories.ExporterModules[nopexporter.NewFactory().Type()] = "go.opentelemetry.io/collector/exporter/nopexporter v0.107.0"
 	factories.ExporterModules[otlpexporter.NewFactory().Type()] = "go.opentelemetry.io/collector/exporter/otlpexporter v0.107.0"
 	factories.ExporterModules[otlphttpexporter.NewFactory().Type()] = "go.opentelemetry.io/collector/exporter/otlphttpexporter v0.107.0"
+	factories.ExporterModules[pluginexporter.NewFactory().Type()] = "go.opentelemetry.io/collector/exporter/pluginexporter v0.107.0"
 
 	factories.Processors, err = processor.MakeFactoryMap(
 		batchprocessor.NewFactory(),
+		filterprocessor.NewFactory(),
+		temporalityprocessor.NewFactory(),
+		probabilisticsamplerprocessor.NewFactory(),
+		aggregationprocessor.NewFactory(),
+		deduplicationprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
+		pluginprocessor.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ProcessorModules = make(map[component.Type]string, len(factories.Processors))
 	factories.ProcessorModules[batchprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/batchprocessor v0.107.0"
+	factories.ProcessorModules[filterprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/filterprocessor v0.107.0"
+	factories.ProcessorModules[temporalityprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/temporalityprocessor v0.107.0"
+	factories.ProcessorModules[probabilisticsamplerprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor v0.107.0"
+	factories.ProcessorModules[aggregationprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/aggregationprocessor v0.107.0"
+	factories.ProcessorModules[deduplicationprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/deduplicationprocessor v0.107.0"
 	factories.ProcessorModules[memorylimiterprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.107.0"
+	factories.ProcessorModules[pluginprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/pluginprocessor v0.107.0"
 
 	factories.Connectors, err = connector.MakeFactoryMap(
 		forwardconnector.NewFactory(),
+		thresholdconnector.NewFactory(),
+		countconnector.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ConnectorModules = make(map[component.Type]string, len(factories.Connectors))
 	factories.ConnectorModules[forwardconnector.NewFactory().Type()] = "go.opentelemetry.io/collector/connector/forwardconnector v0.107.0"
+	factories.ConnectorModules[thresholdconnector.NewFactory().Type()] = "go.opentelemetry.io/collector/connector/thresholdconnector v0.107.0"
+	factories.ConnectorModules[countconnector.NewFactory().Type()] = "go.opentelemetry.io/collector/connector/countconnector v0.107.0"
 
 	return factories, nil
 }
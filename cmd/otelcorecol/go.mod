@@ -15,24 +15,43 @@ require (
 	go.opentelemetry.io/collector/confmap/provider/httpsprovider v0.107.0
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v0.107.0
 	go.opentelemetry.io/collector/connector v0.107.0
+	go.opentelemetry.io/collector/connector/countconnector v0.107.0
 	go.opentelemetry.io/collector/connector/forwardconnector v0.107.0
+	go.opentelemetry.io/collector/connector/thresholdconnector v0.107.0
 	go.opentelemetry.io/collector/exporter v0.107.0
 	go.opentelemetry.io/collector/exporter/debugexporter v0.107.0
 	go.opentelemetry.io/collector/exporter/loggingexporter v0.107.0
 	go.opentelemetry.io/collector/exporter/nopexporter v0.107.0
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.107.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.107.0
+	go.opentelemetry.io/collector/exporter/pluginexporter v0.107.0
 	go.opentelemetry.io/collector/extension v0.107.0
+	go.opentelemetry.io/collector/extension/ackextension v0.107.0
+	go.opentelemetry.io/collector/extension/apikeyauthextension v0.107.0
 	go.opentelemetry.io/collector/extension/ballastextension v0.107.0
+	go.opentelemetry.io/collector/extension/bearertokenauthextension v0.107.0
+	go.opentelemetry.io/collector/extension/k8sobserverextension v0.107.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.107.0
+	go.opentelemetry.io/collector/extension/oauth2clientauthextension v0.107.0
+	go.opentelemetry.io/collector/extension/opampextension v0.107.0
+	go.opentelemetry.io/collector/extension/pprofextension v0.107.0
+	go.opentelemetry.io/collector/extension/receivercontrolextension v0.107.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.107.0
 	go.opentelemetry.io/collector/otelcol v0.107.0
 	go.opentelemetry.io/collector/processor v0.107.0
+	go.opentelemetry.io/collector/processor/aggregationprocessor v0.107.0
 	go.opentelemetry.io/collector/processor/batchprocessor v0.107.0
+	go.opentelemetry.io/collector/processor/deduplicationprocessor v0.107.0
+	go.opentelemetry.io/collector/processor/filterprocessor v0.107.0
 	go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.107.0
+	go.opentelemetry.io/collector/processor/pluginprocessor v0.107.0
+	go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor v0.107.0
+	go.opentelemetry.io/collector/processor/temporalityprocessor v0.107.0
 	go.opentelemetry.io/collector/receiver v0.107.0
+	go.opentelemetry.io/collector/receiver/loadgenreceiver v0.107.0
 	go.opentelemetry.io/collector/receiver/nopreceiver v0.107.0
 	go.opentelemetry.io/collector/receiver/otlpreceiver v0.107.0
+	go.opentelemetry.io/collector/receiver/pluginreceiver v0.107.0
 	golang.org/x/sys v0.24.0
 )
 
@@ -65,6 +84,7 @@ require (
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/mostynb/go-grpc-compression v1.2.3 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/open-telemetry/opamp-go v0.15.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/power-devops/perfstat v0.0.0-20210106213030-5aafc221ea8c // indirect
 	github.com/prometheus/client_golang v1.20.1 // indirect
@@ -82,6 +102,7 @@ require (
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
 	go.opentelemetry.io/collector v0.107.0 // indirect
 	go.opentelemetry.io/collector/client v1.13.0 // indirect
+	go.opentelemetry.io/collector/component/componententities v0.107.0 // indirect
 	go.opentelemetry.io/collector/component/componentprofiles v0.107.0 // indirect
 	go.opentelemetry.io/collector/component/componentstatus v0.107.0 // indirect
 	go.opentelemetry.io/collector/config/configauth v0.107.0 // indirect
@@ -94,15 +115,24 @@ require (
 	go.opentelemetry.io/collector/config/configtelemetry v0.107.0 // indirect
 	go.opentelemetry.io/collector/config/configtls v1.13.0 // indirect
 	go.opentelemetry.io/collector/config/internal v0.107.0 // indirect
+	go.opentelemetry.io/collector/confmap/converter/redactconverter v0.107.0 // indirect
 	go.opentelemetry.io/collector/consumer v0.107.0 // indirect
+	go.opentelemetry.io/collector/consumer/consumerentities v0.107.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumerprofiles v0.107.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumertest v0.107.0 // indirect
+	go.opentelemetry.io/collector/exporter/exporterentities v0.107.0 // indirect
 	go.opentelemetry.io/collector/extension/auth v0.107.0 // indirect
+	go.opentelemetry.io/collector/extension/middleware v0.107.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.13.0 // indirect
+	go.opentelemetry.io/collector/filter/filterexpr v0.107.0 // indirect
 	go.opentelemetry.io/collector/internal/globalgates v0.107.0 // indirect
+	go.opentelemetry.io/collector/internal/pdataconfig v0.107.0 // indirect
 	go.opentelemetry.io/collector/pdata v1.13.0 // indirect
+	go.opentelemetry.io/collector/pdata/pentity v0.107.0 // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.107.0 // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.107.0 // indirect
+	go.opentelemetry.io/collector/processor/processorentities v0.107.0 // indirect
+	go.opentelemetry.io/collector/receiver/receiverentities v0.107.0 // indirect
 	go.opentelemetry.io/collector/semconv v0.107.0 // indirect
 	go.opentelemetry.io/collector/service v0.107.0 // indirect
 	go.opentelemetry.io/contrib/config v0.8.0 // indirect
@@ -129,8 +159,10 @@ require (
 	go.opentelemetry.io/proto/otlp v1.3.1 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/crypto v0.26.0 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
 	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/oauth2 v0.21.0 // indirect
 	golang.org/x/text v0.17.0 // indirect
 	gonum.org/v1/gonum v0.15.1 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20240701130421-f6361c86f094 // indirect
@@ -144,6 +176,8 @@ replace go.opentelemetry.io/collector => ../../
 
 replace go.opentelemetry.io/collector/internal/globalgates => ../../internal/globalgates
 
+replace go.opentelemetry.io/collector/internal/pdataconfig => ../../internal/pdataconfig
+
 replace go.opentelemetry.io/collector/client => ../../client
 
 replace go.opentelemetry.io/collector/otelcol => ../../otelcol
@@ -176,6 +210,8 @@ replace go.opentelemetry.io/collector/config/internal => ../../config/internal
 
 replace go.opentelemetry.io/collector/confmap => ../../confmap
 
+replace go.opentelemetry.io/collector/confmap/converter/redactconverter => ../../confmap/converter/redactconverter
+
 replace go.opentelemetry.io/collector/confmap/provider/envprovider => ../../confmap/provider/envprovider
 
 replace go.opentelemetry.io/collector/confmap/provider/fileprovider => ../../confmap/provider/fileprovider
@@ -190,12 +226,18 @@ replace go.opentelemetry.io/collector/consumer => ../../consumer
 
 replace go.opentelemetry.io/collector/consumer/consumerprofiles => ../../consumer/consumerprofiles
 
+replace go.opentelemetry.io/collector/consumer/consumerentities => ../../consumer/consumerentities
+
 replace go.opentelemetry.io/collector/consumer/consumertest => ../../consumer/consumertest
 
 replace go.opentelemetry.io/collector/connector => ../../connector
 
 replace go.opentelemetry.io/collector/connector/forwardconnector => ../../connector/forwardconnector
 
+replace go.opentelemetry.io/collector/connector/thresholdconnector => ../../connector/thresholdconnector
+
+replace go.opentelemetry.io/collector/connector/countconnector => ../../connector/countconnector
+
 replace go.opentelemetry.io/collector/exporter => ../../exporter
 
 replace go.opentelemetry.io/collector/exporter/debugexporter => ../../exporter/debugexporter
@@ -212,10 +254,24 @@ replace go.opentelemetry.io/collector/extension => ../../extension
 
 replace go.opentelemetry.io/collector/extension/auth => ../../extension/auth
 
+replace go.opentelemetry.io/collector/extension/middleware => ../../extension/middleware
+
 replace go.opentelemetry.io/collector/extension/ballastextension => ../../extension/ballastextension
 
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
+replace go.opentelemetry.io/collector/extension/oauth2clientauthextension => ../../extension/oauth2clientauthextension
+
+replace go.opentelemetry.io/collector/extension/bearertokenauthextension => ../../extension/bearertokenauthextension
+
+replace go.opentelemetry.io/collector/extension/apikeyauthextension => ../../extension/apikeyauthextension
+
+replace go.opentelemetry.io/collector/extension/k8sobserverextension => ../../extension/k8sobserverextension
+
+replace go.opentelemetry.io/collector/extension/ackextension => ../../extension/ackextension
+
+replace go.opentelemetry.io/collector/extension/opampextension => ../../extension/opampextension
+
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
 
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
@@ -226,18 +282,52 @@ replace go.opentelemetry.io/collector/pdata/testdata => ../../pdata/testdata
 
 replace go.opentelemetry.io/collector/pdata/pprofile => ../../pdata/pprofile
 
+replace go.opentelemetry.io/collector/pdata/pentity => ../../pdata/pentity
+
 replace go.opentelemetry.io/collector/processor => ../../processor
 
 replace go.opentelemetry.io/collector/receiver => ../../receiver
 
 replace go.opentelemetry.io/collector/receiver/nopreceiver => ../../receiver/nopreceiver
 
+replace go.opentelemetry.io/collector/receiver/loadgenreceiver => ../../receiver/loadgenreceiver
+
+replace go.opentelemetry.io/collector/receiver/pluginreceiver => ../../receiver/pluginreceiver
+
+replace go.opentelemetry.io/collector/processor/pluginprocessor => ../../processor/pluginprocessor
+
+replace go.opentelemetry.io/collector/exporter/pluginexporter => ../../exporter/pluginexporter
+
 replace go.opentelemetry.io/collector/receiver/otlpreceiver => ../../receiver/otlpreceiver
 
 replace go.opentelemetry.io/collector/processor/batchprocessor => ../../processor/batchprocessor
 
+replace go.opentelemetry.io/collector/processor/filterprocessor => ../../processor/filterprocessor
+
+replace go.opentelemetry.io/collector/filter/filterexpr => ../../filter/filterexpr
+
 replace go.opentelemetry.io/collector/processor/memorylimiterprocessor => ../../processor/memorylimiterprocessor
 
+replace go.opentelemetry.io/collector/processor/temporalityprocessor => ../../processor/temporalityprocessor
+
+replace go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor => ../../processor/probabilisticsamplerprocessor
+
+replace go.opentelemetry.io/collector/processor/aggregationprocessor => ../../processor/aggregationprocessor
+
+replace go.opentelemetry.io/collector/processor/deduplicationprocessor => ../../processor/deduplicationprocessor
+
 replace go.opentelemetry.io/collector/semconv => ../../semconv
 
 replace go.opentelemetry.io/collector/service => ../../service
+
+replace go.opentelemetry.io/collector/component/componententities => ../../component/componententities
+
+replace go.opentelemetry.io/collector/exporter/exporterentities => ../../exporter/exporterentities
+
+replace go.opentelemetry.io/collector/processor/processorentities => ../../processor/processorentities
+
+replace go.opentelemetry.io/collector/receiver/receiverentities => ../../receiver/receiverentities
+
+replace go.opentelemetry.io/collector/extension/pprofextension => ../../extension/pprofextension
+
+replace go.opentelemetry.io/collector/extension/receivercontrolextension => ../../extension/receivercontrolextension
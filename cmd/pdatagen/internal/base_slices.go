@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pdatagen/internal"
+package internal // import "go.opentelemetry.io/collector/cmd/pdatagen/internal"
 
 import (
 	"bytes"
@@ -50,6 +50,21 @@ func (es {{ .structName }}) At(i int) {{ .elementName }} {
 	return {{ .newElement }}
 }
 
+// All returns an iterator over index-value pairs in the slice, see iter.Seq2.
+// It avoids the overhead of calling At for each element:
+//   for i, e := range es.All() {
+//       ... // Do something with the element
+//   }
+func (es {{ .structName }}) All() func(yield func(int, {{ .elementName }}) bool) {
+	return func(yield func(int, {{ .elementName }}) bool) {
+		for i := range *es.orig {
+			if !yield(i, {{ .newElement }}) {
+				return
+			}
+		}
+	}
+}
+
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
 // 1. If the newCap <= cap then no change in capacity.
 // 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
@@ -81,6 +96,32 @@ func (es {{ .structName }}) AppendEmpty() {{ .elementName }} {
 	return es.At(es.Len() - 1)
 }
 
+// AppendEmptyN will append to the end of the slice n empty {{ .elementName }} elements,
+// growing the slice at most once{{ if eq .type "sliceOfPtrs" }} and allocating all the elements at once{{ end }}.
+// The new elements are accessed with At, starting at the length of the slice before the call:
+//   start := es.Len()
+//   es.AppendEmptyN(n)
+//   for i := start; i < es.Len(); i++ {
+//       e := es.At(i)
+//       // Here should set all the values for e.
+//   }
+func (es {{ .structName }}) AppendEmptyN(n int) {
+	es.state.AssertMutable()
+	if n <= 0 {
+		return
+	}
+	{{- if eq .type "sliceOfPtrs" }}
+	oldLen := len(*es.orig)
+	*es.orig = append(*es.orig, make([]{{ .originElementType }}, n)...)
+	origs := make([]{{ .originName }}, n)
+	for i := range origs {
+		(*es.orig)[oldLen+i] = &origs[i]
+	}
+	{{- else }}
+	*es.orig = append(*es.orig, make([]{{ .originElementType }}, n)...)
+	{{- end }}
+}
+
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es {{ .structName }}) MoveAndAppendTo(dest {{ .structName }}) {
@@ -149,6 +190,42 @@ func (es {{ .structName }}) CopyTo(dest {{ .structName }}) {
 }
 
 {{ if eq .type "sliceOfPtrs" -}}
+// {{ .structName }}Builder appends elements to a {{ .structName }} when their number is not known in advance,
+// allocating the elements by chunks of the expected number of elements instead of one at a time.
+// It is meant for high-throughput receivers building large payloads.
+//
+// Must use New{{ .structName }}Builder function to create new instances.
+type {{ .structName }}Builder struct {
+	es       {{ .structName }}
+	sizeHint int
+	chunk    []{{ .originName }}
+}
+
+// New{{ .structName }}Builder returns a builder appending elements to es, which is expected to grow by sizeHint elements.
+// The capacity of es is ensured for sizeHint more elements.
+func New{{ .structName }}Builder(es {{ .structName }}, sizeHint int) *{{ .structName }}Builder {
+	sizeHint = max(sizeHint, 1)
+	es.EnsureCapacity(es.Len() + sizeHint)
+	return &{{ .structName }}Builder{es: es, sizeHint: sizeHint}
+}
+
+// AppendEmpty will append to the end of the slice an empty {{ .elementName }}.
+// It returns the newly added {{ .elementName }}.
+func (b *{{ .structName }}Builder) AppendEmpty() {{ .elementName }} {
+	b.es.state.AssertMutable()
+	if len(b.chunk) == 0 {
+		b.chunk = make([]{{ .originName }}, b.sizeHint)
+	}
+	*b.es.orig = append(*b.es.orig, &b.chunk[0])
+	b.chunk = b.chunk[1:]
+	return b.es.At(b.es.Len() - 1)
+}
+
+// Slice returns the {{ .structName }} the builder appends to.
+func (b *{{ .structName }}Builder) Slice() {{ .structName }} {
+	return b.es
+}
+
 // Sort sorts the {{ .elementName }} elements within {{ .structName }} given the
 // provided less function so that two instances of {{ .structName }}
 // can be compared.
@@ -176,11 +253,72 @@ const sliceTestTemplate = `func Test{{ .structName }}(t *testing.T) {
 	assert.Equal(t, 7, es.Len())
 }
 
+func Test{{ .structName }}All(t *testing.T) {
+	es := generateTest{{ .structName }}()
+	got := 0
+	es.All()(func(i int, el {{ .elementName }}) bool {
+		assert.Equal(t, got, i)
+		assert.Equal(t, es.At(i), el)
+		got++
+		return true
+	})
+	assert.Equal(t, es.Len(), got)
+
+	got = 0
+	es.All()(func(int, {{ .elementName }}) bool {
+		got++
+		return got < 2
+	})
+	assert.Equal(t, 2, got)
+}
+
+func Test{{ .structName }}_AppendEmptyN(t *testing.T) {
+	es := generateTest{{ .structName }}()
+	es.AppendEmptyN(0)
+	assert.Equal(t, 7, es.Len())
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTest{{ .structName }}().At(i), es.At(i))
+	}
+	emptyVal := New{{ .elementName }}()
+	testVal := generateTest{{ .elementName }}()
+	for i := 7; i < 10; i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTest{{ .elementName }}(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+}
+
+{{ if eq .type "sliceOfPtrs" -}}
+func Test{{ .structName }}Builder(t *testing.T) {
+	es := generateTest{{ .structName }}()
+	b := New{{ .structName }}Builder(es, 2)
+	assert.Equal(t, 9, cap(*es.orig))
+
+	emptyVal := New{{ .elementName }}()
+	testVal := generateTest{{ .elementName }}()
+	for i := 7; i < 12; i++ {
+		el := b.AppendEmpty()
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTest{{ .elementName }}(el)
+		assert.Equal(t, testVal, es.At(i))
+	}
+	assert.Equal(t, 12, es.Len())
+	assert.Equal(t, es, b.Slice())
+
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { New{{ .structName }}Builder(new{{ .structName }}(&[]{{ .originElementType }}{}, &sharedState), 0) })
+}
+
+{{ end -}}
 func Test{{ .structName }}ReadOnly(t *testing.T) {
 	sharedState := internal.StateReadOnly
 	es := new{{ .structName }}(&[]{{ .originElementType }}{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := New{{ .structName }}()
 	es.CopyTo(es2)
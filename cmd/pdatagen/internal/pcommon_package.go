@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pdatagen/internal"
+package internal // import "go.opentelemetry.io/collector/cmd/pdatagen/internal"
 
 var pcommon = &Package{
 	name: "pcommon",
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/cmd/pdatagen/internal"
+
+var pentity = &Package{
+	name: "pentity",
+	path: "pentity",
+	imports: []string{
+		`"sort"`,
+		``,
+		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`otlpentities "go.opentelemetry.io/collector/pdata/internal/data/entities/v1"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
+	},
+	testImports: []string{
+		`"testing"`,
+		`"unsafe"`,
+		``,
+		`"github.com/stretchr/testify/assert"`,
+		``,
+		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`otlpentities "go.opentelemetry.io/collector/pdata/internal/data/entities/v1"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
+	},
+	structs: []baseStruct{
+		resourceEntitiesSlice,
+		resourceEntities,
+		scopeEntitiesSlice,
+		scopeEntities,
+		entityEventSlice,
+		entityEvent,
+	},
+}
+
+var resourceEntitiesSlice = &sliceOfPtrs{
+	structName: "ResourceEntitiesSlice",
+	element:    resourceEntities,
+}
+
+var resourceEntities = &messageValueStruct{
+	structName:     "ResourceEntities",
+	description:    "// ResourceEntities is a collection of entity events from a Resource.",
+	originFullName: "otlpentities.ResourceEntities",
+	fields: []baseField{
+		resourceField,
+		schemaURLField,
+		&sliceField{
+			fieldName:   "ScopeEntities",
+			returnSlice: scopeEntitiesSlice,
+		},
+	},
+}
+
+var scopeEntitiesSlice = &sliceOfPtrs{
+	structName: "ScopeEntitiesSlice",
+	element:    scopeEntities,
+}
+
+var scopeEntities = &messageValueStruct{
+	structName:     "ScopeEntities",
+	description:    "// ScopeEntities is a collection of entity events from a LibraryInstrumentation.",
+	originFullName: "otlpentities.ScopeEntities",
+	fields: []baseField{
+		scopeField,
+		schemaURLField,
+		&sliceField{
+			fieldName:   "EntityEvents",
+			returnSlice: entityEventSlice,
+		},
+	},
+}
+
+var entityEventSlice = &sliceOfPtrs{
+	structName: "EntityEventSlice",
+	element:    entityEvent,
+}
+
+var entityEvent = &messageValueStruct{
+	structName:     "EntityEvent",
+	description:    "// EntityEvent is an event reporting an entity being created, updated or deleted.",
+	originFullName: "otlpentities.EntityEvent",
+	fields: []baseField{
+		timeField,
+		&primitiveField{
+			fieldName:  "EntityType",
+			returnType: "string",
+			defaultVal: `""`,
+			testVal:    `"k8s.pod"`,
+		},
+		&sliceField{
+			fieldName:       "ID",
+			originFieldName: "Id",
+			returnSlice:     mapStruct,
+		},
+		&primitiveTypedField{
+			fieldName: "Type",
+			returnType: &primitiveType{
+				structName: "EventType",
+				rawType:    "otlpentities.EntityEventType",
+				defaultVal: `otlpentities.EntityEventType(0)`,
+				testVal:    `otlpentities.EntityEventType(1)`,
+			},
+		},
+		attributes,
+	},
+}
@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pdatagen/internal"
+package internal // import "go.opentelemetry.io/collector/cmd/pdatagen/internal"
 
 import (
 	"bytes"
@@ -54,6 +54,18 @@ func (ms {{ .structName }}) At(i int) {{ .itemType }} {
 	return (*ms.getOrig())[i]
 }
 
+// All returns an iterator over index-value pairs in the slice, see iter.Seq2.
+// Equivalent of range {{ .lowerStructName }}.
+func (ms {{ .structName }}) All() func(yield func(int, {{ .itemType }}) bool) {
+	return func(yield func(int, {{ .itemType }}) bool) {
+		for i, v := range *ms.getOrig() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
 // SetAt sets {{ .itemType }} item at particular index.
 // Equivalent of {{ .lowerStructName }}[i] = val
 func (ms {{ .structName }}) SetAt(i int, val {{ .itemType }}) {
@@ -158,6 +170,25 @@ func Test{{ .structName }}ReadOnly(t *testing.T) {
 	assert.Panics(t, func() { ms2.MoveTo(ms) })
 }
 
+func Test{{ .structName }}All(t *testing.T) {
+	ms := New{{ .structName }}()
+	ms.FromRaw([]{{ .itemType }}{ {{ .testOrigVal }} })
+	var got []{{ .itemType }}
+	ms.All()(func(i int, v {{ .itemType }}) bool {
+		assert.Equal(t, len(got), i)
+		got = append(got, v)
+		return true
+	})
+	assert.Equal(t, ms.AsRaw(), got)
+
+	got = nil
+	ms.All()(func(_ int, v {{ .itemType }}) bool {
+		got = append(got, v)
+		return false
+	})
+	assert.Equal(t, []{{ .itemType }}{ {{ index .testInterfaceOrigVal 0 }} }, got)
+}
+
 func Test{{ .structName }}Append(t *testing.T) {
 	ms := New{{ .structName }}()
 	ms.FromRaw([]{{ .itemType }}{ {{ .testOrigVal }} })
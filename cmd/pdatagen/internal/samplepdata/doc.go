@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run ../.. spec.yaml
+
+// Package samplepdata is a package generated by pdatagen from spec.yaml, used to test pdatagen.
+package samplepdata // import "go.opentelemetry.io/collector/cmd/pdatagen/internal/samplepdata"
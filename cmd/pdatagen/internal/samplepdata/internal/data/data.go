@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package data defines the origin structs of the samplepdata package, as they would
+// be generated from protobuf messages.
+package data // import "go.opentelemetry.io/collector/cmd/pdatagen/internal/samplepdata/internal/data"
+
+type Sample struct {
+	Name    string
+	Count   uint64
+	Kind    int32
+	Enabled bool
+	Details Details
+	Labels  []*Label
+	Events  []Event
+}
+
+type Details struct {
+	Description string
+}
+
+type Label struct {
+	Key   string
+	Value string
+}
+
+type Event struct {
+	Name         string
+	TimeUnixNano uint64
+}
@@ -0,0 +1,14 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplepdata // import "go.opentelemetry.io/collector/cmd/pdatagen/internal/samplepdata"
+
+// SampleKind is the kind of a Sample.
+type SampleKind int32
+
+const (
+	// SampleKindUnspecified is the default SampleKind.
+	SampleKindUnspecified SampleKind = iota
+	// SampleKindTest is the kind of the samples used by the tests.
+	SampleKindTest
+)
@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/cmd/pdatagen/internal"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const externalHeader = `// Code generated by "pdatagen". DO NOT EDIT.`
+
+const stateTemplate = `package internal
+
+// State defines the ownership state of the data: the data shared with other consumers
+// must not be modified.
+type State int32
+
+const (
+	// StateMutable indicates that the data is exclusive to the current consumer.
+	StateMutable State = iota
+
+	// StateReadOnly indicates that the data is shared with other consumers.
+	StateReadOnly
+)
+
+// AssertMutable panics if the state is not StateMutable.
+func (state *State) AssertMutable() {
+	if *state != StateMutable {
+		panic("invalid access to shared data")
+	}
+}
+`
+
+// Spec describes a package of typed wrappers to generate, following the API conventions of pdata,
+// around the origin structs defined outside of pdata, typically generated from protobuf messages.
+type Spec struct {
+	// PackageName is the name of the generated package.
+	PackageName string `mapstructure:"package"`
+	// ImportPath is the import path of the generated package.
+	ImportPath string `mapstructure:"import_path"`
+	// Path is the directory of the generated package, relative to the spec file.
+	Path string `mapstructure:"path"`
+	// LicenseHeader is written at the top of the generated files.
+	LicenseHeader string `mapstructure:"license_header"`
+	// Imports are the imports used by the generated files, such as the package of the origin structs.
+	Imports []string `mapstructure:"imports"`
+	// Structs are the structs and the slices to generate.
+	Structs []StructSpec `mapstructure:"structs"`
+}
+
+// StructSpec describes a generated struct or slice.
+type StructSpec struct {
+	// Name is the name of the generated type.
+	Name string `mapstructure:"name"`
+	// Kind is "message" for the wrapper of an origin struct, "slice_of_ptrs" or "slice_of_values"
+	// for the wrapper of a slice of pointers to origin structs or of origin structs.
+	Kind string `mapstructure:"kind"`
+	// Description is the doc comment of a message, without the comment markers.
+	Description string `mapstructure:"description"`
+	// Origin is the qualified name of the origin struct of a message.
+	Origin string `mapstructure:"origin"`
+	// Element is the name of the message held by a slice.
+	Element string `mapstructure:"element"`
+	// Fields are the fields of a message.
+	Fields []FieldSpec `mapstructure:"fields"`
+}
+
+// FieldSpec describes a field of a generated message.
+type FieldSpec struct {
+	// Name is the name of the accessors of the field.
+	Name string `mapstructure:"name"`
+	// OriginName is the name of the field of the origin struct, if different from Name.
+	OriginName string `mapstructure:"origin_name"`
+	// Kind is "primitive" for a field of a Go basic type, "typed" for a field of a type defined
+	// on a basic type, "message" for a message, and "slice" for a slice.
+	Kind string `mapstructure:"kind"`
+	// Type is the Go type of a "primitive" or "typed" field, or the name of the message or
+	// slice of a "message" or "slice" field.
+	Type string `mapstructure:"type"`
+	// RawType is the type of the origin field of a "typed" field.
+	RawType string `mapstructure:"raw_type"`
+	// DefaultValue is the zero value of a "primitive" or "typed" field, as a Go expression of
+	// the type of the origin field.
+	DefaultValue string `mapstructure:"default_value"`
+	// TestValue is a non-zero value of a "primitive" or "typed" field used by the generated
+	// tests, as a Go expression of the type of the origin field.
+	TestValue string `mapstructure:"test_value"`
+}
+
+// Validate checks that the spec can be generated.
+func (s *Spec) Validate() error {
+	var errs error
+	if !token.IsIdentifier(s.PackageName) || usedByOtherDataTypes(s.PackageName) {
+		errs = errors.Join(errs, fmt.Errorf("invalid package name %q", s.PackageName))
+	}
+	if s.ImportPath == "" {
+		errs = errors.Join(errs, errors.New("missing import_path"))
+	}
+	if len(s.Structs) == 0 {
+		errs = errors.Join(errs, errors.New("no structs to generate"))
+	}
+	names := make(map[string]string, len(s.Structs))
+	for _, st := range s.Structs {
+		if !token.IsExported(st.Name) {
+			errs = errors.Join(errs, fmt.Errorf("invalid struct name %q", st.Name))
+		}
+		if _, ok := names[st.Name]; ok {
+			errs = errors.Join(errs, fmt.Errorf("duplicate struct %q", st.Name))
+		}
+		names[st.Name] = st.Kind
+	}
+	for _, st := range s.Structs {
+		switch st.Kind {
+		case "message":
+			if st.Origin == "" {
+				errs = errors.Join(errs, fmt.Errorf("struct %q: missing origin", st.Name))
+			}
+			for _, f := range st.Fields {
+				if err := f.validate(names); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("struct %q: field %q: %w", st.Name, f.Name, err))
+				}
+			}
+		case "slice_of_ptrs", "slice_of_values":
+			if names[st.Element] != "message" {
+				errs = errors.Join(errs, fmt.Errorf("struct %q: element %q is not a message", st.Name, st.Element))
+			}
+		default:
+			errs = errors.Join(errs, fmt.Errorf("struct %q: invalid kind %q", st.Name, st.Kind))
+		}
+	}
+	return errs
+}
+
+func (f *FieldSpec) validate(names map[string]string) error {
+	if !token.IsExported(f.Name) {
+		return errors.New("invalid field name")
+	}
+	switch f.Kind {
+	case "primitive":
+		if f.Type == "" {
+			return errors.New("missing type")
+		}
+	case "typed":
+		if f.Type == "" || f.RawType == "" {
+			return errors.New("missing type or raw_type")
+		}
+	case "message":
+		if names[f.Type] != "message" {
+			return fmt.Errorf("%q is not a message", f.Type)
+		}
+		if f.OriginName != "" {
+			return errors.New("origin_name is not supported by the message fields")
+		}
+	case "slice":
+		if kind := names[f.Type]; kind != "slice_of_ptrs" && kind != "slice_of_values" {
+			return fmt.Errorf("%q is not a slice", f.Type)
+		}
+	default:
+		return fmt.Errorf("invalid kind %q", f.Kind)
+	}
+	return nil
+}
+
+// Package converts the spec to the Package to generate. It must be valid.
+func (s *Spec) Package() *Package {
+	messages := make(map[string]*messageValueStruct)
+	slices := make(map[string]baseSlice)
+	sliceStructs := make(map[string]baseStruct)
+	for _, st := range s.Structs {
+		if st.Kind == "message" {
+			messages[st.Name] = &messageValueStruct{
+				structName:     st.Name,
+				packageName:    s.PackageName,
+				description:    comment(st.Name, st.Description),
+				originFullName: st.Origin,
+			}
+		}
+	}
+	for _, st := range s.Structs {
+		switch st.Kind {
+		case "slice_of_ptrs":
+			sl := &sliceOfPtrs{structName: st.Name, packageName: s.PackageName, element: messages[st.Element]}
+			slices[st.Name], sliceStructs[st.Name] = sl, sl
+		case "slice_of_values":
+			sl := &sliceOfValues{structName: st.Name, packageName: s.PackageName, element: messages[st.Element]}
+			slices[st.Name], sliceStructs[st.Name] = sl, sl
+		}
+	}
+
+	structs := make([]baseStruct, 0, len(s.Structs))
+	for _, st := range s.Structs {
+		if st.Kind != "message" {
+			structs = append(structs, sliceStructs[st.Name])
+			continue
+		}
+		ms := messages[st.Name]
+		for _, f := range st.Fields {
+			ms.fields = append(ms.fields, f.field(s.PackageName, messages, slices))
+		}
+		structs = append(structs, ms)
+	}
+
+	internalImport := strconv.Quote(path.Join(s.ImportPath, "internal"))
+	imports := []string{`"sort"`, ``, internalImport}
+	testImports := []string{`"testing"`, `"unsafe"`, ``, `"github.com/stretchr/testify/assert"`, ``, internalImport}
+	for _, imp := range s.Imports {
+		imp = importSpec(imp)
+		imports = append(imports, imp)
+		testImports = append(testImports, imp)
+	}
+
+	header := externalHeader
+	if s.LicenseHeader != "" {
+		header = comment("", s.LicenseHeader) + newLine + newLine + externalHeader
+	}
+	return &Package{
+		external:    true,
+		header:      header,
+		name:        s.PackageName,
+		path:        s.Path,
+		imports:     imports,
+		testImports: testImports,
+		structs:     structs,
+	}
+}
+
+func (f *FieldSpec) field(packageName string, messages map[string]*messageValueStruct, slices map[string]baseSlice) baseField {
+	switch f.Kind {
+	case "message":
+		return &messageValueField{fieldName: f.Name, returnMessage: messages[f.Type]}
+	case "slice":
+		return &sliceField{fieldName: f.Name, originFieldName: f.OriginName, returnSlice: slices[f.Type]}
+	case "typed":
+		pkg, name := packageName, f.Type
+		if i := strings.LastIndex(f.Type, "."); i >= 0 {
+			pkg, name = f.Type[:i], f.Type[i+1:]
+		}
+		return &primitiveTypedField{
+			fieldName:       f.Name,
+			originFieldName: f.OriginName,
+			returnType: &primitiveType{
+				structName:  name,
+				packageName: pkg,
+				rawType:     f.RawType,
+				defaultVal:  valueOrDefault(f.DefaultValue, f.RawType+"(0)"),
+				testVal:     valueOrDefault(f.TestValue, f.RawType+"(1)"),
+			},
+		}
+	default:
+		return &primitiveField{
+			fieldName:       f.Name,
+			originFieldName: f.OriginName,
+			returnType:      f.Type,
+			defaultVal:      valueOrDefault(f.DefaultValue, defaultValue(f.Type)),
+			testVal:         valueOrDefault(f.TestValue, testValue(f.Type, f.Name)),
+		}
+	}
+}
+
+func valueOrDefault(v, def string) string {
+	if v != "" {
+		return v
+	}
+	return def
+}
+
+// defaultValue returns the zero value of the Go basic type.
+func defaultValue(typ string) string {
+	switch typ {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	default:
+		return typ + "(0)"
+	}
+}
+
+// testValue returns a non-zero value of the Go basic type.
+func testValue(typ, name string) string {
+	switch typ {
+	case "string":
+		return strconv.Quote("test_" + strings.ToLower(name))
+	case "bool":
+		return "true"
+	default:
+		return typ + "(17)"
+	}
+}
+
+// importSpec quotes the path of the import, optionally preceded by its name.
+func importSpec(imp string) string {
+	name, p, ok := strings.Cut(strings.TrimSpace(imp), " ")
+	if !ok {
+		p, name = name, ""
+	}
+	p = strings.Trim(strings.TrimSpace(p), `"`)
+	if name == "" {
+		return strconv.Quote(p)
+	}
+	return name + " " + strconv.Quote(p)
+}
+
+// comment prefixes the lines of the text with "// ", and the first one with the name.
+func comment(name, text string) string {
+	text = strings.TrimSpace(text)
+	if name != "" {
+		if text == "" {
+			text = name + " is a generated wrapper."
+		} else if !strings.HasPrefix(text, name+" ") {
+			text = name + " " + text
+		}
+	}
+	lines := strings.Split(text, newLine)
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("// "+line, " ")
+	}
+	return strings.Join(lines, newLine)
+}
+
+// GenerateStateFile generates the internal package holding the ownership state of the data
+// of an external package, in the internal directory of the package relative to the root directory.
+func (p *Package) GenerateStateFile(root string) error {
+	dir := filepath.Join(root, p.path, "internal")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	var sb bytes.Buffer
+	sb.WriteString(p.header)
+	sb.WriteString(newLine + newLine)
+	sb.WriteString(stateTemplate)
+	return p.writeFile(filepath.Join(dir, "generated_state.go"), sb.Bytes())
+}
+
+// formatSource removes the unused imports of the Go source, and formats it.
+func formatSource(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if used[importName(imp)] {
+				specs = append(specs, imp)
+			}
+		}
+		gen.Specs = specs
+	}
+	file.Imports = nil
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	// Format again to remove the blank lines left by the removed imports.
+	return format.Source(buf.Bytes())
+}
+
+// importName returns the name under which the import is referenced, assuming that the name
+// of the imported package is the last element of its path when the import has no name.
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+	p, _ := strconv.Unquote(imp.Path.Value)
+	return path.Base(p)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validSpec() Spec {
+	return Spec{
+		PackageName: "psample",
+		ImportPath:  "example.com/psample",
+		Structs: []StructSpec{
+			{
+				Name:   "Sample",
+				Kind:   "message",
+				Origin: "data.Sample",
+				Fields: []FieldSpec{
+					{Name: "Name", Kind: "primitive", Type: "string"},
+					{Name: "Details", Kind: "message", Type: "Details"},
+					{Name: "Labels", Kind: "slice", Type: "LabelSlice"},
+				},
+			},
+			{Name: "Details", Kind: "message", Origin: "data.Details"},
+			{Name: "Label", Kind: "message", Origin: "data.Label"},
+			{Name: "LabelSlice", Kind: "slice_of_values", Element: "Label"},
+		},
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Spec)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*Spec) {},
+		},
+		{
+			name:    "invalid package",
+			modify:  func(s *Spec) { s.PackageName = "p-sample" },
+			wantErr: `invalid package name "p-sample"`,
+		},
+		{
+			name:    "pcommon package",
+			modify:  func(s *Spec) { s.PackageName = "pcommon" },
+			wantErr: `invalid package name "pcommon"`,
+		},
+		{
+			name:    "missing import path",
+			modify:  func(s *Spec) { s.ImportPath = "" },
+			wantErr: "missing import_path",
+		},
+		{
+			name:    "no structs",
+			modify:  func(s *Spec) { s.Structs = nil },
+			wantErr: "no structs to generate",
+		},
+		{
+			name:    "duplicate struct",
+			modify:  func(s *Spec) { s.Structs = append(s.Structs, s.Structs[1]) },
+			wantErr: `duplicate struct "Details"`,
+		},
+		{
+			name:    "invalid kind",
+			modify:  func(s *Spec) { s.Structs[1].Kind = "map" },
+			wantErr: `struct "Details": invalid kind "map"`,
+		},
+		{
+			name:    "missing origin",
+			modify:  func(s *Spec) { s.Structs[1].Origin = "" },
+			wantErr: `struct "Details": missing origin`,
+		},
+		{
+			name:    "invalid element",
+			modify:  func(s *Spec) { s.Structs[3].Element = "LabelSlice" },
+			wantErr: `struct "LabelSlice": element "LabelSlice" is not a message`,
+		},
+		{
+			name:    "invalid field kind",
+			modify:  func(s *Spec) { s.Structs[0].Fields[0].Kind = "map" },
+			wantErr: `struct "Sample": field "Name": invalid kind "map"`,
+		},
+		{
+			name:    "missing raw type",
+			modify:  func(s *Spec) { s.Structs[0].Fields[0] = FieldSpec{Name: "Kind", Kind: "typed", Type: "Kind"} },
+			wantErr: `struct "Sample": field "Kind": missing type or raw_type`,
+		},
+		{
+			name:    "invalid message field",
+			modify:  func(s *Spec) { s.Structs[0].Fields[1].Type = "LabelSlice" },
+			wantErr: `struct "Sample": field "Details": "LabelSlice" is not a message`,
+		},
+		{
+			name:    "invalid slice field",
+			modify:  func(s *Spec) { s.Structs[0].Fields[2].Type = "Label" },
+			wantErr: `struct "Sample": field "Labels": "Label" is not a slice`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := validSpec()
+			tt.modify(&s)
+			err := s.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
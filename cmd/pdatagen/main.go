@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/cmd/pdatagen/internal"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+)
+
+func main() {
+	pdataDir := flag.String("pdata-dir", "", "regenerate the pdata module in the given directory")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s spec.yaml\n       %s --pdata-dir <dir>\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+	switch {
+	case *pdataDir != "":
+		err = generatePdata(*pdataDir)
+	case flag.NArg() == 1:
+		err = run(flag.Arg(0))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generatePdata regenerates the packages of the pdata module.
+func generatePdata(root string) error {
+	for _, fp := range internal.AllPackages {
+		if err := fp.GenerateFiles(root); err != nil {
+			return err
+		}
+		if err := fp.GenerateTestFiles(root); err != nil {
+			return err
+		}
+		if err := fp.GenerateInternalFiles(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run generates the package described by the spec file.
+func run(specPath string) error {
+	specPath, err := filepath.Abs(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for %v: %w", specPath, err)
+	}
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return fmt.Errorf("failed loading %v: %w", specPath, err)
+	}
+
+	root := filepath.Dir(specPath)
+	codeDir := filepath.Join(root, spec.Path)
+	if err = os.MkdirAll(codeDir, 0700); err != nil {
+		return fmt.Errorf("unable to create output directory %q: %w", codeDir, err)
+	}
+	p := spec.Package()
+	if err = p.GenerateFiles(root); err != nil {
+		return err
+	}
+	if err = p.GenerateTestFiles(root); err != nil {
+		return err
+	}
+	return p.GenerateStateFile(root)
+}
+
+func loadSpec(specPath string) (*internal.Spec, error) {
+	retrieved, err := fileprovider.NewFactory().Create(confmaptest.NewNopProviderSettings()).Retrieve(context.Background(), "file:"+specPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := retrieved.AsConf()
+	if err != nil {
+		return nil, err
+	}
+	spec := &internal.Spec{}
+	if err = conf.Unmarshal(spec); err != nil {
+		return nil, err
+	}
+	if err = spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSample(t *testing.T) {
+	sampleDir := filepath.Join("internal", "samplepdata")
+	spec, err := os.ReadFile(filepath.Join(sampleDir, "spec.yaml"))
+	require.NoError(t, err)
+	tmpdir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpdir, "spec.yaml"), spec, 0600))
+
+	require.NoError(t, run(filepath.Join(tmpdir, "spec.yaml")))
+
+	// The generated files must match the ones of the sample package.
+	generated, err := filepath.Glob(filepath.Join(tmpdir, "generated_*.go"))
+	require.NoError(t, err)
+	require.NotEmpty(t, generated)
+	generated = append(generated, filepath.Join(tmpdir, "internal", "generated_state.go"))
+	for _, file := range generated {
+		rel, err := filepath.Rel(tmpdir, file)
+		require.NoError(t, err)
+		got, err := os.ReadFile(file)
+		require.NoError(t, err)
+		want, err := os.ReadFile(filepath.Join(sampleDir, rel))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), rel)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	require.Error(t, run(filepath.Join("testdata", "missing.yaml")))
+	require.ErrorContains(t, run(filepath.Join("testdata", "invalid.yaml")), `"Label" is not a slice`)
+}
@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package componententities holds the data type of the entity events.
+package componententities // import "go.opentelemetry.io/collector/component/componententities"
+
+import "go.opentelemetry.io/collector/component"
+
+var (
+	// DataTypeEntities is the data type tag for entity events.
+	DataTypeEntities = component.MustNewType("entities")
+)
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentstatus // import "go.opentelemetry.io/collector/component/componentstatus"
+
+import (
+	"sync"
+)
+
+// AggregateStatus returns the status summarizing the given component statuses:
+//   - StatusNone if there is no status.
+//   - StatusFatalError, then StatusPermanentError, if any component is in this status.
+//   - StatusStopped if all the components are stopped, StatusStopping if only some of them are,
+//     or if any component is stopping.
+//   - StatusRecoverableError if any component is in this status.
+//   - StatusStarting if any component did not report being started yet.
+//   - StatusOK otherwise.
+func AggregateStatus(statuses ...Status) Status {
+	if len(statuses) == 0 {
+		return StatusNone
+	}
+	counts := make(map[Status]int, len(statuses))
+	for _, s := range statuses {
+		counts[s]++
+	}
+	switch {
+	case counts[StatusFatalError] > 0:
+		return StatusFatalError
+	case counts[StatusPermanentError] > 0:
+		return StatusPermanentError
+	case counts[StatusStopped] == len(statuses):
+		return StatusStopped
+	case counts[StatusStopping] > 0 || counts[StatusStopped] > 0:
+		return StatusStopping
+	case counts[StatusRecoverableError] > 0:
+		return StatusRecoverableError
+	case counts[StatusStarting] > 0 || counts[StatusNone] > 0:
+		return StatusStarting
+	}
+	return StatusOK
+}
+
+// Aggregator is a Watcher keeping the latest Event reported by every component instance,
+// so the overall status of the collector can be computed.
+//
+// It is safe to call the methods of an Aggregator concurrently.
+type Aggregator struct {
+	mu     sync.RWMutex
+	events map[InstanceID]*Event
+}
+
+var _ Watcher = (*Aggregator)(nil)
+
+// NewAggregator returns a new empty Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{events: make(map[InstanceID]*Event)}
+}
+
+// ComponentStatusChanged records the latest event of the source component instance.
+func (a *Aggregator) ComponentStatusChanged(source *InstanceID, event *Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events[*source] = event
+}
+
+// Status returns the aggregated status of all the component instances, see AggregateStatus.
+func (a *Aggregator) Status() Status {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	statuses := make([]Status, 0, len(a.events))
+	for _, ev := range a.events {
+		statuses = append(statuses, ev.Status())
+	}
+	return AggregateStatus(statuses...)
+}
+
+// Events calls f with the latest event of every component instance, in no particular order.
+func (a *Aggregator) Events(f func(*InstanceID, *Event)) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for id, ev := range a.events {
+		f(&id, ev)
+	}
+}
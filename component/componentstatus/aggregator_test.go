@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentstatus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestAggregateStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []Status
+		expected Status
+	}{
+		{name: "empty", expected: StatusNone},
+		{name: "all ok", statuses: []Status{StatusOK, StatusOK}, expected: StatusOK},
+		{name: "starting", statuses: []Status{StatusOK, StatusStarting}, expected: StatusStarting},
+		{name: "recoverable", statuses: []Status{StatusStarting, StatusRecoverableError, StatusOK}, expected: StatusRecoverableError},
+		{name: "permanent", statuses: []Status{StatusRecoverableError, StatusPermanentError, StatusStopping}, expected: StatusPermanentError},
+		{name: "fatal", statuses: []Status{StatusPermanentError, StatusFatalError}, expected: StatusFatalError},
+		{name: "stopping", statuses: []Status{StatusOK, StatusStopping}, expected: StatusStopping},
+		{name: "partially stopped", statuses: []Status{StatusOK, StatusStopped}, expected: StatusStopping},
+		{name: "stopped", statuses: []Status{StatusStopped, StatusStopped}, expected: StatusStopped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AggregateStatus(tt.statuses...))
+		})
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	agg := NewAggregator()
+	assert.Equal(t, StatusNone, agg.Status())
+
+	receiver := NewInstanceID(component.MustNewID("receiver"), component.KindReceiver, component.MustNewID("traces"))
+	exporter := NewInstanceID(component.MustNewID("exporter"), component.KindExporter, component.MustNewID("traces"))
+
+	agg.ComponentStatusChanged(receiver, NewEvent(StatusStarting))
+	agg.ComponentStatusChanged(exporter, NewEvent(StatusStarting))
+	assert.Equal(t, StatusStarting, agg.Status())
+
+	agg.ComponentStatusChanged(receiver, NewEvent(StatusOK))
+	agg.ComponentStatusChanged(exporter, NewEvent(StatusOK))
+	assert.Equal(t, StatusOK, agg.Status())
+
+	// An equal instance ID created separately identifies the same component.
+	agg.ComponentStatusChanged(
+		NewInstanceID(component.MustNewID("exporter"), component.KindExporter, component.MustNewID("traces")),
+		NewRecoverableErrorEvent(errors.New("connection refused")))
+	assert.Equal(t, StatusRecoverableError, agg.Status())
+
+	events := map[component.ID]Status{}
+	agg.Events(func(id *InstanceID, ev *Event) {
+		events[id.ComponentID()] = ev.Status()
+	})
+	assert.Equal(t, map[component.ID]Status{
+		component.MustNewID("receiver"): StatusOK,
+		component.MustNewID("exporter"): StatusRecoverableError,
+	}, events)
+}
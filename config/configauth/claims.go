@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configauth // import "go.opentelemetry.io/collector/config/configauth"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// ContextWithClaims returns a context whose client.Info holds the given claims, for server authenticators
+// to attach them to the context returned by Authenticate. The claims are exposed as the client.ClaimSubject,
+// client.ClaimScopes and client.ClaimTenant attributes of the authentication data, on top of the attributes of
+// the authentication data already in the context. The empty claims are not set. Processors and exporters read
+// them with client.Info.Claims.
+func ContextWithClaims(ctx context.Context, claims client.Claims) context.Context {
+	info := client.FromContext(ctx)
+	attrs := map[string]any{}
+	if claims.Subject != "" {
+		attrs[client.ClaimSubject] = claims.Subject
+	}
+	if len(claims.Scopes) > 0 {
+		scopes := make([]string, len(claims.Scopes))
+		copy(scopes, claims.Scopes)
+		attrs[client.ClaimScopes] = scopes
+	}
+	if claims.Tenant != "" {
+		attrs[client.ClaimTenant] = claims.Tenant
+	}
+	info.Auth = &claimsAuthData{parent: info.Auth, claims: attrs}
+	return client.NewContext(ctx, info)
+}
+
+// claimsAuthData is a client.AuthData adding the claims to the attributes of its parent.
+type claimsAuthData struct {
+	parent client.AuthData
+	claims map[string]any
+}
+
+func (a *claimsAuthData) GetAttribute(name string) any {
+	if v, ok := a.claims[name]; ok {
+		return v
+	}
+	if a.parent == nil {
+		return nil
+	}
+	return a.parent.GetAttribute(name)
+}
+
+func (a *claimsAuthData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a.claims))
+	for name := range a.claims {
+		names = append(names, name)
+	}
+	if a.parent == nil {
+		return names
+	}
+	for _, name := range a.parent.GetAttributeNames() {
+		if _, ok := a.claims[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+type rawAuthData struct{}
+
+func (rawAuthData) GetAttribute(name string) any {
+	switch name {
+	case "raw":
+		return "token"
+	case client.ClaimTenant:
+		return "overridden"
+	}
+	return nil
+}
+
+func (rawAuthData) GetAttributeNames() []string {
+	return []string{"raw", client.ClaimTenant}
+}
+
+func TestContextWithClaims(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{Auth: rawAuthData{}})
+	claims := client.Claims{Subject: "alice", Scopes: []string{"metrics:write"}, Tenant: "acme"}
+	ctx = ContextWithClaims(ctx, claims)
+	claims.Scopes[0] = "changed"
+
+	info := client.FromContext(ctx)
+	assert.Equal(t, client.Claims{Subject: "alice", Scopes: []string{"metrics:write"}, Tenant: "acme"}, info.Claims())
+	assert.Equal(t, "token", info.Auth.GetAttribute("raw"))
+	assert.ElementsMatch(t, []string{client.ClaimSubject, client.ClaimScopes, client.ClaimTenant, "raw"}, info.Auth.GetAttributeNames())
+
+	// The claims not set keep the attributes of the authentication data already in the context.
+	ctx = client.NewContext(context.Background(), client.Info{Auth: rawAuthData{}})
+	info = client.FromContext(ContextWithClaims(ctx, client.Claims{Subject: "bob"}))
+	assert.Equal(t, client.Claims{Subject: "bob", Tenant: "overridden"}, info.Claims())
+
+	info = client.FromContext(ContextWithClaims(context.Background(), client.Claims{Tenant: "acme"}))
+	assert.Equal(t, client.Claims{Tenant: "acme"}, info.Claims())
+	assert.Nil(t, info.Auth.GetAttribute("raw"))
+	assert.Equal(t, []string{client.ClaimTenant}, info.Auth.GetAttributeNames())
+}
@@ -19,12 +19,18 @@ var (
 	errAuthenticatorNotFound = errors.New("authenticator not found")
 	errNotClient             = errors.New("requested authenticator is not a client authenticator")
 	errNotServer             = errors.New("requested authenticator is not a server authenticator")
+	errChainNotClient        = errors.New("authenticators are only supported by servers")
 )
 
 // Authentication defines the auth settings for the receiver.
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID component.ID `mapstructure:"authenticator"`
+
+	// Authenticators specifies the names of other server authenticators, tried in order after
+	// AuthenticatorID if set. The first authenticator accepting the incoming data authenticates it,
+	// the data is rejected only if all of them fail. It is not supported by clients.
+	Authenticators []component.ID `mapstructure:"authenticators"`
 }
 
 // NewDefaultAuthentication returns a default authentication configuration.
@@ -34,21 +40,74 @@ func NewDefaultAuthentication() *Authentication {
 
 // GetServerAuthenticator attempts to select the appropriate auth.Server from the list of extensions,
 // based on the requested extension name. If an authenticator is not found, an error is returned.
+// If several authenticators are configured, the auth.Server returned chains them.
 func (a Authentication) GetServerAuthenticator(_ context.Context, extensions map[component.ID]component.Component) (auth.Server, error) {
-	if ext, found := extensions[a.AuthenticatorID]; found {
+	if len(a.Authenticators) == 0 {
+		return getServer(extensions, a.AuthenticatorID)
+	}
+	ids := a.Authenticators
+	if a.AuthenticatorID != (component.ID{}) {
+		ids = append([]component.ID{a.AuthenticatorID}, ids...)
+	}
+	chain := make(chainServer, 0, len(ids))
+	for _, id := range ids {
+		server, err := getServer(extensions, id)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, server)
+	}
+	if len(chain) == 1 {
+		return chain[0], nil
+	}
+	return chain, nil
+}
+
+func getServer(extensions map[component.ID]component.Component, id component.ID) (auth.Server, error) {
+	if ext, found := extensions[id]; found {
 		if server, ok := ext.(auth.Server); ok {
 			return server, nil
 		}
 		return nil, errNotServer
 	}
 
-	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
+	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", id, errAuthenticatorNotFound)
+}
+
+// chainServer is an auth.Server authenticating the incoming data with the first of its authenticators
+// accepting it. Its authenticators are started and shut down by the host like the other extensions.
+type chainServer []auth.Server
+
+func (chainServer) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (chainServer) Shutdown(context.Context) error {
+	return nil
+}
+
+func (c chainServer) Authenticate(ctx context.Context, sources map[string][]string) (context.Context, error) {
+	var errs error
+	for _, server := range c {
+		authCtx, err := server.Authenticate(ctx, sources)
+		if err == nil {
+			return authCtx, nil
+		}
+		if ctx.Err() != nil {
+			return ctx, ctx.Err()
+		}
+		errs = errors.Join(errs, err)
+	}
+	return ctx, errs
 }
 
 // GetClientAuthenticator attempts to select the appropriate auth.Client from the list of extensions,
 // based on the component id of the extension. If an authenticator is not found, an error is returned.
 // This should be only used by HTTP clients.
 func (a Authentication) GetClientAuthenticator(_ context.Context, extensions map[component.ID]component.Component) (auth.Client, error) {
+	if len(a.Authenticators) > 0 {
+		return nil, errChainNotClient
+	}
 	if ext, found := extensions[a.AuthenticatorID]; found {
 		if client, ok := ext.(auth.Client); ok {
 			return client, nil
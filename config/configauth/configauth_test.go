@@ -5,9 +5,11 @@ package configauth
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -73,6 +75,70 @@ func TestGetServerFails(t *testing.T) {
 	assert.Nil(t, authenticator)
 }
 
+type principalKey struct{}
+
+func newTestServer(principal string, err error) auth.Server {
+	return auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, sources map[string][]string) (context.Context, error) {
+		if err != nil {
+			return ctx, err
+		}
+		if _, ok := sources[principal]; !ok {
+			return ctx, errors.New(principal + " not found")
+		}
+		return context.WithValue(ctx, principalKey{}, principal), nil
+	}))
+}
+
+func TestGetServerChain(t *testing.T) {
+	mtlsID := component.MustNewID("mtls")
+	bearerID := component.MustNewID("bearer")
+	apiKeyID := component.MustNewID("apikey")
+	ext := map[component.ID]component.Component{
+		mtlsID:   newTestServer("mtls", nil),
+		bearerID: newTestServer("authorization", nil),
+		apiKeyID: newTestServer("x-api-key", nil),
+		mockID:   auth.NewClient(),
+	}
+	cfg := &Authentication{
+		AuthenticatorID: mtlsID,
+		Authenticators:  []component.ID{bearerID, apiKeyID},
+	}
+	server, err := cfg.GetServerAuthenticator(context.Background(), ext)
+	require.NoError(t, err)
+	require.NoError(t, server.Start(context.Background(), nil))
+	defer func() { assert.NoError(t, server.Shutdown(context.Background())) }()
+
+	// The first authenticator accepting the data wins.
+	ctx, err := server.Authenticate(context.Background(), map[string][]string{"x-api-key": {"key"}, "authorization": {"token"}})
+	require.NoError(t, err)
+	assert.Equal(t, "authorization", ctx.Value(principalKey{}))
+	ctx, err = server.Authenticate(context.Background(), map[string][]string{"x-api-key": {"key"}})
+	require.NoError(t, err)
+	assert.Equal(t, "x-api-key", ctx.Value(principalKey{}))
+
+	// The data is rejected if all the authenticators fail.
+	_, err = server.Authenticate(context.Background(), map[string][]string{})
+	assert.EqualError(t, err, "mtls not found\nauthorization not found\nx-api-key not found")
+
+	// A cancelled context stops the chain.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = server.Authenticate(cancelled, map[string][]string{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// A single authenticator is returned as is.
+	server, err = (&Authentication{Authenticators: []component.ID{bearerID}}).GetServerAuthenticator(context.Background(), ext)
+	require.NoError(t, err)
+	assert.Equal(t, ext[bearerID], server)
+
+	_, err = (&Authentication{Authenticators: []component.ID{bearerID, mockID}}).GetServerAuthenticator(context.Background(), ext)
+	assert.ErrorIs(t, err, errNotServer)
+	_, err = (&Authentication{Authenticators: []component.ID{component.MustNewID("does_not_exist")}}).GetServerAuthenticator(context.Background(), ext)
+	assert.ErrorIs(t, err, errAuthenticatorNotFound)
+	_, err = (&Authentication{Authenticators: []component.ID{mockID}}).GetClientAuthenticator(context.Background(), ext)
+	assert.ErrorIs(t, err, errChainNotClient)
+}
+
 func TestGetClient(t *testing.T) {
 	testCases := []struct {
 		desc          string
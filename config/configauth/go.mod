@@ -4,6 +4,7 @@ go 1.22.0
 
 require (
 	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/collector/client v1.13.0
 	go.opentelemetry.io/collector/component v0.107.0
 	go.opentelemetry.io/collector/extension v0.107.0
 	go.opentelemetry.io/collector/extension/auth v0.107.0
@@ -39,6 +40,8 @@ require (
 
 replace go.opentelemetry.io/collector/pdata => ../../pdata
 
+replace go.opentelemetry.io/collector/client => ../../client
+
 replace go.opentelemetry.io/collector/confmap => ../../confmap
 
 replace go.opentelemetry.io/collector/component => ../../component
@@ -21,10 +21,15 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -36,9 +41,19 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/middleware"
 )
 
-var errMetadataNotFound = errors.New("no request metadata found")
+var (
+	errMetadataNotFound   = errors.New("no request metadata found")
+	errAuthAndCredentials = errors.New("'auth' and 'credentials' cannot be both set")
+)
+
+// xdsScheme is the scheme of the targets resolved with the xDS protocol, e.g. "xds:///otelcol:4317".
+const xdsScheme = "xds"
+
+var errXDSResolverNotRegistered = errors.New(`no resolver registered for the "xds" scheme, ` +
+	`the google.golang.org/grpc/xds package must be imported by the collector to use xds targets`)
 
 // KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter.
 // Refer to the original data-structure for the meaning of each parameter:
@@ -104,6 +119,11 @@ type ClientConfig struct {
 
 	// Auth configuration for outgoing RPCs.
 	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// Credentials is the ID of the client authenticator extension supplying the credentials of the connection:
+	// its per-RPC credentials, and its transport credentials if it implements auth.ClientTransportCredentials,
+	// which replace the ones derived from the TLS settings. It cannot be set together with Auth.
+	Credentials *component.ID `mapstructure:"credentials"`
 }
 
 // NewDefaultClientConfig returns a new instance of ClientConfig with default values.
@@ -134,11 +154,19 @@ func NewDefaultKeepaliveServerConfig() *KeepaliveServerConfig {
 // The same default values as keepalive.ServerParameters are applicable and get applied by the server.
 // See https://godoc.org/google.golang.org/grpc/keepalive#ServerParameters for details.
 type KeepaliveServerParameters struct {
-	MaxConnectionIdle     time.Duration `mapstructure:"max_connection_idle"`
-	MaxConnectionAge      time.Duration `mapstructure:"max_connection_age"`
+	// MaxConnectionIdle is the duration after which an idle connection is closed by sending a GoAway.
+	MaxConnectionIdle time.Duration `mapstructure:"max_connection_idle"`
+	// MaxConnectionAge is the maximum duration a connection may exist before it is closed by sending a GoAway.
+	// Setting it makes long-lived clients reconnect periodically, which allows rebalancing them across
+	// the instances behind a load balancer.
+	MaxConnectionAge time.Duration `mapstructure:"max_connection_age"`
+	// MaxConnectionAgeGrace is the additional duration after MaxConnectionAge after which the connection
+	// is forcibly closed, giving in-flight RPCs time to complete. It requires MaxConnectionAge to be set.
 	MaxConnectionAgeGrace time.Duration `mapstructure:"max_connection_age_grace"`
-	Time                  time.Duration `mapstructure:"time"`
-	Timeout               time.Duration `mapstructure:"timeout"`
+	// Time is the duration after which the server pings an inactive client to check if the transport is still alive.
+	Time time.Duration `mapstructure:"time"`
+	// Timeout is the duration the server waits for a response to a keepalive ping before closing the connection.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 // NewDefaultKeepaliveServerParameters creates and returns a new instance of KeepaliveServerParameters with default settings.
@@ -146,12 +174,38 @@ func NewDefaultKeepaliveServerParameters() *KeepaliveServerParameters {
 	return &KeepaliveServerParameters{}
 }
 
+// Validate checks if the KeepaliveServerParameters configuration is valid.
+func (ksp *KeepaliveServerParameters) Validate() error {
+	var errs error
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"max_connection_idle", ksp.MaxConnectionIdle},
+		{"max_connection_age", ksp.MaxConnectionAge},
+		{"max_connection_age_grace", ksp.MaxConnectionAgeGrace},
+		{"time", ksp.Time},
+		{"timeout", ksp.Timeout},
+	} {
+		if d.value < 0 {
+			errs = errors.Join(errs, fmt.Errorf("%s must not be negative, got %v", d.name, d.value))
+		}
+	}
+	if ksp.MaxConnectionAgeGrace > 0 && ksp.MaxConnectionAge == 0 {
+		errs = errors.Join(errs, errors.New("max_connection_age_grace requires max_connection_age to be set"))
+	}
+	return errs
+}
+
 // KeepaliveEnforcementPolicy allow configuration of the keepalive.EnforcementPolicy.
 // The same default values as keepalive.EnforcementPolicy are applicable and get applied by the server.
 // See https://godoc.org/google.golang.org/grpc/keepalive#EnforcementPolicy for details.
 type KeepaliveEnforcementPolicy struct {
-	MinTime             time.Duration `mapstructure:"min_time"`
-	PermitWithoutStream bool          `mapstructure:"permit_without_stream"`
+	// MinTime is the minimum duration a client should wait before sending a keepalive ping.
+	// Clients pinging more often are disconnected.
+	MinTime time.Duration `mapstructure:"min_time"`
+	// PermitWithoutStream allows clients to send keepalive pings when there are no active streams.
+	PermitWithoutStream bool `mapstructure:"permit_without_stream"`
 }
 
 // NewDefaultKeepaliveEnforcementPolicy creates and returns a new instance of KeepaliveEnforcementPolicy with default settings.
@@ -159,6 +213,14 @@ func NewDefaultKeepaliveEnforcementPolicy() *KeepaliveEnforcementPolicy {
 	return &KeepaliveEnforcementPolicy{}
 }
 
+// Validate checks if the KeepaliveEnforcementPolicy configuration is valid.
+func (kep *KeepaliveEnforcementPolicy) Validate() error {
+	if kep.MinTime < 0 {
+		return fmt.Errorf("min_time must not be negative, got %v", kep.MinTime)
+	}
+	return nil
+}
+
 // ServerConfig defines common settings for a gRPC server configuration.
 type ServerConfig struct {
 	// Server net.Addr config. For transport only "tcp" and "unix" are valid options.
@@ -189,8 +251,21 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
+	// Middlewares is the list of the extensions providing the gRPC interceptors of this receiver.
+	// They are applied in order to the calls after their authentication, the first one
+	// intercepting the calls first.
+	Middlewares []component.ID `mapstructure:"middlewares"`
+
 	// Include propagates the incoming connection's metadata to downstream consumers.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
+
+	// HealthCheck registers the gRPC health checking service (grpc.health.v1.Health) on the server,
+	// reporting it as serving. The health checks are not authenticated, so load balancers can probe the server.
+	HealthCheck bool `mapstructure:"health_check"`
+
+	// Reflection registers the gRPC server reflection service on the server, used by tools like grpcurl
+	// to list and describe the services.
+	Reflection bool `mapstructure:"reflection"`
 }
 
 // NewDefaultServerConfig returns a new instance of ServerConfig with default values.
@@ -221,6 +296,12 @@ func (gcs *ClientConfig) isSchemeHTTPS() bool {
 	return strings.HasPrefix(gcs.Endpoint, "https://")
 }
 
+// IsSchemeXDS returns true if the endpoint is a target resolved with the xDS protocol, e.g. "xds:///otelcol:4317".
+// Such targets do not have to include a port, which is provided by the xDS control plane.
+func (gcs *ClientConfig) IsSchemeXDS() bool {
+	return strings.HasPrefix(gcs.Endpoint, xdsScheme+":")
+}
+
 // ToClientConn creates a client connection to the given target. By default, it's
 // a non-blocking dial (the function won't wait for connections to be
 // established, and connecting happens in the background). To make it a blocking
@@ -235,6 +316,12 @@ func (gcs *ClientConfig) ToClientConn(ctx context.Context, host component.Host,
 }
 
 func (gcs *ClientConfig) toDialOptions(ctx context.Context, host component.Host, settings component.TelemetrySettings) ([]grpc.DialOption, error) {
+	// The xds resolver, and the balancers it relies on, are registered by the google.golang.org/grpc/xds package,
+	// which reads its bootstrap configuration from the GRPC_XDS_BOOTSTRAP or GRPC_XDS_BOOTSTRAP_CONFIG environment variables.
+	if gcs.IsSchemeXDS() && resolver.Get(xdsScheme) == nil {
+		return nil, errXDSResolverNotRegistered
+	}
+
 	var opts []grpc.DialOption
 	if gcs.Compression.IsCompressed() {
 		cp, err := getGRPCCompressionName(gcs.Compression)
@@ -244,17 +331,32 @@ func (gcs *ClientConfig) toDialOptions(ctx context.Context, host component.Host,
 		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cp)))
 	}
 
-	tlsCfg, err := gcs.TLSSetting.LoadTLSConfig(ctx)
-	if err != nil {
-		return nil, err
+	var transportCred credentials.TransportCredentials
+	if gcs.Credentials != nil {
+		if gcs.Auth != nil {
+			return nil, errAuthAndCredentials
+		}
+		credOpts, tc, cerr := gcs.credentialsOptions(ctx, host)
+		if cerr != nil {
+			return nil, cerr
+		}
+		opts = append(opts, credOpts...)
+		transportCred = tc
 	}
-	cred := insecure.NewCredentials()
-	if tlsCfg != nil {
-		cred = credentials.NewTLS(tlsCfg)
-	} else if gcs.isSchemeHTTPS() {
-		cred = credentials.NewTLS(&tls.Config{})
+
+	if transportCred == nil {
+		tlsCfg, err := gcs.TLSSetting.LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		transportCred = insecure.NewCredentials()
+		if tlsCfg != nil {
+			transportCred = credentials.NewTLS(tlsCfg)
+		} else if gcs.isSchemeHTTPS() {
+			transportCred = credentials.NewTLS(&tls.Config{})
+		}
 	}
-	opts = append(opts, grpc.WithTransportCredentials(cred))
+	opts = append(opts, grpc.WithTransportCredentials(transportCred))
 
 	if gcs.ReadBufferSize > 0 {
 		opts = append(opts, grpc.WithReadBufferSize(gcs.ReadBufferSize))
@@ -285,7 +387,7 @@ func (gcs *ClientConfig) toDialOptions(ctx context.Context, host component.Host,
 
 		perRPCCredentials, perr := grpcAuthenticator.PerRPCCredentials()
 		if perr != nil {
-			return nil, err
+			return nil, perr
 		}
 		opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
 	}
@@ -316,6 +418,37 @@ func (gcs *ClientConfig) toDialOptions(ctx context.Context, host component.Host,
 	return opts, nil
 }
 
+// credentialsOptions returns the dial options of the per-RPC credentials supplied by the credentials extension,
+// and its transport credentials, nil if it does not supply any.
+func (gcs *ClientConfig) credentialsOptions(ctx context.Context, host component.Host) ([]grpc.DialOption, credentials.TransportCredentials, error) {
+	if host.GetExtensions() == nil {
+		return nil, nil, errors.New("no extensions configuration available")
+	}
+	authenticator, err := configauth.Authentication{AuthenticatorID: *gcs.Credentials}.GetClientAuthenticator(ctx, host.GetExtensions())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var opts []grpc.DialOption
+	perRPCCredentials, err := authenticator.PerRPCCredentials()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the per-RPC credentials of %q: %w", gcs.Credentials, err)
+	}
+	if perRPCCredentials != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
+	}
+
+	tc, ok := authenticator.(auth.ClientTransportCredentials)
+	if !ok {
+		return opts, nil, nil
+	}
+	transportCredentials, err := tc.TransportCredentials()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the transport credentials of %q: %w", gcs.Credentials, err)
+	}
+	return opts, transportCredentials, nil
+}
+
 func validateBalancerName(balancerName string) bool {
 	return balancer.Get(balancerName) != nil
 }
@@ -327,7 +460,14 @@ func (gss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		return nil, err
 	}
 	opts = append(opts, extraOpts...)
-	return grpc.NewServer(opts...), nil
+	srv := grpc.NewServer(opts...)
+	if gss.HealthCheck {
+		healthpb.RegisterHealthServer(srv, health.NewServer())
+	}
+	if gss.Reflection {
+		reflection.Register(srv)
+	}
+	return srv, nil
 }
 
 func (gss *ServerConfig) toServerOption(host component.Host, settings component.TelemetrySettings) ([]grpc.ServerOption, error) {
@@ -400,9 +540,15 @@ func (gss *ServerConfig) toServerOption(host component.Host, settings component.
 		}
 
 		uInterceptors = append(uInterceptors, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+			if gss.HealthCheck && isHealthCheckMethod(info.FullMethod) {
+				return handler(ctx, req)
+			}
 			return authUnaryServerInterceptor(ctx, req, info, handler, authenticator)
 		})
 		sInterceptors = append(sInterceptors, func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if gss.HealthCheck && isHealthCheckMethod(info.FullMethod) {
+				return handler(srv, ss)
+			}
 			return authStreamServerInterceptor(srv, ss, info, handler, authenticator)
 		})
 	}
@@ -420,11 +566,51 @@ func (gss *ServerConfig) toServerOption(host component.Host, settings component.
 	uInterceptors = append(uInterceptors, enhanceWithClientInformation(gss.IncludeMetadata))
 	sInterceptors = append(sInterceptors, enhanceStreamWithClientInformation(gss.IncludeMetadata))
 
+	var tapHandles []tap.ServerInHandle
+	for _, id := range gss.Middlewares {
+		server, err := middleware.GetGRPCServer(host.GetExtensions(), id)
+		if err != nil {
+			return nil, err
+		}
+
+		if interceptor := server.UnaryServerInterceptor(); interceptor != nil {
+			uInterceptors = append(uInterceptors, interceptor)
+		}
+		if interceptor := server.StreamServerInterceptor(); interceptor != nil {
+			sInterceptors = append(sInterceptors, interceptor)
+		}
+		if transport, ok := server.(middleware.GRPCServerTransport); ok {
+			if handle := transport.InTapHandle(); handle != nil {
+				tapHandles = append(tapHandles, handle)
+			}
+			if handler := transport.StatsHandler(); handler != nil {
+				opts = append(opts, grpc.StatsHandler(handler))
+			}
+		}
+	}
+	if len(tapHandles) > 0 {
+		// Only one tap handle can be installed on a server.
+		opts = append(opts, grpc.InTapHandle(chainTapHandles(tapHandles)))
+	}
+
 	opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler(otelOpts...)), grpc.ChainUnaryInterceptor(uInterceptors...), grpc.ChainStreamInterceptor(sInterceptors...))
 
 	return opts, nil
 }
 
+// chainTapHandles returns a tap handle calling the given handles in order, until one of them refuses the stream.
+func chainTapHandles(handles []tap.ServerInHandle) tap.ServerInHandle {
+	return func(ctx context.Context, info *tap.Info) (context.Context, error) {
+		for _, handle := range handles {
+			var err error
+			if ctx, err = handle(ctx, info); err != nil {
+				return nil, err
+			}
+		}
+		return ctx, nil
+	}
+}
+
 // getGRPCCompressionName returns compression name registered in grpc.
 func getGRPCCompressionName(compressionType configcompression.Type) (string, error) {
 	switch compressionType {
@@ -472,6 +658,11 @@ func contextWithClient(ctx context.Context, includeMetadata bool) context.Contex
 	return client.NewContext(ctx, cl)
 }
 
+// isHealthCheckMethod returns whether the method is one of the gRPC health checking service.
+func isHealthCheckMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
 func authUnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler, server auth.Server) (any, error) {
 	headers, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
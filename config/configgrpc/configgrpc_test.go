@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,9 +21,16 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -34,6 +42,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/extension/middleware"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/localhostgate"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -83,6 +92,57 @@ func TestNewDefaultKeepaliveServerConfig(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestKeepaliveServerParametersValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *KeepaliveServerParameters
+		errMsg string
+	}{
+		{
+			name:   "default",
+			params: NewDefaultKeepaliveServerParameters(),
+		},
+		{
+			name: "connection rotation",
+			params: &KeepaliveServerParameters{
+				MaxConnectionAge:      time.Minute,
+				MaxConnectionAgeGrace: 10 * time.Second,
+			},
+		},
+		{
+			name: "negative durations",
+			params: &KeepaliveServerParameters{
+				MaxConnectionIdle: -time.Second,
+				Timeout:           -time.Second,
+			},
+			errMsg: "max_connection_idle must not be negative, got -1s\ntimeout must not be negative, got -1s",
+		},
+		{
+			name: "grace without max age",
+			params: &KeepaliveServerParameters{
+				MaxConnectionAgeGrace: 10 * time.Second,
+			},
+			errMsg: "max_connection_age_grace requires max_connection_age to be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestKeepaliveEnforcementPolicyValidate(t *testing.T) {
+	assert.NoError(t, NewDefaultKeepaliveEnforcementPolicy().Validate())
+	assert.NoError(t, (&KeepaliveEnforcementPolicy{MinTime: time.Second, PermitWithoutStream: true}).Validate())
+	assert.EqualError(t, (&KeepaliveEnforcementPolicy{MinTime: -time.Second}).Validate(), "min_time must not be negative, got -1s")
+}
+
 func TestNewDefaultServerConfig(t *testing.T) {
 	expected := &ServerConfig{
 		Keepalive: NewDefaultKeepaliveServerConfig(),
@@ -301,6 +361,259 @@ func TestGrpcServerAuthSettings(t *testing.T) {
 	assert.NotNil(t, srv)
 }
 
+func TestGrpcServerMiddlewares(t *testing.T) {
+	var calls []string
+	newMiddleware := func(name string) middleware.Server {
+		return middleware.NewServer(middleware.WithUnaryServerInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}))
+	}
+	firstID := component.MustNewID("first")
+	secondID := component.MustNewID("second")
+	streamOnlyID := component.MustNewID("streamonly")
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: mockID,
+		},
+		Middlewares: []component.ID{firstID, streamOnlyID, secondID},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, _ map[string][]string) (context.Context, error) {
+				calls = append(calls, "auth")
+				return ctx, nil
+			})),
+			firstID:  newMiddleware("first"),
+			secondID: newMiddleware("second"),
+			streamOnlyID: middleware.NewServer(middleware.WithStreamServerInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, ss)
+			})),
+		},
+	}
+
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"auth", "first", "second"}, calls)
+}
+
+// transportMiddleware is a middleware acting on the transport of the gRPC calls.
+type transportMiddleware struct {
+	middleware.Server
+	tapHandle    tap.ServerInHandle
+	statsHandler stats.Handler
+}
+
+func (m *transportMiddleware) InTapHandle() tap.ServerInHandle {
+	return m.tapHandle
+}
+
+func (m *transportMiddleware) StatsHandler() stats.Handler {
+	return m.statsHandler
+}
+
+// payloadStatsHandler records the wire length of the received messages.
+type payloadStatsHandler struct {
+	mu      sync.Mutex
+	lengths []int
+}
+
+func (h *payloadStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *payloadStatsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InPayload); ok {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.lengths = append(h.lengths, in.WireLength)
+	}
+}
+
+func (h *payloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *payloadStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+func TestGrpcServerTransportMiddlewares(t *testing.T) {
+	var mu sync.Mutex
+	var taps []string
+	refuse := false
+	newTap := func(name string) tap.ServerInHandle {
+		return func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			taps = append(taps, name)
+			if refuse && name == "second" {
+				return nil, status.Error(codes.Unavailable, "refused")
+			}
+			return ctx, nil
+		}
+	}
+	firstID := component.MustNewID("first")
+	secondID := component.MustNewID("second")
+	statsHandler := &payloadStatsHandler{}
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		Middlewares: []component.ID{firstID, secondID},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			firstID:  &transportMiddleware{Server: middleware.NewServer(), tapHandle: newTap("first"), statsHandler: statsHandler},
+			secondID: &transportMiddleware{Server: middleware.NewServer(), tapHandle: newTap("second")},
+		},
+	}
+
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	req := ptraceotlp.NewExportRequest()
+	req.Traces().ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, req, grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	mu.Lock()
+	assert.Equal(t, []string{"first", "second"}, taps)
+	taps = nil
+	refuse = true
+	mu.Unlock()
+	statsHandler.mu.Lock()
+	assert.Len(t, statsHandler.lengths, 1)
+	assert.Positive(t, statsHandler.lengths[0])
+	statsHandler.mu.Unlock()
+
+	// The stream is refused with the status of the tap handle.
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	mu.Lock()
+	assert.Equal(t, []string{"first", "second"}, taps)
+	mu.Unlock()
+}
+
+func TestGrpcServerInvalidMiddlewares(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint: "0.0.0.0:1234",
+		},
+		Middlewares: []component.ID{mockID},
+	}
+
+	_, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: auth.NewServer(),
+		},
+	}
+	_, err = gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+}
+
+func TestGrpcServerHealthCheckAndReflection(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: mockID,
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, _ map[string][]string) (context.Context, error) {
+				return ctx, errors.New("not authenticated")
+			})),
+		},
+	}
+
+	srv, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Empty(t, srv.GetServiceInfo())
+
+	gss.HealthCheck = true
+	gss.Reflection = true
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err = gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	assert.Contains(t, srv.GetServiceInfo(), "grpc.health.v1.Health")
+	assert.Contains(t, srv.GetServiceInfo(), "grpc.reflection.v1.ServerReflection")
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+
+	// The health checks are not authenticated, unlike the other services.
+	resp, err := healthpb.NewHealthClient(grpcClientConn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
 func TestGRPCClientSettingsError(t *testing.T) {
 	tt, err := componenttest.SetupTelemetry(componentID)
 	require.NoError(t, err)
@@ -381,6 +694,43 @@ func TestGRPCClientSettingsError(t *testing.T) {
 			},
 			host: &mockHost{},
 		},
+		{
+			err: "'auth' and 'credentials' cannot be both set",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Auth:        &configauth.Authentication{AuthenticatorID: testAuthID},
+				Credentials: &testAuthID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{testAuthID: &authtest.MockClient{}}},
+		},
+		{
+			err: "failed to resolve authenticator \"doesntexist\": authenticator not found",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &doesntExistID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{}},
+		},
+		{
+			err: "failed to get the per-RPC credentials of \"testauth\": mock Error",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &testAuthID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{testAuthID: &authtest.MockClient{MustError: true}}},
+		},
+		{
+			err: "failed to get the transport credentials of \"testauth\": failed",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &testAuthID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{
+				testAuthID: auth.NewClient(auth.WithClientTransportCredentials(func() (credentials.TransportCredentials, error) {
+					return nil, errors.New("failed")
+				})),
+			}},
+		},
 		{
 			err: "unsupported compression type \"zlib\"",
 			settings: ClientConfig{
@@ -424,6 +774,62 @@ func TestGRPCClientSettingsError(t *testing.T) {
 	}
 }
 
+// tokenCredentials are per-RPC credentials sending a bearer token.
+type tokenCredentials string
+
+func (tc tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(tc)}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func TestGrpcClientCredentials(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	mock := &grpcTraceServer{}
+	ptraceotlp.RegisterGRPCServer(srv, mock)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		// The transport credentials of the extension replace the ones of the TLS settings, which would fail to load.
+		TLSSetting: configtls.ClientConfig{
+			Config: configtls.Config{CAFile: "/doesnt/exist"},
+		},
+		Credentials: &testAuthID,
+	}
+	host := &mockHost{ext: map[component.ID]component.Component{
+		testAuthID: &authtest.MockClient{
+			ResultPerRPCCredentials:    tokenCredentials("token"),
+			ResultTransportCredentials: insecure.NewCredentials(),
+		},
+	}}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	md, ok := metadata.FromIncomingContext(mock.recordedContext)
+	require.True(t, ok)
+	assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+}
+
 func TestUseSecure(t *testing.T) {
 	tt, err := componenttest.SetupTelemetry(componentID)
 	require.NoError(t, err)
@@ -757,6 +1163,41 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	srv.Stop()
 }
 
+func TestXDSClient(t *testing.T) {
+	gcs := &ClientConfig{
+		Endpoint: "xds:///otelcol-gateway:4317",
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	assert.True(t, gcs.IsSchemeXDS())
+	if resolver.Get(xdsScheme) == nil {
+		_, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+		require.ErrorIs(t, err, errXDSResolverNotRegistered)
+	}
+
+	// Stand-in for the xds resolver registered by google.golang.org/grpc/xds.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+	r := manual.NewBuilderWithScheme(xdsScheme)
+	r.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: ln.Addr().String()}}})
+	resolver.Register(r)
+
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, err)
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
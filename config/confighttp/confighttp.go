@@ -13,11 +13,13 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/rs/cors"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
+	"golang.org/x/net/http/httpproxy"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/publicsuffix"
 
@@ -29,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/middleware"
 )
 
 const headerContentEncoding = "Content-Encoding"
@@ -43,6 +46,15 @@ type ClientConfig struct {
 	// ProxyURL setting for the collector
 	ProxyURL string `mapstructure:"proxy_url"`
 
+	// ProxyUsername and ProxyPassword authenticate the requests to the proxy with the basic scheme.
+	// They override the user information of ProxyURL.
+	ProxyUsername string              `mapstructure:"proxy_username"`
+	ProxyPassword configopaque.String `mapstructure:"proxy_password"`
+
+	// NoProxy lists the hosts not reached through the proxy, with the syntax of the NO_PROXY
+	// environment variable, e.g. "*.internal.example.com" or "10.0.0.0/8".
+	NoProxy []string `mapstructure:"no_proxy"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.ClientConfig `mapstructure:"tls"`
 
@@ -105,6 +117,10 @@ type ClientConfig struct {
 	HTTP2PingTimeout time.Duration `mapstructure:"http2_ping_timeout"`
 	// Cookies configures the cookie management of the HTTP client.
 	Cookies *CookiesConfig `mapstructure:"cookies"`
+
+	// Retry configures the retries of the idempotent requests at the transport level, when the connection
+	// is reset or the server is unavailable. Disabled by default.
+	Retry *RetryConfig `mapstructure:"retry"`
 }
 
 // CookiesConfig defines the configuration of the HTTP client regarding cookies served by the server.
@@ -167,11 +183,13 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 
 	// Setting the Proxy URL
 	if hcs.ProxyURL != "" {
-		proxyURL, parseErr := url.ParseRequestURI(hcs.ProxyURL)
-		if parseErr != nil {
-			return nil, parseErr
+		proxy, proxyErr := hcs.proxy()
+		if proxyErr != nil {
+			return nil, proxyErr
 		}
-		transport.Proxy = http.ProxyURL(proxyURL)
+		transport.Proxy = proxy
+	} else if hcs.ProxyUsername != "" || len(hcs.NoProxy) > 0 {
+		return nil, errors.New("proxy_username and no_proxy require proxy_url to be set")
 	}
 
 	transport.DisableKeepAlives = hcs.DisableKeepAlives
@@ -223,6 +241,15 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 		}
 	}
 
+	// Every attempt goes through the compression, headers and auth round trippers,
+	// and the attempts are traced as a single request.
+	if hcs.Retry != nil && hcs.Retry.Enabled {
+		if err = hcs.Retry.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid retry configuration: %w", err)
+		}
+		clientTransport = newRetryRoundTripper(clientTransport, *hcs.Retry)
+	}
+
 	otelOpts := []otelhttp.Option{
 		otelhttp.WithTracerProvider(settings.TracerProvider),
 		otelhttp.WithPropagators(otel.GetTextMapPropagator()),
@@ -287,6 +314,11 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *AuthConfig `mapstructure:"auth"`
 
+	// Middlewares is the list of the extensions providing the HTTP middlewares of this receiver.
+	// They are applied in order to the requests after their authentication, the first one
+	// handling the requests first.
+	Middlewares []component.ID `mapstructure:"middlewares"`
+
 	// MaxRequestBodySize sets the maximum request body size in bytes. Default: 20MiB.
 	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 
@@ -297,6 +329,10 @@ type ServerConfig struct {
 	// Header values are opaque since they may be sensitive.
 	ResponseHeaders map[string]configopaque.String `mapstructure:"response_headers"`
 
+	// HideServerIdentity removes the response headers identifying the server software, Server and
+	// X-Powered-By, set by the handlers of the requests. The headers set in ResponseHeaders are kept.
+	HideServerIdentity bool `mapstructure:"hide_server_identity"`
+
 	// CompressionAlgorithms configures the list of compression algorithms the server can accept. Default: ["", "gzip", "zstd", "zlib", "snappy", "deflate"]
 	CompressionAlgorithms []string `mapstructure:"compression_algorithms"`
 
@@ -330,6 +366,13 @@ type ServerConfig struct {
 	// is zero, the value of ReadTimeout is used. If both are
 	// zero, there is no timeout.
 	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// ShutdownGracePeriod is the maximum amount of time to wait for the
+	// in-flight requests to complete when the server is shut down with
+	// ServerConfig.Shutdown, after which the remaining connections are closed.
+	// If it is zero, the in-flight requests are waited for until the context
+	// passed to Shutdown is done.
+	ShutdownGracePeriod time.Duration `mapstructure:"shutdown_grace_period"`
 }
 
 // NewDefaultServerConfig returns ServerConfig type object with default values.
@@ -355,6 +398,29 @@ type AuthConfig struct {
 	RequestParameters []string `mapstructure:"request_params"`
 }
 
+// proxy returns the function selecting the proxy of the requests.
+func (hcs *ClientConfig) proxy() (func(*http.Request) (*url.URL, error), error) {
+	proxyURL, err := url.ParseRequestURI(hcs.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	if hcs.ProxyUsername != "" {
+		proxyURL.User = url.UserPassword(hcs.ProxyUsername, string(hcs.ProxyPassword))
+	}
+	if len(hcs.NoProxy) == 0 {
+		return http.ProxyURL(proxyURL), nil
+	}
+	// Like with the NO_PROXY environment variable, the requests to localhost are never proxied.
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    strings.Join(hcs.NoProxy, ","),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
 // ToListener creates a net.Listener.
 func (hss *ServerConfig) ToListener(ctx context.Context) (net.Listener, error) {
 	listener, err := net.Listen("tcp", hss.Endpoint)
@@ -428,6 +494,18 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		handler = maxRequestBodySizeInterceptor(handler, hss.MaxRequestBodySize)
 	}
 
+	for i := len(hss.Middlewares) - 1; i >= 0; i-- {
+		server, err := middleware.GetHTTPServer(host.GetExtensions(), hss.Middlewares[i])
+		if err != nil {
+			return nil, err
+		}
+
+		handler, err = server.ServerHandler(handler)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if hss.Auth != nil {
 		server, err := hss.Auth.GetServerAuthenticator(context.Background(), host.GetExtensions())
 		if err != nil {
@@ -437,23 +515,28 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		handler = authInterceptor(handler, server, hss.Auth.RequestParameters)
 	}
 
-	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) > 0 {
-		co := cors.Options{
-			AllowedOrigins:   hss.CORS.AllowedOrigins,
-			AllowCredentials: true,
-			AllowedHeaders:   hss.CORS.AllowedHeaders,
-			MaxAge:           hss.CORS.MaxAge,
-		}
-		handler = cors.New(co).Handler(handler)
+	if hss.CORS != nil && (len(hss.CORS.AllowedOrigins) > 0 || len(hss.CORS.Origins) > 0) {
+		handler = corsHandler(handler, hss.CORS)
 	}
-	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) == 0 && len(hss.CORS.AllowedHeaders) > 0 {
+	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) == 0 && len(hss.CORS.Origins) == 0 && len(hss.CORS.AllowedHeaders) > 0 {
 		settings.Logger.Warn("The CORS configuration specifies allowed headers but no allowed origins, and is therefore ignored.")
 	}
+	if hss.CORS != nil {
+		for _, o := range hss.CORS.Origins {
+			if len(o.AllowedOrigins) == 0 {
+				settings.Logger.Warn("The CORS configuration specifies per-origin allowed headers but no allowed origins, and they are therefore ignored.")
+			}
+		}
+	}
 
 	if hss.ResponseHeaders != nil {
 		handler = responseHeadersHandler(handler, hss.ResponseHeaders)
 	}
 
+	if hss.HideServerIdentity {
+		handler = hideServerIdentityHandler(handler, hss.ResponseHeaders)
+	}
+
 	otelOpts := []otelhttp.Option{
 		otelhttp.WithTracerProvider(settings.TracerProvider),
 		otelhttp.WithPropagators(otel.GetTextMapPropagator()),
@@ -486,6 +569,30 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 	return server, nil
 }
 
+// Shutdown gracefully shuts down a server created by ServerConfig.ToServer.
+// The server stops accepting new connections, asks the clients to close their
+// connections, with a "Connection: close" header on HTTP/1.1 responses or a
+// GOAWAY frame on HTTP/2, and waits for the in-flight requests to complete, up
+// to ShutdownGracePeriod. The connections still active after the grace period
+// are closed, and an error is only returned if ctx is done first.
+func (hss *ServerConfig) Shutdown(ctx context.Context, server *http.Server) error {
+	shutdownCtx := ctx
+	if hss.ShutdownGracePeriod > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(ctx, hss.ShutdownGracePeriod)
+		defer cancel()
+	}
+	err := server.Shutdown(shutdownCtx)
+	if err == nil || shutdownCtx.Err() == nil {
+		return err
+	}
+	closeErr := server.Close()
+	if ctx.Err() != nil {
+		return errors.Join(err, closeErr)
+	}
+	return closeErr
+}
+
 func responseHeadersHandler(handler http.Handler, headers map[string]configopaque.String) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		h := w.Header()
@@ -498,6 +605,59 @@ func responseHeadersHandler(handler http.Handler, headers map[string]configopaqu
 	})
 }
 
+// serverIdentityHeaders are the response headers identifying the server software.
+var serverIdentityHeaders = []string{"Server", "X-Powered-By"}
+
+// hideServerIdentityHandler removes the server identity headers from the responses, except the configured ones.
+func hideServerIdentityHandler(handler http.Handler, configured map[string]configopaque.String) http.Handler {
+	var hidden []string
+	for _, h := range serverIdentityHeaders {
+		keep := false
+		for k := range configured {
+			keep = keep || http.CanonicalHeaderKey(k) == h
+		}
+		if !keep {
+			hidden = append(hidden, h)
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(&identityHidingWriter{ResponseWriter: w, hidden: hidden}, r)
+	})
+}
+
+// identityHidingWriter removes the hidden headers before the header of the response is written.
+type identityHidingWriter struct {
+	http.ResponseWriter
+	hidden []string
+}
+
+func (w *identityHidingWriter) hide() {
+	h := w.Header()
+	for _, k := range w.hidden {
+		h.Del(k)
+	}
+}
+
+func (w *identityHidingWriter) WriteHeader(statusCode int) {
+	w.hide()
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *identityHidingWriter) Write(b []byte) (int, error) {
+	w.hide()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *identityHidingWriter) Flush() {
+	w.hide()
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (w *identityHidingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // CORSConfig configures a receiver for HTTP cross-origin resource sharing (CORS).
 // See the underlying https://github.com/rs/cors package for details.
 type CORSConfig struct {
@@ -518,6 +678,22 @@ type CORSConfig struct {
 	// Set it to the number of seconds that browsers should cache a CORS
 	// preflight response for.
 	MaxAge int `mapstructure:"max_age"`
+
+	// Origins sets additional headers allowed in CORS requests from specific
+	// origins. The first entry matching the Origin header of a request is used,
+	// otherwise AllowedOrigins and AllowedHeaders apply.
+	Origins []CORSOriginConfig `mapstructure:"origins"`
+}
+
+// CORSOriginConfig configures the headers allowed in CORS requests from some origins.
+type CORSOriginConfig struct {
+	// AllowedOrigins sets the origins this configuration applies to, with the
+	// same syntax as CORSConfig.AllowedOrigins.
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+
+	// AllowedHeaders sets what headers will be allowed in CORS requests from
+	// these origins, in addition to CORSConfig.AllowedHeaders.
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
 }
 
 // NewDefaultCORSConfig creates a default cross-origin resource sharing (CORS) configuration.
@@ -525,6 +701,46 @@ func NewDefaultCORSConfig() *CORSConfig {
 	return &CORSConfig{}
 }
 
+// corsHandler handles the CORS requests with the configuration of the first origins matching their Origin header.
+func corsHandler(next http.Handler, cfg *CORSConfig) http.Handler {
+	newCors := func(origins []string, headers []string) *cors.Cors {
+		return cors.New(cors.Options{
+			AllowedOrigins:   origins,
+			AllowCredentials: true,
+			AllowedHeaders:   headers,
+			MaxAge:           cfg.MaxAge,
+		})
+	}
+	// Without allowed origins, the cors package allows any origin, so the requests are passed through instead.
+	defaultHandler := next
+	if len(cfg.AllowedOrigins) > 0 {
+		defaultHandler = newCors(cfg.AllowedOrigins, cfg.AllowedHeaders).Handler(next)
+	}
+	var origins []*cors.Cors
+	var handlers []http.Handler
+	for _, o := range cfg.Origins {
+		if len(o.AllowedOrigins) == 0 {
+			continue
+		}
+		headers := append(append([]string{}, cfg.AllowedHeaders...), o.AllowedHeaders...)
+		c := newCors(o.AllowedOrigins, headers)
+		origins = append(origins, c)
+		handlers = append(handlers, c.Handler(next))
+	}
+	if len(origins) == 0 {
+		return defaultHandler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i, c := range origins {
+			if c.OriginAllowed(r) {
+				handlers[i].ServeHTTP(w, r)
+				return
+			}
+		}
+		defaultHandler.ServeHTTP(w, r)
+	})
+}
+
 func authInterceptor(next http.Handler, server auth.Server, requestParams []string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		sources := r.Header
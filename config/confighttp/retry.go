@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryMaxRetries      = 3
+	defaultRetryInitialInterval = 100 * time.Millisecond
+	defaultRetryMaxInterval     = 5 * time.Second
+	defaultRetryBudgetRatio     = 0.1
+	// retryBudgetCap is the number of retries the budget allows at once, e.g. when the client starts.
+	retryBudgetCap = 10
+	// maxDrainedBodySize is the size of the body of a failed response read to reuse its connection.
+	maxDrainedBodySize = 4096
+)
+
+// RetryConfig defines the retries of the idempotent requests made by the HTTP client at the transport level,
+// when the connection is reset or the server answers with a 502 or 503 status code.
+type RetryConfig struct {
+	// Enabled retries the idempotent requests.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxRetries is the maximum number of retries of a request. Default: 3.
+	MaxRetries int `mapstructure:"max_retries"`
+	// InitialInterval is the time to wait before the first retry, doubled for every next retry. Default: 100ms.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the maximum time to wait between two retries, the Retry-After header of the responses
+	// is honored up to this duration. Default: 5s.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+	// BudgetRatio is the ratio of retries to requests allowed over time, so the retries do not overload a
+	// failing server. Every request adds this ratio to a budget of up to 10 retries, every retry takes one.
+	// Default: 0.1.
+	BudgetRatio float64 `mapstructure:"budget_ratio"`
+}
+
+// NewDefaultRetryConfig returns the default RetryConfig, with the retries disabled.
+func NewDefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:      defaultRetryMaxRetries,
+		InitialInterval: defaultRetryInitialInterval,
+		MaxInterval:     defaultRetryMaxInterval,
+		BudgetRatio:     defaultRetryBudgetRatio,
+	}
+}
+
+// Validate checks if the RetryConfig configuration is valid.
+func (rc *RetryConfig) Validate() error {
+	if !rc.Enabled {
+		return nil
+	}
+	if rc.MaxRetries < 0 {
+		return errors.New("'max_retries' must be non-negative")
+	}
+	if rc.InitialInterval < 0 || rc.MaxInterval < 0 {
+		return errors.New("'initial_interval' and 'max_interval' must be non-negative")
+	}
+	if rc.InitialInterval > 0 && rc.MaxInterval > 0 && rc.MaxInterval < rc.InitialInterval {
+		return errors.New("'max_interval' must be greater than or equal to 'initial_interval'")
+	}
+	if rc.BudgetRatio < 0 || rc.BudgetRatio > 1 {
+		return errors.New("'budget_ratio' must be between 0 and 1")
+	}
+	return nil
+}
+
+// withDefaults returns the configuration with the zero values replaced by the defaults.
+func (rc RetryConfig) withDefaults() RetryConfig {
+	if rc.MaxRetries == 0 {
+		rc.MaxRetries = defaultRetryMaxRetries
+	}
+	if rc.InitialInterval == 0 {
+		rc.InitialInterval = defaultRetryInitialInterval
+	}
+	if rc.MaxInterval == 0 {
+		rc.MaxInterval = max(defaultRetryMaxInterval, rc.InitialInterval)
+	}
+	if rc.BudgetRatio == 0 {
+		rc.BudgetRatio = defaultRetryBudgetRatio
+	}
+	return rc
+}
+
+// retryRoundTripper is a RoundTripper retrying the idempotent requests failing with a transient error,
+// with an exponential backoff, as long as the retry budget allows it.
+type retryRoundTripper struct {
+	transport http.RoundTripper
+	cfg       RetryConfig
+	sleep     func(*http.Request, time.Duration) error
+
+	mu     sync.Mutex
+	budget float64
+}
+
+func newRetryRoundTripper(transport http.RoundTripper, cfg RetryConfig) *retryRoundTripper {
+	return &retryRoundTripper{
+		transport: transport,
+		cfg:       cfg.withDefaults(),
+		sleep:     sleepContext,
+		budget:    retryBudgetCap,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.deposit()
+	if !isRetryable(req) {
+		return rt.transport.RoundTrip(req)
+	}
+	backoff := rt.cfg.InitialInterval
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.transport.RoundTrip(attemptReq)
+		if attempt >= rt.cfg.MaxRetries || !isTransientFailure(resp, err) || !rt.withdraw() {
+			return resp, err
+		}
+		delay := backoff
+		if ra := retryAfter(resp); ra > 0 {
+			delay = min(ra, rt.cfg.MaxInterval)
+		}
+		if resp != nil {
+			// Read the start of the body so the connection can be reused.
+			_, _ = io.CopyN(io.Discard, resp.Body, maxDrainedBodySize)
+			_ = resp.Body.Close()
+		}
+		if err = rt.sleep(req, delay); err != nil {
+			return nil, err
+		}
+		backoff = min(2*backoff, rt.cfg.MaxInterval)
+		if attemptReq, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// deposit adds the budget ratio to the retry budget for every request.
+func (rt *retryRoundTripper) deposit() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.budget = min(rt.budget+rt.cfg.BudgetRatio, retryBudgetCap)
+}
+
+// withdraw takes a retry from the budget, returning false if the budget is exhausted.
+func (rt *retryRoundTripper) withdraw() bool {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.budget < 1 {
+		return false
+	}
+	rt.budget--
+	return true
+}
+
+// isRetryable returns whether the request is idempotent and its body can be sent again.
+func isRetryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	// The same convention as the http.Transport, the requests with an idempotency key can be retried.
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+// isTransientFailure returns whether the connection was reset, or the server answered with a 502 or 503 status code.
+func isTransientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	return resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// retryAfter returns the delay of the Retry-After header of the response, zero if there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// rewindRequest returns a copy of the request with a new body to send it again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// sleepContext waits for the given delay, or until the context of the request is done.
+func sleepContext(req *http.Request, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
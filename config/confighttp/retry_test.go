@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestRetryConfigValidate(t *testing.T) {
+	cfg := NewDefaultRetryConfig()
+	require.NoError(t, cfg.Validate())
+	cfg.Enabled = true
+	require.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*RetryConfig)
+		err    string
+	}{
+		{name: "negative max retries", modify: func(c *RetryConfig) { c.MaxRetries = -1 }, err: "'max_retries' must be non-negative"},
+		{name: "negative interval", modify: func(c *RetryConfig) { c.InitialInterval = -1 }, err: "'initial_interval' and 'max_interval' must be non-negative"},
+		{name: "max interval too low", modify: func(c *RetryConfig) { c.MaxInterval = time.Millisecond }, err: "'max_interval' must be greater than or equal to 'initial_interval'"},
+		{name: "budget ratio too high", modify: func(c *RetryConfig) { c.BudgetRatio = 2 }, err: "'budget_ratio' must be between 0 and 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cfg
+			tt.modify(&c)
+			assert.EqualError(t, c.Validate(), tt.err)
+		})
+	}
+}
+
+// newFlakyServer returns a server answering with the given status codes, then with 200.
+func newFlakyServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		body, _ := io.ReadAll(r.Body)
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func newRetryClient(t *testing.T, endpoint string, cfg RetryConfig) *http.Client {
+	hcs := NewDefaultClientConfig()
+	hcs.Endpoint = endpoint
+	hcs.Retry = &cfg
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return client
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	srv, calls := newFlakyServer(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+	client := newRetryClient(t, srv.URL, RetryConfig{Enabled: true, InitialInterval: time.Millisecond})
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader("payload"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	// The body is sent again with every attempt.
+	assert.Equal(t, "payload", string(body))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryNonIdempotentRequests(t *testing.T) {
+	srv, calls := newFlakyServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := newRetryClient(t, srv.URL, RetryConfig{Enabled: true, InitialInterval: time.Millisecond})
+
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// The requests with an idempotency key are retried.
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+	require.NoError(t, err)
+	req.Header.Set("Idempotency-Key", "abc")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	srv, calls := newFlakyServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := newRetryClient(t, srv.URL, RetryConfig{Enabled: true, MaxRetries: 1, InitialInterval: time.Millisecond})
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryNotTransient(t *testing.T) {
+	srv, calls := newFlakyServer(t, http.StatusInternalServerError)
+	client := newRetryClient(t, srv.URL, RetryConfig{Enabled: true, InitialInterval: time.Millisecond})
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryConnectionReset(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			// Close the connection without answering.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			assert.NoError(t, err)
+			assert.NoError(t, conn.Close())
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	client := newRetryClient(t, srv.URL, RetryConfig{Enabled: true, InitialInterval: time.Millisecond})
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryBudget(t *testing.T) {
+	var calls atomic.Int32
+	rt := newRetryRoundTripper(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+	}), RetryConfig{Enabled: true, MaxRetries: 4, BudgetRatio: 0.5})
+	var delays []time.Duration
+	rt.sleep = func(_ *http.Request, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	for i := 0; i < 3; i++ {
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	// The budget of 10 retries, refilled by half a retry per request, allows 11 retries before it is exhausted.
+	assert.Equal(t, int32(3+11), calls.Load())
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}, delays[:4])
+	assert.Less(t, rt.budget, 1.0)
+}
+
+func TestRetryAfter(t *testing.T) {
+	rt := newRetryRoundTripper(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": []string{"2"}}, Body: http.NoBody}, nil
+	}), RetryConfig{Enabled: true, MaxRetries: 2, MaxInterval: time.Second})
+	var delays []time.Duration
+	rt.sleep = func(_ *http.Request, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	_, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+	require.NoError(t, err)
+	// The Retry-After header is honored up to the maximum interval.
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, delays)
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	srv, calls := newFlakyServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := newRetryClient(t, srv.URL, RetryConfig{Enabled: true, InitialInterval: time.Hour, MaxInterval: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryInvalidConfig(t *testing.T) {
+	hcs := NewDefaultClientConfig()
+	hcs.Retry = &RetryConfig{Enabled: true, MaxRetries: -1}
+	_, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.EqualError(t, err, "invalid retry configuration: 'max_retries' must be non-negative")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,13 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// bindToDevice binds the socket to the network interface with the given name (SO_BINDTODEVICE).
+func bindToDevice(fd int, name string) error {
+	return unix.BindToDevice(fd, name)
+}
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix && !solaris && !linux
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// bindToDevice is only supported on Linux.
+func bindToDevice(int, string) error {
+	return fmt.Errorf("binding to an interface is not supported on %s", runtime.GOOS)
+}
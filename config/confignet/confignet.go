@@ -5,8 +5,10 @@ package confignet // import "go.opentelemetry.io/collector/config/confignet"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"syscall"
 	"time"
 )
 
@@ -67,6 +69,94 @@ func NewDefaultDialerConfig() DialerConfig {
 	return DialerConfig{}
 }
 
+// ListenerConfig contains options for listening on an address.
+type ListenerConfig struct {
+	// IPv6Only only accepts the IPv6 connections when listening on an IPv6 address. By default, listening on the
+	// unspecified IPv6 address, as in "[::]:4317", accepts both the IPv4 and the IPv6 connections (dual-stack).
+	IPv6Only bool `mapstructure:"ipv6_only"`
+
+	// Interface is the name of the network interface the listener is bound to, e.g. "eth0". The connections
+	// received on the other interfaces are ignored. Only supported on Linux.
+	Interface string `mapstructure:"interface"`
+
+	// ReusePort sets SO_REUSEPORT, so multiple listeners, e.g. of multiple collectors, can bind the same address
+	// and the connections or datagrams are distributed between them. Not supported on Windows.
+	ReusePort bool `mapstructure:"reuse_port"`
+
+	// ReceiveBufferSize is the size in bytes of the receive buffer of the socket (SO_RCVBUF). The default, zero,
+	// keeps the size of the operating system.
+	ReceiveBufferSize int `mapstructure:"receive_buffer_size"`
+
+	// TCPNoDelay sets TCP_NODELAY on the accepted TCP connections, sending the data as soon as possible instead of
+	// coalescing the small writes. It is enabled by default.
+	TCPNoDelay *bool `mapstructure:"tcp_no_delay"`
+}
+
+// NewDefaultListenerConfig creates a new ListenerConfig with any default values set
+func NewDefaultListenerConfig() ListenerConfig {
+	return ListenerConfig{}
+}
+
+// Validate checks the listener options are valid.
+func (lc *ListenerConfig) Validate() error {
+	if lc.ReceiveBufferSize < 0 {
+		return errors.New("receive_buffer_size must be non-negative")
+	}
+	return nil
+}
+
+// listen equivalent with net.ListenConfig's Listen, with the listener options applied.
+func (lc *ListenerConfig) listen(ctx context.Context, network, address string) (net.Listener, error) {
+	nlc := net.ListenConfig{Control: lc.control()}
+	ln, err := nlc.Listen(ctx, network, address)
+	if err != nil || lc.TCPNoDelay == nil {
+		return ln, err
+	}
+	return &noDelayListener{Listener: ln, noDelay: *lc.TCPNoDelay}, nil
+}
+
+// listenPacket equivalent with net.ListenConfig's ListenPacket, with the listener options applied.
+func (lc *ListenerConfig) listenPacket(ctx context.Context, network, address string) (net.PacketConn, error) {
+	nlc := net.ListenConfig{Control: lc.control()}
+	return nlc.ListenPacket(ctx, network, address)
+}
+
+// control returns the function setting the socket options before the socket is bound, nil if there are none.
+func (lc *ListenerConfig) control() func(network, address string, c syscall.RawConn) error {
+	if !lc.IPv6Only && lc.Interface == "" && !lc.ReusePort && lc.ReceiveBufferSize == 0 {
+		return nil
+	}
+	return func(network, _ string, c syscall.RawConn) error {
+		var sockErr error
+		if err := c.Control(func(fd uintptr) {
+			sockErr = setSockopts(fd, network, lc)
+		}); err != nil {
+			return err
+		}
+		return sockErr
+	}
+}
+
+// noDelayListener sets TCP_NODELAY on the accepted TCP connections.
+type noDelayListener struct {
+	net.Listener
+	noDelay bool
+}
+
+func (l *noDelayListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		if err = tcpConn.SetNoDelay(l.noDelay); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
 // AddrConfig represents a network endpoint address.
 type AddrConfig struct {
 	// Endpoint configures the address for this network connection.
@@ -82,12 +172,16 @@ type AddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer"`
+
+	// ListenerConfig contains options for listening on an address.
+	ListenerConfig ListenerConfig `mapstructure:"listener"`
 }
 
 // NewDefaultAddrConfig creates a new AddrConfig with any default values set
 func NewDefaultAddrConfig() AddrConfig {
 	return AddrConfig{
-		DialerConfig: NewDefaultDialerConfig(),
+		DialerConfig:   NewDefaultDialerConfig(),
+		ListenerConfig: NewDefaultListenerConfig(),
 	}
 }
 
@@ -99,8 +193,13 @@ func (na *AddrConfig) Dial(ctx context.Context) (net.Conn, error) {
 
 // Listen equivalent with net.ListenConfig's Listen for this address.
 func (na *AddrConfig) Listen(ctx context.Context) (net.Listener, error) {
-	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(na.Transport), na.Endpoint)
+	return na.ListenerConfig.listen(ctx, string(na.Transport), na.Endpoint)
+}
+
+// ListenPacket equivalent with net.ListenConfig's ListenPacket for this address, for the packet-oriented
+// transports such as "udp".
+func (na *AddrConfig) ListenPacket(ctx context.Context) (net.PacketConn, error) {
+	return na.ListenerConfig.listenPacket(ctx, string(na.Transport), na.Endpoint)
 }
 
 func (na *AddrConfig) Validate() error {
@@ -134,12 +233,16 @@ type TCPAddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer"`
+
+	// ListenerConfig contains options for listening on an address.
+	ListenerConfig ListenerConfig `mapstructure:"listener"`
 }
 
 // NewDefaultTCPAddrConfig creates a new TCPAddrConfig with any default values set
 func NewDefaultTCPAddrConfig() TCPAddrConfig {
 	return TCPAddrConfig{
-		DialerConfig: NewDefaultDialerConfig(),
+		DialerConfig:   NewDefaultDialerConfig(),
+		ListenerConfig: NewDefaultListenerConfig(),
 	}
 }
 
@@ -151,6 +254,5 @@ func (na *TCPAddrConfig) Dial(ctx context.Context) (net.Conn, error) {
 
 // Listen equivalent with net.ListenConfig's Listen for this address.
 func (na *TCPAddrConfig) Listen(ctx context.Context) (net.Listener, error) {
-	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(TransportTypeTCP), na.Endpoint)
+	return na.ListenerConfig.listen(ctx, string(TransportTypeTCP), na.Endpoint)
 }
@@ -152,6 +152,68 @@ func TestTCPAddrConfig(t *testing.T) {
 	assert.NoError(t, ln.Close())
 }
 
+func TestListenerConfigValidate(t *testing.T) {
+	lc := &ListenerConfig{ReceiveBufferSize: 1 << 20}
+	assert.NoError(t, lc.Validate())
+
+	lc = &ListenerConfig{ReceiveBufferSize: -1}
+	assert.EqualError(t, lc.Validate(), "receive_buffer_size must be non-negative")
+}
+
+func TestAddrConfigListenPacket(t *testing.T) {
+	nas := &AddrConfig{
+		Endpoint:  "localhost:0",
+		Transport: TransportTypeUDP,
+	}
+	pc, err := nas.ListenPacket(context.Background())
+	require.NoError(t, err)
+
+	nac := &AddrConfig{
+		Endpoint:  pc.LocalAddr().String(),
+		Transport: TransportTypeUDP,
+	}
+	conn, err := nac.Dial(context.Background())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 10)
+	numChr, _, err := pc.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "test", string(buf[:numChr]))
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, pc.Close())
+}
+
+func TestListenerConfigTCPNoDelay(t *testing.T) {
+	noDelay := false
+	nas := &TCPAddrConfig{
+		Endpoint:       "localhost:0",
+		ListenerConfig: ListenerConfig{TCPNoDelay: &noDelay},
+	}
+	ln, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+	require.IsType(t, &noDelayListener{}, ln)
+	done := make(chan bool, 1)
+
+	go func() {
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		assert.IsType(t, &net.TCPConn{}, conn)
+		assert.NoError(t, conn.Close())
+		done <- true
+	}()
+
+	nac := &TCPAddrConfig{
+		Endpoint: ln.Addr().String(),
+	}
+	conn, err := nac.Dial(context.Background())
+	require.NoError(t, err)
+	<-done
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, ln.Close())
+}
+
 func Test_TransportType_UnmarshalText(t *testing.T) {
 	var tt TransportType
 	err := tt.UnmarshalText([]byte("tcp"))
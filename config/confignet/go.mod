@@ -5,6 +5,7 @@ go 1.22.0
 require (
 	github.com/stretchr/testify v1.9.0
 	go.uber.org/goleak v1.3.0
+	golang.org/x/sys v0.23.0
 )
 
 require (
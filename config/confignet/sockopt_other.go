@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build (!unix && !windows) || solaris
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// setSockopts is not supported on this platform.
+func setSockopts(uintptr, string, *ListenerConfig) error {
+	return fmt.Errorf("the listener options are not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix && !solaris
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// setSockopts sets the socket options of the listener on the socket, before it is bound.
+func setSockopts(fd uintptr, network string, lc *ListenerConfig) error {
+	s := int(fd)
+	// The network is suffixed with the IP version of the socket, e.g. "tcp6".
+	if lc.IPv6Only && strings.HasSuffix(network, "6") {
+		if err := unix.SetsockoptInt(s, unix.IPPROTO_IPV6, unix.IPV6_V6ONLY, 1); err != nil {
+			return fmt.Errorf("failed to set IPV6_V6ONLY: %w", err)
+		}
+	}
+	if lc.Interface != "" {
+		if err := bindToDevice(s, lc.Interface); err != nil {
+			return fmt.Errorf("failed to bind to interface %q: %w", lc.Interface, err)
+		}
+	}
+	if lc.ReusePort {
+		if err := unix.SetsockoptInt(s, unix.SOL_SOCKET, unix.SO_REUSEPORT, 1); err != nil {
+			return fmt.Errorf("failed to set SO_REUSEPORT: %w", err)
+		}
+	}
+	if lc.ReceiveBufferSize > 0 {
+		if err := unix.SetsockoptInt(s, unix.SOL_SOCKET, unix.SO_RCVBUF, lc.ReceiveBufferSize); err != nil {
+			return fmt.Errorf("failed to set SO_RCVBUF: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix && !solaris
+
+package confignet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func getSockoptInt(t *testing.T, sc syscall.Conn, level, opt int) int {
+	rc, err := sc.SyscallConn()
+	require.NoError(t, err)
+	var value int
+	var sockErr error
+	require.NoError(t, rc.Control(func(fd uintptr) {
+		value, sockErr = unix.GetsockoptInt(int(fd), level, opt)
+	}))
+	require.NoError(t, sockErr)
+	return value
+}
+
+func TestListenerConfigReusePort(t *testing.T) {
+	nas := &TCPAddrConfig{
+		Endpoint:       "localhost:0",
+		ListenerConfig: ListenerConfig{ReusePort: true},
+	}
+	ln, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, getSockoptInt(t, ln.(*net.TCPListener), unix.SOL_SOCKET, unix.SO_REUSEPORT))
+
+	// A second listener binds the same address.
+	nas.Endpoint = ln.Addr().String()
+	other, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, other.Close())
+	assert.NoError(t, ln.Close())
+}
+
+func TestListenerConfigReceiveBufferSize(t *testing.T) {
+	nas := &AddrConfig{
+		Endpoint:       "localhost:0",
+		Transport:      TransportTypeUDP,
+		ListenerConfig: ListenerConfig{ReceiveBufferSize: 64 * 1024},
+	}
+	pc, err := nas.ListenPacket(context.Background())
+	require.NoError(t, err)
+	// The operating system may round up the size, e.g. Linux doubles it.
+	assert.GreaterOrEqual(t, getSockoptInt(t, pc.(*net.UDPConn), unix.SOL_SOCKET, unix.SO_RCVBUF), 64*1024)
+	assert.NoError(t, pc.Close())
+}
+
+func TestListenerConfigIPv6Only(t *testing.T) {
+	nas := &TCPAddrConfig{
+		Endpoint:       "[::1]:0",
+		ListenerConfig: ListenerConfig{IPv6Only: true},
+	}
+	ln, err := nas.Listen(context.Background())
+	if err != nil {
+		t.Skipf("IPv6 is not available: %v", err)
+	}
+	assert.Equal(t, 1, getSockoptInt(t, ln.(*net.TCPListener), unix.IPPROTO_IPV6, unix.IPV6_V6ONLY))
+	assert.NoError(t, ln.Close())
+}
+
+func TestListenerConfigInterface(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		nas := &TCPAddrConfig{
+			Endpoint:       "localhost:0",
+			ListenerConfig: ListenerConfig{Interface: "lo0"},
+		}
+		_, err := nas.Listen(context.Background())
+		assert.ErrorContains(t, err, "binding to an interface is not supported")
+		return
+	}
+
+	nas := &TCPAddrConfig{
+		Endpoint:       "localhost:0",
+		ListenerConfig: ListenerConfig{Interface: "lo"},
+	}
+	ln, err := nas.Listen(context.Background())
+	if errors.Is(err, unix.EPERM) {
+		t.Skip("Binding to an interface requires the CAP_NET_RAW capability")
+	}
+	require.NoError(t, err)
+	assert.NoError(t, ln.Close())
+
+	nas.ListenerConfig.Interface = "unknown0"
+	_, err = nas.Listen(context.Background())
+	assert.ErrorContains(t, err, `failed to bind to interface "unknown0"`)
+}
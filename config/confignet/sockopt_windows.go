@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// setSockopts sets the socket options of the listener on the socket, before it is bound.
+func setSockopts(fd uintptr, network string, lc *ListenerConfig) error {
+	if lc.Interface != "" {
+		return errors.New("binding to an interface is not supported on windows")
+	}
+	if lc.ReusePort {
+		return errors.New("reusing the port is not supported on windows")
+	}
+	s := syscall.Handle(fd)
+	// The network is suffixed with the IP version of the socket, e.g. "tcp6".
+	if lc.IPv6Only && strings.HasSuffix(network, "6") {
+		if err := syscall.SetsockoptInt(s, syscall.IPPROTO_IPV6, syscall.IPV6_V6ONLY, 1); err != nil {
+			return fmt.Errorf("failed to set IPV6_V6ONLY: %w", err)
+		}
+	}
+	if lc.ReceiveBufferSize > 0 {
+		if err := syscall.SetsockoptInt(s, syscall.SOL_SOCKET, syscall.SO_RCVBUF, lc.ReceiveBufferSize); err != nil {
+			return fmt.Errorf("failed to set SO_RCVBUF: %w", err)
+		}
+	}
+	return nil
+}
@@ -40,6 +40,10 @@ type BackOffConfig struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded. If set to 0, the retries are never stopped.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// FullJitter replaces the randomization of the backoff interval by a uniformly random interval
+	// between 10ms and the computed backoff interval, RandomizationFactor is then ignored.
+	// This spreads the retries of many clients failing at the same time better.
+	FullJitter bool `mapstructure:"full_jitter"`
 }
 
 func (bs *BackOffConfig) Validate() error {
@@ -47,14 +47,15 @@ func (r *clientCAsFileReloader) getClientConfig(original *tls.Config) (*tls.Conf
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 	return &tls.Config{
-		RootCAs:              original.RootCAs,
-		GetCertificate:       original.GetCertificate,
-		GetClientCertificate: original.GetClientCertificate,
-		MinVersion:           original.MinVersion,
-		MaxVersion:           original.MaxVersion,
-		NextProtos:           original.NextProtos,
-		ClientCAs:            r.certPool,
-		ClientAuth:           tls.RequireAndVerifyClientCert,
+		RootCAs:               original.RootCAs,
+		GetCertificate:        original.GetCertificate,
+		GetClientCertificate:  original.GetClientCertificate,
+		VerifyPeerCertificate: original.VerifyPeerCertificate,
+		MinVersion:            original.MinVersion,
+		MaxVersion:            original.MaxVersion,
+		NextProtos:            original.NextProtos,
+		ClientCAs:             r.certPool,
+		ClientAuth:            tls.RequireAndVerifyClientCert,
 	}, nil
 }
 
@@ -123,6 +123,36 @@ type ServerConfig struct {
 	// Reload the ClientCAs file when it is modified
 	// (optional, default false)
 	ReloadClientCAFile bool `mapstructure:"client_ca_file_reload"`
+
+	// Path to the certificate revocation lists, PEM or DER encoded, used to reject the revoked
+	// client certificates. The file is reloaded every ReloadInterval. It requires ClientCAFile. (optional)
+	CRLFile string `mapstructure:"crl_file"`
+
+	// OCSP configures the stapling of the OCSP response of the server certificate and the
+	// checking of the client certificates against the OCSP responders of their issuers. (optional)
+	OCSP *OCSPConfig `mapstructure:"ocsp"`
+}
+
+// OCSPConfig configures the use of the Online Certificate Status Protocol by a server.
+type OCSPConfig struct {
+	// Path to the DER encoded OCSP response of the server certificate, stapled to the
+	// handshakes. The file is reloaded every ReloadInterval. (optional)
+	StapleFile string `mapstructure:"staple_file"`
+
+	// VerifyClientCertificates checks the status of the client certificates with the OCSP
+	// responders of their issuers. It requires ClientCAFile. (optional, default false)
+	VerifyClientCertificates bool `mapstructure:"verify_client_certificates"`
+
+	// ResponderURL is the URL of the OCSP responder used instead of the ones listed by
+	// the client certificates. (optional)
+	ResponderURL string `mapstructure:"responder_url"`
+
+	// Timeout is the timeout of the requests to the OCSP responders. (optional, default 5s)
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// FailOpen accepts the client certificates whose status cannot be determined, because the
+	// responder cannot be reached or does not know them. (optional, default false)
+	FailOpen bool `mapstructure:"fail_open"`
 }
 
 // NewDefaultServerConfig creates a new TLSServerSetting with any default values set.
@@ -410,6 +440,26 @@ func (c ServerConfig) LoadTLSConfig(_ context.Context) (*tls.Config, error) {
 		tlsCfg.ClientCAs = reloader.certPool
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
 	}
+	if c.OCSP != nil && c.OCSP.StapleFile != "" {
+		if tlsCfg.GetCertificate == nil {
+			return nil, errors.New("failed to load TLS config: the OCSP staple_file requires a server certificate")
+		}
+		stapler, err := newOCSPStapler(c.OCSP.StapleFile, c.ReloadInterval, tlsCfg.GetCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		tlsCfg.GetCertificate = stapler.GetCertificate
+	}
+	if c.CRLFile != "" || (c.OCSP != nil && c.OCSP.VerifyClientCertificates) {
+		if c.ClientCAFile == "" {
+			return nil, errors.New("failed to load TLS config: checking the revocation of the client certificates requires client_ca_file")
+		}
+		checker, err := newRevocationChecker(c.CRLFile, c.ReloadInterval, c.OCSP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		tlsCfg.VerifyPeerCertificate = checker.verifyPeerCertificate
+	}
 	return tlsCfg, nil
 }
 
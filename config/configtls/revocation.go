@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const defaultOCSPTimeout = 5 * time.Second
+
+// maxOCSPResponseSize bounds the size of the responses read from the OCSP responders.
+const maxOCSPResponseSize = 1 << 20
+
+// maxOCSPCacheSize bounds the number of OCSP responses cached.
+const maxOCSPCacheSize = 10000
+
+var (
+	errNoOCSPResponder   = errors.New("no OCSP responder for the certificate")
+	errUnknownOCSPStatus = errors.New("the OCSP responder does not know the certificate")
+	errNoRevocationList  = errors.New("no certificate revocation list in the file")
+)
+
+// ocspStapler is a wrapper of the GetCertificate func of a server, stapling an OCSP response
+// to the certificate. The response is reloaded from disk if the last reload happened more
+// than reloadInterval ago.
+type ocspStapler struct {
+	file           string
+	reloadInterval time.Duration
+	getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
+	lock       sync.RWMutex
+	staple     []byte
+	nextReload time.Time
+}
+
+func newOCSPStapler(file string, reloadInterval time.Duration, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) (*ocspStapler, error) {
+	staple, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the OCSP staple %s: %w", file, err)
+	}
+	return &ocspStapler{
+		file:           file,
+		reloadInterval: reloadInterval,
+		getCertificate: getCertificate,
+		staple:         staple,
+		nextReload:     time.Now().Add(reloadInterval),
+	}, nil
+}
+
+func (s *ocspStapler) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, err := s.getCertificate(hello)
+	if err != nil || cert == nil {
+		return cert, err
+	}
+	stapled := *cert
+	stapled.OCSPStaple = s.getStaple()
+	return &stapled, nil
+}
+
+func (s *ocspStapler) getStaple() []byte {
+	now := time.Now()
+	s.lock.RLock()
+	if s.reloadInterval == 0 || !s.nextReload.Before(now) {
+		defer s.lock.RUnlock()
+		return s.staple
+	}
+	s.lock.RUnlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.nextReload.Before(now) {
+		// The previous staple is kept when the file cannot be read, and the
+		// reload is attempted again at the next interval.
+		if staple, err := os.ReadFile(filepath.Clean(s.file)); err == nil {
+			s.staple = staple
+		}
+		s.nextReload = now.Add(s.reloadInterval)
+	}
+	return s.staple
+}
+
+// revocationChecker rejects the revoked client certificates, listed by the certificate
+// revocation lists or reported by the OCSP responders of their issuers.
+type revocationChecker struct {
+	crlFile        string
+	reloadInterval time.Duration
+	ocsp           *OCSPConfig
+	client         *http.Client
+
+	lock       sync.RWMutex
+	crls       []*x509.RevocationList
+	nextReload time.Time
+
+	// ocspCache holds the OCSP responses until their next update, keyed by the
+	// issuer and the serial number of the certificates.
+	ocspLock  sync.Mutex
+	ocspCache map[string]*ocsp.Response
+}
+
+func newRevocationChecker(crlFile string, reloadInterval time.Duration, ocspCfg *OCSPConfig) (*revocationChecker, error) {
+	r := &revocationChecker{
+		crlFile:        crlFile,
+		reloadInterval: reloadInterval,
+		nextReload:     time.Now().Add(reloadInterval),
+		ocspCache:      make(map[string]*ocsp.Response),
+	}
+	if crlFile != "" {
+		crls, err := loadRevocationLists(crlFile)
+		if err != nil {
+			return nil, err
+		}
+		r.crls = crls
+	}
+	if ocspCfg != nil && ocspCfg.VerifyClientCertificates {
+		r.ocsp = ocspCfg
+		timeout := ocspCfg.Timeout
+		if timeout == 0 {
+			timeout = defaultOCSPTimeout
+		}
+		r.client = &http.Client{Timeout: timeout}
+	}
+	return r, nil
+}
+
+// loadRevocationLists loads the PEM or DER encoded certificate revocation lists of the file.
+func loadRevocationLists(file string) ([]*x509.RevocationList, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the certificate revocation lists %s: %w", file, err)
+	}
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		crl, err := x509.ParseRevocationList(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the certificate revocation list %s: %w", file, err)
+		}
+		return []*x509.RevocationList{crl}, nil
+	}
+	var crls []*x509.RevocationList
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "X509 CRL" {
+			continue
+		}
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the certificate revocation list %s: %w", file, err)
+		}
+		crls = append(crls, crl)
+	}
+	if len(crls) == 0 {
+		return nil, fmt.Errorf("failed to load the certificate revocation lists %s: %w", file, errNoRevocationList)
+	}
+	return crls, nil
+}
+
+// getRevocationLists returns the certificate revocation lists, reloading them from disk if
+// the last reload happened more than reloadInterval ago. The previous lists are kept when
+// the file cannot be loaded, and the reload is attempted again at the next interval.
+func (r *revocationChecker) getRevocationLists() []*x509.RevocationList {
+	now := time.Now()
+	r.lock.RLock()
+	if r.crlFile == "" || r.reloadInterval == 0 || !r.nextReload.Before(now) {
+		defer r.lock.RUnlock()
+		return r.crls
+	}
+	r.lock.RUnlock()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.nextReload.Before(now) {
+		if crls, err := loadRevocationLists(r.crlFile); err == nil {
+			r.crls = crls
+		}
+		r.nextReload = now.Add(r.reloadInterval)
+	}
+	return r.crls
+}
+
+// verifyPeerCertificate is called once the client certificate is verified. It accepts the
+// certificate if one of its chains has no revoked certificate.
+func (r *revocationChecker) verifyPeerCertificate(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+	var err error
+	for _, chain := range verifiedChains {
+		if err = r.checkChain(chain); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *revocationChecker) checkChain(chain []*x509.Certificate) error {
+	crls := r.getRevocationLists()
+	for i := 0; i+1 < len(chain); i++ {
+		if err := checkRevocationLists(crls, chain[i], chain[i+1]); err != nil {
+			return err
+		}
+	}
+	if r.ocsp != nil && len(chain) > 1 {
+		// Only the status of the client certificate is requested, the intermediate
+		// certificates are expected to be covered by the revocation lists.
+		return r.checkOCSP(chain[0], chain[1])
+	}
+	return nil
+}
+
+// checkRevocationLists checks the certificate against the revocation lists of its issuer.
+func checkRevocationLists(crls []*x509.RevocationList, cert, issuer *x509.Certificate) error {
+	for _, crl := range crls {
+		if !bytes.Equal(crl.RawIssuer, issuer.RawSubject) || crl.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		for _, revoked := range crl.RevokedCertificateEntries {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("certificate %s was revoked", cert.Subject)
+			}
+		}
+	}
+	return nil
+}
+
+// checkOCSP requests the status of the certificate to the OCSP responder.
+func (r *revocationChecker) checkOCSP(cert, issuer *x509.Certificate) error {
+	resp, err := r.ocspResponse(cert, issuer)
+	if err == nil && resp.Status == ocsp.Unknown {
+		err = errUnknownOCSPStatus
+	}
+	if err != nil {
+		if r.ocsp.FailOpen {
+			return nil
+		}
+		return fmt.Errorf("failed to check the OCSP status of certificate %s: %w", cert.Subject, err)
+	}
+	if resp.Status == ocsp.Revoked {
+		return fmt.Errorf("certificate %s was revoked", cert.Subject)
+	}
+	return nil
+}
+
+func (r *revocationChecker) ocspResponse(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	key := string(issuer.RawSubjectPublicKeyInfo) + cert.SerialNumber.String()
+	now := time.Now()
+	r.ocspLock.Lock()
+	resp, ok := r.ocspCache[key]
+	if ok && !now.Before(resp.NextUpdate) {
+		delete(r.ocspCache, key)
+		ok = false
+	}
+	r.ocspLock.Unlock()
+	if ok {
+		return resp, nil
+	}
+
+	url := r.ocsp.ResponderURL
+	if url == "" {
+		if len(cert.OCSPServer) == 0 {
+			return nil, errNoOCSPResponder
+		}
+		url = cert.OCSPServer[0]
+	}
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpResp, err := r.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the OCSP responder returned status %d", httpResp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, maxOCSPResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	resp, err = ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses without a next update are not cached, since newer information
+	// is always available.
+	if now.Before(resp.NextUpdate) {
+		r.ocspLock.Lock()
+		if len(r.ocspCache) >= maxOCSPCacheSize {
+			for k, cached := range r.ocspCache {
+				if !now.Before(cached.NextUpdate) {
+					delete(r.ocspCache, k)
+				}
+			}
+		}
+		if len(r.ocspCache) < maxOCSPCacheSize {
+			r.ocspCache[key] = resp
+		}
+		r.ocspLock.Unlock()
+	}
+	return resp, nil
+}
@@ -0,0 +1,362 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCA(t *testing.T) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCertificate{cert: cert, key: key}
+}
+
+func (ca testCertificate) issue(t *testing.T, serial int64, name string, ocspServer string) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	if ocspServer != "" {
+		tmpl.OCSPServer = []string{ocspServer}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCertificate{cert: cert, key: key}
+}
+
+func (c testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key, Leaf: c.cert}
+}
+
+func (c testCertificate) writeFiles(t *testing.T, dir, name string) (certFile, keyFile string) {
+	certFile = filepath.Join(dir, name+".crt")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	keyDER, err := x509.MarshalPKCS8PrivateKey(c.key)
+	require.NoError(t, err)
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func (ca testCertificate) writeCRL(t *testing.T, file string, number int64, revoked ...*x509.Certificate) {
+	tmpl := &x509.RevocationList{
+		Number:     big.NewInt(number),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: time.Now().Add(time.Hour),
+	}
+	for _, cert := range revoked {
+		tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   cert.SerialNumber,
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, ca.cert, ca.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), 0600))
+}
+
+// handshake connects a client presenting the certificate to a server using the TLS config,
+// and returns the state of the client connection.
+func handshake(t *testing.T, serverCfg *tls.Config, client testCertificate, rootCAs *x509.CertPool) (tls.ConnectionState, error) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	clientState := make(chan tls.ConnectionState, 1)
+	go func() {
+		c, dialErr := tls.Dial("tcp", ln.Addr().String(), &tls.Config{
+			RootCAs:      rootCAs,
+			ServerName:   "server",
+			Certificates: []tls.Certificate{client.tlsCertificate()},
+			MinVersion:   tls.VersionTLS12,
+		})
+		if dialErr != nil {
+			clientState <- tls.ConnectionState{}
+			return
+		}
+		defer c.Close()
+		clientState <- c.ConnectionState()
+		// Read the alerts sent by the server after the handshake of the client completed.
+		_, _ = io.Copy(io.Discard, c)
+	}()
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	err = tls.Server(conn, serverCfg).Handshake()
+	return <-clientState, err
+}
+
+func newRevocationTestServerConfig(t *testing.T, ca testCertificate) (ServerConfig, *x509.CertPool) {
+	dir := t.TempDir()
+	server := ca.issue(t, 100, "server", "")
+	certFile, keyFile := server.writeFiles(t, dir, "server")
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return ServerConfig{
+		Config: Config{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+		ClientCAFile: caFile,
+	}, pool
+}
+
+func TestRevocationCRL(t *testing.T) {
+	ca := newTestCA(t)
+	cfg, pool := newRevocationTestServerConfig(t, ca)
+	good := ca.issue(t, 2, "good", "")
+	revoked := ca.issue(t, 3, "revoked", "")
+	cfg.CRLFile = filepath.Join(t.TempDir(), "ca.crl")
+	ca.writeCRL(t, cfg.CRLFile, 1, revoked.cert)
+
+	tlsCfg, err := cfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+
+	_, err = handshake(t, tlsCfg, good, pool)
+	assert.NoError(t, err)
+	_, err = handshake(t, tlsCfg, revoked, pool)
+	assert.ErrorContains(t, err, "certificate CN=revoked was revoked")
+}
+
+func TestRevocationCRLReload(t *testing.T) {
+	ca := newTestCA(t)
+	cfg, pool := newRevocationTestServerConfig(t, ca)
+	client := ca.issue(t, 2, "client", "")
+	cfg.CRLFile = filepath.Join(t.TempDir(), "ca.crl")
+	ca.writeCRL(t, cfg.CRLFile, 1)
+	cfg.ReloadInterval = 10 * time.Millisecond
+
+	tlsCfg, err := cfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	_, err = handshake(t, tlsCfg, client, pool)
+	require.NoError(t, err)
+
+	ca.writeCRL(t, cfg.CRLFile, 2, client.cert)
+	assert.Eventually(t, func() bool {
+		_, err = handshake(t, tlsCfg, client, pool)
+		return err != nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.ErrorContains(t, err, "certificate CN=client was revoked")
+}
+
+func TestRevocationCRLFromAnotherIssuer(t *testing.T) {
+	ca := newTestCA(t)
+	other := newTestCA(t)
+	client := ca.issue(t, 2, "client", "")
+	file := filepath.Join(t.TempDir(), "other.crl")
+	// The other CA revokes a certificate with the same serial number.
+	other.writeCRL(t, file, 1, client.cert)
+
+	checker, err := newRevocationChecker(file, 0, nil)
+	require.NoError(t, err)
+	assert.NoError(t, checker.verifyPeerCertificate(nil, [][]*x509.Certificate{{client.cert, ca.cert}}))
+}
+
+func TestLoadRevocationListsDER(t *testing.T) {
+	ca := newTestCA(t)
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{Number: big.NewInt(1)}, ca.cert, ca.key)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "ca.crl")
+	require.NoError(t, os.WriteFile(file, der, 0600))
+
+	crls, err := loadRevocationLists(file)
+	require.NoError(t, err)
+	assert.Len(t, crls, 1)
+}
+
+func TestLoadRevocationListsErrors(t *testing.T) {
+	_, err := loadRevocationLists(filepath.Join("testdata", "does-not-exist.crl"))
+	assert.ErrorContains(t, err, "failed to load the certificate revocation lists")
+
+	_, err = loadRevocationLists(filepath.Join("testdata", "ca-1.crt"))
+	assert.ErrorIs(t, err, errNoRevocationList)
+
+	file := filepath.Join(t.TempDir(), "invalid.crl")
+	require.NoError(t, os.WriteFile(file, []byte("invalid"), 0600))
+	_, err = loadRevocationLists(file)
+	assert.ErrorContains(t, err, "failed to parse the certificate revocation list")
+}
+
+func TestLoadTLSServerConfigRevocationErrors(t *testing.T) {
+	cfg := ServerConfig{CRLFile: filepath.Join("testdata", "ca.crl")}
+	_, err := cfg.LoadTLSConfig(context.Background())
+	assert.ErrorContains(t, err, "requires client_ca_file")
+
+	cfg = ServerConfig{ClientCAFile: filepath.Join("testdata", "ca-1.crt"), CRLFile: filepath.Join("testdata", "does-not-exist.crl")}
+	_, err = cfg.LoadTLSConfig(context.Background())
+	assert.ErrorContains(t, err, "failed to load the certificate revocation lists")
+
+	cfg = ServerConfig{OCSP: &OCSPConfig{StapleFile: filepath.Join("testdata", "does-not-exist.der")}}
+	_, err = cfg.LoadTLSConfig(context.Background())
+	assert.ErrorContains(t, err, "requires a server certificate")
+
+	cfg = ServerConfig{
+		Config: Config{CertFile: filepath.Join("testdata", "server-1.crt"), KeyFile: filepath.Join("testdata", "server-1.key")},
+		OCSP:   &OCSPConfig{StapleFile: filepath.Join("testdata", "does-not-exist.der")},
+	}
+	_, err = cfg.LoadTLSConfig(context.Background())
+	assert.ErrorContains(t, err, "failed to load the OCSP staple")
+}
+
+// newOCSPResponder returns a responder reporting the given status for all the certificates.
+func newOCSPResponder(t *testing.T, ca testCertificate, status *atomic.Int64, requests *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       int(status.Load()),
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, ca.key)
+		require.NoError(t, err)
+		_, _ = w.Write(resp)
+	}))
+}
+
+func TestRevocationOCSP(t *testing.T) {
+	ca := newTestCA(t)
+	var status, requests atomic.Int64
+	responder := newOCSPResponder(t, ca, &status, &requests)
+	defer responder.Close()
+
+	cfg, pool := newRevocationTestServerConfig(t, ca)
+	cfg.OCSP = &OCSPConfig{VerifyClientCertificates: true}
+	tlsCfg, err := cfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+
+	good := ca.issue(t, 2, "good", responder.URL)
+	status.Store(ocsp.Good)
+	_, err = handshake(t, tlsCfg, good, pool)
+	require.NoError(t, err)
+	// The response is cached until its next update.
+	_, err = handshake(t, tlsCfg, good, pool)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), requests.Load())
+
+	revoked := ca.issue(t, 3, "revoked", responder.URL)
+	status.Store(ocsp.Revoked)
+	_, err = handshake(t, tlsCfg, revoked, pool)
+	assert.ErrorContains(t, err, "certificate CN=revoked was revoked")
+
+	unknown := ca.issue(t, 4, "unknown", responder.URL)
+	status.Store(ocsp.Unknown)
+	_, err = handshake(t, tlsCfg, unknown, pool)
+	assert.ErrorIs(t, err, errUnknownOCSPStatus)
+}
+
+func TestRevocationOCSPResponderURL(t *testing.T) {
+	ca := newTestCA(t)
+	var status, requests atomic.Int64
+	status.Store(ocsp.Revoked)
+	responder := newOCSPResponder(t, ca, &status, &requests)
+	defer responder.Close()
+
+	checker, err := newRevocationChecker("", 0, &OCSPConfig{VerifyClientCertificates: true, ResponderURL: responder.URL})
+	require.NoError(t, err)
+	client := ca.issue(t, 2, "client", "")
+	err = checker.verifyPeerCertificate(nil, [][]*x509.Certificate{{client.cert, ca.cert}})
+	assert.ErrorContains(t, err, "certificate CN=client was revoked")
+	assert.Equal(t, int64(1), requests.Load())
+}
+
+func TestRevocationOCSPUnavailable(t *testing.T) {
+	ca := newTestCA(t)
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer responder.Close()
+	withResponder := ca.issue(t, 2, "client", responder.URL)
+	withoutResponder := ca.issue(t, 3, "client", "")
+
+	checker, err := newRevocationChecker("", 0, &OCSPConfig{VerifyClientCertificates: true})
+	require.NoError(t, err)
+	err = checker.verifyPeerCertificate(nil, [][]*x509.Certificate{{withResponder.cert, ca.cert}})
+	assert.ErrorContains(t, err, "the OCSP responder returned status 503")
+	err = checker.verifyPeerCertificate(nil, [][]*x509.Certificate{{withoutResponder.cert, ca.cert}})
+	assert.ErrorIs(t, err, errNoOCSPResponder)
+
+	checker, err = newRevocationChecker("", 0, &OCSPConfig{VerifyClientCertificates: true, FailOpen: true})
+	require.NoError(t, err)
+	assert.NoError(t, checker.verifyPeerCertificate(nil, [][]*x509.Certificate{{withResponder.cert, ca.cert}}))
+	assert.NoError(t, checker.verifyPeerCertificate(nil, [][]*x509.Certificate{{withoutResponder.cert, ca.cert}}))
+}
+
+func TestOCSPStapling(t *testing.T) {
+	ca := newTestCA(t)
+	cfg, pool := newRevocationTestServerConfig(t, ca)
+	cfg.ClientCAFile = ""
+	cfg.ReloadInterval = 10 * time.Millisecond
+	cfg.OCSP = &OCSPConfig{StapleFile: filepath.Join(t.TempDir(), "server.ocsp")}
+	require.NoError(t, os.WriteFile(cfg.OCSP.StapleFile, []byte("staple-1"), 0600))
+
+	tlsCfg, err := cfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	client := ca.issue(t, 2, "client", "")
+	state, err := handshake(t, tlsCfg, client, pool)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("staple-1"), state.OCSPResponse)
+
+	require.NoError(t, os.WriteFile(cfg.OCSP.StapleFile, []byte("staple-2"), 0600))
+	assert.Eventually(t, func() bool {
+		state, err = handshake(t, tlsCfg, client, pool)
+		return err == nil && string(state.OCSPResponse) == "staple-2"
+	}, 5*time.Second, 20*time.Millisecond)
+}
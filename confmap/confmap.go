@@ -22,6 +22,10 @@ import (
 const (
 	// KeyDelimiter is used as the default key delimiter in the default koanf instance.
 	KeyDelimiter = "::"
+
+	// AppendSuffix is the suffix of the keys whose list value is appended, when merged,
+	// to the list of the key without the suffix, instead of replacing it.
+	AppendSuffix = "+"
 )
 
 // New creates a new empty confmap.Conf instance.
@@ -46,6 +50,8 @@ type Conf struct {
 	// This avoids running into an infinite recursion where Unmarshaler.Unmarshal and
 	// Conf.Unmarshal would call each other.
 	skipTopLevelUnmarshaler bool
+	// provenance records where the values come from, it may be nil.
+	provenance *Provenance
 }
 
 // AllKeys returns all keys holding a value, regardless of where they are set.
@@ -143,9 +149,72 @@ func (l *Conf) IsSet(key string) bool {
 }
 
 // Merge merges the input given configuration into the existing config.
+// The lists of the keys with the AppendSuffix in the input are appended to the lists
+// of the keys without the suffix, other values replace the existing ones.
 // Note that the given map may be modified.
 func (l *Conf) Merge(in *Conf) error {
-	return l.k.Merge(in.k)
+	appends, err := l.resolveAppends(in)
+	if err != nil {
+		return err
+	}
+	if err = l.k.Merge(in.k); err != nil {
+		return err
+	}
+	for key, list := range appends {
+		if err = l.k.Set(key, list); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveAppends removes the keys with the AppendSuffix from the input, and returns
+// the lists resulting from their merge by key without the suffix.
+func (l *Conf) resolveAppends(in *Conf) (map[string][]any, error) {
+	var appends map[string][]any
+	for _, key := range in.k.Keys() {
+		if !strings.HasSuffix(key, AppendSuffix) {
+			continue
+		}
+		target := strings.TrimSuffix(key, AppendSuffix)
+		val := in.k.Get(key)
+		in.k.Delete(key)
+		if val == nil {
+			continue
+		}
+		list, ok := toList(val)
+		if !ok {
+			return nil, fmt.Errorf("cannot append to %q: %q must be a list, got %T", target, key, val)
+		}
+		// The lists are appended to the value of the input if set, since it replaces the existing one.
+		base := l.k.Get(target)
+		if in.k.Exists(target) {
+			base = in.k.Get(target)
+			in.k.Delete(target)
+		}
+		merged, ok := toList(base)
+		if !ok && base != nil {
+			return nil, fmt.Errorf("cannot append to %q: it is not a list, got %T", target, base)
+		}
+		if appends == nil {
+			appends = make(map[string][]any)
+		}
+		appends[target] = append(merged, list...)
+	}
+	return appends, nil
+}
+
+// toList returns a copy of a slice of any type as a []any.
+func toList(val any) ([]any, bool) {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	list := make([]any, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+	return list, true
 }
 
 // Sub returns new Conf instance representing a sub-config of this instance.
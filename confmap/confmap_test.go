@@ -309,6 +309,71 @@ func TestMapKeyStringToMapKeyTextUnmarshalerHookFuncErrorUnmarshal(t *testing.T)
 	assert.Error(t, conf.Unmarshal(cfg))
 }
 
+func TestMergeAppend(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     map[string]any
+		in       map[string]any
+		expected map[string]any
+		err      string
+	}{
+		{
+			name:     "replace",
+			base:     map[string]any{"p": map[string]any{"exporters": []any{"a"}}},
+			in:       map[string]any{"p": map[string]any{"exporters": []any{"b"}}},
+			expected: map[string]any{"p": map[string]any{"exporters": []any{"b"}}},
+		},
+		{
+			name:     "append",
+			base:     map[string]any{"p": map[string]any{"exporters": []any{"a"}, "processors": []any{"batch"}}},
+			in:       map[string]any{"p": map[string]any{"exporters+": []any{"b", "c"}}},
+			expected: map[string]any{"p": map[string]any{"exporters": []any{"a", "b", "c"}, "processors": []any{"batch"}}},
+		},
+		{
+			name:     "append to missing",
+			base:     map[string]any{},
+			in:       map[string]any{"p": map[string]any{"exporters+": []string{"b"}}},
+			expected: map[string]any{"p": map[string]any{"exporters": []any{"b"}}},
+		},
+		{
+			name:     "append to replaced",
+			base:     map[string]any{"exporters": []any{"a"}},
+			in:       map[string]any{"exporters": []any{"b"}, "exporters+": []any{"c"}},
+			expected: map[string]any{"exporters": []any{"b", "c"}},
+		},
+		{
+			name:     "append nothing",
+			base:     map[string]any{"exporters": []any{"a"}},
+			in:       map[string]any{"exporters+": nil},
+			expected: map[string]any{"exporters": []any{"a"}},
+		},
+		{
+			name: "append non list",
+			base: map[string]any{"exporters": []any{"a"}},
+			in:   map[string]any{"exporters+": "b"},
+			err:  `cannot append to "exporters": "exporters+" must be a list, got string`,
+		},
+		{
+			name: "append to non list",
+			base: map[string]any{"exporters": "a"},
+			in:   map[string]any{"exporters+": []any{"b"}},
+			err:  `cannot append to "exporters": it is not a list, got string`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := NewFromStringMap(tt.base)
+			err := conf.Merge(NewFromStringMap(tt.in))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conf.ToStringMap())
+		})
+	}
+}
+
 func TestMarshal(t *testing.T) {
 	conf := New()
 	cfg := &TestIDConfig{
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactconverter // import "go.opentelemetry.io/collector/confmap/converter/redactconverter"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// RedactedValue is the value replacing the values of the sensitive keys.
+// It is the value the configopaque.String fields are marshaled as.
+const RedactedValue = "[REDACTED]"
+
+var (
+	mu            sync.RWMutex
+	sensitiveKeys []*regexp.Regexp
+)
+
+// RegisterSensitiveKeys registers regular expressions matching the names of the keys whose values are
+// sensitive, in addition to the configopaque.String fields which are always redacted. The expressions are
+// matched case-insensitively against the name of each key, at any depth, e.g. "^api_?key$" or "password".
+// Components typically call it from the init function of their package.
+func RegisterSensitiveKeys(patterns ...string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return fmt.Errorf("invalid sensitive key pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	sensitiveKeys = append(sensitiveKeys, compiled...)
+	return nil
+}
+
+// MustRegisterSensitiveKeys is like RegisterSensitiveKeys but panics if a pattern is invalid.
+func MustRegisterSensitiveKeys(patterns ...string) {
+	if err := RegisterSensitiveKeys(patterns...); err != nil {
+		panic(err)
+	}
+}
+
+type converter struct{}
+
+// NewFactory returns a factory for a confmap.Converter, which redacts the values of the sensitive keys
+// of a given confmap.Conf. It is meant to be applied to the effective configuration before it is emitted,
+// and not to the configuration the components are created from.
+func NewFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(newConverter)
+}
+
+func newConverter(confmap.ConverterSettings) confmap.Converter {
+	return converter{}
+}
+
+func (converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	mu.RLock()
+	patterns := sensitiveKeys
+	mu.RUnlock()
+	if len(patterns) == 0 {
+		return nil
+	}
+	out := redactMap(conf.ToStringMap(), patterns)
+	return conf.Merge(confmap.NewFromStringMap(out))
+}
+
+func redactMap(m map[string]any, patterns []*regexp.Regexp) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if v != nil && isSensitive(k, patterns) {
+			out[k] = RedactedValue
+			continue
+		}
+		out[k] = redactValue(v, patterns)
+	}
+	return out
+}
+
+func redactValue(value any, patterns []*regexp.Regexp) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return redactMap(v, patterns)
+	case []any:
+		out := make([]any, len(v))
+		for i, el := range v {
+			out[i] = redactValue(el, patterns)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitive(key string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func resetSensitiveKeys(t *testing.T) {
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		sensitiveKeys = nil
+	})
+}
+
+func convert(t *testing.T, in map[string]any) map[string]any {
+	conf := confmap.NewFromStringMap(in)
+	require.NoError(t, NewFactory().Create(confmap.ConverterSettings{}).Convert(context.Background(), conf))
+	return conf.ToStringMap()
+}
+
+func TestConvertWithoutSensitiveKeys(t *testing.T) {
+	in := map[string]any{"exporters": map[string]any{"otlp": map[string]any{"password": "secret"}}}
+	assert.Equal(t, in, convert(t, in))
+}
+
+func TestConvert(t *testing.T) {
+	resetSensitiveKeys(t)
+	require.NoError(t, RegisterSensitiveKeys("password", "^api_?key$"))
+
+	out := convert(t, map[string]any{
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "localhost:4317",
+				"headers":  map[string]any{"API-Key": "secret", "x-tenant": "tenant"},
+				"auth":     map[string]any{"db_password": "secret", "password_file": nil},
+				"clients":  []any{map[string]any{"apikey": "secret", "name": "client"}},
+			},
+			"debug": map[string]any{"Password": map[string]any{"nested": "secret"}},
+		},
+	})
+	assert.Equal(t, map[string]any{
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "localhost:4317",
+				"headers":  map[string]any{"API-Key": "secret", "x-tenant": "tenant"},
+				"auth":     map[string]any{"db_password": RedactedValue, "password_file": nil},
+				"clients":  []any{map[string]any{"apikey": RedactedValue, "name": "client"}},
+			},
+			"debug": map[string]any{"Password": RedactedValue},
+		},
+	}, out)
+}
+
+func TestRegisterSensitiveKeysInvalidPattern(t *testing.T) {
+	resetSensitiveKeys(t)
+	require.EqualError(t, RegisterSensitiveKeys("token", "(("), "invalid sensitive key pattern \"((\": error parsing regexp: missing closing ): `(?i)((`")
+	assert.Panics(t, func() { MustRegisterSensitiveKeys("((") })
+	// No pattern is registered when one of them is invalid.
+	assert.Empty(t, sensitiveKeys)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -77,26 +78,43 @@ func (mr *Resolver) expandValue(ctx context.Context, value any) (any, bool, erro
 	case []any:
 		nslice := make([]any, 0, len(v))
 		nchanged := false
+		// Keep expanding after a failure so all the errors are reported at once, e.g. all unset environment variables.
+		var errs error
 		for _, vint := range v {
 			val, changed, err := mr.expandValue(ctx, vint)
 			if err != nil {
-				return nil, false, err
+				errs = errors.Join(errs, err)
+				continue
 			}
 			nslice = append(nslice, val)
 			nchanged = nchanged || changed
 		}
+		if errs != nil {
+			return nil, false, errs
+		}
 		return nslice, nchanged, nil
 	case map[string]any:
 		nmap := map[string]any{}
 		nchanged := false
-		for mk, mv := range v {
-			val, changed, err := mr.expandValue(ctx, mv)
+		// Expand keys in a deterministic order so that reported errors are stable.
+		keys := make([]string, 0, len(v))
+		for mk := range v {
+			keys = append(keys, mk)
+		}
+		sort.Strings(keys)
+		var errs error
+		for _, mk := range keys {
+			val, changed, err := mr.expandValue(ctx, v[mk])
 			if err != nil {
-				return nil, false, err
+				errs = errors.Join(errs, err)
+				continue
 			}
 			nmap[mk] = val
 			nchanged = nchanged || changed
 		}
+		if errs != nil {
+			return nil, false, errs
+		}
 		return nmap, nchanged, nil
 	}
 	return value, false, nil
@@ -216,6 +234,7 @@ func (mr *Resolver) expandURI(ctx context.Context, input string) (*Retrieved, er
 		return nil, err
 	}
 	mr.closers = append(mr.closers, ret.Close)
+	mr.recorder.recordURI(lURI.asString())
 	return ret, nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 func Test_EscapedEnvVars_NoDefaultScheme(t *testing.T) {
@@ -99,3 +100,46 @@ func Test_EscapedEnvVars_DefaultScheme(t *testing.T) {
 	m := cfgMap.ToStringMap()
 	assert.Equal(t, expectedMap, m)
 }
+
+func Test_EnvVarDefaultValues(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+	t.Setenv("TENANT", "tenant")
+
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:              []string{filepath.Join("testdata", "expand-defaults-env.yaml")},
+		ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory(), envprovider.NewFactory()},
+		DefaultScheme:     "env",
+	})
+	require.NoError(t, err)
+
+	cfgMap, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "localhost:4317",
+				"headers": map[string]any{
+					"api-key": "key",
+					"tenant":  "tenant",
+				},
+			},
+		},
+	}, cfgMap.ToStringMap())
+}
+
+func Test_StrictEnvVars_ReportsAllUnset(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set("confmap.envprovider.strict", true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set("confmap.envprovider.strict", false))
+	}()
+
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:              []string{filepath.Join("testdata", "expand-defaults-env.yaml")},
+		ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory(), envprovider.NewFactory()},
+		DefaultScheme:     "env",
+	})
+	require.NoError(t, err)
+
+	_, err = resolver.Resolve(context.Background())
+	require.EqualError(t, err, "environment variable \"API_KEY\" is not set\nenvironment variable \"TENANT\" is not set")
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MemoryProvider is a Provider serving configurations held in memory, allowing applications
+// embedding a Resolver to provide configurations programmatically.
+//
+// Each configuration is identified by a name and retrieved with the "<scheme>:<name>" URI.
+// Updating a configuration with Set notifies the watcher of its last retrieval, so the Resolver
+// reports a change event and the configuration can be resolved again.
+type MemoryProvider struct {
+	scheme string
+
+	mu       sync.Mutex
+	confs    map[string]any
+	watchers map[string]WatcherFunc
+}
+
+var _ Provider = (*MemoryProvider)(nil)
+
+// NewMemoryProvider returns a new MemoryProvider for the given scheme, e.g. "memory".
+func NewMemoryProvider(scheme string) *MemoryProvider {
+	return &MemoryProvider{
+		scheme:   scheme,
+		confs:    make(map[string]any),
+		watchers: make(map[string]WatcherFunc),
+	}
+}
+
+// Factory returns a ProviderFactory always returning this MemoryProvider, to be used in ResolverSettings.
+func (mp *MemoryProvider) Factory() ProviderFactory {
+	return NewProviderFactory(func(ProviderSettings) Provider {
+		return mp
+	})
+}
+
+// Set stores the configuration with the given name, replacing the previous one if any,
+// and notifies the watcher of the last retrieval of this configuration.
+// The configuration can be any value supported by NewRetrieved, e.g. a map[string]any or a scalar
+// value to be used in ${<scheme>:<name>} expansions.
+func (mp *MemoryProvider) Set(name string, rawConf any) error {
+	if err := checkRawConfType(rawConf); err != nil {
+		return err
+	}
+	if m, ok := rawConf.(map[string]any); ok {
+		rawConf = NewFromStringMap(m).ToStringMap()
+	}
+
+	mp.mu.Lock()
+	mp.confs[name] = rawConf
+	watcher := mp.watchers[name]
+	delete(mp.watchers, name)
+	mp.mu.Unlock()
+
+	if watcher != nil {
+		watcher(&ChangeEvent{})
+	}
+	return nil
+}
+
+// Retrieve implements Provider.
+func (mp *MemoryProvider) Retrieve(_ context.Context, uri string, watcher WatcherFunc) (*Retrieved, error) {
+	if !strings.HasPrefix(uri, mp.scheme+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, mp.scheme)
+	}
+	name := uri[len(mp.scheme)+1:]
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	conf, ok := mp.confs[name]
+	if !ok {
+		return nil, fmt.Errorf("configuration %q not found", name)
+	}
+	if watcher == nil {
+		return NewRetrieved(conf)
+	}
+	mp.watchers[name] = watcher
+	return NewRetrieved(conf, WithRetrievedClose(func(context.Context) error {
+		mp.mu.Lock()
+		defer mp.mu.Unlock()
+		delete(mp.watchers, name)
+		return nil
+	}))
+}
+
+// Scheme implements Provider.
+func (mp *MemoryProvider) Scheme() string {
+	return mp.scheme
+}
+
+// Shutdown implements Provider. The stored configurations are kept, only the watchers are removed.
+func (mp *MemoryProvider) Shutdown(context.Context) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	clear(mp.watchers)
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryProvider(t *testing.T) {
+	mp := NewMemoryProvider("memory")
+	assert.Equal(t, "memory", mp.Scheme())
+
+	_, err := mp.Retrieve(context.Background(), "file:config", nil)
+	assert.EqualError(t, err, `"file:config" uri is not supported by "memory" provider`)
+	_, err = mp.Retrieve(context.Background(), "memory:config", nil)
+	assert.EqualError(t, err, `configuration "config" not found`)
+
+	assert.EqualError(t, mp.Set("config", struct{}{}), "unsupported type=struct {} for retrieved config, ensure that values are wrapped in quotes")
+	conf := map[string]any{"key": "value"}
+	require.NoError(t, mp.Set("config", conf))
+	// The stored configuration is a copy.
+	conf["key"] = "changed"
+
+	var events []*ChangeEvent
+	ret, err := mp.Retrieve(context.Background(), "memory:config", func(event *ChangeEvent) { events = append(events, event) })
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, raw)
+
+	require.NoError(t, mp.Set("other", map[string]any{}))
+	assert.Empty(t, events)
+	require.NoError(t, mp.Set("config", map[string]any{"key": "new"}))
+	// The watcher is only notified once per retrieval.
+	require.NoError(t, mp.Set("config", map[string]any{"key": "newer"}))
+	assert.Len(t, events, 1)
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, mp.Shutdown(context.Background()))
+}
+
+func TestMemoryProviderResolver(t *testing.T) {
+	mp := NewMemoryProvider("memory")
+	require.NoError(t, mp.Set("base", map[string]any{"receivers": map[string]any{"nop": nil}, "level": "${memory:level}"}))
+	require.NoError(t, mp.Set("level", "basic"))
+
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"memory:base"},
+		ProviderFactories: []ProviderFactory{mp.Factory()},
+	})
+	require.NoError(t, err)
+
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "basic", conf.Get("level"))
+
+	require.NoError(t, mp.Set("base", map[string]any{"level": "detailed"}))
+	require.NoError(t, <-resolver.Watch())
+	conf, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"level": "detailed"}, conf.ToStringMap())
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
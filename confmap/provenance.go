@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"slices"
+	"strings"
+)
+
+// Provenance records where the values of a resolved configuration come from.
+// It is immutable, and safe for concurrent use.
+type Provenance struct {
+	sources map[string][]string
+}
+
+// Sources returns the URIs the value of the key comes from: the URI of the configuration
+// setting it, followed by the URIs expanded in the value, e.g. ["file:config.yaml", "env:ENDPOINT"].
+// The keys set by the expansion of a URI to a map, e.g. `key: ${file:extra.yaml}`, come from the
+// URIs of the enclosing key. It returns nil if the value was not set by the resolved configuration,
+// e.g. a default value of a component or a value added by a Converter.
+func (p *Provenance) Sources(key string) []string {
+	if p == nil {
+		return nil
+	}
+	return slices.Clone(p.sources[key])
+}
+
+// provenanceRecorder records the sources of the values while a configuration is resolved.
+type provenanceRecorder struct {
+	sources map[string][]string
+	// expanded holds the URIs expanded in the value being expanded.
+	expanded []string
+}
+
+func newProvenanceRecorder() *provenanceRecorder {
+	return &provenanceRecorder{sources: make(map[string][]string)}
+}
+
+// recordRetrieved records the uri as the source of the values of the retrieved configuration,
+// before it is merged. The lists appended to a key are added to its sources.
+func (r *provenanceRecorder) recordRetrieved(uri string, conf *Conf) {
+	for _, key := range conf.AllKeys() {
+		if target, ok := strings.CutSuffix(key, AppendSuffix); ok {
+			r.sources[target] = append(slices.Clip(r.sources[target]), uri)
+			continue
+		}
+		r.sources[key] = []string{uri}
+	}
+}
+
+// recordExpanded records the URIs expanded in the value of the key as its sources, and the ones
+// of the key as the sources of the keys of the value if it was expanded to a map.
+func (r *provenanceRecorder) recordExpanded(key string, val any) {
+	if len(r.expanded) == 0 {
+		return
+	}
+	sources := append(slices.Clip(r.sources[key]), r.expanded...)
+	r.expanded = nil
+	r.sources[key] = sources
+	if exp, ok := val.(expandedValue); ok {
+		val = exp.Value
+	}
+	if m, ok := val.(map[string]any); ok {
+		r.recordMap(key, m, sources)
+	}
+}
+
+func (r *provenanceRecorder) recordMap(prefix string, m map[string]any, sources []string) {
+	for k, v := range m {
+		key := prefix + KeyDelimiter + k
+		if sub, ok := v.(map[string]any); ok && len(sub) > 0 {
+			r.recordMap(key, sub, sources)
+			continue
+		}
+		r.sources[key] = sources
+	}
+}
+
+// recordURI records a URI expanded in the value being expanded.
+func (r *provenanceRecorder) recordURI(uri string) {
+	if r != nil && !slices.Contains(r.expanded, uri) {
+		r.expanded = append(r.expanded, uri)
+	}
+}
+
+func (r *provenanceRecorder) provenance() *Provenance {
+	return &Provenance{sources: r.sources}
+}
+
+// Provenance returns where the values of the Conf come from, if the Conf was returned by
+// Resolver.Resolve or was given a Provenance with SetProvenance, nil otherwise.
+// The Conf derived from this one, e.g. by Sub, have no Provenance.
+func (l *Conf) Provenance() *Provenance {
+	return l.provenance
+}
+
+// SetProvenance sets where the values of the Conf come from, e.g. to annotate a Conf
+// built from the values of a resolved configuration.
+func (l *Conf) SetProvenance(p *Provenance) {
+	l.provenance = p
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverProvenance(t *testing.T) {
+	files := map[string]map[string]any{
+		"base.yaml": {
+			"receivers": map[string]any{
+				"otlp": map[string]any{
+					"endpoint": "${env:ENDPOINT}",
+					"headers":  []any{"a"},
+				},
+			},
+			"exporters": "${file:exporters.yaml}",
+			"name":      "collector",
+		},
+		"override.yaml": {
+			"name": "override-${env:SUFFIX}",
+			"receivers": map[string]any{
+				"otlp": map[string]any{
+					"headers" + AppendSuffix: []any{"b"},
+				},
+			},
+		},
+		"exporters.yaml": {
+			"debug": map[string]any{
+				"verbosity": "detailed",
+			},
+		},
+	}
+	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(files[uri[len("file:"):]])
+	})
+	envProvider := newFakeProvider("env", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(uri[len("env:"):] + "-value")
+	})
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"file:base.yaml", "file:override.yaml"},
+		ProviderFactories: []ProviderFactory{fileProvider, envProvider},
+	})
+	require.NoError(t, err)
+
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "override-SUFFIX-value", conf.Get("name"))
+
+	p := conf.Provenance()
+	require.NotNil(t, p)
+	assert.Equal(t, []string{"file:base.yaml", "env:ENDPOINT"}, p.Sources("receivers::otlp::endpoint"))
+	assert.Equal(t, []string{"file:base.yaml", "file:override.yaml"}, p.Sources("receivers::otlp::headers"))
+	assert.Equal(t, []string{"file:override.yaml", "env:SUFFIX"}, p.Sources("name"))
+	assert.Equal(t, []string{"file:base.yaml", "file:exporters.yaml"}, p.Sources("exporters::debug::verbosity"))
+	assert.Nil(t, p.Sources("exporters::debug::sampling"))
+
+	// A Conf built from the resolved values has no Provenance, until it is given one.
+	other := NewFromStringMap(conf.ToStringMap())
+	assert.Nil(t, other.Provenance())
+	assert.Nil(t, other.Provenance().Sources("name"))
+	other.SetProvenance(p)
+	assert.Equal(t, []string{"file:override.yaml", "env:SUFFIX"}, other.Provenance().Sources("name"))
+}
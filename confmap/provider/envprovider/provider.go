@@ -13,12 +13,24 @@ import (
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/internal/envvar"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 const (
 	schemeName = "env"
+
+	// defaultValueSeparator separates the name of the environment variable from its default value.
+	defaultValueSeparator = ":-"
 )
 
+var strictFeatureGate = featuregate.GlobalRegistry().MustRegister("confmap.envprovider.strict",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.108.0"),
+	featuregate.WithRegisterDescription("When enabled, referencing an unset environment variable without a default value "+
+		"fails the configuration resolution instead of expanding to an empty string."),
+	// The gate is checked on every resolution, so a change applies from the next configuration reload.
+	featuregate.WithRegisterDynamic())
+
 type provider struct {
 	logger *zap.Logger
 }
@@ -27,6 +39,12 @@ type provider struct {
 //
 // This Provider supports "env" scheme, and can be called with a selector:
 // `env:NAME_OF_ENVIRONMENT_VARIABLE`
+//
+// A default value, used when the environment variable is unset or empty, can be provided with:
+// `env:NAME_OF_ENVIRONMENT_VARIABLE:-default value`
+//
+// When the `confmap.envprovider.strict` feature gate is enabled, referencing an unset environment
+// variable without a default value returns an error.
 func NewFactory() confmap.ProviderFactory {
 	return confmap.NewProviderFactory(newProvider)
 }
@@ -41,15 +59,20 @@ func (emp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 	if !strings.HasPrefix(uri, schemeName+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
-	envVarName := uri[len(schemeName)+1:]
+	envVarName, defaultValue, hasDefault := strings.Cut(uri[len(schemeName)+1:], defaultValueSeparator)
 	if !envvar.ValidationRegexp.MatchString(envVarName) {
 		return nil, fmt.Errorf("environment variable %q has invalid name: must match regex %s", envVarName, envvar.ValidationPattern)
 
 	}
 	val, exists := os.LookupEnv(envVarName)
-	if !exists {
+	switch {
+	case hasDefault && len(val) == 0:
+		val = defaultValue
+	case !exists && strictFeatureGate.IsEnabled():
+		return nil, &UnsetEnvVarError{Name: envVarName}
+	case !exists:
 		emp.logger.Warn("Configuration references unset environment variable", zap.String("name", envVarName))
-	} else if len(val) == 0 {
+	case len(val) == 0:
 		emp.logger.Info("Configuration references empty environment variable", zap.String("name", envVarName))
 	}
 
@@ -63,3 +86,14 @@ func (*provider) Scheme() string {
 func (*provider) Shutdown(context.Context) error {
 	return nil
 }
+
+// UnsetEnvVarError is returned when the configuration references an unset environment variable
+// without a default value, and the `confmap.envprovider.strict` feature gate is enabled.
+type UnsetEnvVarError struct {
+	// Name is the name of the unset environment variable.
+	Name string
+}
+
+func (e *UnsetEnvVarError) Error() string {
+	return fmt.Sprintf("environment variable %q is not set", e.Name)
+}
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/internal/envvar"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 const envSchemePrefix = schemeName + ":"
@@ -154,6 +155,55 @@ func TestEmptyEnvWithLoggerWarn(t *testing.T) {
 	assert.Equal(t, envName, logLine.Context[0].String)
 }
 
+func TestEnvDefaultValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *string
+		expected any
+	}{
+		{name: "unset", expected: "localhost:4317"},
+		{name: "empty", value: new(string), expected: "localhost:4317"},
+		{name: "set", value: func() *string { s := "otherhost:4317"; return &s }(), expected: "otherhost:4317"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const envName = "ENDPOINT"
+			if tt.value != nil {
+				t.Setenv(envName, *tt.value)
+			}
+			env := createProvider()
+			ret, err := env.Retrieve(context.Background(), envSchemePrefix+envName+":-localhost:4317", nil)
+			require.NoError(t, err)
+			raw, err := ret.AsRaw()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, raw)
+			assert.NoError(t, env.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestStrictUnsetEnv(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().SetAtRuntime(strictFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().SetAtRuntime(strictFeatureGate.ID(), false))
+	}()
+
+	env := createProvider()
+	_, err := env.Retrieve(context.Background(), envSchemePrefix+"UNSET_VAR", nil)
+	var unsetErr *UnsetEnvVarError
+	require.ErrorAs(t, err, &unsetErr)
+	assert.Equal(t, "UNSET_VAR", unsetErr.Name)
+	assert.EqualError(t, err, `environment variable "UNSET_VAR" is not set`)
+
+	// A default value or an empty value satisfy the strict mode.
+	_, err = env.Retrieve(context.Background(), envSchemePrefix+"UNSET_VAR:-default", nil)
+	require.NoError(t, err)
+	t.Setenv("EMPTY_VAR", "")
+	_, err = env.Retrieve(context.Background(), envSchemePrefix+"EMPTY_VAR", nil)
+	require.NoError(t, err)
+	assert.NoError(t, env.Shutdown(context.Background()))
+}
+
 func createProvider() confmap.Provider {
 	return NewFactory().Create(confmaptest.NewNopProviderSettings())
 }
@@ -8,7 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -22,13 +25,31 @@ var driverLetterRegexp = regexp.MustCompile("^[A-z]:")
 type Resolver struct {
 	uris          []location
 	providers     map[string]Provider
+	disallowed    map[string]Provider
 	defaultScheme string
 	converters    []Converter
+	interceptor   ConverterInterceptor
 
 	closers []CloseFunc
 	watcher chan error
+
+	// recorder records the sources of the values while a configuration is resolved.
+	recorder *provenanceRecorder
+
+	subscribersMu sync.Mutex
+	subscribers   []*subscriber
 }
 
+type subscriber struct {
+	fn func(*ChangeEvent)
+}
+
+// ConverterInterceptor intercepts the application of each Converter by the Resolver.
+// It receives the Converter about to be applied and the Conf to convert, and must call
+// next to apply the Converter. It can inspect or change the Conf before or after calling next,
+// or skip the Converter by not calling next.
+type ConverterInterceptor func(ctx context.Context, converter Converter, conf *Conf, next func(context.Context, *Conf) error) error
+
 // ResolverSettings are the settings to configure the behavior of the Resolver.
 type ResolverSettings struct {
 	// URIs locations from where the Conf is retrieved, and merged in the given order.
@@ -55,6 +76,20 @@ type ResolverSettings struct {
 	// ConverterSettings contains settings that will be passed to Converter
 	// factories when instantiating Converters.
 	ConverterSettings ConverterSettings
+
+	// ConverterInterceptor, if set, is called for each Converter instead of applying it directly.
+	// It allows embedding applications to observe or alter each conversion step.
+	ConverterInterceptor ConverterInterceptor
+
+	// AllowedSchemes, if set, restricts the schemes of the URIs the configuration is retrieved from and expanded
+	// with, e.g. to forbid fetching configuration from remote locations. The Providers of the other schemes are
+	// not used, the URIs using them are rejected.
+	AllowedSchemes []string
+
+	// SchemePrecedence, if set, orders the URIs by the precedence of their scheme before they are merged:
+	// the values retrieved from the URIs of a scheme override the ones of the schemes listed before it.
+	// The URIs of the schemes not listed are merged first. The URIs of a same scheme are merged in the given order.
+	SchemePrecedence []string
 }
 
 // NewResolver returns a new Resolver that resolves configuration from multiple URIs.
@@ -94,6 +129,7 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 	}
 
 	providers := make(map[string]Provider, len(set.ProviderFactories))
+	disallowed := make(map[string]Provider)
 	for _, factory := range set.ProviderFactories {
 		provider := factory.Create(set.ProviderSettings)
 		scheme := provider.Scheme()
@@ -102,20 +138,45 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 			return nil, fmt.Errorf("invalid 'confmap.Provider' scheme %q", scheme)
 		}
 		// Check that the scheme is unique.
-		if _, ok := providers[scheme]; ok {
+		_, ok := providers[scheme]
+		if _, dup := disallowed[scheme]; ok || dup {
 			return nil, fmt.Errorf("duplicate 'confmap.Provider' scheme %q", scheme)
 		}
 
+		if len(set.AllowedSchemes) > 0 && !slices.Contains(set.AllowedSchemes, scheme) {
+			disallowed[scheme] = provider
+			continue
+		}
 		providers[scheme] = provider
 	}
 
+	for _, scheme := range set.AllowedSchemes {
+		if _, ok := providers[scheme]; !ok {
+			return nil, fmt.Errorf("invalid 'confmap.ResolverSettings' configuration: allowed scheme %q not found in providers list", scheme)
+		}
+	}
+
 	if set.DefaultScheme != "" {
+		if _, ok := disallowed[set.DefaultScheme]; ok {
+			return nil, fmt.Errorf("invalid 'confmap.ResolverSettings' configuration: DefaultScheme %q is not allowed", set.DefaultScheme)
+		}
 		_, ok := providers[set.DefaultScheme]
 		if !ok {
 			return nil, errors.New("invalid 'confmap.ResolverSettings' configuration: DefaultScheme not found in providers list")
 		}
 	}
 
+	precedence := make(map[string]int, len(set.SchemePrecedence))
+	for i, scheme := range set.SchemePrecedence {
+		if _, ok := providers[scheme]; !ok {
+			return nil, fmt.Errorf("invalid 'confmap.ResolverSettings' configuration: SchemePrecedence scheme %q not found in providers list", scheme)
+		}
+		if _, ok := precedence[scheme]; ok {
+			return nil, fmt.Errorf("invalid 'confmap.ResolverSettings' configuration: duplicate SchemePrecedence scheme %q", scheme)
+		}
+		precedence[scheme] = i + 1
+	}
+
 	converters := make([]Converter, len(set.ConverterFactories))
 	for i, factory := range set.ConverterFactories {
 		converters[i] = factory.Create(set.ConverterSettings)
@@ -127,25 +188,33 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		// For backwards compatibility:
 		// - empty url scheme means "file".
 		// - "^[A-z]:" also means "file"
-		if driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
-			uris[i] = location{scheme: "file", opaqueValue: uri}
-			continue
+		implicit := driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":")
+		lURI := location{scheme: "file", opaqueValue: uri}
+		if !implicit {
+			var err error
+			if lURI, err = newLocation(uri); err != nil {
+				return nil, err
+			}
 		}
-		lURI, err := newLocation(uri)
-		if err != nil {
-			return nil, err
+		if _, ok := disallowed[lURI.scheme]; ok {
+			return nil, fmt.Errorf("scheme %q is not allowed on URI %q", lURI.scheme, uri)
 		}
-		if _, ok := providers[lURI.scheme]; !ok {
+		if _, ok := providers[lURI.scheme]; !ok && !implicit {
 			return nil, fmt.Errorf("unsupported scheme on URI %q", uri)
 		}
 		uris[i] = lURI
 	}
+	sort.SliceStable(uris, func(i, j int) bool {
+		return precedence[uris[i].scheme] < precedence[uris[j].scheme]
+	})
 
 	return &Resolver{
 		uris:          uris,
 		providers:     providers,
+		disallowed:    disallowed,
 		defaultScheme: set.DefaultScheme,
 		converters:    converters,
+		interceptor:   set.ConverterInterceptor,
 		watcher:       make(chan error, 1),
 	}, nil
 }
@@ -158,6 +227,9 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		return nil, fmt.Errorf("cannot close previous watch: %w", err)
 	}
 
+	mr.recorder = newProvenanceRecorder()
+	defer func() { mr.recorder = nil }()
+
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
 	for _, uri := range mr.uris {
@@ -170,24 +242,33 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err != nil {
 			return nil, err
 		}
+		mr.recorder.recordRetrieved(uri.asString(), retCfgMap)
 		if err = retMap.Merge(retCfgMap); err != nil {
 			return nil, err
 		}
 	}
 
 	cfgMap := make(map[string]any)
+	// Keep expanding after a failure so all the errors are reported at once, e.g. all unset environment variables.
+	var errs error
 	for _, k := range retMap.AllKeys() {
 		val, err := mr.expandValueRecursively(ctx, retMap.unsanitizedGet(k))
 		if err != nil {
-			return nil, err
+			errs = errors.Join(errs, err)
+			continue
 		}
+		mr.recorder.recordExpanded(k, val)
 		cfgMap[k] = escapeDollarSigns(val)
 	}
+	if errs != nil {
+		return nil, errs
+	}
 	retMap = NewFromStringMap(cfgMap)
+	retMap.provenance = mr.recorder.provenance()
 
 	// Apply the converters in the given order.
 	for _, confConv := range mr.converters {
-		if err := confConv.Convert(ctx, retMap); err != nil {
+		if err := mr.convert(ctx, confConv, retMap); err != nil {
 			return nil, fmt.Errorf("cannot convert the confmap.Conf: %w", err)
 		}
 	}
@@ -195,6 +276,13 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 	return retMap, nil
 }
 
+func (mr *Resolver) convert(ctx context.Context, converter Converter, conf *Conf) error {
+	if mr.interceptor == nil {
+		return converter.Convert(ctx, conf)
+	}
+	return mr.interceptor(ctx, converter, conf, converter.Convert)
+}
+
 func escapeDollarSigns(val any) any {
 	switch v := val.(type) {
 	case string:
@@ -243,11 +331,41 @@ func (mr *Resolver) Shutdown(ctx context.Context) error {
 	for _, p := range mr.providers {
 		errs = multierr.Append(errs, p.Shutdown(ctx))
 	}
+	for _, p := range mr.disallowed {
+		errs = multierr.Append(errs, p.Shutdown(ctx))
+	}
 
 	return errs
 }
 
+// Subscribe registers a function called with every change event reported by the providers,
+// in addition to the events delivered through Watch. Subscribers are called in the order they
+// subscribed, from the goroutine reporting the change, and must not block.
+// The returned function unsubscribes fn, it is safe to call it multiple times.
+func (mr *Resolver) Subscribe(fn func(*ChangeEvent)) (unsubscribe func()) {
+	sub := &subscriber{fn: fn}
+	mr.subscribersMu.Lock()
+	mr.subscribers = append(mr.subscribers, sub)
+	mr.subscribersMu.Unlock()
+	return func() {
+		mr.subscribersMu.Lock()
+		defer mr.subscribersMu.Unlock()
+		for i, s := range mr.subscribers {
+			if s == sub {
+				mr.subscribers = append(mr.subscribers[:i:i], mr.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
 func (mr *Resolver) onChange(event *ChangeEvent) {
+	mr.subscribersMu.Lock()
+	subscribers := mr.subscribers
+	mr.subscribersMu.Unlock()
+	for _, sub := range subscribers {
+		sub.fn(event)
+	}
 	mr.watcher <- event.Error
 }
 
@@ -263,6 +381,9 @@ func (mr *Resolver) closeIfNeeded(ctx context.Context) error {
 func (mr *Resolver) retrieveValue(ctx context.Context, uri location) (*Retrieved, error) {
 	p, ok := mr.providers[uri.scheme]
 	if !ok {
+		if _, disallowed := mr.disallowed[uri.scheme]; disallowed {
+			return nil, fmt.Errorf("scheme %q is not allowed for uri %q", uri.scheme, uri.asString())
+		}
 		return nil, fmt.Errorf("scheme %q is not supported for uri %q", uri.scheme, uri.asString())
 	}
 	return p.Retrieve(ctx, uri.asString(), mr.onChange)
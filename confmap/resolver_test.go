@@ -314,6 +314,26 @@ func TestBackwardsCompatibilityForFilePath(t *testing.T) {
 	}
 }
 
+func TestResolverMergeAppend(t *testing.T) {
+	mp := NewMemoryProvider("memory")
+	require.NoError(t, mp.Set("base", map[string]any{
+		"service": map[string]any{"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"otlp"}}}},
+	}))
+	require.NoError(t, mp.Set("override", map[string]any{
+		"service": map[string]any{"pipelines": map[string]any{"traces": map[string]any{"exporters+": []any{"debug"}}}},
+	}))
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"memory:base", "memory:override"},
+		ProviderFactories: []ProviderFactory{mp.Factory()},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"otlp", "debug"}, conf.Get("service::pipelines::traces::exporters"))
+	assert.False(t, conf.IsSet("service::pipelines::traces::exporters+"))
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
 func TestResolver(t *testing.T) {
 	numCalls := atomic.Int32{}
 	resolver, err := NewResolver(ResolverSettings{
@@ -426,3 +446,149 @@ func TestResolverDefaultProviderSet(t *testing.T) {
 	_, ok := r.providers["env"]
 	assert.True(t, ok)
 }
+
+type setConverter struct {
+	key   string
+	value any
+}
+
+func (c *setConverter) Convert(_ context.Context, conf *Conf) error {
+	return conf.Merge(NewFromStringMap(map[string]any{c.key: c.value}))
+}
+
+func TestResolverConverterInterceptor(t *testing.T) {
+	first := &setConverter{key: "first", value: "set"}
+	second := &setConverter{key: "second", value: "set"}
+	var seen []Converter
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"mock:"},
+		ProviderFactories: []ProviderFactory{newMockProvider(&mockProvider{retM: map[string]any{"key": "value"}})},
+		ConverterFactories: []ConverterFactory{
+			NewConverterFactory(func(ConverterSettings) Converter { return first }),
+			NewConverterFactory(func(ConverterSettings) Converter { return second }),
+		},
+		ConverterInterceptor: func(ctx context.Context, converter Converter, conf *Conf, next func(context.Context, *Conf) error) error {
+			seen = append(seen, converter)
+			// Skip the second converter, and amend the result of the others.
+			if converter == second {
+				return nil
+			}
+			if err := next(ctx, conf); err != nil {
+				return err
+			}
+			return conf.Merge(NewFromStringMap(map[string]any{"intercepted": true}))
+		},
+	})
+	require.NoError(t, err)
+
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Converter{first, second}, seen)
+	assert.Equal(t, map[string]any{"key": "value", "first": "set", "intercepted": true}, conf.ToStringMap())
+}
+
+func TestResolverConverterInterceptorError(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:               []string{"mock:"},
+		ProviderFactories:  []ProviderFactory{newMockProvider(&mockProvider{retM: map[string]any{}})},
+		ConverterFactories: []ConverterFactory{NewConverterFactory(func(ConverterSettings) Converter { return &setConverter{key: "k"} })},
+		ConverterInterceptor: func(context.Context, Converter, *Conf, func(context.Context, *Conf) error) error {
+			return errors.New("interceptor_err")
+		},
+	})
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	assert.EqualError(t, err, "cannot convert the confmap.Conf: interceptor_err")
+}
+
+func TestResolverSubscribe(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"mock:"},
+		ProviderFactories: []ProviderFactory{newMockProvider(&mockProvider{retM: map[string]any{}, errW: errors.New("watch_err")})},
+	})
+	require.NoError(t, err)
+
+	var first, second []error
+	unsubscribeFirst := resolver.Subscribe(func(event *ChangeEvent) { first = append(first, event.Error) })
+	resolver.Subscribe(func(event *ChangeEvent) { second = append(second, event.Error) })
+
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.EqualError(t, <-resolver.Watch(), "watch_err")
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.EqualError(t, <-resolver.Watch(), "watch_err")
+
+	assert.Len(t, first, 1)
+	assert.Len(t, second, 2)
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestResolverAllowedSchemes(t *testing.T) {
+	valueProvider := func(scheme string) ProviderFactory {
+		return newFakeProvider(scheme, func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+			return NewRetrieved(map[string]any{"key": uri})
+		})
+	}
+	factories := []ProviderFactory{valueProvider("mock"), valueProvider("http"), valueProvider("env")}
+
+	_, err := NewResolver(ResolverSettings{URIs: []string{"http:config"}, ProviderFactories: factories, AllowedSchemes: []string{"mock"}})
+	require.EqualError(t, err, `scheme "http" is not allowed on URI "http:config"`)
+
+	_, err = NewResolver(ResolverSettings{URIs: []string{"mock:config"}, ProviderFactories: factories, AllowedSchemes: []string{"mock", "s3"}})
+	require.EqualError(t, err, `invalid 'confmap.ResolverSettings' configuration: allowed scheme "s3" not found in providers list`)
+
+	_, err = NewResolver(ResolverSettings{URIs: []string{"mock:config"}, ProviderFactories: factories, AllowedSchemes: []string{"mock"}, DefaultScheme: "env"})
+	require.EqualError(t, err, `invalid 'confmap.ResolverSettings' configuration: DefaultScheme "env" is not allowed`)
+
+	resolver, err := NewResolver(ResolverSettings{URIs: []string{"mock:config"}, ProviderFactories: factories, AllowedSchemes: []string{"mock"}})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "mock:config"}, conf.ToStringMap())
+
+	// The URIs of the schemes not allowed are not expanded either.
+	resolver, err = NewResolver(ResolverSettings{
+		URIs: []string{"mock:config"},
+		ProviderFactories: []ProviderFactory{
+			newFakeProvider("mock", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{"key": "${http:secret}"})
+			}),
+			valueProvider("http"),
+		},
+		AllowedSchemes: []string{"mock"},
+	})
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	require.ErrorContains(t, err, `scheme "http" is not allowed for uri "http:secret"`)
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestResolverSchemePrecedence(t *testing.T) {
+	valueProvider := func(scheme string) ProviderFactory {
+		return newFakeProvider(scheme, func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+			return NewRetrieved(map[string]any{"key": uri, uri: true})
+		})
+	}
+	factories := []ProviderFactory{valueProvider("mock"), valueProvider("yaml"), valueProvider("other")}
+
+	_, err := NewResolver(ResolverSettings{URIs: []string{"mock:a"}, ProviderFactories: factories, SchemePrecedence: []string{"env"}})
+	require.EqualError(t, err, `invalid 'confmap.ResolverSettings' configuration: SchemePrecedence scheme "env" not found in providers list`)
+	_, err = NewResolver(ResolverSettings{URIs: []string{"mock:a"}, ProviderFactories: factories, SchemePrecedence: []string{"mock", "mock"}})
+	require.EqualError(t, err, `invalid 'confmap.ResolverSettings' configuration: duplicate SchemePrecedence scheme "mock"`)
+
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"yaml:a", "mock:b", "other:c", "mock:d"},
+		ProviderFactories: factories,
+		SchemePrecedence:  []string{"mock", "yaml"},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	// The URIs are merged in the order other:c, mock:b, mock:d, yaml:a.
+	assert.Equal(t, "yaml:a", conf.Get("key"))
+	assert.Len(t, conf.AllKeys(), 5)
+}
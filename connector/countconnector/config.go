@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector // import "go.opentelemetry.io/collector/connector/countconnector"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/pdataconfig"
+)
+
+// Default metrics are emitted if no metrics are configured for a type of item.
+const (
+	defaultMetricNameSpans = "trace.span.count"
+	defaultMetricDescSpans = "The number of spans observed."
+
+	defaultMetricNameSpanEvents = "trace.span.event.count"
+	defaultMetricDescSpanEvents = "The number of span events observed."
+
+	defaultMetricNameDataPoints = "metric.datapoint.count"
+	defaultMetricDescDataPoints = "The number of data points observed."
+
+	defaultMetricNameLogs = "log.record.count"
+	defaultMetricDescLogs = "The number of log records observed."
+)
+
+// StatusCode is the status of the spans matched by a condition, configured by its name, e.g. "Error".
+type StatusCode = pdataconfig.StatusCode
+
+// Severity is the minimum severity of the log records matched by a condition, configured by its short name,
+// e.g. "WARN" or "error".
+type Severity = pdataconfig.Severity
+
+// Condition is satisfied by the items having all the configured properties.
+type Condition struct {
+	// Name is a regular expression the whole name of the spans, span events or metrics must match.
+	// It is not applicable to log records.
+	Name string `mapstructure:"name"`
+
+	// Attributes the items must have: the attributes of the spans, span events, data points or log records.
+	// The values are compared to the string representation of the attribute values.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// ResourceAttributes the resource of the items must have.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+
+	// StatusCode the spans must have, "Unset", "Ok" or "Error". It is only applicable to spans.
+	StatusCode *StatusCode `mapstructure:"status_code"`
+
+	// MinSeverity is the minimum severity of the log records. Records without severity do not match.
+	// It is only applicable to log records.
+	MinSeverity Severity `mapstructure:"min_severity"`
+
+	// Expression is a condition of the filterexpr language the items must satisfy, e.g.
+	// `attributes["http.route"] == "/checkout" or resource.attributes["env"] != "prod"`.
+	// It is not applicable to span events.
+	Expression string `mapstructure:"expression"`
+}
+
+// AttributeConfig is an attribute the counts are grouped by.
+type AttributeConfig struct {
+	// Key of the attribute of the items, also used for the attribute of the data points emitted.
+	Key string `mapstructure:"key"`
+
+	// DefaultValue is the value of the attribute for the items not having it.
+	// If empty, the items not having the attribute are not counted.
+	DefaultValue string `mapstructure:"default_value"`
+}
+
+// MetricInfo is the configuration of a count metric.
+type MetricInfo struct {
+	// Description of the metric.
+	Description string `mapstructure:"description"`
+
+	// Conditions the items must satisfy to be counted, at least one of them if any.
+	// All the items are counted if there are no conditions.
+	Conditions []Condition `mapstructure:"conditions"`
+
+	// Attributes the counts are grouped by.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+}
+
+// Config defines the configuration for the count connector.
+// The keys of the maps are the names of the metrics emitted. If a map is empty, a default metric
+// counting all the items of its type is emitted.
+type Config struct {
+	Spans      map[string]MetricInfo `mapstructure:"spans"`
+	SpanEvents map[string]MetricInfo `mapstructure:"spanevents"`
+	DataPoints map[string]MetricInfo `mapstructure:"datapoints"`
+	Logs       map[string]MetricInfo `mapstructure:"logs"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	return errors.Join(
+		validateMetrics("spans", cfg.Spans, true, true, false, parseSpanExpression),
+		validateMetrics("spanevents", cfg.SpanEvents, true, false, false, nil),
+		validateMetrics("datapoints", cfg.DataPoints, true, false, false, parseDataPointExpression),
+		validateMetrics("logs", cfg.Logs, false, false, true, parseLogExpression),
+	)
+}
+
+func validateMetrics(section string, metrics map[string]MetricInfo, names, statusCodes, severities bool, parseExpr exprParser) error {
+	var errs error
+	for name, info := range metrics {
+		if name == "" {
+			errs = errors.Join(errs, fmt.Errorf("%s: metric name must not be empty", section))
+		}
+		for i, c := range info.Conditions {
+			prefix := fmt.Sprintf("%s::%s: conditions[%d]", section, name, i)
+			if c.Name != "" {
+				if !names {
+					errs = errors.Join(errs, fmt.Errorf("%s: name is not applicable to %s", prefix, section))
+				} else if _, err := regexp.Compile(c.Name); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("%s: invalid name: %w", prefix, err))
+				}
+			}
+			if c.StatusCode != nil && !statusCodes {
+				errs = errors.Join(errs, fmt.Errorf("%s: status_code is not applicable to %s", prefix, section))
+			}
+			if c.MinSeverity != 0 && !severities {
+				errs = errors.Join(errs, fmt.Errorf("%s: min_severity is not applicable to %s", prefix, section))
+			}
+			if c.Expression != "" {
+				if parseExpr == nil {
+					errs = errors.Join(errs, fmt.Errorf("%s: expression is not applicable to %s", prefix, section))
+				} else if _, err := parseExpr(c.Expression); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("%s: invalid expression: %w", prefix, err))
+				}
+			}
+		}
+		keys := map[string]bool{}
+		for i, attr := range info.Attributes {
+			if attr.Key == "" {
+				errs = errors.Join(errs, fmt.Errorf("%s::%s: attributes[%d]: key must be specified", section, name, i))
+			} else if keys[attr.Key] {
+				errs = errors.Join(errs, fmt.Errorf("%s::%s: attributes[%d]: duplicate key %q", section, name, i, attr.Key))
+			}
+			keys[attr.Key] = true
+		}
+	}
+	return errs
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	statusError := StatusCode(ptrace.StatusCodeError)
+	assert.Equal(t,
+		&Config{
+			Spans: map[string]MetricInfo{
+				"checkout.errors": {
+					Description: "The number of failed checkout requests.",
+					Conditions: []Condition{
+						{Name: "POST /checkout.*", StatusCode: &statusError},
+						{
+							Attributes:         map[string]string{"http.route": "/checkout"},
+							ResourceAttributes: map[string]string{"service.name": "shop"},
+						},
+					},
+					Attributes: []AttributeConfig{{Key: "http.response.status_code", DefaultValue: "unknown"}},
+				},
+			},
+			Logs: map[string]MetricInfo{
+				"log.error.count": {
+					Conditions: []Condition{{MinSeverity: Severity(plog.SeverityNumberError)}},
+				},
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	statusOk := StatusCode(ptrace.StatusCodeOk)
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name:   "invalid name",
+			cfg:    &Config{Spans: map[string]MetricInfo{"count": {Conditions: []Condition{{Name: "("}}}}},
+			errMsg: "spans::count: conditions[0]: invalid name: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "name for logs",
+			cfg:    &Config{Logs: map[string]MetricInfo{"count": {Conditions: []Condition{{Name: "a"}}}}},
+			errMsg: "logs::count: conditions[0]: name is not applicable to logs",
+		},
+		{
+			name:   "status code for span events",
+			cfg:    &Config{SpanEvents: map[string]MetricInfo{"count": {Conditions: []Condition{{StatusCode: &statusOk}}}}},
+			errMsg: "spanevents::count: conditions[0]: status_code is not applicable to spanevents",
+		},
+		{
+			name:   "min severity for data points",
+			cfg:    &Config{DataPoints: map[string]MetricInfo{"count": {Conditions: []Condition{{MinSeverity: Severity(plog.SeverityNumberInfo)}}}}},
+			errMsg: "datapoints::count: conditions[0]: min_severity is not applicable to datapoints",
+		},
+		{
+			name:   "expression for span events",
+			cfg:    &Config{SpanEvents: map[string]MetricInfo{"count": {Conditions: []Condition{{Expression: `name == "exception"`}}}}},
+			errMsg: "spanevents::count: conditions[0]: expression is not applicable to spanevents",
+		},
+		{
+			name:   "invalid expression",
+			cfg:    &Config{Logs: map[string]MetricInfo{"count": {Conditions: []Condition{{Expression: `name == "a"`}}}}},
+			errMsg: "logs::count: conditions[0]: invalid expression: unknown path name at position 0",
+		},
+		{
+			name:   "empty metric name",
+			cfg:    &Config{Logs: map[string]MetricInfo{"": {}}},
+			errMsg: "logs: metric name must not be empty",
+		},
+		{
+			name: "invalid attributes",
+			cfg: &Config{Logs: map[string]MetricInfo{"count": {Attributes: []AttributeConfig{
+				{Key: "a"}, {}, {Key: "a"},
+			}}}},
+			errMsg: "logs::count: attributes[1]: key must be specified\nlogs::count: attributes[2]: duplicate key \"a\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, component.ValidateConfig(tt.cfg), tt.errMsg)
+		})
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var sc StatusCode
+	assert.EqualError(t, sc.UnmarshalText([]byte("failed")), `invalid status code: "failed"`)
+	var s Severity
+	assert.EqualError(t, s.UnmarshalText([]byte("loud")), `invalid severity: "loud"`)
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector // import "go.opentelemetry.io/collector/connector/countconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/filter/filterexpr"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const scopeName = "go.opentelemetry.io/collector/connector/countconnector"
+
+// countConnector counts the items received matching the conditions of the configured metrics,
+// and emits for each resource and each call a delta sum per metric.
+type countConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	metricsConsumer consumer.Metrics
+
+	spans      []metricDef
+	spanEvents []metricDef
+	dataPoints []metricDef
+	logs       []metricDef
+
+	// now is used to get the current time, overridden in tests.
+	now func() time.Time
+}
+
+func newCountConnector(cfg *Config, metricsConsumer consumer.Metrics) *countConnector {
+	return &countConnector{
+		metricsConsumer: metricsConsumer,
+		spans:           newMetricDefs(cfg.Spans, defaultMetricNameSpans, defaultMetricDescSpans, parseSpanExpression),
+		spanEvents:      newMetricDefs(cfg.SpanEvents, defaultMetricNameSpanEvents, defaultMetricDescSpanEvents, nil),
+		dataPoints:      newMetricDefs(cfg.DataPoints, defaultMetricNameDataPoints, defaultMetricDescDataPoints, parseDataPointExpression),
+		logs:            newMetricDefs(cfg.Logs, defaultMetricNameLogs, defaultMetricDescLogs, parseLogExpression),
+		now:             time.Now,
+	}
+}
+
+func (c *countConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *countConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	md := pmetric.NewMetrics()
+	ts := pcommon.NewTimestampFromTime(c.now())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		spans, events := newCounter(c.spans), newCounter(c.spanEvents)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			scope := rs.ScopeSpans().At(j).Scope()
+			ss := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < ss.Len(); k++ {
+				span := ss.At(k)
+				spans.update(item{
+					resource:   rs.Resource().Attributes(),
+					name:       span.Name(),
+					attributes: span.Attributes(),
+					status:     span.Status().Code(),
+					span:       filterexpr.SpanContext{Resource: rs.Resource(), Scope: scope, Span: span},
+				})
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					events.update(item{resource: rs.Resource().Attributes(), name: event.Name(), attributes: event.Attributes()})
+				}
+			}
+		}
+		appendResourceMetrics(md, rs.Resource(), ts, spans, events)
+	}
+	return c.export(ctx, md)
+}
+
+func (c *countConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	out := pmetric.NewMetrics()
+	ts := pcommon.NewTimestampFromTime(c.now())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		points := newCounter(c.dataPoints)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			scope := rm.ScopeMetrics().At(j).Scope()
+			ms := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				forEachDataPointAttributes(m, func(attrs pcommon.Map) {
+					points.update(item{
+						resource:   rm.Resource().Attributes(),
+						name:       m.Name(),
+						attributes: attrs,
+						dataPoint:  filterexpr.DataPointContext{Resource: rm.Resource(), Scope: scope, Metric: m, Attributes: attrs},
+					})
+				})
+			}
+		}
+		appendResourceMetrics(out, rm.Resource(), ts, points)
+	}
+	return c.export(ctx, out)
+}
+
+func (c *countConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	md := pmetric.NewMetrics()
+	ts := pcommon.NewTimestampFromTime(c.now())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		records := newCounter(c.logs)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			scope := rl.ScopeLogs().At(j).Scope()
+			lrs := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				records.update(item{
+					resource:   rl.Resource().Attributes(),
+					attributes: lr.Attributes(),
+					severity:   lr.SeverityNumber(),
+					log:        filterexpr.LogContext{Resource: rl.Resource(), Scope: scope, LogRecord: lr},
+				})
+			}
+		}
+		appendResourceMetrics(md, rl.Resource(), ts, records)
+	}
+	return c.export(ctx, md)
+}
+
+func (c *countConnector) export(ctx context.Context, md pmetric.Metrics) error {
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return c.metricsConsumer.ConsumeMetrics(ctx, md)
+}
+
+// appendResourceMetrics appends the metrics of the counters with the resource, if any item was counted.
+func appendResourceMetrics(md pmetric.Metrics, res pcommon.Resource, ts pcommon.Timestamp, counters ...*counter) {
+	empty := true
+	for _, c := range counters {
+		empty = empty && c.empty()
+	}
+	if empty {
+		return
+	}
+	rm := md.ResourceMetrics().AppendEmpty()
+	res.CopyTo(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	for _, c := range counters {
+		c.appendMetrics(sm.Metrics(), ts)
+	}
+}
+
+// forEachDataPointAttributes calls f with the attributes of each data point of the metric.
+func forEachDataPointAttributes(m pmetric.Metric, f func(pcommon.Map)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			f(m.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			f(m.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			f(m.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			f(m.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			f(m.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
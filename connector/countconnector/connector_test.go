@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// attributesKey returns a readable representation of the attributes, with the keys sorted by json.Marshal.
+func attributesKey(m pcommon.Map) string {
+	if m.Len() == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(m.AsRaw())
+	return string(b)
+}
+
+// counts returns the values of the data points of the metrics emitted, by metric name and attributes.
+func counts(t *testing.T, md pmetric.Metrics) map[string]map[string]int64 {
+	got := map[string]map[string]int64{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sm := md.ResourceMetrics().At(i).ScopeMetrics().At(0)
+		assert.Equal(t, scopeName, sm.Scope().Name())
+		for j := 0; j < sm.Metrics().Len(); j++ {
+			m := sm.Metrics().At(j)
+			require.Equal(t, pmetric.MetricTypeSum, m.Type())
+			assert.True(t, m.Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+			if got[m.Name()] == nil {
+				got[m.Name()] = map[string]int64{}
+			}
+			for k := 0; k < m.Sum().DataPoints().Len(); k++ {
+				dp := m.Sum().DataPoints().At(k)
+				assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+				got[m.Name()][attributesKey(dp.Attributes())] += dp.IntValue()
+			}
+		}
+	}
+	return got
+}
+
+func newTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"shop", "cart"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for i, name := range []string{"POST /checkout", "POST /checkout/confirm", "GET /items"} {
+			span := spans.AppendEmpty()
+			span.SetName(name)
+			if i == 0 {
+				span.Status().SetCode(ptrace.StatusCodeError)
+				span.Attributes().PutInt("http.response.status_code", 500)
+				span.Events().AppendEmpty().SetName("exception")
+			} else {
+				span.Attributes().PutInt("http.response.status_code", 200)
+			}
+			span.Events().AppendEmpty().SetName("message")
+		}
+	}
+	return td
+}
+
+func TestTracesToMetrics(t *testing.T) {
+	statusError := StatusCode(ptrace.StatusCodeError)
+	cfg := &Config{
+		Spans: map[string]MetricInfo{
+			"checkout.requests": {
+				Description: "The number of checkout requests.",
+				Conditions:  []Condition{{Name: "POST /checkout.*"}},
+				Attributes:  []AttributeConfig{{Key: "http.response.status_code"}},
+			},
+			"shop.errors": {
+				Conditions: []Condition{{StatusCode: &statusError, ResourceAttributes: map[string]string{"service.name": "shop"}}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateTracesToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	conn.(*countConnector).now = func() time.Time { return time.Unix(0, 20) }
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces()))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{"service.name": "shop"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+	assert.Equal(t, "checkout.requests", metrics.At(0).Name())
+	assert.Equal(t, "The number of checkout requests.", metrics.At(0).Description())
+	assert.Equal(t, "shop.errors", metrics.At(1).Name())
+	assert.Equal(t, "trace.span.event.count", metrics.At(2).Name())
+
+	assert.Equal(t, map[string]map[string]int64{
+		"checkout.requests":      {`{"http.response.status_code":500}`: 2, `{"http.response.status_code":200}`: 2},
+		"shop.errors":            {"": 1},
+		"trace.span.event.count": {"": 8},
+	}, counts(t, md))
+}
+
+func TestExpressions(t *testing.T) {
+	cfg := &Config{
+		Spans: map[string]MetricInfo{
+			"cart.ok": {
+				Conditions: []Condition{{Expression: `resource.attributes["service.name"] == "cart" and status.code != STATUS_CODE_ERROR`}},
+			},
+		},
+		DataPoints: map[string]MetricInfo{
+			"gauge.points": {
+				Conditions: []Condition{{Expression: `metric.type == "Gauge"`}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.MetricsSink)
+	factory := NewFactory()
+	traces, err := factory.CreateTracesToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	traces.(*countConnector).now = func() time.Time { return time.Unix(0, 20) }
+	metrics, err := factory.CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	metrics.(*countConnector).now = func() time.Time { return time.Unix(0, 20) }
+
+	require.NoError(t, traces.ConsumeTraces(context.Background(), newTraces()))
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	require.NoError(t, metrics.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, map[string]map[string]int64{
+		"cart.ok":                {"": 2},
+		"trace.span.event.count": {"": 8},
+	}, counts(t, sink.AllMetrics()[0]))
+	assert.Equal(t, map[string]map[string]int64{"gauge.points": {"": 1}}, counts(t, sink.AllMetrics()[1]))
+}
+
+func TestMetricsToMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	conn.(*countConnector).now = func() time.Time { return time.Unix(0, 20) }
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+	ms.At(0).Gauge().DataPoints().AppendEmpty()
+	// A resource without data points emits nothing.
+	md.ResourceMetrics().AppendEmpty()
+
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 1, sink.AllMetrics()[0].ResourceMetrics().Len())
+	assert.Equal(t, map[string]map[string]int64{"metric.datapoint.count": {"": 6}}, counts(t, sink.AllMetrics()[0]))
+
+	// Nothing is emitted if no item is counted.
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestLogsToMetrics(t *testing.T) {
+	cfg := &Config{
+		Logs: map[string]MetricInfo{
+			"log.error.count": {
+				Conditions: []Condition{{MinSeverity: Severity(plog.SeverityNumberError)}, {Attributes: map[string]string{"level": "error"}}},
+				Attributes: []AttributeConfig{{Key: "component", DefaultValue: "unknown"}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	conn.(*countConnector).now = func() time.Time { return time.Unix(0, 20) }
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().SetSeverityNumber(plog.SeverityNumberFatal)
+	lrs.AppendEmpty().SetSeverityNumber(plog.SeverityNumberInfo)
+	lrs.AppendEmpty().Attributes().PutStr("level", "error")
+	lr := lrs.AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.Attributes().PutStr("component", "db")
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, map[string]map[string]int64{
+		"log.error.count": {`{"component":"unknown"}`: 2, `{"component":"db"}`: 1},
+	}, counts(t, sink.AllMetrics()[0]))
+}
+
+func TestAttributesWithoutDefault(t *testing.T) {
+	cfg := &Config{
+		SpanEvents: map[string]MetricInfo{
+			"event.count": {Attributes: []AttributeConfig{{Key: "exception.type"}}},
+		},
+	}
+	sink := new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateTracesToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	conn.(*countConnector).now = func() time.Time { return time.Unix(0, 20) }
+
+	td := newTraces()
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().PutStr("exception.type", "io")
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllMetrics(), 1)
+	// The spans are still counted by the default metric, the events without the attribute are not counted.
+	assert.Equal(t, map[string]map[string]int64{
+		"trace.span.count": {"": 6},
+		"event.count":      {`{"exception.type":"io"}`: 1},
+	}, counts(t, sink.AllMetrics()[0]))
+}
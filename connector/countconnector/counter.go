@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector // import "go.opentelemetry.io/collector/connector/countconnector"
+
+import (
+	"regexp"
+	"sort"
+
+	"go.opentelemetry.io/collector/filter/filterexpr"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// item holds the properties of a span, span event, data point or log record the conditions are evaluated on.
+type item struct {
+	resource   pcommon.Map
+	name       string
+	attributes pcommon.Map
+	status     ptrace.StatusCode
+	severity   plog.SeverityNumber
+
+	// The context the expressions are evaluated on, depending on the type of the item.
+	span      filterexpr.SpanContext
+	dataPoint filterexpr.DataPointContext
+	log       filterexpr.LogContext
+}
+
+// exprParser parses the expression of a condition for a type of items.
+type exprParser func(expr string) (func(item) bool, error)
+
+func parseSpanExpression(expr string) (func(item) bool, error) {
+	cond, err := filterexpr.ParseSpanCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(it item) bool { return cond.Eval(it.span) }, nil
+}
+
+func parseDataPointExpression(expr string) (func(item) bool, error) {
+	cond, err := filterexpr.ParseDataPointCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(it item) bool { return cond.Eval(it.dataPoint) }, nil
+}
+
+func parseLogExpression(expr string) (func(item) bool, error) {
+	cond, err := filterexpr.ParseLogCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(it item) bool { return cond.Eval(it.log) }, nil
+}
+
+// condition is a compiled Condition.
+type condition struct {
+	name               *regexp.Regexp
+	attributes         map[string]string
+	resourceAttributes map[string]string
+	status             *ptrace.StatusCode
+	minSeverity        plog.SeverityNumber
+	expr               func(item) bool
+}
+
+func (c *condition) match(it item) bool {
+	if c.name != nil && !c.name.MatchString(it.name) {
+		return false
+	}
+	if c.status != nil && *c.status != it.status {
+		return false
+	}
+	if c.minSeverity != plog.SeverityNumberUnspecified && it.severity < c.minSeverity {
+		return false
+	}
+	if !matchAttributes(c.attributes, it.attributes) || !matchAttributes(c.resourceAttributes, it.resource) {
+		return false
+	}
+	return c.expr == nil || c.expr(it)
+}
+
+// matchAttributes returns true if attrs contains all the expected attributes.
+func matchAttributes(expected map[string]string, attrs pcommon.Map) bool {
+	for k, v := range expected {
+		av, ok := attrs.Get(k)
+		if !ok || av.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// metricDef is a compiled MetricInfo.
+type metricDef struct {
+	name        string
+	description string
+	conditions  []condition
+	attributes  []AttributeConfig
+}
+
+// newMetricDefs compiles the metrics configured, sorted by name so they are emitted in a deterministic order.
+// The default metric is returned if none is configured.
+func newMetricDefs(metrics map[string]MetricInfo, defaultName, defaultDesc string, parseExpr exprParser) []metricDef {
+	if len(metrics) == 0 {
+		return []metricDef{{name: defaultName, description: defaultDesc}}
+	}
+	defs := make([]metricDef, 0, len(metrics))
+	for name, info := range metrics {
+		def := metricDef{name: name, description: info.Description, attributes: info.Attributes}
+		for _, cc := range info.Conditions {
+			c := condition{
+				attributes:         cc.Attributes,
+				resourceAttributes: cc.ResourceAttributes,
+				minSeverity:        plog.SeverityNumber(cc.MinSeverity),
+			}
+			if cc.Name != "" {
+				// The expression is checked when the configuration is validated.
+				c.name = regexp.MustCompile("^(?:" + cc.Name + ")$")
+			}
+			if cc.StatusCode != nil {
+				status := ptrace.StatusCode(*cc.StatusCode)
+				c.status = &status
+			}
+			if cc.Expression != "" && parseExpr != nil {
+				// The expression is checked when the configuration is validated.
+				c.expr, _ = parseExpr(cc.Expression)
+			}
+			def.conditions = append(def.conditions, c)
+		}
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].name < defs[j].name })
+	return defs
+}
+
+func (def *metricDef) match(it item) bool {
+	if len(def.conditions) == 0 {
+		return true
+	}
+	for i := range def.conditions {
+		if def.conditions[i].match(it) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupAttributes returns the attributes the count of the item is grouped by,
+// or false if the item is not counted because it lacks one of them.
+func (def *metricDef) groupAttributes(it item) (pcommon.Map, bool) {
+	attrs := pcommon.NewMap()
+	for _, attr := range def.attributes {
+		if v, ok := it.attributes.Get(attr.Key); ok {
+			v.CopyTo(attrs.PutEmpty(attr.Key))
+			continue
+		}
+		if attr.DefaultValue == "" {
+			return attrs, false
+		}
+		attrs.PutStr(attr.Key, attr.DefaultValue)
+	}
+	return attrs, true
+}
+
+type count struct {
+	attributes pcommon.Map
+	value      int64
+}
+
+// counter counts the items of a resource for each metric.
+type counter struct {
+	defs []metricDef
+	// counts holds the counts of each metric by the identity of their attributes.
+	counts []map[[16]byte]*count
+	// keys holds the identities of the counts of each metric, in the order they were seen.
+	keys [][][16]byte
+}
+
+func newCounter(defs []metricDef) *counter {
+	return &counter{
+		defs:   defs,
+		counts: make([]map[[16]byte]*count, len(defs)),
+		keys:   make([][][16]byte, len(defs)),
+	}
+}
+
+func (c *counter) update(it item) {
+	for i := range c.defs {
+		def := &c.defs[i]
+		if !def.match(it) {
+			continue
+		}
+		attrs, ok := def.groupAttributes(it)
+		if !ok {
+			continue
+		}
+		key := attrs.Hash()
+		if c.counts[i] == nil {
+			c.counts[i] = map[[16]byte]*count{}
+		}
+		cnt, ok := c.counts[i][key]
+		if !ok {
+			cnt = &count{attributes: attrs}
+			c.counts[i][key] = cnt
+			c.keys[i] = append(c.keys[i], key)
+		}
+		cnt.value++
+	}
+}
+
+func (c *counter) empty() bool {
+	for _, keys := range c.keys {
+		if len(keys) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendMetrics appends a delta sum for each metric having counts.
+func (c *counter) appendMetrics(ms pmetric.MetricSlice, ts pcommon.Timestamp) {
+	for i := range c.defs {
+		if len(c.keys[i]) == 0 {
+			continue
+		}
+		m := ms.AppendEmpty()
+		m.SetName(c.defs[i].name)
+		m.SetDescription(c.defs[i].description)
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dps := sum.DataPoints()
+		dps.EnsureCapacity(len(c.keys[i]))
+		for _, key := range c.keys[i] {
+			cnt := c.counts[i][key]
+			dp := dps.AppendEmpty()
+			cnt.attributes.CopyTo(dp.Attributes())
+			dp.SetTimestamp(ts)
+			dp.SetIntValue(cnt.value)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector // import "go.opentelemetry.io/collector/connector/countconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/countconnector/internal/metadata"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// NewFactory returns a connector.Factory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithTracesToMetrics(createTracesToMetrics, metadata.TracesToMetricsStability),
+		connector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
+		connector.WithLogsToMetrics(createLogsToMetrics, metadata.LogsToMetricsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// createTracesToMetrics creates a traces to metrics connector based on provided config.
+func createTracesToMetrics(_ context.Context, _ connector.Settings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
+	return newCountConnector(cfg.(*Config), nextConsumer), nil
+}
+
+// createMetricsToMetrics creates a metrics to metrics connector based on provided config.
+func createMetricsToMetrics(_ context.Context, _ connector.Settings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Metrics, error) {
+	return newCountConnector(cfg.(*Config), nextConsumer), nil
+}
+
+// createLogsToMetrics creates a logs to metrics connector based on provided config.
+func createLogsToMetrics(_ context.Context, _ connector.Settings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Logs, error) {
+	return newCountConnector(cfg.(*Config), nextConsumer), nil
+}
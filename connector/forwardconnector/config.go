@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// PipelineConfig defines how the data is forwarded to a downstream pipeline.
+type PipelineConfig struct {
+	// SamplingPercentage is the percentage of the data forwarded to the pipeline, in 0-100.
+	// The spans are sampled by trace ID, so the spans of a trace are forwarded together, the log records
+	// by trace ID when they have one, and randomly otherwise, and the metrics by resource.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+}
+
+// Config defines the configuration for the forward connector.
+type Config struct {
+	// Pipelines configures the forwarding of the data to some of the downstream pipelines, by pipeline ID.
+	// All the data is forwarded to the downstream pipelines not listed.
+	Pipelines map[component.ID]PipelineConfig `mapstructure:"pipelines"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs error
+	for id, pCfg := range cfg.Pipelines {
+		if pCfg.SamplingPercentage < 0 || pCfg.SamplingPercentage > 100 {
+			errs = errors.Join(errs, fmt.Errorf("pipelines::%s: sampling_percentage must be in 0-100", id))
+		}
+	}
+	return errs
+}
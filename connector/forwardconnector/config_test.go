@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Pipelines: map[component.ID]PipelineConfig{
+				component.MustNewIDWithName("traces", "expensive"): {SamplingPercentage: 1},
+				component.MustNewIDWithName("traces", "debug"):     {SamplingPercentage: 12.5},
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := &Config{
+		Pipelines: map[component.ID]PipelineConfig{
+			component.MustNewIDWithName("traces", "negative"): {SamplingPercentage: -1},
+		},
+	}
+	assert.EqualError(t, component.ValidateConfig(cfg), "pipelines::traces/negative: sampling_percentage must be in 0-100")
+
+	cfg.Pipelines = map[component.ID]PipelineConfig{
+		component.MustNewIDWithName("logs", "over"): {SamplingPercentage: 101},
+	}
+	assert.EqualError(t, component.ValidateConfig(cfg), "pipelines::logs/over: sampling_percentage must be in 0-100")
+}
@@ -23,8 +23,6 @@ func NewFactory() connector.Factory {
 	)
 }
 
-type Config struct{}
-
 // createDefaultConfig creates the default configuration.
 func createDefaultConfig() component.Config {
 	return &Config{}
@@ -34,30 +32,54 @@ func createDefaultConfig() component.Config {
 func createTracesToTraces(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Traces,
 ) (connector.Traces, error) {
-	return &forward{Traces: nextConsumer}, nil
+	fCfg := cfg.(*Config)
+	if !fCfg.sampledPipelines(component.DataTypeTraces) {
+		return &forward{Traces: nextConsumer}, nil
+	}
+	all, branches, err := newBranches[consumer.Traces](fCfg, component.DataTypeTraces, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesTee{all: all, branches: branches}, nil
 }
 
 // createMetricsToMetrics creates a metrics receiver based on provided config.
 func createMetricsToMetrics(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Metrics,
 ) (connector.Metrics, error) {
-	return &forward{Metrics: nextConsumer}, nil
+	fCfg := cfg.(*Config)
+	if !fCfg.sampledPipelines(component.DataTypeMetrics) {
+		return &forward{Metrics: nextConsumer}, nil
+	}
+	all, branches, err := newBranches[consumer.Metrics](fCfg, component.DataTypeMetrics, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsTee{all: all, branches: branches}, nil
 }
 
 // createLogsToLogs creates a log receiver based on provided config.
 func createLogsToLogs(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Logs,
 ) (connector.Logs, error) {
-	return &forward{Logs: nextConsumer}, nil
+	fCfg := cfg.(*Config)
+	if !fCfg.sampledPipelines(component.DataTypeLogs) {
+		return &forward{Logs: nextConsumer}, nil
+	}
+	all, branches, err := newBranches[consumer.Logs](fCfg, component.DataTypeLogs, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+	return &logsTee{all: all, branches: branches}, nil
 }
 
 // forward is used to pass signals directly from one pipeline to another.
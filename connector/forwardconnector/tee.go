@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// router is implemented by the routers of the connector package, giving access to the downstream pipelines.
+type router[T any] interface {
+	Consumer(...component.ID) (T, error)
+	PipelineIDs() []component.ID
+}
+
+// branch is a downstream pipeline receiving a sample of the data.
+type branch[T any] struct {
+	consumer   T
+	percentage float64
+}
+
+// sampledPipelines returns whether some downstream pipelines of the data type receive a sample of the data.
+func (cfg *Config) sampledPipelines(dataType component.DataType) bool {
+	for id := range cfg.Pipelines {
+		if id.Type() == dataType {
+			return true
+		}
+	}
+	return false
+}
+
+// newBranches splits the downstream pipelines of the router between the pipelines receiving all the data,
+// returned as a single consumer, the zero value if there is none, and the pipelines receiving a sample of the data.
+func newBranches[T any](cfg *Config, dataType component.DataType, next any) (T, []branch[T], error) {
+	var all T
+	r, ok := next.(router[T])
+	if !ok {
+		return all, nil, errors.New("sampling requires the connector to forward the data to named pipelines")
+	}
+	downstream := map[component.ID]bool{}
+	for _, id := range r.PipelineIDs() {
+		downstream[id] = true
+	}
+	for id := range cfg.Pipelines {
+		if id.Type() == dataType && !downstream[id] {
+			return all, nil, fmt.Errorf("pipeline %q is not a downstream pipeline of the connector", id)
+		}
+	}
+
+	var allIDs []component.ID
+	var branches []branch[T]
+	for _, id := range r.PipelineIDs() {
+		pCfg, ok := cfg.Pipelines[id]
+		switch {
+		case !ok || pCfg.SamplingPercentage >= 100:
+			allIDs = append(allIDs, id)
+		case pCfg.SamplingPercentage > 0:
+			c, err := r.Consumer(id)
+			if err != nil {
+				return all, nil, err
+			}
+			branches = append(branches, branch[T]{consumer: c, percentage: pCfg.SamplingPercentage})
+		}
+	}
+	if len(allIDs) == 0 {
+		return all, branches, nil
+	}
+	all, err := r.Consumer(allIDs...)
+	return all, branches, err
+}
+
+// sampleTraceID returns whether the data of the trace is sampled, the decision being the same for all its spans.
+func sampleTraceID(traceID pcommon.TraceID, percentage float64) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(traceID[:])
+	return float64(h.Sum64()>>11)/(1<<53)*100 < percentage
+}
+
+// sampleRandom returns whether data without a trace ID is sampled.
+func sampleRandom(percentage float64) bool {
+	return rand.Float64()*100 < percentage
+}
+
+// tracesTee forwards all the traces to some downstream pipelines, and a sample of them to the others.
+type tracesTee struct {
+	all      consumer.Traces
+	branches []branch[consumer.Traces]
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (c *tracesTee) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *tracesTee) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var errs error
+	// The samples are copied before the data is forwarded to the other pipelines, which may modify it.
+	for _, b := range c.branches {
+		if sampled := sampleTraces(td, b.percentage); sampled.SpanCount() > 0 {
+			errs = errors.Join(errs, b.consumer.ConsumeTraces(ctx, sampled))
+		}
+	}
+	if c.all != nil {
+		errs = errors.Join(errs, c.all.ConsumeTraces(ctx, td))
+	}
+	return errs
+}
+
+// sampleTraces returns a copy of the sampled spans of the traces.
+func sampleTraces(td ptrace.Traces, percentage float64) ptrace.Traces {
+	sampled := ptrace.NewTraces()
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		var srs ptrace.ResourceSpans
+		hasResource := false
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			var sss ptrace.ScopeSpans
+			hasScope := false
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				if !sampleTraceID(span.TraceID(), percentage) {
+					continue
+				}
+				if !hasResource {
+					hasResource = true
+					srs = sampled.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(srs.Resource())
+					srs.SetSchemaUrl(rs.SchemaUrl())
+				}
+				if !hasScope {
+					hasScope = true
+					sss = srs.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(sss.Scope())
+					sss.SetSchemaUrl(ss.SchemaUrl())
+				}
+				span.CopyTo(sss.Spans().AppendEmpty())
+			}
+		}
+	}
+	return sampled
+}
+
+// metricsTee forwards all the metrics to some downstream pipelines, and a sample of them to the others.
+type metricsTee struct {
+	all      consumer.Metrics
+	branches []branch[consumer.Metrics]
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (c *metricsTee) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *metricsTee) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	for _, b := range c.branches {
+		if sampled := sampleMetrics(md, b.percentage); sampled.ResourceMetrics().Len() > 0 {
+			errs = errors.Join(errs, b.consumer.ConsumeMetrics(ctx, sampled))
+		}
+	}
+	if c.all != nil {
+		errs = errors.Join(errs, c.all.ConsumeMetrics(ctx, md))
+	}
+	return errs
+}
+
+// sampleMetrics returns a copy of the sampled resources of the metrics, so the data points of a resource
+// are not split.
+func sampleMetrics(md pmetric.Metrics, percentage float64) pmetric.Metrics {
+	sampled := pmetric.NewMetrics()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		if sampleRandom(percentage) {
+			md.ResourceMetrics().At(i).CopyTo(sampled.ResourceMetrics().AppendEmpty())
+		}
+	}
+	return sampled
+}
+
+// logsTee forwards all the logs to some downstream pipelines, and a sample of them to the others.
+type logsTee struct {
+	all      consumer.Logs
+	branches []branch[consumer.Logs]
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (c *logsTee) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logsTee) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var errs error
+	for _, b := range c.branches {
+		if sampled := sampleLogs(ld, b.percentage); sampled.LogRecordCount() > 0 {
+			errs = errors.Join(errs, b.consumer.ConsumeLogs(ctx, sampled))
+		}
+	}
+	if c.all != nil {
+		errs = errors.Join(errs, c.all.ConsumeLogs(ctx, ld))
+	}
+	return errs
+}
+
+// sampleLogs returns a copy of the sampled log records, sampled by trace ID when they have one.
+func sampleLogs(ld plog.Logs, percentage float64) plog.Logs {
+	sampled := plog.NewLogs()
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		var srl plog.ResourceLogs
+		hasResource := false
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			var ssl plog.ScopeLogs
+			hasScope := false
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				if !sampleLogRecord(lr, percentage) {
+					continue
+				}
+				if !hasResource {
+					hasResource = true
+					srl = sampled.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(srl.Resource())
+					srl.SetSchemaUrl(rl.SchemaUrl())
+				}
+				if !hasScope {
+					hasScope = true
+					ssl = srl.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(ssl.Scope())
+					ssl.SetSchemaUrl(sl.SchemaUrl())
+				}
+				lr.CopyTo(ssl.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return sampled
+}
+
+// sampleLogRecord returns whether the log record is sampled, by trace ID if it has one, randomly otherwise.
+func sampleLogRecord(lr plog.LogRecord, percentage float64) bool {
+	if lr.TraceID().IsEmpty() {
+		return sampleRandom(percentage)
+	}
+	return sampleTraceID(lr.TraceID(), percentage)
+}
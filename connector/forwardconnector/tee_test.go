@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	allTracesID     = component.MustNewIDWithName("traces", "all")
+	sampledTracesID = component.MustNewIDWithName("traces", "sampled")
+	droppedTracesID = component.MustNewIDWithName("traces", "dropped")
+)
+
+func newTraces(count int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	for i := 0; i < count; i++ {
+		span := ss.Spans().AppendEmpty()
+		span.SetTraceID(pcommon.TraceID{byte(i), byte(i >> 8), 1, 2, 3})
+		// Two spans per trace.
+		span.CopyTo(ss.Spans().AppendEmpty())
+	}
+	return td
+}
+
+func TestTracesTee(t *testing.T) {
+	allSink := new(consumertest.TracesSink)
+	sampledSink := new(consumertest.TracesSink)
+	droppedSink := new(consumertest.TracesSink)
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		allTracesID:     allSink,
+		sampledTracesID: sampledSink,
+		droppedTracesID: droppedSink,
+	})
+	cfg := &Config{Pipelines: map[component.ID]PipelineConfig{
+		sampledTracesID: {SamplingPercentage: 10},
+		droppedTracesID: {SamplingPercentage: 0},
+		// The percentages of the pipelines of the other data types are ignored.
+		component.MustNewIDWithName("logs", "sampled"): {SamplingPercentage: 10},
+	}}
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(), cfg, router)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces(1000)))
+	assert.Equal(t, 2000, allSink.SpanCount())
+	assert.Equal(t, 0, droppedSink.SpanCount())
+	// The spans of a trace are sampled together.
+	assert.Equal(t, 0, sampledSink.SpanCount()%2)
+	assert.InDelta(t, 200, sampledSink.SpanCount(), 60)
+	sampled := sampledSink.AllTraces()[0].ResourceSpans().At(0)
+	service, _ := sampled.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.Str())
+
+	// The sampling decision of a trace is the same for every batch.
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces(1000)))
+	assert.Equal(t, 2*sampledSink.AllTraces()[0].SpanCount(), sampledSink.SpanCount())
+}
+
+func TestTeeUnknownPipeline(t *testing.T) {
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		allTracesID: consumertest.NewNop(),
+	})
+	cfg := &Config{Pipelines: map[component.ID]PipelineConfig{
+		sampledTracesID: {SamplingPercentage: 10},
+	}}
+	_, err := NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(), cfg, router)
+	assert.EqualError(t, err, `pipeline "traces/sampled" is not a downstream pipeline of the connector`)
+
+	_, err = NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(), cfg, consumertest.NewNop())
+	assert.EqualError(t, err, "sampling requires the connector to forward the data to named pipelines")
+}
+
+func TestMetricsTee(t *testing.T) {
+	allSink := new(consumertest.MetricsSink)
+	sampledSink := new(consumertest.MetricsSink)
+	sampledID := component.MustNewIDWithName("metrics", "sampled")
+	router := connector.NewMetricsRouter(map[component.ID]consumer.Metrics{
+		component.MustNewIDWithName("metrics", "all"): allSink,
+		sampledID: sampledSink,
+	})
+	cfg := &Config{Pipelines: map[component.ID]PipelineConfig{
+		sampledID: {SamplingPercentage: 50},
+	}}
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, router)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	for i := 0; i < 1000; i++ {
+		md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	}
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 1000, allSink.DataPointCount())
+	assert.InDelta(t, 500, sampledSink.DataPointCount(), 100)
+}
+
+func TestLogsTee(t *testing.T) {
+	sampledSink := new(consumertest.LogsSink)
+	sampledID := component.MustNewIDWithName("logs", "sampled")
+	router := connector.NewLogsRouter(map[component.ID]consumer.Logs{
+		sampledID: sampledSink,
+	})
+	cfg := &Config{Pipelines: map[component.ID]PipelineConfig{
+		sampledID: {SamplingPercentage: 20},
+	}}
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopSettings(), cfg, router)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 1000; i++ {
+		lr := lrs.AppendEmpty()
+		if i%2 == 0 {
+			lr.SetTraceID(pcommon.TraceID{byte(i), byte(i >> 8), 1, 2, 3})
+		}
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	assert.InDelta(t, 200, sampledSink.LogRecordCount(), 60)
+}
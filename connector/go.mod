@@ -36,6 +36,8 @@ require (
 	github.com/prometheus/common v0.55.0 // indirect
 	github.com/prometheus/procfs v0.15.1 // indirect
 	go.opentelemetry.io/collector/config/configtelemetry v0.107.0 // indirect
+	go.opentelemetry.io/collector/consumer/consumerentities v0.107.0 // indirect
+	go.opentelemetry.io/collector/pdata/pentity v0.107.0 // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.107.0 // indirect
 	go.opentelemetry.io/otel v1.28.0 // indirect
 	go.opentelemetry.io/otel/exporters/prometheus v0.50.0 // indirect
@@ -70,8 +72,12 @@ replace go.opentelemetry.io/collector/pdata/testdata => ../pdata/testdata
 
 replace go.opentelemetry.io/collector/pdata/pprofile => ../pdata/pprofile
 
+replace go.opentelemetry.io/collector/pdata/pentity => ../pdata/pentity
+
 replace go.opentelemetry.io/collector/consumer/consumerprofiles => ../consumer/consumerprofiles
 
+replace go.opentelemetry.io/collector/consumer/consumerentities => ../consumer/consumerentities
+
 replace go.opentelemetry.io/collector/consumer/consumertest => ../consumer/consumertest
 
 replace go.opentelemetry.io/collector/component/componentprofiles => ../component/componentprofiles
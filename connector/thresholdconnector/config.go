@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package thresholdconnector // import "go.opentelemetry.io/collector/connector/thresholdconnector"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/pdataconfig"
+)
+
+// Condition defines how the value of a data point is compared to the threshold.
+type Condition string
+
+const (
+	// ConditionAbove is satisfied when the value is strictly greater than the threshold.
+	ConditionAbove Condition = "above"
+	// ConditionBelow is satisfied when the value is strictly less than the threshold.
+	ConditionBelow Condition = "below"
+)
+
+var _ encoding.TextUnmarshaler = (*Condition)(nil)
+
+// UnmarshalText unmarshalls text to a Condition.
+func (c *Condition) UnmarshalText(text []byte) error {
+	switch str := Condition(text); str {
+	case ConditionAbove, ConditionBelow:
+		*c = str
+		return nil
+	default:
+		return fmt.Errorf("invalid condition: %q", str)
+	}
+}
+
+// Severity is the severity of the emitted log records, configured by its short name, e.g. "WARN" or "error".
+type Severity = pdataconfig.Severity
+
+// Rule is a threshold evaluated over the data points of a gauge or sum metric.
+type Rule struct {
+	// Name identifies the rule in the emitted log records.
+	Name string `mapstructure:"name"`
+
+	// Metric is the name of the metric the rule applies to.
+	Metric string `mapstructure:"metric"`
+
+	// Attributes restricts the rule to the data points having all these attributes.
+	// The values are compared to the string representation of the attribute values.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// Condition is either "above" or "below".
+	Condition Condition `mapstructure:"condition"`
+
+	// Threshold is the value the data points are compared to.
+	Threshold float64 `mapstructure:"threshold"`
+
+	// Severity of the log records emitted when the threshold is crossed (default: WARN).
+	Severity Severity `mapstructure:"severity"`
+}
+
+// Config defines the configuration for the threshold connector.
+type Config struct {
+	// Rules evaluated over the incoming metrics.
+	Rules []Rule `mapstructure:"rules"`
+
+	// EmitResolved emits a log record when a data point goes back within the threshold
+	// after it was crossed (default: true).
+	EmitResolved bool `mapstructure:"emit_resolved"`
+
+	// MaxStale is the time after which a stream crossing a threshold is forgotten if it does not receive
+	// any data point, no record is emitted for it (default: 5m). Zero keeps the streams until they are resolved.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Rules) == 0 {
+		return errors.New("at least one rule must be specified")
+	}
+	var errs error
+	if cfg.MaxStale < 0 {
+		errs = errors.Join(errs, errors.New("max_stale must not be negative"))
+	}
+	names := map[string]bool{}
+	for i, rule := range cfg.Rules {
+		if rule.Name == "" {
+			errs = errors.Join(errs, fmt.Errorf("rules[%d]: name must be specified", i))
+		} else if names[rule.Name] {
+			errs = errors.Join(errs, fmt.Errorf("rules[%d]: duplicate rule name %q", i, rule.Name))
+		}
+		names[rule.Name] = true
+		if rule.Metric == "" {
+			errs = errors.Join(errs, fmt.Errorf("rules[%d]: metric must be specified", i))
+		}
+		if rule.Condition == "" {
+			errs = errors.Join(errs, fmt.Errorf("rules[%d]: condition must be specified", i))
+		}
+	}
+	return errs
+}
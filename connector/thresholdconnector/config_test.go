@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package thresholdconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Rules: []Rule{
+				{
+					Name:       "high_error_rate",
+					Metric:     "http.server.error_rate",
+					Attributes: map[string]string{"http.route": "/checkout"},
+					Condition:  ConditionAbove,
+					Threshold:  0.05,
+					Severity:   Severity(plog.SeverityNumberError),
+				},
+				{
+					Name:      "low_disk",
+					Metric:    "system.filesystem.free",
+					Condition: ConditionBelow,
+					Threshold: 1e9,
+				},
+			},
+			EmitResolved: false,
+			MaxStale:     10 * time.Minute,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name:   "no rules",
+			cfg:    &Config{},
+			errMsg: "at least one rule must be specified",
+		},
+		{
+			name: "missing fields",
+			cfg: &Config{Rules: []Rule{
+				{},
+			}},
+			errMsg: "rules[0]: name must be specified\nrules[0]: metric must be specified\nrules[0]: condition must be specified",
+		},
+		{
+			name: "duplicate names",
+			cfg: &Config{Rules: []Rule{
+				{Name: "rule", Metric: "a", Condition: ConditionAbove},
+				{Name: "rule", Metric: "b", Condition: ConditionBelow},
+			}},
+			errMsg: `rules[1]: duplicate rule name "rule"`,
+		},
+		{
+			name: "negative max stale",
+			cfg: &Config{
+				Rules:    []Rule{{Name: "rule", Metric: "a", Condition: ConditionAbove}},
+				MaxStale: -time.Second,
+			},
+			errMsg: "max_stale must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, component.ValidateConfig(tt.cfg), tt.errMsg)
+		})
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var c Condition
+	assert.EqualError(t, c.UnmarshalText([]byte("equal")), `invalid condition: "equal"`)
+	var s Severity
+	assert.EqualError(t, s.UnmarshalText([]byte("loud")), `invalid severity: "loud"`)
+}
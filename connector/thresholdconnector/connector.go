@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package thresholdconnector // import "go.opentelemetry.io/collector/connector/thresholdconnector"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/collector/connector/thresholdconnector"
+
+	attributeRule      = "threshold.rule"
+	attributeState     = "threshold.state"
+	attributeCondition = "threshold.condition"
+	attributeThreshold = "threshold.limit"
+	attributeMetric    = "metric.name"
+	attributeValue     = "metric.value"
+
+	stateFiring   = "firing"
+	stateResolved = "resolved"
+)
+
+// scopeID identifies an instrumentation scope by its name, version and the hash of its attributes.
+type scopeID struct {
+	name       string
+	version    string
+	attributes [16]byte
+}
+
+// streamID identifies a stream of points by the hash of the resource, the scope and the hash of the point attributes.
+type streamID struct {
+	resource   [16]byte
+	scope      scopeID
+	attributes [16]byte
+}
+
+// thresholdConnector evaluates the configured rules over the incoming metrics, and emits a log record
+// whenever a data point stream crosses a threshold. Only the streams currently crossing a threshold
+// are tracked, so the state stays small as long as thresholds are rarely crossed, and the ones which
+// stop receiving data points are evicted after Config.MaxStale.
+type thresholdConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	cfg          *Config
+	logsConsumer consumer.Logs
+
+	mu sync.Mutex
+	// firing holds the streams currently crossing the threshold of each rule, with the last time they were seen.
+	firing       []map[streamID]time.Time
+	lastEviction time.Time
+
+	// now is used to get the current time, overridden in tests.
+	now func() time.Time
+}
+
+func newThresholdConnector(_ connector.Settings, cfg *Config, logsConsumer consumer.Logs) *thresholdConnector {
+	firing := make([]map[streamID]time.Time, len(cfg.Rules))
+	for i := range firing {
+		firing[i] = map[streamID]time.Time{}
+	}
+	return &thresholdConnector{
+		cfg:          cfg,
+		logsConsumer: logsConsumer,
+		firing:       firing,
+		now:          time.Now,
+	}
+}
+
+func (c *thresholdConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *thresholdConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	ld := plog.NewLogs()
+	now := c.now()
+
+	c.mu.Lock()
+	c.evictStale(now)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		var records plog.LogRecordSlice
+		created := false
+		recordsFor := func() plog.LogRecordSlice {
+			// Create the resource and scope only if at least one record is emitted.
+			if !created {
+				rl := ld.ResourceLogs().AppendEmpty()
+				rm.Resource().CopyTo(rl.Resource())
+				sl := rl.ScopeLogs().AppendEmpty()
+				sl.Scope().SetName(scopeName)
+				records = sl.LogRecords()
+				created = true
+			}
+			return records
+		}
+		resourceID := rm.Resource().Attributes().Hash()
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scope := scopeID{
+				name:       sm.Scope().Name(),
+				version:    sm.Scope().Version(),
+				attributes: sm.Scope().Attributes().Hash(),
+			}
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				c.evaluateMetric(ms.At(k), resourceID, scope, now, recordsFor)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	if ld.LogRecordCount() == 0 {
+		return nil
+	}
+	return c.logsConsumer.ConsumeLogs(ctx, ld)
+}
+
+func (c *thresholdConnector) evaluateMetric(m pmetric.Metric, resourceID [16]byte, scope scopeID, now time.Time, records func() plog.LogRecordSlice) {
+	observed := pcommon.NewTimestampFromTime(now)
+	var dps pmetric.NumberDataPointSlice
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = m.Sum().DataPoints()
+	default:
+		return
+	}
+
+	for ri := range c.cfg.Rules {
+		rule := &c.cfg.Rules[ri]
+		if rule.Metric != m.Name() {
+			continue
+		}
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.Flags().NoRecordedValue() || !matchAttributes(rule.Attributes, dp.Attributes()) {
+				continue
+			}
+			value := numberValue(dp)
+			stream := streamID{resource: resourceID, scope: scope, attributes: dp.Attributes().Hash()}
+			_, wasFiring := c.firing[ri][stream]
+			isFiring := crosses(rule, value)
+			switch {
+			case isFiring:
+				c.firing[ri][stream] = now
+				if !wasFiring {
+					newRecord(records().AppendEmpty(), rule, stateFiring, m.Name(), value, dp, observed)
+				}
+			case !isFiring && wasFiring:
+				delete(c.firing[ri], stream)
+				if c.cfg.EmitResolved {
+					newRecord(records().AppendEmpty(), rule, stateResolved, m.Name(), value, dp, observed)
+				}
+			}
+		}
+	}
+}
+
+// evictStale forgets the firing streams that did not receive any data point for longer than Config.MaxStale.
+// To amortize the cost, streams are scanned at most once per Config.MaxStale.
+func (c *thresholdConnector) evictStale(now time.Time) {
+	if c.cfg.MaxStale <= 0 || now.Sub(c.lastEviction) < c.cfg.MaxStale {
+		return
+	}
+	c.lastEviction = now
+	for _, firing := range c.firing {
+		for id, lastSeen := range firing {
+			if now.Sub(lastSeen) >= c.cfg.MaxStale {
+				delete(firing, id)
+			}
+		}
+	}
+}
+
+func crosses(rule *Rule, value float64) bool {
+	if rule.Condition == ConditionBelow {
+		return value < rule.Threshold
+	}
+	return value > rule.Threshold
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// matchAttributes returns true if attrs contains all the expected attributes.
+func matchAttributes(expected map[string]string, attrs pcommon.Map) bool {
+	for k, v := range expected {
+		av, ok := attrs.Get(k)
+		if !ok || av.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+func newRecord(lr plog.LogRecord, rule *Rule, state string, metricName string, value float64, dp pmetric.NumberDataPoint, observed pcommon.Timestamp) {
+	lr.SetTimestamp(dp.Timestamp())
+	lr.SetObservedTimestamp(observed)
+	severity := plog.SeverityNumber(rule.Severity)
+	if severity == plog.SeverityNumberUnspecified {
+		severity = plog.SeverityNumberWarn
+	}
+	if state == stateResolved {
+		severity = plog.SeverityNumberInfo
+	}
+	lr.SetSeverityNumber(severity)
+	lr.SetSeverityText(strings.ToUpper(severity.String()))
+	lr.Body().SetStr(fmt.Sprintf("%s %s: %s is %v, %s threshold %v", rule.Name, state, metricName, value, rule.Condition, rule.Threshold))
+
+	attrs := lr.Attributes()
+	dp.Attributes().CopyTo(attrs)
+	attrs.PutStr(attributeRule, rule.Name)
+	attrs.PutStr(attributeState, state)
+	attrs.PutStr(attributeCondition, string(rule.Condition))
+	attrs.PutDouble(attributeThreshold, rule.Threshold)
+	attrs.PutStr(attributeMetric, metricName)
+	attrs.PutDouble(attributeValue, value)
+}
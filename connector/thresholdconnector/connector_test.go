@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package thresholdconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newGauge(name string, host string, value float64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", host)
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.Timestamp(10))
+	dp.Attributes().PutStr("device", "sda")
+	dp.SetDoubleValue(value)
+	return md
+}
+
+func TestMetricsToLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rules = []Rule{
+		{
+			Name:      "low_disk",
+			Metric:    "system.filesystem.free",
+			Condition: ConditionBelow,
+			Threshold: 100,
+			Severity:  Severity(plog.SeverityNumberError),
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	conn, err := NewFactory().CreateMetricsToLogs(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	conn.(*thresholdConnector).now = func() time.Time { return time.Unix(0, 20) }
+
+	ctx := context.Background()
+	// Within the threshold, nothing is emitted.
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("system.filesystem.free", "a", 200)))
+	assert.Empty(t, sink.AllLogs())
+
+	// Crossing the threshold emits a single record per stream.
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("system.filesystem.free", "a", 50)))
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("system.filesystem.free", "a", 40)))
+	require.Len(t, sink.AllLogs(), 1)
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"host.name": "a"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.Timestamp(10), lr.Timestamp())
+	assert.Equal(t, pcommon.Timestamp(20), lr.ObservedTimestamp())
+	assert.Equal(t, plog.SeverityNumberError, lr.SeverityNumber())
+	assert.Equal(t, "ERROR", lr.SeverityText())
+	assert.Equal(t, "low_disk firing: system.filesystem.free is 50, below threshold 100", lr.Body().Str())
+	assert.Equal(t, map[string]any{
+		"device":              "sda",
+		"threshold.rule":      "low_disk",
+		"threshold.state":     "firing",
+		"threshold.condition": "below",
+		"threshold.limit":     float64(100),
+		"metric.name":         "system.filesystem.free",
+		"metric.value":        float64(50),
+	}, lr.Attributes().AsRaw())
+
+	// Other streams are evaluated independently.
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("system.filesystem.free", "b", 10)))
+	require.Len(t, sink.AllLogs(), 2)
+
+	// Going back within the threshold resolves the stream.
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("system.filesystem.free", "a", 150)))
+	require.Len(t, sink.AllLogs(), 3)
+	lr = sink.AllLogs()[2].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	state, _ := lr.Attributes().Get("threshold.state")
+	assert.Equal(t, "resolved", state.Str())
+
+	// Other metrics are ignored.
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("system.memory.free", "a", 0)))
+	assert.Len(t, sink.AllLogs(), 3)
+}
+
+func TestMetricsToLogsWithoutResolved(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.EmitResolved = false
+	cfg.Rules = []Rule{
+		{
+			Name:       "high_usage",
+			Metric:     "usage",
+			Attributes: map[string]string{"device": "sda"},
+			Condition:  ConditionAbove,
+			Threshold:  0.9,
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	conn, err := NewFactory().CreateMetricsToLogs(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "a", 0.95)))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, plog.SeverityNumberWarn, sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityNumber())
+
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "a", 0.5)))
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "a", 0.99)))
+	assert.Len(t, sink.AllLogs(), 2)
+}
+
+func TestMetricsToLogsScopes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rules = []Rule{{Name: "high_usage", Metric: "usage", Condition: ConditionAbove, Threshold: 0.9}}
+	sink := new(consumertest.LogsSink)
+	conn, err := NewFactory().CreateMetricsToLogs(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	md := newGauge("usage", "a", 0.95)
+	require.NoError(t, conn.ConsumeMetrics(ctx, md))
+	require.Len(t, sink.AllLogs(), 1)
+
+	// The same data points reported by another scope are another stream.
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().SetName("other")
+	require.NoError(t, conn.ConsumeMetrics(ctx, md))
+	assert.Len(t, sink.AllLogs(), 2)
+}
+
+func TestMetricsToLogsEvictStale(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxStale = time.Minute
+	cfg.Rules = []Rule{{Name: "high_usage", Metric: "usage", Condition: ConditionAbove, Threshold: 0.9}}
+	sink := new(consumertest.LogsSink)
+	conn, err := NewFactory().CreateMetricsToLogs(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	tc := conn.(*thresholdConnector)
+	now := time.Unix(1000, 0)
+	tc.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "a", 0.95)))
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "b", 0.95)))
+	require.Len(t, sink.AllLogs(), 2)
+
+	// Stream "a" keeps receiving data points, stream "b" does not.
+	now = now.Add(45 * time.Second)
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "a", 0.96)))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "a", 0.97)))
+	assert.Len(t, tc.firing[0], 1)
+	assert.Len(t, sink.AllLogs(), 2)
+
+	// The evicted stream fires again when it comes back beyond the threshold.
+	require.NoError(t, conn.ConsumeMetrics(ctx, newGauge("usage", "b", 0.95)))
+	assert.Len(t, sink.AllLogs(), 3)
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package thresholdconnector emits log records when metrics cross configured thresholds.
+package thresholdconnector // import "go.opentelemetry.io/collector/connector/thresholdconnector"
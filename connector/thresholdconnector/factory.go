@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package thresholdconnector // import "go.opentelemetry.io/collector/connector/thresholdconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/thresholdconnector/internal/metadata"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// NewFactory returns a connector.Factory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithMetricsToLogs(createMetricsToLogs, metadata.MetricsToLogsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		EmitResolved: true,
+		MaxStale:     5 * time.Minute,
+	}
+}
+
+// createMetricsToLogs creates a metrics to logs connector based on provided config.
+func createMetricsToLogs(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Metrics, error) {
+	return newThresholdConnector(set, cfg.(*Config), nextConsumer), nil
+}
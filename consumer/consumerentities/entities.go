@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerentities // import "go.opentelemetry.io/collector/consumer/consumerentities"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal"
+	"go.opentelemetry.io/collector/pdata/pentity"
+)
+
+var errNilFunc = errors.New("nil consumer func")
+
+// Entities is an interface that receives pentity.Entities, processes it
+// as needed, and sends it to the next processing node if any or to the destination.
+type Entities interface {
+	internal.BaseConsumer
+	// ConsumeEntities receives pentity.Entities for consumption.
+	ConsumeEntities(ctx context.Context, ed pentity.Entities) error
+}
+
+// ConsumeEntitiesFunc is a helper function that is similar to ConsumeEntities.
+type ConsumeEntitiesFunc func(ctx context.Context, ed pentity.Entities) error
+
+// ConsumeEntities calls f(ctx, ed).
+func (f ConsumeEntitiesFunc) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	return f(ctx, ed)
+}
+
+type baseEntities struct {
+	*internal.BaseImpl
+	ConsumeEntitiesFunc
+}
+
+// NewEntities returns a Entities configured with the provided options.
+func NewEntities(consume ConsumeEntitiesFunc, options ...consumer.Option) (Entities, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	return &baseEntities{
+		BaseImpl:            internal.NewBaseImpl(options...),
+		ConsumeEntitiesFunc: consume,
+	}, nil
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerentities
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pentity"
+)
+
+func TestDefaultEntities(t *testing.T) {
+	cp, err := NewEntities(func(context.Context, pentity.Entities) error { return nil })
+	assert.NoError(t, err)
+	assert.NoError(t, cp.ConsumeEntities(context.Background(), pentity.NewEntities()))
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, cp.Capabilities())
+}
+
+func TestNilFuncEntities(t *testing.T) {
+	_, err := NewEntities(nil)
+	assert.Equal(t, errNilFunc, err)
+}
+
+func TestWithCapabilitiesEntities(t *testing.T) {
+	cp, err := NewEntities(
+		func(context.Context, pentity.Entities) error { return nil },
+		consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	assert.NoError(t, err)
+	assert.NoError(t, cp.ConsumeEntities(context.Background(), pentity.NewEntities()))
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, cp.Capabilities())
+}
+
+func TestConsumeEntities(t *testing.T) {
+	consumeCalled := false
+	cp, err := NewEntities(func(context.Context, pentity.Entities) error { consumeCalled = true; return nil })
+	assert.NoError(t, err)
+	assert.NoError(t, cp.ConsumeEntities(context.Background(), pentity.NewEntities()))
+	assert.True(t, consumeCalled)
+}
+
+func TestConsumeEntities_ReturnError(t *testing.T) {
+	want := errors.New("my_error")
+	cp, err := NewEntities(func(context.Context, pentity.Entities) error { return want })
+	assert.NoError(t, err)
+	assert.Equal(t, want, cp.ConsumeEntities(context.Background(), pentity.NewEntities()))
+}
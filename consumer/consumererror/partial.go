@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "errors"
+
+// partial is an error indicating that only a part of the received data
+// failed to be processed or sent.
+type partial struct {
+	err      error
+	rejected int
+}
+
+// NewPartial wraps an error to indicate that only the given number of items of the
+// received data (spans, data points or log records) failed to be processed or sent,
+// the other items being accepted. The rejected items may be carried by wrapping the
+// error with NewTraces, NewMetrics or NewLogs.
+func NewPartial(err error, rejected int) error {
+	return partial{err: err, rejected: rejected}
+}
+
+func (p partial) Error() string {
+	return p.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (p partial) Unwrap() error {
+	return p.err
+}
+
+// Rejected returns the number of items that failed to be processed or sent, if the
+// error was wrapped with the NewPartial function.
+func Rejected(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var p partial
+	if !errors.As(err, &p) {
+		return 0, false
+	}
+	return p.rejected, true
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestRejected(t *testing.T) {
+	_, ok := Rejected(nil)
+	assert.False(t, ok)
+
+	err := errors.New("testError")
+	_, ok = Rejected(err)
+	assert.False(t, ok)
+
+	partialErr := NewPartial(err, 3)
+	assert.Equal(t, err.Error(), partialErr.Error())
+	rejected, ok := Rejected(partialErr)
+	assert.True(t, ok)
+	assert.Equal(t, 3, rejected)
+
+	rejected, ok = Rejected(fmt.Errorf("%w", NewPermanent(partialErr)))
+	assert.True(t, ok)
+	assert.Equal(t, 3, rejected)
+}
+
+func TestPartial_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	td := testdata.GenerateTraces(2)
+	partialErr := NewPartial(NewTraces(err, td), 2)
+
+	target := testErrorType{}
+	require.True(t, errors.As(partialErr, &target))
+	require.Equal(t, err, target)
+
+	var tracesErr Traces
+	require.True(t, errors.As(partialErr, &tracesErr))
+	assert.Equal(t, td, tracesErr.Data())
+}
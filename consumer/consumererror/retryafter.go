@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+	"time"
+)
+
+// retryAfter is an error requesting a delay before the failed data is retried.
+type retryAfter struct {
+	err   error
+	delay time.Duration
+}
+
+// NewRetryAfter wraps an error to indicate that the data that failed can be retried,
+// but not sooner than the given delay, e.g. because its destination is throttling.
+func NewRetryAfter(err error, delay time.Duration) error {
+	return retryAfter{err: err, delay: delay}
+}
+
+func (r retryAfter) Error() string {
+	return "Retry after " + r.delay.String() + ": " + r.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (r retryAfter) Unwrap() error {
+	return r.err
+}
+
+// RetryAfter returns the delay requested before retrying the data that failed, if the
+// error was wrapped with the NewRetryAfter function.
+func RetryAfter(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var r retryAfter
+	if !errors.As(err, &r) {
+		return 0, false
+	}
+	return r.delay, true
+}
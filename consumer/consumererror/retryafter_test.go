@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	_, ok := RetryAfter(nil)
+	assert.False(t, ok)
+
+	err := errors.New("testError")
+	_, ok = RetryAfter(err)
+	assert.False(t, ok)
+
+	retryErr := NewRetryAfter(err, 5*time.Second)
+	assert.Equal(t, "Retry after 5s: testError", retryErr.Error())
+	delay, ok := RetryAfter(fmt.Errorf("%w", retryErr))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+	assert.False(t, IsPermanent(retryErr))
+}
+
+func TestRetryAfter_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	retryErr := NewRetryAfter(err, time.Second)
+
+	target := testErrorType{}
+	require.True(t, errors.As(retryErr, &target))
+	require.Equal(t, err, target)
+}
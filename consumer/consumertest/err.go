@@ -5,6 +5,7 @@ package consumertest // import "go.opentelemetry.io/collector/consumer/consumert
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -18,5 +19,6 @@ func NewErr(err error) Consumer {
 		ConsumeMetricsFunc:  func(context.Context, pmetric.Metrics) error { return err },
 		ConsumeLogsFunc:     func(context.Context, plog.Logs) error { return err },
 		ConsumeProfilesFunc: func(context.Context, pprofile.Profiles) error { return err },
+		ConsumeEntitiesFunc: func(context.Context, pentity.Entities) error { return err },
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -26,4 +27,5 @@ func TestErr(t *testing.T) {
 	assert.Equal(t, err, ec.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
 	assert.Equal(t, err, ec.ConsumeTraces(context.Background(), ptrace.NewTraces()))
 	assert.Equal(t, err, ec.ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	assert.Equal(t, err, ec.ConsumeEntities(context.Background(), pentity.NewEntities()))
 }
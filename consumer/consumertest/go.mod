@@ -7,8 +7,10 @@ replace go.opentelemetry.io/collector/consumer => ../
 require (
 	github.com/stretchr/testify v1.9.0
 	go.opentelemetry.io/collector/consumer v0.107.0
+	go.opentelemetry.io/collector/consumer/consumerentities v0.107.0
 	go.opentelemetry.io/collector/consumer/consumerprofiles v0.107.0
 	go.opentelemetry.io/collector/pdata v1.13.0
+	go.opentelemetry.io/collector/pdata/pentity v0.107.0
 	go.opentelemetry.io/collector/pdata/pprofile v0.107.0
 	go.opentelemetry.io/collector/pdata/testdata v0.107.0
 	go.uber.org/goleak v1.3.0
@@ -33,8 +35,12 @@ require (
 
 replace go.opentelemetry.io/collector/pdata/pprofile => ../../pdata/pprofile
 
+replace go.opentelemetry.io/collector/pdata/pentity => ../../pdata/pentity
+
 replace go.opentelemetry.io/collector/pdata => ../../pdata
 
 replace go.opentelemetry.io/collector/consumer/consumerprofiles => ../consumerprofiles
 
+replace go.opentelemetry.io/collector/consumer/consumerentities => ../consumerentities
+
 replace go.opentelemetry.io/collector/pdata/testdata => ../../pdata/testdata
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -24,4 +25,5 @@ func TestNop(t *testing.T) {
 	assert.NoError(t, nc.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
 	assert.NoError(t, nc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
 	assert.NoError(t, nc.ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	assert.NoError(t, nc.ConsumeEntities(context.Background(), pentity.NewEntities()))
 }
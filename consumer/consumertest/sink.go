@@ -8,7 +8,9 @@ import (
 	"sync"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -196,3 +198,51 @@ func (ste *ProfilesSink) Reset() {
 
 	ste.profiles = nil
 }
+
+// EntitiesSink is a consumerentities.Entities that acts like a sink that
+// stores all entity events and allows querying them for testing.
+type EntitiesSink struct {
+	nonMutatingConsumer
+	mu          sync.Mutex
+	entities    []pentity.Entities
+	eventsCount int
+}
+
+var _ consumerentities.Entities = (*EntitiesSink)(nil)
+
+// ConsumeEntities stores entity events to this sink.
+func (ste *EntitiesSink) ConsumeEntities(_ context.Context, ed pentity.Entities) error {
+	ste.mu.Lock()
+	defer ste.mu.Unlock()
+
+	ste.entities = append(ste.entities, ed)
+	ste.eventsCount += ed.EventCount()
+
+	return nil
+}
+
+// AllEntities returns the entity events stored by this sink since last Reset.
+func (ste *EntitiesSink) AllEntities() []pentity.Entities {
+	ste.mu.Lock()
+	defer ste.mu.Unlock()
+
+	copyEntities := make([]pentity.Entities, len(ste.entities))
+	copy(copyEntities, ste.entities)
+	return copyEntities
+}
+
+// EventCount returns the number of entity events stored by this sink since last Reset.
+func (ste *EntitiesSink) EventCount() int {
+	ste.mu.Lock()
+	defer ste.mu.Unlock()
+	return ste.eventsCount
+}
+
+// Reset deletes any stored data.
+func (ste *EntitiesSink) Reset() {
+	ste.mu.Lock()
+	defer ste.mu.Unlock()
+
+	ste.entities = nil
+	ste.eventsCount = 0
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -74,3 +75,19 @@ func TestProfilesSink(t *testing.T) {
 	sink.Reset()
 	assert.Equal(t, 0, len(sink.AllProfiles()))
 }
+
+func TestEntitiesSink(t *testing.T) {
+	sink := new(EntitiesSink)
+	ed := pentity.NewEntities()
+	ed.ResourceEntities().AppendEmpty().ScopeEntities().AppendEmpty().EntityEvents().AppendEmpty()
+	want := make([]pentity.Entities, 0, 7)
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sink.ConsumeEntities(context.Background(), ed))
+		want = append(want, ed)
+	}
+	assert.Equal(t, want, sink.AllEntities())
+	assert.Equal(t, 7, sink.EventCount())
+	sink.Reset()
+	assert.Equal(t, 0, len(sink.AllEntities()))
+	assert.Equal(t, 0, sink.EventCount())
+}
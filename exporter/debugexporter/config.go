@@ -4,6 +4,7 @@
 package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexporter"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -33,6 +34,47 @@ type Config struct {
 
 	// UseInternalLogger defines whether the exporter sends the output to the collector's internal logger.
 	UseInternalLogger bool `mapstructure:"use_internal_logger"`
+
+	// OutputFormat defines the format of the output, "text" or "json".
+	OutputFormat OutputFormat `mapstructure:"output_format"`
+
+	// OutputSampling defines the sampling of the output batches, regardless of the verbosity.
+	OutputSampling OutputSamplingConfig `mapstructure:"output_sampling"`
+
+	// Filter defines the data output by the exporter.
+	Filter FilterConfig `mapstructure:"filter"`
+}
+
+// OutputFormat is the format of the output of the exporter.
+type OutputFormat string
+
+const (
+	// OutputFormatText outputs the data as human-readable text.
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON outputs a single JSON line per batch, holding the OTLP JSON encoding of the data
+	// for the normal and detailed verbosity levels.
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// OutputSamplingConfig defines which batches are output: the first ones, then one batch out of Thereafter.
+// The sampling is disabled when both values are zero.
+type OutputSamplingConfig struct {
+	// First is the number of batches output before sampling begins.
+	First int `mapstructure:"first"`
+
+	// Thereafter defines the sampling rate once the first batches are output, every Mth batch is output.
+	// Zero means that no more batches are output.
+	Thereafter int `mapstructure:"thereafter"`
+}
+
+// FilterConfig defines the data output by the exporter.
+type FilterConfig struct {
+	// Signals lists the signals output by the exporter, among "traces", "metrics" and "logs".
+	// All the signals are output if empty.
+	Signals []string `mapstructure:"signals,omitempty"`
+
+	// ResourceAttributes restricts the output to the resources having all these attribute values.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -43,5 +85,23 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("verbosity level %q is not supported", cfg.Verbosity)
 	}
 
+	switch cfg.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		return fmt.Errorf("output format %q is not supported", cfg.OutputFormat)
+	}
+
+	if cfg.OutputSampling.First < 0 || cfg.OutputSampling.Thereafter < 0 {
+		return errors.New("output_sampling values must not be negative")
+	}
+
+	for _, signal := range cfg.Filter.Signals {
+		switch signal {
+		case component.DataTypeTraces.String(), component.DataTypeMetrics.String(), component.DataTypeLogs.String():
+		default:
+			return fmt.Errorf("unknown signal %q in filter signals", signal)
+		}
+	}
+
 	return nil
 }
@@ -34,6 +34,25 @@ func TestUnmarshalConfig(t *testing.T) {
 				Verbosity:          configtelemetry.LevelDetailed,
 				SamplingInitial:    10,
 				SamplingThereafter: 50,
+				OutputFormat:       OutputFormatText,
+			},
+		},
+		{
+			filename: "config_output.yaml",
+			cfg: &Config{
+				Verbosity:          configtelemetry.LevelNormal,
+				SamplingInitial:    defaultSamplingInitial,
+				SamplingThereafter: defaultSamplingThereafter,
+				UseInternalLogger:  true,
+				OutputFormat:       OutputFormatJSON,
+				OutputSampling: OutputSamplingConfig{
+					First:      10,
+					Thereafter: 100,
+				},
+				Filter: FilterConfig{
+					Signals:            []string{"traces", "logs"},
+					ResourceAttributes: map[string]string{"service.name": "checkout"},
+				},
 			},
 		},
 		{
@@ -122,6 +141,30 @@ func TestValidate(t *testing.T) {
 				Verbosity: configtelemetry.LevelDetailed,
 			},
 		},
+		{
+			name: "unknown output format",
+			cfg: &Config{
+				Verbosity:    configtelemetry.LevelBasic,
+				OutputFormat: "yaml",
+			},
+			expectedErr: "output format \"yaml\" is not supported",
+		},
+		{
+			name: "negative output sampling",
+			cfg: &Config{
+				Verbosity:      configtelemetry.LevelBasic,
+				OutputSampling: OutputSamplingConfig{First: -1},
+			},
+			expectedErr: "output_sampling values must not be negative",
+		},
+		{
+			name: "unknown filter signal",
+			cfg: &Config{
+				Verbosity: configtelemetry.LevelBasic,
+				Filter:    FilterConfig{Signals: []string{"profiles"}},
+			},
+			expectedErr: "unknown signal \"profiles\" in filter signals",
+		},
 	}
 
 	for _, tt := range tests {
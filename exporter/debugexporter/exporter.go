@@ -9,9 +9,11 @@ package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexp
 
 import (
 	"context"
+	"encoding/json"
 
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/exporter/debugexporter/internal/normal"
 	"go.opentelemetry.io/collector/exporter/internal/otlptext"
@@ -22,80 +24,118 @@ import (
 
 type debugExporter struct {
 	verbosity        configtelemetry.Level
+	outputFormat     OutputFormat
 	logger           *zap.Logger
 	logsMarshaler    plog.Marshaler
 	metricsMarshaler pmetric.Marshaler
 	tracesMarshaler  ptrace.Marshaler
+
+	sampler *batchSampler
+	// signals holds the signals to output, nil if all the signals are output.
+	signals map[string]bool
+	filter  resourceFilter
 }
 
-func newDebugExporter(logger *zap.Logger, verbosity configtelemetry.Level) *debugExporter {
+func newDebugExporter(logger *zap.Logger, cfg *Config) *debugExporter {
 	var logsMarshaler plog.Marshaler
 	var metricsMarshaler pmetric.Marshaler
 	var tracesMarshaler ptrace.Marshaler
-	if verbosity == configtelemetry.LevelDetailed {
+	switch {
+	case cfg.OutputFormat == OutputFormatJSON:
+		logsMarshaler = &plog.JSONMarshaler{}
+		metricsMarshaler = &pmetric.JSONMarshaler{}
+		tracesMarshaler = &ptrace.JSONMarshaler{}
+	case cfg.Verbosity == configtelemetry.LevelDetailed:
 		logsMarshaler = otlptext.NewTextLogsMarshaler()
 		metricsMarshaler = otlptext.NewTextMetricsMarshaler()
 		tracesMarshaler = otlptext.NewTextTracesMarshaler()
-	} else {
+	default:
 		logsMarshaler = normal.NewNormalLogsMarshaler()
 		metricsMarshaler = normal.NewNormalMetricsMarshaler()
 		tracesMarshaler = normal.NewNormalTracesMarshaler()
 	}
+	var signals map[string]bool
+	if len(cfg.Filter.Signals) > 0 {
+		signals = make(map[string]bool, len(cfg.Filter.Signals))
+		for _, signal := range cfg.Filter.Signals {
+			signals[signal] = true
+		}
+	}
 	return &debugExporter{
-		verbosity:        verbosity,
+		verbosity:        cfg.Verbosity,
+		outputFormat:     cfg.OutputFormat,
 		logger:           logger,
 		logsMarshaler:    logsMarshaler,
 		metricsMarshaler: metricsMarshaler,
 		tracesMarshaler:  tracesMarshaler,
+		sampler:          newBatchSampler(cfg.OutputSampling),
+		signals:          signals,
+		filter:           resourceFilter(cfg.Filter.ResourceAttributes),
 	}
 }
 
-func (s *debugExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
-	s.logger.Info("TracesExporter",
-		zap.Int("resource spans", td.ResourceSpans().Len()),
-		zap.Int("spans", td.SpanCount()))
-	if s.verbosity == configtelemetry.LevelBasic {
-		return nil
+// outputs returns true if the batch of the given signal, holding the given number of resources once filtered,
+// must be output.
+func (s *debugExporter) outputs(dataType component.DataType, resources int) bool {
+	if s.signals != nil && !s.signals[dataType.String()] {
+		return false
 	}
-
-	buf, err := s.tracesMarshaler.MarshalTraces(td)
-	if err != nil {
-		return err
+	if len(s.filter) > 0 && resources == 0 {
+		return false
 	}
-	s.logger.Info(string(buf))
-	return nil
+	return s.sampler.sample()
 }
 
-func (s *debugExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
-	s.logger.Info("MetricsExporter",
-		zap.Int("resource metrics", md.ResourceMetrics().Len()),
-		zap.Int("metrics", md.MetricCount()),
-		zap.Int("data points", md.DataPointCount()))
+// output writes the summary of a batch and, depending on the verbosity, its content marshaled by marshal.
+// With the JSON output format, both are written as a single entry, the content in the "data" field.
+func (s *debugExporter) output(msg string, marshal func() ([]byte, error), summary ...zap.Field) error {
 	if s.verbosity == configtelemetry.LevelBasic {
+		s.logger.Info(msg, summary...)
 		return nil
 	}
-
-	buf, err := s.metricsMarshaler.MarshalMetrics(md)
+	buf, err := marshal()
 	if err != nil {
 		return err
 	}
+	if s.outputFormat == OutputFormatJSON {
+		s.logger.Info(msg, append(summary, zap.Reflect("data", json.RawMessage(buf)))...)
+		return nil
+	}
+	s.logger.Info(msg, summary...)
 	s.logger.Info(string(buf))
 	return nil
 }
 
-func (s *debugExporter) pushLogs(_ context.Context, ld plog.Logs) error {
-	s.logger.Info("LogsExporter",
-		zap.Int("resource logs", ld.ResourceLogs().Len()),
-		zap.Int("log records", ld.LogRecordCount()))
+func (s *debugExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	td = s.filter.filterTraces(td)
+	if !s.outputs(component.DataTypeTraces, td.ResourceSpans().Len()) {
+		return nil
+	}
+	return s.output("TracesExporter",
+		func() ([]byte, error) { return s.tracesMarshaler.MarshalTraces(td) },
+		zap.Int("resource spans", td.ResourceSpans().Len()),
+		zap.Int("spans", td.SpanCount()))
+}
 
-	if s.verbosity == configtelemetry.LevelBasic {
+func (s *debugExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	md = s.filter.filterMetrics(md)
+	if !s.outputs(component.DataTypeMetrics, md.ResourceMetrics().Len()) {
 		return nil
 	}
+	return s.output("MetricsExporter",
+		func() ([]byte, error) { return s.metricsMarshaler.MarshalMetrics(md) },
+		zap.Int("resource metrics", md.ResourceMetrics().Len()),
+		zap.Int("metrics", md.MetricCount()),
+		zap.Int("data points", md.DataPointCount()))
+}
 
-	buf, err := s.logsMarshaler.MarshalLogs(ld)
-	if err != nil {
-		return err
+func (s *debugExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	ld = s.filter.filterLogs(ld)
+	if !s.outputs(component.DataTypeLogs, ld.ResourceLogs().Len()) {
+		return nil
 	}
-	s.logger.Info(string(buf))
-	return nil
+	return s.output("LogsExporter",
+		func() ([]byte, error) { return s.logsMarshaler.MarshalLogs(ld) },
+		zap.Int("resource logs", ld.ResourceLogs().Len()),
+		zap.Int("log records", ld.LogRecordCount()))
 }
@@ -5,12 +5,15 @@ package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/exporter/exportertest"
@@ -69,7 +72,7 @@ func TestLogsExporterNoErrors(t *testing.T) {
 }
 
 func TestExporterErrors(t *testing.T) {
-	le := newDebugExporter(zaptest.NewLogger(t), configtelemetry.LevelDetailed)
+	le := newDebugExporter(zaptest.NewLogger(t), &Config{Verbosity: configtelemetry.LevelDetailed})
 	require.NotNil(t, le)
 
 	errWant := errors.New("my error")
@@ -81,6 +84,65 @@ func TestExporterErrors(t *testing.T) {
 	assert.Equal(t, errWant, le.pushLogs(context.Background(), plog.NewLogs()))
 }
 
+func TestExporterOutputSampling(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	le := newDebugExporter(zap.New(core), &Config{
+		Verbosity:      configtelemetry.LevelDetailed,
+		OutputSampling: OutputSamplingConfig{First: 2, Thereafter: 3},
+	})
+	for i := 0; i < 10; i++ {
+		require.NoError(t, le.pushTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	// Batches #1, #2, #5 and #8 are output, with both the summary and the details regardless of the verbosity.
+	assert.Equal(t, 8, logs.Len())
+	assert.Equal(t, 4, logs.FilterMessage("TracesExporter").Len())
+}
+
+func TestExporterFilter(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	le := newDebugExporter(zap.New(core), &Config{
+		Verbosity: configtelemetry.LevelBasic,
+		Filter: FilterConfig{
+			Signals:            []string{"traces", "logs"},
+			ResourceAttributes: map[string]string{"resource-attr": "resource-attr-val-1"},
+		},
+	})
+
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("resource-attr", "other")
+	require.NoError(t, le.pushTraces(context.Background(), td))
+	require.NoError(t, le.pushMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	ld := testdata.GenerateLogs(2)
+	ld.ResourceLogs().At(0).Resource().Attributes().PutStr("resource-attr", "other")
+	require.NoError(t, le.pushLogs(context.Background(), ld))
+
+	// Metrics are not output, and the logs do not match the filter.
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "TracesExporter", logs.All()[0].Message)
+	assert.Equal(t, map[string]any{"resource spans": int64(1), "spans": int64(2)}, logs.All()[0].ContextMap())
+	// The data is not mutated.
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+}
+
+func TestExporterJSONOutput(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	le := newDebugExporter(zap.New(core), &Config{
+		Verbosity:    configtelemetry.LevelNormal,
+		OutputFormat: OutputFormatJSON,
+	})
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, le.pushTraces(context.Background(), td))
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, int64(2), fields["spans"])
+	raw, ok := fields["data"].(json.RawMessage)
+	require.True(t, ok)
+	got, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(raw)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
+
 type testCase struct {
 	name   string
 	config *Config
@@ -102,6 +164,16 @@ func createTestCases() []testCase {
 				return cfg
 			}(),
 		},
+		{
+			name: "json output without internal logger",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Verbosity = configtelemetry.LevelDetailed
+				cfg.OutputFormat = OutputFormatJSON
+				cfg.UseInternalLogger = false
+				return cfg
+			}(),
+		},
 	}
 }
 
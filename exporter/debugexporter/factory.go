@@ -44,13 +44,14 @@ func createDefaultConfig() component.Config {
 		SamplingInitial:    defaultSamplingInitial,
 		SamplingThereafter: defaultSamplingThereafter,
 		UseInternalLogger:  true,
+		OutputFormat:       OutputFormatText,
 	}
 }
 
 func createTracesExporter(ctx context.Context, set exporter.Settings, config component.Config) (exporter.Traces, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debugExporter := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debugExporter := newDebugExporter(exporterLogger, cfg)
 	return exporterhelper.NewTracesExporter(ctx, set, config,
 		debugExporter.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -62,7 +63,7 @@ func createTracesExporter(ctx context.Context, set exporter.Settings, config com
 func createMetricsExporter(ctx context.Context, set exporter.Settings, config component.Config) (exporter.Metrics, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debugExporter := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debugExporter := newDebugExporter(exporterLogger, cfg)
 	return exporterhelper.NewMetricsExporter(ctx, set, config,
 		debugExporter.pushMetrics,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -74,7 +75,7 @@ func createMetricsExporter(ctx context.Context, set exporter.Settings, config co
 func createLogsExporter(ctx context.Context, set exporter.Settings, config component.Config) (exporter.Logs, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debugExporter := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debugExporter := newDebugExporter(exporterLogger, cfg)
 	return exporterhelper.NewLogsExporter(ctx, set, config,
 		debugExporter.pushLogs,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -100,7 +101,12 @@ func createLogger(cfg *Config, logger *zap.Logger) *zap.Logger {
 }
 
 func createCustomLogger(exporterConfig *Config) *zap.Logger {
+	encoding := "console"
 	encoderConfig := zap.NewDevelopmentEncoderConfig()
+	if exporterConfig.OutputFormat == OutputFormatJSON {
+		encoding = "json"
+		encoderConfig = zap.NewProductionEncoderConfig()
+	}
 	// Do not prefix the output with log level (`info`)
 	encoderConfig.LevelKey = ""
 	// Do not prefix the output with current timestamp.
@@ -112,7 +118,7 @@ func createCustomLogger(exporterConfig *Config) *zap.Logger {
 			Initial:    exporterConfig.SamplingInitial,
 			Thereafter: exporterConfig.SamplingThereafter,
 		},
-		Encoding:      "console",
+		Encoding:      encoding,
 		EncoderConfig: encoderConfig,
 		// Send exporter's output to stdout. This should be made configurable.
 		OutputPaths: []string{"stdout"},
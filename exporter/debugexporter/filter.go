@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// resourceFilter keeps the resources having all the configured attribute values.
+// The data is copied when some resources are filtered out, since the exporter does not mutate the data.
+type resourceFilter map[string]string
+
+func (f resourceFilter) matches(res pcommon.Resource) bool {
+	for k, v := range f {
+		attr, ok := res.Attributes().Get(k)
+		if !ok || attr.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (f resourceFilter) filterTraces(td ptrace.Traces) ptrace.Traces {
+	if len(f) == 0 {
+		return td
+	}
+	filtered := ptrace.NewTraces()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		if f.matches(rss.At(i).Resource()) {
+			rss.At(i).CopyTo(filtered.ResourceSpans().AppendEmpty())
+		}
+	}
+	return filtered
+}
+
+func (f resourceFilter) filterMetrics(md pmetric.Metrics) pmetric.Metrics {
+	if len(f) == 0 {
+		return md
+	}
+	filtered := pmetric.NewMetrics()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		if f.matches(rms.At(i).Resource()) {
+			rms.At(i).CopyTo(filtered.ResourceMetrics().AppendEmpty())
+		}
+	}
+	return filtered
+}
+
+func (f resourceFilter) filterLogs(ld plog.Logs) plog.Logs {
+	if len(f) == 0 {
+		return ld
+	}
+	filtered := plog.NewLogs()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		if f.matches(rls.At(i).Resource()) {
+			rls.At(i).CopyTo(filtered.ResourceLogs().AppendEmpty())
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexporter"
+
+import (
+	"sync/atomic"
+)
+
+// batchSampler selects the batches to output: the first batches, then one batch out of `thereafter`.
+// Unlike the sampling of the logger, it applies to the whole output of a batch regardless of the verbosity.
+type batchSampler struct {
+	first      uint64
+	thereafter uint64
+	count      atomic.Uint64
+}
+
+// newBatchSampler returns a sampler for the given configuration, or nil if the sampling is disabled.
+func newBatchSampler(cfg OutputSamplingConfig) *batchSampler {
+	if cfg.First == 0 && cfg.Thereafter == 0 {
+		return nil
+	}
+	return &batchSampler{first: uint64(cfg.First), thereafter: uint64(cfg.Thereafter)}
+}
+
+// sample returns true if the next batch must be output.
+func (s *batchSampler) sample() bool {
+	if s == nil {
+		return true
+	}
+	n := s.count.Add(1)
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
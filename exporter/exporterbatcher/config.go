@@ -5,10 +5,12 @@ package exporterbatcher // import "go.opentelemetry.io/collector/exporter/export
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
-// Config defines a configuration for batching requests based on a timeout and a minimum number of items.
+// Config defines a configuration for batching requests based on a timeout and a minimum number of items or bytes.
 // MaxSizeItems defines batch splitting functionality if it's more than zero.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
@@ -21,6 +23,16 @@ type Config struct {
 
 	MinSizeConfig `mapstructure:",squash"`
 	MaxSizeConfig `mapstructure:",squash"`
+
+	// MetadataKeys is a list of client.Metadata keys used to partition the batches. When this setting is not empty,
+	// requests are only batched with requests having the same values for the listed metadata keys, and the batches
+	// are exported with a context holding these metadata values. Empty value and unset metadata are treated as
+	// distinct cases. Entries are case-insensitive.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// MetadataCardinalityLimit is the maximum number of distinct combinations of MetadataKeys values.
+	// Requests with a new combination are rejected once it is reached. Zero means no limit.
+	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
 }
 
 // MinSizeConfig defines the configuration for the minimum number of items in a batch.
@@ -31,6 +43,11 @@ type MinSizeConfig struct {
 	// sent regardless of the timeout. There is no guarantee that the batch size always greater than this value.
 	// This option requires the Request to implement RequestItemsCounter interface. Otherwise, it will be ignored.
 	MinSizeItems int `mapstructure:"min_size_items"`
+
+	// MinSizeBytes is the size in bytes at which the batch should be sent regardless of the timeout.
+	// Setting this value to zero disables the condition.
+	// This option requires the Request to implement RequestBytesSizer interface. Otherwise, it will be ignored.
+	MinSizeBytes int `mapstructure:"min_size_bytes"`
 }
 
 // MaxSizeConfig defines the configuration for the maximum number of items in a batch.
@@ -41,6 +58,12 @@ type MaxSizeConfig struct {
 	// If the batch size exceeds this value, it will be broken up into smaller batches if possible.
 	// Setting this value to zero disables the maximum size limit.
 	MaxSizeItems int `mapstructure:"max_size_items"`
+
+	// MaxSizeBytes is the maximum size of the batch in bytes. Requests are not merged into a batch if the result
+	// would exceed this value; the batch is sent first instead. A single request larger than this value is not split.
+	// Setting this value to zero disables the maximum size limit.
+	// This option requires the Request to implement RequestBytesSizer interface. Otherwise, it will be ignored.
+	MaxSizeBytes int `mapstructure:"max_size_bytes"`
 }
 
 func (c Config) Validate() error {
@@ -53,9 +76,26 @@ func (c Config) Validate() error {
 	if c.MaxSizeItems != 0 && c.MaxSizeItems < c.MinSizeItems {
 		return errors.New("max_size_items must be greater than or equal to min_size_items")
 	}
+	if c.MinSizeBytes < 0 {
+		return errors.New("min_size_bytes must be greater than or equal to zero")
+	}
+	if c.MaxSizeBytes < 0 {
+		return errors.New("max_size_bytes must be greater than or equal to zero")
+	}
+	if c.MaxSizeBytes != 0 && c.MaxSizeBytes < c.MinSizeBytes {
+		return errors.New("max_size_bytes must be greater than or equal to min_size_bytes")
+	}
 	if c.FlushTimeout <= 0 {
 		return errors.New("timeout must be greater than zero")
 	}
+	uniq := map[string]bool{}
+	for _, k := range c.MetadataKeys {
+		l := strings.ToLower(k)
+		if uniq[l] {
+			return fmt.Errorf("duplicate entry in metadata_keys: %q (case-insensitive)", l)
+		}
+		uniq[l] = true
+	}
 	return nil
 }
 
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterentities // import "go.opentelemetry.io/collector/exporter/exporterentities"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/internal"
+)
+
+// Entities is an exporter that can consume entity events.
+type Entities = internal.Entities
+
+// CreateEntitiesFunc is the equivalent of Factory.CreateEntities.
+type CreateEntitiesFunc = internal.CreateEntitiesFunc
+
+// WithEntities overrides the default "error not supported" implementation for CreateEntitiesExporter and the default "undefined" stability level.
+func WithEntities(createEntities CreateEntitiesFunc, sl component.StabilityLevel) exporter.FactoryOption {
+	return internal.WithEntities(createEntities, sl)
+}
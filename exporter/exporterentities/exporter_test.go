@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterentities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func TestNewFactoryWithEntities(t *testing.T) {
+	var testType = component.MustNewType("test")
+	defaultCfg := struct{}{}
+	factory := exporter.NewFactory(
+		testType,
+		func() component.Config { return &defaultCfg },
+		WithEntities(createEntities, component.StabilityLevelDevelopment),
+	)
+	assert.EqualValues(t, testType, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.EntitiesExporterStability())
+	_, err := factory.CreateEntitiesExporter(context.Background(), exporter.Settings{}, &defaultCfg)
+	assert.NoError(t, err)
+}
+
+var nopInstance = &nopExporter{
+	Consumer: consumertest.NewNop(),
+}
+
+// nopExporter stores consumed entities for testing purposes.
+type nopExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+}
+
+func createEntities(context.Context, exporter.Settings, component.Config) (Entities, error) {
+	return nopInstance, nil
+}
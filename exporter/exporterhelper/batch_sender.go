@@ -5,22 +5,33 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 )
 
+// errTooManyBatchPartitions is returned when the MetadataCardinalityLimit has been reached.
+var errTooManyBatchPartitions = consumererror.NewPermanent(errors.New("too many batch metadata-value combinations"))
+
 // batchSender is a component that places requests into batches before passing them to the downstream senders.
 // Batches are sent out with any of the following conditions:
-// - batch size reaches cfg.MinSizeItems
+// - batch size reaches cfg.MinSizeItems or cfg.MinSizeBytes
+// - merging the next request would exceed cfg.MaxSizeBytes
 // - cfg.FlushTimeout is elapsed since the timestamp when the previous batch was sent out.
 // - concurrencyLimit is reached.
+// When cfg.MetadataKeys is set, requests are batched in a distinct partition per combination of metadata values.
 type batchSender struct {
 	baseRequestSender
 	cfg            exporterbatcher.Config
@@ -33,9 +44,11 @@ type batchSender struct {
 	concurrencyLimit int64
 	activeRequests   atomic.Int64
 
-	mu          sync.Mutex
-	activeBatch *batch
-	lastFlushed time.Time
+	metadataKeys []string
+
+	// mu protects the partitions and their active batches.
+	mu         sync.Mutex
+	partitions map[attribute.Set]*batchPartition
 
 	logger *zap.Logger
 
@@ -47,9 +60,16 @@ type batchSender struct {
 // newBatchSender returns a new batch consumer component.
 func newBatchSender(cfg exporterbatcher.Config, set exporter.Settings,
 	mf exporterbatcher.BatchMergeFunc[Request], msf exporterbatcher.BatchMergeSplitFunc[Request]) *batchSender {
+	// use lower-case, to be consistent with http/2 headers.
+	mks := make([]string, len(cfg.MetadataKeys))
+	for i, k := range cfg.MetadataKeys {
+		mks[i] = strings.ToLower(k)
+	}
+	sort.Strings(mks)
 	bs := &batchSender{
-		activeBatch:        newEmptyBatch(),
 		cfg:                cfg,
+		metadataKeys:       mks,
+		partitions:         make(map[attribute.Set]*batchPartition),
 		logger:             set.Logger,
 		mergeFunc:          mf,
 		mergeSplitFunc:     msf,
@@ -71,8 +91,10 @@ func (bs *batchSender) Start(_ context.Context, _ component.Host) error {
 				// This loop will handle that case.
 				for bs.activeRequests.Load() > 0 {
 					bs.mu.Lock()
-					if bs.activeBatch.request != nil {
-						bs.exportActiveBatch()
+					for _, p := range bs.partitions {
+						if p.activeBatch.request != nil {
+							bs.exportActiveBatch(p)
+						}
 					}
 					bs.mu.Unlock()
 				}
@@ -84,12 +106,15 @@ func (bs *batchSender) Start(_ context.Context, _ component.Host) error {
 			case <-timer.C:
 				bs.mu.Lock()
 				nextFlush := bs.cfg.FlushTimeout
-				if bs.activeBatch.request != nil {
-					sinceLastFlush := time.Since(bs.lastFlushed)
+				for _, p := range bs.partitions {
+					if p.activeBatch.request == nil {
+						continue
+					}
+					sinceLastFlush := time.Since(p.lastFlushed)
 					if sinceLastFlush >= bs.cfg.FlushTimeout {
-						bs.exportActiveBatch()
+						bs.exportActiveBatch(p)
 					} else {
-						nextFlush = bs.cfg.FlushTimeout - sinceLastFlush
+						nextFlush = min(nextFlush, bs.cfg.FlushTimeout-sinceLastFlush)
 					}
 				}
 				bs.mu.Unlock()
@@ -101,11 +126,22 @@ func (bs *batchSender) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
+// batchPartition holds the active batch of the requests sharing the same values of the metadata keys.
+type batchPartition struct {
+	// metadata holds the values of the metadata keys, nil if no metadata keys are configured.
+	metadata    *client.Metadata
+	activeBatch *batch
+	lastFlushed time.Time
+}
+
 type batch struct {
 	ctx     context.Context
 	request Request
-	done    chan struct{}
-	err     error
+	// bytes is the running total of the sizes in bytes of the requests merged into the batch,
+	// only computed when a bytes limit is configured.
+	bytes int
+	done  chan struct{}
+	err   error
 
 	// requestsBlocked is the number of requests blocked in this batch
 	// that can be immediately released from activeRequests when batch sending completes.
@@ -119,26 +155,91 @@ func newEmptyBatch() *batch {
 	}
 }
 
-// exportActiveBatch exports the active batch asynchronously and replaces it with a new one.
+// partition returns the partition of the request with the given context, creating it if needed.
+// Caller must hold the lock.
+func (bs *batchSender) partition(ctx context.Context) (*batchPartition, error) {
+	if len(bs.metadataKeys) == 0 {
+		p, ok := bs.partitions[attribute.Set{}]
+		if !ok {
+			p = &batchPartition{activeBatch: newEmptyBatch()}
+			bs.partitions[attribute.Set{}] = p
+		}
+		return p, nil
+	}
+
+	// Get each metadata key value, form the corresponding attribute set for use as a map lookup key.
+	info := client.FromContext(ctx)
+	md := map[string][]string{}
+	var attrs []attribute.KeyValue
+	for _, k := range bs.metadataKeys {
+		// Lookup the value in the incoming metadata, copy it into the outgoing metadata, and create a unique
+		// value for the attribute set.
+		vs := info.Metadata.Get(k)
+		md[k] = vs
+		if len(vs) == 1 {
+			attrs = append(attrs, attribute.String(k, vs[0]))
+		} else {
+			attrs = append(attrs, attribute.StringSlice(k, vs))
+		}
+	}
+	aset := attribute.NewSet(attrs...)
+
+	p, ok := bs.partitions[aset]
+	if ok {
+		return p, nil
+	}
+	if bs.cfg.MetadataCardinalityLimit != 0 && len(bs.partitions) >= int(bs.cfg.MetadataCardinalityLimit) {
+		return nil, errTooManyBatchPartitions
+	}
+	metadata := client.NewMetadata(md)
+	p = &batchPartition{metadata: &metadata, activeBatch: newEmptyBatch()}
+	bs.partitions[aset] = p
+	return p, nil
+}
+
+// exportActiveBatch exports the active batch of the partition asynchronously and replaces it with a new one.
 // Caller must hold the lock.
-func (bs *batchSender) exportActiveBatch() {
+func (bs *batchSender) exportActiveBatch(p *batchPartition) {
 	go func(b *batch) {
 		b.err = bs.nextSender.send(b.ctx, b.request)
 		close(b.done)
 		bs.activeRequests.Add(-b.requestsBlocked)
-	}(bs.activeBatch)
-	bs.lastFlushed = time.Now()
-	bs.activeBatch = newEmptyBatch()
+	}(p.activeBatch)
+	p.lastFlushed = time.Now()
+	p.activeBatch = newEmptyBatch()
 }
 
-// isActiveBatchReady returns true if the active batch is ready to be exported.
+// isActiveBatchReady returns true if the active batch of the partition is ready to be exported.
 // The batch is ready if it has reached the minimum size or the concurrency limit is reached.
 // Caller must hold the lock.
-func (bs *batchSender) isActiveBatchReady() bool {
-	return bs.activeBatch.request.ItemsCount() >= bs.cfg.MinSizeItems ||
+func (bs *batchSender) isActiveBatchReady(p *batchPartition) bool {
+	return p.activeBatch.request.ItemsCount() >= bs.cfg.MinSizeItems ||
+		(bs.cfg.MinSizeBytes > 0 && p.activeBatch.bytes >= bs.cfg.MinSizeBytes) ||
 		(bs.concurrencyLimit > 0 && bs.activeRequests.Load() >= bs.concurrencyLimit)
 }
 
+// bytesLimited returns true if the size of the batches in bytes has to be computed.
+func (bs *batchSender) bytesLimited() bool {
+	return bs.cfg.MinSizeBytes > 0 || bs.cfg.MaxSizeBytes > 0
+}
+
+// requestBytes returns the size of the request in bytes, or zero if the size of the batches is not limited.
+func (bs *batchSender) requestBytes(req Request) int {
+	if !bs.bytesLimited() {
+		return 0
+	}
+	return requestBytesSize(req)
+}
+
+// flushIfTooLarge exports the active batch of the partition if merging a request of the given size into it
+// would exceed the maximum size in bytes. Caller must hold the lock.
+func (bs *batchSender) flushIfTooLarge(p *batchPartition, size int) {
+	if bs.cfg.MaxSizeBytes > 0 && p.activeBatch.request != nil &&
+		p.activeBatch.bytes+size > bs.cfg.MaxSizeBytes {
+		bs.exportActiveBatch(p)
+	}
+}
+
 func (bs *batchSender) send(ctx context.Context, req Request) error {
 	// Stopped batch sender should act as pass-through to allow the queue to be drained.
 	if bs.stopped.Load() {
@@ -155,7 +256,14 @@ func (bs *batchSender) send(ctx context.Context, req Request) error {
 func (bs *batchSender) sendMergeSplitBatch(ctx context.Context, req Request) error {
 	bs.mu.Lock()
 
-	reqs, err := bs.mergeSplitFunc(ctx, bs.cfg.MaxSizeConfig, bs.activeBatch.request, req)
+	p, err := bs.partition(ctx)
+	if err != nil {
+		bs.mu.Unlock()
+		return err
+	}
+	size := bs.requestBytes(req)
+	bs.flushIfTooLarge(p, size)
+	reqs, err := bs.mergeSplitFunc(ctx, bs.cfg.MaxSizeConfig, p.activeBatch.request, req)
 	if err != nil || len(reqs) == 0 {
 		bs.mu.Unlock()
 		return err
@@ -163,16 +271,16 @@ func (bs *batchSender) sendMergeSplitBatch(ctx context.Context, req Request) err
 
 	bs.activeRequests.Add(1)
 	if len(reqs) == 1 {
-		bs.activeBatch.requestsBlocked++
+		p.activeBatch.requestsBlocked++
 	} else {
 		// if there was a split, we want to make sure that bs.activeRequests is released once all of the parts are sent instead of using batch.requestsBlocked
 		defer bs.activeRequests.Add(-1)
 	}
-	if len(reqs) == 1 || bs.activeBatch.request != nil {
-		bs.updateActiveBatch(ctx, reqs[0])
-		batch := bs.activeBatch
-		if bs.isActiveBatchReady() || len(reqs) > 1 {
-			bs.exportActiveBatch()
+	if len(reqs) == 1 || p.activeBatch.request != nil {
+		bs.updateActiveBatch(ctx, p, reqs[0], size)
+		batch := p.activeBatch
+		if bs.isActiveBatchReady(p) || len(reqs) > 1 {
+			bs.exportActiveBatch(p)
 		}
 		bs.mu.Unlock()
 		<-batch.done
@@ -198,9 +306,15 @@ func (bs *batchSender) sendMergeSplitBatch(ctx context.Context, req Request) err
 func (bs *batchSender) sendMergeBatch(ctx context.Context, req Request) error {
 	bs.mu.Lock()
 
-	if bs.activeBatch.request != nil {
-		var err error
-		req, err = bs.mergeFunc(ctx, bs.activeBatch.request, req)
+	p, err := bs.partition(ctx)
+	if err != nil {
+		bs.mu.Unlock()
+		return err
+	}
+	size := bs.requestBytes(req)
+	bs.flushIfTooLarge(p, size)
+	if p.activeBatch.request != nil {
+		req, err = bs.mergeFunc(ctx, p.activeBatch.request, req)
 		if err != nil {
 			bs.mu.Unlock()
 			return err
@@ -208,26 +322,33 @@ func (bs *batchSender) sendMergeBatch(ctx context.Context, req Request) error {
 	}
 
 	bs.activeRequests.Add(1)
-	bs.updateActiveBatch(ctx, req)
-	batch := bs.activeBatch
+	bs.updateActiveBatch(ctx, p, req, size)
+	batch := p.activeBatch
 	batch.requestsBlocked++
-	if bs.isActiveBatchReady() {
-		bs.exportActiveBatch()
+	if bs.isActiveBatchReady(p) {
+		bs.exportActiveBatch(p)
 	}
 	bs.mu.Unlock()
 	<-batch.done
 	return batch.err
 }
 
-// updateActiveBatch update the active batch to the new merged request and context.
+// updateActiveBatch update the active batch of the partition to the new merged request and context,
+// adding the size of the request merged into it to the running total of the batch size in bytes.
 // The context is only set once and is not updated after the first call.
 // Merging the context would be complex and require an additional goroutine to handle the context cancellation.
 // We take the approach of using the context from the first request since it's likely to have the shortest timeout.
-func (bs *batchSender) updateActiveBatch(ctx context.Context, req Request) {
-	if bs.activeBatch.request == nil {
-		bs.activeBatch.ctx = ctx
+// If the batches are partitioned, the client metadata of the context is replaced with the metadata of the partition,
+// so the metadata of the first request other than the partition keys is not attributed to the whole batch.
+func (bs *batchSender) updateActiveBatch(ctx context.Context, p *batchPartition, req Request, size int) {
+	if p.activeBatch.request == nil {
+		if p.metadata != nil {
+			ctx = client.NewContext(ctx, client.Info{Metadata: *p.metadata})
+		}
+		p.activeBatch.ctx = ctx
 	}
-	bs.activeBatch.request = req
+	p.activeBatch.request = req
+	p.activeBatch.bytes += size
 }
 
 func (bs *batchSender) Shutdown(context.Context) error {
@@ -14,9 +14,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 func TestBatchSender_Merge(t *testing.T) {
@@ -710,3 +714,134 @@ func queueBatchExporter(t *testing.T, batchOption Option) *baseExporter {
 	require.NoError(t, err)
 	return be
 }
+
+type tracesBatchesSink struct {
+	mu       sync.Mutex
+	spans    []int
+	metadata []client.Metadata
+}
+
+func (s *tracesBatchesSink) consume(ctx context.Context, td ptrace.Traces) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spans = append(s.spans, td.SpanCount())
+	s.metadata = append(s.metadata, client.FromContext(ctx).Metadata)
+	return nil
+}
+
+func (s *tracesBatchesSink) batches() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.spans...)
+}
+
+func TestBatchSender_MetadataPartitions(t *testing.T) {
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.MinSizeItems = 4
+	cfg.FlushTimeout = time.Hour
+	cfg.MetadataKeys = []string{"Tenant"}
+	sink := &tracesBatchesSink{}
+	te, err := NewTracesExporter(context.Background(), defaultSettings, &fakeTracesExporterConfig, sink.consume, WithBatcher(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	wg := sync.WaitGroup{}
+	for _, tenant := range []string{"a", "b", "a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := client.NewContext(context.Background(), client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}, "other": {tenant}}),
+			})
+			assert.NoError(t, te.ConsumeTraces(ctx, testdata.GenerateTraces(2)))
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	// Each batch only holds the data of one tenant.
+	assert.Equal(t, []int{4, 4}, sink.batches())
+	var tenants []string
+	for _, md := range sink.metadata {
+		tenants = append(tenants, md.Get("tenant")...)
+		assert.Empty(t, md.Get("other"))
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, tenants)
+}
+
+func TestBatchSender_MetadataCardinalityLimit(t *testing.T) {
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.MinSizeItems = 1
+	cfg.MetadataKeys = []string{"tenant"}
+	cfg.MetadataCardinalityLimit = 1
+	sink := &tracesBatchesSink{}
+	te, err := NewTracesExporter(context.Background(), defaultSettings, &fakeTracesExporterConfig, sink.consume, WithBatcher(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	ctxA := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {"a"}})})
+	ctxB := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {"b"}})})
+	require.NoError(t, te.ConsumeTraces(ctxA, testdata.GenerateTraces(1)))
+	err = te.ConsumeTraces(ctxB, testdata.GenerateTraces(1))
+	require.ErrorIs(t, err, errTooManyBatchPartitions)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, []int{1}, sink.batches())
+}
+
+func TestBatchSender_MinSizeBytes(t *testing.T) {
+	reqSize := tracesMarshaler.TracesSize(testdata.GenerateTraces(1))
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.MinSizeItems = 1000
+	cfg.MinSizeBytes = 2 * reqSize
+	cfg.FlushTimeout = time.Hour
+	sink := &tracesBatchesSink{}
+	te, err := NewTracesExporter(context.Background(), defaultSettings, &fakeTracesExporterConfig, sink.consume, WithBatcher(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []int{2}, sink.batches())
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestBatchSender_MaxSizeBytes(t *testing.T) {
+	reqSize := tracesMarshaler.TracesSize(testdata.GenerateTraces(1))
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.MinSizeItems = 3
+	cfg.MaxSizeBytes = 2*reqSize + reqSize/2
+	cfg.FlushTimeout = 100 * time.Millisecond
+	sink := &tracesBatchesSink{}
+	te, err := NewTracesExporter(context.Background(), defaultSettings, &fakeTracesExporterConfig, sink.consume, WithBatcher(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		}()
+	}
+	// The third request does not fit in the batch, which is sent right away, before the flush timeout.
+	assert.Eventually(t, func() bool {
+		b := sink.batches()
+		return len(b) == 1 && b[0] == 2
+	}, 50*time.Millisecond, time.Millisecond)
+	wg.Wait()
+	assert.Equal(t, []int{2, 1}, sink.batches())
+}
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+// errCircuitOpen is returned for the requests short-circuited while the circuit breaker is open.
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerSettings configures the circuit breaker stopping the attempts to send data to a failing destination.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether to stop sending data while the destination is failing.
+	Enabled bool `mapstructure:"enabled"`
+	// ConsecutiveFailures is the number of consecutive failed attempts opening the circuit. Zero disables the trigger.
+	ConsecutiveFailures int `mapstructure:"consecutive_failures"`
+	// ErrorRate is the ratio of failed attempts, between 0 and 1, opening the circuit. Zero disables the trigger.
+	ErrorRate float64 `mapstructure:"error_rate"`
+	// MinAttempts is the minimum number of attempts in the window before the error rate is evaluated.
+	MinAttempts int `mapstructure:"min_attempts"`
+	// Window is the period the error rate is computed over.
+	Window time.Duration `mapstructure:"window"`
+	// CoolDown is the time the circuit stays open before an attempt is made to check if the destination recovered.
+	CoolDown time.Duration `mapstructure:"cool_down"`
+}
+
+// NewDefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func NewDefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:             false,
+		ConsecutiveFailures: 5,
+		ErrorRate:           0.5,
+		MinAttempts:         10,
+		Window:              time.Minute,
+		CoolDown:            30 * time.Second,
+	}
+}
+
+// Validate checks if the CircuitBreakerSettings configuration is valid.
+func (cbs *CircuitBreakerSettings) Validate() error {
+	if !cbs.Enabled {
+		return nil
+	}
+	if cbs.ConsecutiveFailures < 0 {
+		return errors.New("'consecutive_failures' must be non-negative")
+	}
+	if cbs.ErrorRate < 0 || cbs.ErrorRate > 1 {
+		return errors.New("'error_rate' must be between 0 and 1")
+	}
+	if cbs.ConsecutiveFailures == 0 && cbs.ErrorRate == 0 {
+		return errors.New("one of 'consecutive_failures' or 'error_rate' must be set")
+	}
+	if cbs.ErrorRate > 0 {
+		if cbs.MinAttempts < 0 {
+			return errors.New("'min_attempts' must be non-negative")
+		}
+		if cbs.Window <= 0 {
+			return errors.New("'window' must be positive")
+		}
+	}
+	if cbs.CoolDown <= 0 {
+		return errors.New("'cool_down' must be positive")
+	}
+	return nil
+}
+
+// CircuitState is the state of the circuit breaker of an exporter.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type CircuitState int
+
+const (
+	// CircuitClosed is the state of a circuit letting the requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen is the state of a circuit short-circuiting the requests until its cool-down period ends.
+	CircuitOpen
+	// CircuitHalfOpen is the state of a circuit letting a single request through to check if the destination recovered.
+	CircuitHalfOpen
+)
+
+// String returns the string representation of the CircuitState.
+func (cs CircuitState) String() string {
+	switch cs {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	}
+	return ""
+}
+
+// circuitBreakerSender is a requestSender short-circuiting the attempts to send the requests while the destination
+// is failing. The requests short-circuited fail with a retryable error asking to retry after the cool-down period,
+// so the retry sender holds them, and the queue keeps buffering the new requests, until the destination recovers.
+type circuitBreakerSender struct {
+	baseRequestSender
+	cfg    CircuitBreakerSettings
+	logger *zap.Logger
+	obsrep *obsReport
+	now    func() time.Time
+
+	mu                  sync.Mutex
+	state               CircuitState
+	openedAt            time.Time
+	probing             bool
+	consecutiveFailures int
+	windowStart         time.Time
+	attempts            int
+	failures            int
+}
+
+func newCircuitBreakerSender(cfg CircuitBreakerSettings, set exporter.Settings, obsrep *obsReport) *circuitBreakerSender {
+	return &circuitBreakerSender{
+		cfg:    cfg,
+		logger: set.Logger,
+		obsrep: obsrep,
+		now:    time.Now,
+	}
+}
+
+// send implements the requestSender interface
+func (cbs *circuitBreakerSender) send(ctx context.Context, req Request) error {
+	if delay, ok := cbs.allow(ctx); !ok {
+		return NewThrottleRetry(errCircuitOpen, delay)
+	}
+	err := cbs.nextSender.send(ctx, req)
+	// Permanent errors are caused by the data, they tell nothing about the destination.
+	if err != nil && consumererror.IsPermanent(err) {
+		cbs.release()
+		return err
+	}
+	cbs.record(ctx, err == nil)
+	return err
+}
+
+// allow returns whether a request can be sent, or the time left before the next attempt otherwise.
+func (cbs *circuitBreakerSender) allow(ctx context.Context) (time.Duration, bool) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	switch cbs.state {
+	case CircuitOpen:
+		remaining := cbs.openedAt.Add(cbs.cfg.CoolDown).Sub(cbs.now())
+		if remaining > 0 {
+			return remaining, false
+		}
+		cbs.setState(ctx, CircuitHalfOpen)
+		cbs.probing = true
+		return 0, true
+	case CircuitHalfOpen:
+		if cbs.probing {
+			return cbs.cfg.CoolDown, false
+		}
+		cbs.probing = true
+		return 0, true
+	}
+	return 0, true
+}
+
+// release lets another request probe the destination if the attempt tells nothing about it.
+func (cbs *circuitBreakerSender) release() {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	cbs.probing = false
+}
+
+// record records the outcome of an attempt, changing the state of the circuit if needed.
+func (cbs *circuitBreakerSender) record(ctx context.Context, success bool) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	if cbs.state == CircuitHalfOpen {
+		cbs.probing = false
+		if success {
+			cbs.reset()
+			cbs.setState(ctx, CircuitClosed)
+		} else {
+			cbs.open(ctx)
+		}
+		return
+	}
+	if cbs.state == CircuitOpen {
+		// The attempt was started before the circuit opened.
+		return
+	}
+
+	now := cbs.now()
+	if now.Sub(cbs.windowStart) >= cbs.cfg.Window {
+		cbs.windowStart = now
+		cbs.attempts, cbs.failures = 0, 0
+	}
+	cbs.attempts++
+	if success {
+		cbs.consecutiveFailures = 0
+		return
+	}
+	cbs.failures++
+	cbs.consecutiveFailures++
+
+	if cbs.cfg.ConsecutiveFailures > 0 && cbs.consecutiveFailures >= cbs.cfg.ConsecutiveFailures {
+		cbs.open(ctx)
+		return
+	}
+	if cbs.cfg.ErrorRate > 0 && cbs.attempts >= cbs.cfg.MinAttempts &&
+		float64(cbs.failures)/float64(cbs.attempts) >= cbs.cfg.ErrorRate {
+		cbs.open(ctx)
+	}
+}
+
+func (cbs *circuitBreakerSender) open(ctx context.Context) {
+	cbs.reset()
+	cbs.openedAt = cbs.now()
+	cbs.setState(ctx, CircuitOpen)
+}
+
+func (cbs *circuitBreakerSender) reset() {
+	cbs.consecutiveFailures = 0
+	cbs.windowStart = cbs.now()
+	cbs.attempts, cbs.failures = 0, 0
+}
+
+// setState changes the state of the circuit, logging and recording the change.
+func (cbs *circuitBreakerSender) setState(ctx context.Context, state CircuitState) {
+	if cbs.state == state {
+		return
+	}
+	from := cbs.state
+	cbs.state = state
+	if state == CircuitOpen {
+		cbs.logger.Warn("Circuit breaker opened, sending is suspended.",
+			zap.String("from", from.String()), zap.Duration("cool_down", cbs.cfg.CoolDown))
+	} else {
+		cbs.logger.Info("Circuit breaker state changed.",
+			zap.String("from", from.String()), zap.String("to", state.String()))
+	}
+	if cbs.obsrep != nil {
+		cbs.obsrep.recordCircuitState(ctx, state)
+	}
+}
+
+// circuitState returns the current state of the circuit.
+func (cbs *circuitBreakerSender) circuitState() CircuitState {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	return cbs.state
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestCircuitBreakerSettingsValidate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerSettings()
+	require.NoError(t, cfg.Validate())
+	cfg.Enabled = true
+	require.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*CircuitBreakerSettings)
+		err    string
+	}{
+		{name: "negative consecutive failures", modify: func(c *CircuitBreakerSettings) { c.ConsecutiveFailures = -1 }, err: "'consecutive_failures' must be non-negative"},
+		{name: "error rate too high", modify: func(c *CircuitBreakerSettings) { c.ErrorRate = 1.5 }, err: "'error_rate' must be between 0 and 1"},
+		{name: "no trigger", modify: func(c *CircuitBreakerSettings) { c.ConsecutiveFailures, c.ErrorRate = 0, 0 }, err: "one of 'consecutive_failures' or 'error_rate' must be set"},
+		{name: "negative min attempts", modify: func(c *CircuitBreakerSettings) { c.MinAttempts = -1 }, err: "'min_attempts' must be non-negative"},
+		{name: "no window", modify: func(c *CircuitBreakerSettings) { c.Window = 0 }, err: "'window' must be positive"},
+		{name: "no cool down", modify: func(c *CircuitBreakerSettings) { c.CoolDown = 0 }, err: "'cool_down' must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cfg
+			tt.modify(&c)
+			assert.EqualError(t, c.Validate(), tt.err)
+		})
+	}
+}
+
+func newTestCircuitBreaker(t *testing.T, cfg CircuitBreakerSettings) (*circuitBreakerSender, *time.Time) {
+	obsrep, err := newObsReport(obsReportSettings{exporterID: defaultID, exporterCreateSettings: defaultSettings, dataType: defaultDataType})
+	require.NoError(t, err)
+	cbs := newCircuitBreakerSender(cfg, defaultSettings, obsrep)
+	cbs.setNextSender(&timeoutSender{})
+	now := time.Unix(100, 0)
+	cbs.now = func() time.Time { return now }
+	return cbs, &now
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerSettings()
+	cfg.ConsecutiveFailures = 2
+	cfg.ErrorRate = 0
+	cfg.CoolDown = 10 * time.Second
+	cbs, now := newTestCircuitBreaker(t, cfg)
+	ctx := context.Background()
+	errExport := errors.New("export failed")
+
+	require.ErrorIs(t, cbs.send(ctx, newMockRequest(1, errExport)), errExport)
+	// Permanent errors do not open the circuit.
+	require.Error(t, cbs.send(ctx, newMockRequest(1, consumererror.NewPermanent(errExport))))
+	assert.Equal(t, CircuitClosed, cbs.circuitState())
+	require.ErrorIs(t, cbs.send(ctx, newMockRequest(1, errExport)), errExport)
+	assert.Equal(t, CircuitOpen, cbs.circuitState())
+
+	// The requests are short-circuited until the end of the cool-down period.
+	*now = now.Add(4 * time.Second)
+	req := newMockRequest(1, nil)
+	err := cbs.send(ctx, req)
+	require.ErrorIs(t, err, errCircuitOpen)
+	assert.False(t, consumererror.IsPermanent(err))
+	delay, ok := retryDelayHint(err)
+	require.True(t, ok)
+	assert.Equal(t, 6*time.Second, delay)
+	req.checkNumRequests(t, 0)
+
+	// A failing probe opens the circuit again.
+	*now = now.Add(6 * time.Second)
+	require.ErrorIs(t, cbs.send(ctx, newMockRequest(1, errExport)), errExport)
+	assert.Equal(t, CircuitOpen, cbs.circuitState())
+
+	// A successful probe closes the circuit.
+	*now = now.Add(10 * time.Second)
+	require.NoError(t, cbs.send(ctx, newMockRequest(1, nil)))
+	assert.Equal(t, CircuitClosed, cbs.circuitState())
+	require.NoError(t, cbs.send(ctx, newMockRequest(1, nil)))
+}
+
+func TestCircuitBreakerErrorRate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerSettings()
+	cfg.ConsecutiveFailures = 0
+	cfg.ErrorRate = 0.6
+	cfg.MinAttempts = 4
+	cfg.Window = time.Minute
+	cbs, now := newTestCircuitBreaker(t, cfg)
+	ctx := context.Background()
+	errExport := errors.New("export failed")
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cbs.send(ctx, newMockRequest(1, nil)))
+		require.Error(t, cbs.send(ctx, newMockRequest(1, errExport)))
+	}
+	// The window is over, the error rate is computed again.
+	*now = now.Add(time.Minute)
+	require.NoError(t, cbs.send(ctx, newMockRequest(1, nil)))
+	require.Error(t, cbs.send(ctx, newMockRequest(1, errExport)))
+	require.Error(t, cbs.send(ctx, newMockRequest(1, errExport)))
+	// Not enough attempts to evaluate the error rate.
+	assert.Equal(t, CircuitClosed, cbs.circuitState())
+	require.Error(t, cbs.send(ctx, newMockRequest(1, errExport)))
+	assert.Equal(t, CircuitOpen, cbs.circuitState())
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerSettings()
+	cfg.ConsecutiveFailures = 1
+	cfg.CoolDown = time.Second
+	cbs, now := newTestCircuitBreaker(t, cfg)
+	ctx := context.Background()
+	require.Error(t, cbs.send(ctx, newMockRequest(1, errors.New("export failed"))))
+
+	*now = now.Add(time.Second)
+	_, ok := cbs.allow(ctx)
+	require.True(t, ok)
+	assert.Equal(t, CircuitHalfOpen, cbs.circuitState())
+	// A single request is let through while the circuit is half-open.
+	require.ErrorIs(t, cbs.send(ctx, newMockRequest(1, nil)), errCircuitOpen)
+	cbs.record(ctx, true)
+	assert.Equal(t, CircuitClosed, cbs.circuitState())
+}
+
+func TestCircuitBreakerWithRetry(t *testing.T) {
+	cbCfg := NewDefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.ConsecutiveFailures = 1
+	cbCfg.CoolDown = 50 * time.Millisecond
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRetry(rCfg), WithCircuitBreaker(cbCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, be.Shutdown(context.Background())) })
+
+	// The retry waits for the end of the cool-down period before the request is sent again.
+	req := newMockRequest(1, errors.New("export failed"))
+	start := time.Now()
+	require.NoError(t, be.send(context.Background(), req))
+	assert.GreaterOrEqual(t, time.Since(start), cbCfg.CoolDown)
+	req.checkNumRequests(t, 2)
+	assert.Equal(t, CircuitClosed, be.ExporterStatus().CircuitState)
+}
@@ -81,7 +81,20 @@ func WithRetry(config configretry.BackOffConfig) Option {
 			o.exportFailureMessage += " Try enabling retry_on_failure config option to retry on retryable errors."
 			return nil
 		}
-		o.retrySender = newRetrySender(config, o.set)
+		o.retrySender = newRetrySender(config, o.set, o.obsrep)
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables the circuit breaker suspending the attempts to send data while the destination is failing.
+// It should be used together with WithRetry, which holds the requests until the circuit closes.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithCircuitBreaker(config CircuitBreakerSettings) Option {
+	return func(o *baseExporter) error {
+		if !config.Enabled {
+			return nil
+		}
+		o.circuitBreakerSender = newCircuitBreakerSender(config, o.set, o.obsrep)
 		return nil
 	}
 }
@@ -98,19 +111,29 @@ func WithQueue(config QueueSettings) Option {
 			o.exportFailureMessage += " Try enabling sending_queue to survive temporary failures."
 			return nil
 		}
-		qf := exporterqueue.NewPersistentQueueFactory[Request](config.StorageID, exporterqueue.PersistentQueueSettings[Request]{
+		qCfg := exporterqueue.Config{
+			Enabled:      config.Enabled,
+			NumConsumers: config.NumConsumers,
+			Autoscaling:  config.Autoscaling,
+			QueueSize:    config.QueueSize,
+			Priority:     config.Priority,
+			FullBehavior: config.FullBehavior,
+			BlockTimeout: config.BlockTimeout,
+		}
+		pqSet := exporterqueue.PersistentQueueSettings[Request]{
 			Marshaler:   o.marshaler,
 			Unmarshaler: o.unmarshaler,
-		})
+		}
+		qf := exporterqueue.NewSpillQueueFactory[Request](
+			exporterqueue.NewPersistentQueueFactory[Request](config.StorageID, pqSet), config.Spill, pqSet)
 		q := qf(context.Background(), exporterqueue.Settings{
 			DataType:         o.signal,
 			ExporterSettings: o.set,
-		}, exporterqueue.Config{
-			Enabled:      config.Enabled,
-			NumConsumers: config.NumConsumers,
-			QueueSize:    config.QueueSize,
-		})
-		o.queueSender = newQueueSender(q, o.set, config.NumConsumers, o.exportFailureMessage, o.obsrep)
+		}, qCfg)
+		qs := newQueueSender(q, o.set, qCfg, o.exportFailureMessage, o.obsrep)
+		// The requests are durably enqueued once they are stored by the persistent queue.
+		qs.durable = config.StorageID != nil
+		o.queueSender = qs
 		return nil
 	}
 }
@@ -132,7 +155,7 @@ func WithRequestQueue(cfg exporterqueue.Config, queueFactory exporterqueue.Facto
 			DataType:         o.signal,
 			ExporterSettings: o.set,
 		}
-		o.queueSender = newQueueSender(queueFactory(context.Background(), set, cfg), o.set, cfg.NumConsumers, o.exportFailureMessage, o.obsrep)
+		o.queueSender = newQueueSender(queueFactory(context.Background(), set, cfg), o.set, cfg, o.exportFailureMessage, o.obsrep)
 		return nil
 	}
 }
@@ -240,11 +263,13 @@ type baseExporter struct {
 	// Chain of senders that the exporter helper applies before passing the data to the actual exporter.
 	// The data is handled by each sender in the respective order starting from the queueSender.
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
-	batchSender   requestSender
-	queueSender   requestSender
-	obsrepSender  requestSender
-	retrySender   requestSender
-	timeoutSender *timeoutSender // timeoutSender is always initialized.
+	batchSender          requestSender
+	queueSender          requestSender
+	obsrepSender         requestSender
+	retrySender          requestSender
+	circuitBreakerSender requestSender
+	statusSender         *statusSender  // statusSender is always initialized.
+	timeoutSender        *timeoutSender // timeoutSender is always initialized.
 
 	consumerOptions []consumer.Option
 }
@@ -258,11 +283,13 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 	be := &baseExporter{
 		signal: signal,
 
-		batchSender:   &baseRequestSender{},
-		queueSender:   &baseRequestSender{},
-		obsrepSender:  osf(obsReport),
-		retrySender:   &baseRequestSender{},
-		timeoutSender: &timeoutSender{cfg: NewDefaultTimeoutSettings()},
+		batchSender:          &baseRequestSender{},
+		queueSender:          &baseRequestSender{},
+		obsrepSender:         osf(obsReport),
+		retrySender:          &baseRequestSender{},
+		circuitBreakerSender: &baseRequestSender{},
+		statusSender:         newStatusSender(),
+		timeoutSender:        &timeoutSender{cfg: NewDefaultTimeoutSettings()},
 
 		set:    set,
 		obsrep: obsReport,
@@ -278,7 +305,8 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 	be.connectSenders()
 
 	if bs, ok := be.batchSender.(*batchSender); ok {
-		// If queue sender is enabled assign to the batch sender the same number of workers.
+		// If queue sender is enabled assign to the batch sender the same number of workers,
+		// or the maximum number of workers if autoscaling is enabled.
 		if qs, ok := be.queueSender.(*queueSender); ok {
 			bs.concurrencyLimit = int64(qs.numConsumers)
 		}
@@ -304,7 +332,9 @@ func (be *baseExporter) connectSenders() {
 	be.queueSender.setNextSender(be.batchSender)
 	be.batchSender.setNextSender(be.obsrepSender)
 	be.obsrepSender.setNextSender(be.retrySender)
-	be.retrySender.setNextSender(be.timeoutSender)
+	be.retrySender.setNextSender(be.circuitBreakerSender)
+	be.circuitBreakerSender.setNextSender(be.statusSender)
+	be.statusSender.setNextSender(be.timeoutSender)
 }
 
 func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
@@ -313,6 +343,11 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	// Then start reporting the status of the exports.
+	if err := be.statusSender.Start(ctx, host); err != nil {
+		return err
+	}
+
 	// If no error then start the batchSender.
 	if err := be.batchSender.Start(ctx, host); err != nil {
 		return err
@@ -330,6 +365,8 @@ func (be *baseExporter) Shutdown(ctx context.Context) error {
 		be.batchSender.Shutdown(ctx),
 		// Then shutdown the queue sender.
 		be.queueSender.Shutdown(ctx),
+		// Then stop reporting the status of the exports.
+		be.statusSender.Shutdown(ctx),
 		// Last shutdown the wrapped exporter itself.
 		be.ShutdownFunc.Shutdown(ctx))
 }
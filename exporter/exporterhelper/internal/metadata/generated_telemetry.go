@@ -31,14 +31,18 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
 	meter                             metric.Meter
+	ExporterCircuitBreakerState       metric.Int64Gauge
+	ExporterCircuitBreakerTransitions metric.Int64Counter
 	ExporterEnqueueFailedLogRecords   metric.Int64Counter
 	ExporterEnqueueFailedMetricPoints metric.Int64Counter
 	ExporterEnqueueFailedSpans        metric.Int64Counter
 	ExporterQueueCapacity             metric.Int64ObservableGauge
 	ExporterQueueSize                 metric.Int64ObservableGauge
+	ExporterRetryBackoff              metric.Int64Gauge
 	ExporterSendFailedLogRecords      metric.Int64Counter
 	ExporterSendFailedMetricPoints    metric.Int64Counter
 	ExporterSendFailedSpans           metric.Int64Counter
+	ExporterSendRetries               metric.Int64Counter
 	ExporterSentLogRecords            metric.Int64Counter
 	ExporterSentMetricPoints          metric.Int64Counter
 	ExporterSentSpans                 metric.Int64Counter
@@ -104,6 +108,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 	} else {
 		builder.meter = noop.Meter{}
 	}
+	builder.ExporterCircuitBreakerState, err = builder.meter.Int64Gauge(
+		"otelcol_exporter_circuit_breaker_state",
+		metric.WithDescription("Current state of the circuit breaker, 0 when closed, 1 when open and 2 when half-open."),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterCircuitBreakerTransitions, err = builder.meter.Int64Counter(
+		"otelcol_exporter_circuit_breaker_transitions",
+		metric.WithDescription("Number of state changes of the circuit breaker."),
+		metric.WithUnit("{transitions}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterEnqueueFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_enqueue_failed_log_records",
 		metric.WithDescription("Number of log records failed to be added to the sending queue."),
@@ -122,6 +138,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterRetryBackoff, err = builder.meter.Int64Gauge(
+		"otelcol_exporter_retry_backoff",
+		metric.WithDescription("Delay scheduled before the most recent retry attempt to send to destination."),
+		metric.WithUnit("ms"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSendFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_send_failed_log_records",
 		metric.WithDescription("Number of log records in failed attempts to send to destination."),
@@ -140,6 +162,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterSendRetries, err = builder.meter.Int64Counter(
+		"otelcol_exporter_send_retries",
+		metric.WithDescription("Number of retries scheduled after failed attempts to send to destination."),
+		metric.WithUnit("{retries}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSentLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_sent_log_records",
 		metric.WithDescription("Number of log record successfully sent to destination."),
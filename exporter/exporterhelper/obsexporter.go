@@ -5,6 +5,7 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -133,6 +134,26 @@ func (or *obsReport) recordMetrics(ctx context.Context, dataType component.DataT
 	failedMeasure.Add(ctx, failed, metric.WithAttributes(or.otelAttrs...))
 }
 
+// recordRetry records that a retry was scheduled after the given delay.
+func (or *obsReport) recordRetry(ctx context.Context, delay time.Duration, throttled bool) {
+	if or.level == configtelemetry.LevelNone {
+		return
+	}
+	or.telemetryBuilder.ExporterSendRetries.Add(ctx, 1,
+		metric.WithAttributes(append([]attribute.KeyValue{attribute.Bool("throttled", throttled)}, or.otelAttrs...)...))
+	or.telemetryBuilder.ExporterRetryBackoff.Record(ctx, delay.Milliseconds(), metric.WithAttributes(or.otelAttrs...))
+}
+
+// recordCircuitState records a change of state of the circuit breaker.
+func (or *obsReport) recordCircuitState(ctx context.Context, state CircuitState) {
+	if or.level == configtelemetry.LevelNone {
+		return
+	}
+	or.telemetryBuilder.ExporterCircuitBreakerTransitions.Add(ctx, 1,
+		metric.WithAttributes(append([]attribute.KeyValue{attribute.String("state", state.String())}, or.otelAttrs...)...))
+	or.telemetryBuilder.ExporterCircuitBreakerState.Record(ctx, int64(state), metric.WithAttributes(or.otelAttrs...))
+}
+
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
 	span := trace.SpanFromContext(ctx)
 	// End the span according to errors.
@@ -6,6 +6,8 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -14,13 +16,27 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
-const defaultQueueSize = 1000
+const (
+	defaultQueueSize = 1000
+
+	// agentQueueSize and agentNumConsumers are the defaults with the agent runtime profile.
+	agentQueueSize    = 100
+	agentNumConsumers = 2
+
+	defaultMinConsumers = 1
+	defaultMaxConsumers = 100
+	// autoscalingInterval is the period between two adjustments of the number of consumers.
+	autoscalingInterval = time.Second
+)
 
 // QueueSettings defines configuration for queueing batches before sending to the consumerSender.
 type QueueSettings struct {
@@ -29,16 +45,39 @@ type QueueSettings struct {
 	// NumConsumers is the number of consumers from the queue. Defaults to 10.
 	// If batching is enabled, a combined batch cannot contain more requests than the number of consumers.
 	// So it's recommended to set higher number of consumers if batching is enabled.
+	// It can be set to "auto" in the configuration to enable Autoscaling.
 	NumConsumers int `mapstructure:"num_consumers"`
+	// Autoscaling configures the bounds of the number of consumers when num_consumers is "auto".
+	Autoscaling exporterqueue.AutoscalingConfig `mapstructure:"autoscaling"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// Priority configures how batches are prioritized in the memory queue.
+	// It cannot be enabled together with the persistent queue.
+	Priority exporterqueue.PriorityConfig `mapstructure:"priority"`
+	// Spill configures the disk tier receiving the batches which do not fit in the memory queue.
+	// It cannot be enabled together with the persistent queue.
+	Spill exporterqueue.SpillConfig `mapstructure:"spill"`
+	// FullBehavior defines what happens to a batch offered to the full queue: "reject", the default,
+	// drops it, "block" blocks the caller until there is room for it, propagating the backpressure.
+	FullBehavior exporterqueue.FullBehavior `mapstructure:"queue_full_behavior"`
+	// BlockTimeout is the maximum time a batch waits for room in the queue when FullBehavior is "block",
+	// after which it is rejected. Zero waits until the context of the request is done.
+	BlockTimeout time.Duration `mapstructure:"block_timeout"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
+// The queue is smaller when the collector runs with the agent runtime profile.
 func NewDefaultQueueSettings() QueueSettings {
+	if runtimeprofile.IsAgent() {
+		return QueueSettings{
+			Enabled:      true,
+			NumConsumers: agentNumConsumers,
+			QueueSize:    agentQueueSize,
+		}
+	}
 	return QueueSettings{
 		Enabled:      true,
 		NumConsumers: 10,
@@ -59,10 +98,33 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("queue size must be positive")
 	}
 
-	if qCfg.NumConsumers <= 0 {
+	if qCfg.NumConsumers <= 0 && !qCfg.Autoscaling.Enabled {
 		return errors.New("number of queue consumers must be positive")
 	}
 
+	if qCfg.Priority.Enabled && qCfg.StorageID != nil {
+		return errors.New("priority cannot be enabled with a persistent queue")
+	}
+
+	if qCfg.Spill.StorageID != nil && qCfg.StorageID != nil {
+		return errors.New("spill cannot be enabled with a persistent queue")
+	}
+
+	if qCfg.BlockTimeout < 0 {
+		return errors.New("block timeout must not be negative")
+	}
+
+	return errors.Join(qCfg.Autoscaling.Validate(), qCfg.Priority.Validate(), qCfg.Spill.Validate())
+}
+
+// Unmarshal a confmap.Conf into the config struct, accepting "auto" as number of consumers.
+func (qCfg *QueueSettings) Unmarshal(conf *confmap.Conf) error {
+	type rawQueueSettings QueueSettings
+	conf, auto := queue.WithoutNumConsumersAuto(conf)
+	if err := conf.Unmarshal((*rawQueueSettings)(qCfg)); err != nil {
+		return err
+	}
+	qCfg.Autoscaling.Enabled = qCfg.Autoscaling.Enabled || auto
 	return nil
 }
 
@@ -73,33 +135,97 @@ type queueSender struct {
 	traceAttribute attribute.KeyValue
 	consumers      *queue.Consumers[Request]
 
+	// block is set when the senders wait for room in the full queue, for up to blockTimeout if positive.
+	block        bool
+	blockTimeout time.Duration
+	// roomMu guards roomC, closed and replaced when a request leaves the queue to wake up the blocked senders.
+	roomMu sync.Mutex
+	roomC  chan struct{}
+	// stopped is closed on shutdown, to release the blocked senders.
+	stopped chan struct{}
+
 	obsrep     *obsReport
 	exporterID component.ID
+
+	// durable is set when the requests are stored in a persistent queue, acknowledging the data in the
+	// ack.ModeEnqueued mode once it is enqueued.
+	durable bool
+
+	// enqueued holds the times the requests in the queue were offered, oldest first.
+	// The requests are assumed to be consumed in order, so it approximates the age of the oldest one
+	// when the queue reorders them, e.g. when it prioritizes them. unrecorded counts the requests consumed
+	// before their time was recorded.
+	enqueuedMu sync.Mutex
+	enqueued   []time.Time
+	unrecorded int
+	startTime  time.Time
+	now        func() time.Time
 }
 
-func newQueueSender(q exporterqueue.Queue[Request], set exporter.Settings, numConsumers int,
+func newQueueSender(q exporterqueue.Queue[Request], set exporter.Settings, cfg exporterqueue.Config,
 	exportFailureMessage string, obsrep *obsReport) *queueSender {
+	numConsumers := cfg.NumConsumers
+	var scaling *queue.AutoscalingSettings
+	if cfg.Autoscaling.Enabled {
+		scaling = autoscalingSettings(cfg.Autoscaling)
+		numConsumers = scaling.MaxConsumers
+	}
 	qs := &queueSender{
 		queue:          q,
 		numConsumers:   numConsumers,
 		traceAttribute: attribute.String(obsmetrics.ExporterKey, set.ID.String()),
 		obsrep:         obsrep,
 		exporterID:     set.ID,
+		now:            time.Now,
+		block:          cfg.FullBehavior == exporterqueue.FullBehaviorBlock,
+		blockTimeout:   cfg.BlockTimeout,
+		roomC:          make(chan struct{}),
+		stopped:        make(chan struct{}),
 	}
 	consumeFunc := func(ctx context.Context, req Request) error {
+		qs.dequeued()
+		qs.roomMade()
 		err := qs.nextSender.send(ctx, req)
+		ack.Release(err, ack.Filter(ack.FromContext(ctx), qs.heldAckModes()...)...)
 		if err != nil {
 			set.Logger.Error("Exporting failed. Dropping data."+exportFailureMessage,
 				zap.Error(err), zap.Int("dropped_items", req.ItemsCount()))
 		}
 		return err
 	}
-	qs.consumers = queue.NewQueueConsumers[Request](q, numConsumers, consumeFunc)
+	if scaling != nil {
+		qs.consumers = queue.NewAutoscalingQueueConsumers[Request](q, *scaling, consumeFunc)
+	} else {
+		qs.consumers = queue.NewQueueConsumers[Request](q, numConsumers, consumeFunc)
+	}
 	return qs
 }
 
+// autoscalingSettings applies the defaults to the autoscaling configuration.
+func autoscalingSettings(cfg exporterqueue.AutoscalingConfig) *queue.AutoscalingSettings {
+	set := &queue.AutoscalingSettings{
+		MinConsumers: cfg.MinConsumers,
+		MaxConsumers: cfg.MaxConsumers,
+		Interval:     autoscalingInterval,
+	}
+	if set.MinConsumers == 0 {
+		set.MinConsumers = defaultMinConsumers
+	}
+	if set.MaxConsumers == 0 {
+		set.MaxConsumers = defaultMaxConsumers
+		if set.MinConsumers > set.MaxConsumers {
+			set.MaxConsumers = set.MinConsumers
+		}
+	}
+	return set
+}
+
 // Start is invoked during service startup.
 func (qs *queueSender) Start(ctx context.Context, host component.Host) error {
+	qs.enqueuedMu.Lock()
+	qs.startTime = qs.now()
+	qs.enqueuedMu.Unlock()
+
 	if err := qs.consumers.Start(ctx, host); err != nil {
 		return err
 	}
@@ -115,6 +241,7 @@ func (qs *queueSender) Start(ctx context.Context, host component.Host) error {
 
 // Shutdown is invoked during service shutdown.
 func (qs *queueSender) Shutdown(ctx context.Context) error {
+	close(qs.stopped)
 	// Stop the queue and consumers, this will drain the queue and will call the retry (which is stopped) that will only
 	// try once every request.
 	return qs.consumers.Shutdown(ctx)
@@ -127,7 +254,11 @@ func (qs *queueSender) send(ctx context.Context, req Request) error {
 	c := context.WithoutCancel(ctx)
 
 	span := trace.SpanFromContext(c)
-	if err := qs.queue.Offer(c, req); err != nil {
+	// The acks of the data are held until the request is consumed, released by the consumers. The request may be
+	// consumed before offer returns, so they are held before it is offered.
+	release := ack.Hold(c, qs.heldAckModes()...)
+	if err := qs.offer(ctx, c, req); err != nil {
+		release(nil)
 		span.AddEvent("Failed to enqueue item.", trace.WithAttributes(qs.traceAttribute))
 		return err
 	}
@@ -135,3 +266,116 @@ func (qs *queueSender) send(ctx context.Context, req Request) error {
 	span.AddEvent("Enqueued item.", trace.WithAttributes(qs.traceAttribute))
 	return nil
 }
+
+// heldAckModes returns the modes of the acks held while the requests are in the queue: all of them unless the queue
+// is durable, the requests being acknowledged once enqueued in the ack.ModeEnqueued mode then.
+func (qs *queueSender) heldAckModes() []ack.Mode {
+	if qs.durable {
+		return []ack.Mode{ack.ModeExported}
+	}
+	return nil
+}
+
+// offer puts the request in the queue. When the queue is full and the senders block, it waits for room in the
+// queue until ctx is done, the block timeout expires or the queue is shut down, the request being rejected then.
+func (qs *queueSender) offer(ctx context.Context, queueCtx context.Context, req Request) error {
+	var timeoutC <-chan time.Time
+	for {
+		// The channel is taken before offering the request, so the room made in between is not missed.
+		roomC := qs.room()
+		offeredAt := qs.now()
+		err := qs.queue.Offer(queueCtx, req)
+		if err == nil {
+			qs.offered(offeredAt)
+			return nil
+		}
+		if !qs.block || !errors.Is(err, queue.ErrQueueIsFull) {
+			return err
+		}
+		if timeoutC == nil && qs.blockTimeout > 0 {
+			timer := time.NewTimer(qs.blockTimeout)
+			defer timer.Stop()
+			timeoutC = timer.C
+		}
+		select {
+		case <-roomC:
+			select {
+			case <-qs.stopped:
+				return err
+			default:
+			}
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-timeoutC:
+			return err
+		case <-qs.stopped:
+			return err
+		}
+	}
+}
+
+// room returns a channel closed when a request leaves the queue.
+func (qs *queueSender) room() <-chan struct{} {
+	qs.roomMu.Lock()
+	defer qs.roomMu.Unlock()
+	return qs.roomC
+}
+
+// roomMade wakes up the senders waiting for room in the queue.
+func (qs *queueSender) roomMade() {
+	if !qs.block {
+		return
+	}
+	qs.roomMu.Lock()
+	defer qs.roomMu.Unlock()
+	close(qs.roomC)
+	qs.roomC = make(chan struct{})
+}
+
+// offered records the time a request accepted by the queue was offered.
+// It is recorded once the queue accepted the request, which may have been consumed in between.
+func (qs *queueSender) offered(t time.Time) {
+	qs.enqueuedMu.Lock()
+	defer qs.enqueuedMu.Unlock()
+	if qs.unrecorded > 0 {
+		qs.unrecorded--
+		return
+	}
+	qs.enqueued = append(qs.enqueued, t)
+	// The requests dropped by the queue to make room for new ones are never consumed, forget the oldest times
+	// once there are more than the requests the queue and its consumers can hold.
+	if limit := qs.queue.Capacity() + qs.numConsumers; len(qs.enqueued) > limit {
+		qs.enqueued = qs.enqueued[len(qs.enqueued)-limit:]
+	}
+}
+
+// dequeued forgets the time of the oldest request when a request is consumed from the queue.
+func (qs *queueSender) dequeued() {
+	qs.enqueuedMu.Lock()
+	defer qs.enqueuedMu.Unlock()
+	switch {
+	case len(qs.enqueued) > 0:
+		qs.enqueued = qs.enqueued[1:]
+	case qs.unrecorded < qs.numConsumers:
+		// The request was consumed before its time was recorded, or restored from a persistent queue.
+		// At most one request per consumer can be in the first case.
+		qs.unrecorded++
+	}
+}
+
+// status returns the size and capacity of the queue, and the age of its oldest request.
+func (qs *queueSender) status() (size int, capacity int, oldestAge time.Duration) {
+	size, capacity = qs.queue.Size(), qs.queue.Capacity()
+	qs.enqueuedMu.Lock()
+	defer qs.enqueuedMu.Unlock()
+	switch n := len(qs.enqueued); {
+	case size == 0:
+		return size, capacity, 0
+	case n < size:
+		// Some requests were restored from a persistent queue when the exporter started.
+		return size, capacity, qs.now().Sub(qs.startTime)
+	default:
+		// The extra times are the ones of the requests being consumed, or dropped by the queue.
+		return size, capacity, qs.now().Sub(qs.enqueued[n-size])
+	}
+}
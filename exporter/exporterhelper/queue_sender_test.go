@@ -18,13 +18,26 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
+func TestNewDefaultQueueSettingsAgentProfile(t *testing.T) {
+	assert.Equal(t, defaultQueueSize, NewDefaultQueueSettings().QueueSize)
+	require.NoError(t, runtimeprofile.Set(runtimeprofile.Agent))
+	t.Cleanup(func() { require.NoError(t, runtimeprofile.Set(runtimeprofile.Default)) })
+	qCfg := NewDefaultQueueSettings()
+	assert.Equal(t, agentNumConsumers, qCfg.NumConsumers)
+	assert.Equal(t, agentQueueSize, qCfg.QueueSize)
+	assert.NoError(t, qCfg.Validate())
+}
+
 func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -59,6 +72,81 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	require.Zero(t, be.queueSender.(*queueSender).queue.Size())
 }
 
+func TestQueuedRetry_BlockOnFull(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 1
+	qCfg.NumConsumers = 1
+	qCfg.FullBehavior = exporterqueue.FullBehaviorBlock
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+	qs := be.queueSender.(*queueSender)
+
+	// The first request blocks the only consumer until it is unlocked, the second one fills the queue.
+	first := newMockRequest(1, nil)
+	first.mu.Lock()
+	require.NoError(t, be.send(context.Background(), first))
+	require.Eventually(t, func() bool { return first.requestCount.Load() == 1 }, time.Second, time.Millisecond)
+	second := newMockRequest(1, nil)
+	require.NoError(t, be.send(context.Background(), second))
+
+	third := newMockRequest(1, nil)
+	sent := make(chan error, 1)
+	go func() {
+		sent <- be.send(context.Background(), third)
+	}()
+	select {
+	case err = <-sent:
+		require.Failf(t, "the request must wait for room in the queue", "sent with %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, 1, qs.queue.Size())
+
+	first.mu.Unlock()
+	require.NoError(t, <-sent)
+	require.Eventually(t, func() bool { return third.requestCount.Load() == 1 }, time.Second, time.Millisecond)
+	second.checkNumRequests(t, 1)
+}
+
+func TestQueuedRetry_BlockOnFullRejected(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 1
+	qCfg.NumConsumers = 0
+	qCfg.FullBehavior = exporterqueue.FullBehaviorBlock
+	qCfg.BlockTimeout = 10 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+
+	// The request is rejected once the block timeout expires.
+	err = be.send(context.Background(), newMockRequest(1, nil))
+	assert.ErrorIs(t, err, queue.ErrQueueIsFull)
+
+	// Or once the context of the request is done.
+	be.queueSender.(*queueSender).blockTimeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = be.send(ctx, newMockRequest(1, nil))
+	assert.ErrorIs(t, err, queue.ErrQueueIsFull)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Or once the exporter shuts down, the queue still being full.
+	sent := make(chan error, 1)
+	go func() {
+		sent <- be.send(context.Background(), newMockRequest(1, nil))
+	}()
+	assert.NoError(t, be.Shutdown(context.Background()))
+	assert.ErrorIs(t, <-sent, queue.ErrQueueIsFull)
+}
+
 func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -260,11 +348,114 @@ func TestQueueSettings_Validate(t *testing.T) {
 
 	assert.EqualError(t, qCfg.Validate(), "number of queue consumers must be positive")
 
+	qCfg = NewDefaultQueueSettings()
+	qCfg.Priority.Enabled = true
+	assert.NoError(t, qCfg.Validate())
+	qCfg.Priority.Low.MaxShare = -1
+	assert.EqualError(t, qCfg.Validate(), "low priority max_share must be in [0, 1]")
+	qCfg.Priority.Low.MaxShare = 0.5
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "priority cannot be enabled with a persistent queue")
+
+	qCfg = NewDefaultQueueSettings()
+	qCfg.Spill.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "spill queue size must be positive")
+	qCfg.Spill.QueueSize = 10
+	assert.NoError(t, qCfg.Validate())
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "spill cannot be enabled with a persistent queue")
+
+	qCfg = NewDefaultQueueSettings()
+	qCfg.Autoscaling.Enabled = true
+	qCfg.Autoscaling.MinConsumers = -1
+	assert.EqualError(t, qCfg.Validate(), "autoscaling min_consumers must not be negative")
+
+	qCfg = NewDefaultQueueSettings()
+	qCfg.FullBehavior = exporterqueue.FullBehaviorBlock
+	qCfg.BlockTimeout = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "block timeout must not be negative")
+
+	qCfg = NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
 }
 
+func TestQueueSettings_UnmarshalNumConsumersAuto(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"num_consumers": "auto",
+		"autoscaling":   map[string]any{"min_consumers": 2},
+	}).Unmarshal(&qCfg))
+	expected := NewDefaultQueueSettings()
+	expected.Autoscaling = exporterqueue.AutoscalingConfig{Enabled: true, MinConsumers: 2}
+	assert.Equal(t, expected, qCfg)
+	assert.NoError(t, qCfg.Validate())
+
+	assert.Equal(t, &queue.AutoscalingSettings{MinConsumers: 2, MaxConsumers: 100, Interval: time.Second},
+		autoscalingSettings(qCfg.Autoscaling))
+	assert.Equal(t, &queue.AutoscalingSettings{MinConsumers: 1, MaxConsumers: 100, Interval: time.Second},
+		autoscalingSettings(exporterqueue.AutoscalingConfig{Enabled: true}))
+	assert.Equal(t, &queue.AutoscalingSettings{MinConsumers: 200, MaxConsumers: 200, Interval: time.Second},
+		autoscalingSettings(exporterqueue.AutoscalingConfig{Enabled: true, MinConsumers: 200}))
+}
+
+func TestQueuedRetry_AutoscalingConsumers(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Autoscaling = exporterqueue.AutoscalingConfig{Enabled: true, MinConsumers: 1, MaxConsumers: 4}
+	rCfg := configretry.NewDefaultBackOffConfig()
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+	assert.Equal(t, 4, be.queueSender.(*queueSender).numConsumers)
+	assert.Equal(t, 1, be.queueSender.(*queueSender).consumers.ActiveConsumers())
+
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), newMockRequest(2, nil)))
+	})
+	ocs.awaitAsyncProcessing()
+	ocs.checkSendItemsCount(t, 2)
+}
+
+func TestQueuedRetry_PriorityShedsLowPriority(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 1
+	qCfg.NumConsumers = 0
+	qCfg.Priority.Enabled = true
+	qCfg.Priority.Signals = map[string]exporterqueue.Priority{defaultDataType.String(): exporterqueue.PriorityLow}
+	set := exportertest.NewNopSettings()
+	logger, observed := observer.New(zap.WarnLevel)
+	set.Logger = zap.New(logger)
+	be, err := newBaseExporter(set, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// Requests get the priority of their signal unless their context has one.
+	require.NoError(t, be.send(context.Background(), newMockRequest(2, nil)))
+	require.Error(t, be.send(context.Background(), newMockRequest(3, nil)))
+	highCtx := exporterqueue.ContextWithPriority(context.Background(), exporterqueue.PriorityHigh)
+	require.NoError(t, be.send(highCtx, newMockRequest(4, nil)))
+	assert.Equal(t, 1, be.queueSender.(*queueSender).queue.Size())
+
+	dropped := observed.FilterMessage("Dropping request from the sending queue to make room for a higher priority request.").All()
+	require.Len(t, dropped, 1)
+	assert.Equal(t, int64(2), dropped[0].ContextMap()["dropped_items"])
+}
+
 func TestQueueRetryWithDisabledQueue(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -335,6 +526,47 @@ func TestQueueFailedRequestDropped(t *testing.T) {
 	assert.Equal(t, "Exporting failed. Dropping data.", observed.All()[0].Message)
 }
 
+func TestQueueSenderAcks(t *testing.T) {
+	be, err := newBaseExporter(exportertest.NewNopSettings(), component.DataTypeLogs, newNoopObsrepSender,
+		WithRequestQueue(exporterqueue.NewDefaultConfig(), exporterqueue.NewMemoryQueueFactory[Request]()))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The acks are held until the request is exported, whatever their mode, since the queue is not durable.
+	errExport := errors.New("some error")
+	a := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, be.send(ack.NewContext(context.Background(), a), newMockRequest(2, errExport)))
+	ack.Release(nil, a)
+	<-a.Done()
+	assert.Equal(t, errExport, a.Err())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestQueueSenderAcksPersistent(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(newMockRequest(2, errors.New("some error")))),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	host := &mockHost{ext: map[component.ID]component.Component{
+		storageID: queue.NewMockStorageExtension(nil),
+	}}
+	require.NoError(t, be.Start(context.Background(), host))
+
+	// The enqueued ack is completed once the request is stored, the exported one once it is exported.
+	enqueued := ack.New(ack.ModeEnqueued, nil)
+	exported := ack.New(ack.ModeExported, nil)
+	require.NoError(t, be.send(ack.NewContext(context.Background(), enqueued, exported), newMockRequest(2, nil)))
+	ack.Release(nil, enqueued, exported)
+	<-enqueued.Done()
+	require.NoError(t, enqueued.Err())
+	<-exported.Done()
+	assert.Error(t, exported.Err())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
 func TestQueuedRetryPersistenceEnabled(t *testing.T) {
 	tt, err := componenttest.SetupTelemetry(defaultID)
 	require.NoError(t, err)
@@ -429,6 +661,31 @@ func TestQueuedRetryPersistentEnabled_NoDataLossOnShutdown(t *testing.T) {
 	replacedReq.checkNumRequests(t, 1)
 }
 
+func TestQueuedRetry_SpillToDisk(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 1
+	qCfg.NumConsumers = 0
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.Spill = exporterqueue.SpillConfig{StorageID: &storageID, QueueSize: 2}
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	host := &mockHost{ext: map[component.ID]component.Component{
+		storageID: queue.NewMockStorageExtension(nil),
+	}}
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The requests which do not fit in the memory queue are spilled to the disk tier.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, be.send(context.Background(), newMockRequest(2, nil)))
+	}
+	assert.Equal(t, 3, be.queueSender.(*queueSender).queue.Capacity())
+}
+
 func TestQueueSenderNoStartShutdown(t *testing.T) {
 	queue := queue.NewBoundedMemoryQueue[Request](queue.MemoryQueueSettings[Request]{})
 	set := exportertest.NewNopSettings()
@@ -437,7 +694,7 @@ func TestQueueSenderNoStartShutdown(t *testing.T) {
 		exporterCreateSettings: exportertest.NewNopSettings(),
 	})
 	assert.NoError(t, err)
-	qs := newQueueSender(queue, set, 1, "", obsrep)
+	qs := newQueueSender(queue, set, exporterqueue.Config{NumConsumers: 1}, "", obsrep)
 	assert.NoError(t, qs.Shutdown(context.Background()))
 }
 
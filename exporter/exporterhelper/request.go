@@ -19,6 +19,17 @@ type Request interface {
 	ItemsCount() int
 }
 
+// RequestBytesSizer is an optional interface that can be implemented by Request to report its size in bytes,
+// required by the batcher to honor the min_size_bytes and max_size_bytes settings.
+// For example, for OTLP exporter, this value represents the size of the request encoded with protobuf.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestBytesSizer interface {
+	Request
+	// BytesSize returns the size of the request in bytes.
+	BytesSize() int
+}
+
 // RequestErrorHandler is an optional interface that can be implemented by Request to provide a way handle partial
 // temporary failures. For example, if some items failed to process and can be retried, this interface allows to
 // return a new Request that contains the items left to be sent. Otherwise, the original Request should be returned.
@@ -40,3 +51,11 @@ func extractPartialRequest(req Request, err error) Request {
 	}
 	return req
 }
+
+// requestBytesSize returns the size of the request in bytes, or zero if the request does not implement RequestBytesSizer.
+func requestBytesSize(req Request) int {
+	if bsReq, ok := req.(RequestBytesSizer); ok {
+		return bsReq.BytesSize()
+	}
+	return 0
+}
@@ -7,12 +7,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -43,20 +49,78 @@ func NewThrottleRetry(err error, delay time.Duration) error {
 	}
 }
 
+// NewThrottleRetryFromHeader creates a new throttle retry error using the delay advertised by the
+// HTTP Retry-After header. The header value may be either a number of seconds or an HTTP-date.
+// If the header is missing or cannot be parsed, the error carries no delay and the retry sender
+// falls back to its exponential backoff schedule.
+func NewThrottleRetryFromHeader(err error, header http.Header) error {
+	delay, _ := parseRetryAfter(header.Get("Retry-After"), time.Now())
+	return NewThrottleRetry(err, delay)
+}
+
+// parseRetryAfter parses the value of a Retry-After header as defined in RFC 9110, section 10.2.3.
+func parseRetryAfter(val string, now time.Time) (time.Duration, bool) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(val); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// retryDelayHint returns the delay requested by the backend before the next attempt, if any.
+// It honors errors created with NewThrottleRetry or consumererror.NewRetryAfter as well as gRPC
+// statuses carrying RetryInfo details.
+func retryDelayHint(err error) (time.Duration, bool) {
+	throttleErr := throttleRetry{}
+	if errors.As(err, &throttleErr) && throttleErr.delay > 0 {
+		return throttleErr.delay, true
+	}
+	if delay, ok := consumererror.RetryAfter(err); ok && delay > 0 {
+		return delay, true
+	}
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		if ri, ok := detail.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+			if delay := ri.GetRetryDelay().AsDuration(); delay > 0 {
+				return delay, true
+			}
+		}
+	}
+	return 0, false
+}
+
 type retrySender struct {
 	baseRequestSender
 	traceAttribute attribute.KeyValue
 	cfg            configretry.BackOffConfig
 	stopCh         chan struct{}
 	logger         *zap.Logger
+	obsrep         *obsReport
 }
 
-func newRetrySender(config configretry.BackOffConfig, set exporter.Settings) *retrySender {
+func newRetrySender(config configretry.BackOffConfig, set exporter.Settings, obsrep *obsReport) *retrySender {
 	return &retrySender{
 		traceAttribute: attribute.String(obsmetrics.ExporterKey, set.ID.String()),
 		cfg:            config,
 		stopCh:         make(chan struct{}),
 		logger:         set.Logger,
+		obsrep:         obsrep,
 	}
 }
 
@@ -69,9 +133,14 @@ func (rs *retrySender) Shutdown(context.Context) error {
 func (rs *retrySender) send(ctx context.Context, req Request) error {
 	// Do not use NewExponentialBackOff since it calls Reset and the code here must
 	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
+	randomizationFactor := rs.cfg.RandomizationFactor
+	if rs.cfg.FullJitter {
+		// The jitter is applied on the non randomized interval.
+		randomizationFactor = 0
+	}
 	expBackoff := backoff.ExponentialBackOff{
 		InitialInterval:     rs.cfg.InitialInterval,
-		RandomizationFactor: rs.cfg.RandomizationFactor,
+		RandomizationFactor: randomizationFactor,
 		Multiplier:          rs.cfg.Multiplier,
 		MaxInterval:         rs.cfg.MaxInterval,
 		MaxElapsedTime:      rs.cfg.MaxElapsedTime,
@@ -102,10 +171,21 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 		if backoffDelay == backoff.Stop {
 			return fmt.Errorf("no more retries left: %w", err)
 		}
+		if rs.cfg.FullJitter {
+			backoffDelay = fullJitter(backoffDelay)
+		}
 
-		throttleErr := throttleRetry{}
-		if errors.As(err, &throttleErr) {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+		// Honor the delay requested by the backend, if any, but never retry sooner than the backoff schedule.
+		hintDelay, throttled := retryDelayHint(err)
+		if throttled {
+			backoffDelay = max(backoffDelay, hintDelay)
+			// Do not wait for a retry that would happen after the max elapsed time.
+			if rs.cfg.MaxElapsedTime != 0 && expBackoff.GetElapsedTime()+backoffDelay > rs.cfg.MaxElapsedTime {
+				return fmt.Errorf("no more retries left: throttled for %s: %w", backoffDelay, err)
+			}
+		}
+		if rs.obsrep != nil {
+			rs.obsrep.recordRetry(ctx, backoffDelay, throttled)
 		}
 
 		backoffDelayStr := backoffDelay.String()
@@ -114,11 +194,13 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 			trace.WithAttributes(
 				rs.traceAttribute,
 				attribute.String("interval", backoffDelayStr),
+				attribute.Bool("throttled", throttled),
 				attribute.String("error", err.Error())))
 		rs.logger.Info(
 			"Exporting failed. Will retry the request after interval.",
 			zap.Error(err),
 			zap.String("interval", backoffDelayStr),
+			zap.Bool("throttled", throttled),
 		)
 		retryNum++
 
@@ -133,6 +215,18 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 	}
 }
 
+// minFullJitterDelay is the lower bound of the jittered delays, so a request is never retried immediately.
+const minFullJitterDelay = 10 * time.Millisecond
+
+// fullJitter returns a uniformly random duration in [min(d, minFullJitterDelay), d].
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	lower := min(d, minFullJitterDelay)
+	return lower + time.Duration(rand.Int63n(int64(d-lower)+1)) // nolint:gosec
+}
+
 // max returns the larger of x or y.
 func max(x, y time.Duration) time.Duration {
 	if x < y {
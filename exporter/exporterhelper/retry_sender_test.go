@@ -6,6 +6,8 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -15,6 +17,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -196,6 +202,110 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	require.Zero(t, be.queueSender.(*queueSender).queue.Size())
 }
 
+func TestQueuedRetry_GRPCRetryInfo(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	st, err := status.New(codes.ResourceExhausted, "resource exhausted").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(100 * time.Millisecond)})
+	require.NoError(t, err)
+	mockR := newMockRequest(2, st.Err())
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.send(context.Background(), mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The initial backoff is 10ms, but because of the RetryInfo this should wait at least 100ms.
+	assert.True(t, 100*time.Millisecond < time.Since(start))
+
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestQueuedRetry_ThrottleExceedsMaxElapsedTime(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 100 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender, WithRetry(rCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(2, NewThrottleRetry(errors.New("throttle error"), time.Hour))
+	start := time.Now()
+	ocs.run(func() {
+		require.ErrorContains(t, be.send(context.Background(), mockR), "no more retries left")
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The server asked for a delay beyond the max elapsed time, so the request must fail without waiting.
+	assert.Less(t, time.Since(start), time.Minute)
+	mockR.checkNumRequests(t, 1)
+	ocs.checkDroppedItemsCount(t, 2)
+}
+
+func TestNewThrottleRetryFromHeader(t *testing.T) {
+	header := http.Header{}
+	err := NewThrottleRetryFromHeader(errors.New("throttle error"), header)
+	delay, ok := retryDelayHint(err)
+	assert.False(t, ok)
+	assert.Zero(t, delay)
+
+	header.Set("Retry-After", "30")
+	err = NewThrottleRetryFromHeader(errors.New("throttle error"), header)
+	delay, ok = retryDelayHint(err)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+}
+
+func TestRetryDelayHintFromConsumerError(t *testing.T) {
+	err := consumererror.NewRetryAfter(errors.New("throttle error"), 10*time.Second)
+	delay, ok := retryDelayHint(fmt.Errorf("wrapped: %w", err))
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, delay)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		value     string
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{name: "empty", value: ""},
+		{name: "seconds", value: "120", wantDelay: 2 * time.Minute, wantOK: true},
+		{name: "negative_seconds", value: "-1"},
+		{name: "http_date", value: now.Add(90 * time.Second).Format(http.TimeFormat), wantDelay: 90 * time.Second, wantOK: true},
+		{name: "http_date_in_past", value: now.Add(-time.Minute).Format(http.TimeFormat), wantOK: true},
+		{name: "invalid", value: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -374,3 +484,42 @@ func (ocs *observabilityConsumerSender) checkSendItemsCount(t *testing.T, want i
 func (ocs *observabilityConsumerSender) checkDroppedItemsCount(t *testing.T, want int) {
 	assert.EqualValues(t, want, ocs.droppedItemsCount.Load())
 }
+
+func TestFullJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), fullJitter(0))
+	assert.Equal(t, time.Millisecond, fullJitter(time.Millisecond))
+	for i := 0; i < 100; i++ {
+		d := fullJitter(time.Second)
+		assert.GreaterOrEqual(t, d, minFullJitterDelay)
+		assert.LessOrEqual(t, d, time.Second)
+	}
+}
+
+func TestQueueRetryWithFullJitter(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	rCfg.FullJitter = true
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(2, errors.New("transient error"))
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.send(context.Background(), mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// In the newMockConcurrentExporter we count requests and items even for failed requests
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Status is a snapshot of the state of an exporter built with the exporter helpers.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type Status struct {
+	// QueueSize is the number of requests in the sending queue.
+	QueueSize int
+	// QueueCapacity is the capacity of the sending queue, zero if the queue is disabled.
+	QueueCapacity int
+	// OldestItemAge is the time spent in the sending queue by its oldest request, zero if the queue is empty.
+	// The requests restored from a persistent queue are assumed to be enqueued when the exporter started.
+	OldestItemAge time.Duration
+	// ConsecutiveFailures is the number of attempts to export a request that failed with a retryable error
+	// since the last successful one. Attempts failing with a permanent error are not counted, since retrying
+	// them would not help and they do not indicate that the destination is unavailable.
+	ConsecutiveFailures int
+	// LastError is the error of the last failed attempt, nil if the last attempt succeeded.
+	LastError error
+	// LastSuccess is the time of the last successful attempt, zero if none succeeded yet.
+	LastSuccess time.Time
+	// CircuitState is the state of the circuit breaker, always closed if the circuit breaker is disabled.
+	CircuitState CircuitState
+}
+
+// StatusProvider is implemented by the exporters built with the exporter helpers,
+// to query their current state, e.g. to alert when an exporter is stuck.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type StatusProvider interface {
+	// ExporterStatus returns the current state of the exporter.
+	ExporterStatus() Status
+}
+
+var _ StatusProvider = (*baseExporter)(nil)
+
+// ExporterStatus implements StatusProvider.
+func (be *baseExporter) ExporterStatus() Status {
+	st := be.statusSender.status()
+	if qs, ok := be.queueSender.(*queueSender); ok {
+		st.QueueSize, st.QueueCapacity, st.OldestItemAge = qs.status()
+	}
+	if cbs, ok := be.circuitBreakerSender.(*circuitBreakerSender); ok {
+		st.CircuitState = cbs.circuitState()
+	}
+	return st
+}
+
+// statusSender is a requestSender recording the outcome of every attempt to export a request.
+// The exporter reports a recoverable error status when its attempts start failing, and an OK status
+// once an attempt succeeds again.
+type statusSender struct {
+	baseRequestSender
+
+	mu                  sync.Mutex
+	host                component.Host
+	consecutiveFailures int
+	lastErr             error
+	lastSuccess         time.Time
+	now                 func() time.Time
+}
+
+func newStatusSender() *statusSender {
+	return &statusSender{now: time.Now}
+}
+
+func (ss *statusSender) Start(_ context.Context, host component.Host) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.host = host
+	return nil
+}
+
+func (ss *statusSender) Shutdown(context.Context) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	// The status of the exporter is reported by the service once it is shut down.
+	ss.host = nil
+	return nil
+}
+
+func (ss *statusSender) send(ctx context.Context, req Request) error {
+	err := ss.nextSender.send(ctx, req)
+	if err != nil && consumererror.IsPermanent(err) {
+		return err
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if err != nil {
+		ss.consecutiveFailures++
+		ss.lastErr = err
+		if ss.consecutiveFailures == 1 && ss.host != nil {
+			componentstatus.ReportStatus(ss.host, componentstatus.NewRecoverableErrorEvent(
+				fmt.Errorf("exporting failed: %w", err)))
+		}
+		return err
+	}
+	recovered := ss.consecutiveFailures > 0
+	ss.consecutiveFailures = 0
+	ss.lastErr = nil
+	ss.lastSuccess = ss.now()
+	if recovered && ss.host != nil {
+		componentstatus.ReportStatus(ss.host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	return nil
+}
+
+func (ss *statusSender) status() Status {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return Status{
+		ConsecutiveFailures: ss.consecutiveFailures,
+		LastError:           ss.lastErr,
+		LastSuccess:         ss.lastSuccess,
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+)
+
+// statusHost is a host recording the status events reported to it.
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, ev)
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var statuses []componentstatus.Status
+	for _, ev := range h.events {
+		statuses = append(statuses, ev.Status())
+	}
+	return statuses
+}
+
+func TestExporterStatus(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender)
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, be.Start(context.Background(), host))
+	now := time.Unix(100, 0)
+	be.statusSender.now = func() time.Time { return now }
+
+	var sp StatusProvider = be
+	assert.Equal(t, Status{}, sp.ExporterStatus())
+
+	errExport := errors.New("export failed")
+	require.ErrorIs(t, be.send(context.Background(), newMockRequest(1, errExport)), errExport)
+	require.Error(t, be.send(context.Background(), newMockRequest(1, errExport)))
+	// Permanent errors do not count as failures.
+	require.Error(t, be.send(context.Background(), newMockRequest(1, consumererror.NewPermanent(errExport))))
+	st := sp.ExporterStatus()
+	assert.Equal(t, 2, st.ConsecutiveFailures)
+	require.ErrorIs(t, st.LastError, errExport)
+	assert.True(t, st.LastSuccess.IsZero())
+	assert.Zero(t, st.QueueCapacity)
+
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+	assert.Equal(t, Status{LastSuccess: now}, sp.ExporterStatus())
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+
+	// The status is only reported when the exports start failing and when they recover.
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	require.Error(t, be.send(context.Background(), newMockRequest(1, errExport)))
+	assert.Len(t, host.statuses(), 2)
+}
+
+func TestExporterStatusQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 10
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	qs := be.queueSender.(*queueSender)
+	now := time.Unix(100, 0)
+	qs.now = func() time.Time { return now }
+
+	// Enqueue the requests before starting the consumers, so they stay in the queue.
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+	now = now.Add(time.Second)
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+	now = now.Add(time.Second)
+
+	st := be.ExporterStatus()
+	assert.Equal(t, 2, st.QueueSize)
+	assert.Equal(t, 10, st.QueueCapacity)
+	assert.Equal(t, 2*time.Second, st.OldestItemAge)
+
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, be.Shutdown(context.Background())) })
+	assert.Eventually(t, func() bool { return be.ExporterStatus().QueueSize == 0 }, time.Second, time.Millisecond)
+	assert.Zero(t, be.ExporterStatus().OldestItemAge)
+}
+
+func TestQueueSenderStatusRestored(t *testing.T) {
+	qs := &queueSender{queue: &fixedSizeQueue{size: 3}, numConsumers: 1}
+	start := time.Unix(100, 0)
+	now := start
+	qs.now = func() time.Time { return now }
+	qs.startTime = start
+	now = now.Add(time.Second)
+	qs.offered(now)
+	now = now.Add(time.Second)
+
+	// Two requests were restored from a persistent queue when the exporter started.
+	size, capacity, age := qs.status()
+	assert.Equal(t, 3, size)
+	assert.Equal(t, 5, capacity)
+	assert.Equal(t, 2*time.Second, age)
+
+	// The times of the requests dropped by the queue are forgotten.
+	for i := 0; i < 10; i++ {
+		qs.offered(now)
+	}
+	assert.Len(t, qs.enqueued, 6)
+	_, _, age = qs.status()
+	assert.Zero(t, age)
+}
+
+// fixedSizeQueue is a queue reporting a fixed size.
+type fixedSizeQueue struct {
+	exporterqueue.Queue[Request]
+	size int
+}
+
+func (q *fixedSizeQueue) Size() int     { return q.size }
+func (q *fixedSizeQueue) Capacity() int { return 5 }
+
+func TestQueueSenderStatusConsumedBeforeRecorded(t *testing.T) {
+	qs := &queueSender{queue: &fixedSizeQueue{size: 1}, numConsumers: 1}
+	now := time.Unix(100, 0)
+	qs.now = func() time.Time { return now }
+
+	// The first request is consumed before its time is recorded, the second one stays in the queue.
+	qs.dequeued()
+	qs.offered(now)
+	now = now.Add(time.Second)
+	qs.offered(now)
+	now = now.Add(time.Second)
+
+	assert.Len(t, qs.enqueued, 1)
+	_, _, age := qs.status()
+	assert.Equal(t, time.Second, age)
+}
@@ -6,14 +6,22 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"math"
 	"time"
 )
 
 // TimeoutSettings for timeout. The timeout applies to individual attempts to send data to the backend.
 type TimeoutSettings struct {
 	// Timeout is the timeout for every attempt to send data to the backend.
-	// A zero timeout means no timeout.
+	// A zero timeout means no timeout, unless PerItemTimeout is set.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// PerItemTimeout is added to Timeout for every item of the request: spans, metric data points or log records.
+	// It lets large requests take longer than small ones.
+	PerItemTimeout time.Duration `mapstructure:"per_item_timeout"`
+
+	// MaxTimeout caps the timeout scaled by the number of items. A zero MaxTimeout means no cap.
+	MaxTimeout time.Duration `mapstructure:"max_timeout"`
 }
 
 func (ts *TimeoutSettings) Validate() error {
@@ -21,6 +29,15 @@ func (ts *TimeoutSettings) Validate() error {
 	if ts.Timeout < 0 {
 		return errors.New("'timeout' must be non-negative")
 	}
+	if ts.PerItemTimeout < 0 {
+		return errors.New("'per_item_timeout' must be non-negative")
+	}
+	if ts.MaxTimeout < 0 {
+		return errors.New("'max_timeout' must be non-negative")
+	}
+	if ts.MaxTimeout != 0 && ts.MaxTimeout < ts.Timeout {
+		return errors.New("'max_timeout' must be greater than or equal to 'timeout'")
+	}
 	return nil
 }
 
@@ -31,6 +48,22 @@ func NewDefaultTimeoutSettings() TimeoutSettings {
 	}
 }
 
+// timeout returns the timeout of a request with the given number of items, zero meaning no timeout.
+func (ts *TimeoutSettings) timeout(items int) time.Duration {
+	if ts.PerItemTimeout == 0 || items <= 0 {
+		return ts.Timeout
+	}
+	// Cap the number of items to avoid overflowing the duration.
+	if limit := time.Duration(math.MaxInt64-ts.Timeout) / ts.PerItemTimeout; time.Duration(items) > limit {
+		items = int(limit)
+	}
+	timeout := ts.Timeout + time.Duration(items)*ts.PerItemTimeout
+	if ts.MaxTimeout != 0 && timeout > ts.MaxTimeout {
+		return ts.MaxTimeout
+	}
+	return timeout
+}
+
 // timeoutSender is a requestSender that adds a `timeout` to every request that passes this sender.
 type timeoutSender struct {
 	baseRequestSender
@@ -38,13 +71,14 @@ type timeoutSender struct {
 }
 
 func (ts *timeoutSender) send(ctx context.Context, req Request) error {
+	timeout := ts.cfg.timeout(req.ItemsCount())
 	// TODO: Remove this by avoiding to create the timeout sender if timeout is 0.
-	if ts.cfg.Timeout == 0 {
+	if timeout == 0 {
 		return req.Export(ctx)
 	}
 	// Intentionally don't overwrite the context inside the request, because in case of retries deadline will not be
 	// updated because this deadline most likely is before the next one.
-	tCtx, cancelFunc := context.WithTimeout(ctx, ts.cfg.Timeout)
+	tCtx, cancelFunc := context.WithTimeout(ctx, timeout)
 	defer cancelFunc()
 	return req.Export(tCtx)
 }
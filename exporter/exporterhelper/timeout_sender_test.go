@@ -4,10 +4,13 @@
 package exporterhelper
 
 import (
+	"context"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDefaultTimeoutSettings(t *testing.T) {
@@ -22,3 +25,98 @@ func TestInvalidTimeout(t *testing.T) {
 	cfg.Timeout = -1
 	assert.Error(t, cfg.Validate())
 }
+
+func TestInvalidScaledTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    TimeoutSettings
+		errMsg string
+	}{
+		{
+			name:   "negative per item timeout",
+			cfg:    TimeoutSettings{Timeout: time.Second, PerItemTimeout: -1},
+			errMsg: "'per_item_timeout' must be non-negative",
+		},
+		{
+			name:   "negative max timeout",
+			cfg:    TimeoutSettings{Timeout: time.Second, MaxTimeout: -1},
+			errMsg: "'max_timeout' must be non-negative",
+		},
+		{
+			name:   "max timeout lower than timeout",
+			cfg:    TimeoutSettings{Timeout: time.Second, MaxTimeout: time.Millisecond},
+			errMsg: "'max_timeout' must be greater than or equal to 'timeout'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.errMsg)
+		})
+	}
+}
+
+func TestScaledTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      TimeoutSettings
+		items    int
+		expected time.Duration
+	}{
+		{
+			name:     "fixed",
+			cfg:      TimeoutSettings{Timeout: 5 * time.Second},
+			items:    1000,
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "no timeout",
+			cfg:      TimeoutSettings{},
+			items:    1000,
+			expected: 0,
+		},
+		{
+			name:     "scaled",
+			cfg:      TimeoutSettings{Timeout: 5 * time.Second, PerItemTimeout: time.Millisecond},
+			items:    1000,
+			expected: 6 * time.Second,
+		},
+		{
+			name:     "scaled without base",
+			cfg:      TimeoutSettings{PerItemTimeout: time.Millisecond},
+			items:    1000,
+			expected: time.Second,
+		},
+		{
+			name:     "empty request",
+			cfg:      TimeoutSettings{Timeout: 5 * time.Second, PerItemTimeout: time.Millisecond},
+			items:    0,
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "capped",
+			cfg:      TimeoutSettings{Timeout: 5 * time.Second, PerItemTimeout: time.Millisecond, MaxTimeout: 10 * time.Second},
+			items:    100000,
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "overflow",
+			cfg:      TimeoutSettings{Timeout: 5 * time.Second, PerItemTimeout: time.Hour},
+			items:    math.MaxInt,
+			expected: 5*time.Second + time.Duration(math.MaxInt64-5*time.Second)/time.Hour*time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.cfg.Validate())
+			assert.Equal(t, tt.expected, tt.cfg.timeout(tt.items))
+		})
+	}
+}
+
+func TestTimeoutSenderScaled(t *testing.T) {
+	ts := &timeoutSender{cfg: TimeoutSettings{Timeout: 50 * time.Millisecond, PerItemTimeout: 50 * time.Millisecond}}
+	// The large request takes longer than the base timeout, but less than its scaled timeout.
+	require.NoError(t, ts.send(context.Background(), &fakeRequest{items: 4, delay: 100 * time.Millisecond}))
+	err := ts.send(context.Background(), &fakeRequest{items: 1, delay: time.Second})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
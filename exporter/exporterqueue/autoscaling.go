@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporterqueue"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+// NumConsumersAuto is the value of num_consumers enabling the autoscaling of the number of consumers.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+const NumConsumersAuto = queue.NumConsumersAuto
+
+// AutoscalingConfig defines the bounds of the number of consumers when num_consumers is set to "auto".
+// The number of consumers starts at MinConsumers and is adjusted based on the queue depth and the
+// observed export latency.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type AutoscalingConfig struct {
+	// Enabled indicates whether the number of consumers is autoscaled. It is set when num_consumers is "auto",
+	// in which case the configured number of consumers is ignored.
+	Enabled bool `mapstructure:"-"`
+	// MinConsumers is the minimum number of consumers. Defaults to 1.
+	MinConsumers int `mapstructure:"min_consumers"`
+	// MaxConsumers is the maximum number of consumers. Defaults to 100.
+	MaxConsumers int `mapstructure:"max_consumers"`
+}
+
+// Validate checks if the AutoscalingConfig configuration is valid.
+func (aCfg *AutoscalingConfig) Validate() error {
+	if !aCfg.Enabled {
+		return nil
+	}
+	if aCfg.MinConsumers < 0 {
+		return errors.New("autoscaling min_consumers must not be negative")
+	}
+	if aCfg.MaxConsumers < 0 {
+		return errors.New("autoscaling max_consumers must not be negative")
+	}
+	if aCfg.MaxConsumers > 0 && aCfg.MinConsumers > aCfg.MaxConsumers {
+		return errors.New("autoscaling min_consumers must not be greater than max_consumers")
+	}
+	return nil
+}
@@ -5,8 +5,13 @@ package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporter
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
 // Config defines configuration for queueing requests before exporting.
@@ -17,15 +22,56 @@ type Config struct {
 	// Enabled indicates whether to not enqueue batches before exporting.
 	Enabled bool `mapstructure:"enabled"`
 	// NumConsumers is the number of consumers from the queue.
+	// It can be set to "auto" in the configuration to enable Autoscaling.
 	NumConsumers int `mapstructure:"num_consumers"`
+	// Autoscaling configures the bounds of the number of consumers when num_consumers is "auto".
+	Autoscaling AutoscalingConfig `mapstructure:"autoscaling"`
 	// QueueSize is the maximum number of requests allowed in queue at any given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// Priority configures how requests are prioritized in the memory queue.
+	Priority PriorityConfig `mapstructure:"priority"`
+	// FullBehavior defines what happens to a request offered to the full queue. Defaults to "reject".
+	FullBehavior FullBehavior `mapstructure:"queue_full_behavior"`
+	// BlockTimeout is the maximum time a request waits for room in the queue when FullBehavior is "block",
+	// after which it is rejected. Zero waits until the context of the request is done.
+	BlockTimeout time.Duration `mapstructure:"block_timeout"`
+}
+
+// FullBehavior defines what happens to a request offered to a full queue.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type FullBehavior string
+
+const (
+	// FullBehaviorReject rejects the request with ErrQueueIsFull, the data is dropped unless the caller retries.
+	FullBehaviorReject FullBehavior = "reject"
+	// FullBehaviorBlock blocks the caller until there is room for the request in the queue, so the backpressure
+	// propagates to the receivers and to their clients.
+	FullBehaviorBlock FullBehavior = "block"
+)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (fb *FullBehavior) UnmarshalText(text []byte) error {
+	switch v := FullBehavior(text); v {
+	case FullBehaviorReject, FullBehaviorBlock:
+		*fb = v
+		return nil
+	}
+	return fmt.Errorf("unknown queue full behavior %q, must be one of %q or %q", text, FullBehaviorReject, FullBehaviorBlock)
 }
 
 // NewDefaultConfig returns the default Config.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func NewDefaultConfig() Config {
+	// The queue is smaller when the collector runs with the agent runtime profile.
+	if runtimeprofile.IsAgent() {
+		return Config{
+			Enabled:      true,
+			NumConsumers: 2,
+			QueueSize:    100,
+		}
+	}
 	return Config{
 		Enabled:      true,
 		NumConsumers: 10,
@@ -38,12 +84,27 @@ func (qCfg *Config) Validate() error {
 	if !qCfg.Enabled {
 		return nil
 	}
-	if qCfg.NumConsumers <= 0 {
+	if qCfg.NumConsumers <= 0 && !qCfg.Autoscaling.Enabled {
 		return errors.New("number of consumers must be positive")
 	}
 	if qCfg.QueueSize <= 0 {
 		return errors.New("queue size must be positive")
 	}
+	if qCfg.BlockTimeout < 0 {
+		return errors.New("block timeout must not be negative")
+	}
+	return errors.Join(qCfg.Autoscaling.Validate(), qCfg.Priority.Validate())
+}
+
+type rawConfig Config
+
+// Unmarshal a confmap.Conf into the config struct, accepting "auto" as number of consumers.
+func (qCfg *Config) Unmarshal(conf *confmap.Conf) error {
+	conf, auto := queue.WithoutNumConsumersAuto(conf)
+	if err := conf.Unmarshal((*rawConfig)(qCfg)); err != nil {
+		return err
+	}
+	qCfg.Autoscaling.Enabled = qCfg.Autoscaling.Enabled || auto
 	return nil
 }
 
@@ -59,3 +120,21 @@ type PersistentQueueConfig struct {
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
 }
+
+type rawPersistentQueueConfig struct {
+	rawConfig `mapstructure:",squash"`
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+// Unmarshal a confmap.Conf into the config struct, accepting "auto" as number of consumers.
+func (pCfg *PersistentQueueConfig) Unmarshal(conf *confmap.Conf) error {
+	conf, auto := queue.WithoutNumConsumersAuto(conf)
+	raw := rawPersistentQueueConfig{rawConfig: rawConfig(pCfg.Config), StorageID: pCfg.StorageID}
+	if err := conf.Unmarshal(&raw); err != nil {
+		return err
+	}
+	pCfg.Config = Config(raw.rawConfig)
+	pCfg.StorageID = raw.StorageID
+	pCfg.Autoscaling.Enabled = pCfg.Autoscaling.Enabled || auto
+	return nil
+}
@@ -5,8 +5,14 @@ package exporterqueue
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
 func TestQueueConfig_Validate(t *testing.T) {
@@ -16,6 +22,16 @@ func TestQueueConfig_Validate(t *testing.T) {
 	qCfg.NumConsumers = 0
 	assert.EqualError(t, qCfg.Validate(), "number of consumers must be positive")
 
+	qCfg.Autoscaling.Enabled = true
+	assert.NoError(t, qCfg.Validate())
+	qCfg.Autoscaling.MinConsumers = 10
+	qCfg.Autoscaling.MaxConsumers = 5
+	assert.EqualError(t, qCfg.Validate(), "autoscaling min_consumers must not be greater than max_consumers")
+
+	qCfg = NewDefaultConfig()
+	qCfg.BlockTimeout = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "block timeout must not be negative")
+
 	qCfg = NewDefaultConfig()
 	qCfg.QueueSize = 0
 	assert.EqualError(t, qCfg.Validate(), "queue size must be positive")
@@ -24,3 +40,58 @@ func TestQueueConfig_Validate(t *testing.T) {
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
 }
+
+func TestNewDefaultConfigAgentProfile(t *testing.T) {
+	require.NoError(t, runtimeprofile.Set(runtimeprofile.Agent))
+	t.Cleanup(func() { require.NoError(t, runtimeprofile.Set(runtimeprofile.Default)) })
+	qCfg := NewDefaultConfig()
+	assert.Equal(t, 2, qCfg.NumConsumers)
+	assert.Equal(t, 100, qCfg.QueueSize)
+	assert.NoError(t, qCfg.Validate())
+}
+
+func TestQueueConfig_UnmarshalNumConsumersAuto(t *testing.T) {
+	qCfg := NewDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"num_consumers": "auto",
+		"autoscaling":   map[string]any{"max_consumers": 20},
+	}).Unmarshal(&qCfg))
+	expected := NewDefaultConfig()
+	expected.Autoscaling = AutoscalingConfig{Enabled: true, MaxConsumers: 20}
+	assert.Equal(t, expected, qCfg)
+
+	qCfg = NewDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{"num_consumers": 5}).Unmarshal(&qCfg))
+	assert.Equal(t, 5, qCfg.NumConsumers)
+	assert.False(t, qCfg.Autoscaling.Enabled)
+
+	qCfg = NewDefaultConfig()
+	assert.Error(t, confmap.NewFromStringMap(map[string]any{"num_consumers": "many"}).Unmarshal(&qCfg))
+}
+
+func TestPersistentQueueConfig_UnmarshalNumConsumersAuto(t *testing.T) {
+	pCfg := PersistentQueueConfig{Config: NewDefaultConfig()}
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"num_consumers": "auto",
+		"storage":       "file_storage",
+	}).Unmarshal(&pCfg))
+	assert.True(t, pCfg.Autoscaling.Enabled)
+	assert.Equal(t, 1_000, pCfg.QueueSize)
+	require.NotNil(t, pCfg.StorageID)
+	assert.Equal(t, component.MustNewID("file_storage"), *pCfg.StorageID)
+
+	assert.Error(t, confmap.NewFromStringMap(map[string]any{"unknown": true}).Unmarshal(&pCfg))
+}
+
+func TestQueueConfig_UnmarshalFullBehavior(t *testing.T) {
+	qCfg := NewDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"queue_full_behavior": "block",
+		"block_timeout":       "5s",
+	}).Unmarshal(&qCfg))
+	assert.Equal(t, FullBehaviorBlock, qCfg.FullBehavior)
+	assert.Equal(t, 5*time.Second, qCfg.BlockTimeout)
+
+	assert.ErrorContains(t, confmap.NewFromStringMap(map[string]any{"queue_full_behavior": "drop"}).Unmarshal(&qCfg),
+		`unknown queue full behavior "drop", must be one of "reject" or "block"`)
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporterqueue"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+// Priority is the priority of a request in the queue.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type Priority string
+
+const (
+	// PriorityHigh requests are consumed first and shed last.
+	PriorityHigh Priority = "high"
+	// PriorityNormal is the priority of requests without explicit priority.
+	PriorityNormal Priority = "normal"
+	// PriorityLow requests are consumed last and shed first.
+	PriorityLow Priority = "low"
+)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *Priority) UnmarshalText(text []byte) error {
+	switch v := Priority(text); v {
+	case PriorityHigh, PriorityNormal, PriorityLow:
+		*p = v
+		return nil
+	}
+	return fmt.Errorf("unknown priority %q, must be one of %q, %q or %q", text, PriorityHigh, PriorityNormal, PriorityLow)
+}
+
+func (p Priority) toInternal() queue.Priority {
+	switch p {
+	case PriorityHigh:
+		return queue.PriorityHigh
+	case PriorityLow:
+		return queue.PriorityLow
+	}
+	return queue.PriorityNormal
+}
+
+// DropPolicy defines what happens to a request when there is no room left for its priority,
+// once all the requests of lower priorities have been shed.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type DropPolicy string
+
+const (
+	// DropPolicyReject rejects the new request with ErrQueueIsFull.
+	DropPolicyReject DropPolicy = "reject"
+	// DropPolicyDropOldest drops the oldest requests of the same priority to make room for the new request.
+	DropPolicyDropOldest DropPolicy = "drop_oldest"
+)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (dp *DropPolicy) UnmarshalText(text []byte) error {
+	switch v := DropPolicy(text); v {
+	case DropPolicyReject, DropPolicyDropOldest:
+		*dp = v
+		return nil
+	}
+	return fmt.Errorf("unknown drop policy %q, must be one of %q or %q", text, DropPolicyReject, DropPolicyDropOldest)
+}
+
+type priorityContextKey struct{}
+
+// ContextWithPriority returns a copy of ctx carrying the given priority. Requests offered to a queue with
+// priorities enabled get the priority of their context, if any, instead of the default priority of their signal.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, p)
+}
+
+func priorityFromContext(ctx context.Context) (Priority, bool) {
+	p, ok := ctx.Value(priorityContextKey{}).(Priority)
+	return p, ok
+}
+
+// PriorityConfig defines how requests are prioritized in the memory queue.
+// Priorities are not supported by the persistent queue.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type PriorityConfig struct {
+	// Enabled indicates whether requests are prioritized.
+	Enabled bool `mapstructure:"enabled"`
+	// Signals maps a signal type ("traces", "metrics" or "logs") to the priority of its requests,
+	// used when the request context has no priority. Defaults to "normal".
+	Signals map[string]Priority `mapstructure:"signals"`
+	// High configures the capacity slice and drop policy of high priority requests.
+	High PriorityLevelConfig `mapstructure:"high"`
+	// Normal configures the capacity slice and drop policy of normal priority requests.
+	Normal PriorityLevelConfig `mapstructure:"normal"`
+	// Low configures the capacity slice and drop policy of low priority requests.
+	Low PriorityLevelConfig `mapstructure:"low"`
+}
+
+// PriorityLevelConfig defines the configuration of a single priority.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type PriorityLevelConfig struct {
+	// MaxShare is the share of the queue size that requests of this priority may use, in [0, 1].
+	// Zero, the default, lets requests of this priority use the whole queue.
+	MaxShare float64 `mapstructure:"max_share"`
+	// DropPolicy is applied when there is no room for a request of this priority. Defaults to "reject".
+	DropPolicy DropPolicy `mapstructure:"drop_policy"`
+}
+
+// Validate checks if the PriorityConfig configuration is valid.
+func (pCfg *PriorityConfig) Validate() error {
+	if !pCfg.Enabled {
+		return nil
+	}
+	signals := make([]string, 0, len(pCfg.Signals))
+	for signal := range pCfg.Signals {
+		signals = append(signals, signal)
+	}
+	sort.Strings(signals)
+	var errs error
+	for _, signal := range signals {
+		switch signal {
+		case component.DataTypeTraces.String(), component.DataTypeMetrics.String(), component.DataTypeLogs.String():
+		default:
+			errs = errors.Join(errs, fmt.Errorf("unknown signal %q in priority signals", signal))
+		}
+	}
+	for _, level := range []struct {
+		name string
+		cfg  PriorityLevelConfig
+	}{
+		{string(PriorityHigh), pCfg.High},
+		{string(PriorityNormal), pCfg.Normal},
+		{string(PriorityLow), pCfg.Low},
+	} {
+		if level.cfg.MaxShare < 0 || level.cfg.MaxShare > 1 {
+			errs = errors.Join(errs, fmt.Errorf("%s priority max_share must be in [0, 1]", level.name))
+		}
+	}
+	return errs
+}
+
+// priorityQueueSettings converts the configuration to the settings of the internal priority queue
+// holding requests of the given signal.
+func priorityQueueSettings[T any](pCfg PriorityConfig, dataType component.DataType, sizer queue.Sizer[T], capacity int64) queue.PriorityQueueSettings[T] {
+	defaultPriority := PriorityNormal
+	if p, ok := pCfg.Signals[dataType.String()]; ok {
+		defaultPriority = p
+	}
+	set := queue.PriorityQueueSettings[T]{
+		Sizer:    sizer,
+		Capacity: capacity,
+		PriorityFunc: func(ctx context.Context, _ T) queue.Priority {
+			if p, ok := priorityFromContext(ctx); ok {
+				return p.toInternal()
+			}
+			return defaultPriority.toInternal()
+		},
+	}
+	set.Levels[queue.PriorityHigh] = levelSettings(pCfg.High, capacity)
+	set.Levels[queue.PriorityNormal] = levelSettings(pCfg.Normal, capacity)
+	set.Levels[queue.PriorityLow] = levelSettings(pCfg.Low, capacity)
+	return set
+}
+
+func levelSettings(lCfg PriorityLevelConfig, capacity int64) queue.PriorityLevelSettings {
+	levelCapacity := capacity
+	if lCfg.MaxShare > 0 {
+		levelCapacity = max(int64(lCfg.MaxShare*float64(capacity)), 1)
+	}
+	dropPolicy := queue.DropPolicyReject
+	if lCfg.DropPolicy == DropPolicyDropOldest {
+		dropPolicy = queue.DropPolicyDropOldest
+	}
+	return queue.PriorityLevelSettings{Capacity: levelCapacity, DropPolicy: dropPolicy}
+}
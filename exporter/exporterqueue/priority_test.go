@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+func TestPriorityConfig_Validate(t *testing.T) {
+	pCfg := PriorityConfig{}
+	pCfg.High.MaxShare = -0.5
+	// Disabled configuration is not validated.
+	require.NoError(t, pCfg.Validate())
+
+	pCfg.Enabled = true
+	pCfg.Low.MaxShare = 1.5
+	pCfg.Signals = map[string]Priority{"profiles": PriorityLow, "traces": PriorityHigh, "events": PriorityLow}
+	assert.EqualError(t, pCfg.Validate(), `unknown signal "events" in priority signals
+unknown signal "profiles" in priority signals
+high priority max_share must be in [0, 1]
+low priority max_share must be in [0, 1]`)
+
+	pCfg = PriorityConfig{Enabled: true}
+	pCfg.Signals = map[string]Priority{"traces": PriorityHigh}
+	assert.NoError(t, pCfg.Validate())
+}
+
+func TestPriority_UnmarshalText(t *testing.T) {
+	var p Priority
+	require.NoError(t, p.UnmarshalText([]byte("high")))
+	assert.Equal(t, PriorityHigh, p)
+	assert.EqualError(t, p.UnmarshalText([]byte("urgent")), `unknown priority "urgent", must be one of "high", "normal" or "low"`)
+
+	var dp DropPolicy
+	require.NoError(t, dp.UnmarshalText([]byte("drop_oldest")))
+	assert.Equal(t, DropPolicyDropOldest, dp)
+	assert.EqualError(t, dp.UnmarshalText([]byte("drop_newest")), `unknown drop policy "drop_newest", must be one of "reject" or "drop_oldest"`)
+}
+
+func TestPriorityQueueSettings(t *testing.T) {
+	pCfg := PriorityConfig{}
+	pCfg.Signals = map[string]Priority{"logs": PriorityLow}
+	pCfg.Low = PriorityLevelConfig{MaxShare: 0.25, DropPolicy: DropPolicyDropOldest}
+	pCfg.Normal.MaxShare = 0.001
+
+	set := priorityQueueSettings[int](pCfg, component.DataTypeLogs, &queue.RequestSizer[int]{}, 100)
+	assert.Equal(t, queue.PriorityLevelSettings{Capacity: 25, DropPolicy: queue.DropPolicyDropOldest}, set.Levels[queue.PriorityLow])
+	assert.Equal(t, queue.PriorityLevelSettings{Capacity: 1, DropPolicy: queue.DropPolicyReject}, set.Levels[queue.PriorityNormal])
+	assert.Equal(t, queue.PriorityLevelSettings{Capacity: 100, DropPolicy: queue.DropPolicyReject}, set.Levels[queue.PriorityHigh])
+
+	assert.Equal(t, queue.PriorityLow, set.PriorityFunc(context.Background(), 0))
+	ctx := ContextWithPriority(context.Background(), PriorityHigh)
+	assert.Equal(t, queue.PriorityHigh, set.PriorityFunc(ctx, 0))
+
+	set = priorityQueueSettings[int](pCfg, component.DataTypeTraces, &queue.RequestSizer[int]{}, 100)
+	assert.Equal(t, queue.PriorityNormal, set.PriorityFunc(context.Background(), 0))
+}
@@ -6,9 +6,12 @@ package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporter
 import (
 	"context"
 
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
 )
 
 // ErrQueueIsFull is the error that Queue returns when full.
@@ -47,7 +50,17 @@ type Factory[T any] func(context.Context, Settings, Config) Queue[T]
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func NewMemoryQueueFactory[T itemsCounter]() Factory[T] {
-	return func(_ context.Context, _ Settings, cfg Config) Queue[T] {
+	return func(_ context.Context, set Settings, cfg Config) Queue[T] {
+		if cfg.Priority.Enabled {
+			pqSet := priorityQueueSettings[T](cfg.Priority, set.DataType, sizerFromConfig[T](cfg), capacityFromConfig(cfg))
+			pqSet.OnDrop = func(ctx context.Context, req T) {
+				set.ExporterSettings.Logger.Warn("Dropping request from the sending queue to make room for a higher priority request.",
+					zap.Int("dropped_items", req.ItemsCount()))
+				// The acks of the request are held by the sending queue until it is consumed.
+				ack.Release(ack.ErrDropped, ack.FromContext(ctx)...)
+			}
+			return queue.NewPriorityQueue[T](pqSet)
+		}
 		return queue.NewBoundedMemoryQueue[T](queue.MemoryQueueSettings[T]{
 			Sizer:    sizerFromConfig[T](cfg),
 			Capacity: capacityFromConfig(cfg),
@@ -66,7 +79,7 @@ type PersistentQueueSettings[T any] struct {
 }
 
 // NewPersistentQueueFactory returns a factory to create a new persistent queue.
-// If cfg.StorageID is nil then it falls back to memory queue.
+// If cfg.StorageID is nil then it falls back to memory queue. The persistent queue ignores the priority configuration.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func NewPersistentQueueFactory[T itemsCounter](storageID *component.ID, factorySettings PersistentQueueSettings[T]) Factory[T] {
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporterqueue"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+// SpillConfig defines the disk tier of the memory queue: the requests which do not fit in the memory queue
+// are spilled to a persistent queue, and replayed into the memory queue once it has room again.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type SpillConfig struct {
+	// StorageID if not empty, enables the disk tier and uses the component specified as a storage extension.
+	StorageID *component.ID `mapstructure:"storage"`
+	// QueueSize is the maximum number of requests spilled to the disk tier.
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// Validate checks if the SpillConfig configuration is valid.
+func (sCfg *SpillConfig) Validate() error {
+	if sCfg.StorageID == nil {
+		return nil
+	}
+	if sCfg.QueueSize <= 0 {
+		return errors.New("spill queue size must be positive")
+	}
+	return nil
+}
+
+// NewSpillQueueFactory returns a factory to create the queues of memoryFactory backed by a disk tier.
+// If spillCfg.StorageID is nil then it returns memoryFactory.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func NewSpillQueueFactory[T itemsCounter](memoryFactory Factory[T], spillCfg SpillConfig, factorySettings PersistentQueueSettings[T]) Factory[T] {
+	if spillCfg.StorageID == nil {
+		return memoryFactory
+	}
+	return func(ctx context.Context, set Settings, cfg Config) Queue[T] {
+		disk := queue.NewPersistentQueue[T](queue.PersistentQueueSettings[T]{
+			Sizer:            sizerFromConfig[T](cfg),
+			Capacity:         int64(spillCfg.QueueSize),
+			DataType:         set.DataType,
+			StorageID:        *spillCfg.StorageID,
+			Marshaler:        factorySettings.Marshaler,
+			Unmarshaler:      factorySettings.Unmarshaler,
+			ExporterSettings: set.ExporterSettings,
+		})
+		return queue.NewSpillQueue[T](memoryFactory(ctx, set, cfg), disk)
+	}
+}
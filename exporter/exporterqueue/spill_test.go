@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+type fakeRequest struct{}
+
+func (fakeRequest) ItemsCount() int { return 1 }
+
+func TestSpillConfig_Validate(t *testing.T) {
+	sCfg := SpillConfig{}
+	// Disabled configuration is not validated.
+	assert.NoError(t, sCfg.Validate())
+
+	storageID := component.MustNewID("file_storage")
+	sCfg.StorageID = &storageID
+	assert.EqualError(t, sCfg.Validate(), "spill queue size must be positive")
+	sCfg.QueueSize = 100
+	assert.NoError(t, sCfg.Validate())
+}
+
+func TestNewSpillQueueFactory(t *testing.T) {
+	set := Settings{DataType: component.DataTypeTraces, ExporterSettings: exportertest.NewNopSettings()}
+	cfg := NewDefaultConfig()
+
+	// Without storage, the memory queue is not backed by a disk tier.
+	qf := NewSpillQueueFactory[fakeRequest](NewMemoryQueueFactory[fakeRequest](), SpillConfig{}, PersistentQueueSettings[fakeRequest]{})
+	assert.Equal(t, 1_000, qf(context.Background(), set, cfg).Capacity())
+
+	storageID := component.MustNewID("file_storage")
+	qf = NewSpillQueueFactory[fakeRequest](NewMemoryQueueFactory[fakeRequest](), SpillConfig{StorageID: &storageID, QueueSize: 5_000},
+		PersistentQueueSettings[fakeRequest]{})
+	assert.NotNil(t, qf(context.Background(), set, cfg))
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality // import "go.opentelemetry.io/collector/exporter/exportertemporality"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines the configuration for converting delta metric streams to cumulative temporality.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type Config struct {
+	// MaxStale is the duration after which a stream that did not receive any data point is evicted,
+	// and its accumulated state is discarded. The next data point for an evicted stream starts a new
+	// cumulative series. Setting this value to zero disables time-based eviction.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+
+	// MaxStreams is the maximum number of streams tracked at any given time. Data points for new streams
+	// are dropped once the limit is reached. Setting this value to zero disables the limit.
+	MaxStreams int `mapstructure:"max_streams"`
+}
+
+// NewDefaultConfig returns the default Config.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func NewDefaultConfig() Config {
+	return Config{
+		MaxStale:   5 * time.Minute,
+		MaxStreams: 0,
+	}
+}
+
+// Validate checks if the Config is valid.
+func (c *Config) Validate() error {
+	if c.MaxStale < 0 {
+		return errors.New("max_stale must be greater than or equal to zero")
+	}
+	if c.MaxStreams < 0 {
+		return errors.New("max_streams must be greater than or equal to zero")
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality // import "go.opentelemetry.io/collector/exporter/exportertemporality"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// CumulativeToDelta converts cumulative sums and histograms to delta temporality, so exporters
+// targeting delta-only backends can opt in to receive cumulative data.
+//
+// The converter keeps the last cumulative value of every stream it has seen. The first data point of a stream
+// is dropped, since the interval it covers may have been reported already, unless the stream started after the
+// converter was created. The following data points are the difference with the previous data point, starting at
+// its timestamp. A reset of the stream, signaled by a new start timestamp or detected by a decrease of a monotonic
+// sum or of a histogram count or bucket, emits the cumulative value of the data point, which covers the interval
+// since the reset. The minimum and maximum of histograms are removed, since they cannot be derived from the
+// cumulative ones.
+//
+// Data points as old as the previous one of their stream are dropped, as well as data points for new streams
+// once Config.MaxStreams is reached. Streams that did not receive any data point for Config.MaxStale are evicted.
+//
+// Metrics of other types, delta metrics and exponential histograms are left untouched.
+//
+// It is safe to call the methods of a CumulativeToDelta concurrently.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type CumulativeToDelta struct {
+	streams
+	// startTime is when the converter was created: the streams starting after it are known to be complete.
+	startTime pcommon.Timestamp
+}
+
+// NewCumulativeToDelta returns a new CumulativeToDelta converter.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func NewCumulativeToDelta(cfg Config) *CumulativeToDelta {
+	return &CumulativeToDelta{
+		streams: streams{
+			cfg: cfg,
+			m:   make(map[streamID]*stream),
+			now: time.Now,
+		},
+		startTime: pcommon.NewTimestampFromTime(time.Now()),
+	}
+}
+
+// ConvertMetrics converts in place all the cumulative sums and histograms in md to delta temporality.
+// Metrics, scopes and resources left without any data point are removed. The caller must own md, exporters
+// using the converter must declare that they mutate data.
+func (c *CumulativeToDelta) ConvertMetrics(md pmetric.Metrics) {
+	c.convert(md, c.convertMetric)
+}
+
+// convertMetric converts the data points of the metric, and returns whether the metric must be removed.
+func (c *CumulativeToDelta) convertMetric(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, now time.Time) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		if sum.DataPoints().Len() == 0 || sum.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return false
+		}
+		metricID := metricIdentity(res, scope, m)
+		sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			return !c.convertNumber(newStreamID(metricID, dp.Attributes()), sum.IsMonotonic(), dp, now)
+		})
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		return sum.DataPoints().Len() == 0
+	case pmetric.MetricTypeHistogram:
+		hist := m.Histogram()
+		if hist.DataPoints().Len() == 0 || hist.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return false
+		}
+		metricID := metricIdentity(res, scope, m)
+		hist.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			return !c.convertHistogram(newStreamID(metricID, dp.Attributes()), dp, now)
+		})
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		return hist.DataPoints().Len() == 0
+	}
+	return false
+}
+
+// convertNumber converts the cumulative sum data point, and returns whether it must be kept.
+func (c *CumulativeToDelta) convertNumber(id streamID, monotonic bool, dp pmetric.NumberDataPoint, now time.Time) bool {
+	if dp.Flags().NoRecordedValue() {
+		// The stream is marked stale by its source: the next data point starts a new stream.
+		delete(c.m, id)
+		return true
+	}
+	s, known, keep := c.lookupNumber(id, dp, now)
+	if !keep {
+		return false
+	}
+	keep, diff := c.delta(s, known, known && monotonic && s.numberDecreased(dp), dp)
+	s.saveNumber(dp, diff)
+	return keep
+}
+
+// convertHistogram converts the cumulative histogram data point, and returns whether it must be kept.
+func (c *CumulativeToDelta) convertHistogram(id streamID, dp pmetric.HistogramDataPoint, now time.Time) bool {
+	if dp.Flags().NoRecordedValue() {
+		delete(c.m, id)
+		return true
+	}
+	s, known, keep := c.lookupHistogram(id, dp, now)
+	if !keep {
+		return false
+	}
+	keep, diff := c.delta(s, known, known && s.histogramDecreased(dp), dp)
+	s.saveHistogram(dp, diff)
+	return keep
+}
+
+// delta sets the start timestamp of the delta data point converted from a cumulative data point. It returns
+// whether the data point must be kept, and whether its value must be set to the difference with the previous
+// data point rather than left as the cumulative value since the start of the stream.
+func (c *CumulativeToDelta) delta(s *stream, known, decreased bool, dp dataPoint) (keep bool, diff bool) {
+	start := dp.StartTimestamp()
+	defer func() {
+		s.inStart = start
+		s.last = dp.Timestamp()
+	}()
+	if !known {
+		return start != 0 && start >= c.startTime, false
+	}
+	if decreased || start != s.inStart {
+		// The stream was reset: the cumulative value is the delta since the reset.
+		if start == 0 || start < s.last {
+			dp.SetStartTimestamp(s.last)
+		}
+		return true, false
+	}
+	dp.SetStartTimestamp(s.last)
+	return true, true
+}
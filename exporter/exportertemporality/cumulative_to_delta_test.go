@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// converterStart is the start time of the converters under test, the timestamps of the points are in seconds
+// relatively to it.
+var converterStart = time.Unix(1000, 0)
+
+func ts(sec int) pcommon.Timestamp {
+	if sec < 0 {
+		return 0
+	}
+	return pcommon.NewTimestampFromTime(converterStart.Add(time.Duration(sec) * time.Second))
+}
+
+type point struct {
+	start, ts int
+	value     int64
+}
+
+func newSum(temporality pmetric.AggregationTemporality, monotonic bool, p point) pmetric.Metrics {
+	md := newDeltaSum("requests", ts(p.start), ts(p.ts), p.value, nil)
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+	sum.SetAggregationTemporality(temporality)
+	sum.SetIsMonotonic(monotonic)
+	return md
+}
+
+// convertPoints converts the points one by one, and returns the points emitted.
+func convertPoints(t *testing.T, convert func(pmetric.Metrics), temporality pmetric.AggregationTemporality, monotonic bool, points []point) []point {
+	var got []point
+	for _, p := range points {
+		md := newSum(temporality, monotonic, p)
+		convert(md)
+		if md.ResourceMetrics().Len() == 0 {
+			continue
+		}
+		dps := firstSum(t, md).DataPoints()
+		require.Equal(t, 1, dps.Len())
+		got = append(got, point{
+			start: int(dps.At(0).StartTimestamp().AsTime().Sub(converterStart) / time.Second),
+			ts:    int(dps.At(0).Timestamp().AsTime().Sub(converterStart) / time.Second),
+			value: dps.At(0).IntValue(),
+		})
+	}
+	return got
+}
+
+func newTestCumulativeToDelta() *CumulativeToDelta {
+	c := NewCumulativeToDelta(NewDefaultConfig())
+	c.startTime = pcommon.NewTimestampFromTime(converterStart)
+	c.now = func() time.Time { return converterStart }
+	return c
+}
+
+func TestCumulativeToDelta_Sum(t *testing.T) {
+	tests := []struct {
+		name      string
+		monotonic bool
+		points    []point
+		expected  []point
+	}{
+		{
+			name:      "cumulative to delta",
+			monotonic: true,
+			points:    []point{{-100, 10, 3}, {-100, 20, 5}, {-100, 30, 10}, {-100, 30, 10}},
+			expected:  []point{{10, 20, 2}, {20, 30, 5}},
+		},
+		{
+			name:      "stream started after the converter",
+			monotonic: true,
+			points:    []point{{5, 10, 3}, {5, 20, 5}},
+			expected:  []point{{5, 10, 3}, {10, 20, 2}},
+		},
+		{
+			name:      "signaled reset",
+			monotonic: true,
+			points:    []point{{-100, 10, 3}, {-100, 20, 5}, {25, 30, 4}},
+			expected:  []point{{10, 20, 2}, {25, 30, 4}},
+		},
+		{
+			name:      "unsignaled reset",
+			monotonic: true,
+			points:    []point{{-100, 10, 3}, {-100, 20, 5}, {-100, 30, 1}},
+			expected:  []point{{10, 20, 2}, {20, 30, 1}},
+		},
+		{
+			name:     "non monotonic sum",
+			points:   []point{{-100, 10, 3}, {-100, 20, 5}, {-100, 30, 1}},
+			expected: []point{{10, 20, 2}, {20, 30, -4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCumulativeToDelta()
+			got := convertPoints(t, c.ConvertMetrics, pmetric.AggregationTemporalityCumulative, tt.monotonic, tt.points)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestCumulativeToDelta_DeltaUntouched(t *testing.T) {
+	c := newTestCumulativeToDelta()
+	points := []point{{0, 10, 3}, {10, 20, 2}, {10, 20, 2}}
+	got := convertPoints(t, c.ConvertMetrics, pmetric.AggregationTemporalityDelta, true, points)
+	assert.Equal(t, points, got)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCumulativeToDelta_NoRecordedValue(t *testing.T) {
+	c := newTestCumulativeToDelta()
+	c.ConvertMetrics(newSum(pmetric.AggregationTemporalityCumulative, true, point{-100, 10, 1}))
+	assert.Equal(t, 1, c.Len())
+
+	md := newSum(pmetric.AggregationTemporalityCumulative, true, point{-100, 20, 0})
+	firstSum(t, md).DataPoints().At(0).SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	c.ConvertMetrics(md)
+	assert.Equal(t, 1, firstSum(t, md).DataPoints().Len())
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCumulativeToDelta_Histogram(t *testing.T) {
+	c := newTestCumulativeToDelta()
+	bounds := []float64{1, 10}
+
+	md := newDeltaHistogram(ts(-100), ts(10), bounds, []uint64{1, 2, 0}, 6, 0.5, 5)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	c.ConvertMetrics(md)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = newDeltaHistogram(ts(-100), ts(20), bounds, []uint64{1, 3, 1}, 20, 0.5, 12)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	c.ConvertMetrics(md)
+	hist := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, hist.AggregationTemporality())
+	dp := hist.DataPoints().At(0)
+	assert.Equal(t, ts(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, []uint64{0, 1, 1}, dp.BucketCounts().AsRaw())
+	assert.InDelta(t, 14, dp.Sum(), 0)
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+
+	// A decrease of a bucket is an unsignaled reset.
+	md = newDeltaHistogram(ts(-100), ts(30), bounds, []uint64{2, 0, 0}, 1, 0.5, 0.5)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	c.ConvertMetrics(md)
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, ts(20), dp.StartTimestamp())
+	assert.Equal(t, []uint64{2, 0, 0}, dp.BucketCounts().AsRaw())
+	assert.True(t, dp.HasMin())
+}
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality // import "go.opentelemetry.io/collector/exporter/exportertemporality"
+
+import (
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// DeltaToCumulative converts delta sums and histograms to cumulative temporality, so exporters
+// targeting cumulative-only backends can opt in to receive delta data.
+//
+// The converter keeps the accumulated value and the start time of every stream it has seen.
+// Data points older than the last one accumulated for their stream are dropped, as well as data points
+// for new streams once Config.MaxStreams is reached. Streams that did not receive any data point
+// for Config.MaxStale are evicted.
+//
+// Metrics of other types and exponential histograms are left untouched, and so are the cumulative metrics
+// unless WithNormalizeCumulative is used.
+//
+// It is safe to call the methods of a DeltaToCumulative concurrently.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type DeltaToCumulative struct {
+	streams
+	normalize bool
+}
+
+// DeltaToCumulativeOption configures a DeltaToCumulative.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type DeltaToCumulativeOption func(*DeltaToCumulative)
+
+// WithNormalizeCumulative normalizes the cumulative sums and histograms: a missing start timestamp is set to the
+// timestamp of the first data point of the stream, and a decrease of a monotonic sum or of a histogram count or
+// bucket, without a new start timestamp, is signaled by setting the start timestamp to the timestamp of the
+// previous data point. Data points as old as the previous one of their stream are dropped.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithNormalizeCumulative() DeltaToCumulativeOption {
+	return func(c *DeltaToCumulative) {
+		c.normalize = true
+	}
+}
+
+// NewDeltaToCumulative returns a new DeltaToCumulative converter.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func NewDeltaToCumulative(cfg Config, opts ...DeltaToCumulativeOption) *DeltaToCumulative {
+	c := &DeltaToCumulative{
+		streams: streams{
+			cfg: cfg,
+			m:   make(map[streamID]*stream),
+			now: time.Now,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ConvertMetrics converts in place all the delta sums and histograms in md to cumulative temporality.
+// Metrics, scopes and resources left without any data point are removed. The caller must own md, exporters
+// using the converter must declare that they mutate data.
+func (c *DeltaToCumulative) ConvertMetrics(md pmetric.Metrics) {
+	c.convert(md, c.convertMetric)
+}
+
+// convertMetric converts the data points of the metric, and returns whether the metric must be removed.
+func (c *DeltaToCumulative) convertMetric(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, now time.Time) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		if sum.DataPoints().Len() == 0 {
+			return false
+		}
+		switch sum.AggregationTemporality() {
+		case pmetric.AggregationTemporalityDelta:
+			metricID := metricIdentity(res, scope, m)
+			sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+				return !c.accumulateNumber(newStreamID(metricID, dp.Attributes()), dp, now)
+			})
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		case pmetric.AggregationTemporalityCumulative:
+			if !c.normalize {
+				return false
+			}
+			metricID := metricIdentity(res, scope, m)
+			sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+				return !c.normalizeNumber(newStreamID(metricID, dp.Attributes()), sum.IsMonotonic(), dp, now)
+			})
+		default:
+			return false
+		}
+		return sum.DataPoints().Len() == 0
+	case pmetric.MetricTypeHistogram:
+		hist := m.Histogram()
+		if hist.DataPoints().Len() == 0 {
+			return false
+		}
+		switch hist.AggregationTemporality() {
+		case pmetric.AggregationTemporalityDelta:
+			metricID := metricIdentity(res, scope, m)
+			hist.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+				return !c.accumulateHistogram(newStreamID(metricID, dp.Attributes()), dp, now)
+			})
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		case pmetric.AggregationTemporalityCumulative:
+			if !c.normalize {
+				return false
+			}
+			metricID := metricIdentity(res, scope, m)
+			hist.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+				return !c.normalizeHistogram(newStreamID(metricID, dp.Attributes()), dp, now)
+			})
+		default:
+			return false
+		}
+		return hist.DataPoints().Len() == 0
+	}
+	return false
+}
+
+// lookup returns the state for the given delta stream, and whether the data point ending at ts must be kept.
+func (c *DeltaToCumulative) lookup(id streamID, start, ts pcommon.Timestamp, now time.Time) (*stream, bool) {
+	s, ok := c.m[id]
+	if !ok {
+		if s, ok = c.add(id); !ok {
+			return nil, false
+		}
+		if start == 0 {
+			start = ts
+		}
+		s.start = start
+	} else if ts <= s.last || (start != 0 && start < s.last) {
+		// Out of order or overlapping data point, accumulating it would double count.
+		return nil, false
+	}
+	s.last = ts
+	s.lastSeen = now
+	return s, true
+}
+
+func (c *DeltaToCumulative) accumulateNumber(id streamID, dp pmetric.NumberDataPoint, now time.Time) bool {
+	isNew := c.m[id] == nil
+	s, ok := c.lookup(id, dp.StartTimestamp(), dp.Timestamp(), now)
+	if !ok {
+		return false
+	}
+	isInt := dp.ValueType() == pmetric.NumberDataPointValueTypeInt
+	if isNew || s.isInt != isInt {
+		// First data point or value type change, start accumulating from scratch.
+		s.isInt = isInt
+		s.intValue = 0
+		s.doubleValue = 0
+	}
+
+	dp.SetStartTimestamp(s.start)
+	if dp.Flags().NoRecordedValue() {
+		return true
+	}
+	if isInt {
+		s.intValue += dp.IntValue()
+		dp.SetIntValue(s.intValue)
+	} else {
+		s.doubleValue += dp.DoubleValue()
+		dp.SetDoubleValue(s.doubleValue)
+	}
+	return true
+}
+
+func (c *DeltaToCumulative) accumulateHistogram(id streamID, dp pmetric.HistogramDataPoint, now time.Time) bool {
+	isNew := c.m[id] == nil
+	s, ok := c.lookup(id, dp.StartTimestamp(), dp.Timestamp(), now)
+	if !ok {
+		return false
+	}
+	bounds := dp.ExplicitBounds().AsRaw()
+	if !isNew && !slices.Equal(s.bounds, bounds) {
+		// The bucket layout changed, the previous state cannot be merged with the new one.
+		*s = stream{start: dp.Timestamp(), last: s.last, lastSeen: s.lastSeen}
+		if dp.StartTimestamp() != 0 {
+			s.start = dp.StartTimestamp()
+		}
+	}
+	s.bounds = bounds
+
+	dp.SetStartTimestamp(s.start)
+	if dp.Flags().NoRecordedValue() {
+		return true
+	}
+
+	s.count += dp.Count()
+	dp.SetCount(s.count)
+
+	if dp.HasSum() {
+		s.sum += dp.Sum()
+		s.hasSum = true
+	}
+	if s.hasSum {
+		dp.SetSum(s.sum)
+	}
+
+	if dp.HasMin() && (!s.hasMin || dp.Min() < s.min) {
+		s.min = dp.Min()
+		s.hasMin = true
+	}
+	if s.hasMin {
+		dp.SetMin(s.min)
+	}
+
+	if dp.HasMax() && (!s.hasMax || dp.Max() > s.max) {
+		s.max = dp.Max()
+		s.hasMax = true
+	}
+	if s.hasMax {
+		dp.SetMax(s.max)
+	}
+
+	counts := dp.BucketCounts()
+	if len(s.buckets) != counts.Len() {
+		s.buckets = make([]uint64, counts.Len())
+	}
+	for i := 0; i < counts.Len(); i++ {
+		s.buckets[i] += counts.At(i)
+	}
+	counts.FromRaw(s.buckets)
+	return true
+}
+
+// normalizeNumber normalizes the cumulative sum data point, and returns whether it must be kept.
+func (c *DeltaToCumulative) normalizeNumber(id streamID, monotonic bool, dp pmetric.NumberDataPoint, now time.Time) bool {
+	if dp.Flags().NoRecordedValue() {
+		// The stream is marked stale by its source: the next data point starts a new stream.
+		delete(c.m, id)
+		return true
+	}
+	s, known, keep := c.lookupNumber(id, dp, now)
+	if !keep {
+		return false
+	}
+	s.normalizeStart(known, known && monotonic && s.numberDecreased(dp), dp)
+	s.saveNumber(dp, false)
+	return true
+}
+
+// normalizeHistogram normalizes the cumulative histogram data point, and returns whether it must be kept.
+func (c *DeltaToCumulative) normalizeHistogram(id streamID, dp pmetric.HistogramDataPoint, now time.Time) bool {
+	if dp.Flags().NoRecordedValue() {
+		delete(c.m, id)
+		return true
+	}
+	s, known, keep := c.lookupHistogram(id, dp, now)
+	if !keep {
+		return false
+	}
+	s.normalizeStart(known, known && s.histogramDecreased(dp), dp)
+	s.saveHistogram(dp, false)
+	return true
+}
+
+// normalizeStart sets the start timestamp of a cumulative data point: a missing start timestamp is set to the
+// timestamp of the first data point of the stream, and a reset which is not signaled by a new start timestamp
+// is signaled by setting it to the previous timestamp.
+func (s *stream) normalizeStart(known, decreased bool, dp dataPoint) {
+	start := dp.StartTimestamp()
+	switch {
+	case !known:
+		s.start = start
+		if s.start == 0 {
+			s.start = dp.Timestamp()
+		}
+	case start != 0 && start != s.inStart:
+		s.start = start
+	case decreased:
+		s.start = s.last
+	}
+	s.inStart = start
+	s.last = dp.Timestamp()
+	dp.SetStartTimestamp(s.start)
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newDeltaSum(name string, start, ts pcommon.Timestamp, value int64, attrs map[string]any) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(value)
+	_ = dp.Attributes().FromRaw(attrs)
+	return md
+}
+
+func newDeltaHistogram(start, ts pcommon.Timestamp, bounds []float64, counts []uint64, sum, minVal, maxVal float64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	hist := m.SetEmptyHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	var count uint64
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(minVal)
+	dp.SetMax(maxVal)
+	return md
+}
+
+func firstSum(t *testing.T, md pmetric.Metrics) pmetric.Sum {
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	return ms.At(0).Sum()
+}
+
+func TestDeltaToCumulative_Sum(t *testing.T) {
+	c := NewDeltaToCumulative(NewDefaultConfig())
+
+	md := newDeltaSum("requests", 10, 20, 5, nil)
+	c.ConvertMetrics(md)
+	sum := firstSum(t, md)
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	assert.Equal(t, pcommon.Timestamp(10), sum.DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, int64(5), sum.DataPoints().At(0).IntValue())
+
+	md = newDeltaSum("requests", 20, 30, 7, nil)
+	c.ConvertMetrics(md)
+	sum = firstSum(t, md)
+	assert.Equal(t, pcommon.Timestamp(10), sum.DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(30), sum.DataPoints().At(0).Timestamp())
+	assert.Equal(t, int64(12), sum.DataPoints().At(0).IntValue())
+
+	// A different set of attributes is a different stream.
+	md = newDeltaSum("requests", 20, 30, 3, map[string]any{"code": "200"})
+	c.ConvertMetrics(md)
+	assert.Equal(t, int64(3), firstSum(t, md).DataPoints().At(0).IntValue())
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestDeltaToCumulative_DropOutOfOrder(t *testing.T) {
+	c := NewDeltaToCumulative(NewDefaultConfig())
+	c.ConvertMetrics(newDeltaSum("requests", 10, 20, 5, nil))
+
+	md := newDeltaSum("requests", 5, 15, 1, nil)
+	c.ConvertMetrics(md)
+	// The only data point was dropped, so the metric, its scope and its resource are removed.
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = newDeltaSum("requests", 20, 25, 1, nil)
+	c.ConvertMetrics(md)
+	assert.Equal(t, int64(6), firstSum(t, md).DataPoints().At(0).IntValue())
+}
+
+func TestDeltaToCumulative_CumulativeUntouched(t *testing.T) {
+	c := NewDeltaToCumulative(NewDefaultConfig())
+	md := newDeltaSum("requests", 10, 20, 5, nil)
+	firstSum(t, md).SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	expected := pmetric.NewMetrics()
+	md.CopyTo(expected)
+
+	c.ConvertMetrics(md)
+	assert.Equal(t, expected, md)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestDeltaToCumulative_Histogram(t *testing.T) {
+	c := NewDeltaToCumulative(NewDefaultConfig())
+	bounds := []float64{1, 10}
+
+	c.ConvertMetrics(newDeltaHistogram(10, 20, bounds, []uint64{1, 2, 0}, 12, 0.5, 8))
+	md := newDeltaHistogram(20, 30, bounds, []uint64{0, 1, 1}, 20, 2, 15)
+	c.ConvertMetrics(md)
+
+	hist := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, hist.AggregationTemporality())
+	dp := hist.DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts().AsRaw())
+	assert.InDelta(t, 32, dp.Sum(), 0.0001)
+	assert.InDelta(t, 0.5, dp.Min(), 0.0001)
+	assert.InDelta(t, 15, dp.Max(), 0.0001)
+
+	// Changing the bucket layout restarts the stream.
+	md = newDeltaHistogram(30, 40, []float64{5}, []uint64{1, 1}, 7, 1, 6)
+	c.ConvertMetrics(md)
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(30), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, []uint64{1, 1}, dp.BucketCounts().AsRaw())
+}
+
+func TestDeltaToCumulative_MaxStreams(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.MaxStreams = 1
+	c := NewDeltaToCumulative(cfg)
+
+	c.ConvertMetrics(newDeltaSum("a", 10, 20, 1, nil))
+	md := newDeltaSum("b", 10, 20, 1, nil)
+	c.ConvertMetrics(md)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestDeltaToCumulative_EvictStale(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.MaxStale = time.Minute
+	c := NewDeltaToCumulative(cfg)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.ConvertMetrics(newDeltaSum("requests", 10, 20, 5, nil))
+	assert.Equal(t, 1, c.Len())
+
+	now = now.Add(2 * time.Minute)
+	md := newDeltaSum("requests", 20, 30, 7, nil)
+	c.ConvertMetrics(md)
+	// The previous state was evicted, so a new cumulative series starts.
+	dp := firstSum(t, md).DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, int64(7), dp.IntValue())
+}
+
+func TestDeltaToCumulative_NormalizeCumulative(t *testing.T) {
+	c := NewDeltaToCumulative(NewDefaultConfig(), WithNormalizeCumulative())
+	points := []point{{-1, 10, 3}, {-1, 20, 5}, {-1, 30, 1}, {-1, 25, 1}, {-1, 40, 2}, {35, 50, 1}}
+	got := convertPoints(t, c.ConvertMetrics, pmetric.AggregationTemporalityCumulative, true, points)
+	assert.Equal(t, []point{{10, 10, 3}, {10, 20, 5}, {20, 30, 1}, {20, 40, 2}, {35, 50, 1}}, got)
+
+	// The delta and the cumulative streams of a metric are different streams.
+	md := newSum(pmetric.AggregationTemporalityDelta, true, point{50, 60, 4})
+	c.ConvertMetrics(md)
+	assert.Equal(t, int64(4), firstSum(t, md).DataPoints().At(0).IntValue())
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.MaxStale = -1
+	require.EqualError(t, cfg.Validate(), "max_stale must be greater than or equal to zero")
+
+	cfg = NewDefaultConfig()
+	cfg.MaxStreams = -1
+	require.EqualError(t, cfg.Validate(), "max_streams must be greater than or equal to zero")
+}
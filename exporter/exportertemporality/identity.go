@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality // import "go.opentelemetry.io/collector/exporter/exportertemporality"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// streamID uniquely identifies a metric stream: the resource, the instrumentation scope,
+// the metric identity (name, unit, type, temporality and monotonicity) and the data point attributes.
+type streamID string
+
+// metricIdentity returns the prefix shared by all the streams of the given metric.
+func metricIdentity(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) string {
+	var sb strings.Builder
+	writeMap(&sb, res.Attributes())
+	sb.WriteByte(0)
+	sb.WriteString(scope.Name())
+	sb.WriteByte(0)
+	sb.WriteString(scope.Version())
+	sb.WriteByte(0)
+	writeMap(&sb, scope.Attributes())
+	sb.WriteByte(0)
+	sb.WriteString(m.Name())
+	sb.WriteByte(0)
+	sb.WriteString(m.Unit())
+	sb.WriteByte(0)
+	sb.WriteString(m.Type().String())
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sb.WriteByte(0)
+		sb.WriteString(m.Sum().AggregationTemporality().String())
+		sb.WriteByte(0)
+		sb.WriteString(strconv.FormatBool(m.Sum().IsMonotonic()))
+	case pmetric.MetricTypeHistogram:
+		sb.WriteByte(0)
+		sb.WriteString(m.Histogram().AggregationTemporality().String())
+	}
+	return sb.String()
+}
+
+// newStreamID returns the identity of the stream the data point with the given attributes belongs to.
+func newStreamID(metricID string, attrs pcommon.Map) streamID {
+	var sb strings.Builder
+	sb.WriteString(metricID)
+	sb.WriteByte(0)
+	writeMap(&sb, attrs)
+	return streamID(sb.String())
+}
+
+// writeMap writes the hash of the map, independent of the insertion order.
+func writeMap(sb *strings.Builder, m pcommon.Map) {
+	if m.Len() == 0 {
+		return
+	}
+	h := m.Hash()
+	sb.Write(h[:])
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertemporality // import "go.opentelemetry.io/collector/exporter/exportertemporality"
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// stream holds the state of a single metric stream.
+type stream struct {
+	// start is the start timestamp of the emitted cumulative points.
+	start pcommon.Timestamp
+	// inStart is the start timestamp of the last received cumulative point.
+	inStart pcommon.Timestamp
+	// last is the timestamp of the last received point.
+	last     pcommon.Timestamp
+	lastSeen time.Time
+
+	// Cumulative state for sums.
+	isInt       bool
+	intValue    int64
+	doubleValue float64
+
+	// Cumulative state for histograms.
+	count   uint64
+	sum     float64
+	hasSum  bool
+	min     float64
+	hasMin  bool
+	max     float64
+	hasMax  bool
+	bounds  []float64
+	buckets []uint64
+}
+
+// dataPoint is the part of the data points used to track the timeline of a stream.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+}
+
+// streams holds the state of the streams seen by a converter.
+type streams struct {
+	cfg Config
+
+	mu           sync.Mutex
+	m            map[streamID]*stream
+	lastEviction time.Time
+	// now is used to get the current time, overridden in tests.
+	now func() time.Time
+}
+
+// convert calls convertMetric for every metric of md, and removes the metrics, scopes and resources left
+// without data points. The convertMetric func returns whether the metric must be removed.
+func (s *streams) convert(md pmetric.Metrics, convertMetric func(pcommon.Resource, pcommon.InstrumentationScope, pmetric.Metric, time.Time) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evictStale(now)
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if rm.ScopeMetrics().Len() == 0 {
+			return false
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			if sm.Metrics().Len() == 0 {
+				return false
+			}
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return convertMetric(rm.Resource(), sm.Scope(), m, now)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// Len returns the number of streams currently tracked.
+func (s *streams) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.m)
+}
+
+// add starts tracking the given stream, replacing its previous state, unless Config.MaxStreams is reached.
+func (s *streams) add(id streamID) (*stream, bool) {
+	if _, ok := s.m[id]; !ok && s.cfg.MaxStreams > 0 && len(s.m) >= s.cfg.MaxStreams {
+		return nil, false
+	}
+	st := &stream{}
+	s.m[id] = st
+	return st, true
+}
+
+// lookupCumulative returns the state of the stream of a cumulative data point ending at ts, whether the stream
+// was known and compatible with the data point, and whether the data point must be kept.
+func (s *streams) lookupCumulative(id streamID, ts pcommon.Timestamp, compatible func(*stream) bool, now time.Time) (st *stream, known bool, keep bool) {
+	st, known = s.m[id]
+	if known && !compatible(st) {
+		known = false
+	}
+	if known && ts <= st.last {
+		// Out of order or duplicate data point.
+		return nil, true, false
+	}
+	if !known {
+		var ok bool
+		if st, ok = s.add(id); !ok {
+			return nil, false, false
+		}
+	}
+	st.lastSeen = now
+	return st, known, true
+}
+
+// lookupNumber returns the state of the stream of a cumulative sum data point, see lookupCumulative.
+func (s *streams) lookupNumber(id streamID, dp pmetric.NumberDataPoint, now time.Time) (*stream, bool, bool) {
+	isInt := dp.ValueType() == pmetric.NumberDataPointValueTypeInt
+	return s.lookupCumulative(id, dp.Timestamp(), func(st *stream) bool { return st.isInt == isInt }, now)
+}
+
+// lookupHistogram returns the state of the stream of a cumulative histogram data point, see lookupCumulative.
+// A change of the buckets starts a new stream.
+func (s *streams) lookupHistogram(id streamID, dp pmetric.HistogramDataPoint, now time.Time) (*stream, bool, bool) {
+	bounds := dp.ExplicitBounds().AsRaw()
+	st, known, keep := s.lookupCumulative(id, dp.Timestamp(), func(st *stream) bool {
+		return slices.Equal(st.bounds, bounds) && len(st.buckets) == dp.BucketCounts().Len()
+	}, now)
+	if keep && !known {
+		st.bounds = bounds
+	}
+	return st, known, keep
+}
+
+// saveNumber saves the value of the cumulative sum data point. If diff is set, the value of the data point is
+// set to its difference with the previous value of the stream.
+func (st *stream) saveNumber(dp pmetric.NumberDataPoint, diff bool) {
+	st.isInt = dp.ValueType() == pmetric.NumberDataPointValueTypeInt
+	if st.isInt {
+		v := dp.IntValue()
+		if diff {
+			dp.SetIntValue(v - st.intValue)
+		}
+		st.intValue = v
+		return
+	}
+	v := dp.DoubleValue()
+	if diff {
+		dp.SetDoubleValue(v - st.doubleValue)
+	}
+	st.doubleValue = v
+}
+
+// numberDecreased returns whether the value of the data point is lower than the previous value of the stream.
+func (st *stream) numberDecreased(dp pmetric.NumberDataPoint) bool {
+	if st.isInt {
+		return dp.IntValue() < st.intValue
+	}
+	return dp.DoubleValue() < st.doubleValue
+}
+
+// saveHistogram saves the buckets of the cumulative histogram data point. If diff is set, the buckets of the data
+// point are set to their difference with the previous ones of the stream, and its minimum and maximum are removed
+// since the extrema of the interval cannot be derived from the cumulative extrema.
+func (st *stream) saveHistogram(dp pmetric.HistogramDataPoint, diff bool) {
+	count, sum, hasSum := dp.Count(), dp.Sum(), dp.HasSum()
+	if diff {
+		dp.SetCount(count - st.count)
+		if hasSum && st.hasSum {
+			dp.SetSum(sum - st.sum)
+		} else {
+			dp.RemoveSum()
+		}
+		dp.RemoveMin()
+		dp.RemoveMax()
+	}
+	st.count, st.sum, st.hasSum = count, sum, hasSum
+
+	counts := dp.BucketCounts()
+	if len(st.buckets) != counts.Len() {
+		st.buckets = make([]uint64, counts.Len())
+	}
+	for i := 0; i < counts.Len(); i++ {
+		v := counts.At(i)
+		if diff {
+			counts.SetAt(i, v-st.buckets[i])
+		}
+		st.buckets[i] = v
+	}
+}
+
+// histogramDecreased returns whether the count of the data point or of one of its buckets is lower than the
+// previous one of the stream, meaning that the histogram was reset.
+func (st *stream) histogramDecreased(dp pmetric.HistogramDataPoint) bool {
+	if dp.Count() < st.count {
+		return true
+	}
+	for i := range st.buckets {
+		if dp.BucketCounts().At(i) < st.buckets[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// evictStale removes the streams that did not receive any data point for longer than Config.MaxStale.
+// To amortize the cost, streams are scanned at most once per Config.MaxStale.
+func (s *streams) evictStale(now time.Time) {
+	if s.cfg.MaxStale <= 0 || now.Sub(s.lastEviction) < s.cfg.MaxStale {
+		return
+	}
+	s.lastEviction = now
+	for id, st := range s.m {
+		if now.Sub(st.lastSeen) >= s.cfg.MaxStale {
+			delete(s.m, id)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertest // import "go.opentelemetry.io/collector/exporter/exportertest"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ErrFault is the error wrapped by the errors returned by a FaultSink.
+var ErrFault = errors.New("injected fault")
+
+// Fault is the outcome of a call to a FaultSink.
+// The zero value is a call succeeding immediately.
+type Fault struct {
+	// Delay is added to the call before it returns, unless its context is done first.
+	Delay time.Duration
+
+	// Fail makes the call fail, no data being accepted.
+	Fail bool
+
+	// Rejected makes the call fail for the given number of items (spans, data points or log records),
+	// the last ones of the data, the other items being accepted. The error returned carries the number of
+	// rejected items, see consumererror.NewPartial.
+	Rejected int
+
+	// Permanent makes the error of a failed call permanent, see consumererror.NewPermanent.
+	// Otherwise, the error carries the data that failed to be retried, see consumererror.NewTraces.
+	Permanent bool
+
+	// RetryAfter is the delay requested before retrying a failed call, see consumererror.NewRetryAfter.
+	RetryAfter time.Duration
+}
+
+// err returns the error of the call, wrapping the data rejected if any.
+func (f Fault) err(rejected int, wrap func(error) error) error {
+	err := ErrFault
+	if f.RetryAfter > 0 {
+		err = consumererror.NewRetryAfter(err, f.RetryAfter)
+	}
+	if !f.Fail {
+		err = consumererror.NewPartial(err, rejected)
+	}
+	if f.Permanent {
+		return consumererror.NewPermanent(err)
+	}
+	// Only the data of retryable errors is retried.
+	return wrap(err)
+}
+
+// FaultSink is a consumer injecting faults in the calls made to it on a deterministic schedule,
+// to test how components handle failures, e.g. how an exporter retries or queues data.
+// The data accepted is stored like the sinks of the consumertest package.
+type FaultSink struct {
+	mu       sync.Mutex
+	schedule []Fault
+	calls    int
+	failures int
+
+	traces  consumertest.TracesSink
+	metrics consumertest.MetricsSink
+	logs    consumertest.LogsSink
+}
+
+var _ consumer.Traces = (*FaultSink)(nil)
+var _ consumer.Metrics = (*FaultSink)(nil)
+var _ consumer.Logs = (*FaultSink)(nil)
+
+// NewFaultSink returns a FaultSink applying the faults of the schedule to the calls made to it in order,
+// whatever their signal. The calls made once the schedule is exhausted succeed.
+func NewFaultSink(schedule ...Fault) *FaultSink {
+	return &FaultSink{schedule: schedule}
+}
+
+// Capabilities implements the consumer interfaces.
+func (fs *FaultSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+// next returns the fault of the next call.
+func (fs *FaultSink) next() Fault {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var f Fault
+	if fs.calls < len(fs.schedule) {
+		f = fs.schedule[fs.calls]
+	}
+	fs.calls++
+	if f.Fail || f.Rejected > 0 {
+		fs.failures++
+	}
+	return f
+}
+
+// wait waits for the delay of the fault, or until the context is done.
+func wait(ctx context.Context, f Fault) error {
+	if f.Delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(f.Delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ConsumeTraces applies the next fault of the schedule and stores the spans accepted.
+func (fs *FaultSink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	f := fs.next()
+	if err := wait(ctx, f); err != nil {
+		return err
+	}
+	if f.Fail {
+		return f.err(td.SpanCount(), func(err error) error { return consumererror.NewTraces(err, td) })
+	}
+	if f.Rejected <= 0 {
+		return fs.traces.ConsumeTraces(ctx, td)
+	}
+	accepted, rejected := splitTraces(td, td.SpanCount()-f.Rejected)
+	_ = fs.traces.ConsumeTraces(ctx, accepted)
+	return f.err(rejected.SpanCount(), func(err error) error { return consumererror.NewTraces(err, rejected) })
+}
+
+// ConsumeMetrics applies the next fault of the schedule and stores the data points accepted.
+func (fs *FaultSink) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	f := fs.next()
+	if err := wait(ctx, f); err != nil {
+		return err
+	}
+	if f.Fail {
+		return f.err(md.DataPointCount(), func(err error) error { return consumererror.NewMetrics(err, md) })
+	}
+	if f.Rejected <= 0 {
+		return fs.metrics.ConsumeMetrics(ctx, md)
+	}
+	accepted, rejected := splitMetrics(md, md.DataPointCount()-f.Rejected)
+	_ = fs.metrics.ConsumeMetrics(ctx, accepted)
+	return f.err(rejected.DataPointCount(), func(err error) error { return consumererror.NewMetrics(err, rejected) })
+}
+
+// ConsumeLogs applies the next fault of the schedule and stores the log records accepted.
+func (fs *FaultSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	f := fs.next()
+	if err := wait(ctx, f); err != nil {
+		return err
+	}
+	if f.Fail {
+		return f.err(ld.LogRecordCount(), func(err error) error { return consumererror.NewLogs(err, ld) })
+	}
+	if f.Rejected <= 0 {
+		return fs.logs.ConsumeLogs(ctx, ld)
+	}
+	accepted, rejected := splitLogs(ld, ld.LogRecordCount()-f.Rejected)
+	_ = fs.logs.ConsumeLogs(ctx, accepted)
+	return f.err(rejected.LogRecordCount(), func(err error) error { return consumererror.NewLogs(err, rejected) })
+}
+
+// Calls returns the number of calls made to the sink.
+func (fs *FaultSink) Calls() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.calls
+}
+
+// Failures returns the number of calls that failed, fully or partially.
+func (fs *FaultSink) Failures() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.failures
+}
+
+// AllTraces returns the traces accepted by the sink.
+func (fs *FaultSink) AllTraces() []ptrace.Traces {
+	return fs.traces.AllTraces()
+}
+
+// SpanCount returns the number of spans accepted by the sink.
+func (fs *FaultSink) SpanCount() int {
+	return fs.traces.SpanCount()
+}
+
+// AllMetrics returns the metrics accepted by the sink.
+func (fs *FaultSink) AllMetrics() []pmetric.Metrics {
+	return fs.metrics.AllMetrics()
+}
+
+// DataPointCount returns the number of data points accepted by the sink.
+func (fs *FaultSink) DataPointCount() int {
+	return fs.metrics.DataPointCount()
+}
+
+// AllLogs returns the logs accepted by the sink.
+func (fs *FaultSink) AllLogs() []plog.Logs {
+	return fs.logs.AllLogs()
+}
+
+// LogRecordCount returns the number of log records accepted by the sink.
+func (fs *FaultSink) LogRecordCount() int {
+	return fs.logs.LogRecordCount()
+}
+
+// Reset deletes the data accepted and restarts the schedule.
+func (fs *FaultSink) Reset() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.calls = 0
+	fs.failures = 0
+	fs.traces.Reset()
+	fs.metrics.Reset()
+	fs.logs.Reset()
+}
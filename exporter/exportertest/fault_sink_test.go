@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestFaultSinkSchedule(t *testing.T) {
+	sink := NewFaultSink(
+		Fault{Fail: true},
+		Fault{Fail: true, Permanent: true},
+		Fault{},
+	)
+	ctx := context.Background()
+
+	err := sink.ConsumeTraces(ctx, testdata.GenerateTraces(2))
+	require.ErrorIs(t, err, ErrFault)
+	assert.False(t, consumererror.IsPermanent(err))
+	var tracesErr consumererror.Traces
+	require.ErrorAs(t, err, &tracesErr)
+	assert.Equal(t, 2, tracesErr.Data().SpanCount())
+
+	err = sink.ConsumeMetrics(ctx, testdata.GenerateMetrics(2))
+	require.ErrorIs(t, err, ErrFault)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	require.NoError(t, sink.ConsumeLogs(ctx, testdata.GenerateLogs(2)))
+	// The schedule is exhausted.
+	require.NoError(t, sink.ConsumeLogs(ctx, testdata.GenerateLogs(1)))
+
+	assert.Equal(t, 4, sink.Calls())
+	assert.Equal(t, 2, sink.Failures())
+	assert.Equal(t, 0, sink.SpanCount())
+	assert.Equal(t, 0, sink.DataPointCount())
+	assert.Equal(t, 3, sink.LogRecordCount())
+
+	sink.Reset()
+	assert.Equal(t, 0, sink.Calls())
+	assert.Equal(t, 0, sink.LogRecordCount())
+	assert.Error(t, sink.ConsumeLogs(ctx, testdata.GenerateLogs(1)))
+}
+
+func TestFaultSinkPartial(t *testing.T) {
+	sink := NewFaultSink(
+		Fault{Rejected: 1, RetryAfter: time.Second},
+		Fault{Rejected: 3},
+		Fault{Rejected: 2, Permanent: true},
+	)
+	ctx := context.Background()
+
+	err := sink.ConsumeTraces(ctx, testdata.GenerateTraces(3))
+	rejected, ok := consumererror.Rejected(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, rejected)
+	delay, ok := consumererror.RetryAfter(err)
+	require.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+	var tracesErr consumererror.Traces
+	require.ErrorAs(t, err, &tracesErr)
+	assert.Equal(t, 1, tracesErr.Data().SpanCount())
+	assert.Equal(t, 2, sink.SpanCount())
+
+	err = sink.ConsumeMetrics(ctx, testdata.GenerateMetrics(2))
+	rejected, ok = consumererror.Rejected(err)
+	require.True(t, ok)
+	assert.Equal(t, 3, rejected)
+	var metricsErr consumererror.Metrics
+	require.ErrorAs(t, err, &metricsErr)
+	assert.Equal(t, 3, metricsErr.Data().DataPointCount())
+	assert.Equal(t, 1, sink.DataPointCount())
+
+	err = sink.ConsumeLogs(ctx, testdata.GenerateLogs(5))
+	assert.True(t, consumererror.IsPermanent(err))
+	rejected, ok = consumererror.Rejected(err)
+	require.True(t, ok)
+	assert.Equal(t, 2, rejected)
+	assert.False(t, errors.As(err, &consumererror.Logs{}))
+	assert.Equal(t, 3, sink.LogRecordCount())
+}
+
+func TestFaultSinkDelay(t *testing.T) {
+	sink := NewFaultSink(Fault{Delay: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sink.ConsumeTraces(ctx, testdata.GenerateTraces(1)), context.DeadlineExceeded)
+	assert.Equal(t, 0, sink.SpanCount())
+}
+
+func TestFaultSinkRetry(t *testing.T) {
+	sink := NewFaultSink(
+		Fault{Fail: true},
+		Fault{Rejected: 1},
+	)
+	retryConfig := configretry.NewDefaultBackOffConfig()
+	retryConfig.InitialInterval = time.Millisecond
+	exp, err := exporterhelper.NewTracesExporter(context.Background(), NewNopSettings(), &struct{}{}, sink.ConsumeTraces,
+		exporterhelper.WithRetry(retryConfig))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	// Only the span rejected by the partial failure is retried.
+	assert.Equal(t, 3, sink.Calls())
+	assert.Equal(t, 3, sink.SpanCount())
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 1, sink.AllTraces()[1].SpanCount())
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportertest // import "go.opentelemetry.io/collector/exporter/exportertest"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// keepFirst returns a function telling if an item must be removed to keep the first n items
+// when called in order for each item of the data.
+func keepFirst(n int) func() bool {
+	i := 0
+	return func() bool {
+		i++
+		return i > n
+	}
+}
+
+// dropFirst returns a function telling if an item must be removed to drop the first n items
+// when called in order for each item of the data.
+func dropFirst(n int) func() bool {
+	keep := keepFirst(n)
+	return func() bool {
+		return !keep()
+	}
+}
+
+// splitTraces returns copies of td with its first n spans and with the other spans.
+func splitTraces(td ptrace.Traces, n int) (ptrace.Traces, ptrace.Traces) {
+	first, last := ptrace.NewTraces(), ptrace.NewTraces()
+	td.CopyTo(first)
+	td.CopyTo(last)
+	removeSpans(first, keepFirst(n))
+	removeSpans(last, dropFirst(n))
+	return first, last
+}
+
+func removeSpans(td ptrace.Traces, remove func() bool) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(ptrace.Span) bool { return remove() })
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+// splitMetrics returns copies of md with its first n data points and with the other data points.
+func splitMetrics(md pmetric.Metrics, n int) (pmetric.Metrics, pmetric.Metrics) {
+	first, last := pmetric.NewMetrics(), pmetric.NewMetrics()
+	md.CopyTo(first)
+	md.CopyTo(last)
+	removeDataPoints(first, keepFirst(n))
+	removeDataPoints(last, dropFirst(n))
+	return first, last
+}
+
+func removeDataPoints(md pmetric.Metrics, remove func() bool) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					m.Gauge().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return remove() })
+					return m.Gauge().DataPoints().Len() == 0
+				case pmetric.MetricTypeSum:
+					m.Sum().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return remove() })
+					return m.Sum().DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					m.Histogram().DataPoints().RemoveIf(func(pmetric.HistogramDataPoint) bool { return remove() })
+					return m.Histogram().DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					m.ExponentialHistogram().DataPoints().RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return remove() })
+					return m.ExponentialHistogram().DataPoints().Len() == 0
+				case pmetric.MetricTypeSummary:
+					m.Summary().DataPoints().RemoveIf(func(pmetric.SummaryDataPoint) bool { return remove() })
+					return m.Summary().DataPoints().Len() == 0
+				}
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// splitLogs returns copies of ld with its first n log records and with the other log records.
+func splitLogs(ld plog.Logs, n int) (plog.Logs, plog.Logs) {
+	first, last := plog.NewLogs(), plog.NewLogs()
+	ld.CopyTo(first)
+	ld.CopyTo(last)
+	removeLogRecords(first, keepFirst(n))
+	removeLogRecords(last, dropFirst(n))
+	return first, last
+}
+
+func removeLogRecords(ld plog.Logs, remove func() bool) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(plog.LogRecord) bool { return remove() })
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
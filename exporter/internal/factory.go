@@ -47,6 +47,14 @@ type Factory interface {
 	// ProfilesExporterStability gets the stability level of the ProfilesExporter.
 	ProfilesExporterStability() component.StabilityLevel
 
+	// CreateEntitiesExporter creates an EntitiesExporter based on this config.
+	// If the exporter type does not support entities,
+	// this function returns the error [component.ErrDataTypeIsNotSupported].
+	CreateEntitiesExporter(ctx context.Context, set Settings, cfg component.Config) (Entities, error)
+
+	// EntitiesExporterStability gets the stability level of the EntitiesExporter.
+	EntitiesExporterStability() component.StabilityLevel
+
 	unexportedFactoryFunc()
 }
 
@@ -101,6 +109,9 @@ func (f CreateLogsFunc) CreateLogsExporter(ctx context.Context, set Settings, cf
 // CreateProfilesFunc is the equivalent of Factory.CreateProfiles.
 type CreateProfilesFunc func(context.Context, Settings, component.Config) (Profiles, error)
 
+// CreateEntitiesFunc is the equivalent of Factory.CreateEntities.
+type CreateEntitiesFunc func(context.Context, Settings, component.Config) (Entities, error)
+
 // CreateProfilesExporter implements ExporterFactory.CreateProfilesExporter().
 func (f CreateProfilesFunc) CreateProfilesExporter(ctx context.Context, set Settings, cfg component.Config) (Profiles, error) {
 	if f == nil {
@@ -109,6 +120,14 @@ func (f CreateProfilesFunc) CreateProfilesExporter(ctx context.Context, set Sett
 	return f(ctx, set, cfg)
 }
 
+// CreateEntitiesExporter implements ExporterFactory.CreateEntitiesExporter().
+func (f CreateEntitiesFunc) CreateEntitiesExporter(ctx context.Context, set Settings, cfg component.Config) (Entities, error) {
+	if f == nil {
+		return nil, component.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg)
+}
+
 type factory struct {
 	cfgType component.Type
 	component.CreateDefaultConfigFunc
@@ -120,6 +139,8 @@ type factory struct {
 	logsStabilityLevel component.StabilityLevel
 	CreateProfilesFunc
 	profilesStabilityLevel component.StabilityLevel
+	CreateEntitiesFunc
+	entitiesStabilityLevel component.StabilityLevel
 }
 
 func (f *factory) Type() component.Type {
@@ -144,6 +165,10 @@ func (f *factory) ProfilesExporterStability() component.StabilityLevel {
 	return f.profilesStabilityLevel
 }
 
+func (f *factory) EntitiesExporterStability() component.StabilityLevel {
+	return f.entitiesStabilityLevel
+}
+
 // WithTraces overrides the default "error not supported" implementation for CreateTracesExporter and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -176,6 +201,14 @@ func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel
 	})
 }
 
+// WithEntities overrides the default "error not supported" implementation for CreateEntitiesExporter and the default "undefined" stability level.
+func WithEntities(createEntities CreateEntitiesFunc, sl component.StabilityLevel) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.entitiesStabilityLevel = sl
+		o.CreateEntitiesFunc = createEntities
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
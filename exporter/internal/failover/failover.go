@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package failover implements the failover of an exporter from its primary endpoint to ordered
+// failover endpoints, with the health probing of the unhealthy endpoints and the automatic fail-back.
+package failover // import "go.opentelemetry.io/collector/exporter/internal/failover"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Settings configures a Group.
+type Settings struct {
+	// Endpoints are the names of the endpoints, the primary one first followed by the failover ones
+	// in order of preference.
+	Endpoints []string
+	// FailureThreshold is the number of consecutive failed exports after which an endpoint is unhealthy.
+	FailureThreshold int
+	// ProbeInterval is the interval between the probes of the unhealthy endpoints.
+	ProbeInterval time.Duration
+	Logger        *zap.Logger
+}
+
+// Group sends the data to the first healthy endpoint, in order of preference. An endpoint is unhealthy
+// once FailureThreshold exports to it failed in a row, and healthy again once a probe succeeds, so the
+// data fails back to the primary endpoint as soon as it recovers.
+type Group struct {
+	set   Settings
+	probe func(ctx context.Context, endpoint int) error
+
+	mu        sync.Mutex
+	failures  []int
+	unhealthy []bool
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewGroup returns a Group probing the unhealthy endpoints with probe, called with the index of the endpoint.
+func NewGroup(set Settings, probe func(ctx context.Context, endpoint int) error) *Group {
+	return &Group{
+		set:       set,
+		probe:     probe,
+		failures:  make([]int, len(set.Endpoints)),
+		unhealthy: make([]bool, len(set.Endpoints)),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start starts probing the unhealthy endpoints.
+func (g *Group) Start() {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		ticker := time.NewTicker(g.set.ProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				g.probeUnhealthy()
+			case <-g.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown stops probing the endpoints.
+func (g *Group) Shutdown() {
+	select {
+	case <-g.stopCh:
+	default:
+		close(g.stopCh)
+	}
+	g.wg.Wait()
+}
+
+// Export sends the data with send, called with the index of the endpoint, to the first healthy endpoint,
+// failing over to the next ones while the error is retryable. All the endpoints are tried in order when
+// none is healthy. It returns the error of the last endpoint tried.
+func (g *Group) Export(ctx context.Context, send func(ctx context.Context, endpoint int) error) error {
+	var err error
+	for _, i := range g.order() {
+		err = send(ctx, i)
+		g.report(i, err)
+		if err == nil || consumererror.IsPermanent(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Healthy returns whether the endpoint is healthy.
+func (g *Group) Healthy(endpoint int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.unhealthy[endpoint]
+}
+
+// order returns the healthy endpoints in order of preference, or all of them if none is healthy.
+func (g *Group) order() []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	order := make([]int, 0, len(g.unhealthy))
+	for i, unhealthy := range g.unhealthy {
+		if !unhealthy {
+			order = append(order, i)
+		}
+	}
+	if len(order) == 0 {
+		for i := range g.unhealthy {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// report records the result of an export to the endpoint. The permanent errors are caused by the data,
+// they show that the endpoint is reachable.
+func (g *Group) report(endpoint int, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err == nil || consumererror.IsPermanent(err) {
+		g.failures[endpoint] = 0
+		return
+	}
+	g.failures[endpoint]++
+	if !g.unhealthy[endpoint] && g.failures[endpoint] >= g.set.FailureThreshold {
+		g.unhealthy[endpoint] = true
+		g.set.Logger.Warn("Endpoint is unhealthy, failing over to the next endpoint",
+			zap.String("endpoint", g.set.Endpoints[endpoint]), zap.Error(err))
+	}
+}
+
+// probeUnhealthy probes the unhealthy endpoints, marking them healthy again when the probe succeeds.
+func (g *Group) probeUnhealthy() {
+	for i := range g.set.Endpoints {
+		if g.Healthy(i) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), g.set.ProbeInterval)
+		err := g.probe(ctx, i)
+		cancel()
+		if err != nil && !consumererror.IsPermanent(err) {
+			g.set.Logger.Debug("Probe of the unhealthy endpoint failed",
+				zap.String("endpoint", g.set.Endpoints[i]), zap.Error(err))
+			continue
+		}
+		g.mu.Lock()
+		g.unhealthy[i] = false
+		g.failures[i] = 0
+		g.mu.Unlock()
+		g.set.Logger.Info("Endpoint is healthy again", zap.String("endpoint", g.set.Endpoints[i]))
+	}
+}
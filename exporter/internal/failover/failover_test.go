@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func newTestGroup(probe func(context.Context, int) error) *Group {
+	return NewGroup(Settings{
+		Endpoints:        []string{"primary", "secondary", "tertiary"},
+		FailureThreshold: 2,
+		ProbeInterval:    10 * time.Millisecond,
+		Logger:           zap.NewNop(),
+	}, probe)
+}
+
+func TestGroupFailover(t *testing.T) {
+	g := newTestGroup(nil)
+	errDown := errors.New("down")
+	var sent []int
+	send := func(down ...int) func(context.Context, int) error {
+		return func(_ context.Context, i int) error {
+			sent = append(sent, i)
+			for _, d := range down {
+				if d == i {
+					return errDown
+				}
+			}
+			return nil
+		}
+	}
+
+	// The data fails over to the next endpoint on a retryable error.
+	require.NoError(t, g.Export(context.Background(), send(0)))
+	assert.Equal(t, []int{0, 1}, sent)
+	assert.True(t, g.Healthy(0))
+
+	// The primary endpoint is skipped once unhealthy.
+	sent = nil
+	require.NoError(t, g.Export(context.Background(), send(0)))
+	assert.False(t, g.Healthy(0))
+	sent = nil
+	require.NoError(t, g.Export(context.Background(), send()))
+	assert.Equal(t, []int{1}, sent)
+
+	// The permanent errors are returned without failover.
+	sent = nil
+	errData := consumererror.NewPermanent(errors.New("bad data"))
+	assert.Equal(t, errData, g.Export(context.Background(), func(_ context.Context, i int) error {
+		sent = append(sent, i)
+		return errData
+	}))
+	assert.Equal(t, []int{1}, sent)
+
+	// All the endpoints are tried when none is healthy, the last error is returned.
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, errDown, g.Export(context.Background(), send(0, 1, 2)))
+	}
+	sent = nil
+	assert.Equal(t, errDown, g.Export(context.Background(), send(0, 1, 2)))
+	assert.Equal(t, []int{0, 1, 2}, sent)
+}
+
+func TestGroupFailBack(t *testing.T) {
+	var primaryUp atomic.Bool
+	g := newTestGroup(func(_ context.Context, i int) error {
+		if i == 0 && !primaryUp.Load() {
+			return errors.New("down")
+		}
+		return nil
+	})
+	g.Start()
+	t.Cleanup(g.Shutdown)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, g.Export(context.Background(), func(_ context.Context, i int) error {
+			if i == 0 {
+				return errors.New("down")
+			}
+			return nil
+		}))
+	}
+	require.False(t, g.Healthy(0))
+	time.Sleep(30 * time.Millisecond)
+	assert.False(t, g.Healthy(0))
+
+	primaryUp.Store(true)
+	assert.Eventually(t, func() bool { return g.Healthy(0) }, time.Second, 5*time.Millisecond)
+	var sent []int
+	require.NoError(t, g.Export(context.Background(), func(_ context.Context, i int) error {
+		sent = append(sent, i)
+		return nil
+	}))
+	assert.Equal(t, []int{0}, sent)
+}
@@ -6,15 +6,56 @@ package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 )
 
+// latencySaturationFactor is the ratio between the observed and the baseline consume latency above which
+// the destination is considered saturated, so the number of active consumers is decreased.
+const latencySaturationFactor = 2
+
+// NumConsumersAuto is the value of num_consumers enabling the autoscaling of the number of consumers.
+const NumConsumersAuto = "auto"
+
+// WithoutNumConsumersAuto returns a copy of conf without num_consumers if it is set to NumConsumersAuto,
+// and whether it was, so the queue configurations can be unmarshaled with an int number of consumers.
+func WithoutNumConsumersAuto(conf *confmap.Conf) (*confmap.Conf, bool) {
+	if v, ok := conf.Get("num_consumers").(string); !ok || v != NumConsumersAuto {
+		return conf, false
+	}
+	raw := conf.ToStringMap()
+	delete(raw, "num_consumers")
+	return confmap.NewFromStringMap(raw), true
+}
+
+// AutoscalingSettings defines the bounds of the number of active consumers when autoscaling is enabled.
+type AutoscalingSettings struct {
+	MinConsumers int
+	MaxConsumers int
+	// Interval is the period between two scaling decisions.
+	Interval time.Duration
+}
+
 type Consumers[T any] struct {
 	queue        Queue[T]
 	numConsumers int
 	consumeFunc  func(context.Context, T) error
 	stopWG       sync.WaitGroup
+
+	// The fields below are only used when autoscaling is enabled.
+	autoscaling *AutoscalingSettings
+	mu          sync.Mutex
+	activeCond  *sync.Cond
+	active      int
+	stopped     bool
+	stopCh      chan struct{}
+	busy        atomic.Int64
+	latencySum  atomic.Int64
+	latencyCnt  atomic.Int64
+	baseline    time.Duration
 }
 
 func NewQueueConsumers[T any](q Queue[T], numConsumers int, consumeFunc func(context.Context, T) error) *Consumers[T] {
@@ -26,6 +67,32 @@ func NewQueueConsumers[T any](q Queue[T], numConsumers int, consumeFunc func(con
 	}
 }
 
+// NewAutoscalingQueueConsumers returns consumers whose number of active consumers is periodically adjusted
+// between set.MinConsumers and set.MaxConsumers, based on the queue depth and the observed consume latency.
+// More consumers are activated while requests are waiting in the queue and all the active consumers are busy,
+// unless the consume latency degrades, which indicates that the destination is saturated.
+// Consumers are deactivated when the destination is saturated or when they are idle with an empty queue.
+func NewAutoscalingQueueConsumers[T any](q Queue[T], set AutoscalingSettings, consumeFunc func(context.Context, T) error) *Consumers[T] {
+	qc := &Consumers[T]{
+		queue:        q,
+		numConsumers: set.MaxConsumers,
+		autoscaling:  &set,
+		active:       set.MinConsumers,
+		stopCh:       make(chan struct{}),
+	}
+	qc.activeCond = sync.NewCond(&qc.mu)
+	qc.consumeFunc = func(ctx context.Context, req T) error {
+		qc.busy.Add(1)
+		defer qc.busy.Add(-1)
+		start := time.Now()
+		err := consumeFunc(ctx, req)
+		qc.latencySum.Add(int64(time.Since(start)))
+		qc.latencyCnt.Add(1)
+		return err
+	}
+	return qc
+}
+
 // Start ensures that queue and all consumers are started.
 func (qc *Consumers[T]) Start(ctx context.Context, host component.Host) error {
 	if err := qc.queue.Start(ctx, host); err != nil {
@@ -40,6 +107,7 @@ func (qc *Consumers[T]) Start(ctx context.Context, host component.Host) error {
 			startWG.Done()
 			defer qc.stopWG.Done()
 			for {
+				qc.waitActive(i)
 				if !qc.queue.Consume(qc.consumeFunc) {
 					return
 				}
@@ -48,14 +116,100 @@ func (qc *Consumers[T]) Start(ctx context.Context, host component.Host) error {
 	}
 	startWG.Wait()
 
+	if qc.autoscaling != nil {
+		qc.stopWG.Add(1)
+		go func() {
+			defer qc.stopWG.Done()
+			ticker := time.NewTicker(qc.autoscaling.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					qc.scale()
+				case <-qc.stopCh:
+					return
+				}
+			}
+		}()
+	}
+
 	return nil
 }
 
+// waitActive blocks the consumer with the given index while it is not active.
+// All the consumers are activated once the consumers are shut down, to drain the queue.
+func (qc *Consumers[T]) waitActive(i int) {
+	if qc.autoscaling == nil {
+		return
+	}
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	for i >= qc.active && !qc.stopped {
+		qc.activeCond.Wait()
+	}
+}
+
+// scale adjusts the number of active consumers based on the queue depth and the consume latency
+// observed since the previous call.
+func (qc *Consumers[T]) scale() {
+	latencyCnt := qc.latencyCnt.Swap(0)
+	latencySum := qc.latencySum.Swap(0)
+	queueSize := qc.queue.Size()
+	busy := int(qc.busy.Load())
+
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	saturated := false
+	if latencyCnt > 0 {
+		latency := time.Duration(latencySum / latencyCnt)
+		saturated = qc.baseline > 0 && latency > latencySaturationFactor*qc.baseline
+		// The baseline follows lower latencies immediately and higher latencies slowly,
+		// so that it adapts to a destination becoming permanently slower.
+		if qc.baseline == 0 || latency < qc.baseline {
+			qc.baseline = latency
+		} else {
+			qc.baseline += (latency - qc.baseline) / 8
+		}
+	}
+
+	active := qc.active
+	switch {
+	case saturated:
+		active--
+	case queueSize > 0 && busy >= qc.active:
+		active += max(qc.active/4, 1)
+	case queueSize == 0 && busy < qc.active:
+		active--
+	}
+	active = min(max(active, qc.autoscaling.MinConsumers), qc.autoscaling.MaxConsumers)
+	if active != qc.active {
+		qc.active = active
+		qc.activeCond.Broadcast()
+	}
+}
+
+// ActiveConsumers returns the number of consumers currently consuming from the queue.
+func (qc *Consumers[T]) ActiveConsumers() int {
+	if qc.autoscaling == nil {
+		return qc.numConsumers
+	}
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	return qc.active
+}
+
 // Shutdown ensures that queue and all consumers are stopped.
 func (qc *Consumers[T]) Shutdown(ctx context.Context) error {
 	if err := qc.queue.Shutdown(ctx); err != nil {
 		return err
 	}
+	if qc.autoscaling != nil {
+		qc.mu.Lock()
+		qc.stopped = true
+		qc.activeCond.Broadcast()
+		qc.mu.Unlock()
+		close(qc.stopCh)
+	}
 	qc.stopWG.Wait()
 	return nil
 }
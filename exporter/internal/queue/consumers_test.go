@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newTestAutoscalingConsumers(t *testing.T, minConsumers, maxConsumers int) (Queue[string], *Consumers[string]) {
+	q := NewBoundedMemoryQueue[string](MemoryQueueSettings[string]{Sizer: &RequestSizer[string]{}, Capacity: 100})
+	qc := NewAutoscalingQueueConsumers[string](q, AutoscalingSettings{
+		MinConsumers: minConsumers,
+		MaxConsumers: maxConsumers,
+		// Scaling decisions are triggered manually in the tests.
+		Interval: time.Hour,
+	}, func(context.Context, string) error { return nil })
+	require.NoError(t, qc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, qc.Shutdown(context.Background()))
+	})
+	return q, qc
+}
+
+// observe records the given number of busy consumers and consume latency, as if they were observed during an interval.
+func (qc *Consumers[T]) observe(busy int, latency time.Duration) {
+	qc.busy.Store(int64(busy))
+	qc.latencySum.Store(int64(latency))
+	qc.latencyCnt.Store(1)
+}
+
+func TestAutoscalingConsumers_ScaleUpWhileQueueGrows(t *testing.T) {
+	q := NewBoundedMemoryQueue[string](MemoryQueueSettings[string]{Sizer: &RequestSizer[string]{}, Capacity: 100})
+	qc := NewAutoscalingQueueConsumers[string](q, AutoscalingSettings{MinConsumers: 1, MaxConsumers: 10, Interval: time.Hour},
+		func(context.Context, string) error { return nil })
+	require.NoError(t, q.Offer(context.Background(), "a"))
+
+	expected := []int{2, 3, 4, 5, 6, 7, 8, 10, 10}
+	for _, e := range expected {
+		qc.observe(qc.ActiveConsumers(), 10*time.Millisecond)
+		qc.scale()
+		assert.Equal(t, e, qc.ActiveConsumers())
+	}
+}
+
+func TestAutoscalingConsumers_ScaleDownWhenSaturated(t *testing.T) {
+	q := NewBoundedMemoryQueue[string](MemoryQueueSettings[string]{Sizer: &RequestSizer[string]{}, Capacity: 100})
+	qc := NewAutoscalingQueueConsumers[string](q, AutoscalingSettings{MinConsumers: 1, MaxConsumers: 10, Interval: time.Hour},
+		func(context.Context, string) error { return nil })
+	require.NoError(t, q.Offer(context.Background(), "a"))
+
+	qc.observe(1, 10*time.Millisecond)
+	qc.scale()
+	qc.observe(2, 10*time.Millisecond)
+	qc.scale()
+	assert.Equal(t, 3, qc.ActiveConsumers())
+
+	// The latency degrades, adding more consumers would only add load to the destination.
+	qc.observe(3, 50*time.Millisecond)
+	qc.scale()
+	assert.Equal(t, 2, qc.ActiveConsumers())
+}
+
+func TestAutoscalingConsumers_ScaleDownWhenIdle(t *testing.T) {
+	_, qc := newTestAutoscalingConsumers(t, 2, 10)
+	qc.mu.Lock()
+	qc.active = 4
+	qc.mu.Unlock()
+
+	for _, e := range []int{3, 2, 2} {
+		qc.observe(0, 10*time.Millisecond)
+		qc.scale()
+		assert.Equal(t, e, qc.ActiveConsumers())
+	}
+}
+
+func TestAutoscalingConsumers_OnlyActiveConsumersConsume(t *testing.T) {
+	q := NewBoundedMemoryQueue[string](MemoryQueueSettings[string]{Sizer: &RequestSizer[string]{}, Capacity: 100})
+	release := make(chan struct{})
+	var inFlight, maxInFlight atomic.Int64
+	qc := NewAutoscalingQueueConsumers[string](q, AutoscalingSettings{MinConsumers: 1, MaxConsumers: 3, Interval: time.Hour},
+		func(context.Context, string) error {
+			n := inFlight.Add(1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			<-release
+			inFlight.Add(-1)
+			return nil
+		})
+	require.NoError(t, qc.Start(context.Background(), componenttest.NewNopHost()))
+	for _, item := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, q.Offer(context.Background(), item))
+	}
+	assert.Eventually(t, func() bool { return inFlight.Load() == 1 }, time.Second, time.Millisecond)
+
+	qc.scale()
+	assert.Equal(t, 2, qc.ActiveConsumers())
+	assert.Eventually(t, func() bool { return inFlight.Load() == 2 }, time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return maxInFlight.Load() > 2 }, 50*time.Millisecond, time.Millisecond)
+
+	close(release)
+	require.NoError(t, qc.Shutdown(context.Background()))
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestAutoscalingConsumers_ConsumeWithMinConsumers(t *testing.T) {
+	q, qc := newTestAutoscalingConsumers(t, 1, 5)
+	assert.Equal(t, 1, qc.ActiveConsumers())
+	for _, item := range []string{"a", "b", "c"} {
+		require.NoError(t, q.Offer(context.Background(), item))
+	}
+	assert.Eventually(t, func() bool { return q.Size() == 0 }, time.Second, time.Millisecond)
+}
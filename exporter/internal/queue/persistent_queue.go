@@ -62,6 +62,9 @@ type persistentQueue[T any] struct {
 	currentlyDispatchedItems []uint64
 	refClient                int64
 	stopped                  bool
+	// contexts holds the contexts the items were offered with, by index, to consume them with the same context
+	// while the collector runs. The items left from a previous run are consumed with a background context.
+	contexts map[uint64]context.Context
 }
 
 const (
@@ -194,6 +197,7 @@ func (pq *persistentQueue[T]) restoreQueueSizeFromStorage(ctx context.Context) (
 func (pq *persistentQueue[T]) Consume(consumeFunc func(context.Context, T) error) bool {
 	for {
 		var (
+			ctx                  context.Context
 			req                  T
 			onProcessingFinished func(error)
 			consumed             bool
@@ -202,7 +206,7 @@ func (pq *persistentQueue[T]) Consume(consumeFunc func(context.Context, T) error
 		// If we are stopped we still process all the other events in the channel before, but we
 		// return fast in the `getNextItem`, so we will free the channel fast and get to the stop.
 		_, ok := pq.sizedChannel.pop(func(permanentQueueEl) int64 {
-			req, onProcessingFinished, consumed = pq.getNextItem(context.Background())
+			ctx, req, onProcessingFinished, consumed = pq.getNextItem(context.Background())
 			if !consumed {
 				return 0
 			}
@@ -212,7 +216,7 @@ func (pq *persistentQueue[T]) Consume(consumeFunc func(context.Context, T) error
 			return false
 		}
 		if consumed {
-			onProcessingFinished(consumeFunc(context.Background(), req))
+			onProcessingFinished(consumeFunc(ctx, req))
 			return true
 		}
 	}
@@ -261,7 +265,15 @@ func (pq *persistentQueue[T]) unrefClient(ctx context.Context) error {
 func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
-	return pq.putInternal(ctx, req)
+	index := pq.writeIndex
+	if err := pq.putInternal(ctx, req); err != nil {
+		return err
+	}
+	if pq.contexts == nil {
+		pq.contexts = make(map[uint64]context.Context)
+	}
+	pq.contexts[index] = ctx
+	return nil
 }
 
 // putInternal is the internal version that requires caller to hold the mutex lock.
@@ -302,23 +314,30 @@ func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
 	return nil
 }
 
-// getNextItem pulls the next available item from the persistent storage along with a callback function that should be
-// called after the item is processed to clean up the storage. If no new item is available, returns false.
-func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (T, func(error), bool) {
+// getNextItem pulls the next available item from the persistent storage along with the context it was offered with
+// and a callback function that should be called after the item is processed to clean up the storage.
+// If no new item is available, returns false.
+func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (context.Context, T, func(error), bool) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
 	var request T
 
 	if pq.stopped {
-		return request, nil, false
+		return nil, request, nil, false
 	}
 
 	if pq.readIndex == pq.writeIndex {
-		return request, nil, false
+		return nil, request, nil, false
 	}
 
 	index := pq.readIndex
+	reqCtx, found := pq.contexts[index]
+	if found {
+		delete(pq.contexts, index)
+	} else {
+		reqCtx = context.Background()
+	}
 	// Increase here, so even if errors happen below, it always iterates
 	pq.readIndex++
 	pq.currentlyDispatchedItems = append(pq.currentlyDispatchedItems, index)
@@ -339,13 +358,13 @@ func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (T, func(error),
 			pq.logger.Error("Error deleting item from queue", zap.Error(err))
 		}
 
-		return request, nil, false
+		return nil, request, nil, false
 	}
 
 	// Increase the reference count, so the client is not closed while the request is being processed.
 	// The client cannot be closed because we hold the lock since last we checked `stopped`.
 	pq.refClient++
-	return request, func(consumeErr error) {
+	return reqCtx, request, func(consumeErr error) {
 		// Delete the item from the persistent storage after it was processed.
 		pq.mu.Lock()
 		// Always unref client even if the consumer is shutdown because we always ref it for every valid request.
@@ -461,13 +461,13 @@ func TestPersistentQueue_CurrentlyProcessedItems(t *testing.T) {
 	requireCurrentlyDispatchedItemsEqual(t, ps, []uint64{})
 
 	// Takes index 0 in process.
-	readReq, _, found := ps.getNextItem(context.Background())
+	_, readReq, _, found := ps.getNextItem(context.Background())
 	require.True(t, found)
 	assert.Equal(t, req, readReq)
 	requireCurrentlyDispatchedItemsEqual(t, ps, []uint64{0})
 
 	// This takes item 1 to process.
-	secondReadReq, onProcessingFinished, found := ps.getNextItem(context.Background())
+	_, secondReadReq, onProcessingFinished, found := ps.getNextItem(context.Background())
 	require.True(t, found)
 	assert.Equal(t, req, secondReadReq)
 	requireCurrentlyDispatchedItemsEqual(t, ps, []uint64{0, 1})
@@ -542,7 +542,7 @@ func TestPersistentQueue_PutCloseReadClose(t *testing.T) {
 	assert.NoError(t, ps.Offer(context.Background(), req))
 	assert.Equal(t, 2, ps.Size())
 	// TODO: Remove this, after the initialization writes the readIndex.
-	_, _, _ = ps.getNextItem(context.Background())
+	_, _, _, _ = ps.getNextItem(context.Background())
 	assert.NoError(t, ps.Shutdown(context.Background()))
 
 	newPs := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
@@ -563,6 +563,30 @@ func TestPersistentQueue_PutCloseReadClose(t *testing.T) {
 	assert.NoError(t, newPs.Shutdown(context.Background()))
 }
 
+type ctxKey struct{}
+
+func TestPersistentQueue_ConsumeWithOfferedContext(t *testing.T) {
+	req := newTracesRequest(1, 1)
+	ext := NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+
+	require.NoError(t, ps.Offer(context.WithValue(context.Background(), ctxKey{}, "first"), req))
+	require.NoError(t, ps.Offer(context.WithValue(context.Background(), ctxKey{}, "second"), req))
+	assert.True(t, ps.Consume(func(ctx context.Context, _ tracesRequest) error {
+		assert.Equal(t, "first", ctx.Value(ctxKey{}))
+		return nil
+	}))
+	assert.NoError(t, ps.Shutdown(context.Background()))
+
+	// The items left from a previous run are consumed with a background context.
+	newPs := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+	assert.True(t, newPs.Consume(func(ctx context.Context, _ tracesRequest) error {
+		assert.Nil(t, ctx.Value(ctxKey{}))
+		return nil
+	}))
+	assert.NoError(t, newPs.Shutdown(context.Background()))
+}
+
 func BenchmarkPersistentQueue_TraceSpans(b *testing.B) {
 	cases := []struct {
 		numTraces        int
@@ -670,7 +694,7 @@ func TestPersistentQueue_ShutdownWhileConsuming(t *testing.T) {
 
 	assert.NoError(t, ps.Offer(context.Background(), newTracesRequest(5, 10)))
 
-	_, onProcessingFinished, ok := ps.getNextItem(context.Background())
+	_, _, onProcessingFinished, ok := ps.getNextItem(context.Background())
 	require.True(t, ok)
 	assert.False(t, ps.client.(*mockStorageClient).isClosed())
 	assert.NoError(t, ps.Shutdown(context.Background()))
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Priority is the priority of an item in a priority queue.
+type Priority int
+
+const (
+	// PriorityLow is the lowest priority, items with this priority are shed first.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh is the highest priority, items with this priority are consumed first.
+	PriorityHigh
+
+	numPriorities = int(PriorityHigh) + 1
+)
+
+// DropPolicy defines what happens when an item is offered and there is no room for it
+// once all the lower priority items have been shed.
+type DropPolicy int
+
+const (
+	// DropPolicyReject rejects the offered item with ErrQueueIsFull.
+	DropPolicyReject DropPolicy = iota
+	// DropPolicyDropOldest drops the oldest items of the same priority to make room for the offered item.
+	DropPolicyDropOldest
+)
+
+// PriorityLevelSettings defines the settings of a single priority level.
+type PriorityLevelSettings struct {
+	// Capacity is the maximum total size of the items of this priority.
+	Capacity int64
+	// DropPolicy is applied when there is no room for an item of this priority.
+	DropPolicy DropPolicy
+}
+
+// PriorityQueueSettings defines internal parameters for priorityQueue creation.
+type PriorityQueueSettings[T any] struct {
+	Sizer    Sizer[T]
+	Capacity int64
+	// Levels holds the settings of each priority level, indexed by Priority.
+	Levels [numPriorities]PriorityLevelSettings
+	// PriorityFunc returns the priority of an offered item.
+	PriorityFunc func(context.Context, T) Priority
+	// OnDrop, if set, is called for every item dropped from the queue after it was accepted.
+	OnDrop func(context.Context, T)
+}
+
+// priorityQueue is a bounded memory queue holding a FIFO list per priority level.
+// Consumers always get the oldest item of the highest non-empty priority. When the queue is full,
+// the oldest items of lower priorities are dropped first to make room for new items.
+type priorityQueue[T any] struct {
+	component.StartFunc
+	set PriorityQueueSettings[T]
+
+	mu        sync.Mutex
+	hasItems  *sync.Cond
+	levels    [numPriorities]*list.List
+	levelUsed [numPriorities]int64
+	used      int64
+	stopped   bool
+}
+
+// NewPriorityQueue constructs a new memory queue where items are consumed and shed according to their priority.
+func NewPriorityQueue[T any](set PriorityQueueSettings[T]) Queue[T] {
+	pq := &priorityQueue[T]{set: set}
+	pq.hasItems = sync.NewCond(&pq.mu)
+	for i := range pq.levels {
+		pq.levels[i] = list.New()
+	}
+	return pq
+}
+
+// Offer is used by the producer to submit new item to the queue.
+func (pq *priorityQueue[T]) Offer(ctx context.Context, req T) error {
+	p := pq.priorityOf(ctx, req)
+	size := pq.set.Sizer.Sizeof(req)
+	level := pq.set.Levels[p]
+
+	var dropped []droppedEl[T]
+	pq.mu.Lock()
+	if pq.stopped || size > pq.set.Capacity || size > level.Capacity {
+		pq.mu.Unlock()
+		return ErrQueueIsFull
+	}
+	// The level capacity can only be freed by dropping items of the same level.
+	for pq.levelUsed[p]+size > level.Capacity {
+		if level.DropPolicy != DropPolicyDropOldest {
+			pq.mu.Unlock()
+			return ErrQueueIsFull
+		}
+		dropped = append(dropped, droppedEl[T]{p: p, el: pq.removeOldest(p)})
+	}
+	for pq.used+size > pq.set.Capacity {
+		victim, ok := pq.lowestNonEmpty(p)
+		if !ok {
+			if level.DropPolicy != DropPolicyDropOldest || pq.levels[p].Len() == 0 {
+				pq.restore(dropped)
+				pq.mu.Unlock()
+				return ErrQueueIsFull
+			}
+			victim = p
+		}
+		dropped = append(dropped, droppedEl[T]{p: victim, el: pq.removeOldest(victim)})
+	}
+	pq.levels[p].PushBack(memQueueEl[T]{ctx: ctx, req: req})
+	pq.levelUsed[p] += size
+	pq.used += size
+	pq.hasItems.Signal()
+	pq.mu.Unlock()
+
+	if pq.set.OnDrop != nil {
+		for _, d := range dropped {
+			pq.set.OnDrop(d.el.ctx, d.el.req)
+		}
+	}
+	return nil
+}
+
+// lowestNonEmpty returns the lowest priority, strictly lower than p, that holds at least one item.
+func (pq *priorityQueue[T]) lowestNonEmpty(p Priority) (Priority, bool) {
+	for i := PriorityLow; i < p; i++ {
+		if pq.levels[i].Len() > 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// removeOldest removes and returns the oldest item of the given priority. The caller must hold the lock
+// and ensure the level is not empty.
+func (pq *priorityQueue[T]) removeOldest(p Priority) memQueueEl[T] {
+	el := pq.levels[p].Remove(pq.levels[p].Front()).(memQueueEl[T])
+	size := pq.set.Sizer.Sizeof(el.req)
+	pq.levelUsed[p] -= size
+	pq.used -= size
+	return el
+}
+
+// restore puts back the items removed while trying to make room for an item that was eventually rejected.
+// The items are put back in front of their level to preserve the order. The caller must hold the lock.
+func (pq *priorityQueue[T]) restore(dropped []droppedEl[T]) {
+	for i := len(dropped) - 1; i >= 0; i-- {
+		d := dropped[i]
+		pq.levels[d.p].PushFront(d.el)
+		size := pq.set.Sizer.Sizeof(d.el.req)
+		pq.levelUsed[d.p] += size
+		pq.used += size
+	}
+}
+
+// priorityOf returns the priority of the given item, falling back to PriorityNormal for unknown values.
+func (pq *priorityQueue[T]) priorityOf(ctx context.Context, req T) Priority {
+	p := pq.set.PriorityFunc(ctx, req)
+	if p < PriorityLow || p > PriorityHigh {
+		return PriorityNormal
+	}
+	return p
+}
+
+type droppedEl[T any] struct {
+	p  Priority
+	el memQueueEl[T]
+}
+
+// Consume applies the provided function on the oldest item of the highest non-empty priority.
+// The call blocks until there is an item available or the queue is stopped.
+// The function returns true when an item is consumed or false if the queue is stopped and emptied.
+func (pq *priorityQueue[T]) Consume(consumeFunc func(context.Context, T) error) bool {
+	pq.mu.Lock()
+	for pq.isEmpty() && !pq.stopped {
+		pq.hasItems.Wait()
+	}
+	if pq.isEmpty() {
+		pq.mu.Unlock()
+		return false
+	}
+	var el memQueueEl[T]
+	for p := PriorityHigh; p >= PriorityLow; p-- {
+		if pq.levels[p].Len() > 0 {
+			el = pq.removeOldest(p)
+			break
+		}
+	}
+	pq.mu.Unlock()
+
+	// the memory queue doesn't handle consume errors
+	_ = consumeFunc(el.ctx, el.req)
+	return true
+}
+
+func (pq *priorityQueue[T]) isEmpty() bool {
+	for _, l := range pq.levels {
+		if l.Len() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Shutdown stops accepting new items and wakes up the consumers to drain the queue.
+func (pq *priorityQueue[T]) Shutdown(context.Context) error {
+	pq.mu.Lock()
+	pq.stopped = true
+	pq.hasItems.Broadcast()
+	pq.mu.Unlock()
+	return nil
+}
+
+// Size returns the current size of the queue.
+func (pq *priorityQueue[T]) Size() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return int(pq.used)
+}
+
+// Capacity returns the capacity of the queue.
+func (pq *priorityQueue[T]) Capacity() int {
+	return int(pq.set.Capacity)
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+// newTestPriorityQueue returns a queue where the priority of an item is given by its prefix: "h", "n" or "l".
+func newTestPriorityQueue(capacity int64, levels [numPriorities]PriorityLevelSettings, onDrop func(context.Context, string)) Queue[string] {
+	return newTestSizedPriorityQueue(&RequestSizer[string]{}, capacity, levels, onDrop)
+}
+
+func newTestSizedPriorityQueue(sizer Sizer[string], capacity int64, levels [numPriorities]PriorityLevelSettings, onDrop func(context.Context, string)) Queue[string] {
+	return NewPriorityQueue[string](PriorityQueueSettings[string]{
+		Sizer:    sizer,
+		Capacity: capacity,
+		Levels:   levels,
+		PriorityFunc: func(_ context.Context, item string) Priority {
+			switch {
+			case strings.HasPrefix(item, "h"):
+				return PriorityHigh
+			case strings.HasPrefix(item, "l"):
+				return PriorityLow
+			}
+			return PriorityNormal
+		},
+		OnDrop: onDrop,
+	})
+}
+
+func uniformLevels(capacity int64, policy DropPolicy) [numPriorities]PriorityLevelSettings {
+	var levels [numPriorities]PriorityLevelSettings
+	for i := range levels {
+		levels[i] = PriorityLevelSettings{Capacity: capacity, DropPolicy: policy}
+	}
+	return levels
+}
+
+func consumeAll(t *testing.T, q Queue[string]) []string {
+	var consumed []string
+	for q.Size() > 0 {
+		require.True(t, q.Consume(func(_ context.Context, item string) error {
+			consumed = append(consumed, item)
+			return nil
+		}))
+	}
+	return consumed
+}
+
+func TestPriorityQueue_ConsumeOrder(t *testing.T) {
+	q := newTestPriorityQueue(10, uniformLevels(10, DropPolicyReject), nil)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	for _, item := range []string{"l1", "n1", "h1", "l2", "h2", "n2"} {
+		require.NoError(t, q.Offer(context.Background(), item))
+	}
+	assert.Equal(t, 6, q.Size())
+	assert.Equal(t, []string{"h1", "h2", "n1", "n2", "l1", "l2"}, consumeAll(t, q))
+	assert.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestPriorityQueue_ShedLowerPriorityFirst(t *testing.T) {
+	var dropped []string
+	q := newTestPriorityQueue(3, uniformLevels(3, DropPolicyReject), func(_ context.Context, item string) {
+		dropped = append(dropped, item)
+	})
+	for _, item := range []string{"l1", "n1", "l2"} {
+		require.NoError(t, q.Offer(context.Background(), item))
+	}
+
+	// The queue is full, the oldest low priority items are shed first.
+	require.NoError(t, q.Offer(context.Background(), "h1"))
+	require.NoError(t, q.Offer(context.Background(), "n2"))
+	assert.Equal(t, []string{"l1", "l2"}, dropped)
+
+	// A normal priority item can only shed low priority items.
+	require.ErrorIs(t, q.Offer(context.Background(), "n3"), ErrQueueIsFull)
+	require.NoError(t, q.Offer(context.Background(), "h2"))
+	assert.Equal(t, []string{"l1", "l2", "n1"}, dropped)
+
+	// Low priority items cannot shed anything with the reject policy.
+	require.ErrorIs(t, q.Offer(context.Background(), "l3"), ErrQueueIsFull)
+	assert.Equal(t, []string{"h1", "h2", "n2"}, consumeAll(t, q))
+}
+
+func TestPriorityQueue_DropOldest(t *testing.T) {
+	var dropped []string
+	q := newTestPriorityQueue(2, uniformLevels(2, DropPolicyDropOldest), func(_ context.Context, item string) {
+		dropped = append(dropped, item)
+	})
+	for _, item := range []string{"n1", "n2", "n3"} {
+		require.NoError(t, q.Offer(context.Background(), item))
+	}
+	assert.Equal(t, []string{"n1"}, dropped)
+
+	// There is no lower priority item to shed and no low priority item to replace.
+	require.ErrorIs(t, q.Offer(context.Background(), "l1"), ErrQueueIsFull)
+	require.NoError(t, q.Offer(context.Background(), "n4"))
+	assert.Equal(t, []string{"n1", "n2"}, dropped)
+	assert.Equal(t, []string{"n3", "n4"}, consumeAll(t, q))
+}
+
+func TestPriorityQueue_LevelCapacity(t *testing.T) {
+	levels := uniformLevels(4, DropPolicyReject)
+	levels[PriorityLow] = PriorityLevelSettings{Capacity: 1, DropPolicy: DropPolicyReject}
+	levels[PriorityNormal] = PriorityLevelSettings{Capacity: 2, DropPolicy: DropPolicyDropOldest}
+	var dropped []string
+	q := newTestPriorityQueue(4, levels, func(_ context.Context, item string) {
+		dropped = append(dropped, item)
+	})
+
+	require.NoError(t, q.Offer(context.Background(), "l1"))
+	require.ErrorIs(t, q.Offer(context.Background(), "l2"), ErrQueueIsFull)
+
+	require.NoError(t, q.Offer(context.Background(), "n1"))
+	require.NoError(t, q.Offer(context.Background(), "n2"))
+	require.NoError(t, q.Offer(context.Background(), "n3"))
+	assert.Equal(t, []string{"n1"}, dropped)
+	assert.Equal(t, 3, q.Size())
+	assert.Equal(t, 4, q.Capacity())
+
+	assert.Equal(t, []string{"n2", "n3", "l1"}, consumeAll(t, q))
+}
+
+// lenSizer sizes an item by the number of characters after its priority prefix.
+type lenSizer struct{}
+
+func (lenSizer) Sizeof(item string) int64 {
+	return int64(len(item) - 1)
+}
+
+func TestPriorityQueue_RejectRestoresDropped(t *testing.T) {
+	levels := uniformLevels(3, DropPolicyReject)
+	levels[PriorityNormal] = PriorityLevelSettings{Capacity: 2, DropPolicy: DropPolicyDropOldest}
+	var dropped []string
+	q := newTestSizedPriorityQueue(lenSizer{}, 3, levels, func(_ context.Context, item string) {
+		dropped = append(dropped, item)
+	})
+	require.NoError(t, q.Offer(context.Background(), "hhh"))
+	require.NoError(t, q.Offer(context.Background(), "nn"))
+	assert.Equal(t, 3, q.Size())
+
+	// Replacing "nn" makes room in the normal level but not in the queue, so nothing is dropped.
+	require.ErrorIs(t, q.Offer(context.Background(), "nnn"), ErrQueueIsFull)
+	assert.Empty(t, dropped)
+	assert.Equal(t, 3, q.Size())
+	assert.Equal(t, []string{"hhh", "nn"}, consumeAll(t, q))
+}
+
+func TestPriorityQueue_ShutdownWhileConsuming(t *testing.T) {
+	q := newTestPriorityQueue(10, uniformLevels(10, DropPolicyReject), nil)
+	require.NoError(t, q.Offer(context.Background(), "n1"))
+
+	var wg sync.WaitGroup
+	var consumed []string
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for q.Consume(func(_ context.Context, item string) error {
+			consumed = append(consumed, item)
+			return nil
+		}) {
+		}
+	}()
+	require.NoError(t, q.Shutdown(context.Background()))
+	wg.Wait()
+	assert.Equal(t, []string{"n1"}, consumed)
+	assert.ErrorIs(t, q.Offer(context.Background(), "n2"), ErrQueueIsFull)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
+)
+
+// spillQueue is a memory queue backed by a disk tier: the items offered while the memory queue is full
+// are spilled to the disk queue, and replayed into the memory queue as soon as it has room again.
+// The consumers only consume from the memory queue.
+//
+// The items moved from the disk tier to the memory queue are no longer persisted. The spilled items are
+// consumed with the context they were offered with, or a background context after a restart, like the items
+// of the persistent queue.
+type spillQueue[T any] struct {
+	memory Queue[T]
+	disk   Queue[T]
+
+	// room is notified every time an item is removed from the memory queue.
+	room     chan struct{}
+	stopCh   chan struct{}
+	replayWG sync.WaitGroup
+}
+
+// NewSpillQueue returns a queue spilling the items which do not fit in the memory queue to the disk queue.
+func NewSpillQueue[T any](memory Queue[T], disk Queue[T]) Queue[T] {
+	return &spillQueue[T]{
+		memory: memory,
+		disk:   disk,
+		room:   make(chan struct{}, 1),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start starts both tiers and the replay of the spilled items.
+func (q *spillQueue[T]) Start(ctx context.Context, host component.Host) error {
+	if err := q.memory.Start(ctx, host); err != nil {
+		return err
+	}
+	if err := q.disk.Start(ctx, host); err != nil {
+		return errors.Join(err, q.memory.Shutdown(ctx))
+	}
+	q.replayWG.Add(1)
+	go q.replay()
+	return nil
+}
+
+// replay moves the spilled items to the memory queue, waiting for room when it is full.
+func (q *spillQueue[T]) replay() {
+	defer q.replayWG.Done()
+	for q.disk.Consume(func(ctx context.Context, req T) error {
+		for {
+			err := q.memory.Offer(ctx, req)
+			if !errors.Is(err, ErrQueueIsFull) {
+				return err
+			}
+			select {
+			case <-q.room:
+			case <-q.stopCh:
+				// Keep the item in the disk queue, so it is replayed after a restart.
+				return experr.NewShutdownErr(err)
+			}
+		}
+	}) {
+	}
+}
+
+// Offer inserts the item in the memory queue, or in the disk queue if the memory queue is full.
+// It returns ErrQueueIsFull if both tiers are full.
+func (q *spillQueue[T]) Offer(ctx context.Context, req T) error {
+	if err := q.memory.Offer(ctx, req); !errors.Is(err, ErrQueueIsFull) {
+		return err
+	}
+	return q.disk.Offer(ctx, req)
+}
+
+// Consume applies the provided function on the head of the memory queue.
+// The call blocks until there is an item available or the queue is stopped.
+// The function returns true when an item is consumed or false if the queue is stopped and emptied.
+func (q *spillQueue[T]) Consume(consumeFunc func(context.Context, T) error) bool {
+	return q.memory.Consume(func(ctx context.Context, req T) error {
+		select {
+		case q.room <- struct{}{}:
+		default:
+		}
+		return consumeFunc(ctx, req)
+	})
+}
+
+// Shutdown stops the replay, keeping the remaining spilled items in the disk queue, then stops the memory
+// queue to initiate its draining.
+func (q *spillQueue[T]) Shutdown(ctx context.Context) error {
+	close(q.stopCh)
+	diskErr := q.disk.Shutdown(ctx)
+	q.replayWG.Wait()
+	return errors.Join(diskErr, q.memory.Shutdown(ctx))
+}
+
+// Size returns the total size of both tiers.
+func (q *spillQueue[T]) Size() int {
+	return q.memory.Size() + q.disk.Size()
+}
+
+// Capacity returns the total capacity of both tiers.
+func (q *spillQueue[T]) Capacity() int {
+	return q.memory.Capacity() + q.disk.Capacity()
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func newTestSpillQueue(ext storage.Extension, memoryCapacity, diskCapacity int64) (Queue[tracesRequest], component.Host) {
+	memory := NewBoundedMemoryQueue[tracesRequest](MemoryQueueSettings[tracesRequest]{
+		Sizer:    &RequestSizer[tracesRequest]{},
+		Capacity: memoryCapacity,
+	})
+	disk := NewPersistentQueue[tracesRequest](PersistentQueueSettings[tracesRequest]{
+		Sizer:            &RequestSizer[tracesRequest]{},
+		Capacity:         diskCapacity,
+		DataType:         component.DataTypeTraces,
+		StorageID:        component.ID{},
+		Marshaler:        marshalTracesRequest,
+		Unmarshaler:      unmarshalTracesRequest,
+		ExporterSettings: exportertest.NewNopSettings(),
+	})
+	host := &mockHost{ext: map[component.ID]component.Component{{}: ext}}
+	return NewSpillQueue(memory, disk), host
+}
+
+func TestSpillQueue(t *testing.T) {
+	q, host := newTestSpillQueue(NewMockStorageExtension(nil), 1, 3)
+	require.NoError(t, q.Start(context.Background(), host))
+	assert.Equal(t, 4, q.Capacity())
+
+	// The first request fills the memory queue, the next ones are spilled to the disk queue.
+	for i := 0; i < 4; i++ {
+		require.NoError(t, q.Offer(context.Background(), newTracesRequest(1, i+1)))
+	}
+	require.ErrorIs(t, q.Offer(context.Background(), newTracesRequest(1, 10)), ErrQueueIsFull)
+
+	// The spilled requests are replayed once the memory queue has room again.
+	var consumed []int
+	for i := 0; i < 4; i++ {
+		require.True(t, q.Consume(func(_ context.Context, req tracesRequest) error {
+			consumed = append(consumed, req.ItemsCount())
+			return nil
+		}))
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, consumed)
+	assert.Equal(t, 0, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestSpillQueue_ShutdownKeepsSpilledItems(t *testing.T) {
+	ext := NewMockStorageExtension(nil)
+	q, host := newTestSpillQueue(ext, 1, 10)
+	require.NoError(t, q.Start(context.Background(), host))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, q.Offer(context.Background(), newTracesRequest(1, i+1)))
+	}
+	// Wait for the first spilled item to be waiting for room in the memory queue.
+	disk := q.(*spillQueue[tracesRequest]).disk
+	assert.Eventually(t, func() bool { return disk.Size() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, q.Shutdown(context.Background()))
+
+	// The item in the memory queue is drained on shutdown.
+	require.True(t, q.Consume(func(_ context.Context, req tracesRequest) error {
+		assert.Equal(t, 1, req.ItemsCount())
+		return nil
+	}))
+	require.False(t, q.Consume(func(context.Context, tracesRequest) error { return nil }))
+
+	// The spilled items are replayed after a restart.
+	q, host = newTestSpillQueue(ext, 1, 10)
+	require.NoError(t, q.Start(context.Background(), host))
+	var consumed []int
+	for i := 0; i < 2; i++ {
+		require.True(t, q.Consume(func(_ context.Context, req tracesRequest) error {
+			consumed = append(consumed, req.ItemsCount())
+			return nil
+		}))
+	}
+	assert.ElementsMatch(t, []int{2, 3}, consumed)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
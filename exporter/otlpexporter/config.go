@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -29,14 +30,84 @@ type Config struct {
 	BatcherConfig exporterbatcher.Config `mapstructure:"batcher"`
 
 	configgrpc.ClientConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// RPCTimeout is the timeout of each Export RPC, sent to the server as the deadline of the RPC.
+	// Unlike Timeout, which bounds the whole export attempt, it only bounds the RPC. Zero means no RPC timeout.
+	RPCTimeout time.Duration `mapstructure:"rpc_timeout"`
+
+	// PropagateDeadline propagates the deadline and the cancellation of the incoming requests to the Export RPCs,
+	// when the data is exported without sending queue. If false, the RPCs are only bounded by Timeout and RPCTimeout.
+	PropagateDeadline bool `mapstructure:"propagate_deadline"`
+
+	// DeadlineMargin is subtracted from the propagated deadline, leaving time to respond to the clients
+	// before the deadline of their requests is exceeded.
+	DeadlineMargin time.Duration `mapstructure:"deadline_margin"`
+
+	// Failover configures the endpoints the data is sent to while the endpoint is unhealthy.
+	Failover FailoverConfig `mapstructure:"failover"`
+}
+
+// FailoverConfig defines the failover endpoints of the exporter. An endpoint is unhealthy once FailureThreshold
+// exports to it failed in a row, the data being sent to the next healthy endpoint, and healthy again once an empty
+// export request sent every ProbeInterval succeeds. The failover is disabled when no endpoint is set.
+type FailoverConfig struct {
+	// Endpoints are the failover endpoints, in order of preference. They are connected to with the same settings
+	// as the endpoint.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// FailureThreshold is the number of consecutive failed exports after which an endpoint is unhealthy.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// ProbeInterval is the interval between the probes of the unhealthy endpoints.
+	ProbeInterval time.Duration `mapstructure:"probe_interval"`
+}
+
+func (fc *FailoverConfig) enabled() bool {
+	return len(fc.Endpoints) > 0
 }
 
 func (c *Config) Validate() error {
+	if c.RPCTimeout < 0 {
+		return errors.New(`"rpc_timeout" must be non-negative`)
+	}
+	if c.DeadlineMargin < 0 {
+		return errors.New(`"deadline_margin" must be non-negative`)
+	}
+
+	if err := c.validateEndpoint(); err != nil {
+		return err
+	}
+	if !c.Failover.enabled() {
+		return nil
+	}
+	if c.Failover.FailureThreshold <= 0 {
+		return errors.New(`failover "failure_threshold" must be positive`)
+	}
+	if c.Failover.ProbeInterval <= 0 {
+		return errors.New(`failover "probe_interval" must be positive`)
+	}
+	for _, endpoint := range c.Failover.Endpoints {
+		fc := *c
+		fc.Endpoint = endpoint
+		if err := fc.validateEndpoint(); err != nil {
+			return fmt.Errorf("invalid failover endpoint %q: %w", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// validateEndpoint checks that the endpoint is set, with a port unless it is an xDS target.
+func (c *Config) validateEndpoint() error {
 	endpoint := c.sanitizedEndpoint()
 	if endpoint == "" {
 		return errors.New(`requires a non-empty "endpoint"`)
 	}
 
+	// The port of xds targets is optional, it is provided by the xDS control plane.
+	if c.IsSchemeXDS() {
+		return nil
+	}
+
 	// Validate that the port is in the address
 	_, port, err := net.SplitHostPort(endpoint)
 	if err != nil {
@@ -58,6 +129,9 @@ func (c *Config) sanitizedEndpoint() string {
 	case strings.HasPrefix(c.Endpoint, "dns://"):
 		r := regexp.MustCompile("^dns://[/]?")
 		return r.ReplaceAllString(c.Endpoint, "")
+	case c.IsSchemeXDS():
+		r := regexp.MustCompile("^xds:(//[^/]*/)?")
+		return r.ReplaceAllString(c.Endpoint, "")
 	default:
 		return c.Endpoint
 	}
@@ -87,6 +87,14 @@ func TestUnmarshalConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            &configauth.Authentication{AuthenticatorID: component.MustNewID("nop")},
 			},
+			RPCTimeout:        5 * time.Second,
+			PropagateDeadline: false,
+			DeadlineMargin:    100 * time.Millisecond,
+			Failover: FailoverConfig{
+				Endpoints:        []string{"5.6.7.8:1234"},
+				FailureThreshold: 5,
+				ProbeInterval:    30 * time.Second,
+			},
 		}, cfg)
 }
 
@@ -114,6 +122,26 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_timeout",
 			errorMsg: `'timeout' must be non-negative`,
 		},
+		{
+			name:     "invalid_rpc_timeout",
+			errorMsg: `"rpc_timeout" must be non-negative`,
+		},
+		{
+			name:     "invalid_deadline_margin",
+			errorMsg: `"deadline_margin" must be non-negative`,
+		},
+		{
+			name:     "invalid_failover_endpoint",
+			errorMsg: `invalid failover endpoint "backup.example.com": address backup.example.com: missing port in address`,
+		},
+		{
+			name:     "invalid_failover_failure_threshold",
+			errorMsg: `failover "failure_threshold" must be positive`,
+		},
+		{
+			name:     "invalid_failover_probe_interval",
+			errorMsg: `failover "probe_interval" must be positive`,
+		},
 		{
 			name:     "invalid_retry",
 			errorMsg: `'randomization_factor' must be within [0, 1]`,
@@ -149,6 +177,17 @@ func TestValidDNSEndpoint(t *testing.T) {
 	assert.NoError(t, cfg.Validate())
 }
 
+func TestValidXDSEndpoint(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "xds:///otelcol-gateway"
+	assert.NoError(t, cfg.Validate())
+	cfg.Endpoint = "xds://authority/otelcol-gateway:4317"
+	assert.NoError(t, cfg.Validate())
+	cfg.Endpoint = "xds:///"
+	assert.EqualError(t, cfg.Validate(), `requires a non-empty "endpoint"`)
+}
+
 func TestSanitizeEndpoint(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
@@ -158,4 +197,6 @@ func TestSanitizeEndpoint(t *testing.T) {
 	assert.Equal(t, "backend.example.com:4317", cfg.sanitizedEndpoint())
 	cfg.Endpoint = "dns:////backend.example.com:4317"
 	assert.Equal(t, "/backend.example.com:4317", cfg.sanitizedEndpoint())
+	cfg.Endpoint = "xds:///otelcol-gateway:4317"
+	assert.Equal(t, "otelcol-gateway:4317", cfg.sanitizedEndpoint())
 }
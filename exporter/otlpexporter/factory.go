@@ -5,6 +5,7 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
@@ -29,6 +30,11 @@ func NewFactory() exporter.Factory {
 	)
 }
 
+const (
+	defaultFailoverFailureThreshold = 3
+	defaultFailoverProbeInterval    = 10 * time.Second
+)
+
 func createDefaultConfig() component.Config {
 	batcherCfg := exporterbatcher.NewDefaultConfig()
 	batcherCfg.Enabled = false
@@ -45,6 +51,11 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		PropagateDeadline: true,
+		Failover: FailoverConfig{
+			FailureThreshold: defaultFailoverFailureThreshold,
+			ProbeInterval:    defaultFailoverProbeInterval,
+		},
 	}
 }
 
@@ -54,6 +65,7 @@ func createTracesExporter(
 	cfg component.Config,
 ) (exporter.Traces, error) {
 	oce := newExporter(cfg, set)
+	oce.probe = oce.probeTraces
 	oCfg := cfg.(*Config)
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
 		oce.pushTraces,
@@ -73,6 +85,7 @@ func createMetricsExporter(
 	cfg component.Config,
 ) (exporter.Metrics, error) {
 	oce := newExporter(cfg, set)
+	oce.probe = oce.probeMetrics
 	oCfg := cfg.(*Config)
 	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
 		oce.pushMetrics,
@@ -92,6 +105,7 @@ func createLogsExporter(
 	cfg component.Config,
 ) (exporter.Logs, error) {
 	oce := newExporter(cfg, set)
+	oce.probe = oce.probeLogs
 	oCfg := cfg.(*Config)
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
 		oce.pushLogs,
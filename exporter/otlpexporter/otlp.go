@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -28,17 +30,27 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
 
-type baseExporter struct {
-	// Input configuration.
-	config *Config
-
-	// gRPC clients and connection.
+// endpointClient holds the gRPC connection to an endpoint and its clients.
+type endpointClient struct {
 	traceExporter  ptraceotlp.GRPCClient
 	metricExporter pmetricotlp.GRPCClient
 	logExporter    plogotlp.GRPCClient
 	clientConn     *grpc.ClientConn
-	metadata       metadata.MD
-	callOptions    []grpc.CallOption
+}
+
+type baseExporter struct {
+	// Input configuration.
+	config *Config
+
+	// gRPC clients of the endpoint, followed by the ones of the failover endpoints.
+	clients     []*endpointClient
+	metadata    metadata.MD
+	callOptions []grpc.CallOption
+
+	// failover sends the data to the healthy endpoints, nil if the failover is disabled.
+	failover *failover.Group
+	// probe sends an empty request of the signal of the exporter to an endpoint, to probe its health.
+	probe func(ctx context.Context, endpoint int) error
 
 	settings component.TelemetrySettings
 
@@ -58,12 +70,30 @@ func newExporter(cfg component.Config, set exporter.Settings) *baseExporter {
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) (err error) {
-	if e.clientConn, err = e.config.ClientConfig.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
-		return err
+	endpoints := append([]string{e.config.Endpoint}, e.config.Failover.Endpoints...)
+	for _, endpoint := range endpoints {
+		clientCfg := e.config.ClientConfig
+		clientCfg.Endpoint = endpoint
+		var clientConn *grpc.ClientConn
+		if clientConn, err = clientCfg.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
+			return err
+		}
+		e.clients = append(e.clients, &endpointClient{
+			traceExporter:  ptraceotlp.NewGRPCClient(clientConn),
+			metricExporter: pmetricotlp.NewGRPCClient(clientConn),
+			logExporter:    plogotlp.NewGRPCClient(clientConn),
+			clientConn:     clientConn,
+		})
+	}
+	if e.config.Failover.enabled() {
+		e.failover = failover.NewGroup(failover.Settings{
+			Endpoints:        endpoints,
+			FailureThreshold: e.config.Failover.FailureThreshold,
+			ProbeInterval:    e.config.Failover.ProbeInterval,
+			Logger:           e.settings.Logger,
+		}, e.probe)
+		e.failover.Start()
 	}
-	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
-	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
-	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
 	headers := map[string]string{}
 	for k, v := range e.config.ClientConfig.Headers {
 		headers[k] = string(v)
@@ -77,15 +107,37 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
-	if e.clientConn != nil {
-		return e.clientConn.Close()
+	if e.failover != nil {
+		e.failover.Shutdown()
 	}
-	return nil
+	var errs error
+	for _, c := range e.clients {
+		errs = multierr.Append(errs, c.clientConn.Close())
+	}
+	return errs
+}
+
+// export sends the data with send to the endpoint, or to the first healthy endpoint if the failover is enabled.
+func (e *baseExporter) export(ctx context.Context, send func(context.Context, *endpointClient) error) error {
+	if e.failover == nil {
+		return send(ctx, e.clients[0])
+	}
+	return e.failover.Export(ctx, func(ctx context.Context, endpoint int) error {
+		return send(ctx, e.clients[endpoint])
+	})
 }
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	return e.export(ctx, func(ctx context.Context, c *endpointClient) error {
+		return e.exportTraces(ctx, c, req)
+	})
+}
+
+func (e *baseExporter) exportTraces(ctx context.Context, c *endpointClient, req ptraceotlp.ExportRequest) error {
+	ctx, cancel := e.exportContext(ctx)
+	defer cancel()
+	resp, respErr := c.traceExporter.Export(ctx, req, e.callOptions...)
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -101,7 +153,15 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	return e.export(ctx, func(ctx context.Context, c *endpointClient) error {
+		return e.exportMetrics(ctx, c, req)
+	})
+}
+
+func (e *baseExporter) exportMetrics(ctx context.Context, c *endpointClient, req pmetricotlp.ExportRequest) error {
+	ctx, cancel := e.exportContext(ctx)
+	defer cancel()
+	resp, respErr := c.metricExporter.Export(ctx, req, e.callOptions...)
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -117,7 +177,15 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	return e.export(ctx, func(ctx context.Context, c *endpointClient) error {
+		return e.exportLogs(ctx, c, req)
+	})
+}
+
+func (e *baseExporter) exportLogs(ctx context.Context, c *endpointClient, req plogotlp.ExportRequest) error {
+	ctx, cancel := e.exportContext(ctx)
+	defer cancel()
+	resp, respErr := c.logExporter.Export(ctx, req, e.callOptions...)
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -131,6 +199,53 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	return nil
 }
 
+// probeTraces, probeMetrics and probeLogs send an empty export request to the endpoint, bounded by ctx only.
+func (e *baseExporter) probeTraces(ctx context.Context, endpoint int) error {
+	_, err := e.clients[endpoint].traceExporter.Export(e.enhanceContext(ctx), ptraceotlp.NewExportRequest(), e.callOptions...)
+	return processError(err)
+}
+
+func (e *baseExporter) probeMetrics(ctx context.Context, endpoint int) error {
+	_, err := e.clients[endpoint].metricExporter.Export(e.enhanceContext(ctx), pmetricotlp.NewExportRequest(), e.callOptions...)
+	return processError(err)
+}
+
+func (e *baseExporter) probeLogs(ctx context.Context, endpoint int) error {
+	_, err := e.clients[endpoint].logExporter.Export(e.enhanceContext(ctx), plogotlp.NewExportRequest(), e.callOptions...)
+	return processError(err)
+}
+
+// exportContext returns the context of an Export RPC, derived from the context of the export attempt.
+// Its deadline is the earliest of the deadline propagated from the incoming request, reduced by
+// DeadlineMargin, and of the timeouts of the export attempt and of the RPC.
+func (e *baseExporter) exportContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	cancels := make([]context.CancelFunc, 0, 2)
+	if !e.config.PropagateDeadline {
+		// Detaching the context from the incoming request also removes the timeout of
+		// the export attempt set by the exporter helper, it is applied again.
+		ctx = context.WithoutCancel(ctx)
+		if e.config.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, e.config.Timeout)
+			cancels = append(cancels, cancel)
+		}
+	} else if deadline, ok := ctx.Deadline(); ok && e.config.DeadlineMargin > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-e.config.DeadlineMargin))
+		cancels = append(cancels, cancel)
+	}
+	if e.config.RPCTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.config.RPCTimeout)
+		cancels = append(cancels, cancel)
+	}
+	return e.enhanceContext(ctx), func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
 func (e *baseExporter) enhanceContext(ctx context.Context) context.Context {
 	if e.metadata.Len() > 0 {
 		return metadata.NewOutgoingContext(ctx, e.metadata)
@@ -667,6 +667,55 @@ func TestSendTracesOnResourceExhaustion(t *testing.T) {
 	}, 10*time.Second, 5*time.Millisecond, "Should retry if RetryInfo is included into status details by the server.")
 }
 
+func TestSendTracesFailover(t *testing.T) {
+	primaryLn, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	primary, _ := otlpTracesReceiverOnGRPCServer(primaryLn, false)
+	defer primary.srv.GracefulStop()
+	primary.setExportError(status.Error(codes.Unavailable, "unavailable"))
+	backupLn, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	backup, _ := otlpTracesReceiverOnGRPCServer(backupLn, false)
+	defer backup.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.Enabled = false
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: primaryLn.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	cfg.Failover = FailoverConfig{
+		Endpoints:        []string{backupLn.Addr().String()},
+		FailureThreshold: 1,
+		ProbeInterval:    20 * time.Millisecond,
+	}
+	exp, err := factory.CreateTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	// The traces fail over to the backup endpoint, which receives them while the primary one is unhealthy.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 2, backup.totalItems.Load())
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 4, backup.totalItems.Load())
+	// Only the first traces were sent to the primary endpoint, the probes are empty.
+	assert.EqualValues(t, 2, primary.totalItems.Load())
+
+	// The traces fail back to the primary endpoint once it is probed healthy.
+	primary.setExportError(nil)
+	assert.Eventually(t, func() bool {
+		assert.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+		return primary.totalItems.Load() > 2
+	}, 10*time.Second, 20*time.Millisecond)
+}
+
 func startServerAndMakeRequest(t *testing.T, exp exporter.Traces, td ptrace.Traces, ln net.Listener) {
 	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
 	defer rcv.srv.GracefulStop()
@@ -795,3 +844,86 @@ func TestSendLogData(t *testing.T) {
 	assert.Len(t, observed.FilterLevelExact(zap.WarnLevel).All(), 1)
 	assert.Contains(t, observed.FilterLevelExact(zap.WarnLevel).All()[0].Message, "Partial success")
 }
+
+func TestExportContextDeadline(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         func(*Config)
+		incoming       time.Duration
+		cancelIncoming bool
+		expected       time.Duration
+		expectCanceled bool
+	}{
+		{
+			name:     "propagate",
+			config:   func(*Config) {},
+			incoming: time.Second,
+			expected: time.Second,
+		},
+		{
+			name:           "propagate cancellation",
+			config:         func(*Config) {},
+			incoming:       time.Second,
+			cancelIncoming: true,
+			expected:       time.Second,
+			expectCanceled: true,
+		},
+		{
+			name:     "margin",
+			config:   func(cfg *Config) { cfg.DeadlineMargin = 100 * time.Millisecond },
+			incoming: time.Second,
+			expected: 900 * time.Millisecond,
+		},
+		{
+			name:     "rpc timeout",
+			config:   func(cfg *Config) { cfg.RPCTimeout = 200 * time.Millisecond },
+			incoming: time.Second,
+			expected: 200 * time.Millisecond,
+		},
+		{
+			name: "no propagation",
+			config: func(cfg *Config) {
+				cfg.PropagateDeadline = false
+				cfg.DeadlineMargin = 100 * time.Millisecond
+			},
+			incoming:       time.Second,
+			cancelIncoming: true,
+			expected:       5 * time.Second,
+		},
+		{
+			name: "no propagation with rpc timeout",
+			config: func(cfg *Config) {
+				cfg.PropagateDeadline = false
+				cfg.RPCTimeout = 10 * time.Second
+			},
+			incoming: time.Second,
+			expected: 5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Timeout = 5 * time.Second
+			tt.config(cfg)
+			exp := newExporter(cfg, exportertest.NewNopSettings())
+
+			now := time.Now()
+			incoming, cancelIncoming := context.WithDeadline(context.Background(), now.Add(tt.incoming))
+			defer cancelIncoming()
+			ctx, cancel := exp.exportContext(incoming)
+			defer cancel()
+			if tt.cancelIncoming {
+				cancelIncoming()
+			}
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.WithinDuration(t, now.Add(tt.expected), deadline, 50*time.Millisecond)
+			if tt.expectCanceled {
+				assert.ErrorIs(t, ctx.Err(), context.Canceled)
+			} else {
+				assert.NoError(t, ctx.Err())
+			}
+		})
+	}
+}
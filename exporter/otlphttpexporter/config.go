@@ -7,6 +7,8 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"net/url"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -60,6 +62,52 @@ type Config struct {
 
 	// The encoding to export telemetry (default: "proto")
 	Encoding EncodingType `mapstructure:"encoding"`
+
+	// RetryableStatusCodes lists the HTTP status codes for which a failed request is retried.
+	// If empty, the status codes defined as retryable by the OTLP specification are used:
+	// 429, 502, 503 and 504.
+	RetryableStatusCodes []int `mapstructure:"retryable_status_codes"`
+
+	// Hedging configures sending a duplicate of slow requests to a second endpoint.
+	Hedging HedgingConfig `mapstructure:"hedging"`
+
+	// Failover configures the endpoints the data is sent to while the endpoint is unhealthy.
+	Failover FailoverConfig `mapstructure:"failover"`
+}
+
+// HedgingConfig defines the configuration for request hedging. When enabled, a request which did not
+// complete after Delay is also sent to Endpoint, and the first successful response is used.
+// Hedging trades additional load on the backends for lower tail latency, the backend must be able to
+// handle duplicated data.
+type HedgingConfig struct {
+	// Enabled enables request hedging.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Delay is how long to wait for a response before sending the hedged request.
+	Delay time.Duration `mapstructure:"delay"`
+
+	// Endpoint is the base URL hedged requests are sent to, the signal path is appended to it
+	// the same way as for the main endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// FailoverConfig defines the failover endpoints of the exporter. An endpoint is unhealthy once FailureThreshold
+// exports to it failed in a row, the data being sent to the next healthy endpoint, and healthy again once an empty
+// export request sent every ProbeInterval succeeds. The failover is disabled when no endpoint is set.
+type FailoverConfig struct {
+	// Endpoints are the base URLs of the failover endpoints, in order of preference. The signal path is
+	// appended to them the same way as for the main endpoint.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// FailureThreshold is the number of consecutive failed exports after which an endpoint is unhealthy.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// ProbeInterval is the interval between the probes of the unhealthy endpoints.
+	ProbeInterval time.Duration `mapstructure:"probe_interval"`
+}
+
+func (fc *FailoverConfig) enabled() bool {
+	return len(fc.Endpoints) > 0
 }
 
 var _ component.Config = (*Config)(nil)
@@ -69,5 +117,40 @@ func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" && cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" && cfg.LogsEndpoint == "" {
 		return errors.New("at least one endpoint must be specified")
 	}
+	for _, code := range cfg.RetryableStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid retryable status code: %d", code)
+		}
+		if code >= 200 && code <= 299 {
+			return fmt.Errorf("successful status code %d cannot be retryable", code)
+		}
+	}
+	if cfg.Hedging.Enabled {
+		if cfg.Hedging.Delay <= 0 {
+			return errors.New("hedging delay must be greater than zero")
+		}
+		if cfg.Hedging.Endpoint == "" {
+			return errors.New("hedging endpoint must be specified")
+		}
+		if _, err := url.Parse(cfg.Hedging.Endpoint); err != nil {
+			return fmt.Errorf("hedging endpoint must be a valid URL: %w", err)
+		}
+	}
+	if cfg.Failover.enabled() {
+		if cfg.Failover.FailureThreshold <= 0 {
+			return errors.New("failover failure_threshold must be greater than zero")
+		}
+		if cfg.Failover.ProbeInterval <= 0 {
+			return errors.New("failover probe_interval must be greater than zero")
+		}
+		for _, endpoint := range cfg.Failover.Endpoints {
+			if endpoint == "" {
+				return errors.New("failover endpoints must not be empty")
+			}
+			if _, err := url.Parse(endpoint); err != nil {
+				return fmt.Errorf("failover endpoint must be a valid URL: %w", err)
+			}
+		}
+	}
 	return nil
 }
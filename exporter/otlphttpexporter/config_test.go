@@ -45,13 +45,25 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				FullJitter:          true,
 			},
 			QueueConfig: exporterhelper.QueueSettings{
 				Enabled:      true,
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
-			Encoding: EncodingProto,
+			Encoding:             EncodingProto,
+			RetryableStatusCodes: []int{429, 500, 503},
+			Hedging: HedgingConfig{
+				Enabled:  true,
+				Delay:    200 * time.Millisecond,
+				Endpoint: "https://5.6.7.8:1234",
+			},
+			Failover: FailoverConfig{
+				Endpoints:        []string{"https://9.10.11.12:1234"},
+				FailureThreshold: 5,
+				ProbeInterval:    30 * time.Second,
+			},
 			ClientConfig: confighttp.ClientConfig{
 				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -128,3 +140,99 @@ func TestUnmarshalEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "retryable status codes",
+			modify: func(cfg *Config) {
+				cfg.RetryableStatusCodes = []int{500, 503}
+			},
+		},
+		{
+			name: "invalid retryable status code",
+			modify: func(cfg *Config) {
+				cfg.RetryableStatusCodes = []int{600}
+			},
+			errMsg: "invalid retryable status code: 600",
+		},
+		{
+			name: "successful retryable status code",
+			modify: func(cfg *Config) {
+				cfg.RetryableStatusCodes = []int{204}
+			},
+			errMsg: "successful status code 204 cannot be retryable",
+		},
+		{
+			name: "hedging",
+			modify: func(cfg *Config) {
+				cfg.Hedging = HedgingConfig{Enabled: true, Delay: time.Second, Endpoint: "https://backup:4318"}
+			},
+		},
+		{
+			name: "hedging without delay",
+			modify: func(cfg *Config) {
+				cfg.Hedging = HedgingConfig{Enabled: true, Endpoint: "https://backup:4318"}
+			},
+			errMsg: "hedging delay must be greater than zero",
+		},
+		{
+			name: "hedging without endpoint",
+			modify: func(cfg *Config) {
+				cfg.Hedging = HedgingConfig{Enabled: true, Delay: time.Second}
+			},
+			errMsg: "hedging endpoint must be specified",
+		},
+		{
+			name: "failover",
+			modify: func(cfg *Config) {
+				cfg.Failover.Endpoints = []string{"https://backup:4318"}
+			},
+		},
+		{
+			name: "failover without failure threshold",
+			modify: func(cfg *Config) {
+				cfg.Failover.Endpoints = []string{"https://backup:4318"}
+				cfg.Failover.FailureThreshold = 0
+			},
+			errMsg: "failover failure_threshold must be greater than zero",
+		},
+		{
+			name: "failover without probe interval",
+			modify: func(cfg *Config) {
+				cfg.Failover.Endpoints = []string{"https://backup:4318"}
+				cfg.Failover.ProbeInterval = 0
+			},
+			errMsg: "failover probe_interval must be greater than zero",
+		},
+		{
+			name: "failover with empty endpoint",
+			modify: func(cfg *Config) {
+				cfg.Failover.Endpoints = []string{""}
+			},
+			errMsg: "failover endpoints must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Endpoint = "https://localhost:4318"
+			tt.modify(cfg)
+			err := component.ValidateConfig(cfg)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
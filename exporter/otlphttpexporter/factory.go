@@ -32,6 +32,11 @@ func NewFactory() exporter.Factory {
 	)
 }
 
+const (
+	defaultFailoverFailureThreshold = 3
+	defaultFailoverProbeInterval    = 10 * time.Second
+)
+
 func createDefaultConfig() component.Config {
 	return &Config{
 		RetryConfig: configretry.NewDefaultBackOffConfig(),
@@ -46,6 +51,10 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		Failover: FailoverConfig{
+			FailureThreshold: defaultFailoverFailureThreshold,
+			ProbeInterval:    defaultFailoverProbeInterval,
+		},
 	}
 }
 
@@ -60,11 +69,37 @@ func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string)
 	case oCfg.Endpoint == "":
 		return "", fmt.Errorf("either endpoint or %s_endpoint must be specified", signalName)
 	default:
-		if strings.HasSuffix(oCfg.Endpoint, "/") {
-			return oCfg.Endpoint + "v1/" + signalName, nil
-		}
-		return oCfg.Endpoint + "/v1/" + signalName, nil
+		return appendSignalPath(oCfg.Endpoint, signalName), nil
+	}
+}
+
+// composeHedgeURL returns the URL hedged requests for the given signal are sent to,
+// or an empty string if hedging is disabled.
+func composeHedgeURL(oCfg *Config, signalName string) string {
+	if !oCfg.Hedging.Enabled {
+		return ""
+	}
+	return appendSignalPath(oCfg.Hedging.Endpoint, signalName)
+}
+
+// composeFailoverURLs returns the URLs the data of the given signal is sent to while the
+// endpoint is unhealthy, or nil if the failover is disabled.
+func composeFailoverURLs(oCfg *Config, signalName string) []string {
+	if !oCfg.Failover.enabled() {
+		return nil
+	}
+	urls := make([]string, 0, len(oCfg.Failover.Endpoints))
+	for _, endpoint := range oCfg.Failover.Endpoints {
+		urls = append(urls, appendSignalPath(endpoint, signalName))
+	}
+	return urls
+}
+
+func appendSignalPath(endpoint string, signalName string) string {
+	if strings.HasSuffix(endpoint, "/") {
+		return endpoint + "v1/" + signalName
 	}
+	return endpoint + "/v1/" + signalName
 }
 
 func createTracesExporter(
@@ -82,10 +117,14 @@ func createTracesExporter(
 	if err != nil {
 		return nil, err
 	}
+	oce.tracesHedgeURL = composeHedgeURL(oCfg, "traces")
+	oce.failoverURLs = composeFailoverURLs(oCfg, "traces")
+	oce.probe = oce.probeTraces
 
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
 		oce.pushTraces,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -108,10 +147,14 @@ func createMetricsExporter(
 	if err != nil {
 		return nil, err
 	}
+	oce.metricsHedgeURL = composeHedgeURL(oCfg, "metrics")
+	oce.failoverURLs = composeFailoverURLs(oCfg, "metrics")
+	oce.probe = oce.probeMetrics
 
 	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
 		oce.pushMetrics,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -134,10 +177,14 @@ func createLogsExporter(
 	if err != nil {
 		return nil, err
 	}
+	oce.logsHedgeURL = composeHedgeURL(oCfg, "logs")
+	oce.failoverURLs = composeFailoverURLs(oCfg, "logs")
+	oce.probe = oce.probeLogs
 
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
 		oce.pushLogs,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
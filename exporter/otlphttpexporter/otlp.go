@@ -12,7 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
-	"strconv"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/internal/httphelper"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -39,14 +40,23 @@ type baseExporter struct {
 	tracesURL  string
 	metricsURL string
 	logsURL    string
-	logger     *zap.Logger
-	settings   component.TelemetrySettings
+	// URLs hedged requests are sent to, empty if hedging is disabled.
+	tracesHedgeURL  string
+	metricsHedgeURL string
+	logsHedgeURL    string
+	// URLs of the failover endpoints of the signal, nil if the failover is disabled.
+	failoverURLs []string
+	// failover sends the data to the healthy endpoints, nil if the failover is disabled.
+	failover *failover.Group
+	// probe sends an empty request of the signal of the exporter to an endpoint, to probe its health.
+	probe    func(ctx context.Context, endpoint int) error
+	logger   *zap.Logger
+	settings component.TelemetrySettings
 	// Default user-agent header.
 	userAgent string
 }
 
 const (
-	headerRetryAfter         = "Retry-After"
 	maxHTTPResponseReadBytes = 64 * 1024
 
 	jsonContentType     = "application/json"
@@ -84,9 +94,45 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	e.client = client
+	if len(e.failoverURLs) > 0 {
+		e.failover = failover.NewGroup(failover.Settings{
+			Endpoints:        append([]string{e.signalURL()}, e.failoverURLs...),
+			FailureThreshold: e.config.Failover.FailureThreshold,
+			ProbeInterval:    e.config.Failover.ProbeInterval,
+			Logger:           e.logger,
+		}, e.probe)
+		e.failover.Start()
+	}
+	return nil
+}
+
+func (e *baseExporter) shutdown(context.Context) error {
+	if e.failover != nil {
+		e.failover.Shutdown()
+	}
 	return nil
 }
 
+// signalURL returns the URL of the signal of the exporter, only one of them being set.
+func (e *baseExporter) signalURL() string {
+	switch {
+	case e.tracesURL != "":
+		return e.tracesURL
+	case e.metricsURL != "":
+		return e.metricsURL
+	default:
+		return e.logsURL
+	}
+}
+
+// endpointURL returns the URL of the given endpoint, the first one being url and the next ones the failover URLs.
+func (e *baseExporter) endpointURL(url string, endpoint int) string {
+	if endpoint == 0 {
+		return url
+	}
+	return e.failoverURLs[endpoint-1]
+}
+
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	tr := ptraceotlp.NewExportRequestFromTraces(td)
 
@@ -105,7 +151,7 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.tracesURL, request, e.tracesPartialSuccessHandler)
+	return e.exportFailover(ctx, e.tracesURL, e.tracesHedgeURL, request, e.tracesPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
@@ -125,7 +171,7 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	return e.export(ctx, e.metricsURL, request, e.metricsPartialSuccessHandler)
+	return e.exportFailover(ctx, e.metricsURL, e.metricsHedgeURL, request, e.metricsPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
@@ -146,10 +192,115 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.logsURL, request, e.logsPartialSuccessHandler)
+	return e.exportFailover(ctx, e.logsURL, e.logsHedgeURL, request, e.logsPartialSuccessHandler)
+}
+
+// exportFailover sends the request to url, or to the first healthy endpoint if the failover is enabled.
+// The requests sent to the failover endpoints are hedged the same way as the ones sent to url.
+func (e *baseExporter) exportFailover(ctx context.Context, url string, hedgeURL string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+	if e.failover == nil {
+		return e.export(ctx, url, hedgeURL, request, partialSuccessHandler)
+	}
+	return e.failover.Export(ctx, func(ctx context.Context, endpoint int) error {
+		return e.export(ctx, e.endpointURL(url, endpoint), hedgeURL, request, partialSuccessHandler)
+	})
+}
+
+// probeTraces, probeMetrics and probeLogs send an empty export request to the endpoint, bounded by ctx only.
+func (e *baseExporter) probeTraces(ctx context.Context, endpoint int) error {
+	return e.sendProbe(ctx, e.endpointURL(e.tracesURL, endpoint), ptraceotlp.NewExportRequest(), e.tracesPartialSuccessHandler)
+}
+
+func (e *baseExporter) probeMetrics(ctx context.Context, endpoint int) error {
+	return e.sendProbe(ctx, e.endpointURL(e.metricsURL, endpoint), pmetricotlp.NewExportRequest(), e.metricsPartialSuccessHandler)
+}
+
+func (e *baseExporter) probeLogs(ctx context.Context, endpoint int) error {
+	return e.sendProbe(ctx, e.endpointURL(e.logsURL, endpoint), plogotlp.NewExportRequest(), e.logsPartialSuccessHandler)
+}
+
+type exportRequest interface {
+	MarshalProto() ([]byte, error)
+	MarshalJSON() ([]byte, error)
+}
+
+func (e *baseExporter) sendProbe(ctx context.Context, url string, tr exportRequest, partialSuccessHandler partialSuccessHandler) error {
+	var err error
+	var request []byte
+	switch e.config.Encoding {
+	case EncodingJSON:
+		request, err = tr.MarshalJSON()
+	case EncodingProto:
+		request, err = tr.MarshalProto()
+	default:
+		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
+	}
+
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.send(ctx, url, request, partialSuccessHandler)
+}
+
+// export sends the request to url. If hedgeURL is set and no response is received from url
+// within the hedging delay, the request is also sent to hedgeURL, and the first successful
+// response is used. If both requests fail, the error of the request sent to url is returned.
+func (e *baseExporter) export(ctx context.Context, url string, hedgeURL string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+	if hedgeURL == "" {
+		return e.send(ctx, url, request, partialSuccessHandler)
+	}
+
+	// Cancel the outstanding request once a response is used.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		err    error
+		hedged bool
+		// body and contentType hold the successful response, handled once for the response which is used.
+		body        []byte
+		contentType string
+	}
+	results := make(chan result, 2)
+	attempt := func(url string, hedged bool) {
+		res := result{hedged: hedged}
+		res.err = e.send(ctx, url, request, func(body []byte, contentType string) error {
+			res.body, res.contentType = body, contentType
+			return nil
+		})
+		results <- res
+	}
+	go attempt(url, false)
+
+	timer := time.NewTimer(e.config.Hedging.Delay)
+	defer timer.Stop()
+	select {
+	case res := <-results:
+		if res.err != nil {
+			return res.err
+		}
+		return partialSuccessHandler(res.body, res.contentType)
+	case <-timer.C:
+	}
+
+	e.logger.Debug("Sending hedged request", zap.String("url", hedgeURL))
+	go attempt(hedgeURL, true)
+
+	first := <-results
+	if first.err == nil {
+		return partialSuccessHandler(first.body, first.contentType)
+	}
+	second := <-results
+	if second.err == nil {
+		return partialSuccessHandler(second.body, second.contentType)
+	}
+	if first.hedged {
+		return second.err
+	}
+	return first.err
 }
 
-func (e *baseExporter) export(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+func (e *baseExporter) send(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
@@ -198,26 +349,30 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	}
 	formattedErr = httphelper.NewStatusFromMsgAndHTTPCode(errString, resp.StatusCode).Err()
 
-	if isRetryableStatusCode(resp.StatusCode) {
-		// A retry duration of 0 seconds will trigger the default backoff policy
-		// of our caller (retry handler).
-		retryAfter := 0
-
+	if e.isRetryableStatusCode(resp.StatusCode) {
 		// Check if the server is overwhelmed.
 		// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#otlphttp-throttling
 		isThrottleError := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
-		if val := resp.Header.Get(headerRetryAfter); isThrottleError && val != "" {
-			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
-			}
+		if isThrottleError {
+			return exporterhelper.NewThrottleRetryFromHeader(formattedErr, resp.Header)
 		}
 
-		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
+		// A retry duration of 0 seconds will trigger the default backoff policy
+		// of our caller (retry handler).
+		return exporterhelper.NewThrottleRetry(formattedErr, 0)
 	}
 
 	return consumererror.NewPermanent(formattedErr)
 }
 
+// Determine if the status code is retryable, either according to the configuration or the specification.
+func (e *baseExporter) isRetryableStatusCode(code int) bool {
+	if len(e.config.RetryableStatusCodes) > 0 {
+		return slices.Contains(e.config.RetryableStatusCodes, code)
+	}
+	return isRetryableStatusCode(code)
+}
+
 // Determine if the status code is retryable according to the specification.
 // For more, see https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures-1
 func isRetryableStatusCode(code int) bool {
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -242,6 +243,227 @@ func TestErrorResponses(t *testing.T) {
 	}
 }
 
+func TestRetryableStatusCodes(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseStatus int
+		retryable      bool
+	}{
+		{
+			name:           "configured",
+			responseStatus: http.StatusInternalServerError,
+			retryable:      true,
+		},
+		{
+			name:           "not configured",
+			responseStatus: http.StatusServiceUnavailable,
+			retryable:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+				writer.WriteHeader(test.responseStatus)
+			})
+			defer srv.Close()
+
+			cfg := &Config{
+				Encoding:             EncodingProto,
+				TracesEndpoint:       fmt.Sprintf("%s/v1/traces", srv.URL),
+				RetryableStatusCodes: []int{http.StatusInternalServerError},
+			}
+			exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			err = exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+			require.Error(t, err)
+			assert.Equal(t, !test.retryable, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestHedging(t *testing.T) {
+	tests := []struct {
+		name          string
+		primaryDelay  time.Duration
+		primaryStatus int
+		hedgeStatus   int
+		expectHedge   bool
+		expectErr     bool
+	}{
+		{
+			name:          "fast primary",
+			primaryStatus: http.StatusOK,
+			hedgeStatus:   http.StatusOK,
+		},
+		{
+			name:          "slow primary",
+			primaryDelay:  time.Second,
+			primaryStatus: http.StatusOK,
+			hedgeStatus:   http.StatusOK,
+			expectHedge:   true,
+		},
+		{
+			name:          "slow primary failing hedge",
+			primaryDelay:  100 * time.Millisecond,
+			primaryStatus: http.StatusOK,
+			hedgeStatus:   http.StatusBadRequest,
+			expectHedge:   true,
+		},
+		{
+			name:          "both failing",
+			primaryDelay:  100 * time.Millisecond,
+			primaryStatus: http.StatusBadRequest,
+			hedgeStatus:   http.StatusBadRequest,
+			expectHedge:   true,
+			expectErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			primary := createBackend("/v1/traces", func(writer http.ResponseWriter, req *http.Request) {
+				select {
+				case <-time.After(test.primaryDelay):
+				case <-req.Context().Done():
+					return
+				}
+				writer.WriteHeader(test.primaryStatus)
+			})
+			defer primary.Close()
+			var hedged atomic.Bool
+			hedge := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+				hedged.Store(true)
+				writer.WriteHeader(test.hedgeStatus)
+			})
+			defer hedge.Close()
+
+			cfg := &Config{
+				Encoding: EncodingProto,
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: primary.URL,
+				},
+				Hedging: HedgingConfig{
+					Enabled:  true,
+					Delay:    10 * time.Millisecond,
+					Endpoint: hedge.URL,
+				},
+			}
+			exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			err = exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+			if test.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), primary.URL)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectHedge, hedged.Load())
+		})
+	}
+}
+
+func TestHedgingHandlesPartialSuccessOnce(t *testing.T) {
+	primary := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer primary.Close()
+	hedge := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer hedge.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Hedging = HedgingConfig{Enabled: true, Delay: 10 * time.Millisecond, Endpoint: hedge.URL}
+	exp, err := newExporter(cfg, exportertest.NewNopSettings())
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	// The handler is slow enough for both requests to succeed before the outstanding one is cancelled.
+	var handled atomic.Int64
+	handler := func([]byte, string) error {
+		handled.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+	require.NoError(t, exp.export(context.Background(), primary.URL+"/v1/traces", hedge.URL+"/v1/traces", nil, handler))
+	assert.Equal(t, int64(1), handled.Load())
+}
+
+func TestFailover(t *testing.T) {
+	// The probes are empty requests, only the exported traces are counted.
+	var primaryDown atomic.Bool
+	primaryDown.Store(true)
+	var primaryTraces, backupTraces atomic.Int64
+	countTraces := func(counter *atomic.Int64, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		if len(body) > 0 {
+			counter.Add(1)
+		}
+	}
+	primary := createBackend("/v1/traces", func(writer http.ResponseWriter, req *http.Request) {
+		if primaryDown.Load() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		countTraces(&primaryTraces, req)
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer primary.Close()
+	backup := createBackend("/v1/traces", func(writer http.ResponseWriter, req *http.Request) {
+		countTraces(&backupTraces, req)
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer backup.Close()
+
+	cfg := &Config{
+		Encoding: EncodingProto,
+		ClientConfig: confighttp.ClientConfig{
+			Endpoint: primary.URL,
+		},
+		Failover: FailoverConfig{
+			Endpoints:        []string{backup.URL},
+			FailureThreshold: 1,
+			ProbeInterval:    10 * time.Millisecond,
+		},
+	}
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	// The primary endpoint fails, the traces are sent to the backup one, which then receives the next ones.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, int64(2), backupTraces.Load())
+
+	// Once the primary endpoint answers the probes, the traces fail back to it.
+	primaryDown.Store(false)
+	assert.Eventually(t, func() bool {
+		return assert.NoError(t, exp.ConsumeTraces(context.Background(), td)) && primaryTraces.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	backupCount := backupTraces.Load()
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, backupCount, backupTraces.Load())
+}
+
 func TestErrorResponseInvalidResponseBody(t *testing.T) {
 	resp := &http.Response{
 		StatusCode:    400,
@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginexporter // import "go.opentelemetry.io/collector/exporter/pluginexporter"
+
+import (
+	"go.opentelemetry.io/collector/internal/plugin/host"
+)
+
+// Config configures the plugin exporter: the process of the plugin and the configuration of the component it runs.
+type Config = host.Config
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package pluginexporter runs an exporter served by a plugin executable, out of the collector process.
+package pluginexporter // import "go.opentelemetry.io/collector/exporter/pluginexporter"
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginexporter // import "go.opentelemetry.io/collector/exporter/pluginexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/plugin/host"
+	"go.opentelemetry.io/collector/exporter/pluginexporter/internal/metadata"
+)
+
+// NewFactory returns an exporter.Factory for the plugin exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTraces, metadata.TracesStability),
+		exporter.WithMetrics(createMetrics, metadata.MetricsStability),
+		exporter.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	cfg := host.NewDefaultConfig()
+	return &cfg
+}
+
+// createTraces creates an exporter running the plugin in a traces pipeline.
+func createTraces(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindExporter, component.DataTypeTraces, nil), nil
+}
+
+// createMetrics creates an exporter running the plugin in a metrics pipeline.
+func createMetrics(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindExporter, component.DataTypeMetrics, nil), nil
+}
+
+// createLogs creates an exporter running the plugin in a logs pipeline.
+func createLogs(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindExporter, component.DataTypeLogs, nil), nil
+}
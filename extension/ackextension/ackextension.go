@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "go.opentelemetry.io/collector/extension/ackextension"
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+)
+
+type ackExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	maxPending int64
+	pending    atomic.Int64
+}
+
+var _ ack.Extension = (*ackExtension)(nil)
+
+func newAckExtension(cfg *Config) *ackExtension {
+	return &ackExtension{maxPending: int64(cfg.MaxPending)}
+}
+
+// NewAck returns the Ack of a request, or an error if too many requests are waiting for their acknowledgment.
+func (e *ackExtension) NewAck(_ context.Context, mode ack.Mode) (*ack.Ack, error) {
+	if e.pending.Add(1) > e.maxPending {
+		e.pending.Add(-1)
+		return nil, fmt.Errorf("too many requests waiting for their acknowledgment, max_pending is %d", e.maxPending)
+	}
+	return ack.New(mode, func(error) {
+		e.pending.Add(-1)
+	}), nil
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+)
+
+func TestNewAckMaxPending(t *testing.T) {
+	ext := newAckExtension(&Config{MaxPending: 1})
+
+	a, err := ext.NewAck(context.Background(), ack.ModeExported)
+	require.NoError(t, err)
+	assert.Equal(t, ack.ModeExported, a.Mode())
+
+	_, err = ext.NewAck(context.Background(), ack.ModeExported)
+	require.Error(t, err)
+
+	ack.Release(nil, a)
+	a, err = ext.NewAck(context.Background(), ack.ModeEnqueued)
+	require.NoError(t, err)
+	ack.Release(nil, a)
+	assert.Zero(t, ext.pending.Load())
+}
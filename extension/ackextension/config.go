@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "go.opentelemetry.io/collector/extension/ackextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errInvalidMaxPending = errors.New("max_pending must be greater than zero")
+
+// Config defines the configuration of the ack extension.
+type Config struct {
+	// MaxPending is the maximum number of requests waiting for their acknowledgment.
+	// The receivers refuse the requests received above this limit.
+	MaxPending int `mapstructure:"max_pending"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxPending <= 0 {
+		return errInvalidMaxPending
+	}
+	return nil
+}
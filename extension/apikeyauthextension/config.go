@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension // import "go.opentelemetry.io/collector/extension/apikeyauthextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var (
+	errNoHeaderProvided = errors.New("no header provided in the API key auth configuration")
+	errNoKeysProvided   = errors.New("neither keys nor keys_file provided in the API key auth configuration")
+	errEmptyKey         = errors.New("empty key provided in the API key auth configuration")
+)
+
+// Config defines the configuration of the API key server authenticator.
+type Config struct {
+	// Header is the name of the header carrying the API key, "X-API-Key" by default.
+	// It is matched case-insensitively.
+	Header string `mapstructure:"header"`
+
+	// Keys is the list of the accepted API keys.
+	Keys []configopaque.String `mapstructure:"keys"`
+
+	// KeysFile is the path of a file holding the accepted API keys, one per line.
+	// Empty lines and lines starting with '#' are ignored. The keys are reloaded
+	// when the file changes, and accepted in addition to Keys.
+	KeysFile string `mapstructure:"keys_file"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Header == "" {
+		return errNoHeaderProvided
+	}
+	if len(cfg.Keys) == 0 && cfg.KeysFile == "" {
+		return errNoKeysProvided
+	}
+	for _, key := range cfg.Keys {
+		if key == "" {
+			return errEmptyKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package apikeyauthextension implements a server authenticator for HTTP and gRPC servers,
+// accepting the requests carrying one of the configured API keys in a header. The keys can
+// be read from a file, reloaded when the file changes.
+package apikeyauthextension // import "go.opentelemetry.io/collector/extension/apikeyauthextension"
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension // import "go.opentelemetry.io/collector/extension/apikeyauthextension"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var (
+	errMissingHeader = errors.New("missing or empty API key header")
+	errInvalidKey    = errors.New("invalid API key")
+)
+
+// keySet holds the digests of the accepted keys, so that looking up a key
+// does not leak through its timing how much of it matches an accepted key.
+type keySet map[[sha256.Size]byte]struct{}
+
+// apiKeyAuth authenticates the requests carrying one of the configured keys,
+// reloading the keys of the keys file when it changes.
+type apiKeyAuth struct {
+	header     string
+	staticKeys []configopaque.String
+	keysFile   string
+	logger     *zap.Logger
+
+	keys atomic.Pointer[keySet]
+
+	watcher    *fsnotify.Watcher
+	shutdownCH chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newAPIKeyAuth(cfg *Config, logger *zap.Logger) *apiKeyAuth {
+	return &apiKeyAuth{
+		header:     cfg.Header,
+		staticKeys: cfg.Keys,
+		keysFile:   cfg.KeysFile,
+		logger:     logger,
+	}
+}
+
+func (a *apiKeyAuth) start(context.Context, component.Host) error {
+	if a.keysFile == "" {
+		keys, _ := a.loadKeys()
+		a.keys.Store(&keys)
+		return nil
+	}
+
+	keys, err := a.loadKeys()
+	if err != nil {
+		return err
+	}
+	a.keys.Store(&keys)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher to reload the API keys file: %w", err)
+	}
+	if err = watcher.Add(a.keysFile); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to add the API keys file to watcher: %w", err)
+	}
+	a.watcher = watcher
+	a.shutdownCH = make(chan struct{})
+	a.wg.Add(1)
+	go a.handleWatcherEvents()
+	return nil
+}
+
+func (a *apiKeyAuth) shutdown(context.Context) error {
+	if a.shutdownCH == nil {
+		return nil
+	}
+	close(a.shutdownCH)
+	a.wg.Wait()
+	a.shutdownCH = nil
+	return a.watcher.Close()
+}
+
+func (a *apiKeyAuth) handleWatcherEvents() {
+	defer a.wg.Done()
+	for {
+		select {
+		case <-a.shutdownCH:
+			return
+		case err, ok := <-a.watcher.Errors:
+			if !ok {
+				return
+			}
+			a.logger.Warn("Error watching the API keys file", zap.String("path", a.keysFile), zap.Error(err))
+		case event, ok := <-a.watcher.Events:
+			if !ok {
+				return
+			}
+			// The file is replaced rather than written when updated by renaming another file over it,
+			// like Kubernetes does for the files of the mounted ConfigMaps and Secrets, so the watch has
+			// to be moved to the new file.
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) || event.Has(fsnotify.Chmod) {
+				_ = a.watcher.Remove(event.Name)
+				if err := a.watcher.Add(a.keysFile); err != nil {
+					a.logger.Warn("Failed to watch the API keys file", zap.String("path", a.keysFile), zap.Error(err))
+				}
+				a.reload()
+			}
+			if event.Has(fsnotify.Write) {
+				a.reload()
+			}
+		}
+	}
+}
+
+// reload replaces the accepted keys with the ones of the keys file,
+// keeping the previous keys when the file cannot be read.
+func (a *apiKeyAuth) reload() {
+	keys, err := a.loadKeys()
+	if err != nil {
+		a.logger.Warn("Failed to reload the API keys file, keeping the previous keys", zap.String("path", a.keysFile), zap.Error(err))
+		return
+	}
+	a.keys.Store(&keys)
+	a.logger.Info("Reloaded the API keys file", zap.String("path", a.keysFile), zap.Int("keys", len(keys)))
+}
+
+// loadKeys returns the configured keys and the keys of the keys file.
+func (a *apiKeyAuth) loadKeys() (keySet, error) {
+	keys := make(keySet, len(a.staticKeys))
+	for _, key := range a.staticKeys {
+		keys[sha256.Sum256([]byte(key))] = struct{}{}
+	}
+	if a.keysFile == "" {
+		return keys, nil
+	}
+
+	content, err := os.ReadFile(a.keysFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the API keys file: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys[sha256.Sum256([]byte(line))] = struct{}{}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the API keys file: %w", err)
+	}
+	return keys, nil
+}
+
+func (a *apiKeyAuth) authenticate(ctx context.Context, sources map[string][]string) (context.Context, error) {
+	key := strings.TrimSpace(getHeader(sources, a.header))
+	if key == "" {
+		return ctx, errMissingHeader
+	}
+	keys := a.keys.Load()
+	if keys == nil {
+		return ctx, errInvalidKey
+	}
+	if _, ok := (*keys)[sha256.Sum256([]byte(key))]; !ok {
+		return ctx, errInvalidKey
+	}
+	return ctx, nil
+}
+
+// getHeader returns the first value of the header, looked up case-insensitively since
+// the HTTP servers canonicalize the header names while the gRPC servers lowercase them.
+func getHeader(sources map[string][]string, name string) string {
+	if values, ok := sources[name]; ok && len(values) > 0 {
+		return values[0]
+	}
+	for key, values := range sources {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
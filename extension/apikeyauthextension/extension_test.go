@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func startServer(t *testing.T, cfg *Config) auth.Server {
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	server, ok := ext.(auth.Server)
+	require.True(t, ok)
+	return server
+}
+
+func authenticate(server auth.Server, key string) error {
+	_, err := server.Authenticate(context.Background(), map[string][]string{"x-api-key": {key}})
+	return err
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Keys = []configopaque.String{"key1", "key2"}
+	server := startServer(t, cfg)
+
+	_, err := server.Authenticate(context.Background(), map[string][]string{"X-Api-Key": {"key1"}})
+	assert.NoError(t, err)
+	assert.NoError(t, authenticate(server, "key2"))
+	assert.ErrorIs(t, authenticate(server, "key3"), errInvalidKey)
+	assert.ErrorIs(t, authenticate(server, ""), errMissingHeader)
+	_, err = server.Authenticate(context.Background(), map[string][]string{"Authorization": {"key1"}})
+	assert.ErrorIs(t, err, errMissingHeader)
+}
+
+func TestKeysFile(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(keysFile, []byte("# the keys of the team A\nkey1\n\n  key2  \n"), 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Keys = []configopaque.String{"static"}
+	cfg.KeysFile = keysFile
+	server := startServer(t, cfg)
+
+	assert.NoError(t, authenticate(server, "key1"))
+	assert.NoError(t, authenticate(server, "key2"))
+	assert.NoError(t, authenticate(server, "static"))
+	assert.ErrorIs(t, authenticate(server, "# the keys of the team A"), errInvalidKey)
+	assert.ErrorIs(t, authenticate(server, "key3"), errInvalidKey)
+
+	// The keys are reloaded when the file is written.
+	require.NoError(t, os.WriteFile(keysFile, []byte("key3\n"), 0600))
+	assert.Eventually(t, func() bool {
+		return authenticate(server, "key3") == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, authenticate(server, "key1"), errInvalidKey)
+	assert.NoError(t, authenticate(server, "static"))
+
+	// The keys are reloaded when another file is renamed over the file.
+	newKeysFile := keysFile + ".new"
+	require.NoError(t, os.WriteFile(newKeysFile, []byte("key4\n"), 0600))
+	require.NoError(t, os.Rename(newKeysFile, keysFile))
+	assert.Eventually(t, func() bool {
+		return authenticate(server, "key4") == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, authenticate(server, "key3"), errInvalidKey)
+
+	// The previous keys are kept when the file is removed.
+	require.NoError(t, os.Remove(keysFile))
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, authenticate(server, "key4"))
+}
+
+func TestKeysFileMissing(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.KeysFile = filepath.Join(t.TempDir(), "missing")
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	assert.ErrorContains(t, ext.Start(context.Background(), componenttest.NewNopHost()), "failed to read the API keys file")
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension // import "go.opentelemetry.io/collector/extension/apikeyauthextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/apikeyauthextension/internal/metadata"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+const defaultHeader = "X-API-Key"
+
+// NewFactory creates a factory for the API key auth extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Header: defaultHeader,
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	ka := newAPIKeyAuth(cfg.(*Config), set.Logger)
+	return auth.NewServer(
+		auth.WithServerStart(ka.start),
+		auth.WithServerAuthenticate(ka.authenticate),
+		auth.WithServerShutdown(ka.shutdown),
+	), nil
+}
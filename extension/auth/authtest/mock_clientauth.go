@@ -15,15 +15,17 @@ import (
 )
 
 var (
-	_            auth.Client = (*MockClient)(nil)
-	errMockError             = errors.New("mock Error")
+	_            auth.Client                     = (*MockClient)(nil)
+	_            auth.ClientTransportCredentials = (*MockClient)(nil)
+	errMockError                                 = errors.New("mock Error")
 )
 
 // MockClient provides a mock implementation of GRPCClient and HTTPClient interfaces
 type MockClient struct {
-	ResultRoundTripper      http.RoundTripper
-	ResultPerRPCCredentials credentials.PerRPCCredentials
-	MustError               bool
+	ResultRoundTripper         http.RoundTripper
+	ResultPerRPCCredentials    credentials.PerRPCCredentials
+	ResultTransportCredentials credentials.TransportCredentials
+	MustError                  bool
 }
 
 // Start for the MockClient does nothing
@@ -53,3 +55,12 @@ func (m *MockClient) PerRPCCredentials() (credentials.PerRPCCredentials, error)
 	}
 	return m.ResultPerRPCCredentials, nil
 }
+
+// TransportCredentials for the MockClient either returns error if the mock authenticator is forced to or
+// returns the supplied resultTransportCredentials.
+func (m *MockClient) TransportCredentials() (credentials.TransportCredentials, error) {
+	if m.MustError {
+		return nil, errMockError
+	}
+	return m.ResultTransportCredentials, nil
+}
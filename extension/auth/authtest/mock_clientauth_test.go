@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func TestNilStartAndShutdown(t *testing.T) {
@@ -126,3 +127,15 @@ func TestMockPerRPCCredential(t *testing.T) {
 		})
 	}
 }
+
+func TestMockTransportCredentials(t *testing.T) {
+	tc := insecure.NewCredentials()
+	m := &MockClient{ResultTransportCredentials: tc}
+	got, err := m.TransportCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, tc, got)
+
+	m.MustError = true
+	_, err = m.TransportCredentials()
+	assert.Error(t, err)
+}
@@ -25,6 +25,15 @@ type Client interface {
 	PerRPCCredentials() (credentials.PerRPCCredentials, error)
 }
 
+// ClientTransportCredentials is an optional interface of the Client authenticators supplying the transport
+// credentials of the gRPC connections, e.g. ALTS or the credentials of a cloud provider. They replace the
+// transport credentials derived from the TLS settings of the gRPC clients using the authenticator as credentials.
+type ClientTransportCredentials interface {
+	// TransportCredentials returns the TransportCredentials securing the gRPC connections,
+	// or nil to keep the ones derived from the TLS settings.
+	TransportCredentials() (credentials.TransportCredentials, error)
+}
+
 // ClientOption represents the possible options for NewClient.
 type ClientOption func(*defaultClient)
 
@@ -48,11 +57,24 @@ func (f ClientPerRPCCredentialsFunc) PerRPCCredentials() (credentials.PerRPCCred
 	return f()
 }
 
+// ClientTransportCredentialsFunc specifies the function that returns the TransportCredentials securing gRPC connections.
+type ClientTransportCredentialsFunc func() (credentials.TransportCredentials, error)
+
+func (f ClientTransportCredentialsFunc) TransportCredentials() (credentials.TransportCredentials, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return f()
+}
+
+var _ ClientTransportCredentials = (*defaultClient)(nil)
+
 type defaultClient struct {
 	component.StartFunc
 	component.ShutdownFunc
 	ClientRoundTripperFunc
 	ClientPerRPCCredentialsFunc
+	ClientTransportCredentialsFunc
 }
 
 // WithClientStart overrides the default `Start` function for a component.Component.
@@ -87,6 +109,14 @@ func WithClientPerRPCCredentials(perRPCCredentialsFunc ClientPerRPCCredentialsFu
 	}
 }
 
+// WithClientTransportCredentials provides a `TransportCredentials` function for this client authenticator.
+// There's no default: the transport credentials are derived from the TLS settings of the gRPC clients.
+func WithClientTransportCredentials(transportCredentialsFunc ClientTransportCredentialsFunc) ClientOption {
+	return func(o *defaultClient) {
+		o.ClientTransportCredentialsFunc = transportCredentialsFunc
+	}
+}
+
 // NewClient returns a Client configured with the provided options.
 func NewClient(options ...ClientOption) Client {
 	bc := &defaultClient{}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -37,6 +38,12 @@ func TestClientDefaultValues(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("transport-credentials", func(t *testing.T) {
+		tc, err := e.(ClientTransportCredentials).TransportCredentials()
+		assert.Nil(t, tc)
+		assert.NoError(t, err)
+	})
+
 	t.Run("shutdown", func(t *testing.T) {
 		err := e.Shutdown(context.Background())
 		assert.NoError(t, err)
@@ -115,5 +122,20 @@ func TestWithPerRPCCredentials(t *testing.T) {
 	assert.True(t, called)
 	assert.NotNil(t, p)
 	assert.NoError(t, err)
+}
 
+func TestWithTransportCredentials(t *testing.T) {
+	called := false
+	e := NewClient(WithClientTransportCredentials(func() (credentials.TransportCredentials, error) {
+		called = true
+		return insecure.NewCredentials(), nil
+	}))
+
+	// test
+	tc, err := e.(ClientTransportCredentials).TransportCredentials()
+
+	// verify
+	assert.True(t, called)
+	assert.NotNil(t, tc)
+	assert.NoError(t, err)
 }
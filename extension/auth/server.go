@@ -25,7 +25,7 @@ type Server interface {
 	// The resulting context should contain the authentication data, such as the principal/username, group membership (if available), and the raw
 	// authentication data (if possible). This will allow other components in the pipeline to make decisions based on that data, such as routing based
 	// on tenancy as determined by the group membership, or passing through the authentication data to the next collector/backend.
-	// The context keys to be used are not defined yet.
+	// The subject, scopes and tenant of the client should be attached to the context with configauth.ContextWithClaims.
 	Authenticate(ctx context.Context, sources map[string][]string) (context.Context, error)
 }
 
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var (
+	errNoHeaderProvided = errors.New("no header provided in the bearer token auth configuration")
+	errNoTokensProvided = errors.New("no tokens provided in the bearer token auth configuration")
+	errEmptyToken       = errors.New("empty token provided in the bearer token auth configuration")
+)
+
+// Config defines the configuration of the bearer token server authenticator.
+type Config struct {
+	// Header is the name of the header carrying the token, "Authorization" by default.
+	// It is matched case-insensitively.
+	Header string `mapstructure:"header"`
+
+	// Scheme is the authentication scheme preceding the token in the header, "Bearer" by default.
+	// When empty, the header value is the token itself.
+	Scheme string `mapstructure:"scheme"`
+
+	// Tokens is the list of the accepted tokens.
+	Tokens []configopaque.String `mapstructure:"tokens"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Header == "" {
+		return errNoHeaderProvided
+	}
+	if len(cfg.Tokens) == 0 {
+		return errNoTokensProvided
+	}
+	for _, token := range cfg.Tokens {
+		if token == "" {
+			return errEmptyToken
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/extension/bearertokenauthextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr error
+	}{
+		{
+			id:          component.NewID(metadata.Type),
+			expectedErr: errNoTokensProvided,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "tokens"),
+			expected: &Config{
+				Header: "Authorization",
+				Scheme: "Bearer",
+				Tokens: []configopaque.String{"token1", "token2"},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Header: "X-Token",
+				Tokens: []configopaque.String{"token1"},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_tokens"),
+			expectedErr: errNoTokensProvided,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_header"),
+			expectedErr: errNoHeaderProvided,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "empty_token"),
+			expectedErr: errEmptyToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package bearertokenauthextension implements a server authenticator for HTTP and gRPC servers,
+// accepting the requests carrying one of a static list of bearer tokens.
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
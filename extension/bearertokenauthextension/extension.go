@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strings"
+)
+
+var (
+	errMissingHeader = errors.New("missing or empty authorization header")
+	errInvalidScheme = errors.New("invalid authorization scheme")
+	errInvalidToken  = errors.New("invalid bearer token")
+)
+
+// bearerTokenAuth authenticates the requests carrying one of the configured tokens.
+type bearerTokenAuth struct {
+	header string
+	scheme string
+	// tokens holds the digests of the accepted tokens, so that looking up a token
+	// does not leak through its timing how much of it matches an accepted token.
+	tokens map[[sha256.Size]byte]struct{}
+}
+
+func newBearerTokenAuth(cfg *Config) *bearerTokenAuth {
+	tokens := make(map[[sha256.Size]byte]struct{}, len(cfg.Tokens))
+	for _, token := range cfg.Tokens {
+		tokens[sha256.Sum256([]byte(token))] = struct{}{}
+	}
+	return &bearerTokenAuth{
+		header: cfg.Header,
+		scheme: cfg.Scheme,
+		tokens: tokens,
+	}
+}
+
+func (b *bearerTokenAuth) authenticate(ctx context.Context, sources map[string][]string) (context.Context, error) {
+	value := getHeader(sources, b.header)
+	if value == "" {
+		return ctx, errMissingHeader
+	}
+
+	token := value
+	if b.scheme != "" {
+		scheme, rest, ok := strings.Cut(value, " ")
+		if !ok || !strings.EqualFold(scheme, b.scheme) {
+			return ctx, errInvalidScheme
+		}
+		token = strings.TrimSpace(rest)
+	}
+
+	if _, ok := b.tokens[sha256.Sum256([]byte(token))]; !ok {
+		return ctx, errInvalidToken
+	}
+	return ctx, nil
+}
+
+// getHeader returns the first value of the header, looked up case-insensitively since
+// the HTTP servers canonicalize the header names while the gRPC servers lowercase them.
+func getHeader(sources map[string][]string, name string) string {
+	if values, ok := sources[name]; ok && len(values) > 0 {
+		return values[0]
+	}
+	for key, values := range sources {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		scheme      string
+		sources     map[string][]string
+		expectedErr error
+	}{
+		{
+			name:    "http",
+			sources: map[string][]string{"Authorization": {"Bearer token1"}},
+		},
+		{
+			name:    "grpc",
+			sources: map[string][]string{"authorization": {"Bearer token2"}},
+		},
+		{
+			name:    "scheme case insensitive",
+			sources: map[string][]string{"Authorization": {"bearer token1"}},
+		},
+		{
+			name:        "missing header",
+			sources:     map[string][]string{"X-Other": {"Bearer token1"}},
+			expectedErr: errMissingHeader,
+		},
+		{
+			name:        "empty header",
+			sources:     map[string][]string{"Authorization": {""}},
+			expectedErr: errMissingHeader,
+		},
+		{
+			name:        "invalid scheme",
+			sources:     map[string][]string{"Authorization": {"Basic token1"}},
+			expectedErr: errInvalidScheme,
+		},
+		{
+			name:        "missing scheme",
+			sources:     map[string][]string{"Authorization": {"token1"}},
+			expectedErr: errInvalidScheme,
+		},
+		{
+			name:        "invalid token",
+			sources:     map[string][]string{"Authorization": {"Bearer token3"}},
+			expectedErr: errInvalidToken,
+		},
+		{
+			name:    "custom header without scheme",
+			header:  "X-Token",
+			sources: map[string][]string{"x-token": {"token1"}},
+		},
+		{
+			name:        "custom header with scheme",
+			header:      "X-Token",
+			sources:     map[string][]string{"X-Token": {"Bearer token1"}},
+			expectedErr: errInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Tokens = []configopaque.String{"token1", "token2"}
+			if tt.header != "" {
+				cfg.Header = tt.header
+				cfg.Scheme = tt.scheme
+			}
+			ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+			server, ok := ext.(auth.Server)
+			require.True(t, ok)
+			_, err = server.Authenticate(context.Background(), tt.sources)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
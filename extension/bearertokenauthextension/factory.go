@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/bearertokenauthextension/internal/metadata"
+)
+
+const (
+	defaultHeader = "Authorization"
+	defaultScheme = "Bearer"
+)
+
+// NewFactory creates a factory for the bearer token auth extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Header: defaultHeader,
+		Scheme: defaultScheme,
+	}
+}
+
+func createExtension(_ context.Context, _ extension.Settings, cfg component.Config) (extension.Extension, error) {
+	ba := newBearerTokenAuth(cfg.(*Config))
+	return auth.NewServer(auth.WithServerAuthenticate(ba.authenticate)), nil
+}
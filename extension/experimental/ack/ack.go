@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ack // import "go.opentelemetry.io/collector/extension/experimental/ack"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/extension"
+)
+
+// Mode defines when the data is acknowledged.
+type Mode string
+
+const (
+	// ModeEnqueued acknowledges the data once it is enqueued in a persistent queue, or exported
+	// by the exporters without persistent queue.
+	ModeEnqueued Mode = "enqueued"
+	// ModeExported acknowledges the data once it is exported.
+	ModeExported Mode = "exported"
+)
+
+// UnmarshalText unmarshals and validates the mode.
+func (m *Mode) UnmarshalText(text []byte) error {
+	switch mode := Mode(text); mode {
+	case ModeEnqueued, ModeExported:
+		*m = mode
+		return nil
+	default:
+		return fmt.Errorf("unknown ack mode %q, must be %q or %q", text, ModeEnqueued, ModeExported)
+	}
+}
+
+// Extension is the interface that the extensions tracking the acknowledgments must implement.
+type Extension interface {
+	extension.Extension
+
+	// NewAck returns the Ack of the data received in a request, or an error if it cannot be
+	// tracked, e.g. when too many acknowledgments are pending.
+	NewAck(ctx context.Context, mode Mode) (*Ack, error)
+}
+
+// Ack is the pending acknowledgment of the data received in a request. It starts held by the
+// receiver, and is completed once all its holds are released.
+type Ack struct {
+	mode   Mode
+	onDone func(error)
+
+	mu    sync.Mutex
+	holds int
+	err   error
+	done  chan struct{}
+}
+
+// New returns an Ack held once, calling onDone, if not nil, with its result once completed.
+// It is used by the implementations of Extension.
+func New(mode Mode, onDone func(error)) *Ack {
+	return &Ack{
+		mode:   mode,
+		onDone: onDone,
+		holds:  1,
+		done:   make(chan struct{}),
+	}
+}
+
+// Mode returns when the data is acknowledged.
+func (a *Ack) Mode() Mode {
+	return a.mode
+}
+
+// Done returns a channel closed once the Ack is completed.
+func (a *Ack) Done() <-chan struct{} {
+	return a.done
+}
+
+// Err returns the first error the Ack was released with, once it is completed.
+func (a *Ack) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+func (a *Ack) retain() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.holds == 0 {
+		// Retaining a completed Ack has no effect, its result is already known.
+		return
+	}
+	a.holds++
+}
+
+func (a *Ack) release(err error) {
+	a.mu.Lock()
+	if a.holds == 0 {
+		a.mu.Unlock()
+		return
+	}
+	if err != nil && a.err == nil {
+		a.err = err
+	}
+	a.holds--
+	completed := a.holds == 0
+	result := a.err
+	a.mu.Unlock()
+	if completed {
+		close(a.done)
+		if a.onDone != nil {
+			a.onDone(result)
+		}
+	}
+}
+
+// Retain holds the acks until they are released, to keep them pending while the data is handed
+// over asynchronously.
+func Retain(acks ...*Ack) {
+	for _, a := range acks {
+		a.retain()
+	}
+}
+
+// Release releases a hold of the acks with the result of the handover of the data. The acks are
+// completed with the first error they are released with.
+func Release(err error, acks ...*Ack) {
+	for _, a := range acks {
+		a.release(err)
+	}
+}
+
+// Hold holds the acks of the context of the data whose mode is one of the given modes, or all
+// of them if no mode is given, and returns the function releasing them. It must be called
+// exactly once, with the result of the handover of the data.
+func Hold(ctx context.Context, modes ...Mode) func(error) {
+	acks := Filter(FromContext(ctx), modes...)
+	if len(acks) == 0 {
+		return func(error) {}
+	}
+	Retain(acks...)
+	return func(err error) {
+		Release(err, acks...)
+	}
+}
+
+// Filter returns the acks whose mode is one of the given modes, or all of them if no mode is given.
+func Filter(acks []*Ack, modes ...Mode) []*Ack {
+	if len(modes) == 0 {
+		return acks
+	}
+	var filtered []*Ack
+	for _, a := range acks {
+		for _, m := range modes {
+			if a.mode == m {
+				filtered = append(filtered, a)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// ErrDropped is the error the acks of the data dropped by a component are released with.
+var ErrDropped = errors.New("the data was dropped before being acknowledged")
+
+type ctxKey struct{}
+
+// NewContext returns a context carrying the acks of the data, in place of the ones of ctx.
+func NewContext(ctx context.Context, acks ...*Ack) context.Context {
+	return context.WithValue(ctx, ctxKey{}, acks)
+}
+
+// FromContext returns the acks of the data carried by the context.
+func FromContext(ctx context.Context) []*Ack {
+	acks, _ := ctx.Value(ctxKey{}).([]*Ack)
+	return acks
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModeUnmarshalText(t *testing.T) {
+	var m Mode
+	require.NoError(t, m.UnmarshalText([]byte("exported")))
+	assert.Equal(t, ModeExported, m)
+	require.NoError(t, m.UnmarshalText([]byte("enqueued")))
+	assert.Equal(t, ModeEnqueued, m)
+	assert.Error(t, m.UnmarshalText([]byte("received")))
+}
+
+func TestAckRelease(t *testing.T) {
+	var result error
+	calls := 0
+	a := New(ModeExported, func(err error) {
+		calls++
+		result = err
+	})
+	assert.Equal(t, ModeExported, a.Mode())
+
+	Retain(a)
+	Release(nil, a)
+	select {
+	case <-a.Done():
+		t.Fatal("ack completed while held")
+	default:
+	}
+
+	errExport := errors.New("export failed")
+	Release(errExport, a)
+	<-a.Done()
+	assert.Equal(t, errExport, a.Err())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, errExport, result)
+
+	// Holding or releasing a completed ack has no effect.
+	Retain(a)
+	Release(errors.New("other"), a)
+	assert.Equal(t, errExport, a.Err())
+	assert.Equal(t, 1, calls)
+}
+
+func TestHold(t *testing.T) {
+	enqueued := New(ModeEnqueued, nil)
+	exported := New(ModeExported, nil)
+	ctx := NewContext(context.Background(), enqueued, exported)
+	assert.Equal(t, []*Ack{enqueued, exported}, FromContext(ctx))
+
+	release := Hold(ctx, ModeExported)
+	Release(nil, enqueued, exported)
+	<-enqueued.Done()
+	select {
+	case <-exported.Done():
+		t.Fatal("held ack completed")
+	default:
+	}
+	release(nil)
+	<-exported.Done()
+	require.NoError(t, exported.Err())
+
+	assert.Empty(t, FromContext(context.Background()))
+	Hold(context.Background())(nil)
+}
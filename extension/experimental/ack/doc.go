@@ -0,0 +1,13 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ack implements the acknowledgment of the data received by the receivers once it is
+// durably enqueued or exported, for the pipelines requiring at-least-once delivery guarantees.
+//
+// The receivers configured to wait for the acknowledgments attach an Ack to the context of the
+// data they pass to the pipeline. The components handing the data over asynchronously, e.g. the
+// batch processor or the sending queue of the exporters, hold the acks of the data until it is
+// handed over to the next consumer, with Hold or Retain and Release. The Ack is completed once the
+// data was consumed by the whole pipeline and all the holds are released, so the receivers can
+// reply to the senders.
+package ack // import "go.opentelemetry.io/collector/extension/experimental/ack"
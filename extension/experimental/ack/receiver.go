@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ack // import "go.opentelemetry.io/collector/extension/experimental/ack"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoExtension    = errors.New("the ack extension must be set")
+	errInvalidTimeout = errors.New("the ack timeout must not be negative")
+)
+
+// ReceiverConfig configures a receiver to reply to the senders only once the data is acknowledged.
+type ReceiverConfig struct {
+	// Extension is the ID of the extension tracking the acknowledgments.
+	Extension component.ID `mapstructure:"extension"`
+	// Mode defines when the data is acknowledged, "enqueued" by default.
+	Mode Mode `mapstructure:"mode"`
+	// Timeout is the maximum time to wait for the acknowledgment, 0 to wait until the request is
+	// canceled.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *ReceiverConfig) Validate() error {
+	if cfg.Extension == (component.ID{}) {
+		return errNoExtension
+	}
+	if cfg.Timeout < 0 {
+		return errInvalidTimeout
+	}
+	return nil
+}
+
+// ToWaiter returns the Waiter waiting for the acknowledgments tracked by the configured extension.
+func (cfg *ReceiverConfig) ToWaiter(extensions map[component.ID]component.Component) (*Waiter, error) {
+	ext, found := extensions[cfg.Extension]
+	if !found {
+		return nil, fmt.Errorf("ack extension %q not found", cfg.Extension)
+	}
+	ackExt, ok := ext.(Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not an ack extension", cfg.Extension)
+	}
+	mode := cfg.Mode
+	if mode == "" {
+		mode = ModeEnqueued
+	}
+	return &Waiter{ext: ackExt, mode: mode, timeout: cfg.Timeout}, nil
+}
+
+// Waiter waits for the acknowledgment of the data passed by a receiver to the pipeline.
+// A nil Waiter does not wait.
+type Waiter struct {
+	ext     Extension
+	mode    Mode
+	timeout time.Duration
+}
+
+// Consume calls consume with a context carrying a new Ack, and waits for the acknowledgment of the
+// data. It returns the error of consume, or the error the data was acknowledged with.
+func (w *Waiter) Consume(ctx context.Context, consume func(context.Context) error) error {
+	if w == nil {
+		return consume(ctx)
+	}
+	a, err := w.ext.NewAck(ctx, w.mode)
+	if err != nil {
+		return err
+	}
+	err = consume(NewContext(ctx, a))
+	Release(err, a)
+	if err != nil {
+		return err
+	}
+
+	var timeout <-chan time.Time
+	if w.timeout > 0 {
+		timer := time.NewTimer(w.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-a.Done():
+		return a.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return fmt.Errorf("data not acknowledged after %v", w.timeout)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type testExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	err error
+}
+
+func (e *testExtension) NewAck(context.Context, Mode) (*Ack, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return New(ModeEnqueued, nil), nil
+}
+
+var ackID = component.MustNewID("ack")
+
+func TestReceiverConfigValidate(t *testing.T) {
+	require.NoError(t, (&ReceiverConfig{Extension: ackID}).Validate())
+	assert.Equal(t, errNoExtension, (&ReceiverConfig{}).Validate())
+	assert.Equal(t, errInvalidTimeout, (&ReceiverConfig{Extension: ackID, Timeout: -time.Second}).Validate())
+}
+
+func TestReceiverConfigToWaiter(t *testing.T) {
+	cfg := &ReceiverConfig{Extension: ackID}
+	_, err := cfg.ToWaiter(nil)
+	require.Error(t, err)
+	_, err = cfg.ToWaiter(map[component.ID]component.Component{ackID: struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}})
+	require.Error(t, err)
+
+	w, err := cfg.ToWaiter(map[component.ID]component.Component{ackID: &testExtension{}})
+	require.NoError(t, err)
+	assert.Equal(t, ModeEnqueued, w.mode)
+}
+
+func TestWaiterConsume(t *testing.T) {
+	w := &Waiter{ext: &testExtension{}, mode: ModeEnqueued}
+
+	// The data consumed synchronously is acknowledged when consume returns.
+	require.NoError(t, w.Consume(context.Background(), func(ctx context.Context) error {
+		assert.Len(t, FromContext(ctx), 1)
+		return nil
+	}))
+
+	errConsume := errors.New("consume failed")
+	assert.Equal(t, errConsume, w.Consume(context.Background(), func(context.Context) error {
+		return errConsume
+	}))
+
+	// The data handed over asynchronously is acknowledged once released.
+	errExport := errors.New("export failed")
+	assert.Equal(t, errExport, w.Consume(context.Background(), func(ctx context.Context) error {
+		release := Hold(ctx)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			release(errExport)
+		}()
+		return nil
+	}))
+
+	var released func(error)
+	w.timeout = time.Millisecond
+	require.Error(t, w.Consume(context.Background(), func(ctx context.Context) error {
+		released = Hold(ctx)
+		return nil
+	}))
+	released(nil)
+
+	errTooMany := errors.New("too many pending acks")
+	w = &Waiter{ext: &testExtension{err: errTooMany}}
+	assert.Equal(t, errTooMany, w.Consume(context.Background(), func(context.Context) error {
+		t.Fatal("unexpected call")
+		return nil
+	}))
+
+	// A nil Waiter does not wait.
+	w = nil
+	require.NoError(t, w.Consume(context.Background(), func(ctx context.Context) error {
+		Hold(ctx)
+		return nil
+	}))
+}
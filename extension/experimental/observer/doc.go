@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package observer defines the interface of the extensions discovering
+// endpoints, e.g. the pods or the containers of the host.
+package observer // import "go.opentelemetry.io/collector/extension/experimental/observer"
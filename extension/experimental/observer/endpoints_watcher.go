@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observer // import "go.opentelemetry.io/collector/extension/experimental/observer"
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ListFunc lists the endpoints currently discovered by an observer.
+type ListFunc func(ctx context.Context) ([]Endpoint, error)
+
+// EndpointsWatcher implements the ListAndWatch and Unsubscribe methods of the observers listing their endpoints
+// periodically. It notifies the subscribers of the differences between two consecutive lists, the endpoints
+// being compared by their ID.
+type EndpointsWatcher struct {
+	list            ListFunc
+	refreshInterval time.Duration
+	onError         func(error)
+
+	mu        sync.Mutex
+	endpoints map[string]Endpoint
+	notifies  []Notify
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewEndpointsWatcher returns a watcher listing the endpoints every refresh interval once started. The errors of
+// the lists are passed to onError, if set, the endpoints being kept unchanged until the next list.
+func NewEndpointsWatcher(list ListFunc, refreshInterval time.Duration, onError func(error)) *EndpointsWatcher {
+	return &EndpointsWatcher{
+		list:            list,
+		refreshInterval: refreshInterval,
+		onError:         onError,
+		endpoints:       map[string]Endpoint{},
+	}
+}
+
+// Start lists the endpoints, then refreshes them every refresh interval until Shutdown is called.
+func (w *EndpointsWatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(w.refreshInterval)
+		defer ticker.Stop()
+		for {
+			w.refresh(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Shutdown stops refreshing the endpoints and unsubscribes all the subscribers.
+func (w *EndpointsWatcher) Shutdown() {
+	if w.cancel != nil {
+		w.cancel()
+		<-w.done
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notifies = nil
+}
+
+// ListAndWatch calls OnAdd with the endpoints listed so far, then notifies the changes of the endpoints until
+// Unsubscribe is called. The methods of notify must not call ListAndWatch or Unsubscribe.
+func (w *EndpointsWatcher) ListAndWatch(notify Notify) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notifies = append(w.notifies, notify)
+	if len(w.endpoints) == 0 {
+		return
+	}
+	endpoints := make([]Endpoint, 0, len(w.endpoints))
+	for _, e := range w.endpoints {
+		endpoints = append(endpoints, e)
+	}
+	notify.OnAdd(sortedEndpoints(endpoints))
+}
+
+// Unsubscribe stops notifying the changes of the endpoints. Notify is not called once it returns.
+func (w *EndpointsWatcher) Unsubscribe(notify Notify) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notifies = slices.DeleteFunc(w.notifies, func(n Notify) bool { return n == notify })
+}
+
+// refresh lists the endpoints and notifies the subscribers of their changes.
+func (w *EndpointsWatcher) refresh(ctx context.Context) {
+	listed, err := w.list(ctx)
+	if err != nil {
+		if ctx.Err() == nil && w.onError != nil {
+			w.onError(err)
+		}
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	endpoints := make(map[string]Endpoint, len(listed))
+	var added, removed, changed []Endpoint
+	for _, e := range listed {
+		endpoints[e.ID] = e
+		switch old, ok := w.endpoints[e.ID]; {
+		case !ok:
+			added = append(added, e)
+		case old.Type != e.Type || old.Target != e.Target || !maps.Equal(old.Details, e.Details):
+			changed = append(changed, e)
+		}
+	}
+	for id, e := range w.endpoints {
+		if _, ok := endpoints[id]; !ok {
+			removed = append(removed, e)
+		}
+	}
+	w.endpoints = endpoints
+
+	sortedEndpoints(removed)
+	sortedEndpoints(added)
+	sortedEndpoints(changed)
+	for _, n := range w.notifies {
+		if len(removed) > 0 {
+			n.OnRemove(removed)
+		}
+		if len(added) > 0 {
+			n.OnAdd(added)
+		}
+		if len(changed) > 0 {
+			n.OnChange(changed)
+		}
+	}
+}
+
+// sortedEndpoints sorts the endpoints by ID, so they are notified in a deterministic order.
+func sortedEndpoints(endpoints []Endpoint) []Endpoint {
+	slices.SortFunc(endpoints, func(a, b Endpoint) int { return strings.Compare(a.ID, b.ID) })
+	return endpoints
+}
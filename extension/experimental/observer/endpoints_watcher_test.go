@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingNotify records the notifications of an observer.
+type recordingNotify struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (n *recordingNotify) record(kind string, endpoints []Endpoint) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, e := range endpoints {
+		n.events = append(n.events, kind+" "+e.ID+" "+e.Target)
+	}
+}
+
+func (n *recordingNotify) OnAdd(added []Endpoint)      { n.record("add", added) }
+func (n *recordingNotify) OnRemove(removed []Endpoint) { n.record("remove", removed) }
+func (n *recordingNotify) OnChange(changed []Endpoint) { n.record("change", changed) }
+
+func (n *recordingNotify) get() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.events...)
+}
+
+// lister returns the endpoints set by the tests.
+type lister struct {
+	mu        sync.Mutex
+	endpoints []Endpoint
+	err       error
+}
+
+func (l *lister) set(err error, endpoints ...Endpoint) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.endpoints = endpoints
+	l.err = err
+}
+
+func (l *lister) list(context.Context) ([]Endpoint, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.endpoints, l.err
+}
+
+func TestEndpointsWatcher(t *testing.T) {
+	l := &lister{}
+	var errs []error
+	w := NewEndpointsWatcher(l.list, time.Minute, func(err error) { errs = append(errs, err) })
+
+	redis := Endpoint{ID: "redis", Type: EndpointTypePort, Target: "10.0.0.1:6379", Details: map[string]string{"port": "6379"}}
+	web := Endpoint{ID: "web", Type: EndpointTypePort, Target: "10.0.0.2:80"}
+	l.set(nil, web, redis)
+	w.refresh(context.Background())
+
+	// The endpoints listed so far are notified to the new subscribers.
+	first := &recordingNotify{}
+	w.ListAndWatch(first)
+	assert.Equal(t, []string{"add redis 10.0.0.1:6379", "add web 10.0.0.2:80"}, first.get())
+
+	second := &recordingNotify{}
+	w.ListAndWatch(second)
+	movedRedis := redis
+	movedRedis.Target = "10.0.0.3:6379"
+	db := Endpoint{ID: "db", Type: EndpointTypePort, Target: "10.0.0.4:5432"}
+	l.set(nil, db, movedRedis)
+	w.refresh(context.Background())
+	assert.Equal(t, []string{
+		"add redis 10.0.0.1:6379",
+		"add web 10.0.0.2:80",
+		"remove web 10.0.0.2:80",
+		"add db 10.0.0.4:5432",
+		"change redis 10.0.0.3:6379",
+	}, second.get())
+
+	// The endpoints are kept when the list fails.
+	l.set(errors.New("unavailable"))
+	w.refresh(context.Background())
+	assert.Len(t, errs, 1)
+
+	// The details are compared.
+	w.Unsubscribe(first)
+	labeledRedis := movedRedis
+	labeledRedis.Details = map[string]string{"port": "6379", "pod.label.app": "redis"}
+	l.set(nil, db, labeledRedis)
+	w.refresh(context.Background())
+	assert.Equal(t, "change redis 10.0.0.3:6379", second.get()[5])
+	assert.Len(t, first.get(), 5)
+
+	// Unchanged endpoints are not notified.
+	w.refresh(context.Background())
+	assert.Len(t, second.get(), 6)
+}
+
+func TestEndpointsWatcherStartShutdown(t *testing.T) {
+	l := &lister{}
+	l.set(nil, Endpoint{ID: "redis", Target: "10.0.0.1:6379"})
+	w := NewEndpointsWatcher(l.list, time.Millisecond, nil)
+	n := &recordingNotify{}
+	w.ListAndWatch(n)
+	w.Start()
+	assert.Eventually(t, func() bool {
+		return len(n.get()) == 1
+	}, time.Second, time.Millisecond)
+
+	l.set(nil)
+	assert.Eventually(t, func() bool {
+		return len(n.get()) == 2
+	}, time.Second, time.Millisecond)
+	w.Shutdown()
+	assert.Equal(t, []string{"add redis 10.0.0.1:6379", "remove redis 10.0.0.1:6379"}, n.get())
+}
+
+func TestEndpointsWatcherShutdownNotStarted(t *testing.T) {
+	w := NewEndpointsWatcher(func(context.Context) ([]Endpoint, error) { return nil, nil }, time.Minute, nil)
+	w.Shutdown()
+}
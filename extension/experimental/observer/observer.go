@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observer // import "go.opentelemetry.io/collector/extension/experimental/observer"
+
+import (
+	"go.opentelemetry.io/collector/extension"
+)
+
+// EndpointType is the type of the target of an endpoint.
+type EndpointType string
+
+const (
+	// EndpointTypePod is a Kubernetes pod, its target is the IP address of the pod.
+	EndpointTypePod EndpointType = "pod"
+	// EndpointTypePort is a port of a container, its target is the address of the port, e.g. "10.0.0.1:6379".
+	EndpointTypePort EndpointType = "port"
+	// EndpointTypeK8sEndpoint is an endpoint of a Kubernetes service, its target is the address of the endpoint.
+	EndpointTypeK8sEndpoint EndpointType = "k8s.endpoint"
+)
+
+// Endpoint is a target discovered by an observer.
+type Endpoint struct {
+	// ID identifies the endpoint among the endpoints of the observer, it does not change when the endpoint changes.
+	ID string
+	// Type is the type of the target of the endpoint.
+	Type EndpointType
+	// Target is the address of the endpoint, e.g. "10.0.0.1:6379".
+	Target string
+	// Details describe the endpoint, e.g. the name of the port or the labels of the pod.
+	Details map[string]string
+}
+
+// Notify is notified of the changes of the endpoints discovered by an observer.
+// The methods of Notify are called sequentially by the observer.
+type Notify interface {
+	// OnAdd is called with the endpoints discovered since the previous notification.
+	OnAdd(added []Endpoint)
+	// OnRemove is called with the endpoints which disappeared since the previous notification.
+	OnRemove(removed []Endpoint)
+	// OnChange is called with the endpoints whose target or details changed since the previous notification.
+	OnChange(changed []Endpoint)
+}
+
+// Observer is the interface that observer extensions must implement.
+type Observer interface {
+	extension.Extension
+
+	// ListAndWatch calls OnAdd with the endpoints discovered so far, then notifies the changes of the
+	// endpoints until Unsubscribe is called.
+	ListAndWatch(notify Notify)
+
+	// Unsubscribe stops notifying the changes of the endpoints. Notify is not called once it returns.
+	Unsubscribe(notify Notify)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension // import "go.opentelemetry.io/collector/extension/k8sobserverextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// AuthType is the way the observer authenticates with the Kubernetes API server.
+type AuthType string
+
+const (
+	// AuthTypeServiceAccount authenticates with the token of the service account of the pod of the collector.
+	AuthTypeServiceAccount AuthType = "serviceAccount"
+	// AuthTypeNone does not authenticate, e.g. when the API server is reached through "kubectl proxy", or
+	// authenticates with the authenticator extension of the api_server client.
+	AuthTypeNone AuthType = "none"
+)
+
+var (
+	errNothingObserved     = errors.New("at least one of observe_pods and observe_endpoints must be enabled")
+	errNoRefreshInterval   = errors.New("refresh_interval must be positive")
+	errUnsupportedAuthType = fmt.Errorf("auth_type must be %q or %q", AuthTypeServiceAccount, AuthTypeNone)
+)
+
+// Config defines the configuration of the Kubernetes observer.
+type Config struct {
+	// APIServer is the client of the Kubernetes API server. If its endpoint is empty, the API server of the cluster
+	// the collector runs in is used, trusting the CA of the service account unless a CA is set.
+	APIServer confighttp.ClientConfig `mapstructure:"api_server"`
+
+	// AuthType is the way the observer authenticates with the API server, "serviceAccount" by default.
+	AuthType AuthType `mapstructure:"auth_type"`
+
+	// Node, if set, only observes the pods and the endpoints of the given node, e.g. the node of the collector
+	// when it runs as a DaemonSet.
+	Node string `mapstructure:"node"`
+
+	// Namespace, if set, only observes the pods and the endpoints of the given namespace.
+	Namespace string `mapstructure:"namespace"`
+
+	// ObservePods reports the running pods and the ports of their containers, true by default.
+	ObservePods bool `mapstructure:"observe_pods"`
+
+	// ObserveEndpoints reports the endpoints of the services, listed from their endpoint slices.
+	ObserveEndpoints bool `mapstructure:"observe_endpoints"`
+
+	// RefreshInterval is the interval between two lists of the pods and the endpoints, 10s by default.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.AuthType != AuthTypeServiceAccount && cfg.AuthType != AuthTypeNone {
+		return errUnsupportedAuthType
+	}
+	if !cfg.ObservePods && !cfg.ObserveEndpoints {
+		return errNothingObserved
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errNoRefreshInterval
+	}
+	return nil
+}
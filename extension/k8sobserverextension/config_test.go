@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/extension/k8sobserverextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr error
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "node"),
+			expected: func(cfg *Config) {
+				cfg.Node = "node-1"
+				cfg.Namespace = "monitoring"
+				cfg.ObserveEndpoints = true
+				cfg.RefreshInterval = 30 * time.Second
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "proxy"),
+			expected: func(cfg *Config) {
+				cfg.APIServer.Endpoint = "http://localhost:8001"
+				cfg.AuthType = AuthTypeNone
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_auth"),
+			expectedErr: errUnsupportedAuthType,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "nothing"),
+			expectedErr: errNothingObserved,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_refresh"),
+			expectedErr: errNoRefreshInterval,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package k8sobserverextension implements an observer extension discovering the pods, their ports and the
+// endpoints of the services of a Kubernetes cluster, by listing them periodically from the Kubernetes API server.
+package k8sobserverextension // import "go.opentelemetry.io/collector/extension/k8sobserverextension"
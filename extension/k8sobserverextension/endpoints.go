@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension // import "go.opentelemetry.io/collector/extension/k8sobserverextension"
+
+import (
+	"net"
+	"strconv"
+
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+)
+
+// The fields of the Kubernetes objects used by the observer.
+
+type objectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	UID         string            `json:"uid"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type pod struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		NodeName   string      `json:"nodeName"`
+		Containers []container `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase"`
+		PodIP string `json:"podIP"`
+	} `json:"status"`
+}
+
+type container struct {
+	Name  string          `json:"name"`
+	Image string          `json:"image"`
+	Ports []containerPort `json:"ports"`
+}
+
+type containerPort struct {
+	Name          string `json:"name"`
+	ContainerPort int32  `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+type endpointSlice struct {
+	Metadata  objectMeta      `json:"metadata"`
+	Endpoints []sliceEndpoint `json:"endpoints"`
+	Ports     []slicePort     `json:"ports"`
+}
+
+type sliceEndpoint struct {
+	Addresses  []string `json:"addresses"`
+	Conditions struct {
+		Ready *bool `json:"ready"`
+	} `json:"conditions"`
+	NodeName  string `json:"nodeName"`
+	TargetRef *struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	} `json:"targetRef"`
+}
+
+type slicePort struct {
+	Name     string `json:"name"`
+	Port     *int32 `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+const (
+	// serviceNameLabel is the label of the endpoint slices holding the name of their service.
+	serviceNameLabel = "kubernetes.io/service-name"
+	defaultProtocol  = "TCP"
+)
+
+// podEndpoints returns the endpoints of a pod and of the ports of its containers. The pods without an IP address
+// and the terminated pods are skipped.
+func podEndpoints(p pod) []observer.Endpoint {
+	if p.Status.PodIP == "" || p.Status.Phase == "Succeeded" || p.Status.Phase == "Failed" {
+		return nil
+	}
+	id := p.Metadata.Namespace + "/" + p.Metadata.Name
+	details := map[string]string{
+		"namespace": p.Metadata.Namespace,
+		"pod.name":  p.Metadata.Name,
+		"pod.uid":   p.Metadata.UID,
+		"node.name": p.Spec.NodeName,
+	}
+	for k, v := range p.Metadata.Labels {
+		details["pod.label."+k] = v
+	}
+	for k, v := range p.Metadata.Annotations {
+		details["pod.annotation."+k] = v
+	}
+	endpoints := []observer.Endpoint{{
+		ID:      id,
+		Type:    observer.EndpointTypePod,
+		Target:  p.Status.PodIP,
+		Details: details,
+	}}
+
+	for _, c := range p.Spec.Containers {
+		for _, port := range c.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = defaultProtocol
+			}
+			number := strconv.Itoa(int(port.ContainerPort))
+			portDetails := make(map[string]string, len(details)+5)
+			for k, v := range details {
+				portDetails[k] = v
+			}
+			portDetails["container.name"] = c.Name
+			portDetails["container.image"] = c.Image
+			portDetails["port"] = number
+			portDetails["port.name"] = port.Name
+			portDetails["port.protocol"] = protocol
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:      id + "/" + number + "/" + protocol,
+				Type:    observer.EndpointTypePort,
+				Target:  net.JoinHostPort(p.Status.PodIP, number),
+				Details: portDetails,
+			})
+		}
+	}
+	return endpoints
+}
+
+// sliceEndpoints returns an endpoint for every address and port of an endpoint slice. If node is set, only the
+// endpoints of this node are returned.
+func sliceEndpoints(s endpointSlice, node string) []observer.Endpoint {
+	service := s.Metadata.Labels[serviceNameLabel]
+	if service == "" {
+		service = s.Metadata.Name
+	}
+	var endpoints []observer.Endpoint
+	for _, e := range s.Endpoints {
+		if node != "" && e.NodeName != node {
+			continue
+		}
+		// The endpoints whose readiness is unknown are considered ready.
+		ready := e.Conditions.Ready == nil || *e.Conditions.Ready
+		for _, address := range e.Addresses {
+			for _, port := range s.Ports {
+				if port.Port == nil {
+					continue
+				}
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = defaultProtocol
+				}
+				number := strconv.Itoa(int(*port.Port))
+				details := map[string]string{
+					"namespace":      s.Metadata.Namespace,
+					"service.name":   service,
+					"node.name":      e.NodeName,
+					"port":           number,
+					"port.name":      port.Name,
+					"port.protocol":  protocol,
+					"endpoint.ready": strconv.FormatBool(ready),
+				}
+				if e.TargetRef != nil && e.TargetRef.Kind == "Pod" {
+					details["pod.name"] = e.TargetRef.Name
+				}
+				target := net.JoinHostPort(address, number)
+				endpoints = append(endpoints, observer.Endpoint{
+					ID:      s.Metadata.Namespace + "/" + service + "/" + target + "/" + protocol,
+					Type:    observer.EndpointTypeK8sEndpoint,
+					Target:  target,
+					Details: details,
+				})
+			}
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+)
+
+func TestPodEndpoints(t *testing.T) {
+	var p pod
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metadata": {"name": "dns-0", "namespace": "kube-system", "uid": "uid", "annotations": {"scrape": "true"}},
+		"spec": {"nodeName": "node-1", "containers": [{"name": "dns", "image": "coredns", "ports": [
+			{"name": "dns", "containerPort": 53, "protocol": "UDP"},
+			{"name": "metrics", "containerPort": 9153}
+		]}]},
+		"status": {"phase": "Running", "podIP": "fd00::1"}
+	}`), &p))
+
+	endpoints := podEndpoints(p)
+	require.Len(t, endpoints, 3)
+	assert.Equal(t, "true", endpoints[0].Details["pod.annotation.scrape"])
+	assert.Equal(t, observer.Endpoint{
+		ID:     "kube-system/dns-0/53/UDP",
+		Type:   observer.EndpointTypePort,
+		Target: "[fd00::1]:53",
+		Details: map[string]string{
+			"namespace":             "kube-system",
+			"pod.name":              "dns-0",
+			"pod.uid":               "uid",
+			"node.name":             "node-1",
+			"pod.annotation.scrape": "true",
+			"container.name":        "dns",
+			"container.image":       "coredns",
+			"port":                  "53",
+			"port.name":             "dns",
+			"port.protocol":         "UDP",
+		},
+	}, endpoints[1])
+	assert.Equal(t, "kube-system/dns-0/9153/TCP", endpoints[2].ID)
+
+	// The terminated pods are not observed.
+	p.Status.Phase = "Succeeded"
+	assert.Empty(t, podEndpoints(p))
+}
+
+func TestSliceEndpoints(t *testing.T) {
+	var s endpointSlice
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metadata": {"name": "web-xyz", "namespace": "default"},
+		"endpoints": [
+			{"addresses": ["10.0.0.1"], "conditions": {"ready": false}, "nodeName": "node-1"},
+			{"addresses": ["10.0.0.2"], "nodeName": "node-2"}
+		],
+		"ports": [{"name": "http", "port": 80}, {"name": "all"}]
+	}`), &s))
+
+	endpoints := sliceEndpoints(s, "")
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, observer.Endpoint{
+		ID:     "default/web-xyz/10.0.0.1:80/TCP",
+		Type:   observer.EndpointTypeK8sEndpoint,
+		Target: "10.0.0.1:80",
+		Details: map[string]string{
+			"namespace":      "default",
+			"service.name":   "web-xyz",
+			"node.name":      "node-1",
+			"port":           "80",
+			"port.name":      "http",
+			"port.protocol":  "TCP",
+			"endpoint.ready": "false",
+		},
+	}, endpoints[0])
+	assert.Equal(t, "true", endpoints[1].Details["endpoint.ready"])
+
+	endpoints = sliceEndpoints(s, "node-2")
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "10.0.0.2:80", endpoints[0].Target)
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension // import "go.opentelemetry.io/collector/extension/k8sobserverextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+)
+
+const (
+	// listLimit is the maximum number of objects returned by a single list request.
+	listLimit = "500"
+	// maxErrorBodySize is the maximum size of the body of an error response reported in the error.
+	maxErrorBodySize = 1024
+)
+
+// serviceAccountDir is the directory the token and the CA of the service account are mounted in.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+var errNotInCluster = errors.New("the collector does not run in a Kubernetes cluster, KUBERNETES_SERVICE_HOST and " +
+	"KUBERNETES_SERVICE_PORT are not set: the api_server endpoint must be set")
+
+type k8sObserver struct {
+	cfg       *Config
+	telemetry component.TelemetrySettings
+	watcher   *observer.EndpointsWatcher
+
+	client   *http.Client
+	endpoint string
+}
+
+var _ observer.Observer = (*k8sObserver)(nil)
+
+func newObserver(cfg *Config, telemetry component.TelemetrySettings) *k8sObserver {
+	o := &k8sObserver{cfg: cfg, telemetry: telemetry}
+	o.watcher = observer.NewEndpointsWatcher(o.list, cfg.RefreshInterval, func(err error) {
+		telemetry.Logger.Error("Failed to list the Kubernetes endpoints", zap.Error(err))
+	})
+	return o
+}
+
+func (o *k8sObserver) Start(ctx context.Context, host component.Host) error {
+	clientCfg := o.cfg.APIServer
+	if clientCfg.Endpoint == "" {
+		apiHost, apiPort := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if apiHost == "" || apiPort == "" {
+			return errNotInCluster
+		}
+		clientCfg.Endpoint = "https://" + net.JoinHostPort(apiHost, apiPort)
+		if clientCfg.TLSSetting.CAFile == "" && clientCfg.TLSSetting.CAPem == "" {
+			clientCfg.TLSSetting.CAFile = filepath.Join(serviceAccountDir, "ca.crt")
+		}
+	}
+	client, err := clientCfg.ToClient(ctx, host, o.telemetry)
+	if err != nil {
+		return err
+	}
+	o.client = client
+	o.endpoint = strings.TrimSuffix(clientCfg.Endpoint, "/")
+	o.watcher.Start()
+	return nil
+}
+
+func (o *k8sObserver) Shutdown(context.Context) error {
+	o.watcher.Shutdown()
+	return nil
+}
+
+func (o *k8sObserver) ListAndWatch(notify observer.Notify) {
+	o.watcher.ListAndWatch(notify)
+}
+
+func (o *k8sObserver) Unsubscribe(notify observer.Notify) {
+	o.watcher.Unsubscribe(notify)
+}
+
+// list lists the endpoints of the pods and of the services.
+func (o *k8sObserver) list(ctx context.Context) ([]observer.Endpoint, error) {
+	var endpoints []observer.Endpoint
+	if o.cfg.ObservePods {
+		query := url.Values{}
+		if o.cfg.Node != "" {
+			query.Set("fieldSelector", "spec.nodeName="+o.cfg.Node)
+		}
+		pods, err := listObjects[pod](ctx, o, o.resourcePath("/api/v1", "pods"), query)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pods {
+			endpoints = append(endpoints, podEndpoints(p)...)
+		}
+	}
+	if o.cfg.ObserveEndpoints {
+		endpointSlices, err := listObjects[endpointSlice](ctx, o, o.resourcePath("/apis/discovery.k8s.io/v1", "endpointslices"), url.Values{})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range endpointSlices {
+			endpoints = append(endpoints, sliceEndpoints(s, o.cfg.Node)...)
+		}
+	}
+	return endpoints, nil
+}
+
+// resourcePath returns the path listing the resources of the observed namespaces.
+func (o *k8sObserver) resourcePath(group, resource string) string {
+	if o.cfg.Namespace == "" {
+		return group + "/" + resource
+	}
+	return group + "/namespaces/" + url.PathEscape(o.cfg.Namespace) + "/" + resource
+}
+
+// objectList is a page of a list of Kubernetes objects.
+type objectList[T any] struct {
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+	Items []T `json:"items"`
+}
+
+// listObjects lists all the objects of a resource, page by page.
+func listObjects[T any](ctx context.Context, o *k8sObserver, path string, query url.Values) ([]T, error) {
+	var items []T
+	query.Set("limit", listLimit)
+	for {
+		var page objectList[T]
+		if err := o.get(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if page.Metadata.Continue == "" {
+			return items, nil
+		}
+		query.Set("continue", page.Metadata.Continue)
+	}
+}
+
+// get decodes the JSON response of a GET request to the API server.
+func (o *k8sObserver) get(ctx context.Context, path string, query url.Values, into any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.endpoint+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if o.cfg.AuthType == AuthTypeServiceAccount {
+		// The token is read for every request, since it is rotated by the kubelet.
+		token, err := os.ReadFile(filepath.Join(serviceAccountDir, "token"))
+		if err != nil {
+			return fmt.Errorf("failed to read the service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("failed to list %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err = json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("failed to decode the list of %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+const (
+	podsPage1 = `{"metadata": {"continue": "page2"}, "items": [{
+		"metadata": {"name": "redis-0", "namespace": "monitoring", "uid": "uid-0", "labels": {"app": "redis"}},
+		"spec": {"nodeName": "node-1", "containers": [{"name": "redis", "image": "redis:7", "ports": [{"name": "redis", "containerPort": 6379}]}]},
+		"status": {"phase": "Running", "podIP": "10.0.0.1"}
+	}]}`
+	podsPage2 = `{"metadata": {}, "items": [{
+		"metadata": {"name": "web-0", "namespace": "monitoring", "uid": "uid-1"},
+		"spec": {"nodeName": "node-1", "containers": [{"name": "web", "image": "nginx"}]},
+		"status": {"phase": "Pending"}
+	}]}`
+	endpointSlices = `{"metadata": {}, "items": [{
+		"metadata": {"name": "redis-abcde", "namespace": "monitoring", "labels": {"kubernetes.io/service-name": "redis"}},
+		"endpoints": [{"addresses": ["10.0.0.1"], "conditions": {"ready": true}, "nodeName": "node-1", "targetRef": {"kind": "Pod", "name": "redis-0"}}],
+		"ports": [{"name": "redis", "port": 6379, "protocol": "TCP"}]
+	}]}`
+)
+
+// recordingNotify records the endpoints notified by an observer.
+type recordingNotify struct {
+	mu        sync.Mutex
+	endpoints map[string]observer.Endpoint
+}
+
+func (n *recordingNotify) OnAdd(added []observer.Endpoint) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, e := range added {
+		n.endpoints[e.ID] = e
+	}
+}
+
+func (n *recordingNotify) OnRemove(removed []observer.Endpoint) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, e := range removed {
+		delete(n.endpoints, e.ID)
+	}
+}
+
+func (n *recordingNotify) OnChange(changed []observer.Endpoint) {
+	n.OnAdd(changed)
+}
+
+func (n *recordingNotify) get() map[string]observer.Endpoint {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	endpoints := make(map[string]observer.Endpoint, len(n.endpoints))
+	for id, e := range n.endpoints {
+		endpoints[id] = e
+	}
+	return endpoints
+}
+
+// setServiceAccountDir mounts the service account in a temporary directory, without token if empty.
+func setServiceAccountDir(t *testing.T, token string) {
+	dir := t.TempDir()
+	if token != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte(token+"\n"), 0o600))
+	}
+	prev := serviceAccountDir
+	serviceAccountDir = dir
+	t.Cleanup(func() { serviceAccountDir = prev })
+}
+
+func TestObserver(t *testing.T) {
+	setServiceAccountDir(t, "secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "500", r.URL.Query().Get("limit"))
+		switch r.URL.Path {
+		case "/api/v1/namespaces/monitoring/pods":
+			assert.Equal(t, "spec.nodeName=node-1", r.URL.Query().Get("fieldSelector"))
+			if r.URL.Query().Get("continue") == "page2" {
+				_, _ = w.Write([]byte(podsPage2))
+				return
+			}
+			_, _ = w.Write([]byte(podsPage1))
+		case "/apis/discovery.k8s.io/v1/namespaces/monitoring/endpointslices":
+			_, _ = w.Write([]byte(endpointSlices))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIServer.Endpoint = server.URL
+	cfg.Node = "node-1"
+	cfg.Namespace = "monitoring"
+	cfg.ObserveEndpoints = true
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	obs := ext.(observer.Observer)
+	notify := &recordingNotify{endpoints: map[string]observer.Endpoint{}}
+	obs.ListAndWatch(notify)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool {
+		return len(notify.get()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	endpoints := notify.get()
+	assert.Equal(t, observer.Endpoint{
+		ID:     "monitoring/redis-0",
+		Type:   observer.EndpointTypePod,
+		Target: "10.0.0.1",
+		Details: map[string]string{
+			"namespace":     "monitoring",
+			"pod.name":      "redis-0",
+			"pod.uid":       "uid-0",
+			"node.name":     "node-1",
+			"pod.label.app": "redis",
+		},
+	}, endpoints["monitoring/redis-0"])
+	assert.Equal(t, "10.0.0.1:6379", endpoints["monitoring/redis-0/6379/TCP"].Target)
+	assert.Equal(t, observer.EndpointTypeK8sEndpoint, endpoints["monitoring/redis/10.0.0.1:6379/TCP"].Type)
+	obs.Unsubscribe(notify)
+}
+
+func TestObserverListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`pods is forbidden`))
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.AuthType = AuthTypeNone
+	o := newObserver(cfg, componenttest.NewNopTelemetrySettings())
+	o.client = server.Client()
+	o.endpoint = server.URL
+	_, err := o.list(context.Background())
+	assert.EqualError(t, err, "failed to list /api/v1/pods: 403 Forbidden: pods is forbidden")
+}
+
+func TestObserverMissingToken(t *testing.T) {
+	setServiceAccountDir(t, "")
+	o := newObserver(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	o.client = http.DefaultClient
+	o.endpoint = "http://localhost:1"
+	_, err := o.list(context.Background())
+	assert.ErrorContains(t, err, "failed to read the service account token")
+}
+
+func TestObserverNotInCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), createDefaultConfig())
+	require.NoError(t, err)
+	assert.ErrorIs(t, ext.Start(context.Background(), componenttest.NewNopHost()), errNotInCluster)
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserverextension // import "go.opentelemetry.io/collector/extension/k8sobserverextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/k8sobserverextension/internal/metadata"
+)
+
+const (
+	defaultRefreshInterval = 10 * time.Second
+	defaultTimeout         = 30 * time.Second
+)
+
+// NewFactory creates a factory for the Kubernetes observer extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	apiServer := confighttp.NewDefaultClientConfig()
+	apiServer.Timeout = defaultTimeout
+	return &Config{
+		APIServer:       apiServer,
+		AuthType:        AuthTypeServiceAccount,
+		ObservePods:     true,
+		RefreshInterval: defaultRefreshInterval,
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newObserver(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,16 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("k8s_observer")
+	ScopeName = "go.opentelemetry.io/collector/extension/k8sobserverextension"
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
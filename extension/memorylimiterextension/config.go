@@ -4,7 +4,20 @@
 package memorylimiterextension // import "go.opentelemetry.io/collector/extension/memorylimiterextension"
 
 import (
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
-type Config = memorylimiter.Config
+// Config defines the configuration of the memory limiter extension.
+type Config struct {
+	memorylimiter.Config `mapstructure:",squash"`
+
+	// RequestLimitMiB is the maximum size, in MiB, of the requests admitted at the same time
+	// by the servers using the extension as a middleware. The size of a request is its
+	// Content-Length for HTTP, or the size of its message for gRPC.
+	// A request bigger than the limit on its own is admitted when no other request is in flight.
+	// Defaults to zero, so the requests are only refused when the memory usage is above the limits.
+	RequestLimitMiB uint32 `mapstructure:"request_limit_mib"`
+}
+
+var _ component.Config = (*Config)(nil)
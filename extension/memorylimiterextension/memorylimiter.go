@@ -5,25 +5,62 @@ package memorylimiterextension // import "go.opentelemetry.io/collector/extensio
 
 import (
 	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/middleware"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
+const mibBytes = 1024 * 1024
+
+// errRequestRefused is returned to the clients of the servers using the extension
+// when a request is refused before being read.
+var errRequestRefused = errors.New("request refused due to high memory usage")
+
 type memoryLimiterExtension struct {
 	memLimiter *memorylimiter.MemoryLimiter
+
+	// requestLimit is the maximum size in bytes of the requests in flight, zero if there is no limit.
+	requestLimit int64
+	// retryAfter is the delay suggested to the clients before retrying a refused request.
+	retryAfter time.Duration
+
+	mu       sync.Mutex
+	inFlight int64
+	requests int
 }
 
+var (
+	_ middleware.Server              = (*memoryLimiterExtension)(nil)
+	_ middleware.GRPCServerTransport = (*memoryLimiterExtension)(nil)
+)
+
 // newMemoryLimiter returns a new memorylimiter extension.
 func newMemoryLimiter(cfg *Config, logger *zap.Logger) (*memoryLimiterExtension, error) {
-	ml, err := memorylimiter.NewMemoryLimiter(cfg, logger)
+	ml, err := memorylimiter.NewMemoryLimiter(&cfg.Config, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &memoryLimiterExtension{memLimiter: ml}, nil
+	return &memoryLimiterExtension{
+		memLimiter:   ml,
+		requestLimit: int64(cfg.RequestLimitMiB) * mibBytes,
+		retryAfter:   cfg.CheckInterval,
+	}, nil
 }
 
 func (ml *memoryLimiterExtension) Start(ctx context.Context, host component.Host) error {
@@ -38,3 +75,154 @@ func (ml *memoryLimiterExtension) Shutdown(ctx context.Context) error {
 func (ml *memoryLimiterExtension) MustRefuse() bool {
 	return ml.memLimiter.MustRefuse()
 }
+
+// OnPressureChange registers a function called with true when the memory usage goes above the soft limit, and
+// with false when it goes back within the limits, e.g. for the batch processor to flush its pending batches early.
+// The function must not block. OnPressureChange returns a function unregistering it.
+func (ml *memoryLimiterExtension) OnPressureChange(f func(pressure bool)) (unregister func()) {
+	return ml.memLimiter.OnPressureChange(f)
+}
+
+// reservation is the memory reserved for a request in flight.
+type reservation struct {
+	size     int64
+	released bool
+}
+
+// admit reserves the memory of a request of the given size in bytes, negative if unknown,
+// before the request is read. It returns false if the request must be refused, otherwise
+// the reservation must be released once the request is handled. A request of unknown size
+// is refused when the requests in flight already use the whole limit.
+func (ml *memoryLimiterExtension) admit(size int64) (*reservation, bool) {
+	if ml.MustRefuse() {
+		return nil, false
+	}
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	if ml.requestLimit > 0 && ml.requests > 0 {
+		if size < 0 && ml.inFlight >= ml.requestLimit || ml.inFlight+size > ml.requestLimit {
+			return nil, false
+		}
+	}
+	res := &reservation{size: max(size, 0)}
+	ml.inFlight += res.size
+	ml.requests++
+	return res, true
+}
+
+// resize changes the memory reserved for a request as it is read. It returns false if the requests
+// in flight then use more than the limit, unless the request is the only one.
+func (ml *memoryLimiterExtension) resize(res *reservation, size int64) bool {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	if res.released {
+		return true
+	}
+	ml.inFlight += size - res.size
+	res.size = size
+	return ml.requestLimit <= 0 || ml.requests <= 1 || ml.inFlight <= ml.requestLimit
+}
+
+// release releases the memory reserved by admit for a request.
+func (ml *memoryLimiterExtension) release(res *reservation) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	if res.released {
+		return
+	}
+	res.released = true
+	ml.inFlight -= res.size
+	ml.requests--
+}
+
+// countingBody reserves the memory of a request body without Content-Length as it is read,
+// and fails once the requests in flight use more than the limit.
+type countingBody struct {
+	io.ReadCloser
+	ml   *memoryLimiterExtension
+	res  *reservation
+	read int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if !b.ml.resize(b.res, b.read) {
+		return n, errRequestRefused
+	}
+	return n, err
+}
+
+// ServerHandler refuses the HTTP requests before their body is read, based on their Content-Length,
+// with a 503 Service Unavailable response suggesting when to retry. The bodies without Content-Length
+// are counted as they are read, and fail to be read past the limit.
+func (ml *memoryLimiterExtension) ServerHandler(next http.Handler) (http.Handler, error) {
+	retryAfter := strconv.Itoa(int(math.Ceil(ml.retryAfter.Seconds())))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := ml.admit(r.ContentLength)
+		if !ok {
+			w.Header().Set("Retry-After", retryAfter)
+			http.Error(w, errRequestRefused.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer ml.release(res)
+		if r.ContentLength < 0 && r.Body != nil && r.Body != http.NoBody {
+			r.Body = &countingBody{ReadCloser: r.Body, ml: ml, res: res}
+		}
+		next.ServeHTTP(w, r)
+	}), nil
+}
+
+// UnaryServerInterceptor returns nil: the gRPC calls are admitted at the transport, see InTapHandle.
+func (ml *memoryLimiterExtension) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return nil
+}
+
+// StreamServerInterceptor returns nil: the gRPC streams are admitted at the transport, see InTapHandle.
+func (ml *memoryLimiterExtension) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return nil
+}
+
+type reservationKey struct{}
+
+// InTapHandle refuses the gRPC calls and streams when they are created, before their messages are read, with an
+// Unavailable status the clients retry. The memory reserved for a stream is released when the stream ends.
+func (ml *memoryLimiterExtension) InTapHandle() tap.ServerInHandle {
+	return func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+		res, ok := ml.admit(-1)
+		if !ok {
+			return nil, status.Error(codes.Unavailable, errRequestRefused.Error())
+		}
+		context.AfterFunc(ctx, func() { ml.release(res) })
+		return context.WithValue(ctx, reservationKey{}, res), nil
+	}
+}
+
+// StatsHandler sizes the reservations of the gRPC calls and streams with the wire length of the last message
+// they received, which the admission of the next calls and streams accounts for.
+func (ml *memoryLimiterExtension) StatsHandler() stats.Handler {
+	return (*payloadHandler)(ml)
+}
+
+// payloadHandler is the stats.Handler resizing the reservations of the gRPC streams.
+type payloadHandler memoryLimiterExtension
+
+func (h *payloadHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *payloadHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	in, ok := s.(*stats.InPayload)
+	if !ok {
+		return
+	}
+	if res, ok := ctx.Value(reservationKey{}).(*reservation); ok {
+		(*memoryLimiterExtension)(h).resize(res, int64(in.WireLength))
+	}
+}
+
+func (h *payloadHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *payloadHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -5,13 +5,23 @@ package memorylimiterextension
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
@@ -29,9 +39,11 @@ func TestMemoryPressureResponse(t *testing.T) {
 		{
 			name: "Below memAllocLimit",
 			mlCfg: &Config{
-				CheckInterval:         time.Second,
-				MemoryLimitPercentage: 50,
-				MemorySpikePercentage: 1,
+				Config: memorylimiter.Config{
+					CheckInterval:         time.Second,
+					MemoryLimitPercentage: 50,
+					MemorySpikePercentage: 1,
+				},
 			},
 			memAlloc:    800,
 			expectError: false,
@@ -39,9 +51,11 @@ func TestMemoryPressureResponse(t *testing.T) {
 		{
 			name: "Above memAllocLimit",
 			mlCfg: &Config{
-				CheckInterval:         time.Second,
-				MemoryLimitPercentage: 50,
-				MemorySpikePercentage: 1,
+				Config: memorylimiter.Config{
+					CheckInterval:         time.Second,
+					MemoryLimitPercentage: 50,
+					MemorySpikePercentage: 1,
+				},
 			},
 			memAlloc:    1800,
 			expectError: true,
@@ -49,9 +63,11 @@ func TestMemoryPressureResponse(t *testing.T) {
 		{
 			name: "Below memSpikeLimit",
 			mlCfg: &Config{
-				CheckInterval:         time.Second,
-				MemoryLimitPercentage: 50,
-				MemorySpikePercentage: 10,
+				Config: memorylimiter.Config{
+					CheckInterval:         time.Second,
+					MemoryLimitPercentage: 50,
+					MemorySpikePercentage: 10,
+				},
 			},
 			memAlloc:    800,
 			expectError: false,
@@ -59,9 +75,11 @@ func TestMemoryPressureResponse(t *testing.T) {
 		{
 			name: "Above memSpikeLimit",
 			mlCfg: &Config{
-				CheckInterval:         time.Second,
-				MemoryLimitPercentage: 50,
-				MemorySpikePercentage: 11,
+				Config: memorylimiter.Config{
+					CheckInterval:         time.Second,
+					MemoryLimitPercentage: 50,
+					MemorySpikePercentage: 11,
+				},
 			},
 			memAlloc:    800,
 			expectError: true,
@@ -93,6 +111,164 @@ func TestMemoryPressureResponse(t *testing.T) {
 	})
 }
 
+func newTestLimiter(t *testing.T, memAlloc uint64, requestLimitMiB uint32) *memoryLimiterExtension {
+	memorylimiter.GetMemoryFn = totalMemory
+	memorylimiter.ReadMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = memAlloc
+	}
+	t.Cleanup(func() {
+		memorylimiter.GetMemoryFn = iruntime.TotalMemory
+		memorylimiter.ReadMemStatsFn = runtime.ReadMemStats
+	})
+	ml, err := newMemoryLimiter(&Config{
+		Config: memorylimiter.Config{
+			CheckInterval:         1500 * time.Millisecond,
+			MemoryLimitPercentage: 50,
+			MemorySpikePercentage: 10,
+		},
+		RequestLimitMiB: requestLimitMiB,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	ml.memLimiter.CheckMemLimits()
+	return ml
+}
+
+func TestOnPressureChange(t *testing.T) {
+	memAlloc := uint64(800)
+	memorylimiter.GetMemoryFn = totalMemory
+	memorylimiter.ReadMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = memAlloc
+	}
+	t.Cleanup(func() {
+		memorylimiter.GetMemoryFn = iruntime.TotalMemory
+		memorylimiter.ReadMemStatsFn = runtime.ReadMemStats
+	})
+	ml, err := newMemoryLimiter(&Config{
+		Config: memorylimiter.Config{
+			CheckInterval:         time.Second,
+			MemoryLimitPercentage: 50,
+			MemorySpikePercentage: 10,
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	var signals []bool
+	unregister := ml.OnPressureChange(func(pressure bool) { signals = append(signals, pressure) })
+
+	for _, alloc := range []uint64{1800, 800, 1800} {
+		memAlloc = alloc
+		ml.memLimiter.CheckMemLimits()
+	}
+	unregister()
+	memAlloc = 800
+	ml.memLimiter.CheckMemLimits()
+	assert.Equal(t, []bool{true, false, true}, signals)
+}
+
+func TestServerHandler(t *testing.T) {
+	refusing := newTestLimiter(t, 1800, 0)
+	handler, err := refusing.ServerHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		assert.Fail(t, "the request must be refused before being handled")
+	}))
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader("data")))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+
+	ml := newTestLimiter(t, 800, 1)
+	var nested []int
+	handler, err = ml.ServerHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			// The other requests are made while this one is in flight.
+			for _, body := range []string{strings.Repeat("a", mibBytes), "b", ""} {
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader(body))
+				if body == "" {
+					req.ContentLength = -1
+				}
+				handler.ServeHTTP(rec, req)
+				nested = append(nested, rec.Code)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader(strings.Repeat("a", 1024))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK}, nested)
+	assert.Zero(t, ml.inFlight)
+	assert.Zero(t, ml.requests)
+
+	// A request bigger than the limit is admitted when it is the only one.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader(strings.Repeat("a", 2*mibBytes))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServerHandlerUnknownLength(t *testing.T) {
+	ml := newTestLimiter(t, 800, 1)
+	var readErr error
+	var handler http.Handler
+	handler, err := ml.ServerHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			// The body without Content-Length is read while this request is in flight.
+			req := httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader(strings.Repeat("a", mibBytes)))
+			req.ContentLength = -1
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		} else {
+			_, readErr = io.ReadAll(r.Body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.NoError(t, err)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader("data")))
+	assert.ErrorIs(t, readErr, errRequestRefused)
+	assert.Zero(t, ml.inFlight)
+	assert.Zero(t, ml.requests)
+
+	// A body without Content-Length is read past the limit when it is the only request.
+	req := httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader(strings.Repeat("a", 2*mibBytes)))
+	req.ContentLength = -1
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.NoError(t, readErr)
+}
+
+func TestInTapHandle(t *testing.T) {
+	_, err := newTestLimiter(t, 1800, 0).InTapHandle()(context.Background(), &tap.Info{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	ml := newTestLimiter(t, 800, 1)
+	assert.Nil(t, ml.UnaryServerInterceptor())
+	assert.Nil(t, ml.StreamServerInterceptor())
+	handle := ml.InTapHandle()
+	streamCtx, cancel := context.WithCancel(context.Background())
+	ctx, err := handle(streamCtx, &tap.Info{})
+	require.NoError(t, err)
+
+	// The stream is sized with the wire length of its last message.
+	handler := ml.StatsHandler()
+	handler.HandleRPC(ctx, &stats.InPayload{WireLength: 1024})
+	handler.HandleRPC(ctx, &stats.InPayload{WireLength: mibBytes})
+	ml.mu.Lock()
+	assert.Equal(t, int64(mibBytes), ml.inFlight)
+	ml.mu.Unlock()
+	_, err = handle(context.Background(), &tap.Info{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// The reservation is released when the stream ends.
+	cancel()
+	assert.Eventually(t, func() bool {
+		ml.mu.Lock()
+		defer ml.mu.Unlock()
+		return ml.inFlight == 0 && ml.requests == 0
+	}, time.Second, time.Millisecond)
+	otherCtx, cancelOther := context.WithCancel(context.Background())
+	defer cancelOther()
+	_, err = handle(otherCtx, &tap.Info{})
+	assert.NoError(t, err)
+}
+
 type mockHost struct {
 	component.Host
 }
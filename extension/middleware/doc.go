@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package middleware defines the extensions providing HTTP middlewares
+// and gRPC interceptors, applied by the confighttp and configgrpc servers
+// listing them in their middlewares setting.
+package middleware // import "go.opentelemetry.io/collector/extension/middleware"
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware // import "go.opentelemetry.io/collector/extension/middleware"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/tap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+var (
+	errMiddlewareNotFound = errors.New("middleware not found")
+	errNotHTTPServer      = errors.New("requested extension is not an HTTP server middleware")
+	errNotGRPCServer      = errors.New("requested extension is not a gRPC server middleware")
+)
+
+// HTTPServer is an Extension providing a middleware for the HTTP servers, applied by the
+// confighttp servers listing the extension in their middlewares. The middleware is applied
+// to the requests after their authentication, so it can handle cross-cutting concerns like
+// the request logging, the rate limiting, or additional authorization checks.
+type HTTPServer interface {
+	extension.Extension
+
+	// ServerHandler returns the handler wrapping the next handler of the server.
+	// It is called once for each server, when the server is created.
+	ServerHandler(next http.Handler) (http.Handler, error)
+}
+
+// GRPCServer is an Extension providing interceptors for the gRPC servers, applied by the
+// configgrpc servers listing the extension in their middlewares. The interceptors are applied
+// to the calls after their authentication.
+type GRPCServer interface {
+	extension.Extension
+
+	// UnaryServerInterceptor returns the interceptor of the unary calls, nil if there is none.
+	UnaryServerInterceptor() grpc.UnaryServerInterceptor
+
+	// StreamServerInterceptor returns the interceptor of the streaming calls, nil if there is none.
+	StreamServerInterceptor() grpc.StreamServerInterceptor
+}
+
+// Server is an Extension providing both an HTTP server middleware and gRPC server interceptors.
+// GRPCServerTransport is optionally implemented by the gRPC server middlewares acting on the transport,
+// before the messages of the calls are read and decoded.
+type GRPCServerTransport interface {
+	// InTapHandle returns the handle called when a stream is created, before its messages are read, nil if there
+	// is none. The stream is refused with the status of the returned error.
+	InTapHandle() tap.ServerInHandle
+
+	// StatsHandler returns the handler of the events of the calls, such as the received messages and their
+	// wire length, nil if there is none.
+	StatsHandler() stats.Handler
+}
+
+type Server interface {
+	HTTPServer
+	GRPCServer
+}
+
+// ServerHandlerFunc defines the signature of the function wrapping the next handler
+// of an HTTP server. See HTTPServer.ServerHandler.
+type ServerHandlerFunc func(next http.Handler) (http.Handler, error)
+
+func (f ServerHandlerFunc) ServerHandler(next http.Handler) (http.Handler, error) {
+	if f == nil {
+		return next, nil
+	}
+	return f(next)
+}
+
+type defaultServer struct {
+	ServerHandlerFunc
+	component.StartFunc
+	component.ShutdownFunc
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+}
+
+func (s *defaultServer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return s.unaryInterceptor
+}
+
+func (s *defaultServer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return s.streamInterceptor
+}
+
+// ServerOption represents the possible options for NewServer.
+type ServerOption func(*defaultServer)
+
+// WithServerHandler specifies the function wrapping the handler of the HTTP servers.
+// The default returns the handler unchanged.
+func WithServerHandler(handlerFunc ServerHandlerFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.ServerHandlerFunc = handlerFunc
+	}
+}
+
+// WithUnaryServerInterceptor specifies the interceptor of the unary gRPC calls.
+// The default does not intercept the calls.
+func WithUnaryServerInterceptor(interceptor grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *defaultServer) {
+		o.unaryInterceptor = interceptor
+	}
+}
+
+// WithStreamServerInterceptor specifies the interceptor of the streaming gRPC calls.
+// The default does not intercept the calls.
+func WithStreamServerInterceptor(interceptor grpc.StreamServerInterceptor) ServerOption {
+	return func(o *defaultServer) {
+		o.streamInterceptor = interceptor
+	}
+}
+
+// WithServerStart overrides the default `Start` function for a component.Component.
+// The default always returns nil.
+func WithServerStart(startFunc component.StartFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.StartFunc = startFunc
+	}
+}
+
+// WithServerShutdown overrides the default `Shutdown` function for a component.Component.
+// The default always returns nil.
+func WithServerShutdown(shutdownFunc component.ShutdownFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.ShutdownFunc = shutdownFunc
+	}
+}
+
+// NewServer returns a Server configured with the provided options.
+func NewServer(options ...ServerOption) Server {
+	s := &defaultServer{}
+
+	for _, op := range options {
+		op(s)
+	}
+
+	return s
+}
+
+// GetHTTPServer returns the HTTP server middleware of the extension with the given ID.
+// An error is returned if the extension is not found or is not an HTTP server middleware.
+func GetHTTPServer(extensions map[component.ID]component.Component, id component.ID) (HTTPServer, error) {
+	if ext, found := extensions[id]; found {
+		if server, ok := ext.(HTTPServer); ok {
+			return server, nil
+		}
+		return nil, fmt.Errorf("failed to resolve middleware %q: %w", id, errNotHTTPServer)
+	}
+	return nil, fmt.Errorf("failed to resolve middleware %q: %w", id, errMiddlewareNotFound)
+}
+
+// GetGRPCServer returns the gRPC server middleware of the extension with the given ID.
+// An error is returned if the extension is not found or is not a gRPC server middleware.
+func GetGRPCServer(extensions map[component.ID]component.Component, id component.ID) (GRPCServer, error) {
+	if ext, found := extensions[id]; found {
+		if server, ok := ext.(GRPCServer); ok {
+			return server, nil
+		}
+		return nil, fmt.Errorf("failed to resolve middleware %q: %w", id, errNotGRPCServer)
+	}
+	return nil, fmt.Errorf("failed to resolve middleware %q: %w", id, errMiddlewareNotFound)
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func TestDefaultValues(t *testing.T) {
+	// prepare
+	e := NewServer()
+
+	// test
+	t.Run("start", func(t *testing.T) {
+		err := e.Start(context.Background(), componenttest.NewNopHost())
+		assert.NoError(t, err)
+	})
+
+	t.Run("server handler", func(t *testing.T) {
+		next := http.NotFoundHandler()
+		handler, err := e.ServerHandler(next)
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("interceptors", func(t *testing.T) {
+		assert.Nil(t, e.UnaryServerInterceptor())
+		assert.Nil(t, e.StreamServerInterceptor())
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		err := e.Shutdown(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestWithServerHandler(t *testing.T) {
+	e := NewServer(WithServerHandler(func(next http.Handler) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Middleware", "called")
+			next.ServeHTTP(w, r)
+		}), nil
+	}))
+
+	handler, err := e.ServerHandler(http.NotFoundHandler())
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "called", rec.Header().Get("X-Middleware"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestWithServerInterceptors(t *testing.T) {
+	unaryCalled := false
+	streamCalled := false
+	e := NewServer(
+		WithUnaryServerInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			unaryCalled = true
+			return handler(ctx, req)
+		}),
+		WithStreamServerInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			streamCalled = true
+			return handler(srv, ss)
+		}),
+	)
+
+	_, err := e.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, unaryCalled)
+
+	err = e.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{}, func(any, grpc.ServerStream) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, streamCalled)
+}
+
+func TestWithServerStart(t *testing.T) {
+	called := false
+	e := NewServer(WithServerStart(func(context.Context, component.Host) error {
+		called = true
+		return nil
+	}))
+
+	// test
+	err := e.Start(context.Background(), componenttest.NewNopHost())
+
+	// verify
+	assert.True(t, called)
+	assert.NoError(t, err)
+}
+
+func TestWithServerShutdown(t *testing.T) {
+	called := false
+	e := NewServer(WithServerShutdown(func(context.Context) error {
+		called = true
+		return nil
+	}))
+
+	// test
+	err := e.Shutdown(context.Background())
+
+	// verify
+	assert.True(t, called)
+	assert.NoError(t, err)
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+var _ extension.Extension = (*nopExtension)(nil)
+
+func TestGetServers(t *testing.T) {
+	serverID := component.MustNewID("middleware")
+	otherID := component.MustNewID("other")
+	extensions := map[component.ID]component.Component{
+		serverID: NewServer(),
+		otherID:  &nopExtension{},
+	}
+
+	httpServer, err := GetHTTPServer(extensions, serverID)
+	assert.NoError(t, err)
+	assert.NotNil(t, httpServer)
+	grpcServer, err := GetGRPCServer(extensions, serverID)
+	assert.NoError(t, err)
+	assert.NotNil(t, grpcServer)
+
+	_, err = GetHTTPServer(extensions, otherID)
+	assert.ErrorIs(t, err, errNotHTTPServer)
+	_, err = GetGRPCServer(extensions, otherID)
+	assert.ErrorIs(t, err, errNotGRPCServer)
+
+	_, err = GetHTTPServer(extensions, component.MustNewID("missing"))
+	assert.ErrorIs(t, err, errMiddlewareNotFound)
+	_, err = GetGRPCServer(extensions, component.MustNewID("missing"))
+	assert.ErrorIs(t, err, errMiddlewareNotFound)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var (
+	errNoClientIDProvided     = errors.New("no ClientID provided in the OAuth2 client auth extension configuration")
+	errNoClientSecretProvided = errors.New("no ClientSecret provided in the OAuth2 client auth extension configuration")
+	errNoTokenURLProvided     = errors.New("no TokenURL provided in the OAuth2 client auth extension configuration")
+	errInvalidBackoff         = errors.New("backoff::max_interval must be greater than or equal to backoff::initial_interval, which must be greater than zero")
+)
+
+// Config stores the configuration for the OAuth2 client credentials authenticator.
+type Config struct {
+	// ClientID is the application's ID.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the application's secret.
+	ClientSecret configopaque.String `mapstructure:"client_secret"`
+
+	// TokenURL is the resource server's token endpoint URL, e.g. https://example.com/oauth2/token.
+	TokenURL string `mapstructure:"token_url"`
+
+	// Scopes specifies the optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+
+	// EndpointParams specifies additional parameters for requests to the token endpoint, e.g. the audience.
+	EndpointParams url.Values `mapstructure:"endpoint_params"`
+
+	// TLSSetting configures the TLS connection to the token endpoint.
+	TLSSetting configtls.ClientConfig `mapstructure:"tls"`
+
+	// Timeout is the timeout of the requests to the token endpoint. Zero means no timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// ExpiryBuffer is how long before the expiry of the cached token a new token is requested,
+	// so that requests are not sent with a token expiring while in flight.
+	ExpiryBuffer time.Duration `mapstructure:"expiry_buffer"`
+
+	// Backoff configures how long the token endpoint is not requested again after a failure,
+	// the requests failing with the last error meanwhile.
+	Backoff BackoffConfig `mapstructure:"backoff"`
+}
+
+// BackoffConfig configures the exponential backoff between two failed requests to the token endpoint.
+type BackoffConfig struct {
+	// InitialInterval is the backoff after the first failure, doubled after every consecutive failure.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the upper bound of the backoff.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.ClientID == "" {
+		return errNoClientIDProvided
+	}
+	if cfg.ClientSecret == "" {
+		return errNoClientSecretProvided
+	}
+	if cfg.TokenURL == "" {
+		return errNoTokenURLProvided
+	}
+	if cfg.Backoff.InitialInterval <= 0 || cfg.Backoff.MaxInterval < cfg.Backoff.InitialInterval {
+		return errInvalidBackoff
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/extension/oauth2clientauthextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr error
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				ClientID:       "someclientid",
+				ClientSecret:   "someclientsecret",
+				TokenURL:       "https://example.com/oauth2/default/v1/token",
+				Scopes:         []string{"api.metrics"},
+				EndpointParams: url.Values{"audience": []string{"someaudience"}},
+				Timeout:        2 * time.Second,
+				ExpiryBuffer:   time.Minute,
+				Backoff: BackoffConfig{
+					InitialInterval: 5 * time.Second,
+					MaxInterval:     5 * time.Minute,
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missingid"),
+			expectedErr: errNoClientIDProvided,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidbackoff"),
+			expectedErr: errInvalidBackoff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.ErrorIs(t, cfg.Validate(), errNoClientIDProvided)
+	cfg.ClientID = "id"
+	assert.ErrorIs(t, cfg.Validate(), errNoClientSecretProvided)
+	cfg.ClientSecret = "secret"
+	assert.ErrorIs(t, cfg.Validate(), errNoTokenURLProvided)
+	cfg.TokenURL = "https://example.com/token"
+	assert.NoError(t, cfg.Validate())
+	cfg.Backoff.InitialInterval = 0
+	assert.ErrorIs(t, cfg.Validate(), errInvalidBackoff)
+}
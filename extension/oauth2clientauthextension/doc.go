@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package oauth2clientauthextension implements a client authenticator for HTTP and gRPC clients,
+// adding to the requests the access token obtained with the OAuth2 client credentials flow.
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/credentials"
+)
+
+// clientAuthenticator obtains access tokens with the OAuth2 client credentials flow and adds them to the requests.
+// The tokens are cached until ExpiryBuffer before their expiry.
+type clientAuthenticator struct {
+	tokenSource oauth2.TokenSource
+	backoff     *backoffTokenSource
+}
+
+func newClientAuthenticator(cfg *Config, logger *zap.Logger) (*clientAuthenticator, error) {
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS configuration of the token endpoint: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	ccCfg := &clientcredentials.Config{
+		ClientID:       cfg.ClientID,
+		ClientSecret:   string(cfg.ClientSecret),
+		TokenURL:       cfg.TokenURL,
+		Scopes:         cfg.Scopes,
+		EndpointParams: cfg.EndpointParams,
+	}
+	// The token source uses the HTTP client of the context for all its requests to the token endpoint.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	})
+	src := &backoffTokenSource{
+		src:             ccCfg.TokenSource(ctx),
+		initialInterval: cfg.Backoff.InitialInterval,
+		maxInterval:     cfg.Backoff.MaxInterval,
+		logger:          logger,
+		now:             time.Now,
+	}
+	return &clientAuthenticator{
+		tokenSource: oauth2.ReuseTokenSourceWithExpiry(nil, src, cfg.ExpiryBuffer),
+		backoff:     src,
+	}, nil
+}
+
+// roundTripper returns a RoundTripper adding the access token to the HTTP requests.
+func (ca *clientAuthenticator) roundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &oauth2.Transport{
+		Source: ca.tokenSource,
+		Base:   base,
+	}, nil
+}
+
+// perRPCCredentials returns a PerRPCCredentials adding the access token to the gRPC requests.
+func (ca *clientAuthenticator) perRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{tokenSource: ca.tokenSource}, nil
+}
+
+// perRPCAuth is a gRPC credentials.PerRPCCredentials implementation that returns an 'authorization' header.
+type perRPCAuth struct {
+	tokenSource oauth2.TokenSource
+}
+
+// GetRequestMetadata returns the request metadata to be used with the RPC.
+func (c *perRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": token.Type() + " " + token.AccessToken}, nil
+}
+
+// RequireTransportSecurity always returns true for this implementation, since tokens must not be sent in clear text.
+func (c *perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
+
+// backoffTokenSource requests tokens to src, unless the last request failed less than the current backoff ago,
+// in which case the last error is returned. This keeps the token endpoint from being flooded with requests
+// while it fails, since every export request needs a token.
+type backoffTokenSource struct {
+	src             oauth2.TokenSource
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	logger          *zap.Logger
+	now             func() time.Time
+
+	mu       sync.Mutex
+	lastErr  error
+	interval time.Duration
+	retryAt  time.Time
+}
+
+func (s *backoffTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastErr != nil && s.now().Before(s.retryAt) {
+		return nil, s.lastErr
+	}
+
+	token, err := s.src.Token()
+	if err != nil {
+		if s.interval == 0 {
+			s.interval = s.initialInterval
+		} else {
+			s.interval = min(2*s.interval, s.maxInterval)
+		}
+		s.retryAt = s.now().Add(s.interval)
+		s.lastErr = fmt.Errorf("failed to get an OAuth2 access token: %w", err)
+		s.logger.Warn("Failed to get an OAuth2 access token, backing off",
+			zap.Error(err), zap.Duration("backoff", s.interval))
+		return nil, s.lastErr
+	}
+	s.lastErr = nil
+	s.interval = 0
+	return token, nil
+}
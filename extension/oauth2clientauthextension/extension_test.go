@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+// newTokenServer returns a token endpoint issuing tokens expiring in expiresIn seconds,
+// failing while fail is true, and the number of attempts to get a token.
+func newTokenServer(t *testing.T, expiresIn int, fail *atomic.Bool) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The client credentials are sent in the header, then in the body if the request fails.
+		n := requests.Load()
+		if _, _, ok := r.BasicAuth(); ok {
+			n = requests.Add(1)
+		}
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "someaudience", r.PostForm.Get("audience"))
+		if fail != nil && fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestConfig(tokenURL string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "someclientid"
+	cfg.ClientSecret = "someclientsecret"
+	cfg.TokenURL = tokenURL
+	cfg.EndpointParams = map[string][]string{"audience": {"someaudience"}}
+	return cfg
+}
+
+func TestRoundTripper(t *testing.T) {
+	server, requests := newTokenServer(t, 3600, nil)
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), newTestConfig(server.URL))
+	require.NoError(t, err)
+
+	var authorization string
+	rt, err := ext.(auth.Client).RoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		authorization = r.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost/v1/traces", nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "Bearer token-1", authorization)
+	}
+	// The token is cached until it expires.
+	assert.Equal(t, int64(1), requests.Load())
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	server, requests := newTokenServer(t, 60, nil)
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), newTestConfig(server.URL))
+	require.NoError(t, err)
+
+	creds, err := ext.(auth.Client).PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	// The token expires within the expiry buffer, so a new token is requested every time.
+	for i := 1; i <= 2; i++ {
+		md, err := creds.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"authorization": fmt.Sprintf("Bearer token-%d", i)}, md)
+	}
+	assert.Equal(t, int64(2), requests.Load())
+}
+
+func TestTokenBackoff(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	server, requests := newTokenServer(t, 3600, &fail)
+	cfg := newTestConfig(server.URL)
+	cfg.Backoff.MaxInterval = 3 * time.Second
+	ca, err := newClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	now := time.Now()
+	ca.backoff.now = func() time.Time { return now }
+
+	_, err = ca.tokenSource.Token()
+	require.ErrorContains(t, err, "failed to get an OAuth2 access token")
+	assert.Equal(t, int64(1), requests.Load())
+
+	// The token endpoint is not requested again during the backoff.
+	_, err = ca.tokenSource.Token()
+	require.Error(t, err)
+	assert.Equal(t, int64(1), requests.Load())
+
+	// The backoff doubles after every consecutive failure, up to the maximum interval.
+	for _, expected := range []time.Duration{2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		now = now.Add(ca.backoff.interval)
+		_, err = ca.tokenSource.Token()
+		require.Error(t, err)
+		assert.Equal(t, expected, ca.backoff.interval)
+	}
+	assert.Equal(t, int64(4), requests.Load())
+
+	fail.Store(false)
+	now = now.Add(ca.backoff.interval)
+	token, err := ca.tokenSource.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-5", token.AccessToken)
+	assert.Zero(t, ca.backoff.interval)
+}
+
+func TestInvalidTLSConfig(t *testing.T) {
+	cfg := newTestConfig("https://localhost/token")
+	cfg.TLSSetting = configtls.ClientConfig{Config: configtls.Config{CAFile: "/nonexistent/ca.pem"}}
+	_, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.ErrorContains(t, err, "failed to load the TLS configuration of the token endpoint")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/oauth2clientauthextension/internal/metadata"
+)
+
+const (
+	defaultExpiryBuffer           = 5 * time.Minute
+	defaultBackoffInitialInterval = time.Second
+	defaultBackoffMaxInterval     = time.Minute
+)
+
+// NewFactory creates a factory for the OAuth2 client auth extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ExpiryBuffer: defaultExpiryBuffer,
+		Backoff: BackoffConfig{
+			InitialInterval: defaultBackoffInitialInterval,
+			MaxInterval:     defaultBackoffMaxInterval,
+		},
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	ca, err := newClientAuthenticator(cfg.(*Config), set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewClient(
+		auth.WithClientRoundTripper(ca.roundTripper),
+		auth.WithClientPerRPCCredentials(ca.perRPCCredentials),
+	), nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the OpAMP extension.
+type Config struct {
+	// Server is the OpAMP server to connect to.
+	Server ServerConfig `mapstructure:"server"`
+
+	// InstanceUID is the UUID identifying the collector on the OpAMP server.
+	// A random UUID is generated once per process if empty.
+	InstanceUID string `mapstructure:"instance_uid"`
+
+	// Capabilities defines what the collector reports and accepts.
+	Capabilities CapabilitiesConfig `mapstructure:"capabilities"`
+
+	// AgentDescription defines additional attributes describing the collector.
+	AgentDescription AgentDescriptionConfig `mapstructure:"agent_description"`
+}
+
+// ServerConfig defines the OpAMP server. Only the plain HTTP transport is supported.
+type ServerConfig struct {
+	HTTP HTTPServerConfig `mapstructure:"http"`
+}
+
+// HTTPServerConfig defines the HTTP client connecting to the OpAMP server.
+type HTTPServerConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// PollingInterval is the interval between two messages sent to the server, which is when the server
+	// can send messages back with the HTTP transport. Defaults to 30s.
+	PollingInterval time.Duration `mapstructure:"polling_interval"`
+}
+
+// CapabilitiesConfig defines the capabilities of the collector reported to the OpAMP server.
+type CapabilitiesConfig struct {
+	// ReportsEffectiveConfig enables reporting the effective configuration of the collector.
+	// Disabled by default, since the configuration may contain secrets.
+	ReportsEffectiveConfig bool `mapstructure:"reports_effective_config"`
+	// ReportsHealth enables reporting the health of the collector and of its components.
+	ReportsHealth bool `mapstructure:"reports_health"`
+	// ReportsPackageStatuses enables reporting the collector binary as a package with its version.
+	ReportsPackageStatuses bool `mapstructure:"reports_package_statuses"`
+	// AcceptsRemoteConfig enables applying the remote configurations offered by the server.
+	// Remote configurations are only applied if the collector is configured with the provider returned
+	// by NewProviderFactory. Disabled by default.
+	AcceptsRemoteConfig bool `mapstructure:"accepts_remote_config"`
+}
+
+// AgentDescriptionConfig defines the attributes describing the collector.
+type AgentDescriptionConfig struct {
+	// NonIdentifyingAttributes are added to the non-identifying attributes of the collector,
+	// e.g. the deployment environment. They override the attributes detected by the extension.
+	NonIdentifyingAttributes map[string]string `mapstructure:"non_identifying_attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Server.HTTP.Endpoint == "" {
+		return errors.New("server::http::endpoint must be specified")
+	}
+	if cfg.Server.HTTP.PollingInterval <= 0 {
+		return errors.New("server::http::polling_interval must be greater than zero")
+	}
+	if cfg.InstanceUID != "" {
+		if _, err := uuid.Parse(cfg.InstanceUID); err != nil {
+			return fmt.Errorf("instance_uid must be a valid UUID: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Server.HTTP.Endpoint = "https://opamp.example.com/v1/opamp"
+	expected.Server.HTTP.PollingInterval = time.Minute
+	expected.InstanceUID = "0190a7b4-3b9c-7c5e-8d4a-2f6e1b3c9d70"
+	expected.Capabilities = CapabilitiesConfig{
+		ReportsEffectiveConfig: true,
+		ReportsHealth:          true,
+		ReportsPackageStatuses: true,
+		AcceptsRemoteConfig:    true,
+	}
+	expected.AgentDescription.NonIdentifyingAttributes = map[string]string{"deployment.environment": "production"}
+	assert.Equal(t, expected, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Config)
+		expected string
+	}{
+		{
+			name:     "missing endpoint",
+			modify:   func(*Config) {},
+			expected: "server::http::endpoint must be specified",
+		},
+		{
+			name: "invalid polling interval",
+			modify: func(cfg *Config) {
+				cfg.Server.HTTP.Endpoint = "http://localhost:4320/v1/opamp"
+				cfg.Server.HTTP.PollingInterval = 0
+			},
+			expected: "server::http::polling_interval must be greater than zero",
+		},
+		{
+			name: "invalid instance uid",
+			modify: func(cfg *Config) {
+				cfg.Server.HTTP.Endpoint = "http://localhost:4320/v1/opamp"
+				cfg.InstanceUID = "collector-1"
+			},
+			expected: "instance_uid must be a valid UUID: invalid UUID length: 11",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
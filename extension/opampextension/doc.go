@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package opampextension implements an extension managing the collector with the OpAMP protocol:
+// it reports the description, the health, the effective configuration and the package statuses
+// of the collector to an OpAMP server, and receives remote configurations served by the confmap
+// provider returned by NewProviderFactory.
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
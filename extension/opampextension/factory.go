@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/metadata"
+)
+
+const defaultPollingInterval = 30 * time.Second
+
+// NewFactory creates a factory for the OpAMP extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Server: ServerConfig{
+			HTTP: HTTPServerConfig{
+				ClientConfig:    confighttp.NewDefaultClientConfig(),
+				PollingInterval: defaultPollingInterval,
+			},
+		},
+		Capabilities: CapabilitiesConfig{
+			ReportsHealth:          true,
+			ReportsPackageStatuses: true,
+		},
+	}
+}
+
+// createExtension creates the extension based on this config.
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newOpAMPAgent(cfg.(*Config), set)
+}
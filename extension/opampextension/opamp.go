@@ -0,0 +1,415 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeYAML     = "text/yaml"
+
+	// maxResponseSize is the maximum size of a message of the server.
+	maxResponseSize = 64 * 1024 * 1024
+)
+
+// generatedInstanceUID is the instance UID of the process when none is configured,
+// kept across the reloads of the collector.
+var generatedInstanceUID = sync.OnceValue(func() uuid.UUID {
+	return uuid.New()
+})
+
+type opampAgent struct {
+	cfg         *Config
+	set         extension.Settings
+	instanceUID []byte
+	startTime   time.Time
+	statuses    *componentstatus.Aggregator
+	// generation is the generation of the remote configuration state when the extension started.
+	generation uint64
+
+	client  *http.Client
+	cancel  context.CancelFunc
+	trigger chan struct{}
+	wg      sync.WaitGroup
+
+	// mu protects the fields below.
+	mu              sync.Mutex
+	sequenceNum     uint64
+	effectiveConfig *confmap.Conf
+	// reported holds the encoding of the fields last reported to the server by name,
+	// so unchanged fields are not reported again.
+	reported map[string][]byte
+}
+
+var (
+	_ extension.Extension       = (*opampAgent)(nil)
+	_ extension.ConfigWatcher   = (*opampAgent)(nil)
+	_ extension.PipelineWatcher = (*opampAgent)(nil)
+	_ componentstatus.Watcher   = (*opampAgent)(nil)
+)
+
+func newOpAMPAgent(cfg *Config, set extension.Settings) (*opampAgent, error) {
+	uid := generatedInstanceUID()
+	if cfg.InstanceUID != "" {
+		var err error
+		if uid, err = uuid.Parse(cfg.InstanceUID); err != nil {
+			return nil, fmt.Errorf("invalid instance_uid: %w", err)
+		}
+	}
+	return &opampAgent{
+		cfg:         cfg,
+		set:         set,
+		instanceUID: uid[:],
+		startTime:   time.Now(),
+		statuses:    componentstatus.NewAggregator(),
+		trigger:     make(chan struct{}, 1),
+		reported:    map[string][]byte{},
+	}, nil
+}
+
+func (a *opampAgent) Start(ctx context.Context, host component.Host) error {
+	client, err := a.cfg.Server.HTTP.ToClient(ctx, host, a.set.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	a.client = client
+	a.generation = remote.started()
+
+	ctx, a.cancel = context.WithCancel(context.Background())
+	a.wg.Add(1)
+	go a.run(ctx)
+	return nil
+}
+
+func (a *opampAgent) Shutdown(ctx context.Context) error {
+	if a.cancel == nil {
+		return nil
+	}
+	a.cancel()
+	a.wg.Wait()
+
+	// The collector stops before it is ready when it fails to start with the remote configuration it reloaded with.
+	if a.cfg.Capabilities.AcceptsRemoteConfig {
+		remote.stopped(a.generation)
+	}
+	// Tell the server that the collector disconnects, best effort.
+	msg, _ := a.buildMessage()
+	msg.AgentDisconnect = &protobufs.AgentDisconnect{}
+	if _, err := a.send(ctx, msg); err != nil {
+		a.set.Logger.Debug("Failed to report the disconnection to the OpAMP server", zap.Error(err))
+	}
+	return nil
+}
+
+// NotifyConfig reports the effective configuration of the collector, if enabled.
+func (a *opampAgent) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	a.mu.Lock()
+	a.effectiveConfig = conf
+	a.mu.Unlock()
+	a.poll()
+	return nil
+}
+
+// Ready reports the remote configuration the collector reloaded with as applied, once the collector is ready.
+func (a *opampAgent) Ready() error {
+	if a.cfg.Capabilities.AcceptsRemoteConfig && remote.ready(a.generation) {
+		a.poll()
+	}
+	return nil
+}
+
+func (a *opampAgent) NotReady() error {
+	return nil
+}
+
+// ComponentStatusChanged reports the health of the collector, if enabled.
+func (a *opampAgent) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	a.statuses.ComponentStatusChanged(source, event)
+	a.poll()
+}
+
+// poll sends a message to the server as soon as possible, e.g. to report a change or to get the pending messages
+// of the server.
+func (a *opampAgent) poll() {
+	select {
+	case a.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (a *opampAgent) run(ctx context.Context) {
+	defer a.wg.Done()
+	ticker := time.NewTicker(a.cfg.Server.HTTP.PollingInterval)
+	defer ticker.Stop()
+	for {
+		a.exchange(ctx)
+		select {
+		case <-ticker.C:
+		case <-a.trigger:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// exchange sends the state of the collector which changed since it was last reported to the server,
+// and processes the message returned by the server.
+func (a *opampAgent) exchange(ctx context.Context) {
+	msg, fields := a.buildMessage()
+	resp, err := a.send(ctx, msg)
+	if err != nil {
+		if ctx.Err() == nil {
+			a.set.Logger.Warn("Failed to exchange messages with the OpAMP server", zap.Error(err))
+		}
+		return
+	}
+
+	a.mu.Lock()
+	for name, b := range fields {
+		a.reported[name] = b
+	}
+	if resp.Flags&uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState) != 0 {
+		clear(a.reported)
+		a.poll()
+	}
+	a.mu.Unlock()
+
+	if resp.ErrorResponse != nil {
+		a.set.Logger.Warn("The OpAMP server returned an error", zap.String("error", resp.ErrorResponse.ErrorMessage))
+	}
+	if resp.RemoteConfig != nil && a.cfg.Capabilities.AcceptsRemoteConfig {
+		status, received := remote.apply(resp.RemoteConfig)
+		if received {
+			if status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED {
+				a.set.Logger.Warn("Failed to apply the remote configuration", zap.String("error", status.ErrorMessage))
+			} else {
+				a.set.Logger.Info("Remote configuration received, reloading the collector")
+			}
+			a.poll()
+		}
+	}
+}
+
+// buildMessage returns the message to send to the server, with the fields which changed since they were last
+// reported, and the encoding of these fields.
+func (a *opampAgent) buildMessage() (*protobufs.AgentToServer, map[string][]byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sequenceNum++
+	msg := &protobufs.AgentToServer{
+		InstanceUid:  a.instanceUID,
+		SequenceNum:  a.sequenceNum,
+		Capabilities: a.capabilities(),
+	}
+	fields := map[string][]byte{}
+	// changed compares the encoding of a message holding a single field with the last reported one.
+	changed := func(name string, m *protobufs.AgentToServer) bool {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		if err != nil {
+			a.set.Logger.Warn("Failed to encode the "+name, zap.Error(err))
+			return true
+		}
+		if bytes.Equal(a.reported[name], b) {
+			return false
+		}
+		fields[name] = b
+		return true
+	}
+
+	if desc := a.agentDescription(); changed("agent_description", &protobufs.AgentToServer{AgentDescription: desc}) {
+		msg.AgentDescription = desc
+	}
+	if a.cfg.Capabilities.ReportsHealth {
+		if health := a.health(); changed("health", &protobufs.AgentToServer{Health: health}) {
+			msg.Health = health
+		}
+	}
+	if a.cfg.Capabilities.ReportsEffectiveConfig && a.effectiveConfig != nil {
+		if ec, err := effectiveConfig(a.effectiveConfig); err != nil {
+			a.set.Logger.Warn("Failed to encode the effective configuration", zap.Error(err))
+		} else if changed("effective_config", &protobufs.AgentToServer{EffectiveConfig: ec}) {
+			msg.EffectiveConfig = ec
+		}
+	}
+	if a.cfg.Capabilities.AcceptsRemoteConfig {
+		if status := remote.lastStatus(); status != nil && changed("remote_config_status", &protobufs.AgentToServer{RemoteConfigStatus: status}) {
+			msg.RemoteConfigStatus = status
+		}
+	}
+	if a.cfg.Capabilities.ReportsPackageStatuses {
+		if ps := a.packageStatuses(); changed("package_statuses", &protobufs.AgentToServer{PackageStatuses: ps}) {
+			msg.PackageStatuses = ps
+		}
+	}
+	return msg, fields
+}
+
+func (a *opampAgent) capabilities() uint64 {
+	capabilities := protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus
+	if a.cfg.Capabilities.ReportsEffectiveConfig {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig
+	}
+	if a.cfg.Capabilities.ReportsHealth {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth
+	}
+	if a.cfg.Capabilities.ReportsPackageStatuses {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+	}
+	if a.cfg.Capabilities.AcceptsRemoteConfig {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
+	}
+	return uint64(capabilities)
+}
+
+func (a *opampAgent) agentDescription() *protobufs.AgentDescription {
+	desc := &protobufs.AgentDescription{
+		IdentifyingAttributes: []*protobufs.KeyValue{
+			stringKeyValue("service.name", a.set.BuildInfo.Command),
+			stringKeyValue("service.version", a.set.BuildInfo.Version),
+			stringKeyValue("service.instance.id", uuid.UUID(a.instanceUID).String()),
+		},
+	}
+	nonIdentifying := map[string]string{
+		"os.type":   runtime.GOOS,
+		"host.arch": runtime.GOARCH,
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		nonIdentifying["host.name"] = hostname
+	}
+	for k, v := range a.cfg.AgentDescription.NonIdentifyingAttributes {
+		nonIdentifying[k] = v
+	}
+	keys := make([]string, 0, len(nonIdentifying))
+	for k := range nonIdentifying {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		desc.NonIdentifyingAttributes = append(desc.NonIdentifyingAttributes, stringKeyValue(k, nonIdentifying[k]))
+	}
+	return desc
+}
+
+func stringKeyValue(key, value string) *protobufs.KeyValue {
+	return &protobufs.KeyValue{
+		Key:   key,
+		Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// health returns the health of the collector, aggregating the statuses of its components.
+func (a *opampAgent) health() *protobufs.ComponentHealth {
+	status := a.statuses.Status()
+	health := &protobufs.ComponentHealth{
+		Healthy:            isHealthy(status),
+		StartTimeUnixNano:  uint64(a.startTime.UnixNano()),
+		Status:             status.String(),
+		ComponentHealthMap: map[string]*protobufs.ComponentHealth{},
+	}
+	var lastErrorTime time.Time
+	a.statuses.Events(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		key := id.Kind().String() + ":" + id.ComponentID().String()
+		ch := &protobufs.ComponentHealth{
+			Healthy:            isHealthy(ev.Status()),
+			Status:             ev.Status().String(),
+			StatusTimeUnixNano: uint64(ev.Timestamp().UnixNano()),
+		}
+		if err := ev.Err(); err != nil {
+			ch.LastError = err.Error()
+			if ev.Timestamp().After(lastErrorTime) {
+				lastErrorTime = ev.Timestamp()
+				health.LastError = ch.LastError
+			}
+		}
+		// A component instance per pipeline reports the same status, keep the worst one.
+		if prev, ok := health.ComponentHealthMap[key]; !ok || (prev.Healthy && !ch.Healthy) {
+			health.ComponentHealthMap[key] = ch
+		}
+		health.StatusTimeUnixNano = max(health.StatusTimeUnixNano, ch.StatusTimeUnixNano)
+	})
+	return health
+}
+
+func isHealthy(status componentstatus.Status) bool {
+	switch status {
+	case componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+		return false
+	}
+	return true
+}
+
+func effectiveConfig(conf *confmap.Conf) (*protobufs.EffectiveConfig, error) {
+	body, err := yaml.Marshal(conf.ToStringMap())
+	if err != nil {
+		return nil, err
+	}
+	return &protobufs.EffectiveConfig{ConfigMap: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+		"": {Body: body, ContentType: contentTypeYAML},
+	}}}, nil
+}
+
+// packageStatuses reports the collector binary as the only package of the agent.
+func (a *opampAgent) packageStatuses() *protobufs.PackageStatuses {
+	name := a.set.BuildInfo.Command
+	return &protobufs.PackageStatuses{Packages: map[string]*protobufs.PackageStatus{
+		name: {
+			Name:            name,
+			AgentHasVersion: a.set.BuildInfo.Version,
+			Status:          protobufs.PackageStatusEnum_PackageStatusEnum_Installed,
+		},
+	}}
+}
+
+// send sends a message to the server with the HTTP transport and returns the message of the server.
+func (a *opampAgent) send(ctx context.Context, msg *protobufs.AgentToServer) (*protobufs.ServerToAgent, error) {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.Server.HTTP.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentTypeProtobuf)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %q", resp.Status)
+	}
+	serverToAgent := &protobufs.ServerToAgent{}
+	if err = proto.Unmarshal(body, serverToAgent); err != nil {
+		return nil, fmt.Errorf("invalid message of the OpAMP server: %w", err)
+	}
+	return serverToAgent, nil
+}
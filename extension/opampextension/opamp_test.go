@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+// fakeServer is an OpAMP server recording the messages of the agent.
+type fakeServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []*protobufs.AgentToServer
+	// responses are returned in order, then empty messages.
+	responses []*protobufs.ServerToAgent
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		msg := &protobufs.AgentToServer{}
+		assert.NoError(t, proto.Unmarshal(body, msg))
+
+		s.mu.Lock()
+		s.messages = append(s.messages, msg)
+		resp := &protobufs.ServerToAgent{InstanceUid: msg.InstanceUid}
+		if len(s.responses) > 0 {
+			resp, s.responses = s.responses[0], s.responses[1:]
+		}
+		s.mu.Unlock()
+		b, err := proto.Marshal(resp)
+		assert.NoError(t, err)
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeServer) respond(resp *protobufs.ServerToAgent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = append(s.responses, resp)
+}
+
+// lastMessage returns the last message matching f, if any.
+func (s *fakeServer) lastMessage(f func(*protobufs.AgentToServer) bool) *protobufs.AgentToServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if f(s.messages[i]) {
+			return s.messages[i]
+		}
+	}
+	return nil
+}
+
+func (s *fakeServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages)
+}
+
+func newTestAgent(t *testing.T, endpoint string, modify func(*Config)) *opampAgent {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Server.HTTP.Endpoint = endpoint
+	cfg.Server.HTTP.PollingInterval = 10 * time.Millisecond
+	cfg.InstanceUID = "0190a7b4-3b9c-7c5e-8d4a-2f6e1b3c9d70"
+	modify(cfg)
+	set := extensiontest.NewNopSettings()
+	set.BuildInfo = component.BuildInfo{Command: "otelcol", Version: "1.2.3"}
+	agent, err := newOpAMPAgent(cfg, set)
+	require.NoError(t, err)
+	return agent
+}
+
+// attributes returns the string attributes by key.
+func attributes(kvs []*protobufs.KeyValue) map[string]string {
+	attrs := map[string]string{}
+	for _, kv := range kvs {
+		attrs[kv.Key] = kv.Value.GetStringValue()
+	}
+	return attrs
+}
+
+// useRemoteState replaces the remote configuration state of the process for the duration of the test.
+func useRemoteState(t *testing.T) *remoteConfigState {
+	prev := remote
+	remote = newRemoteConfigState()
+	t.Cleanup(func() { remote = prev })
+	return remote
+}
+
+func TestAgentReportsState(t *testing.T) {
+	useRemoteState(t)
+	server := newFakeServer(t)
+	agent := newTestAgent(t, server.URL, func(cfg *Config) {
+		cfg.Capabilities.ReportsEffectiveConfig = true
+		cfg.AgentDescription.NonIdentifyingAttributes = map[string]string{"deployment.environment": "test"}
+	})
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, agent.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{"otlp": nil},
+	})))
+	agent.ComponentStatusChanged(componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver),
+		componentstatus.NewRecoverableErrorEvent(errors.New("connection refused")))
+
+	var msg *protobufs.AgentToServer
+	require.Eventually(t, func() bool {
+		msg = server.lastMessage(func(m *protobufs.AgentToServer) bool {
+			return m.EffectiveConfig != nil && m.Health != nil && !m.Health.Healthy
+		})
+		return msg != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses), msg.Capabilities)
+	assert.Equal(t, "receivers:\n    otlp: null\n", string(msg.EffectiveConfig.ConfigMap.ConfigMap[""].Body))
+	assert.Equal(t, "StatusRecoverableError", msg.Health.Status)
+	assert.Equal(t, "connection refused", msg.Health.LastError)
+	assert.Equal(t, "connection refused", msg.Health.ComponentHealthMap["Receiver:otlp"].LastError)
+
+	// The agent description and the package statuses are reported once, with the first message.
+	first := server.lastMessage(func(m *protobufs.AgentToServer) bool { return m.SequenceNum == 1 })
+	require.NotNil(t, first)
+	identifying := attributes(first.AgentDescription.IdentifyingAttributes)
+	assert.Equal(t, "otelcol", identifying["service.name"])
+	assert.Equal(t, "0190a7b4-3b9c-7c5e-8d4a-2f6e1b3c9d70", identifying["service.instance.id"])
+	assert.Equal(t, "test", attributes(first.AgentDescription.NonIdentifyingAttributes)["deployment.environment"])
+	assert.Equal(t, "1.2.3", first.PackageStatuses.Packages["otelcol"].AgentHasVersion)
+	require.Eventually(t, func() bool { return server.count() > 5 }, 5*time.Second, 10*time.Millisecond)
+	assert.Nil(t, server.lastMessage(func(m *protobufs.AgentToServer) bool {
+		return m.SequenceNum > 1 && (m.AgentDescription != nil || m.PackageStatuses != nil)
+	}))
+
+	require.NoError(t, agent.Shutdown(context.Background()))
+	assert.NotNil(t, server.lastMessage(func(m *protobufs.AgentToServer) bool { return m.AgentDisconnect != nil }))
+}
+
+func TestAgentReportFullState(t *testing.T) {
+	useRemoteState(t)
+	server := newFakeServer(t)
+	server.respond(&protobufs.ServerToAgent{})
+	server.respond(&protobufs.ServerToAgent{Flags: uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState)})
+	agent := newTestAgent(t, server.URL, func(*Config) {})
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, agent.Shutdown(context.Background())) }()
+
+	assert.Eventually(t, func() bool {
+		return server.lastMessage(func(m *protobufs.AgentToServer) bool {
+			return m.SequenceNum > 2 && m.AgentDescription != nil
+		}) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAgentRemoteConfig(t *testing.T) {
+	state := useRemoteState(t)
+	provider := NewProviderFactory().Create(confmap.ProviderSettings{})
+	changed := make(chan struct{}, 1)
+	_, err := provider.Retrieve(context.Background(), "opamp:config", func(*confmap.ChangeEvent) { changed <- struct{}{} })
+	require.NoError(t, err)
+
+	server := newFakeServer(t)
+	server.respond(&protobufs.ServerToAgent{RemoteConfig: &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"a.yaml": {Body: []byte("exporters:\n  debug: {}\n"), ContentType: "text/yaml"},
+			"b.yaml": {Body: []byte("exporters:\n  debug:\n    verbosity: detailed\n")},
+		}},
+		ConfigHash: []byte("v1"),
+	}})
+	agent := newTestAgent(t, server.URL, func(cfg *Config) { cfg.Capabilities.AcceptsRemoteConfig = true })
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the remote configuration was not applied")
+	}
+	retrieved, err := provider.Retrieve(context.Background(), "opamp:config", nil)
+	require.NoError(t, err)
+	conf, err := retrieved.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"exporters": map[string]any{"debug": map[string]any{"verbosity": "detailed"}}}, conf.ToStringMap())
+
+	// The remote configuration is applying until the collector reloaded with it.
+	assert.Eventually(t, func() bool {
+		return server.lastMessage(hasRemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "v1")) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, agent.Shutdown(context.Background()))
+	assert.Nil(t, server.lastMessage(hasRemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, "v1")))
+
+	reloaded := newTestAgent(t, server.URL, func(cfg *Config) { cfg.Capabilities.AcceptsRemoteConfig = true })
+	require.NoError(t, reloaded.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, reloaded.Shutdown(context.Background())) }()
+	require.NoError(t, reloaded.Ready())
+	assert.Eventually(t, func() bool {
+		return server.lastMessage(hasRemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "v1")) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The same remote configuration offered again is not applied again.
+	status, received := state.apply(&protobufs.AgentRemoteConfig{ConfigHash: []byte("v1")})
+	assert.False(t, received)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, status.Status)
+}
+
+func TestAgentRemoteConfigReloadFailed(t *testing.T) {
+	state := useRemoteState(t)
+	state.used = true
+	status, received := state.apply(&protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"config": {Body: []byte("exporters:\n  debug: {}\n")},
+		}},
+		ConfigHash: []byte("v1"),
+	})
+	require.True(t, received)
+	require.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, status.Status)
+
+	// The collector reloaded with the remote configuration stops before it is ready.
+	server := newFakeServer(t)
+	agent := newTestAgent(t, server.URL, func(cfg *Config) { cfg.Capabilities.AcceptsRemoteConfig = true })
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, agent.Shutdown(context.Background()))
+
+	msg := server.lastMessage(func(m *protobufs.AgentToServer) bool { return m.AgentDisconnect != nil })
+	require.NotNil(t, msg)
+	require.NotNil(t, msg.RemoteConfigStatus)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, msg.RemoteConfigStatus.Status)
+	assert.Equal(t, "the collector failed to start with the remote configuration", msg.RemoteConfigStatus.ErrorMessage)
+}
+
+// hasRemoteConfigStatus returns a predicate matching the messages reporting the given remote configuration status.
+func hasRemoteConfigStatus(status protobufs.RemoteConfigStatuses, hash string) func(*protobufs.AgentToServer) bool {
+	return func(m *protobufs.AgentToServer) bool {
+		return m.RemoteConfigStatus != nil && m.RemoteConfigStatus.Status == status &&
+			string(m.RemoteConfigStatus.LastRemoteConfigHash) == hash
+	}
+}
+
+func TestAgentRemoteConfigFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		use      bool
+		file     *protobufs.AgentConfigFile
+		expected string
+	}{
+		{
+			name:     "provider not used",
+			file:     &protobufs.AgentConfigFile{Body: []byte("exporters: {}")},
+			expected: `the collector configuration does not use the "opamp:config" URI`,
+		},
+		{
+			name:     "unsupported content type",
+			use:      true,
+			file:     &protobufs.AgentConfigFile{Body: []byte("{}"), ContentType: "application/json"},
+			expected: `config file "config": unsupported content type "application/json"`,
+		},
+		{
+			name:     "invalid yaml",
+			use:      true,
+			file:     &protobufs.AgentConfigFile{Body: []byte("exporters")},
+			expected: `config file "config": retrieved value (type=string) cannot be used as a Conf`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := useRemoteState(t)
+			state.used = tt.use
+			status, received := state.apply(&protobufs.AgentRemoteConfig{
+				Config:     &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{"config": tt.file}},
+				ConfigHash: []byte("v1"),
+			})
+			assert.True(t, received)
+			assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+			assert.Equal(t, tt.expected, status.ErrorMessage)
+		})
+	}
+}
+
+func TestAgentServerUnavailable(t *testing.T) {
+	useRemoteState(t)
+	server := newFakeServer(t)
+	server.Close()
+	agent := newTestAgent(t, server.URL, func(*Config) {})
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, agent.Shutdown(context.Background()))
+	// The state is reported again once the server is available.
+	assert.Empty(t, agent.reported)
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// providerScheme is the scheme of the confmap provider serving the remote configurations.
+	providerScheme = "opamp"
+	// remoteConfigName is the name of the remote configuration, retrieved with the "opamp:config" URI.
+	remoteConfigName = "config"
+)
+
+// remote is the remote configuration state of the process. It is kept across the reloads of the collector
+// triggered by the remote configurations, which recreate the extension.
+var remote = newRemoteConfigState()
+
+// NewProviderFactory returns a factory for the confmap provider serving the remote configurations received
+// by the OpAMP extension with the "opamp:config" URI. The URI resolves to an empty configuration until
+// a remote configuration is received, so it is meant to be merged with a local configuration, e.g.
+// "--config=file:config.yaml --config=opamp:config". The collector reloads when a remote configuration is applied.
+func NewProviderFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return &remoteConfigProvider{MemoryProvider: remote.provider, state: remote}
+	})
+}
+
+// remoteConfigProvider records that the remote configuration is used by the collector.
+type remoteConfigProvider struct {
+	*confmap.MemoryProvider
+	state *remoteConfigState
+}
+
+func (p *remoteConfigProvider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	p.state.mu.Lock()
+	p.state.used = true
+	p.state.mu.Unlock()
+	return p.MemoryProvider.Retrieve(ctx, uri, watcher)
+}
+
+type remoteConfigState struct {
+	provider *confmap.MemoryProvider
+
+	mu sync.Mutex
+	// used is true once the remote configuration is retrieved by the collector.
+	used bool
+	// status is the status of the last remote configuration received, nil if none.
+	status *protobufs.RemoteConfigStatus
+	// generation is incremented when a remote configuration is applied, so the extensions started by the reload
+	// it triggers can tell it apart from the previous ones.
+	generation uint64
+}
+
+func newRemoteConfigState() *remoteConfigState {
+	provider := confmap.NewMemoryProvider(providerScheme)
+	if err := provider.Set(remoteConfigName, map[string]any{}); err != nil {
+		panic(err)
+	}
+	return &remoteConfigState{provider: provider}
+}
+
+// lastStatus returns the status of the last remote configuration received, nil if none.
+func (s *remoteConfigState) lastStatus() *protobufs.RemoteConfigStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// started returns the generation of the state, recorded by an extension when it starts.
+func (s *remoteConfigState) started() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generation
+}
+
+// ready reports the remote configuration being applied as applied, if the collector reloaded with it,
+// i.e. if the extension calling it was started with the current generation. It returns true if the status changed.
+func (s *remoteConfigState) ready(generation uint64) bool {
+	return s.setApplyingStatus(generation, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+}
+
+// stopped reports the remote configuration being applied as failed, if the collector reloaded with it
+// and stopped before it was ready.
+func (s *remoteConfigState) stopped(generation uint64) {
+	s.setApplyingStatus(generation, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		"the collector failed to start with the remote configuration")
+}
+
+// setApplyingStatus replaces the status of the remote configuration being applied by the given one,
+// if the generation is the current one. It returns true if the status changed.
+func (s *remoteConfigState) setApplyingStatus(generation uint64, status protobufs.RemoteConfigStatuses, errMsg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if generation != s.generation || s.status == nil || s.status.Status != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING {
+		return false
+	}
+	// The status may be held by a message being built, replace it instead of updating it.
+	s.status = &protobufs.RemoteConfigStatus{LastRemoteConfigHash: s.status.LastRemoteConfigHash, Status: status, ErrorMessage: errMsg}
+	return true
+}
+
+// apply serves the remote configuration with the provider, unless it is the last remote configuration received,
+// and returns its status. It returns false if the remote configuration was already received.
+// The remote configuration is reported as applying until the collector reloaded with it.
+func (s *remoteConfigState) apply(rc *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, bool) {
+	s.mu.Lock()
+	if s.status != nil && bytes.Equal(s.status.LastRemoteConfigHash, rc.ConfigHash) {
+		s.mu.Unlock()
+		return s.status, false
+	}
+	status := &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: rc.ConfigHash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING,
+	}
+	conf, err := s.remoteConf(rc)
+	if err != nil {
+		status.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+		status.ErrorMessage = err.Error()
+	} else {
+		s.generation++
+	}
+	s.status = status
+	s.mu.Unlock()
+
+	if err == nil {
+		// Set notifies the collector, which reloads and recreates the extension, outside the lock.
+		if err = s.provider.Set(remoteConfigName, conf.ToStringMap()); err != nil {
+			status = &protobufs.RemoteConfigStatus{
+				LastRemoteConfigHash: rc.ConfigHash,
+				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+				ErrorMessage:         err.Error(),
+			}
+			s.mu.Lock()
+			s.status = status
+			s.mu.Unlock()
+		}
+	}
+	return status, true
+}
+
+// remoteConf merges the configuration files of the remote configuration, in the order of their names.
+// Caller must hold the lock.
+func (s *remoteConfigState) remoteConf(rc *protobufs.AgentRemoteConfig) (*confmap.Conf, error) {
+	if !s.used {
+		return nil, fmt.Errorf("the collector configuration does not use the %q URI", providerScheme+":"+remoteConfigName)
+	}
+	var files map[string]*protobufs.AgentConfigFile
+	if rc.Config != nil {
+		files = rc.Config.ConfigMap
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	conf := confmap.New()
+	var errs error
+	for _, name := range names {
+		file := files[name]
+		if file.ContentType != "" && !strings.Contains(file.ContentType, "yaml") {
+			errs = errors.Join(errs, fmt.Errorf("config file %q: unsupported content type %q", name, file.ContentType))
+			continue
+		}
+		retrieved, err := confmap.NewRetrievedFromYAML(file.Body)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("config file %q: %w", name, err))
+			continue
+		}
+		fileConf, err := retrieved.AsConf()
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("config file %q: %w", name, err))
+			continue
+		}
+		if err = conf.Merge(fileConf); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("config file %q: %w", name, err))
+		}
+	}
+	return conf, errs
+}
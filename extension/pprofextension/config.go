@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the extension serving the pprof profiles.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// BlockProfileFraction is the fraction of the goroutine blocking events reported in the blocking
+	// profile, see runtime.SetBlockProfileRate. Zero, the default, disables the blocking profile.
+	BlockProfileFraction int `mapstructure:"block_profile_fraction"`
+
+	// MutexProfileFraction is the fraction of the mutex contention events reported in the mutex profile,
+	// see runtime.SetMutexProfileFraction. Zero, the default, disables the mutex profile.
+	MutexProfileFraction int `mapstructure:"mutex_profile_fraction"`
+
+	// ProfilesDirectory is the directory the profiles captured on demand are written to. Empty, the default,
+	// disables the capture endpoint.
+	ProfilesDirectory string `mapstructure:"profiles_directory"`
+
+	// MaxCaptureDuration is the maximum duration of a CPU profile captured on demand.
+	MaxCaptureDuration time.Duration `mapstructure:"max_capture_duration"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.ServerConfig.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"pprof\" extension")
+	}
+	if cfg.BlockProfileFraction < 0 {
+		return errors.New("'block_profile_fraction' must be non-negative")
+	}
+	if cfg.MutexProfileFraction < 0 {
+		return errors.New("'mutex_profile_fraction' must be non-negative")
+	}
+	if cfg.MaxCaptureDuration <= 0 {
+		return errors.New("'max_capture_duration' must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: "localhost:56888",
+			},
+			BlockProfileFraction: 3,
+			MutexProfileFraction: 5,
+			ProfilesDirectory:    "/var/lib/otelcol/profiles",
+			MaxCaptureDuration:   time.Minute,
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:     "no endpoint",
+			mutate:   func(cfg *Config) { cfg.Endpoint = "" },
+			expected: `"endpoint" is required when using the "pprof" extension`,
+		},
+		{
+			name:     "negative block profile fraction",
+			mutate:   func(cfg *Config) { cfg.BlockProfileFraction = -1 },
+			expected: "'block_profile_fraction' must be non-negative",
+		},
+		{
+			name:     "negative mutex profile fraction",
+			mutate:   func(cfg *Config) { cfg.MutexProfileFraction = -1 },
+			expected: "'mutex_profile_fraction' must be non-negative",
+		},
+		{
+			name:     "no max capture duration",
+			mutate:   func(cfg *Config) { cfg.MaxCaptureDuration = 0 },
+			expected: "'max_capture_duration' must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package pprofextension serves the runtime profiling data of the collector in the format expected by the pprof
+// visualization tool, and captures the profiles on demand to files.
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
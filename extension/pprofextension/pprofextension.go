@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	httppprof "net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+const (
+	cpuProfile = "cpu"
+	// defaultCaptureDuration is the duration of the CPU profiles captured without the seconds parameter.
+	defaultCaptureDuration = 30 * time.Second
+)
+
+type pprofExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+
+	// The mutex profile fraction before the extension started, restored on shutdown.
+	prevMutexProfileFraction int
+
+	// The CPU profile being captured is stopped on shutdown, after which no profile is captured.
+	captureMu      sync.Mutex
+	captureStopped bool
+	captureStopCh  chan struct{}
+	captureWG      sync.WaitGroup
+}
+
+// captureResponse is the response of the capture endpoint.
+type captureResponse struct {
+	Profile  string `json:"profile"`
+	Path     string `json:"path"`
+	Duration string `json:"duration,omitempty"`
+}
+
+func (pe *pprofExtension) Start(ctx context.Context, host component.Host) error {
+	runtime.SetBlockProfileRate(pe.config.BlockProfileFraction)
+	pe.prevMutexProfileFraction = runtime.SetMutexProfileFraction(pe.config.MutexProfileFraction)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+	if pe.config.ProfilesDirectory != "" {
+		mux.HandleFunc("/debug/pprof/capture", pe.handleCapture)
+	}
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := pe.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	pe.telemetry.Logger.Info("Starting pprof extension", zap.Any("config", pe.config))
+	pe.server, err = pe.config.ToServer(ctx, host, pe.telemetry, mux)
+	if err != nil {
+		return err
+	}
+	pe.stopCh = make(chan struct{})
+	go func() {
+		defer close(pe.stopCh)
+
+		if errHTTP := pe.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+func (pe *pprofExtension) Shutdown(ctx context.Context) error {
+	if pe.server == nil {
+		return nil
+	}
+	err := pe.config.Shutdown(ctx, pe.server)
+	if pe.stopCh != nil {
+		<-pe.stopCh
+	}
+
+	// Stop the CPU profile being captured, the profile written so far is kept.
+	pe.captureMu.Lock()
+	pe.captureStopped = true
+	close(pe.captureStopCh)
+	pe.captureMu.Unlock()
+	pe.captureWG.Wait()
+
+	runtime.SetBlockProfileRate(0)
+	runtime.SetMutexProfileFraction(pe.prevMutexProfileFraction)
+	return err
+}
+
+// handleCapture captures the profile named by the profile parameter to a file of the profiles directory: the CPU
+// profile, the default, during the number of seconds of the seconds parameter in the background, the other
+// profiles of runtime/pprof, e.g. heap or goroutine, immediately.
+func (pe *pprofExtension) handleCapture(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("profile")
+	if name == "" {
+		name = cpuProfile
+	}
+	if name == cpuProfile {
+		pe.captureCPUProfile(w, r)
+		return
+	}
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, fmt.Sprintf("unknown profile %q", name), http.StatusBadRequest)
+		return
+	}
+	path, f, err := pe.createProfileFile(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = profile.WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = f.Close(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	pe.telemetry.Logger.Info("Profile captured", zap.String("profile", name), zap.String("path", path))
+	writeCaptureResponse(w, http.StatusOK, captureResponse{Profile: name, Path: path})
+}
+
+func (pe *pprofExtension) captureCPUProfile(w http.ResponseWriter, r *http.Request) {
+	duration := defaultCaptureDuration
+	if s := r.URL.Query().Get("seconds"); s != "" {
+		seconds, err := strconv.Atoi(s)
+		if err != nil || seconds <= 0 {
+			http.Error(w, fmt.Sprintf("invalid seconds %q", s), http.StatusBadRequest)
+			return
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+	if duration > pe.config.MaxCaptureDuration {
+		http.Error(w, fmt.Sprintf("the duration must not exceed %v", pe.config.MaxCaptureDuration), http.StatusBadRequest)
+		return
+	}
+
+	pe.captureMu.Lock()
+	defer pe.captureMu.Unlock()
+	if pe.captureStopped {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	path, f, err := pe.createProfileFile(cpuProfile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Only one CPU profile can be captured at a time, including by the profile endpoint.
+	if err = pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	pe.captureWG.Add(1)
+	go func() {
+		defer pe.captureWG.Done()
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-pe.captureStopCh:
+		}
+		pprof.StopCPUProfile()
+		if errClose := f.Close(); errClose != nil {
+			pe.telemetry.Logger.Warn("Failed to write the profile", zap.String("profile", cpuProfile), zap.Error(errClose))
+			return
+		}
+		pe.telemetry.Logger.Info("Profile captured", zap.String("profile", cpuProfile), zap.String("path", path))
+	}()
+	writeCaptureResponse(w, http.StatusAccepted, captureResponse{Profile: cpuProfile, Path: path, Duration: duration.String()})
+}
+
+// createProfileFile creates the file of a profile captured now.
+func (pe *pprofExtension) createProfileFile(name string) (string, *os.File, error) {
+	path := filepath.Join(pe.config.ProfilesDirectory, fmt.Sprintf("%s-%s.pprof", name, time.Now().UTC().Format("20060102T150405.000Z")))
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return "", nil, err
+	}
+	return path, f, nil
+}
+
+func writeCaptureResponse(w http.ResponseWriter, status int, resp captureResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newServer(config *Config, telemetry component.TelemetrySettings) *pprofExtension {
+	return &pprofExtension{
+		config:        config,
+		telemetry:     telemetry,
+		captureStopCh: make(chan struct{}),
+	}
+}
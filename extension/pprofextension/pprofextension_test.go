@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func startExtension(t *testing.T, profilesDirectory string) string {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		ProfilesDirectory:  profilesDirectory,
+		MaxCaptureDuration: time.Minute,
+	}
+	pprofExt := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, pprofExt.Shutdown(context.Background())) })
+	return "http://" + cfg.ServerConfig.Endpoint + "/debug/pprof/"
+}
+
+func TestPprofExtensionUsage(t *testing.T) {
+	url := startExtension(t, "")
+
+	resp, err := http.Get(url + "goroutine?debug=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The capture endpoint requires a profiles directory.
+	resp, err = http.Post(url+"capture?profile=heap", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCaptureProfile(t *testing.T) {
+	dir := t.TempDir()
+	url := startExtension(t, dir)
+
+	resp, err := http.Post(url+"capture?profile=heap", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var captured captureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&captured))
+	assert.Equal(t, "heap", captured.Profile)
+	assert.Equal(t, dir, filepath.Dir(captured.Path))
+	info, err := os.Stat(captured.Path)
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+}
+
+func TestCaptureCPUProfile(t *testing.T) {
+	dir := t.TempDir()
+	url := startExtension(t, dir)
+
+	resp, err := http.Post(url+"capture?seconds=1", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var captured captureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&captured))
+	assert.Equal(t, captureResponse{Profile: "cpu", Path: captured.Path, Duration: "1s"}, captured)
+
+	// Only one CPU profile is captured at a time.
+	resp, err = http.Post(url+"capture?profile=cpu", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	assert.Eventually(t, func() bool {
+		info, errStat := os.Stat(captured.Path)
+		return errStat == nil && info.Size() > 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestCaptureInvalidRequest(t *testing.T) {
+	url := startExtension(t, t.TempDir())
+
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		status int
+	}{
+		{name: "not post", method: http.MethodGet, query: "profile=heap", status: http.StatusMethodNotAllowed},
+		{name: "unknown profile", method: http.MethodPost, query: "profile=unknown", status: http.StatusBadRequest},
+		{name: "invalid seconds", method: http.MethodPost, query: "seconds=abc", status: http.StatusBadRequest},
+		{name: "negative seconds", method: http.MethodPost, query: "seconds=-1", status: http.StatusBadRequest},
+		{name: "too long", method: http.MethodPost, query: "seconds=3600", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url+"capture?"+tt.query, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestShutdownStopsCapture(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		ProfilesDirectory:  dir,
+		MaxCaptureDuration: time.Minute,
+	}
+	pprofExt := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+
+	resp, err := http.Post("http://"+cfg.ServerConfig.Endpoint+"/debug/pprof/capture?seconds=60", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	start := time.Now()
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+	assert.Less(t, time.Since(start), 30*time.Second)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
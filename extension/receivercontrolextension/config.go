@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivercontrolextension // import "go.opentelemetry.io/collector/extension/receivercontrolextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the extension pausing and resuming the receivers.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.ServerConfig.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"receivercontrol\" extension")
+	}
+	return nil
+}
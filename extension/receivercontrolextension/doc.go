@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package receivercontrolextension serves an API pausing and resuming the receivers of the collector at runtime,
+// without restarting it.
+package receivercontrolextension // import "go.opentelemetry.io/collector/extension/receivercontrolextension"
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivercontrolextension // import "go.opentelemetry.io/collector/extension/receivercontrolextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+// receiverController is implemented by the component.Host of the collector, to pause and resume its receivers.
+type receiverController interface {
+	GetReceivers() map[component.ID]bool
+	PauseReceiver(ctx context.Context, id component.ID) error
+	ResumeReceiver(ctx context.Context, id component.ID) error
+}
+
+type receiverControlExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+
+	// The controller is nil if the host does not support pausing the receivers.
+	controller receiverController
+}
+
+// receiverState is the state of a receiver, as returned by the endpoints.
+type receiverState struct {
+	ID     string `json:"id"`
+	Paused bool   `json:"paused"`
+}
+
+// receiversResponse is the body returned by the receivers endpoint.
+type receiversResponse struct {
+	Receivers []receiverState `json:"receivers"`
+}
+
+func (rc *receiverControlExtension) Start(ctx context.Context, host component.Host) error {
+	if controller, ok := host.(receiverController); ok {
+		rc.controller = controller
+	} else {
+		rc.telemetry.Logger.Warn("The host does not support pausing the receivers, the requests will be refused")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/receivers", rc.handleReceivers)
+	mux.HandleFunc("/receivers/pause", func(w http.ResponseWriter, r *http.Request) {
+		rc.handleStateChange(w, r, true)
+	})
+	mux.HandleFunc("/receivers/resume", func(w http.ResponseWriter, r *http.Request) {
+		rc.handleStateChange(w, r, false)
+	})
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := rc.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	rc.telemetry.Logger.Info("Starting receiver control extension", zap.Any("config", rc.config))
+	rc.server, err = rc.config.ToServer(ctx, host, rc.telemetry, mux)
+	if err != nil {
+		return err
+	}
+	rc.stopCh = make(chan struct{})
+	go func() {
+		defer close(rc.stopCh)
+
+		if errHTTP := rc.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+func (rc *receiverControlExtension) Shutdown(ctx context.Context) error {
+	if rc.server == nil {
+		return nil
+	}
+	err := rc.config.Shutdown(ctx, rc.server)
+	if rc.stopCh != nil {
+		<-rc.stopCh
+	}
+	return err
+}
+
+// handleReceivers lists the receivers of the pipelines, sorted by ID, with whether they are paused.
+func (rc *receiverControlExtension) handleReceivers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if rc.controller == nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	receivers := rc.controller.GetReceivers()
+	resp := receiversResponse{Receivers: make([]receiverState, 0, len(receivers))}
+	for id, paused := range receivers {
+		resp.Receivers = append(resp.Receivers, receiverState{ID: id.String(), Paused: paused})
+	}
+	sort.Slice(resp.Receivers, func(i, j int) bool { return resp.Receivers[i].ID < resp.Receivers[j].ID })
+	writeResponse(w, resp)
+}
+
+// handleStateChange pauses or resumes the receiver named by the id parameter.
+func (rc *receiverControlExtension) handleStateChange(w http.ResponseWriter, r *http.Request, pause bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if rc.controller == nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	var id component.ID
+	if err := id.UnmarshalText([]byte(r.URL.Query().Get("id"))); err != nil {
+		http.Error(w, fmt.Sprintf("invalid receiver id: %v", err), http.StatusBadRequest)
+		return
+	}
+	if _, ok := rc.controller.GetReceivers()[id]; !ok {
+		http.Error(w, fmt.Sprintf("unknown receiver %q", id), http.StatusNotFound)
+		return
+	}
+
+	var err error
+	if pause {
+		err = rc.controller.PauseReceiver(r.Context(), id)
+	} else {
+		err = rc.controller.ResumeReceiver(r.Context(), id)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rc.telemetry.Logger.Info("Receiver state changed", zap.Stringer("receiver", id), zap.Bool("paused", pause))
+	writeResponse(w, receiverState{ID: id.String(), Paused: pause})
+}
+
+func writeResponse(w http.ResponseWriter, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newServer(config *Config, telemetry component.TelemetrySettings) *receiverControlExtension {
+	return &receiverControlExtension{
+		config:    config,
+		telemetry: telemetry,
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivercontrolextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+// controllerHost is a host pausing and resuming its receivers.
+type controllerHost struct {
+	component.Host
+	mu        sync.Mutex
+	receivers map[component.ID]bool
+	err       error
+}
+
+func (h *controllerHost) GetReceivers() map[component.ID]bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	receivers := make(map[component.ID]bool, len(h.receivers))
+	for id, paused := range h.receivers {
+		receivers[id] = paused
+	}
+	return receivers
+}
+
+func (h *controllerHost) PauseReceiver(_ context.Context, id component.ID) error {
+	return h.setPaused(id, true)
+}
+
+func (h *controllerHost) ResumeReceiver(_ context.Context, id component.ID) error {
+	return h.setPaused(id, false)
+}
+
+func (h *controllerHost) setPaused(id component.ID, paused bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.err != nil {
+		return h.err
+	}
+	h.receivers[id] = paused
+	return nil
+}
+
+func startExtension(t *testing.T, host component.Host) string {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+	}
+	ext := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	return "http://" + cfg.ServerConfig.Endpoint + "/receivers"
+}
+
+func getReceivers(t *testing.T, url string) receiversResponse {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var receivers receiversResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&receivers))
+	return receivers
+}
+
+func TestPauseResumeReceiver(t *testing.T) {
+	host := &controllerHost{
+		Host: componenttest.NewNopHost(),
+		receivers: map[component.ID]bool{
+			component.MustNewID("otlp"):                  false,
+			component.MustNewIDWithName("otlp", "other"): false,
+		},
+	}
+	url := startExtension(t, host)
+
+	assert.Equal(t, receiversResponse{Receivers: []receiverState{
+		{ID: "otlp", Paused: false},
+		{ID: "otlp/other", Paused: false},
+	}}, getReceivers(t, url))
+
+	resp, err := http.Post(url+"/pause?id=otlp/other", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var state receiverState
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+	assert.Equal(t, receiverState{ID: "otlp/other", Paused: true}, state)
+	assert.Equal(t, receiversResponse{Receivers: []receiverState{
+		{ID: "otlp", Paused: false},
+		{ID: "otlp/other", Paused: true},
+	}}, getReceivers(t, url))
+
+	resp, err = http.Post(url+"/resume?id=otlp/other", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, receiversResponse{Receivers: []receiverState{
+		{ID: "otlp", Paused: false},
+		{ID: "otlp/other", Paused: false},
+	}}, getReceivers(t, url))
+}
+
+func TestInvalidRequest(t *testing.T) {
+	host := &controllerHost{
+		Host:      componenttest.NewNopHost(),
+		receivers: map[component.ID]bool{component.MustNewID("otlp"): false},
+	}
+	url := startExtension(t, host)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{name: "list not get", method: http.MethodPost, path: "", status: http.StatusMethodNotAllowed},
+		{name: "pause not post", method: http.MethodGet, path: "/pause?id=otlp", status: http.StatusMethodNotAllowed},
+		{name: "no id", method: http.MethodPost, path: "/pause", status: http.StatusBadRequest},
+		{name: "invalid id", method: http.MethodPost, path: "/resume?id=otlp/", status: http.StatusBadRequest},
+		{name: "unknown receiver", method: http.MethodPost, path: "/pause?id=unknown", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url+tt.path, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestPauseReceiverError(t *testing.T) {
+	host := &controllerHost{
+		Host:      componenttest.NewNopHost(),
+		receivers: map[component.ID]bool{component.MustNewID("otlp"): false},
+		err:       errors.New("failed to pause"),
+	}
+	url := startExtension(t, host)
+
+	resp, err := http.Post(url+"/pause?id=otlp", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestHostNotSupported(t *testing.T) {
+	url := startExtension(t, componenttest.NewNopHost())
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	resp, err = http.Post(url+"/pause?id=otlp", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
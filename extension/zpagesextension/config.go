@@ -13,6 +13,10 @@ import (
 // Config has the configuration for the extension enabling the zPages extension.
 type Config struct {
 	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// AllowFeatureGateChanges allows enabling and disabling the dynamic feature gates at runtime from the
+	// featurez page. The zPages are read-only otherwise.
+	AllowFeatureGateChanges bool `mapstructure:"allow_feature_gate_changes"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -15,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
 const (
@@ -45,6 +46,10 @@ type registerableTracerProvider interface {
 }
 
 func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) error {
+	if runtimeprofile.IsAgent() {
+		zpe.telemetry.Logger.Warn("zPages are disabled by the agent runtime profile")
+		return nil
+	}
 
 	zPagesMux := http.NewServeMux()
 
@@ -75,7 +80,11 @@ func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) erro
 	}
 
 	zpe.telemetry.Logger.Info("Starting zPages extension", zap.Any("config", zpe.config))
-	zpe.server, err = zpe.config.ToServer(ctx, host, zpe.telemetry, zPagesMux)
+	var handler http.Handler = zPagesMux
+	if !zpe.config.AllowFeatureGateChanges {
+		handler = readOnlyHandler(zPagesMux)
+	}
+	zpe.server, err = zpe.config.ToServer(ctx, host, zpe.telemetry, handler)
 	if err != nil {
 		return err
 	}
@@ -91,11 +100,11 @@ func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) erro
 	return nil
 }
 
-func (zpe *zpagesExtension) Shutdown(context.Context) error {
+func (zpe *zpagesExtension) Shutdown(ctx context.Context) error {
 	if zpe.server == nil {
 		return nil
 	}
-	err := zpe.server.Close()
+	err := zpe.config.Shutdown(ctx, zpe.server)
 	if zpe.stopCh != nil {
 		<-zpe.stopCh
 	}
@@ -111,6 +120,18 @@ func (zpe *zpagesExtension) Shutdown(context.Context) error {
 	return err
 }
 
+// readOnlyHandler rejects the requests which may change the state of the collector.
+func readOnlyHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func newServer(config *Config, telemetry component.TelemetrySettings) *zpagesExtension {
 	return &zpagesExtension{
 		config:              config,
@@ -19,6 +19,7 @@ type Gate struct {
 	fromVersion  *version.Version
 	toVersion    *version.Version
 	stage        Stage
+	dynamic      bool
 	enabled      *atomic.Bool
 }
 
@@ -32,6 +33,11 @@ func (g *Gate) IsEnabled() bool {
 	return g.enabled.Load()
 }
 
+// IsDynamic returns true if the Gate can be enabled or disabled at runtime with Registry.SetAtRuntime.
+func (g *Gate) IsDynamic() bool {
+	return g.dynamic
+}
+
 // Description returns the description for the Gate.
 func (g *Gate) Description() string {
 	return g.description
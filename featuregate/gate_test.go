@@ -25,6 +25,7 @@ func TestGate(t *testing.T) {
 		description:  "test gate",
 		enabled:      enabled,
 		stage:        StageAlpha,
+		dynamic:      true,
 		referenceURL: "http://example.com",
 		fromVersion:  from,
 		toVersion:    to,
@@ -34,6 +35,7 @@ func TestGate(t *testing.T) {
 	assert.Equal(t, "test gate", g.Description())
 	assert.True(t, g.IsEnabled())
 	assert.Equal(t, StageAlpha, g.Stage())
+	assert.True(t, g.IsDynamic())
 	assert.Equal(t, "http://example.com", g.ReferenceURL())
 	assert.Equal(t, "v0.61.0", g.FromVersion())
 	assert.Equal(t, "v0.64.0", g.ToVersion())
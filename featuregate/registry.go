@@ -53,6 +53,16 @@ func (ro registerOptionFunc) apply(g *Gate) error {
 	return ro(g)
 }
 
+// WithRegisterDynamic allows the Gate to be enabled or disabled at runtime with Registry.SetAtRuntime.
+// Only the alpha and beta gates can be dynamic, and only the gates checked every time the feature is used,
+// rather than once when the components are created, should be.
+func WithRegisterDynamic() RegisterOption {
+	return registerOptionFunc(func(g *Gate) error {
+		g.dynamic = true
+		return nil
+	})
+}
+
 // WithRegisterDescription adds description for the Gate.
 func WithRegisterDescription(description string) RegisterOption {
 	return registerOptionFunc(func(g *Gate) error {
@@ -158,6 +168,10 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 		return nil, fmt.Errorf("no removal version set for %v gate %q", g.stage.String(), id)
 	}
 
+	if (g.stage == StageStable || g.stage == StageDeprecated) && g.dynamic {
+		return nil, fmt.Errorf("%v gate %q cannot be dynamic", g.stage.String(), id)
+	}
+
 	if g.fromVersion != nil && g.toVersion != nil && g.toVersion.LessThan(g.fromVersion) {
 		return nil, fmt.Errorf("toVersion %q is before fromVersion %q", g.toVersion, g.fromVersion)
 	}
@@ -170,15 +184,10 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 
 // Set the enabled valued for a Gate identified by the given id.
 func (r *Registry) Set(id string, enabled bool) error {
-	v, ok := r.gates.Load(id)
-	if !ok {
-		validGates := []string{}
-		r.VisitAll(func(g *Gate) {
-			validGates = append(validGates, g.ID())
-		})
-		return fmt.Errorf("no such feature gate %q. valid gates: %v", id, validGates)
+	g, err := r.get(id)
+	if err != nil {
+		return err
 	}
-	g := v.(*Gate)
 
 	switch g.stage {
 	case StageStable:
@@ -197,6 +206,32 @@ func (r *Registry) Set(id string, enabled bool) error {
 	return nil
 }
 
+// SetAtRuntime sets the enabled value for a dynamic Gate identified by the given id, after the components
+// were created. It returns an error if the Gate is not dynamic, see WithRegisterDynamic.
+func (r *Registry) SetAtRuntime(id string, enabled bool) error {
+	g, err := r.get(id)
+	if err != nil {
+		return err
+	}
+	if !g.dynamic {
+		return fmt.Errorf("feature gate %q is not dynamic, can not be changed at runtime", id)
+	}
+	g.enabled.Store(enabled)
+	return nil
+}
+
+func (r *Registry) get(id string) (*Gate, error) {
+	v, ok := r.gates.Load(id)
+	if !ok {
+		validGates := []string{}
+		r.VisitAll(func(g *Gate) {
+			validGates = append(validGates, g.ID())
+		})
+		return nil, fmt.Errorf("no such feature gate %q. valid gates: %v", id, validGates)
+	}
+	return v.(*Gate), nil
+}
+
 // VisitAll visits all the gates in lexicographical order, calling fn for each.
 func (r *Registry) VisitAll(fn func(*Gate)) {
 	var gates []*Gate
@@ -59,6 +59,28 @@ func TestRegistryApplyError(t *testing.T) {
 	assert.Error(t, r.Set("deprecated", true))
 }
 
+func TestRegistrySetAtRuntime(t *testing.T) {
+	r := NewRegistry()
+	assert.Error(t, r.SetAtRuntime("foo", true))
+
+	g := r.MustRegister("foo", StageAlpha, WithRegisterDynamic())
+	assert.True(t, g.IsDynamic())
+	require.NoError(t, r.SetAtRuntime("foo", true))
+	assert.True(t, g.IsEnabled())
+	require.NoError(t, r.SetAtRuntime("foo", false))
+	assert.False(t, g.IsEnabled())
+
+	g = r.MustRegister("bar", StageBeta)
+	assert.False(t, g.IsDynamic())
+	assert.EqualError(t, r.SetAtRuntime("bar", false), `feature gate "bar" is not dynamic, can not be changed at runtime`)
+	assert.True(t, g.IsEnabled())
+
+	_, err := r.Register("stable", StageStable, WithRegisterToVersion("v1.0.0"), WithRegisterDynamic())
+	assert.EqualError(t, err, `Stable gate "stable" cannot be dynamic`)
+	_, err = r.Register("deprecated", StageDeprecated, WithRegisterToVersion("v1.0.0"), WithRegisterDynamic())
+	assert.EqualError(t, err, `Deprecated gate "deprecated" cannot be dynamic`)
+}
+
 func TestRegistryApply(t *testing.T) {
 	r := NewRegistry()
 	fooGate := r.MustRegister("foo", StageAlpha, WithRegisterDescription("Test Gate"))
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterexpr // import "go.opentelemetry.io/collector/filter/filterexpr"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// SpanContext is a span, with its resource and scope.
+//
+// The paths of the span conditions are name, kind, status.code, status.message and attributes["key"],
+// along with the paths of the resource and the scope: resource.attributes["key"], instrumentation_scope.name,
+// instrumentation_scope.version and instrumentation_scope.attributes["key"].
+type SpanContext struct {
+	Resource pcommon.Resource
+	Scope    pcommon.InstrumentationScope
+	Span     ptrace.Span
+}
+
+// LogContext is a log record, with its resource and scope.
+//
+// The paths of the log conditions are body, severity_number, severity_text and attributes["key"], along
+// with the paths of the resource and the scope.
+type LogContext struct {
+	Resource  pcommon.Resource
+	Scope     pcommon.InstrumentationScope
+	LogRecord plog.LogRecord
+}
+
+// DataPointContext is a data point, with its metric, resource and scope.
+//
+// The paths of the data point conditions are metric.name, metric.description, metric.unit, metric.type, e.g.
+// "Gauge" or "Sum", and attributes["key"] for the attributes of the data point, along with the paths of the
+// resource and the scope.
+type DataPointContext struct {
+	Resource   pcommon.Resource
+	Scope      pcommon.InstrumentationScope
+	Metric     pmetric.Metric
+	Attributes pcommon.Map
+}
+
+// ParseSpanCondition parses a condition on spans.
+func ParseSpanCondition(expr string) (*Condition[SpanContext], error) {
+	return parse(expr, resolveSpan)
+}
+
+// ParseLogCondition parses a condition on log records.
+func ParseLogCondition(expr string) (*Condition[LogContext], error) {
+	return parse(expr, resolveLog)
+}
+
+// ParseDataPointCondition parses a condition on data points.
+func ParseDataPointCondition(expr string) (*Condition[DataPointContext], error) {
+	return parse(expr, resolveDataPoint)
+}
+
+func resolveSpan(p path) (func(SpanContext) any, bool) {
+	switch {
+	case p.is("name"):
+		return func(ctx SpanContext) any { return ctx.Span.Name() }, true
+	case p.is("kind"):
+		return func(ctx SpanContext) any { return int64(ctx.Span.Kind()) }, true
+	case p.is("status", "code"):
+		return func(ctx SpanContext) any { return int64(ctx.Span.Status().Code()) }, true
+	case p.is("status", "message"):
+		return func(ctx SpanContext) any { return ctx.Span.Status().Message() }, true
+	case p.isKey("attributes"):
+		return func(ctx SpanContext) any { return fromValue(ctx.Span.Attributes().Get(p.key)) }, true
+	}
+	return resolveScope(p,
+		func(ctx SpanContext) pcommon.Resource { return ctx.Resource },
+		func(ctx SpanContext) pcommon.InstrumentationScope { return ctx.Scope })
+}
+
+func resolveLog(p path) (func(LogContext) any, bool) {
+	switch {
+	case p.is("body"):
+		return func(ctx LogContext) any { return fromValue(ctx.LogRecord.Body(), true) }, true
+	case p.is("severity_number"):
+		return func(ctx LogContext) any { return int64(ctx.LogRecord.SeverityNumber()) }, true
+	case p.is("severity_text"):
+		return func(ctx LogContext) any { return ctx.LogRecord.SeverityText() }, true
+	case p.isKey("attributes"):
+		return func(ctx LogContext) any { return fromValue(ctx.LogRecord.Attributes().Get(p.key)) }, true
+	}
+	return resolveScope(p,
+		func(ctx LogContext) pcommon.Resource { return ctx.Resource },
+		func(ctx LogContext) pcommon.InstrumentationScope { return ctx.Scope })
+}
+
+func resolveDataPoint(p path) (func(DataPointContext) any, bool) {
+	switch {
+	case p.is("metric", "name"):
+		return func(ctx DataPointContext) any { return ctx.Metric.Name() }, true
+	case p.is("metric", "description"):
+		return func(ctx DataPointContext) any { return ctx.Metric.Description() }, true
+	case p.is("metric", "unit"):
+		return func(ctx DataPointContext) any { return ctx.Metric.Unit() }, true
+	case p.is("metric", "type"):
+		return func(ctx DataPointContext) any { return ctx.Metric.Type().String() }, true
+	case p.isKey("attributes"):
+		return func(ctx DataPointContext) any { return fromValue(ctx.Attributes.Get(p.key)) }, true
+	}
+	return resolveScope(p,
+		func(ctx DataPointContext) pcommon.Resource { return ctx.Resource },
+		func(ctx DataPointContext) pcommon.InstrumentationScope { return ctx.Scope })
+}
+
+// resolveScope resolves the paths of the resource and the scope, shared by all the contexts.
+func resolveScope[K any](p path, resource func(K) pcommon.Resource, scope func(K) pcommon.InstrumentationScope) (func(K) any, bool) {
+	switch {
+	case p.isKey("resource", "attributes"):
+		return func(ctx K) any { return fromValue(resource(ctx).Attributes().Get(p.key)) }, true
+	case p.is("instrumentation_scope", "name"):
+		return func(ctx K) any { return scope(ctx).Name() }, true
+	case p.is("instrumentation_scope", "version"):
+		return func(ctx K) any { return scope(ctx).Version() }, true
+	case p.isKey("instrumentation_scope", "attributes"):
+		return func(ctx K) any { return fromValue(scope(ctx).Attributes().Get(p.key)) }, true
+	}
+	return nil, false
+}
+
+// is returns whether the path has the given names and no key.
+func (p path) is(names ...string) bool {
+	return !p.hasKey && slices.Equal(p.names, names)
+}
+
+// isKey returns whether the path has the given names and a key.
+func (p path) isKey(names ...string) bool {
+	return p.hasKey && slices.Equal(p.names, names)
+}
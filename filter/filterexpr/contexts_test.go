@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestParseSpanCondition(t *testing.T) {
+	ctx := SpanContext{
+		Resource: pcommon.NewResource(),
+		Scope:    pcommon.NewInstrumentationScope(),
+		Span:     ptrace.NewSpan(),
+	}
+	ctx.Resource.Attributes().PutStr("service.name", "checkout")
+	ctx.Scope.SetVersion("1.2.0")
+	ctx.Scope.Attributes().PutStr("library", "net/http")
+	ctx.Span.SetName("GET /health")
+	ctx.Span.SetKind(ptrace.SpanKindServer)
+	ctx.Span.Status().SetCode(ptrace.StatusCodeError)
+	ctx.Span.Status().SetMessage("timeout")
+	ctx.Span.Attributes().PutInt("http.status_code", 504)
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `kind == SPAN_KIND_SERVER and name matches "^GET /health"`, want: true},
+		{expr: `kind == SPAN_KIND_CLIENT`, want: false},
+		{expr: `status.code == STATUS_CODE_ERROR and status.message == "timeout"`, want: true},
+		{expr: `attributes["http.status_code"] >= 500`, want: true},
+		{expr: `resource.attributes["service.name"] == "checkout"`, want: true},
+		{expr: `instrumentation_scope.version == "1.2.0" and instrumentation_scope.attributes["library"] == "net/http"`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := ParseSpanCondition(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cond.Eval(ctx))
+		})
+	}
+
+	_, err := ParseSpanCondition(`severity_number >= WARN`)
+	assert.EqualError(t, err, "unknown path severity_number at position 0")
+}
+
+func TestParseDataPointCondition(t *testing.T) {
+	m := pmetric.NewMetric()
+	m.SetName("http.server.duration")
+	m.SetDescription("duration of the requests")
+	m.SetUnit("ms")
+	dp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("http.route", "/users")
+	ctx := DataPointContext{
+		Resource:   pcommon.NewResource(),
+		Scope:      pcommon.NewInstrumentationScope(),
+		Metric:     m,
+		Attributes: dp.Attributes(),
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `metric.name == "http.server.duration" and metric.unit == "ms"`, want: true},
+		{expr: `metric.type == "Histogram"`, want: true},
+		{expr: `metric.description matches "requests"`, want: true},
+		{expr: `attributes["http.route"] == "/users" and resource.attributes["host"] == nil`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := ParseDataPointCondition(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cond.Eval(ctx))
+		})
+	}
+
+	_, err := ParseDataPointCondition(`name == "a"`)
+	assert.EqualError(t, err, "unknown path name at position 0")
+}
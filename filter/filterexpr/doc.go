@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filterexpr provides a small expression language to match telemetry items, e.g. in the
+// filtering and routing components. A condition is parsed once for the context of a signal, then
+// evaluated on the items of that signal:
+//
+//	cond, err := filterexpr.ParseLogCondition(`resource.attributes["env"] == "prod" and severity_number >= WARN`)
+//	...
+//	if cond.Eval(filterexpr.LogContext{Resource: res, Scope: scope, LogRecord: lr}) { ... }
+//
+// A condition combines comparisons with the "and", "or" and "not" operators and parentheses. The
+// comparison operators are ==, !=, <, <=, >, >= and "matches", whose right operand is a regular
+// expression string literal. The operands are:
+//
+//   - the literals: strings between double quotes, integers, floats, true, false and nil;
+//   - the paths of the context, e.g. name, attributes["key"] or resource.attributes["key"], nil if the
+//     value is not set;
+//   - the enum constants: the severities, e.g. WARN or ERROR2, the span kinds, e.g. SPAN_KIND_SERVER,
+//     and the status codes, e.g. STATUS_CODE_ERROR.
+//
+// Comparing values of different types, e.g. a string and an integer, is false, except for integers
+// and floats which are compared by their numeric value.
+package filterexpr // import "go.opentelemetry.io/collector/filter/filterexpr"
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterexpr // import "go.opentelemetry.io/collector/filter/filterexpr"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenInt
+	tokenFloat
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenDot
+)
+
+type token struct {
+	kind tokenKind
+	// text is the source of the token, unquoted for strings.
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at position %d", t.text, t.pos)
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// tokenize splits the expression into tokens, terminated by a tokenEOF token.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(expr); {
+		c := expr[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenLBracket, text: "[", pos: pos})
+			pos++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenRBracket, text: "]", pos: pos})
+			pos++
+		case c == '.':
+			tokens = append(tokens, token{kind: tokenDot, text: ".", pos: pos})
+			pos++
+		case c == '"':
+			end, err := scanString(expr, pos)
+			if err != nil {
+				return nil, err
+			}
+			s, err := strconv.Unquote(expr[pos:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", pos, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s, pos: pos})
+			pos = end
+		case isDigit(c) || (c == '-' && pos+1 < len(expr) && isDigit(expr[pos+1])):
+			end := pos + 1
+			for end < len(expr) && (isDigit(expr[end]) || expr[end] == '.' || expr[end] == 'e' || expr[end] == 'E' ||
+				((expr[end] == '-' || expr[end] == '+') && (expr[end-1] == 'e' || expr[end-1] == 'E'))) {
+				end++
+			}
+			text := expr[pos:end]
+			kind := tokenInt
+			if strings.ContainsAny(text, ".eE") {
+				kind = tokenFloat
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos})
+			pos = end
+		case isIdentStart(rune(c)):
+			end := pos + 1
+			for end < len(expr) && isIdentPart(rune(expr[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expr[pos:end], pos: pos})
+			pos = end
+		default:
+			op := scanOperator(expr[pos:])
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+// scanString returns the end of the string literal starting at pos.
+func scanString(expr string, pos int) (int, error) {
+	for i := pos + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string at position %d", pos)
+}
+
+func scanOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterexpr // import "go.opentelemetry.io/collector/filter/filterexpr"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var errEmptyExpression = errors.New("empty expression")
+
+// Condition is a parsed condition, evaluated on the items of the context K.
+// A Condition is safe for concurrent use.
+type Condition[K any] struct {
+	expr string
+	eval func(K) bool
+}
+
+// Eval returns whether the item of the context matches the condition.
+func (c *Condition[K]) Eval(ctx K) bool {
+	return c.eval(ctx)
+}
+
+// String returns the source of the condition.
+func (c *Condition[K]) String() string {
+	return c.expr
+}
+
+// path is a path of a context, e.g. resource.attributes["key"].
+type path struct {
+	names []string
+	// key is the key of the map accessed by the path, if hasKey is set.
+	key    string
+	hasKey bool
+}
+
+func (p path) String() string {
+	s := strings.Join(p.names, ".")
+	if p.hasKey {
+		s += "[" + strconv.Quote(p.key) + "]"
+	}
+	return s
+}
+
+// resolver returns the getter of a path of the context K, or false if the path does not exist.
+type resolver[K any] func(p path) (func(K) any, bool)
+
+// operand is a literal, whose value is known when the condition is parsed, or a path.
+type operand[K any] struct {
+	get     func(K) any
+	literal bool
+	value   any
+}
+
+// parser is a recursive descent parser of the grammar:
+//
+//	or         = and { "or" and }
+//	and        = not { "and" not }
+//	not        = "not" not | comparison
+//	comparison = "(" or ")" | operand [ operator operand | "matches" string ]
+//	operand    = literal | constant | path
+//	path       = ident { "." ident } [ "[" string "]" ]
+type parser[K any] struct {
+	tokens  []token
+	pos     int
+	resolve resolver[K]
+}
+
+func parse[K any](expr string, resolve resolver[K]) (*Condition[K], error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, errEmptyExpression
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser[K]{tokens: tokens, resolve: resolve}
+	eval, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %v", t)
+	}
+	return &Condition[K]{expr: expr, eval: eval}, nil
+}
+
+func (p *parser[K]) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser[K]) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the given keyword.
+func (p *parser[K]) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tokenIdent && t.text == kw {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser[K]) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s, got %v", what, t)
+	}
+	return t, nil
+}
+
+func (p *parser[K]) parseOr() (func(K) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(ctx K) bool { return l(ctx) || right(ctx) }
+	}
+	return left, nil
+}
+
+func (p *parser[K]) parseAnd() (func(K) bool, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(ctx K) bool { return l(ctx) && right(ctx) }
+	}
+	return left, nil
+}
+
+func (p *parser[K]) parseNot() (func(K) bool, error) {
+	if p.keyword("not") {
+		cond, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx K) bool { return !cond(ctx) }, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser[K]) parseComparison() (func(K) bool, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return cond, nil
+	}
+
+	start := p.peek()
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.keyword("matches") {
+		t, err := p.expect(tokenString, "regular expression string")
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression at position %d: %w", t.pos, err)
+		}
+		return func(ctx K) bool {
+			s, ok := left.get(ctx).(string)
+			return ok && re.MatchString(s)
+		}, nil
+	}
+	if p.peek().kind != tokenOperator {
+		// An operand alone is a condition if it is a boolean.
+		if left.literal {
+			b, ok := left.value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%v is not a condition", start)
+			}
+			return func(K) bool { return b }, nil
+		}
+		return func(ctx K) bool { return left.get(ctx) == true }, nil
+	}
+	op := p.next().text
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx K) bool {
+		return compare(op, left.get(ctx), right.get(ctx))
+	}, nil
+}
+
+func (p *parser[K]) parseOperand() (operand[K], error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return literal[K](t.text), nil
+	case tokenInt:
+		i, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return operand[K]{}, fmt.Errorf("invalid integer %v: %w", t, err)
+		}
+		return literal[K](i), nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return operand[K]{}, fmt.Errorf("invalid float %v: %w", t, err)
+		}
+		return literal[K](f), nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return literal[K](true), nil
+		case "false":
+			return literal[K](false), nil
+		case "nil":
+			return literal[K](nil), nil
+		case "and", "or", "not", "matches":
+			return operand[K]{}, fmt.Errorf("unexpected %v", t)
+		}
+		if c, ok := constants[t.text]; ok {
+			return literal[K](c), nil
+		}
+		return p.parsePath(t)
+	default:
+		return operand[K]{}, fmt.Errorf("unexpected %v", t)
+	}
+}
+
+func (p *parser[K]) parsePath(first token) (operand[K], error) {
+	pa := path{names: []string{first.text}}
+	for p.peek().kind == tokenDot {
+		p.next()
+		t, err := p.expect(tokenIdent, "path name")
+		if err != nil {
+			return operand[K]{}, err
+		}
+		pa.names = append(pa.names, t.text)
+	}
+	if p.peek().kind == tokenLBracket {
+		p.next()
+		t, err := p.expect(tokenString, "string key")
+		if err != nil {
+			return operand[K]{}, err
+		}
+		if _, err = p.expect(tokenRBracket, `"]"`); err != nil {
+			return operand[K]{}, err
+		}
+		pa.key, pa.hasKey = t.text, true
+	}
+	get, ok := p.resolve(pa)
+	if !ok {
+		return operand[K]{}, fmt.Errorf("unknown path %s at position %d", pa, first.pos)
+	}
+	return operand[K]{get: get}, nil
+}
+
+func literal[K any](v any) operand[K] {
+	return operand[K]{
+		get:     func(K) any { return v },
+		literal: true,
+		value:   v,
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newLogContext() LogContext {
+	ctx := LogContext{
+		Resource:  pcommon.NewResource(),
+		Scope:     pcommon.NewInstrumentationScope(),
+		LogRecord: plog.NewLogRecord(),
+	}
+	ctx.Resource.Attributes().PutStr("env", "prod")
+	ctx.Resource.Attributes().PutInt("replicas", 3)
+	ctx.Scope.SetName("io.opentelemetry.http")
+	ctx.LogRecord.SetSeverityNumber(plog.SeverityNumberError)
+	ctx.LogRecord.SetSeverityText("error")
+	ctx.LogRecord.Body().SetStr("connection refused")
+	ctx.LogRecord.Attributes().PutDouble("ratio", 0.5)
+	ctx.LogRecord.Attributes().PutBool("retry", true)
+	ctx.LogRecord.Attributes().PutEmptySlice("tags").AppendEmpty().SetStr("a")
+	return ctx
+}
+
+func TestParseLogCondition(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `resource.attributes["env"] == "prod" and severity_number >= WARN`, want: true},
+		{expr: `resource.attributes["env"] == "prod" and severity_number >= FATAL`, want: false},
+		{expr: `resource.attributes["env"] != "prod" or severity_number < WARN`, want: false},
+		{expr: `not resource.attributes["env"] == "dev"`, want: true},
+		{expr: `not (severity_number == ERROR or true)`, want: false},
+		{expr: `false or severity_number == ERROR and severity_text == "error"`, want: true},
+		{expr: `resource.attributes["replicas"] > 2.5`, want: true},
+		{expr: `resource.attributes["replicas"] == 3.0`, want: true},
+		{expr: `resource.attributes["replicas"] == "3"`, want: false},
+		{expr: `resource.attributes["replicas"] != "3"`, want: true},
+		{expr: `attributes["ratio"] <= 0.5 and attributes["ratio"] > -1`, want: true},
+		{expr: `attributes["retry"]`, want: true},
+		{expr: `attributes["retry"] == false`, want: false},
+		{expr: `attributes["missing"] == nil`, want: true},
+		{expr: `attributes["missing"] < 1`, want: false},
+		{expr: `attributes["tags"] == "[\"a\"]"`, want: true},
+		{expr: `body matches "^connection (refused|reset)$"`, want: true},
+		{expr: `attributes["retry"] matches "true"`, want: false},
+		{expr: `instrumentation_scope.name matches "http" and instrumentation_scope.version == ""`, want: true},
+		{expr: `body > "a" and body < "d"`, want: true},
+	}
+	ctx := newLogContext()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := ParseLogCondition(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cond.Eval(ctx))
+			assert.Equal(t, tt.expr, cond.String())
+		})
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		errMsg string
+	}{
+		{expr: ` `, errMsg: "empty expression"},
+		{expr: `name == "a"`, errMsg: "unknown path name at position 0"},
+		{expr: `attributes == "a"`, errMsg: "unknown path attributes at position 0"},
+		{expr: `resource.attributes["env"`, errMsg: `expected "]", got end of expression`},
+		{expr: `resource.attributes[env]`, errMsg: `expected string key, got "env" at position 20`},
+		{expr: `body == "a`, errMsg: "unterminated string at position 8"},
+		{expr: `body = "a"`, errMsg: `unexpected character '=' at position 5`},
+		{expr: `body == "a" and`, errMsg: "unexpected end of expression"},
+		{expr: `(body == "a"`, errMsg: `expected ")", got end of expression`},
+		{expr: `body == "a" "b"`, errMsg: `unexpected "b" at position 12`},
+		{expr: `"a"`, errMsg: `"a" at position 0 is not a condition`},
+		{expr: `body matches ".*("`, errMsg: "invalid regular expression at position 13: error parsing regexp: missing closing ): `.*(`"},
+		{expr: `body matches name`, errMsg: `expected regular expression string, got "name" at position 13`},
+		{expr: `body == 99999999999999999999`, errMsg: `invalid integer "99999999999999999999" at position 8: strconv.ParseInt: parsing "99999999999999999999": value out of range`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseLogCondition(tt.expr)
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	assert.True(t, compare("==", nil, nil))
+	assert.False(t, compare("<=", nil, nil) && compare("<", nil, nil))
+	assert.True(t, compare("!=", true, false))
+	assert.False(t, compare("<", false, true))
+	assert.True(t, compare("<", int64(1), 1.5))
+	assert.True(t, compare(">=", 2.0, int64(2)))
+	assert.False(t, compare("==", "1", int64(1)))
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterexpr // import "go.opentelemetry.io/collector/filter/filterexpr"
+
+import (
+	"cmp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// constants are the enum constants, by name.
+var constants = func() map[string]any {
+	c := map[string]any{}
+	for sn := plog.SeverityNumberTrace; sn <= plog.SeverityNumberFatal4; sn++ {
+		c[strings.ToUpper(sn.String())] = int64(sn)
+	}
+	for _, sk := range []ptrace.SpanKind{
+		ptrace.SpanKindUnspecified, ptrace.SpanKindInternal, ptrace.SpanKindServer,
+		ptrace.SpanKindClient, ptrace.SpanKindProducer, ptrace.SpanKindConsumer,
+	} {
+		c["SPAN_KIND_"+strings.ToUpper(sk.String())] = int64(sk)
+	}
+	for _, sc := range []ptrace.StatusCode{ptrace.StatusCodeUnset, ptrace.StatusCodeOk, ptrace.StatusCodeError} {
+		c["STATUS_CODE_"+strings.ToUpper(sc.String())] = int64(sc)
+	}
+	return c
+}()
+
+// fromValue returns the value of an attribute or a body, nil if it is not set. The values of the operands
+// are nil, string, int64, float64 or bool, the maps, slices and bytes are compared by their string representation.
+func fromValue(v pcommon.Value, ok bool) any {
+	if !ok {
+		return nil
+	}
+	switch v.Type() {
+	case pcommon.ValueTypeEmpty:
+		return nil
+	case pcommon.ValueTypeStr:
+		return v.Str()
+	case pcommon.ValueTypeInt:
+		return v.Int()
+	case pcommon.ValueTypeDouble:
+		return v.Double()
+	case pcommon.ValueTypeBool:
+		return v.Bool()
+	default:
+		return v.AsString()
+	}
+}
+
+// compare returns the result of the comparison of the values with the operator. Values of different
+// types are not equal and not ordered, except for the numbers.
+func compare(op string, l, r any) bool {
+	c, comparable := order(l, r)
+	switch op {
+	case "==":
+		return comparable && c == 0
+	case "!=":
+		return !comparable || c != 0
+	case "<":
+		return comparable && c < 0
+	case "<=":
+		return comparable && c <= 0
+	case ">":
+		return comparable && c > 0
+	case ">=":
+		return comparable && c >= 0
+	default:
+		return false
+	}
+}
+
+// order returns -1, 0 or 1 if l is less than, equal to or greater than r, and false if the values
+// cannot be compared. The booleans and nil are only equal to themselves.
+func order(l, r any) (int, bool) {
+	switch lv := l.(type) {
+	case nil:
+		if r == nil {
+			return 0, true
+		}
+	case string:
+		if rv, ok := r.(string); ok {
+			return strings.Compare(lv, rv), true
+		}
+	case bool:
+		if rv, ok := r.(bool); ok && lv == rv {
+			return 0, true
+		}
+	case int64:
+		switch rv := r.(type) {
+		case int64:
+			return cmp.Compare(lv, rv), true
+		case float64:
+			return cmp.Compare(float64(lv), rv), true
+		}
+	case float64:
+		switch rv := r.(type) {
+		case int64:
+			return cmp.Compare(lv, float64(rv)), true
+		case float64:
+			return cmp.Compare(lv, rv), true
+		}
+	}
+	return 0, false
+}
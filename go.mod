@@ -17,15 +17,18 @@ require (
 	go.opentelemetry.io/collector/component/componentstatus v0.107.0
 	go.opentelemetry.io/collector/confmap v0.107.0
 	go.opentelemetry.io/collector/consumer v0.107.0
+	go.opentelemetry.io/collector/consumer/consumerentities v0.107.0
 	go.opentelemetry.io/collector/consumer/consumertest v0.107.0
 	go.opentelemetry.io/collector/featuregate v1.13.0
 	go.opentelemetry.io/collector/pdata v1.13.0
+	go.opentelemetry.io/collector/pdata/pentity v0.107.0
 	go.opentelemetry.io/collector/pdata/testdata v0.107.0
 	go.opentelemetry.io/contrib/config v0.8.0
 	go.uber.org/goleak v1.3.0
 	go.uber.org/multierr v1.11.0
 	go.uber.org/zap v1.27.0
 	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
@@ -86,7 +89,6 @@ require (
 	golang.org/x/text v0.16.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20240701130421-f6361c86f094 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240701130421-f6361c86f094 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -118,4 +120,8 @@ retract (
 
 replace go.opentelemetry.io/collector/pdata/pprofile => ./pdata/pprofile
 
+replace go.opentelemetry.io/collector/pdata/pentity => ./pdata/pentity
+
 replace go.opentelemetry.io/collector/consumer/consumerprofiles => ./consumer/consumerprofiles
+
+replace go.opentelemetry.io/collector/consumer/consumerentities => ./consumer/consumerentities
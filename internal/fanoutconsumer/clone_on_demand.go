@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// CloneOnDemand is implemented by consumers that mutate data, but accept data shared with other consumers.
+// Such consumers receive the data marked as read-only when it is shared, and clone it only right before
+// it is mutated, see NewCloneOnDemandTraces, NewCloneOnDemandMetrics, NewCloneOnDemandLogs
+// and NewCloneOnDemandEntities.
+// This avoids cloning the data upfront when it is never mutated, e.g. when it is refused or dropped earlier.
+type CloneOnDemand interface {
+	// ClonesOnDemand returns true if the consumer clones read-only data before mutating it.
+	ClonesOnDemand() bool
+}
+
+func clonesOnDemand(c any) bool {
+	cod, ok := c.(CloneOnDemand)
+	return ok && cod.ClonesOnDemand()
+}
+
+// NewCloneOnDemandTraces wraps a mutating consumer.Traces so it always receives mutable data.
+// Read-only data is cloned before being passed to next, mutable data is passed as is.
+func NewCloneOnDemandTraces(next consumer.Traces) consumer.Traces {
+	return &cloneOnDemandTraces{Traces: next}
+}
+
+type cloneOnDemandTraces struct {
+	consumer.Traces
+}
+
+func (c *cloneOnDemandTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if td.IsReadOnly() {
+		td = cloneTraces(td)
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+// NewCloneOnDemandMetrics wraps a mutating consumer.Metrics so it always receives mutable data.
+// Read-only data is cloned before being passed to next, mutable data is passed as is.
+func NewCloneOnDemandMetrics(next consumer.Metrics) consumer.Metrics {
+	return &cloneOnDemandMetrics{Metrics: next}
+}
+
+type cloneOnDemandMetrics struct {
+	consumer.Metrics
+}
+
+func (c *cloneOnDemandMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if md.IsReadOnly() {
+		md = cloneMetrics(md)
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// NewCloneOnDemandLogs wraps a mutating consumer.Logs so it always receives mutable data.
+// Read-only data is cloned before being passed to next, mutable data is passed as is.
+func NewCloneOnDemandLogs(next consumer.Logs) consumer.Logs {
+	return &cloneOnDemandLogs{Logs: next}
+}
+
+type cloneOnDemandLogs struct {
+	consumer.Logs
+}
+
+func (c *cloneOnDemandLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if ld.IsReadOnly() {
+		ld = cloneLogs(ld)
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// NewCloneOnDemandEntities wraps a mutating consumerentities.Entities so it always receives mutable data.
+// Read-only data is cloned before being passed to next, mutable data is passed as is.
+func NewCloneOnDemandEntities(next consumerentities.Entities) consumerentities.Entities {
+	return &cloneOnDemandEntities{Entities: next}
+}
+
+type cloneOnDemandEntities struct {
+	consumerentities.Entities
+}
+
+func (c *cloneOnDemandEntities) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	if ed.IsReadOnly() {
+		ed = cloneEntities(ed)
+	}
+	return c.Entities.ConsumeEntities(ctx, ed)
+}
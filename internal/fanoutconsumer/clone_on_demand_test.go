@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type onDemandTracesSink struct {
+	*consumertest.TracesSink
+}
+
+func (*onDemandTracesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (*onDemandTracesSink) ClonesOnDemand() bool {
+	return true
+}
+
+type onDemandMetricsSink struct {
+	*consumertest.MetricsSink
+}
+
+func (*onDemandMetricsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (*onDemandMetricsSink) ClonesOnDemand() bool {
+	return true
+}
+
+type onDemandLogsSink struct {
+	*consumertest.LogsSink
+}
+
+func (*onDemandLogsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (*onDemandLogsSink) ClonesOnDemand() bool {
+	return true
+}
+
+func TestTracesNotMultiplexingCloneOnDemand(t *testing.T) {
+	p := &onDemandTracesSink{TracesSink: new(consumertest.TracesSink)}
+	assert.Same(t, p, NewTraces([]consumer.Traces{p}))
+}
+
+func TestTracesMultiplexingCloneOnDemand(t *testing.T) {
+	p1 := &onDemandTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := new(consumertest.TracesSink)
+
+	tfc := NewTraces([]consumer.Traces{p1, p2})
+	assert.False(t, tfc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The data is shared without any clone, and marked as read-only so p1 clones it before mutating it.
+	assert.True(t, td == p1.AllTraces()[0])
+	assert.True(t, td == p2.AllTraces()[0])
+	assert.True(t, td.IsReadOnly())
+}
+
+func TestTracesMultiplexingCloneOnDemandWithMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &onDemandTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewTraces([]consumer.Traces{p1, p2})
+	assert.True(t, tfc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The mutating consumer gets its own copy, the clone on demand consumer gets the original mutable data.
+	assert.True(t, td != p1.AllTraces()[0])
+	assert.EqualValues(t, td, p1.AllTraces()[0])
+	assert.True(t, td == p2.AllTraces()[0])
+	assert.False(t, td.IsReadOnly())
+}
+
+func TestMetricsMultiplexingCloneOnDemand(t *testing.T) {
+	p1 := &onDemandMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p2 := &onDemandMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+
+	mfc := NewMetrics([]consumer.Metrics{p1, p2})
+	assert.True(t, mfc.Capabilities().MutatesData)
+	md := testdata.GenerateMetrics(1)
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+
+	// All the consumers mutate the data, only the first one gets a copy.
+	assert.True(t, md != p1.AllMetrics()[0])
+	assert.EqualValues(t, md, p1.AllMetrics()[0])
+	assert.True(t, md == p2.AllMetrics()[0])
+	assert.False(t, md.IsReadOnly())
+
+	// Read-only data is shared, the consumers clone it on demand.
+	md = testdata.GenerateMetrics(1)
+	md.MarkReadOnly()
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+	assert.True(t, md == p1.AllMetrics()[1])
+	assert.True(t, md == p2.AllMetrics()[1])
+}
+
+func TestLogsMultiplexingCloneOnDemand(t *testing.T) {
+	p1 := &onDemandLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p2 := new(consumertest.LogsSink)
+
+	lfc := NewLogs([]consumer.Logs{p1, p2})
+	assert.False(t, lfc.Capabilities().MutatesData)
+	ld := testdata.GenerateLogs(1)
+	require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+
+	assert.True(t, ld == p1.AllLogs()[0])
+	assert.True(t, ld == p2.AllLogs()[0])
+	assert.True(t, ld.IsReadOnly())
+}
+
+func TestCloneOnDemandTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tc := NewCloneOnDemandTraces(sink)
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.True(t, td == sink.AllTraces()[0])
+
+	td.MarkReadOnly()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.True(t, td != sink.AllTraces()[1])
+	assert.EqualValues(t, testdata.GenerateTraces(1), sink.AllTraces()[1])
+	assert.False(t, sink.AllTraces()[1].IsReadOnly())
+}
+
+func TestCloneOnDemandMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mc := NewCloneOnDemandMetrics(sink)
+
+	md := testdata.GenerateMetrics(1)
+	md.MarkReadOnly()
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), md))
+	assert.True(t, md != sink.AllMetrics()[0])
+	assert.False(t, sink.AllMetrics()[0].IsReadOnly())
+}
+
+func TestCloneOnDemandLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lc := NewCloneOnDemandLogs(sink)
+
+	ld := testdata.GenerateLogs(1)
+	ld.MarkReadOnly()
+	require.NoError(t, lc.ConsumeLogs(context.Background(), ld))
+	assert.True(t, ld != sink.AllLogs()[0])
+	assert.False(t, sink.AllLogs()[0].IsReadOnly())
+}
+
+func TestCloneOnDemandEntities(t *testing.T) {
+	sink := new(consumertest.EntitiesSink)
+	ec := NewCloneOnDemandEntities(sink)
+
+	ed := generateEntities()
+	ed.MarkReadOnly()
+	require.NoError(t, ec.ConsumeEntities(context.Background(), ed))
+	assert.True(t, ed != sink.AllEntities()[0])
+	assert.False(t, sink.AllEntities()[0].IsReadOnly())
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/pdata/pentity"
+)
+
+// NewEntities wraps multiple entity events consumers in a single one.
+// It fanouts the incoming data to all the consumers, and does smart routing:
+//   - Clones only to the consumer that needs to mutate the data.
+//   - Shares the data with consumers that clone it on demand, see CloneOnDemand.
+//   - If all consumers needs to mutate the data one will get the original mutable data, including the ones
+//     cloning on demand.
+func NewEntities(lcs []consumerentities.Entities) consumerentities.Entities {
+	// Don't wrap if there is only one consumer that does not need its own copy of the data.
+	if len(lcs) == 1 && (!lcs[0].Capabilities().MutatesData || clonesOnDemand(lcs[0])) {
+		return lcs[0]
+	}
+
+	lc := &entitiesConsumer{}
+	for i := 0; i < len(lcs); i++ {
+		switch {
+		case !lcs[i].Capabilities().MutatesData:
+			lc.readonly = append(lc.readonly, lcs[i])
+		case clonesOnDemand(lcs[i]):
+			// The consumer mutates the data, but can safely receive shared read-only data.
+			lc.readonly = append(lc.readonly, lcs[i])
+			lc.onDemand++
+		default:
+			lc.mutable = append(lc.mutable, lcs[i])
+		}
+	}
+	if lc.onDemand == len(lc.readonly) {
+		// All the consumers mutate the data, in this order the mutating ones get the data before the ones
+		// cloning on demand.
+		lc.all = append(append([]consumerentities.Entities{}, lc.mutable...), lc.readonly...)
+	}
+	return lc
+}
+
+type entitiesConsumer struct {
+	mutable []consumerentities.Entities
+	// readonly contains the consumers that can receive shared data,
+	// including the mutating consumers that clone the data on demand.
+	readonly []consumerentities.Entities
+	// onDemand is the number of consumers in readonly that clone the data on demand.
+	onDemand int
+	// all contains all the consumers if all of them mutate the data.
+	all []consumerentities.Entities
+}
+
+func (lsc *entitiesConsumer) Capabilities() consumer.Capabilities {
+	// The original data is passed as mutable to the last consumer if all consumers are mutating.
+	return consumer.Capabilities{MutatesData: len(lsc.all) > 0}
+}
+
+// ConsumeEntities exports the pentity.Entities to all consumers wrapped by the current one.
+func (lsc *entitiesConsumer) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	var errs error
+
+	if len(lsc.all) > 0 && !ed.IsReadOnly() {
+		// Clone the data before sending to all consumers except the last one, which gets the original data.
+		// Sharing the data with the consumers cloning on demand would clone it once more.
+		for i := 0; i < len(lsc.all)-1; i++ {
+			errs = multierr.Append(errs, lsc.all[i].ConsumeEntities(ctx, cloneEntities(ed)))
+		}
+		return multierr.Append(errs, lsc.all[len(lsc.all)-1].ConsumeEntities(ctx, ed))
+	}
+
+	// Clone the data before sending to the mutating consumers. Never share the same data between a mutating and
+	// a non-mutating consumer since the non-mutating consumer may process data async and the mutating consumer
+	// may change the data before that.
+	for _, lc := range lsc.mutable {
+		errs = multierr.Append(errs, lc.ConsumeEntities(ctx, cloneEntities(ed)))
+	}
+
+	// Mark the data as read-only if it will be sent to more than one read-only consumer.
+	if len(lsc.readonly) > 1 && !ed.IsReadOnly() {
+		ed.MarkReadOnly()
+	}
+	for _, lc := range lsc.readonly {
+		errs = multierr.Append(errs, lc.ConsumeEntities(ctx, ed))
+	}
+
+	return errs
+}
+
+func cloneEntities(ed pentity.Entities) pentity.Entities {
+	clonedEntities := pentity.NewEntities()
+	ed.CopyTo(clonedEntities)
+	return clonedEntities
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pentity"
+)
+
+func TestEntitiesNotMultiplexing(t *testing.T) {
+	nop := consumertest.NewNop()
+	efc := NewEntities([]consumerentities.Entities{nop})
+	assert.Same(t, nop, efc)
+}
+
+func TestEntitiesNotMultiplexingMutating(t *testing.T) {
+	p := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	efc := NewEntities([]consumerentities.Entities{p})
+	assert.True(t, efc.Capabilities().MutatesData)
+}
+
+func TestEntitiesMultiplexingNonMutating(t *testing.T) {
+	p1 := new(consumertest.EntitiesSink)
+	p2 := new(consumertest.EntitiesSink)
+	p3 := new(consumertest.EntitiesSink)
+
+	efc := NewEntities([]consumerentities.Entities{p1, p2, p3})
+	assert.False(t, efc.Capabilities().MutatesData)
+	ed := generateEntities()
+
+	for i := 0; i < 2; i++ {
+		err := efc.ConsumeEntities(context.Background(), ed)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, ed == p1.AllEntities()[0])
+	assert.True(t, ed == p1.AllEntities()[1])
+	assert.EqualValues(t, ed, p1.AllEntities()[0])
+	assert.EqualValues(t, ed, p1.AllEntities()[1])
+
+	assert.True(t, ed == p2.AllEntities()[0])
+	assert.True(t, ed == p2.AllEntities()[1])
+	assert.EqualValues(t, ed, p2.AllEntities()[0])
+	assert.EqualValues(t, ed, p2.AllEntities()[1])
+
+	assert.True(t, ed == p3.AllEntities()[0])
+	assert.True(t, ed == p3.AllEntities()[1])
+	assert.EqualValues(t, ed, p3.AllEntities()[0])
+	assert.EqualValues(t, ed, p3.AllEntities()[1])
+
+	// The data should be marked as read only.
+	assert.True(t, ed.IsReadOnly())
+}
+
+func TestEntitiesMultiplexingMutating(t *testing.T) {
+	p1 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p2 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p3 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+
+	efc := NewEntities([]consumerentities.Entities{p1, p2, p3})
+	assert.True(t, efc.Capabilities().MutatesData)
+	ed := generateEntities()
+
+	for i := 0; i < 2; i++ {
+		err := efc.ConsumeEntities(context.Background(), ed)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, ed != p1.AllEntities()[0])
+	assert.True(t, ed != p1.AllEntities()[1])
+	assert.EqualValues(t, ed, p1.AllEntities()[0])
+	assert.EqualValues(t, ed, p1.AllEntities()[1])
+
+	assert.True(t, ed != p2.AllEntities()[0])
+	assert.True(t, ed != p2.AllEntities()[1])
+	assert.EqualValues(t, ed, p2.AllEntities()[0])
+	assert.EqualValues(t, ed, p2.AllEntities()[1])
+
+	// For this consumer, will receive the initial data.
+	assert.True(t, ed == p3.AllEntities()[0])
+	assert.True(t, ed == p3.AllEntities()[1])
+	assert.EqualValues(t, ed, p3.AllEntities()[0])
+	assert.EqualValues(t, ed, p3.AllEntities()[1])
+
+	// The data should not be marked as read only.
+	assert.False(t, ed.IsReadOnly())
+}
+
+func TestReadOnlyEntitiesMultiplexingMutating(t *testing.T) {
+	p1 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p2 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p3 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+
+	efc := NewEntities([]consumerentities.Entities{p1, p2, p3})
+	assert.True(t, efc.Capabilities().MutatesData)
+	ldOrig := generateEntities()
+	ed := generateEntities()
+	ed.MarkReadOnly()
+
+	for i := 0; i < 2; i++ {
+		err := efc.ConsumeEntities(context.Background(), ed)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	// All consumers should receive the cloned data.
+
+	assert.True(t, ed != p1.AllEntities()[0])
+	assert.True(t, ed != p1.AllEntities()[1])
+	assert.EqualValues(t, ldOrig, p1.AllEntities()[0])
+	assert.EqualValues(t, ldOrig, p1.AllEntities()[1])
+
+	assert.True(t, ed != p2.AllEntities()[0])
+	assert.True(t, ed != p2.AllEntities()[1])
+	assert.EqualValues(t, ldOrig, p2.AllEntities()[0])
+	assert.EqualValues(t, ldOrig, p2.AllEntities()[1])
+
+	assert.True(t, ed != p3.AllEntities()[0])
+	assert.True(t, ed != p3.AllEntities()[1])
+	assert.EqualValues(t, ldOrig, p3.AllEntities()[0])
+	assert.EqualValues(t, ldOrig, p3.AllEntities()[1])
+}
+
+func TestEntitiesMultiplexingMixLastMutating(t *testing.T) {
+	p1 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p2 := new(consumertest.EntitiesSink)
+	p3 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+
+	efc := NewEntities([]consumerentities.Entities{p1, p2, p3})
+	assert.False(t, efc.Capabilities().MutatesData)
+	ed := generateEntities()
+
+	for i := 0; i < 2; i++ {
+		err := efc.ConsumeEntities(context.Background(), ed)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, ed != p1.AllEntities()[0])
+	assert.True(t, ed != p1.AllEntities()[1])
+	assert.EqualValues(t, ed, p1.AllEntities()[0])
+	assert.EqualValues(t, ed, p1.AllEntities()[1])
+
+	// For this consumer, will receive the initial data.
+	assert.True(t, ed == p2.AllEntities()[0])
+	assert.True(t, ed == p2.AllEntities()[1])
+	assert.EqualValues(t, ed, p2.AllEntities()[0])
+	assert.EqualValues(t, ed, p2.AllEntities()[1])
+
+	// For this consumer, will clone the initial data.
+	assert.True(t, ed != p3.AllEntities()[0])
+	assert.True(t, ed != p3.AllEntities()[1])
+	assert.EqualValues(t, ed, p3.AllEntities()[0])
+	assert.EqualValues(t, ed, p3.AllEntities()[1])
+
+	// The data should not be marked as read only.
+	assert.False(t, ed.IsReadOnly())
+}
+
+func TestEntitiesMultiplexingMixLastNonMutating(t *testing.T) {
+	p1 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p2 := &mutatingEntitiesSink{EntitiesSink: new(consumertest.EntitiesSink)}
+	p3 := new(consumertest.EntitiesSink)
+
+	efc := NewEntities([]consumerentities.Entities{p1, p2, p3})
+	assert.False(t, efc.Capabilities().MutatesData)
+	ed := generateEntities()
+
+	for i := 0; i < 2; i++ {
+		err := efc.ConsumeEntities(context.Background(), ed)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, ed != p1.AllEntities()[0])
+	assert.True(t, ed != p1.AllEntities()[1])
+	assert.EqualValues(t, ed, p1.AllEntities()[0])
+	assert.EqualValues(t, ed, p1.AllEntities()[1])
+
+	assert.True(t, ed != p2.AllEntities()[0])
+	assert.True(t, ed != p2.AllEntities()[1])
+	assert.EqualValues(t, ed, p2.AllEntities()[0])
+	assert.EqualValues(t, ed, p2.AllEntities()[1])
+
+	// For this consumer, will receive the initial data.
+	assert.True(t, ed == p3.AllEntities()[0])
+	assert.True(t, ed == p3.AllEntities()[1])
+	assert.EqualValues(t, ed, p3.AllEntities()[0])
+	assert.EqualValues(t, ed, p3.AllEntities()[1])
+
+	// The data should not be marked as read only.
+	assert.False(t, ed.IsReadOnly())
+}
+
+func TestEntitiesWhenErrors(t *testing.T) {
+	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
+	p2 := consumertest.NewErr(errors.New("my error"))
+	p3 := new(consumertest.EntitiesSink)
+
+	efc := NewEntities([]consumerentities.Entities{p1, p2, p3})
+	ed := generateEntities()
+
+	for i := 0; i < 2; i++ {
+		assert.Error(t, efc.ConsumeEntities(context.Background(), ed))
+	}
+
+	assert.True(t, ed == p3.AllEntities()[0])
+	assert.True(t, ed == p3.AllEntities()[1])
+	assert.EqualValues(t, ed, p3.AllEntities()[0])
+	assert.EqualValues(t, ed, p3.AllEntities()[1])
+}
+
+type mutatingEntitiesSink struct {
+	*consumertest.EntitiesSink
+}
+
+func (mts *mutatingEntitiesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func generateEntities() pentity.Entities {
+	ed := pentity.NewEntities()
+	event := ed.ResourceEntities().AppendEmpty().ScopeEntities().AppendEmpty().EntityEvents().AppendEmpty()
+	event.SetEntityType("k8s.pod")
+	event.SetType(pentity.EventTypeCreate)
+	event.ID().PutStr("k8s.pod.uid", "0a1b2c")
+	return ed
+}
@@ -17,52 +17,70 @@ import (
 // NewLogs wraps multiple log consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with consumers that clone it on demand, see CloneOnDemand.
+//   - If all consumers needs to mutate the data one will get the original mutable data, including the ones
+//     cloning on demand.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
-	// Don't wrap if there is only one non-mutating consumer.
-	if len(lcs) == 1 && !lcs[0].Capabilities().MutatesData {
+	// Don't wrap if there is only one consumer that does not need its own copy of the data.
+	if len(lcs) == 1 && (!lcs[0].Capabilities().MutatesData || clonesOnDemand(lcs[0])) {
 		return lcs[0]
 	}
 
 	lc := &logsConsumer{}
 	for i := 0; i < len(lcs); i++ {
-		if lcs[i].Capabilities().MutatesData {
-			lc.mutable = append(lc.mutable, lcs[i])
-		} else {
+		switch {
+		case !lcs[i].Capabilities().MutatesData:
+			lc.readonly = append(lc.readonly, lcs[i])
+		case clonesOnDemand(lcs[i]):
+			// The consumer mutates the data, but can safely receive shared read-only data.
 			lc.readonly = append(lc.readonly, lcs[i])
+			lc.onDemand++
+		default:
+			lc.mutable = append(lc.mutable, lcs[i])
 		}
 	}
+	if lc.onDemand == len(lc.readonly) {
+		// All the consumers mutate the data, in this order the mutating ones get the data before the ones
+		// cloning on demand.
+		lc.all = append(append([]consumer.Logs{}, lc.mutable...), lc.readonly...)
+	}
 	return lc
 }
 
 type logsConsumer struct {
-	mutable  []consumer.Logs
+	mutable []consumer.Logs
+	// readonly contains the consumers that can receive shared data,
+	// including the mutating consumers that clone the data on demand.
 	readonly []consumer.Logs
+	// onDemand is the number of consumers in readonly that clone the data on demand.
+	onDemand int
+	// all contains all the consumers if all of them mutate the data.
+	all []consumer.Logs
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(lsc.mutable) > 0 && len(lsc.readonly) == 0}
+	// The original data is passed as mutable to the last consumer if all consumers are mutating.
+	return consumer.Capabilities{MutatesData: len(lsc.all) > 0}
 }
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var errs error
 
-	if len(lsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(lsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, lsc.mutable[i].ConsumeLogs(ctx, cloneLogs(ld)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := lsc.mutable[len(lsc.mutable)-1]
-		if len(lsc.readonly) == 0 && !ld.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, ld))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, cloneLogs(ld)))
+	if len(lsc.all) > 0 && !ld.IsReadOnly() {
+		// Clone the data before sending to all consumers except the last one, which gets the original data.
+		// Sharing the data with the consumers cloning on demand would clone it once more.
+		for i := 0; i < len(lsc.all)-1; i++ {
+			errs = multierr.Append(errs, lsc.all[i].ConsumeLogs(ctx, cloneLogs(ld)))
 		}
+		return multierr.Append(errs, lsc.all[len(lsc.all)-1].ConsumeLogs(ctx, ld))
+	}
+
+	// Clone the data before sending to the mutating consumers. Never share the same data between a mutating and
+	// a non-mutating consumer since the non-mutating consumer may process data async and the mutating consumer
+	// may change the data before that.
+	for _, lc := range lsc.mutable {
+		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, cloneLogs(ld)))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
@@ -15,52 +15,70 @@ import (
 // NewMetrics wraps multiple metrics consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with consumers that clone it on demand, see CloneOnDemand.
+//   - If all consumers needs to mutate the data one will get the original mutable data, including the ones
+//     cloning on demand.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
-	// Don't wrap if there is only one non-mutating consumer.
-	if len(mcs) == 1 && !mcs[0].Capabilities().MutatesData {
+	// Don't wrap if there is only one consumer that does not need its own copy of the data.
+	if len(mcs) == 1 && (!mcs[0].Capabilities().MutatesData || clonesOnDemand(mcs[0])) {
 		return mcs[0]
 	}
 
 	mc := &metricsConsumer{}
 	for i := 0; i < len(mcs); i++ {
-		if mcs[i].Capabilities().MutatesData {
-			mc.mutable = append(mc.mutable, mcs[i])
-		} else {
+		switch {
+		case !mcs[i].Capabilities().MutatesData:
+			mc.readonly = append(mc.readonly, mcs[i])
+		case clonesOnDemand(mcs[i]):
+			// The consumer mutates the data, but can safely receive shared read-only data.
 			mc.readonly = append(mc.readonly, mcs[i])
+			mc.onDemand++
+		default:
+			mc.mutable = append(mc.mutable, mcs[i])
 		}
 	}
+	if mc.onDemand == len(mc.readonly) {
+		// All the consumers mutate the data, in this order the mutating ones get the data before the ones
+		// cloning on demand.
+		mc.all = append(append([]consumer.Metrics{}, mc.mutable...), mc.readonly...)
+	}
 	return mc
 }
 
 type metricsConsumer struct {
-	mutable  []consumer.Metrics
+	mutable []consumer.Metrics
+	// readonly contains the consumers that can receive shared data,
+	// including the mutating consumers that clone the data on demand.
 	readonly []consumer.Metrics
+	// onDemand is the number of consumers in readonly that clone the data on demand.
+	onDemand int
+	// all contains all the consumers if all of them mutate the data.
+	all []consumer.Metrics
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(msc.mutable) > 0 && len(msc.readonly) == 0}
+	// The original data is passed as mutable to the last consumer if all consumers are mutating.
+	return consumer.Capabilities{MutatesData: len(msc.all) > 0}
 }
 
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	var errs error
 
-	if len(msc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(msc.mutable)-1; i++ {
-			errs = multierr.Append(errs, msc.mutable[i].ConsumeMetrics(ctx, cloneMetrics(md)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := msc.mutable[len(msc.mutable)-1]
-		if len(msc.readonly) == 0 && !md.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, md))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, cloneMetrics(md)))
+	if len(msc.all) > 0 && !md.IsReadOnly() {
+		// Clone the data before sending to all consumers except the last one, which gets the original data.
+		// Sharing the data with the consumers cloning on demand would clone it once more.
+		for i := 0; i < len(msc.all)-1; i++ {
+			errs = multierr.Append(errs, msc.all[i].ConsumeMetrics(ctx, cloneMetrics(md)))
 		}
+		return multierr.Append(errs, msc.all[len(msc.all)-1].ConsumeMetrics(ctx, md))
+	}
+
+	// Clone the data before sending to the mutating consumers. Never share the same data between a mutating and
+	// a non-mutating consumer since the non-mutating consumer may process data async and the mutating consumer
+	// may change the data before that.
+	for _, mc := range msc.mutable {
+		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, cloneMetrics(md)))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
@@ -15,52 +15,70 @@ import (
 // NewTraces wraps multiple trace consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with consumers that clone it on demand, see CloneOnDemand.
+//   - If all consumers needs to mutate the data one will get the original mutable data, including the ones
+//     cloning on demand.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
-	// Don't wrap if there is only one non-mutating consumer.
-	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
+	// Don't wrap if there is only one consumer that does not need its own copy of the data.
+	if len(tcs) == 1 && (!tcs[0].Capabilities().MutatesData || clonesOnDemand(tcs[0])) {
 		return tcs[0]
 	}
 
 	tc := &tracesConsumer{}
 	for i := 0; i < len(tcs); i++ {
-		if tcs[i].Capabilities().MutatesData {
-			tc.mutable = append(tc.mutable, tcs[i])
-		} else {
+		switch {
+		case !tcs[i].Capabilities().MutatesData:
+			tc.readonly = append(tc.readonly, tcs[i])
+		case clonesOnDemand(tcs[i]):
+			// The consumer mutates the data, but can safely receive shared read-only data.
 			tc.readonly = append(tc.readonly, tcs[i])
+			tc.onDemand++
+		default:
+			tc.mutable = append(tc.mutable, tcs[i])
 		}
 	}
+	if tc.onDemand == len(tc.readonly) {
+		// All the consumers mutate the data, in this order the mutating ones get the data before the ones
+		// cloning on demand.
+		tc.all = append(append([]consumer.Traces{}, tc.mutable...), tc.readonly...)
+	}
 	return tc
 }
 
 type tracesConsumer struct {
-	mutable  []consumer.Traces
+	mutable []consumer.Traces
+	// readonly contains the consumers that can receive shared data,
+	// including the mutating consumers that clone the data on demand.
 	readonly []consumer.Traces
+	// onDemand is the number of consumers in readonly that clone the data on demand.
+	onDemand int
+	// all contains all the consumers if all of them mutate the data.
+	all []consumer.Traces
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(tsc.mutable) > 0 && len(tsc.readonly) == 0}
+	// The original data is passed as mutable to the last consumer if all consumers are mutating.
+	return consumer.Capabilities{MutatesData: len(tsc.all) > 0}
 }
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	var errs error
 
-	if len(tsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(tsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, tsc.mutable[i].ConsumeTraces(ctx, cloneTraces(td)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
-		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, td))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, cloneTraces(td)))
+	if len(tsc.all) > 0 && !td.IsReadOnly() {
+		// Clone the data before sending to all consumers except the last one, which gets the original data.
+		// Sharing the data with the consumers cloning on demand would clone it once more.
+		for i := 0; i < len(tsc.all)-1; i++ {
+			errs = multierr.Append(errs, tsc.all[i].ConsumeTraces(ctx, cloneTraces(td)))
 		}
+		return multierr.Append(errs, tsc.all[len(tsc.all)-1].ConsumeTraces(ctx, td))
+	}
+
+	// Clone the data before sending to the mutating consumers. Never share the same data between a mutating and
+	// a non-mutating consumer since the non-mutating consumer may process data async and the mutating consumer
+	// may change the data before that.
+	for _, tc := range tsc.mutable {
+		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, cloneTraces(td)))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
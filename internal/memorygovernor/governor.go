@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memorygovernor manages the soft memory limit of the Go runtime (GOMEMLIMIT) of the collector,
+// replacing the memory ballast to keep the garbage collector from running too often while the heap is small.
+package memorygovernor // import "go.opentelemetry.io/collector/internal/memorygovernor"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"runtime/debug"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+const (
+	mibBytes = 1024 * 1024
+
+	// goMemLimitEnv is the environment variable of the Go runtime setting the soft memory limit,
+	// which takes precedence over the governor.
+	goMemLimitEnv = "GOMEMLIMIT"
+)
+
+var (
+	// GetMemoryFn and SetMemoryLimitFn make it overridable by tests
+	GetMemoryFn      = iruntime.TotalMemory
+	SetMemoryLimitFn = debug.SetMemoryLimit
+
+	errLimitPercentageOutOfRange = errors.New("'limit_percentage' must be greater than zero and less than or equal to hundred")
+)
+
+var (
+	// mu protects the variables below, the soft memory limit being global to the process.
+	mu sync.Mutex
+	// active is the started governor, if any.
+	active *Governor
+	// limiterLimits holds the soft limits of the started memory limiters.
+	limiterLimits = map[any]uint64{}
+)
+
+// Governor sets the soft memory limit of the Go runtime to a percentage of the total memory available to the process,
+// detected from the cgroup limits or the physical memory. The soft limit is lowered to the soft limit of the memory
+// limiters, if any, so the garbage collector reclaims memory before the memory limiters refuse data.
+type Governor struct {
+	logger *zap.Logger
+	// limit is the soft memory limit configured for the process, in bytes.
+	limit uint64
+	// previous is the soft memory limit before the governor was started.
+	previous int64
+	// disabled is true if the soft memory limit is set by the GOMEMLIMIT environment variable.
+	disabled bool
+}
+
+// New returns a new Governor setting the soft memory limit to limitPercentage % of the total memory.
+func New(logger *zap.Logger, limitPercentage uint32) (*Governor, error) {
+	if limitPercentage == 0 || limitPercentage > 100 {
+		return nil, errLimitPercentageOutOfRange
+	}
+	totalMemory, err := GetMemoryFn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total memory: %w", err)
+	}
+	return &Governor{
+		logger: logger,
+		limit:  totalMemory * uint64(limitPercentage) / 100,
+	}, nil
+}
+
+// Start applies the soft memory limit, unless it is set by the GOMEMLIMIT environment variable.
+func (g *Governor) Start() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if active != nil {
+		return errors.New("a memory governor is already started")
+	}
+	if v, ok := os.LookupEnv(goMemLimitEnv); ok {
+		g.logger.Info("Soft memory limit set by the GOMEMLIMIT environment variable, the memory governor is disabled",
+			zap.String("gomemlimit", v))
+		g.disabled = true
+		return nil
+	}
+	active = g
+	g.previous = SetMemoryLimitFn(-1)
+	g.apply()
+	return nil
+}
+
+// Shutdown restores the soft memory limit set before the governor was started.
+func (g *Governor) Shutdown() {
+	mu.Lock()
+	defer mu.Unlock()
+	if g.disabled || active != g {
+		return
+	}
+	active = nil
+	SetMemoryLimitFn(g.previous)
+}
+
+// apply sets the soft memory limit, the lowest of the configured limit and of the soft limits of the memory limiters.
+// Caller must hold the lock.
+func (g *Governor) apply() {
+	limit := g.limit
+	for _, l := range limiterLimits {
+		limit = min(limit, l)
+	}
+	if limit > math.MaxInt64 {
+		limit = math.MaxInt64
+	}
+	if SetMemoryLimitFn(int64(limit)) != int64(limit) {
+		g.logger.Info("Soft memory limit of the Go runtime updated",
+			zap.Uint64("limit_mib", limit/mibBytes),
+			zap.Uint64("configured_limit_mib", g.limit/mibBytes),
+			zap.Int("memory_limiters", len(limiterLimits)))
+	}
+}
+
+// RegisterLimiter records the soft limit of a started memory limiter, in bytes, identified by key.
+// The soft memory limit of the Go runtime is lowered to this limit if a governor is started.
+func RegisterLimiter(key any, softLimit uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+	limiterLimits[key] = softLimit
+	if active != nil {
+		active.apply()
+	}
+}
+
+// UnregisterLimiter removes the soft limit of the memory limiter identified by key.
+func UnregisterLimiter(key any) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(limiterLimits, key)
+	if active != nil {
+		active.apply()
+	}
+}
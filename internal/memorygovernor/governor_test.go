@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorygovernor
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeRuntime replaces the total memory and the soft memory limit of the Go runtime for the duration of a test.
+func fakeRuntime(t *testing.T, totalMemory uint64) *int64 {
+	limit := int64(math.MaxInt64)
+	origGetMemoryFn, origSetMemoryLimitFn := GetMemoryFn, SetMemoryLimitFn
+	GetMemoryFn = func() (uint64, error) { return totalMemory, nil }
+	SetMemoryLimitFn = func(l int64) int64 {
+		prev := limit
+		if l >= 0 {
+			limit = l
+		}
+		return prev
+	}
+	t.Cleanup(func() {
+		GetMemoryFn, SetMemoryLimitFn = origGetMemoryFn, origSetMemoryLimitFn
+	})
+	return &limit
+}
+
+func TestGovernor(t *testing.T) {
+	limit := fakeRuntime(t, 1000*mibBytes)
+	g, err := New(zap.NewNop(), 80)
+	require.NoError(t, err)
+	require.NoError(t, g.Start())
+	assert.Equal(t, int64(800*mibBytes), *limit)
+	assert.Error(t, g.Start())
+
+	// The soft limit follows the lowest soft limit of the memory limiters.
+	RegisterLimiter("a", 700*mibBytes)
+	RegisterLimiter("b", 600*mibBytes)
+	assert.Equal(t, int64(600*mibBytes), *limit)
+	UnregisterLimiter("b")
+	assert.Equal(t, int64(700*mibBytes), *limit)
+	UnregisterLimiter("a")
+	assert.Equal(t, int64(800*mibBytes), *limit)
+
+	g.Shutdown()
+	assert.Equal(t, int64(math.MaxInt64), *limit)
+
+	// Limiters registered without a started governor do not change the soft limit.
+	RegisterLimiter("a", 700*mibBytes)
+	assert.Equal(t, int64(math.MaxInt64), *limit)
+	UnregisterLimiter("a")
+}
+
+func TestGovernorLimiterRegisteredBeforeStart(t *testing.T) {
+	limit := fakeRuntime(t, 1000*mibBytes)
+	RegisterLimiter("a", 500*mibBytes)
+	defer UnregisterLimiter("a")
+
+	g, err := New(zap.NewNop(), 80)
+	require.NoError(t, err)
+	require.NoError(t, g.Start())
+	defer g.Shutdown()
+	assert.Equal(t, int64(500*mibBytes), *limit)
+}
+
+func TestGovernorGOMEMLIMIT(t *testing.T) {
+	limit := fakeRuntime(t, 1000*mibBytes)
+	t.Setenv("GOMEMLIMIT", "200MiB")
+	g, err := New(zap.NewNop(), 80)
+	require.NoError(t, err)
+	require.NoError(t, g.Start())
+	RegisterLimiter("a", 500*mibBytes)
+	defer UnregisterLimiter("a")
+	assert.Equal(t, int64(math.MaxInt64), *limit)
+	g.Shutdown()
+}
+
+func TestNewGovernorErrors(t *testing.T) {
+	fakeRuntime(t, 1000*mibBytes)
+	_, err := New(zap.NewNop(), 0)
+	assert.ErrorIs(t, err, errLimitPercentageOutOfRange)
+	_, err = New(zap.NewNop(), 101)
+	assert.ErrorIs(t, err, errLimitPercentageOutOfRange)
+
+	GetMemoryFn = func() (uint64, error) { return 0, errors.New("no memory") }
+	_, err = New(zap.NewNop(), 80)
+	assert.EqualError(t, err, "failed to get total memory: no memory")
+}
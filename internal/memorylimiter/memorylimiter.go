@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/internal/iruntime"
+	"go.opentelemetry.io/collector/internal/memorygovernor"
 )
 
 const (
@@ -63,6 +64,10 @@ type MemoryLimiter struct {
 	refCounter     int
 	waitGroup      sync.WaitGroup
 	closed         chan struct{}
+
+	// listeners are notified when the memory usage crosses the soft limit, keyed by their registration.
+	listenersLock sync.Mutex
+	listeners     map[*func(bool)]struct{}
 }
 
 // NewMemoryLimiter returns a new memory limiter component
@@ -95,6 +100,8 @@ func (ml *MemoryLimiter) startMonitoring() {
 
 	ml.refCounter++
 	if ml.refCounter == 1 {
+		// Lower the soft memory limit of the Go runtime so the garbage collector runs before data is refused.
+		memorygovernor.RegisterLimiter(ml, ml.usageChecker.memAllocLimit-ml.usageChecker.memSpikeLimit)
 		ml.closed = make(chan struct{})
 		ml.waitGroup.Add(1)
 		go func() {
@@ -128,6 +135,7 @@ func (ml *MemoryLimiter) Shutdown(context.Context) error {
 		ml.ticker.Stop()
 		close(ml.closed)
 		ml.waitGroup.Wait()
+		memorygovernor.UnregisterLimiter(ml)
 	}
 	ml.refCounter--
 	return nil
@@ -174,6 +182,33 @@ func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
 	return ms
 }
 
+// OnPressureChange registers a function called with true when the memory usage goes above the soft limit, and
+// with false when it goes back within the limits. The function is called by the monitoring goroutine, so it must
+// not block. OnPressureChange returns a function unregistering it.
+func (ml *MemoryLimiter) OnPressureChange(f func(pressure bool)) (unregister func()) {
+	key := &f
+	ml.listenersLock.Lock()
+	defer ml.listenersLock.Unlock()
+	if ml.listeners == nil {
+		ml.listeners = make(map[*func(bool)]struct{})
+	}
+	ml.listeners[key] = struct{}{}
+	return func() {
+		ml.listenersLock.Lock()
+		defer ml.listenersLock.Unlock()
+		delete(ml.listeners, key)
+	}
+}
+
+// notifyPressure calls the functions registered with OnPressureChange.
+func (ml *MemoryLimiter) notifyPressure(pressure bool) {
+	ml.listenersLock.Lock()
+	defer ml.listenersLock.Unlock()
+	for f := range ml.listeners {
+		(*f)(pressure)
+	}
+}
+
 // CheckMemLimits inspects current memory usage against threshold and toggle mustRefuse when threshold is exceeded
 func (ml *MemoryLimiter) CheckMemLimits() {
 	ms := ml.readMemStats()
@@ -212,6 +247,9 @@ func (ml *MemoryLimiter) CheckMemLimits() {
 	}
 
 	ml.mustRefuse.Store(mustRefuse)
+	if mustRefuse != wasRefusing {
+		ml.notifyPressure(mustRefuse)
+	}
 }
 
 type memUsageChecker struct {
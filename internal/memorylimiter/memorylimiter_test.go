@@ -54,6 +54,34 @@ func TestMemoryPressureResponse(t *testing.T) {
 	assert.True(t, ml.MustRefuse())
 }
 
+func TestOnPressureChange(t *testing.T) {
+	var currentMemAlloc uint64
+	ml := &MemoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+		},
+		mustRefuse: &atomic.Bool{},
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+		logger: zap.NewNop(),
+	}
+	var signals []bool
+	unregister := ml.OnPressureChange(func(pressure bool) { signals = append(signals, pressure) })
+
+	// Only the crossings of the soft limit are signaled.
+	for _, alloc := range []uint64{800, 1800, 1900, 500, 600} {
+		currentMemAlloc = alloc
+		ml.CheckMemLimits()
+	}
+	assert.Equal(t, []bool{true, false}, signals)
+
+	unregister()
+	currentMemAlloc = 1800
+	ml.CheckMemLimits()
+	assert.Len(t, signals, 2)
+}
+
 func TestGetDecision(t *testing.T) {
 	t.Run("fixed_limit", func(t *testing.T) {
 		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
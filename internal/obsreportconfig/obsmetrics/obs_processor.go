@@ -18,5 +18,9 @@ const (
 )
 
 var (
-	ProcessorMetricPrefix = ProcessorKey + MetricNameSep
+	ProcessorPrefix                 = ProcessorKey + SpanNameSep
+	ProcessorMetricPrefix           = ProcessorKey + MetricNameSep
+	ProcessTraceDataOperationSuffix = SpanNameSep + "traces"
+	ProcessMetricsOperationSuffix   = SpanNameSep + "metrics"
+	ProcessLogsOperationSuffix      = SpanNameSep + "logs"
 )
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pdataconfig provides the configuration types of the pdata enumerations shared by the components.
+package pdataconfig // import "go.opentelemetry.io/collector/internal/pdataconfig"
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Severity is a log severity, configured by its short name, e.g. "WARN" or "error".
+type Severity plog.SeverityNumber
+
+var _ encoding.TextUnmarshaler = (*Severity)(nil)
+
+// UnmarshalText unmarshalls text to a Severity.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for sn := plog.SeverityNumberTrace; sn <= plog.SeverityNumberFatal4; sn++ {
+		if strings.EqualFold(sn.String(), string(text)) {
+			*s = Severity(sn)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid severity: %q", string(text))
+}
+
+// StatusCode is a span status code, configured by its name, e.g. "Error".
+type StatusCode ptrace.StatusCode
+
+var _ encoding.TextUnmarshaler = (*StatusCode)(nil)
+
+// UnmarshalText unmarshalls text to a StatusCode.
+func (sc *StatusCode) UnmarshalText(text []byte) error {
+	for _, code := range []ptrace.StatusCode{ptrace.StatusCodeUnset, ptrace.StatusCodeOk, ptrace.StatusCodeError} {
+		if strings.EqualFold(code.String(), string(text)) {
+			*sc = StatusCode(code)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status code: %q", string(text))
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdataconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestSeverityUnmarshalText(t *testing.T) {
+	var s Severity
+	require.NoError(t, s.UnmarshalText([]byte("error")))
+	assert.Equal(t, Severity(plog.SeverityNumberError), s)
+	require.NoError(t, s.UnmarshalText([]byte("WARN2")))
+	assert.Equal(t, Severity(plog.SeverityNumberWarn2), s)
+	assert.EqualError(t, s.UnmarshalText([]byte("loud")), `invalid severity: "loud"`)
+}
+
+func TestStatusCodeUnmarshalText(t *testing.T) {
+	var sc StatusCode
+	require.NoError(t, sc.UnmarshalText([]byte("error")))
+	assert.Equal(t, StatusCode(ptrace.StatusCodeError), sc)
+	require.NoError(t, sc.UnmarshalText([]byte("Unset")))
+	assert.Equal(t, StatusCode(ptrace.StatusCodeUnset), sc)
+	assert.EqualError(t, sc.UnmarshalText([]byte("failed")), `invalid status code: "failed"`)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host // import "go.opentelemetry.io/collector/internal/plugin/host"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config configures the process of a plugin and the component it runs. It is the configuration of the plugin
+// receivers, processors and exporters.
+type Config struct {
+	// Command is the path of the plugin executable, searched in the PATH if it does not contain a separator.
+	Command string `mapstructure:"command"`
+
+	// Args are the arguments of the plugin executable.
+	Args []string `mapstructure:"args"`
+
+	// Env are the environment variables of the plugin, in addition to the ones of the collector.
+	Env map[string]string `mapstructure:"env"`
+
+	// Config is the configuration of the component run by the plugin, unmarshaled by the factory of the plugin.
+	Config map[string]any `mapstructure:"config"`
+
+	// StartTimeout is the maximum time for the plugin to listen for the collector and to start its component.
+	StartTimeout time.Duration `mapstructure:"start_timeout"`
+
+	// ShutdownTimeout is the maximum time for the plugin to shut its component down and exit, after which it is killed.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// NewDefaultConfig returns the default configuration of the plugin components.
+func NewDefaultConfig() Config {
+	return Config{
+		StartTimeout:    10 * time.Second,
+		ShutdownTimeout: 10 * time.Second,
+	}
+}
+
+// Validate checks if the configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Command == "" {
+		return errors.New("'command' must be specified")
+	}
+	if cfg.StartTimeout <= 0 {
+		return errors.New("'start_timeout' must be positive")
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		return errors.New("'shutdown_timeout' must be positive")
+	}
+	return nil
+}
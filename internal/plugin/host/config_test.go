@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		errMsg string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{name: "no command", modify: func(cfg *Config) { cfg.Command = "" }, errMsg: "'command' must be specified"},
+		{name: "no start timeout", modify: func(cfg *Config) { cfg.StartTimeout = 0 }, errMsg: "'start_timeout' must be positive"},
+		{name: "negative shutdown timeout", modify: func(cfg *Config) { cfg.ShutdownTimeout = -time.Second }, errMsg: "'shutdown_timeout' must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Command = "/usr/local/bin/plugin"
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package host runs the component of a plugin in a process of its own, on behalf of the plugin receivers,
+// processors and exporters.
+package host // import "go.opentelemetry.io/collector/internal/plugin/host"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/plugin/protocol"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Component runs the component of a plugin. The plugin is started with the component and exits when it is
+// shut down. The data consumed by the component is sent to the plugin, and the data emitted by the plugin
+// receivers and processors is passed to the next consumer.
+type Component struct {
+	cfg      *Config
+	logger   *zap.Logger
+	id       component.ID
+	kind     component.Kind
+	dataType component.DataType
+	// next is the next consumer of the receivers and processors, nil for the exporters.
+	next any
+
+	nextServer *grpc.Server
+	cmd        *exec.Cmd
+	stdin      io.WriteCloser
+	exited     chan struct{}
+	conn       *grpc.ClientConn
+	// shuttingDown tells the process exits expectedly.
+	shuttingDown atomic.Bool
+
+	traces  consumer.Traces
+	metrics consumer.Metrics
+	logs    consumer.Logs
+}
+
+// New returns a Component running the plugin configured by cfg, as a component of the given kind, for the
+// data type of the pipeline. next is the next consumer of the receivers and processors.
+func New(cfg *Config, set component.TelemetrySettings, id component.ID, kind component.Kind, dataType component.DataType, next any) *Component {
+	return &Component{
+		cfg:      cfg,
+		logger:   set.Logger,
+		id:       id,
+		kind:     kind,
+		dataType: dataType,
+		next:     next,
+	}
+}
+
+// Start starts the plugin and its component.
+func (c *Component) Start(ctx context.Context, host component.Host) error {
+	req := protocol.StartRequest{ID: c.id, Kind: c.kind, DataType: c.dataType, Config: c.cfg.Config}
+	if c.next != nil {
+		secret, err := protocol.NewSecret()
+		if err != nil {
+			return err
+		}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return fmt.Errorf("failed to listen for the plugin: %w", err)
+		}
+		c.nextServer = grpc.NewServer(protocol.ServerOptions(secret)...)
+		protocol.RegisterConsumer(c.nextServer, func() any { return c.next })
+		go func() {
+			if err := c.nextServer.Serve(lis); err != nil {
+				c.logger.Error("Failed to serve the plugin", zap.Error(err))
+			}
+		}()
+		req.NextEndpoint = lis.Addr().String()
+		req.NextSecret = secret
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.StartTimeout)
+	defer cancel()
+	addr, secret, err := c.launch(ctx, host)
+	if err != nil {
+		return err
+	}
+	if c.conn, err = grpc.NewClient(addr, protocol.DialOptions(secret)...); err != nil {
+		return fmt.Errorf("failed to connect to the plugin: %w", err)
+	}
+	if err = protocol.NewComponentClient(c.conn).Start(ctx, req); err != nil {
+		return fmt.Errorf("failed to start the plugin component: %w", err)
+	}
+	c.traces = protocol.NewTraces(c.conn)
+	c.metrics = protocol.NewMetrics(c.conn)
+	c.logs = protocol.NewLogs(c.conn)
+	return nil
+}
+
+// launch starts the plugin process and returns the address it listens on and its secret, read from its handshake.
+func (c *Component) launch(ctx context.Context, host component.Host) (addr, secret string, err error) {
+	c.cmd = exec.Command(c.cfg.Command, c.cfg.Args...) //nolint:gosec // the command is configured by the user
+	c.cmd.Env = os.Environ()
+	for k, v := range c.cfg.Env {
+		c.cmd.Env = append(c.cmd.Env, k+"="+v)
+	}
+	// The processes started by the plugin may keep its output open after it exits.
+	c.cmd.WaitDelay = c.cfg.ShutdownTimeout
+
+	handshake := make(chan string, 1)
+	var once sync.Once
+	c.cmd.Stdout = &lineWriter{write: func(line string) {
+		handshaken := false
+		once.Do(func() {
+			handshake <- line
+			handshaken = true
+		})
+		if !handshaken {
+			c.logger.Warn("Unexpected plugin output", zap.String("output", line))
+		}
+	}}
+	c.cmd.Stderr = &lineWriter{write: func(line string) {
+		c.logger.Info("Plugin log", zap.String("log", line))
+	}}
+	if c.stdin, err = c.cmd.StdinPipe(); err != nil {
+		return "", "", err
+	}
+	if err = c.cmd.Start(); err != nil {
+		return "", "", fmt.Errorf("failed to start the plugin: %w", err)
+	}
+
+	c.exited = make(chan struct{})
+	go func() {
+		err := c.cmd.Wait()
+		if !c.shuttingDown.Load() {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(fmt.Errorf("plugin exited unexpectedly: %w", err)))
+		}
+		close(c.exited)
+	}()
+
+	select {
+	case line := <-handshake:
+		return protocol.ParseHandshake(line)
+	case <-c.exited:
+		return "", "", errors.New("plugin exited before the handshake")
+	case <-ctx.Done():
+		return "", "", fmt.Errorf("plugin did not complete the handshake: %w", ctx.Err())
+	}
+}
+
+// Shutdown shuts the component of the plugin down and waits for the plugin to exit, killing it after the
+// shutdown timeout.
+func (c *Component) Shutdown(ctx context.Context) error {
+	c.shuttingDown.Store(true)
+	var errs error
+	if c.conn != nil {
+		select {
+		case <-c.exited:
+		default:
+			shutdownCtx, cancel := context.WithTimeout(ctx, c.cfg.ShutdownTimeout)
+			errs = multierr.Append(errs, protocol.NewComponentClient(c.conn).Shutdown(shutdownCtx))
+			cancel()
+		}
+		errs = multierr.Append(errs, c.conn.Close())
+	}
+	if c.exited != nil {
+		_ = c.stdin.Close()
+		select {
+		case <-c.exited:
+		case <-time.After(c.cfg.ShutdownTimeout):
+			errs = multierr.Append(errs, c.cmd.Process.Kill())
+			<-c.exited
+		}
+	}
+	if c.nextServer != nil {
+		c.nextServer.Stop()
+	}
+	return errs
+}
+
+func (c *Component) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.traces.ConsumeTraces(ctx, td)
+}
+
+func (c *Component) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.metrics.ConsumeMetrics(ctx, md)
+}
+
+func (c *Component) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.logs.ConsumeLogs(ctx, ld)
+}
+
+// Capabilities returns the capabilities of the component: the data is serialized to the plugin, so it is not mutated.
+func (c *Component) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// lineWriter calls write with every line written to it.
+type lineWriter struct {
+	write func(line string)
+	buf   []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.write(string(bytes.TrimRight(w.buf[:i], "\r")))
+		w.buf = w.buf[i+1:]
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "go.opentelemetry.io/collector/internal/plugin/protocol"
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// secretKey is the gRPC metadata key carrying the secret of the server called. The servers of the collector and of
+// the plugins listen on loopback addresses reachable by any local process, they refuse the calls without their secret.
+const secretKey = "otelcol-plugin-secret"
+
+// NewSecret returns a random secret, generated by every server for the session between the collector and a plugin.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate the plugin secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ServerOptions returns the options of the gRPC servers of the collector and the plugins, refusing the calls
+// without the secret.
+func ServerOptions(secret string) []grpc.ServerOption {
+	check := func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, v := range md.Get(secretKey) {
+			if subtle.ConstantTimeCompare([]byte(v), []byte(secret)) == 1 {
+				return nil
+			}
+		}
+		return status.Error(codes.Unauthenticated, "invalid plugin secret")
+	}
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := check(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := check(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// DialOptions returns the options of the gRPC clients of the collector and the plugins, sending the secret of
+// the server with every call.
+func DialOptions(secret string) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(metadata.AppendToOutgoingContext(ctx, secretKey, secret), method, req, reply, cc, opts...)
+		}),
+	}
+}
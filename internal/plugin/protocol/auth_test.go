@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type nopComponentServer struct{}
+
+func (nopComponentServer) Start(context.Context, StartRequest) error {
+	return nil
+}
+
+func (nopComponentServer) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestSecret(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	other, err := NewSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 64)
+	assert.NotEqual(t, secret, other)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(ServerOptions(secret)...)
+	RegisterComponentServer(srv, nopComponentServer{})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	call := func(secret string) error {
+		conn, err := grpc.NewClient(lis.Addr().String(), DialOptions(secret)...)
+		require.NoError(t, err)
+		defer conn.Close()
+		return NewComponentClient(conn).Shutdown(context.Background())
+	}
+	assert.NoError(t, call(secret))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(other)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call("")))
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "go.opentelemetry.io/collector/internal/plugin/protocol"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// RegisterConsumer registers the OTLP gRPC services passing the received data to the consumer returned by
+// getConsumer, which may be nil until the component is started.
+func RegisterConsumer(s *grpc.Server, getConsumer func() any) {
+	ptraceotlp.RegisterGRPCServer(s, &tracesServer{getConsumer: getConsumer})
+	pmetricotlp.RegisterGRPCServer(s, &metricsServer{getConsumer: getConsumer})
+	plogotlp.RegisterGRPCServer(s, &logsServer{getConsumer: getConsumer})
+}
+
+type tracesServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	getConsumer func() any
+}
+
+func (s *tracesServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	next, ok := s.getConsumer().(consumer.Traces)
+	if !ok {
+		return ptraceotlp.NewExportResponse(), toStatus(errNotStarted)
+	}
+	return ptraceotlp.NewExportResponse(), toStatus(next.ConsumeTraces(ctx, req.Traces()))
+}
+
+type metricsServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	getConsumer func() any
+}
+
+func (s *metricsServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	next, ok := s.getConsumer().(consumer.Metrics)
+	if !ok {
+		return pmetricotlp.NewExportResponse(), toStatus(errNotStarted)
+	}
+	return pmetricotlp.NewExportResponse(), toStatus(next.ConsumeMetrics(ctx, req.Metrics()))
+}
+
+type logsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	getConsumer func() any
+}
+
+func (s *logsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	next, ok := s.getConsumer().(consumer.Logs)
+	if !ok {
+		return plogotlp.NewExportResponse(), toStatus(errNotStarted)
+	}
+	return plogotlp.NewExportResponse(), toStatus(next.ConsumeLogs(ctx, req.Logs()))
+}
+
+// toStatus converts the error returned by a consumer to a gRPC status: the permanent errors are not retryable
+// and are converted to codes.InvalidArgument, the other errors to codes.Unavailable.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if consumererror.IsPermanent(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+const permanentPrefix = "Permanent error: "
+
+// fromStatus converts the gRPC status returned by toStatus back to a consumer error.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if st := status.Convert(err); st.Code() == codes.InvalidArgument {
+		// The message of the permanent errors already starts with their prefix.
+		return consumererror.NewPermanent(errors.New(strings.TrimPrefix(st.Message(), permanentPrefix)))
+	}
+	return err
+}
+
+// NewTraces returns a consumer.Traces sending the traces to the OTLP gRPC server at the other end of the connection.
+func NewTraces(cc *grpc.ClientConn) consumer.Traces {
+	return tracesClient{client: ptraceotlp.NewGRPCClient(cc)}
+}
+
+type tracesClient struct {
+	client ptraceotlp.GRPCClient
+}
+
+func (c tracesClient) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	_, err := c.client.Export(ctx, ptraceotlp.NewExportRequestFromTraces(td))
+	return fromStatus(err)
+}
+
+func (c tracesClient) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// NewMetrics returns a consumer.Metrics sending the metrics to the OTLP gRPC server at the other end of the connection.
+func NewMetrics(cc *grpc.ClientConn) consumer.Metrics {
+	return metricsClient{client: pmetricotlp.NewGRPCClient(cc)}
+}
+
+type metricsClient struct {
+	client pmetricotlp.GRPCClient
+}
+
+func (c metricsClient) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	_, err := c.client.Export(ctx, pmetricotlp.NewExportRequestFromMetrics(md))
+	return fromStatus(err)
+}
+
+func (c metricsClient) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// NewLogs returns a consumer.Logs sending the logs to the OTLP gRPC server at the other end of the connection.
+func NewLogs(cc *grpc.ClientConn) consumer.Logs {
+	return logsClient{client: plogotlp.NewGRPCClient(cc)}
+}
+
+type logsClient struct {
+	client plogotlp.GRPCClient
+}
+
+func (c logsClient) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	_, err := c.client.Export(ctx, plogotlp.NewExportRequestFromLogs(ld))
+	return fromStatus(err)
+}
+
+func (c logsClient) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package protocol implements the protocol between the collector and its plugins: the handshake written by
+// the plugins once they listen for the collector, the secrets authenticating the calls between them, the gRPC
+// service starting and shutting down the component of the plugins, and the OTLP gRPC services exchanging the
+// data of the pipelines.
+package protocol // import "go.opentelemetry.io/collector/internal/plugin/protocol"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Version is the version of the protocol, written in the handshake so the collector refuses incompatible plugins.
+const Version = 2
+
+// handshakePrefix starts the handshake line, to tell it apart from any other output of the plugins.
+const handshakePrefix = "otelcol-plugin"
+
+// Handshake returns the line written by the plugins on their standard output once they listen on addr, with the
+// secret the collector must send with its calls.
+func Handshake(addr, secret string) string {
+	return fmt.Sprintf("%s|%d|%s|%s", handshakePrefix, Version, addr, secret)
+}
+
+// ParseHandshake returns the address the plugin listens on and its secret, written in its handshake line.
+func ParseHandshake(line string) (addr, secret string, err error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) < 3 || parts[0] != handshakePrefix {
+		return "", "", fmt.Errorf("invalid plugin handshake %q", line)
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid plugin protocol version %q", parts[1])
+	}
+	if version != Version {
+		return "", "", fmt.Errorf("unsupported plugin protocol version %d, expected %d", version, Version)
+	}
+	if len(parts) != 4 || parts[3] == "" {
+		return "", "", fmt.Errorf("invalid plugin handshake %q", line)
+	}
+	return parts[2], parts[3], nil
+}
+
+// StartRequest starts the component of a plugin.
+type StartRequest struct {
+	// ID is the ID of the component in the configuration of the collector.
+	ID component.ID
+	// Kind is the kind of component expected by the collector, which must be the one created by the plugin.
+	Kind component.Kind
+	// DataType is the type of the data of the pipeline the component is part of.
+	DataType component.DataType
+	// NextEndpoint is the address of the OTLP gRPC server of the collector receiving the data emitted by the
+	// receivers and the processors. It is empty for the exporters.
+	NextEndpoint string
+	// NextSecret is the secret the plugin must send with its calls to the NextEndpoint.
+	NextSecret string
+	// Config is the configuration of the component, unmarshaled by the factory of the plugin.
+	Config map[string]any
+}
+
+var kinds = map[component.Kind]string{
+	component.KindReceiver:  "receiver",
+	component.KindProcessor: "processor",
+	component.KindExporter:  "exporter",
+}
+
+// KindName returns the name of the kind of component, as sent in the StartRequest.
+func KindName(kind component.Kind) string {
+	return kinds[kind]
+}
+
+func (r StartRequest) toStruct() (*structpb.Struct, error) {
+	cfg := r.Config
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+	return structpb.NewStruct(map[string]any{
+		"id":            r.ID.String(),
+		"kind":          KindName(r.Kind),
+		"data_type":     r.DataType.String(),
+		"next_endpoint": r.NextEndpoint,
+		"next_secret":   r.NextSecret,
+		"config":        cfg,
+	})
+}
+
+func startRequestFromStruct(s *structpb.Struct) (StartRequest, error) {
+	fields := s.GetFields()
+	var r StartRequest
+	if err := r.ID.UnmarshalText([]byte(fields["id"].GetStringValue())); err != nil {
+		return r, fmt.Errorf("invalid component ID: %w", err)
+	}
+	kind := fields["kind"].GetStringValue()
+	for k, name := range kinds {
+		if name == kind {
+			r.Kind = k
+		}
+	}
+	if r.Kind == 0 {
+		return r, fmt.Errorf("invalid component kind %q", kind)
+	}
+	dataType, err := component.NewType(fields["data_type"].GetStringValue())
+	if err != nil {
+		return r, fmt.Errorf("invalid data type: %w", err)
+	}
+	r.DataType = dataType
+	r.NextEndpoint = fields["next_endpoint"].GetStringValue()
+	r.NextSecret = fields["next_secret"].GetStringValue()
+	r.Config = fields["config"].GetStructValue().AsMap()
+	return r, nil
+}
+
+// serviceName is the name of the gRPC service starting and shutting down the component of the plugins.
+const serviceName = "opentelemetry.collector.plugin.v1.Component"
+
+// ComponentServer is the service starting and shutting down the component of a plugin.
+type ComponentServer interface {
+	// Start creates the component with the configuration of the request and starts it. The plugin runs a
+	// single component, so it is only started once.
+	Start(context.Context, StartRequest) error
+	// Shutdown shuts the component down. The plugin exits once the call returns.
+	Shutdown(context.Context) error
+}
+
+// RegisterComponentServer registers the ComponentServer to the grpc.Server.
+func RegisterComponentServer(s *grpc.Server, srv ComponentServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*ComponentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Start", Handler: startHandler},
+		{MethodName: "Shutdown", Handler: shutdownHandler},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func startHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	start := func(ctx context.Context, req any) (any, error) {
+		r, err := startRequestFromStruct(req.(*structpb.Struct))
+		if err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, srv.(ComponentServer).Start(ctx, r)
+	}
+	if interceptor == nil {
+		return start(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/Start"}, start)
+}
+
+func shutdownHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	shutdown := func(ctx context.Context, _ any) (any, error) {
+		return &emptypb.Empty{}, srv.(ComponentServer).Shutdown(ctx)
+	}
+	if interceptor == nil {
+		return shutdown(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/Shutdown"}, shutdown)
+}
+
+// ComponentClient calls the ComponentServer of a plugin.
+type ComponentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewComponentClient returns a ComponentClient calling the plugin through the given connection.
+func NewComponentClient(cc grpc.ClientConnInterface) ComponentClient {
+	return ComponentClient{cc: cc}
+}
+
+// Start starts the component of the plugin.
+func (c ComponentClient) Start(ctx context.Context, r StartRequest) error {
+	in, err := r.toStruct()
+	if err != nil {
+		return fmt.Errorf("invalid start request: %w", err)
+	}
+	return c.cc.Invoke(ctx, "/"+serviceName+"/Start", in, new(emptypb.Empty))
+}
+
+// Shutdown shuts the component of the plugin down.
+func (c ComponentClient) Shutdown(ctx context.Context) error {
+	return c.cc.Invoke(ctx, "/"+serviceName+"/Shutdown", &emptypb.Empty{}, new(emptypb.Empty))
+}
+
+// errNotStarted is returned when data is sent to a plugin whose component is not started.
+var errNotStarted = errors.New("the plugin component is not started")
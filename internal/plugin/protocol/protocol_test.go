@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestHandshake(t *testing.T) {
+	addr, secret, err := ParseHandshake(Handshake("127.0.0.1:4317", "s3cr3t") + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:4317", addr)
+	assert.Equal(t, "s3cr3t", secret)
+
+	_, _, err = ParseHandshake("listening on 127.0.0.1:4317")
+	assert.EqualError(t, err, `invalid plugin handshake "listening on 127.0.0.1:4317"`)
+	_, _, err = ParseHandshake("otelcol-plugin|v2|127.0.0.1:4317|s3cr3t")
+	assert.EqualError(t, err, `invalid plugin protocol version "v2"`)
+	_, _, err = ParseHandshake("otelcol-plugin|1|127.0.0.1:4317")
+	assert.EqualError(t, err, "unsupported plugin protocol version 1, expected 2")
+	_, _, err = ParseHandshake("otelcol-plugin|2|127.0.0.1:4317|")
+	assert.EqualError(t, err, `invalid plugin handshake "otelcol-plugin|2|127.0.0.1:4317|"`)
+}
+
+func TestStartRequest(t *testing.T) {
+	req := StartRequest{
+		ID:           component.MustNewIDWithName("plugin", "custom"),
+		Kind:         component.KindProcessor,
+		DataType:     component.DataTypeMetrics,
+		NextEndpoint: "127.0.0.1:4317",
+		NextSecret:   "s3cr3t",
+		Config: map[string]any{
+			"endpoint": "localhost:8080",
+			"timeout":  "5s",
+			"headers":  map[string]any{"key": "value"},
+			"hosts":    []any{"a", "b"},
+			"enabled":  true,
+			"size":     float64(16),
+		},
+	}
+	s, err := req.toStruct()
+	require.NoError(t, err)
+	got, err := startRequestFromStruct(s)
+	require.NoError(t, err)
+	assert.Equal(t, req, got)
+
+	s.Fields["kind"].Kind = nil
+	_, err = startRequestFromStruct(s)
+	assert.EqualError(t, err, `invalid component kind ""`)
+}
+
+func TestStatus(t *testing.T) {
+	assert.NoError(t, toStatus(nil))
+	assert.NoError(t, fromStatus(nil))
+
+	err := toStatus(errors.New("queue is full"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	err = fromStatus(err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	err = toStatus(consumererror.NewPermanent(errors.New("invalid data")))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = fromStatus(err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.EqualError(t, err, "Permanent error: invalid data")
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package runtimeprofile holds the runtime profile of the collector, selected with the --profile flag.
+// The profile tunes the defaults of the service and of the components for a deployment, so it must be
+// set before the default configurations of the components are created.
+package runtimeprofile // import "go.opentelemetry.io/collector/internal/runtimeprofile"
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Profile is a runtime profile of the collector.
+type Profile string
+
+const (
+	// Default is the profile of a collector deployed as a gateway or as a standalone service.
+	Default Profile = "default"
+	// Agent is the profile of a collector deployed as a sidecar or a DaemonSet agent, minimizing its footprint:
+	// the zPages are disabled, the internal telemetry is reduced to the basic metrics, and the exporter queues
+	// and the service subsystems are smaller.
+	Agent Profile = "agent"
+)
+
+var current atomic.Value
+
+func init() {
+	current.Store(Default)
+}
+
+// Set sets the runtime profile of the collector.
+func Set(p Profile) error {
+	switch p {
+	case Default, Agent:
+		current.Store(p)
+		return nil
+	}
+	return fmt.Errorf("unknown runtime profile %q, must be %q or %q", p, Default, Agent)
+}
+
+// Get returns the runtime profile of the collector.
+func Get() Profile {
+	return current.Load().(Profile)
+}
+
+// IsAgent returns whether the collector runs with the agent profile.
+func IsAgent() bool {
+	return Get() == Agent
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	assert.Equal(t, Default, Get())
+	assert.False(t, IsAgent())
+
+	require.NoError(t, Set(Agent))
+	t.Cleanup(func() { require.NoError(t, Set(Default)) })
+	assert.Equal(t, Agent, Get())
+	assert.True(t, IsAgent())
+
+	require.EqualError(t, Set("tiny"), `unknown runtime profile "tiny", must be "default" or "agent"`)
+	assert.Equal(t, Agent, Get())
+}
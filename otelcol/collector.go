@@ -21,9 +21,11 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/redactconverter"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/eventlog"
 )
 
 // State defines Collector's state.
@@ -105,6 +107,9 @@ type Collector struct {
 	asyncErrorChannel          chan error
 	bc                         *bufferedCore
 	updateConfigProviderLogger func(core zapcore.Core)
+
+	// eventLog keeps the recent events of the collector across the reloads of its configuration.
+	eventLog *eventlog.Log
 }
 
 // NewCollector creates and returns a new instance of Collector.
@@ -131,6 +136,7 @@ func NewCollector(set CollectorSettings) (*Collector, error) {
 		// the number of signals getting notified on is recommended.
 		signalsChannel:             make(chan os.Signal, 3),
 		asyncErrorChannel:          make(chan error),
+		eventLog:                   eventlog.NewLog(eventlog.NewDefaultSettings()),
 		configProvider:             configProvider,
 		bc:                         bc,
 		updateConfigProviderLogger: cc.SetCore,
@@ -179,6 +185,16 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	if err = conf.Marshal(cfg); err != nil {
 		return fmt.Errorf("could not marshal configuration: %w", err)
 	}
+	// The configopaque.String values are already redacted by the marshaling.
+	redactor := redactconverter.NewFactory().Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	if err = redactor.Convert(ctx, conf); err != nil {
+		return fmt.Errorf("could not redact configuration: %w", err)
+	}
+	// The provenance of the values is only known for the configurations resolved by the default ConfigProvider,
+	// the values set by default by the components have none.
+	if cp, ok := col.configProvider.(*configProvider); ok {
+		conf.SetProvenance(cp.provenance)
+	}
 
 	col.service, err = service.New(ctx, service.Settings{
 		BuildInfo:     col.set.BuildInfo,
@@ -204,6 +220,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		},
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
+		EventLog:          col.eventLog,
 	}, cfg.Service)
 	if err != nil {
 		return err
@@ -235,6 +252,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	col.service.Logger().Warn("Config updated, restart service")
+	col.eventLog.Record(eventlog.KindConfigReload, "", "Config updated, restarting service")
 	col.setCollectorState(StateClosing)
 
 	if err := col.service.Shutdown(ctx); err != nil {
@@ -242,6 +260,7 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	}
 
 	if err := col.setupConfigurationComponents(ctx); err != nil {
+		col.eventLog.Record(eventlog.KindConfigReload, "", "Config reload failed: "+err.Error())
 		return fmt.Errorf("failed to setup configuration components: %w", err)
 	}
 
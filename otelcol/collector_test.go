@@ -22,6 +22,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/redactconverter"
+	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
@@ -114,6 +116,23 @@ func TestCollectorStateAfterConfigChange(t *testing.T) {
 
 	wg.Wait()
 	assert.Equal(t, StateClosed, col.GetState())
+
+	// The event log is kept across the reloads of the configuration.
+	var msgs []string
+	for _, ev := range col.eventLog.Events() {
+		if ev.Component == "" {
+			msgs = append(msgs, string(ev.Kind)+": "+ev.Message)
+		}
+	}
+	assert.Equal(t, []string{
+		"lifecycle: Service started",
+		"config_reload: Config updated, restarting service",
+		"lifecycle: Service stopping",
+		"lifecycle: Service stopped",
+		"lifecycle: Service started",
+		"lifecycle: Service stopping",
+		"lifecycle: Service stopped",
+	}, msgs)
 }
 
 func TestCollectorReportError(t *testing.T) {
@@ -225,6 +244,54 @@ func TestComponentStatusWatcher(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+type configWatcherConfig struct {
+	Password string `mapstructure:"password"`
+}
+
+type configWatcherExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	notified chan *confmap.Conf
+}
+
+func (e *configWatcherExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	e.notified <- conf
+	return nil
+}
+
+func TestCollectorNotifyRedactedConfig(t *testing.T) {
+	require.NoError(t, redactconverter.RegisterSensitiveKeys("^password$"))
+
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	watcher := &configWatcherExtension{notified: make(chan *confmap.Conf, 1)}
+	factory := extension.NewFactory(component.MustNewType("configwatcher"),
+		func() component.Config { return &configWatcherConfig{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return watcher, nil
+		}, component.StabilityLevelDevelopment)
+	factories.Extensions[factory.Type()] = factory
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:              component.NewDefaultBuildInfo(),
+		Factories:              func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-configwatcher.yaml")}),
+	})
+	require.NoError(t, err)
+	wg := startCollector(context.Background(), t, col)
+
+	conf := <-watcher.notified
+	assert.Equal(t, redactconverter.RedactedValue, conf.Get("extensions::configwatcher::password"))
+	// The values are annotated with the file they come from, the default values have no source.
+	assert.Equal(t, []string{"file:" + filepath.Join("testdata", "otelcol-configwatcher.yaml")},
+		conf.Provenance().Sources("extensions::configwatcher::password"))
+	assert.Equal(t, "info", conf.Get("service::telemetry::logs::level"))
+	assert.Nil(t, conf.Provenance().Sources("service::telemetry::logs::level"))
+
+	col.Shutdown()
+	wg.Wait()
+}
+
 func TestCollectorSendSignal(t *testing.T) {
 	col, err := NewCollector(CollectorSettings{
 		BuildInfo:              component.NewDefaultBuildInfo(),
@@ -6,6 +6,8 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"slices"
 
 	"github.com/spf13/cobra"
 
@@ -39,6 +41,8 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	}
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
+	rootCmd.AddCommand(newSchemaSubCommand(set))
+	rootCmd.AddCommand(newTestSubCommand(set, flagSet))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -55,7 +59,22 @@ func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error
 		return errors.New("at least one config flag must be provided")
 	}
 
-	if globalgates.UseUnifiedEnvVarExpansionRules.IsEnabled() && set.ConfigProviderSettings.ResolverSettings.DefaultScheme == "" {
+	if allowed := getSchemesFlag(flags, allowedSchemesFlag); len(allowed) > 0 {
+		// The flag can only restrict further the schemes allowed by the settings.
+		for _, scheme := range allowed {
+			if len(resolverSet.AllowedSchemes) > 0 && !slices.Contains(resolverSet.AllowedSchemes, scheme) {
+				return fmt.Errorf("config scheme %q is not allowed by the collector settings", scheme)
+			}
+		}
+		resolverSet.AllowedSchemes = allowed
+	}
+	if precedence := getSchemesFlag(flags, configSchemePrecedenceFlag); len(precedence) > 0 {
+		resolverSet.SchemePrecedence = precedence
+	}
+
+	// The environment variables are not expanded by default if the scheme is not allowed.
+	envAllowed := len(resolverSet.AllowedSchemes) == 0 || slices.Contains(resolverSet.AllowedSchemes, "env")
+	if globalgates.UseUnifiedEnvVarExpansionRules.IsEnabled() && set.ConfigProviderSettings.ResolverSettings.DefaultScheme == "" && envAllowed {
 		set.ConfigProviderSettings.ResolverSettings.DefaultScheme = "env"
 	}
 
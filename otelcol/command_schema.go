@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+)
+
+// newSchemaSubCommand constructs a new schema sub command using the given CollectorSettings.
+func newSchemaSubCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Outputs a JSON Schema of the configuration accepted by this collector distribution",
+		Long: "Outputs a JSON Schema of the configuration accepted by this collector distribution, derived from the default configuration of the available components. " +
+			"The schema can be used for editor autocompletion and to validate configuration files in CI. The output format is not stable and can change between releases.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+			schema := configschema.New(set.BuildInfo.Description, []configschema.Kind{
+				{Name: "receivers", Factories: toFactories(sortFactoriesByType(factories.Receivers))},
+				{Name: "processors", Factories: toFactories(sortFactoriesByType(factories.Processors))},
+				{Name: "exporters", Factories: toFactories(sortFactoriesByType(factories.Exporters))},
+				{Name: "connectors", Factories: toFactories(sortFactoriesByType(factories.Connectors))},
+				{Name: "extensions", Factories: toFactories(sortFactoriesByType(factories.Extensions))},
+			})
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(schema)
+		},
+	}
+}
+
+func toFactories[T component.Factory](factories []T) []component.Factory {
+	out := make([]component.Factory, 0, len(factories))
+	for _, f := range factories {
+		out = append(out, f)
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestSchemaSubCommand(t *testing.T) {
+	cmd := NewCommand(CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: nopFactories})
+	cmd.SetArgs([]string{"schema"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	properties, ok := schema["properties"].(map[string]any)
+	require.True(t, ok)
+	for _, kind := range []string{"receivers", "processors", "exporters", "connectors", "extensions", "service"} {
+		assert.Contains(t, properties, kind)
+	}
+	receivers := properties["receivers"].(map[string]any)
+	assert.Contains(t, receivers["patternProperties"], "^nop(/.+)?$")
+}
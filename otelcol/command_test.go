@@ -159,3 +159,68 @@ func Test_UseUnifiedEnvVarExpansionRules(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigSchemesFlags(t *testing.T) {
+	tests := []struct {
+		name               string
+		allowed            []string
+		args               []string
+		expectedAllowed    []string
+		expectedPrecedence []string
+		expectedDefault    string
+		expectedErr        string
+	}{
+		{
+			name:            "no flags",
+			args:            []string{"--config=otelcol-nop.yaml"},
+			expectedDefault: "env",
+		},
+		{
+			name:               "flags",
+			args:               []string{"--config=otelcol-nop.yaml", "--allowed-config-schemes=file, yaml", "--config-scheme-precedence=file,yaml"},
+			expectedAllowed:    []string{"file", "yaml"},
+			expectedPrecedence: []string{"file", "yaml"},
+		},
+		{
+			name:            "restrict settings",
+			allowed:         []string{"file", "env"},
+			args:            []string{"--config=otelcol-nop.yaml", "--allowed-config-schemes=env", "--allowed-config-schemes=file"},
+			expectedAllowed: []string{"env", "file"},
+			expectedDefault: "env",
+		},
+		{
+			name:        "widen settings",
+			allowed:     []string{"file"},
+			args:        []string{"--config=otelcol-nop.yaml", "--allowed-config-schemes=file,http"},
+			expectedErr: `config scheme "http" is not allowed by the collector settings`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileProvider := newFakeProvider("file", func(context.Context, string, confmap.WatcherFunc) (*confmap.Retrieved, error) {
+				return &confmap.Retrieved{}, nil
+			})
+			set := CollectorSettings{
+				ConfigProviderSettings: ConfigProviderSettings{
+					ResolverSettings: confmap.ResolverSettings{
+						ProviderFactories: []confmap.ProviderFactory{fileProvider},
+						AllowedSchemes:    tt.allowed,
+					},
+				},
+			}
+			flgs := flags(featuregate.NewRegistry())
+			require.NoError(t, flgs.Parse(tt.args))
+
+			err := updateSettingsUsingFlags(&set, flgs)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			resolverSet := set.ConfigProviderSettings.ResolverSettings
+			assert.Equal(t, tt.expectedAllowed, resolverSet.AllowedSchemes)
+			assert.Equal(t, tt.expectedPrecedence, resolverSet.SchemePrecedence)
+			assert.Equal(t, tt.expectedDefault, resolverSet.DefaultScheme)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"flag"
+
+	"github.com/spf13/cobra"
+)
+
+// newTestSubCommand constructs a new test sub command using the given CollectorSettings.
+func newTestSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var samplePaths []string
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Runs sample OTLP payloads through the pipelines and outputs what the exporters would send",
+		Long: "Runs sample OTLP JSON payloads through the pipelines of the config, without network calls: the samples are injected at every receiver, " +
+			"go through the configured processors and connectors, and the data each exporter would send is written as OTLP JSON. " +
+			"The receivers, exporters and extensions are not started.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+			return col.TestRun(cmd.Context(), samplePaths, cmd.OutOrStdout())
+		},
+	}
+	testCmd.Flags().AddGoFlagSet(flagSet)
+	testCmd.Flags().StringArrayVar(&samplePaths, "sample", nil, "Path to an OTLP JSON file with the traces, metrics or logs to inject at the receivers, can be repeated.")
+	return testCmd
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestTestSubCommand(t *testing.T) {
+	cmd := newTestSubCommand(CollectorSettings{
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-testrun.yaml")}),
+	}, flags(featuregate.GlobalRegistry()))
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{
+		"--sample", filepath.Join("testdata", "sample-traces.json"),
+		"--sample", filepath.Join("testdata", "sample-metrics.json"),
+		"--sample", filepath.Join("testdata", "sample-logs.json"),
+	})
+	require.NoError(t, cmd.Execute())
+
+	// The nop processor drops the metrics.
+	assert.Contains(t, out.String(), "exporter nop (traces): 1 spans\n{\n  \"resourceSpans\"")
+	assert.Contains(t, out.String(), "exporter nop/2 (traces): 1 spans\n")
+	assert.Contains(t, out.String(), "\"name\": \"GET /cart\"")
+	assert.Contains(t, out.String(), "exporter nop (metrics): nothing exported\n")
+	assert.Contains(t, out.String(), "exporter nop/2 (logs): 2 log records\n")
+	assert.Contains(t, out.String(), "\"stringValue\": \"slow request\"")
+	assert.NotContains(t, out.String(), "exporter nop (logs)")
+}
+
+func TestTestSubCommandNoSample(t *testing.T) {
+	cmd := newTestSubCommand(CollectorSettings{
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-testrun.yaml")}),
+	}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{})
+	require.ErrorIs(t, cmd.Execute(), errNoSample)
+}
+
+func TestReadSamples(t *testing.T) {
+	smp, err := readSamples([]string{filepath.Join("testdata", "sample-traces.json"), filepath.Join("testdata", "sample-logs.json")})
+	require.NoError(t, err)
+	assert.Len(t, smp.traces, 1)
+	assert.Empty(t, smp.metrics)
+	assert.Len(t, smp.logs, 1)
+
+	_, err = readSamples([]string{filepath.Join("testdata", "otelcol-nop.yaml")})
+	require.ErrorContains(t, err, "failed to parse sample")
+	_, err = readSamples([]string{filepath.Join("testdata", "does-not-exist.json")})
+	require.ErrorContains(t, err, "failed to read sample")
+	empty := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(empty, []byte("{}"), 0600))
+	_, err = readSamples([]string{empty})
+	require.ErrorContains(t, err, "no resourceSpans, resourceMetrics or resourceLogs field")
+}
@@ -46,6 +46,8 @@ type ConfigProvider interface {
 
 type configProvider struct {
 	mapResolver *confmap.Resolver
+	// provenance records where the values of the last configuration come from.
+	provenance *confmap.Provenance
 }
 
 var _ ConfigProvider = (*configProvider)(nil)
@@ -78,6 +80,7 @@ func (cm *configProvider) Get(ctx context.Context, factories Factories) (*Config
 	if err != nil {
 		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
 	}
+	cm.provenance = conf.Provenance()
 
 	var cfg *configSettings
 	if cfg, err = unmarshal(conf, factories); err != nil {
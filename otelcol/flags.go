@@ -9,10 +9,14 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
 const (
-	configFlag = "config"
+	configFlag                 = "config"
+	allowedSchemesFlag         = "allowed-config-schemes"
+	configSchemePrecedenceFlag = "config-scheme-precedence"
+	profileFlag                = "profile"
 )
 
 type configFlagValue struct {
@@ -20,6 +24,26 @@ type configFlagValue struct {
 	sets   []string
 }
 
+// schemesFlagValue is a comma separated list of config provider schemes.
+type schemesFlagValue struct {
+	schemes []string
+}
+
+func (s *schemesFlagValue) Set(val string) error {
+	for _, scheme := range strings.Split(val, ",") {
+		scheme = strings.TrimSpace(scheme)
+		if scheme == "" {
+			return errors.New("empty scheme")
+		}
+		s.schemes = append(s.schemes, scheme)
+	}
+	return nil
+}
+
+func (s *schemesFlagValue) String() string {
+	return strings.Join(s.schemes, ",")
+}
+
 func (s *configFlagValue) Set(val string) error {
 	s.values = append(s.values, val)
 	return nil
@@ -49,6 +73,23 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 			return nil
 		})
 
+	flagSet.Var(new(schemesFlagValue), allowedSchemesFlag,
+		"Comma separated list of the schemes the configuration can be retrieved from and expanded with,"+
+			" e.g. `--allowed-config-schemes=file,yaml` to forbid the environment variables and remote locations."+
+			" All the schemes of the config providers are allowed if not set.")
+
+	flagSet.Var(new(schemesFlagValue), configSchemePrecedenceFlag,
+		"Comma separated list of schemes ordering the config locations by precedence before they are merged,"+
+			" the locations of a scheme overriding the ones of the schemes listed before it, e.g. `--config-scheme-precedence=file,yaml`.")
+
+	flagSet.Func(profileFlag,
+		"Runtime profile of the collector, `default` or `agent`. The agent profile minimizes the footprint of a collector"+
+			" deployed as a sidecar or a DaemonSet agent: the zPages are disabled, the internal telemetry is reduced to"+
+			" the basic metrics, and the exporter queues are smaller.",
+		func(s string) error {
+			return runtimeprofile.Set(runtimeprofile.Profile(s))
+		})
+
 	reg.RegisterFlags(flagSet)
 	return flagSet
 }
@@ -57,3 +98,7 @@ func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
 }
+
+func getSchemesFlag(flagSet *flag.FlagSet, name string) []string {
+	return flagSet.Lookup(name).Value.(*schemesFlagValue).schemes
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 )
 
 func TestSetFlag(t *testing.T) {
@@ -69,3 +70,19 @@ func TestSetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemesFlag(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.EqualError(t, flgs.Parse([]string{"--allowed-config-schemes=file,,env"}), `invalid value "file,,env" for flag -allowed-config-schemes: empty scheme`)
+}
+
+func TestProfileFlag(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, runtimeprofile.Set(runtimeprofile.Default)) })
+
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--profile=agent"}))
+	assert.True(t, runtimeprofile.IsAgent())
+
+	flgs = flags(featuregate.NewRegistry())
+	require.EqualError(t, flgs.Parse([]string{"--profile=tiny"}), `invalid value "tiny" for flag -profile: unknown runtime profile "tiny", must be "default" or "agent"`)
+}
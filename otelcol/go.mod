@@ -5,15 +5,19 @@ go 1.22.0
 require (
 	github.com/spf13/cobra v1.8.1
 	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/collector v0.107.0
 	go.opentelemetry.io/collector/component v0.107.0
 	go.opentelemetry.io/collector/component/componentstatus v0.107.0
 	go.opentelemetry.io/collector/config/configtelemetry v0.107.0
 	go.opentelemetry.io/collector/confmap v0.107.0
+	go.opentelemetry.io/collector/confmap/converter/redactconverter v0.107.0
 	go.opentelemetry.io/collector/connector v0.107.0
+	go.opentelemetry.io/collector/consumer v0.107.0
 	go.opentelemetry.io/collector/exporter v0.107.0
 	go.opentelemetry.io/collector/extension v0.107.0
 	go.opentelemetry.io/collector/featuregate v1.13.0
 	go.opentelemetry.io/collector/internal/globalgates v0.107.0
+	go.opentelemetry.io/collector/pdata v1.13.0
 	go.opentelemetry.io/collector/processor v0.107.0
 	go.opentelemetry.io/collector/receiver v0.107.0
 	go.opentelemetry.io/collector/service v0.107.0
@@ -63,14 +67,17 @@ require (
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
-	go.opentelemetry.io/collector v0.107.0 // indirect
+	go.opentelemetry.io/collector/component/componententities v0.107.0 // indirect
 	go.opentelemetry.io/collector/component/componentprofiles v0.107.0 // indirect
-	go.opentelemetry.io/collector/consumer v0.107.0 // indirect
+	go.opentelemetry.io/collector/consumer/consumerentities v0.107.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumerprofiles v0.107.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumertest v0.107.0 // indirect
-	go.opentelemetry.io/collector/pdata v1.13.0 // indirect
+	go.opentelemetry.io/collector/exporter/exporterentities v0.107.0 // indirect
+	go.opentelemetry.io/collector/pdata/pentity v0.107.0 // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.107.0 // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.107.0 // indirect
+	go.opentelemetry.io/collector/processor/processorentities v0.107.0 // indirect
+	go.opentelemetry.io/collector/receiver/receiverentities v0.107.0 // indirect
 	go.opentelemetry.io/collector/semconv v0.107.0 // indirect
 	go.opentelemetry.io/contrib/config v0.8.0 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.28.0 // indirect
@@ -103,6 +110,14 @@ replace go.opentelemetry.io/collector => ../
 
 replace go.opentelemetry.io/collector/service => ../service
 
+replace go.opentelemetry.io/collector/component/componententities => ../component/componententities
+
+replace go.opentelemetry.io/collector/exporter/exporterentities => ../exporter/exporterentities
+
+replace go.opentelemetry.io/collector/processor/processorentities => ../processor/processorentities
+
+replace go.opentelemetry.io/collector/receiver/receiverentities => ../receiver/receiverentities
+
 replace go.opentelemetry.io/collector/connector => ../connector
 
 replace go.opentelemetry.io/collector/component => ../component
@@ -113,6 +128,8 @@ replace go.opentelemetry.io/collector/pdata/testdata => ../pdata/testdata
 
 replace go.opentelemetry.io/collector/pdata/pprofile => ../pdata/pprofile
 
+replace go.opentelemetry.io/collector/pdata/pentity => ../pdata/pentity
+
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../extension/zpagesextension
 
 replace go.opentelemetry.io/collector/extension => ../extension
@@ -121,6 +138,8 @@ replace go.opentelemetry.io/collector/exporter => ../exporter
 
 replace go.opentelemetry.io/collector/confmap => ../confmap
 
+replace go.opentelemetry.io/collector/confmap/converter/redactconverter => ../confmap/converter/redactconverter
+
 replace go.opentelemetry.io/collector/config/configtelemetry => ../config/configtelemetry
 
 replace go.opentelemetry.io/collector/processor => ../processor
@@ -153,6 +172,8 @@ replace go.opentelemetry.io/collector/internal/globalgates => ../internal/global
 
 replace go.opentelemetry.io/collector/consumer/consumerprofiles => ../consumer/consumerprofiles
 
+replace go.opentelemetry.io/collector/consumer/consumerentities => ../consumer/consumerentities
+
 replace go.opentelemetry.io/collector/consumer/consumertest => ../consumer/consumertest
 
 replace go.opentelemetry.io/collector/component/componentprofiles => ../component/componentprofiles
@@ -160,3 +181,5 @@ replace go.opentelemetry.io/collector/component/componentprofiles => ../componen
 replace go.opentelemetry.io/collector/client => ../client
 
 replace go.opentelemetry.io/collector/component/componentstatus => ../component/componentstatus
+
+replace go.opentelemetry.io/collector/extension/middleware => ../extension/middleware
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Schema is a JSON Schema document, as defined by https://json-schema.org/draft/2020-12/schema.
+// Only the subset of keywords required to describe component configurations is supported,
+// Type holds either a type name or a list of type names.
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Format               string             `json:"format,omitempty"`
+}
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// nullableObject is the type of the component configurations and of their nested sections. An empty section,
+// e.g. "otlp:" or "grpc:", is decoded as null by YAML and stands for the default configuration.
+var nullableObject = []string{"object", "null"}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Kind describes the components of a single kind (e.g. receivers) to be included in the schema.
+type Kind struct {
+	// Name is the key used for this kind in the configuration, e.g. "receivers".
+	Name string
+	// Factories are the registered factories for this kind.
+	Factories []component.Factory
+}
+
+// New returns a JSON Schema for a collector configuration containing the given component kinds.
+// Component configurations are derived from the default configuration returned by each factory,
+// and the default values are included in the schema.
+func New(title string, kinds []Kind) *Schema {
+	root := &Schema{
+		SchemaURI:            draft,
+		Title:                title,
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	for _, kind := range kinds {
+		ks := &Schema{
+			Type:                 "object",
+			PatternProperties:    map[string]*Schema{},
+			AdditionalProperties: false,
+		}
+		for _, f := range kind.Factories {
+			// Component IDs have the form "type" or "type/name".
+			pattern := "^" + regexp.QuoteMeta(f.Type().String()) + "(/.+)?$"
+			ks.PatternProperties[pattern] = ForConfig(f.CreateDefaultConfig())
+		}
+		root.Properties[kind.Name] = ks
+	}
+	root.Properties["service"] = &Schema{Type: "object"}
+	return root
+}
+
+// ForConfig returns the JSON Schema of the given configuration value.
+func ForConfig(cfg any) *Schema {
+	if cfg == nil {
+		return &Schema{}
+	}
+	return forValue(reflect.ValueOf(cfg), map[reflect.Type]bool{})
+}
+
+func forValue(v reflect.Value, visiting map[reflect.Type]bool) *Schema {
+	t := v.Type()
+
+	// Types that unmarshal themselves from text are represented as strings, e.g. component.ID.
+	if t != durationType && (t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)) {
+		s := &Schema{Type: "string"}
+		if m, ok := v.Interface().(encoding.TextMarshaler); ok && !isZero(v) {
+			if text, err := m.MarshalText(); err == nil {
+				s.Default = string(text)
+			}
+		}
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return forValue(reflect.Zero(t.Elem()), visiting)
+		}
+		return forValue(v.Elem(), visiting)
+	case reflect.Interface:
+		if v.IsNil() {
+			return &Schema{}
+		}
+		return forValue(v.Elem(), visiting)
+	case reflect.Bool:
+		return withDefault(&Schema{Type: "boolean"}, v)
+	case reflect.Int64:
+		if t == durationType {
+			s := &Schema{Type: "string", Format: "duration"}
+			if !v.IsZero() {
+				s.Default = time.Duration(v.Int()).String()
+			}
+			return s
+		}
+		return withDefault(&Schema{Type: "integer"}, v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return withDefault(&Schema{Type: "integer"}, v)
+	case reflect.Float32, reflect.Float64:
+		return withDefault(&Schema{Type: "number"}, v)
+	case reflect.String:
+		return withDefault(&Schema{Type: "string"}, v)
+	case reflect.Slice, reflect.Array:
+		elem := reflect.Zero(t.Elem())
+		if v.Len() > 0 {
+			elem = v.Index(0)
+		}
+		return &Schema{Type: "array", Items: forValue(elem, visiting)}
+	case reflect.Map:
+		return &Schema{Type: nullableObject, AdditionalProperties: forValue(reflect.Zero(t.Elem()), visiting)}
+	case reflect.Struct:
+		return forStruct(v, visiting)
+	}
+	return &Schema{}
+}
+
+func forStruct(v reflect.Value, visiting map[reflect.Type]bool) *Schema {
+	t := v.Type()
+	s := &Schema{Type: nullableObject, Properties: map[string]*Schema{}}
+	// Recursive types cannot be expanded, allow any value for the nested occurrence.
+	if visiting[t] {
+		return &Schema{Type: nullableObject}
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, squash, skip := parseTag(field)
+		if skip {
+			continue
+		}
+		fs := forValue(v.Field(i), visiting)
+		if squash {
+			for k, p := range fs.Properties {
+				s.Properties[k] = p
+			}
+			continue
+		}
+		s.Properties[name] = fs
+	}
+	return s
+}
+
+// parseTag returns the configuration key of the field following the mapstructure conventions.
+func parseTag(field reflect.StructField) (name string, squash bool, skip bool) {
+	tag := field.Tag.Get("mapstructure")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "squash" {
+			return "", true, false
+		}
+	}
+	if field.Anonymous && parts[0] == "" {
+		return "", true, false
+	}
+	if parts[0] == "" {
+		return strings.ToLower(field.Name), false, false
+	}
+	return parts[0], false, false
+}
+
+// withDefault sets the default value of the schema, converted to the underlying basic type
+// so that custom marshalers (e.g. redacting opaque values) do not leak into the schema.
+func withDefault(s *Schema, v reflect.Value) *Schema {
+	if isZero(v) {
+		return s
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		s.Default = v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.Default = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Default = v.Uint()
+	case reflect.Float32, reflect.Float64:
+		s.Default = v.Float()
+	case reflect.String:
+		s.Default = v.String()
+	}
+	return s
+}
+
+func isZero(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+type Embedded struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type testConfig struct {
+	Embedded `mapstructure:",squash"`
+	Enabled  bool              `mapstructure:"enabled"`
+	Timeout  time.Duration     `mapstructure:"timeout"`
+	Ratio    float64           `mapstructure:"ratio"`
+	Headers  map[string]string `mapstructure:"headers"`
+	Items    []int             `mapstructure:"items"`
+	Storage  *component.ID     `mapstructure:"storage"`
+	Nested   *testConfig       `mapstructure:"nested"`
+	Ignored  string            `mapstructure:"-"`
+	NoTag    string
+	private  string
+}
+
+func TestForConfig(t *testing.T) {
+	cfg := &testConfig{
+		Embedded: Embedded{Endpoint: "localhost:4317"},
+		Enabled:  true,
+		Timeout:  5 * time.Second,
+		private:  "private",
+	}
+	s := ForConfig(cfg)
+	assert.Equal(t, nullableObject, s.Type)
+	assert.Equal(t, &Schema{Type: "string", Default: "localhost:4317"}, s.Properties["endpoint"])
+	assert.Equal(t, &Schema{Type: "boolean", Default: true}, s.Properties["enabled"])
+	assert.Equal(t, &Schema{Type: "string", Format: "duration", Default: "5s"}, s.Properties["timeout"])
+	assert.Equal(t, &Schema{Type: "number"}, s.Properties["ratio"])
+	assert.Equal(t, &Schema{Type: nullableObject, AdditionalProperties: &Schema{Type: "string"}}, s.Properties["headers"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "integer"}}, s.Properties["items"])
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["storage"])
+	assert.Equal(t, &Schema{Type: nullableObject}, s.Properties["nested"])
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["notag"])
+	assert.NotContains(t, s.Properties, "Ignored")
+	assert.NotContains(t, s.Properties, "private")
+	assert.Len(t, s.Properties, 9)
+}
+
+func TestNew(t *testing.T) {
+	factory := receivertest.NewNopFactory()
+	s := New("test", []Kind{{Name: "receivers", Factories: []component.Factory{factory}}})
+	assert.Equal(t, draft, s.SchemaURI)
+	assert.Equal(t, "test", s.Title)
+	require.Contains(t, s.Properties, "receivers")
+	require.Contains(t, s.Properties, "service")
+	assert.Contains(t, s.Properties["receivers"].PatternProperties, "^nop(/.+)?$")
+
+	_, err := json.Marshal(s)
+	require.NoError(t, err)
+}
+
+func TestNewEmptyBodies(t *testing.T) {
+	factory := receiver.NewFactory(component.MustNewType("test"), func() component.Config {
+		return &testConfig{Nested: &testConfig{}}
+	})
+	s := New("test", []Kind{{Name: "receivers", Factories: []component.Factory{factory}}})
+
+	var conf any
+	require.NoError(t, yaml.Unmarshal([]byte(`
+receivers:
+  test:
+  test/nested:
+    nested:
+    headers:
+  test/invalid:
+    enabled: "true"
+`), &conf))
+	// Round-trip through JSON to validate the values as a JSON Schema validator would see them.
+	buf, err := json.Marshal(conf)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(buf, &conf))
+	receivers := conf.(map[string]any)["receivers"].(map[string]any)
+	rs := s.Properties["receivers"]
+
+	require.NoError(t, validate(rs, map[string]any{"test": receivers["test"], "test/nested": receivers["test/nested"]}))
+	assert.EqualError(t, validate(rs, map[string]any{"test/invalid": receivers["test/invalid"]}), `test/invalid: enabled: "true" is not of type boolean`)
+}
+
+// validate checks the value against the subset of JSON Schema keywords produced by this package.
+func validate(s *Schema, v any) error {
+	if !matchesType(s.Type, v) {
+		return fmt.Errorf("%#v is not of type %v", v, s.Type)
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			ps, err := propertySchema(s, k)
+			if err != nil {
+				return err
+			}
+			if err := validate(ps, val); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+		}
+	case []any:
+		for _, val := range v {
+			if s.Items == nil {
+				continue
+			}
+			if err := validate(s.Items, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func propertySchema(s *Schema, key string) (*Schema, error) {
+	if ps, ok := s.Properties[key]; ok {
+		return ps, nil
+	}
+	for pattern, ps := range s.PatternProperties {
+		if regexp.MustCompile(pattern).MatchString(key) {
+			return ps, nil
+		}
+	}
+	switch ap := s.AdditionalProperties.(type) {
+	case *Schema:
+		return ap, nil
+	case bool:
+		if !ap {
+			return nil, fmt.Errorf("unexpected property %q", key)
+		}
+	}
+	return &Schema{}, nil
+}
+
+func matchesType(typ any, v any) bool {
+	var types []string
+	switch typ := typ.(type) {
+	case nil:
+		return true
+	case string:
+		types = []string{typ}
+	case []string:
+		types = typ
+	}
+	var actual string
+	switch v := v.(type) {
+	case nil:
+		actual = "null"
+	case bool:
+		actual = "boolean"
+	case string:
+		actual = "string"
+	case float64:
+		actual = "number"
+		if v == float64(int64(v)) && slices.Contains(types, "integer") {
+			actual = "integer"
+		}
+	case map[string]any:
+		actual = "object"
+	case []any:
+		actual = "array"
+	}
+	return slices.Contains(types, actual)
+}
@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service"
+)
+
+var errNoSample = errors.New("at least one sample must be provided")
+
+// samples are the OTLP payloads injected at the receivers by a test run.
+type samples struct {
+	traces  []ptrace.Traces
+	metrics []pmetric.Metrics
+	logs    []plog.Logs
+}
+
+// readSamples reads the OTLP JSON files at the given paths, the signal of every file is
+// detected from its top-level field.
+func readSamples(paths []string) (*samples, error) {
+	if len(paths) == 0 {
+		return nil, errNoSample
+	}
+	smp := &samples{}
+	for _, p := range paths {
+		buf, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sample: %w", err)
+		}
+		var fields map[string]json.RawMessage
+		if err = json.Unmarshal(buf, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse sample %q: %w", p, err)
+		}
+		switch {
+		case fields["resourceSpans"] != nil:
+			var td ptrace.Traces
+			if td, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(buf); err == nil {
+				smp.traces = append(smp.traces, td)
+			}
+		case fields["resourceMetrics"] != nil:
+			var md pmetric.Metrics
+			if md, err = (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(buf); err == nil {
+				smp.metrics = append(smp.metrics, md)
+			}
+		case fields["resourceLogs"] != nil:
+			var ld plog.Logs
+			if ld, err = (&plog.JSONUnmarshaler{}).UnmarshalLogs(buf); err == nil {
+				smp.logs = append(smp.logs, ld)
+			}
+		default:
+			err = errors.New("no resourceSpans, resourceMetrics or resourceLogs field")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sample %q: %w", p, err)
+		}
+	}
+	return smp, nil
+}
+
+// testRun runs the samples through the pipelines of a configuration. The receivers are replaced by
+// components injecting the samples when they start, and the exporters by components recording the
+// data they receive, so no network call is made. The processors and connectors are the configured ones.
+type testRun struct {
+	samples *samples
+
+	mu      sync.Mutex
+	errs    []string
+	traces  map[component.ID]ptrace.Traces
+	metrics map[component.ID]pmetric.Metrics
+	logs    map[component.ID]plog.Logs
+}
+
+func newTestRun(smp *samples) *testRun {
+	return &testRun{
+		samples: smp,
+		traces:  make(map[component.ID]ptrace.Traces),
+		metrics: make(map[component.ID]pmetric.Metrics),
+		logs:    make(map[component.ID]plog.Logs),
+	}
+}
+
+// TestRun runs the given OTLP JSON samples through the pipelines of the configuration and writes
+// to w the data each exporter would send, without starting the receivers, exporters and extensions.
+func (col *Collector) TestRun(ctx context.Context, samplePaths []string, w io.Writer) error {
+	smp, err := readSamples(samplePaths)
+	if err != nil {
+		return err
+	}
+	factories, err := col.set.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	cfg, err := col.configProvider.Get(ctx, factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	tr := newTestRun(smp)
+	receivers := make(map[component.Type]receiver.Factory, len(factories.Receivers))
+	for typ, f := range factories.Receivers {
+		receivers[typ] = tr.receiverFactory(f)
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		exporters[typ] = tr.exporterFactory(f)
+	}
+
+	// The extensions, the discovered receivers and the internal telemetry could make network calls.
+	cfg.Service.Extensions = nil
+	cfg.Service.Discovery = nil
+	cfg.Service.Telemetry.Metrics.Level = configtelemetry.LevelNone
+	cfg.Service.Telemetry.Metrics.Address = ""
+	cfg.Service.Telemetry.Metrics.Readers = nil
+	cfg.Service.Telemetry.Traces.Processors = nil
+
+	srv, err := service.New(ctx, service.Settings{
+		BuildInfo:           col.set.BuildInfo,
+		ReceiversConfigs:    cfg.Receivers,
+		ReceiversFactories:  receivers,
+		ProcessorsConfigs:   cfg.Processors,
+		ProcessorsFactories: factories.Processors,
+		ExportersConfigs:    cfg.Exporters,
+		ExportersFactories:  exporters,
+		ConnectorsConfigs:   cfg.Connectors,
+		ConnectorsFactories: factories.Connectors,
+		ExtensionsFactories: map[component.Type]extension.Factory{},
+		AsyncErrorChannel:   make(chan error, 1),
+		LoggingOptions:      col.set.LoggingOptions,
+	}, cfg.Service)
+	if err != nil {
+		return err
+	}
+	// The processors buffering the data, e.g. batch, flush it when they are shut down.
+	if err = srv.Start(ctx); err != nil {
+		return multierr.Combine(err, srv.Shutdown(ctx))
+	}
+	if err = srv.Shutdown(ctx); err != nil {
+		return err
+	}
+	return tr.report(w)
+}
+
+func (tr *testRun) recordError(format string, args ...any) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.errs = append(tr.errs, fmt.Sprintf(format, args...))
+}
+
+// sampleReceiver injects the samples in its pipelines when it starts.
+type sampleReceiver struct {
+	component.ShutdownFunc
+	id       component.ID
+	dataType component.DataType
+	tr       *testRun
+	inject   func(context.Context) error
+}
+
+func (r *sampleReceiver) Start(ctx context.Context, _ component.Host) error {
+	if err := r.inject(ctx); err != nil {
+		r.tr.recordError("receiver %s (%s): %v", r.id, r.dataType, err)
+	}
+	return nil
+}
+
+func (tr *testRun) receiverFactory(f receiver.Factory) receiver.Factory {
+	return receiver.NewFactory(f.Type(), f.CreateDefaultConfig,
+		receiver.WithTraces(func(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Traces) (receiver.Traces, error) {
+			return &sampleReceiver{id: set.ID, dataType: component.DataTypeTraces, tr: tr, inject: func(ctx context.Context) error {
+				for _, td := range tr.samples.traces {
+					clone := ptrace.NewTraces()
+					td.CopyTo(clone)
+					if err := next.ConsumeTraces(ctx, clone); err != nil {
+						return err
+					}
+				}
+				return nil
+			}}, nil
+		}, component.StabilityLevelStable),
+		receiver.WithMetrics(func(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+			return &sampleReceiver{id: set.ID, dataType: component.DataTypeMetrics, tr: tr, inject: func(ctx context.Context) error {
+				for _, md := range tr.samples.metrics {
+					clone := pmetric.NewMetrics()
+					md.CopyTo(clone)
+					if err := next.ConsumeMetrics(ctx, clone); err != nil {
+						return err
+					}
+				}
+				return nil
+			}}, nil
+		}, component.StabilityLevelStable),
+		receiver.WithLogs(func(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Logs) (receiver.Logs, error) {
+			return &sampleReceiver{id: set.ID, dataType: component.DataTypeLogs, tr: tr, inject: func(ctx context.Context) error {
+				for _, ld := range tr.samples.logs {
+					clone := plog.NewLogs()
+					ld.CopyTo(clone)
+					if err := next.ConsumeLogs(ctx, clone); err != nil {
+						return err
+					}
+				}
+				return nil
+			}}, nil
+		}, component.StabilityLevelStable),
+	)
+}
+
+// recordingTracesExporter records the traces it receives instead of sending them.
+type recordingTracesExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+// recordingMetricsExporter records the metrics it receives instead of sending them.
+type recordingMetricsExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Metrics
+}
+
+// recordingLogsExporter records the logs it receives instead of sending them.
+type recordingLogsExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Logs
+}
+
+func (tr *testRun) exporterFactory(f exporter.Factory) exporter.Factory {
+	return exporter.NewFactory(f.Type(), f.CreateDefaultConfig,
+		exporter.WithTraces(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Traces, error) {
+			tr.mu.Lock()
+			tr.traces[set.ID] = ptrace.NewTraces()
+			tr.mu.Unlock()
+			next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+				tr.mu.Lock()
+				defer tr.mu.Unlock()
+				clone := ptrace.NewTraces()
+				td.CopyTo(clone)
+				clone.ResourceSpans().MoveAndAppendTo(tr.traces[set.ID].ResourceSpans())
+				return nil
+			})
+			return &recordingTracesExporter{Traces: next}, err
+		}, component.StabilityLevelStable),
+		exporter.WithMetrics(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Metrics, error) {
+			tr.mu.Lock()
+			tr.metrics[set.ID] = pmetric.NewMetrics()
+			tr.mu.Unlock()
+			next, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+				tr.mu.Lock()
+				defer tr.mu.Unlock()
+				clone := pmetric.NewMetrics()
+				md.CopyTo(clone)
+				clone.ResourceMetrics().MoveAndAppendTo(tr.metrics[set.ID].ResourceMetrics())
+				return nil
+			})
+			return &recordingMetricsExporter{Metrics: next}, err
+		}, component.StabilityLevelStable),
+		exporter.WithLogs(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Logs, error) {
+			tr.mu.Lock()
+			tr.logs[set.ID] = plog.NewLogs()
+			tr.mu.Unlock()
+			next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+				tr.mu.Lock()
+				defer tr.mu.Unlock()
+				clone := plog.NewLogs()
+				ld.CopyTo(clone)
+				clone.ResourceLogs().MoveAndAppendTo(tr.logs[set.ID].ResourceLogs())
+				return nil
+			})
+			return &recordingLogsExporter{Logs: next}, err
+		}, component.StabilityLevelStable),
+	)
+}
+
+// report writes the errors returned to the receivers, then the data received by every exporter as OTLP JSON.
+func (tr *testRun) report(w io.Writer) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	for _, e := range tr.errs {
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+	for _, id := range sortedIDs(tr.traces) {
+		td := tr.traces[id]
+		buf, err := (&ptrace.JSONMarshaler{Indent: "  "}).MarshalTraces(td)
+		if err != nil {
+			return err
+		}
+		if err = writeExported(w, id, component.DataTypeTraces, td.SpanCount(), "spans", buf); err != nil {
+			return err
+		}
+	}
+	for _, id := range sortedIDs(tr.metrics) {
+		md := tr.metrics[id]
+		buf, err := (&pmetric.JSONMarshaler{Indent: "  "}).MarshalMetrics(md)
+		if err != nil {
+			return err
+		}
+		if err = writeExported(w, id, component.DataTypeMetrics, md.DataPointCount(), "data points", buf); err != nil {
+			return err
+		}
+	}
+	for _, id := range sortedIDs(tr.logs) {
+		ld := tr.logs[id]
+		buf, err := (&plog.JSONMarshaler{Indent: "  "}).MarshalLogs(ld)
+		if err != nil {
+			return err
+		}
+		if err = writeExported(w, id, component.DataTypeLogs, ld.LogRecordCount(), "log records", buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeExported(w io.Writer, id component.ID, dataType component.DataType, count int, unit string, buf []byte) error {
+	if count == 0 {
+		_, err := fmt.Fprintf(w, "exporter %s (%s): nothing exported\n", id, dataType)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "exporter %s (%s): %d %s\n%s\n", id, dataType, count, unit, buf)
+	return err
+}
+
+func sortedIDs[T any](m map[component.ID]T) []component.ID {
+	ids := make([]component.ID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"slices"
+	"strings"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// canonicalMessage is implemented by the generated protos.
+type canonicalMessage interface {
+	Size() int
+	MarshalToSizedBuffer(dAtA []byte) (int, error)
+}
+
+// MarshalCanonical returns the canonical proto encoding of orig, where the entries of every attributes map
+// and of every map value are sorted by key. orig is sorted in place, so it must be a copy owned by the caller.
+func MarshalCanonical(orig canonicalMessage) []byte {
+	switch orig := orig.(type) {
+	case *otlptrace.ResourceSpans:
+		sortResourceSpans(orig)
+	case *otlpmetrics.ResourceMetrics:
+		sortResourceMetrics(orig)
+	case *otlplogs.ResourceLogs:
+		sortResourceLogs(orig)
+	}
+	buf := make([]byte, orig.Size())
+	// The generated marshaling only fails when the buffer is too small.
+	n, _ := orig.MarshalToSizedBuffer(buf)
+	return buf[len(buf)-n:]
+}
+
+// CanonicalHash returns the 128-bit FNV-1a hash of a canonical encoding.
+func CanonicalHash(buf []byte) [16]byte {
+	h := NewHasher()
+	h.Write(buf)
+	return h.Sum()
+}
+
+func sortResourceSpans(orig *otlptrace.ResourceSpans) {
+	sortKeyValues(orig.Resource.Attributes)
+	for _, ss := range orig.ScopeSpans {
+		sortKeyValues(ss.Scope.Attributes)
+		for _, span := range ss.Spans {
+			sortKeyValues(span.Attributes)
+			for _, event := range span.Events {
+				sortKeyValues(event.Attributes)
+			}
+			for _, link := range span.Links {
+				sortKeyValues(link.Attributes)
+			}
+		}
+	}
+}
+
+func sortResourceMetrics(orig *otlpmetrics.ResourceMetrics) {
+	sortKeyValues(orig.Resource.Attributes)
+	for _, sm := range orig.ScopeMetrics {
+		sortKeyValues(sm.Scope.Attributes)
+		for _, m := range sm.Metrics {
+			sortKeyValues(m.Metadata)
+			switch data := m.Data.(type) {
+			case *otlpmetrics.Metric_Gauge:
+				sortNumberDataPoints(data.Gauge.DataPoints)
+			case *otlpmetrics.Metric_Sum:
+				sortNumberDataPoints(data.Sum.DataPoints)
+			case *otlpmetrics.Metric_Histogram:
+				for _, dp := range data.Histogram.DataPoints {
+					sortKeyValues(dp.Attributes)
+					sortExemplars(dp.Exemplars)
+				}
+			case *otlpmetrics.Metric_ExponentialHistogram:
+				for _, dp := range data.ExponentialHistogram.DataPoints {
+					sortKeyValues(dp.Attributes)
+					sortExemplars(dp.Exemplars)
+				}
+			case *otlpmetrics.Metric_Summary:
+				for _, dp := range data.Summary.DataPoints {
+					sortKeyValues(dp.Attributes)
+				}
+			}
+		}
+	}
+}
+
+func sortNumberDataPoints(dps []*otlpmetrics.NumberDataPoint) {
+	for _, dp := range dps {
+		sortKeyValues(dp.Attributes)
+		sortExemplars(dp.Exemplars)
+	}
+}
+
+func sortExemplars(exemplars []otlpmetrics.Exemplar) {
+	for i := range exemplars {
+		sortKeyValues(exemplars[i].FilteredAttributes)
+	}
+}
+
+func sortResourceLogs(orig *otlplogs.ResourceLogs) {
+	sortKeyValues(orig.Resource.Attributes)
+	for _, sl := range orig.ScopeLogs {
+		sortKeyValues(sl.Scope.Attributes)
+		for _, lr := range sl.LogRecords {
+			sortAnyValue(&lr.Body)
+			sortKeyValues(lr.Attributes)
+		}
+	}
+}
+
+// sortKeyValues sorts by key the entries of the map and of its nested map values.
+func sortKeyValues(kvs []otlpcommon.KeyValue) {
+	slices.SortStableFunc(kvs, func(a, b otlpcommon.KeyValue) int { return strings.Compare(a.Key, b.Key) })
+	for i := range kvs {
+		sortAnyValue(&kvs[i].Value)
+	}
+}
+
+func sortAnyValue(v *otlpcommon.AnyValue) {
+	switch val := v.Value.(type) {
+	case *otlpcommon.AnyValue_KvlistValue:
+		if val.KvlistValue != nil {
+			sortKeyValues(val.KvlistValue.Values)
+		}
+	case *otlpcommon.AnyValue_ArrayValue:
+		if val.ArrayValue != nil {
+			for i := range val.ArrayValue.Values {
+				sortAnyValue(&val.ArrayValue.Values[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1 holds the data model of the entity events. The OTLP protocol does not define the entity events
+// yet, so unlike the other signals the model is not generated from the protocol buffers definitions, and the
+// entity events can only be exchanged within the collector.
+package v1 // import "go.opentelemetry.io/collector/pdata/internal/data/entities/v1"
+
+import (
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+)
+
+// EntityEventType is the type of an entity event.
+type EntityEventType int32
+
+const (
+	// EntityEventType_ENTITY_EVENT_TYPE_UNSPECIFIED is the type of the events without a type.
+	EntityEventType_ENTITY_EVENT_TYPE_UNSPECIFIED EntityEventType = 0
+	// EntityEventType_ENTITY_EVENT_TYPE_CREATE is the type of the events reporting an entity being created.
+	EntityEventType_ENTITY_EVENT_TYPE_CREATE EntityEventType = 1
+	// EntityEventType_ENTITY_EVENT_TYPE_UPDATE is the type of the events reporting the attributes of an entity being updated.
+	EntityEventType_ENTITY_EVENT_TYPE_UPDATE EntityEventType = 2
+	// EntityEventType_ENTITY_EVENT_TYPE_DELETE is the type of the events reporting an entity being deleted.
+	EntityEventType_ENTITY_EVENT_TYPE_DELETE EntityEventType = 3
+)
+
+// EntitiesData is a collection of entity events, grouped by the resource and the instrumentation scope
+// reporting them.
+type EntitiesData struct {
+	ResourceEntities []*ResourceEntities
+}
+
+// ResourceEntities is a collection of entity events reported by a Resource.
+type ResourceEntities struct {
+	Resource      otlpresource.Resource
+	ScopeEntities []*ScopeEntities
+	SchemaUrl     string
+}
+
+// ScopeEntities is a collection of entity events reported by an InstrumentationScope.
+type ScopeEntities struct {
+	Scope        otlpcommon.InstrumentationScope
+	EntityEvents []*EntityEvent
+	SchemaUrl    string
+}
+
+// EntityEvent is an event reporting an entity being created, updated or deleted.
+type EntityEvent struct {
+	// TimeUnixNano is the time the event occurred.
+	TimeUnixNano uint64
+	// EntityType is the type of the entity, e.g. "k8s.pod".
+	EntityType string
+	// Id holds the attributes identifying the entity.
+	Id []otlpcommon.KeyValue
+	// Type is the type of the event.
+	Type EntityEventType
+	// Attributes holds the descriptive attributes of the entity, empty for the delete events.
+	Attributes []otlpcommon.KeyValue
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+const (
+	offset128Lower = 0x62b821756295c58d
+	offset128Upper = 0x6c62272e07bb0142
+	prime128Lower  = 0x13b
+	prime128Shift  = 24
+)
+
+// Hasher computes a 128-bit FNV-1a hash. Unlike hash/fnv, it is a value which does not allocate.
+type Hasher struct {
+	upper, lower uint64
+}
+
+// NewHasher returns a new Hasher.
+func NewHasher() Hasher {
+	return Hasher{upper: offset128Upper, lower: offset128Lower}
+}
+
+// Write writes raw bytes.
+func (h *Hasher) Write(b []byte) {
+	for _, c := range b {
+		h.writeByte(c)
+	}
+}
+
+// WriteString writes the length of the string followed by its bytes.
+func (h *Hasher) WriteString(s string) {
+	h.WriteUint64(uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h.writeByte(s[i])
+	}
+}
+
+// WriteUint64 writes the little-endian encoding of v.
+func (h *Hasher) WriteUint64(v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	h.Write(buf[:])
+}
+
+func (h *Hasher) writeByte(c byte) {
+	h.lower ^= uint64(c)
+	upper, lower := bits.Mul64(prime128Lower, h.lower)
+	upper += h.lower<<prime128Shift + prime128Lower*h.upper
+	h.upper, h.lower = upper, lower
+}
+
+// Sum returns the hash of the data written so far.
+func (h *Hasher) Sum() [16]byte {
+	var sum [16]byte
+	binary.BigEndian.PutUint64(sum[:8], h.upper)
+	binary.BigEndian.PutUint64(sum[8:], h.lower)
+	return sum
+}
+
+// The types of the values, written before them.
+const (
+	hashTypeEmpty uint64 = iota
+	hashTypeStr
+	hashTypeInt
+	hashTypeDouble
+	hashTypeBool
+	hashTypeMap
+	hashTypeSlice
+	hashTypeBytes
+)
+
+// HashKeyValues returns the hash of a map, which does not depend on the order of its entries: the hashes of the
+// entries are summed, so the entries do not need to be sorted.
+func HashKeyValues(kvs []otlpcommon.KeyValue) [16]byte {
+	var upper, lower uint64
+	for i := range kvs {
+		e := NewHasher()
+		e.WriteString(kvs[i].Key)
+		e.writeAnyValue(&kvs[i].Value)
+		var carry uint64
+		lower, carry = bits.Add64(lower, e.lower, 0)
+		upper, _ = bits.Add64(upper, e.upper, carry)
+	}
+	h := NewHasher()
+	h.WriteUint64(uint64(len(kvs)))
+	h.WriteUint64(upper)
+	h.WriteUint64(lower)
+	return h.Sum()
+}
+
+func (h *Hasher) writeAnyValue(v *otlpcommon.AnyValue) {
+	switch val := v.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		h.WriteUint64(hashTypeStr)
+		h.WriteString(val.StringValue)
+	case *otlpcommon.AnyValue_IntValue:
+		h.WriteUint64(hashTypeInt)
+		h.WriteUint64(uint64(val.IntValue))
+	case *otlpcommon.AnyValue_DoubleValue:
+		h.WriteUint64(hashTypeDouble)
+		h.WriteUint64(math.Float64bits(val.DoubleValue))
+	case *otlpcommon.AnyValue_BoolValue:
+		h.WriteUint64(hashTypeBool)
+		if val.BoolValue {
+			h.WriteUint64(1)
+		} else {
+			h.WriteUint64(0)
+		}
+	case *otlpcommon.AnyValue_KvlistValue:
+		h.WriteUint64(hashTypeMap)
+		var sum [16]byte
+		if val.KvlistValue != nil {
+			sum = HashKeyValues(val.KvlistValue.Values)
+		} else {
+			sum = HashKeyValues(nil)
+		}
+		h.Write(sum[:])
+	case *otlpcommon.AnyValue_ArrayValue:
+		h.WriteUint64(hashTypeSlice)
+		if val.ArrayValue == nil {
+			h.WriteUint64(0)
+			return
+		}
+		h.WriteUint64(uint64(len(val.ArrayValue.Values)))
+		for i := range val.ArrayValue.Values {
+			h.writeAnyValue(&val.ArrayValue.Values[i])
+		}
+	case *otlpcommon.AnyValue_BytesValue:
+		h.WriteUint64(hashTypeBytes)
+		h.WriteUint64(uint64(len(val.BytesValue)))
+		h.Write(val.BytesValue)
+	default:
+		h.WriteUint64(hashTypeEmpty)
+	}
+}
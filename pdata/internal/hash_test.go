@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasher(t *testing.T) {
+	for _, data := range []string{"", "a", "attributes", string(make([]byte, 100))} {
+		expected := fnv.New128a()
+		_, _ = expected.Write([]byte(data))
+		h := NewHasher()
+		h.Write([]byte(data))
+		sum := h.Sum()
+		assert.Equal(t, expected.Sum(nil), sum[:])
+	}
+}
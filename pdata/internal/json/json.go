@@ -20,3 +20,25 @@ var marshaler = &jsonpb.Marshaler{
 func Marshal(out io.Writer, pb proto.Message) error {
 	return marshaler.Marshal(out, pb)
 }
+
+// MarshalOptions changes the encoding of the OTLP/JSON format, the zero value keeps the OTLP/JSON encoding.
+type MarshalOptions struct {
+	// Indent, if not empty, indents the nested values with it.
+	Indent string
+	// EnumsAsNames encodes the enums with their names instead of their integer values.
+	EnumsAsNames bool
+	// EmitDefaults encodes the fields with zero values.
+	EmitDefaults bool
+}
+
+// MarshalWithOptions marshals pb to the OTLP/JSON format changed by the given options.
+func MarshalWithOptions(out io.Writer, pb proto.Message, opts MarshalOptions) error {
+	if opts == (MarshalOptions{}) {
+		return Marshal(out, pb)
+	}
+	m := *marshaler
+	m.Indent = opts.Indent
+	m.EnumsAsInts = !opts.EnumsAsNames
+	m.EmitDefaults = opts.EmitDefaults
+	return m.Marshal(out, pb)
+}
@@ -225,6 +225,17 @@ func (m Map) Range(f func(k string, v Value) bool) {
 	}
 }
 
+// All returns an iterator over key-value pairs in the map, see iter.Seq2.
+//
+// Example:
+//
+//	for k, v := range sm.All() {
+//	    ...
+//	}
+func (m Map) All() func(yield func(string, Value) bool) {
+	return m.Range
+}
+
 // CopyTo copies all elements from the current map overriding the destination.
 func (m Map) CopyTo(dest Map) {
 	dest.getState().AssertMutable()
@@ -252,6 +263,13 @@ func (m Map) CopyTo(dest Map) {
 	*dest.getOrig() = origs
 }
 
+// Hash returns a 128-bit fingerprint of the Map, which does not depend on the order the entries were inserted in,
+// in the Map or in any of its map values. It does not allocate, so it can be used on the hot path as an identity or
+// routing key, but it is not a cryptographic hash.
+func (m Map) Hash() [16]byte {
+	return internal.HashKeyValues(*m.getOrig())
+}
+
 // AsRaw returns a standard go map representation of this Map.
 func (m Map) AsRaw() map[string]any {
 	rawMap := make(map[string]any)
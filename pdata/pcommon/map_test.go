@@ -323,6 +323,26 @@ func TestMap_Range(t *testing.T) {
 	assert.EqualValues(t, 0, len(rawMap))
 }
 
+func TestMap_All(t *testing.T) {
+	am := NewMap()
+	am.PutStr("k_string", "123")
+	am.PutInt("k_int", 123)
+	am.PutBool("k_bool", true)
+	got := map[string]any{}
+	am.All()(func(k string, v Value) bool {
+		got[k] = v.AsRaw()
+		return true
+	})
+	assert.Equal(t, am.AsRaw(), got)
+
+	calls := 0
+	am.All()(func(string, Value) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls)
+}
+
 func TestMap_FromRaw(t *testing.T) {
 	am := NewMap()
 	assert.NoError(t, am.FromRaw(map[string]any{}))
@@ -546,3 +566,42 @@ func TestInvalidMap(t *testing.T) {
 	assert.Panics(t, func() { v.AsRaw() })
 	assert.Panics(t, func() { _ = v.FromRaw(map[string]any{"foo": "bar"}) })
 }
+
+func TestMap_Hash(t *testing.T) {
+	m1 := NewMap()
+	m1.PutStr("k1", "v1")
+	m1.PutInt("k2", 2)
+	nested := m1.PutEmptyMap("k3")
+	nested.PutStr("a", "1")
+	nested.PutBool("b", true)
+
+	m2 := NewMap()
+	nested = m2.PutEmptyMap("k3")
+	nested.PutBool("b", true)
+	nested.PutStr("a", "1")
+	m2.PutInt("k2", 2)
+	m2.PutStr("k1", "v1")
+	assert.Equal(t, m1.Hash(), m2.Hash())
+	// Hashing does not reorder the entries of the Map.
+	assert.Equal(t, "k3", (*m2.getOrig())[0].Key)
+
+	m2.PutStr("k1", "v2")
+	assert.NotEqual(t, m1.Hash(), m2.Hash())
+	assert.NotEqual(t, NewMap().Hash(), m1.Hash())
+
+	// The type of the values is part of their identity.
+	m3 := NewMap()
+	m3.PutStr("k", "1")
+	m4 := NewMap()
+	m4.PutInt("k", 1)
+	assert.NotEqual(t, m3.Hash(), m4.Hash())
+
+	// The slices are ordered.
+	m5 := NewMap()
+	m5.PutEmptySlice("k").FromRaw([]any{1, 2})
+	m6 := NewMap()
+	m6.PutEmptySlice("k").FromRaw([]any{2, 1})
+	assert.NotEqual(t, m5.Hash(), m6.Hash())
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { m1.Hash() }))
+}
@@ -58,6 +58,22 @@ func (es Slice) At(ix int) Value {
 	return newValue(&(*es.getOrig())[ix], es.getState())
 }
 
+// All returns an iterator over index-value pairs in the slice, see iter.Seq2.
+// It avoids the overhead of calling At for each element:
+//
+//	for i, e := range es.All() {
+//	    ... // Do something with the element
+//	}
+func (es Slice) All() func(yield func(int, Value) bool) {
+	return func(yield func(int, Value) bool) {
+		for i := range *es.getOrig() {
+			if !yield(i, newValue(&(*es.getOrig())[i], es.getState())) {
+				return
+			}
+		}
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es Slice) CopyTo(dest Slice) {
 	dest.getState().AssertMutable()
@@ -31,6 +31,18 @@ func TestSlice(t *testing.T) {
 	}
 }
 
+func TestSlice_All(t *testing.T) {
+	es := NewSlice()
+	assert.NoError(t, es.FromRaw([]any{"a", int64(1), true}))
+	var got []any
+	es.All()(func(i int, v Value) bool {
+		assert.Equal(t, len(got), i)
+		got = append(got, v.AsRaw())
+		return i < 1
+	})
+	assert.Equal(t, []any{"a", int64(1)}, got)
+}
+
 func TestSliceReadOnly(t *testing.T) {
 	state := internal.StateReadOnly
 	es := newSlice(&[]otlpcommon.AnyValue{{Value: &otlpcommon.AnyValue_IntValue{IntValue: 3}}}, &state)
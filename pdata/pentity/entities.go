@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pentity holds the entity events, reporting the entities of the infrastructure, e.g. hosts, pods or
+// services, being created, updated or deleted, so the inventory of the infrastructure can be built.
+package pentity // import "go.opentelemetry.io/collector/pdata/pentity"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpentities "go.opentelemetry.io/collector/pdata/internal/data/entities/v1"
+)
+
+// Entities is the top-level struct that is propagated through the entities pipeline.
+// Use NewEntities to create new instance, zero-initialized instance is not valid for use.
+type Entities struct {
+	orig  *otlpentities.EntitiesData
+	state *internal.State
+}
+
+// NewEntities creates a new Entities struct.
+func NewEntities() Entities {
+	state := internal.StateMutable
+	return Entities{orig: &otlpentities.EntitiesData{}, state: &state}
+}
+
+// IsReadOnly returns true if this Entities instance is read-only.
+func (ms Entities) IsReadOnly() bool {
+	return *ms.state == internal.StateReadOnly
+}
+
+// CopyTo copies the Entities instance overriding the destination.
+func (ms Entities) CopyTo(dest Entities) {
+	ms.ResourceEntities().CopyTo(dest.ResourceEntities())
+}
+
+// ResourceEntities returns the ResourceEntitiesSlice associated with this Entities.
+func (ms Entities) ResourceEntities() ResourceEntitiesSlice {
+	return newResourceEntitiesSlice(&ms.orig.ResourceEntities, ms.state)
+}
+
+// EventCount calculates the total number of entity events.
+func (ms Entities) EventCount() int {
+	eventCount := 0
+	rss := ms.ResourceEntities()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeEntities()
+		for j := 0; j < ilss.Len(); j++ {
+			eventCount += ilss.At(j).EntityEvents().Len()
+		}
+	}
+	return eventCount
+}
+
+// MarkReadOnly marks the Entities as shared so that no further modifications can be done on it.
+func (ms Entities) MarkReadOnly() {
+	*ms.state = internal.StateReadOnly
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pentity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyEntitiesInvalidUsage(t *testing.T) {
+	entities := NewEntities()
+	assert.False(t, entities.IsReadOnly())
+	res := entities.ResourceEntities().AppendEmpty().Resource()
+	res.Attributes().PutStr("k1", "v1")
+	entities.MarkReadOnly()
+	assert.True(t, entities.IsReadOnly())
+	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
+}
+
+func TestEventCount(t *testing.T) {
+	entities := NewEntities()
+	assert.EqualValues(t, 0, entities.EventCount())
+
+	rs := entities.ResourceEntities().AppendEmpty()
+	assert.EqualValues(t, 0, entities.EventCount())
+
+	ils := rs.ScopeEntities().AppendEmpty()
+	assert.EqualValues(t, 0, entities.EventCount())
+
+	ils.EntityEvents().AppendEmpty()
+	assert.EqualValues(t, 1, entities.EventCount())
+
+	rms := entities.ResourceEntities()
+	rms.EnsureCapacity(3)
+	rms.AppendEmpty().ScopeEntities().AppendEmpty()
+	illl := rms.AppendEmpty().ScopeEntities().AppendEmpty().EntityEvents()
+	for i := 0; i < 5; i++ {
+		illl.AppendEmpty()
+	}
+	// 5 + 1 (from rms.At(0) initialized first)
+	assert.EqualValues(t, 6, entities.EventCount())
+}
+
+func TestEntitiesCopyTo(t *testing.T) {
+	entities := NewEntities()
+	event := entities.ResourceEntities().AppendEmpty().ScopeEntities().AppendEmpty().EntityEvents().AppendEmpty()
+	event.SetEntityType("k8s.pod")
+	event.SetType(EventTypeCreate)
+	event.ID().PutStr("k8s.pod.uid", "0a1b2c")
+	event.Attributes().PutStr("k8s.pod.name", "checkout")
+
+	dest := NewEntities()
+	entities.CopyTo(dest)
+	assert.Equal(t, entities, dest)
+	assert.Equal(t, 1, dest.EventCount())
+}
+
+func TestEventTypeString(t *testing.T) {
+	assert.Equal(t, "Unspecified", EventTypeUnspecified.String())
+	assert.Equal(t, "Create", EventTypeCreate.String())
+	assert.Equal(t, "Update", EventTypeUpdate.String())
+	assert.Equal(t, "Delete", EventTypeDelete.String())
+	assert.Equal(t, "", EventType(100).String())
+}
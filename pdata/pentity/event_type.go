@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pentity // import "go.opentelemetry.io/collector/pdata/pentity"
+
+import (
+	otlpentities "go.opentelemetry.io/collector/pdata/internal/data/entities/v1"
+)
+
+// EventType is the type of an EntityEvent.
+type EventType int32
+
+const (
+	// EventTypeUnspecified is the type of the events without a type.
+	EventTypeUnspecified = EventType(otlpentities.EntityEventType_ENTITY_EVENT_TYPE_UNSPECIFIED)
+	// EventTypeCreate is the type of the events reporting an entity being created.
+	EventTypeCreate = EventType(otlpentities.EntityEventType_ENTITY_EVENT_TYPE_CREATE)
+	// EventTypeUpdate is the type of the events reporting the attributes of an entity being updated.
+	EventTypeUpdate = EventType(otlpentities.EntityEventType_ENTITY_EVENT_TYPE_UPDATE)
+	// EventTypeDelete is the type of the events reporting an entity being deleted.
+	EventTypeDelete = EventType(otlpentities.EntityEventType_ENTITY_EVENT_TYPE_DELETE)
+)
+
+// String returns the string representation of the EventType.
+func (et EventType) String() string {
+	switch et {
+	case EventTypeUnspecified:
+		return "Unspecified"
+	case EventTypeCreate:
+		return "Create"
+	case EventTypeUpdate:
+		return "Update"
+	case EventTypeDelete:
+		return "Delete"
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+)
+
+// MarshalCanonical returns a deterministic proto encoding of the ResourceLogs, which does not depend on the order
+// the attributes were inserted in: the entries of every attributes map and of every map value are sorted by key.
+// The order of the log records and of the other slices is preserved.
+func (ms ResourceLogs) MarshalCanonical() []byte {
+	dest := NewResourceLogs()
+	ms.CopyTo(dest)
+	return internal.MarshalCanonical(dest.orig)
+}
+
+// Hash returns a 128-bit fingerprint of the canonical encoding of the ResourceLogs, see MarshalCanonical.
+// It can be used as a deduplication or routing key, but it is not a cryptographic hash.
+func (ms ResourceLogs) Hash() [16]byte {
+	return internal.CanonicalHash(ms.MarshalCanonical())
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceLogsHash(t *testing.T) {
+	newResourceLogs := func(keys ...string) ResourceLogs {
+		rl := NewResourceLogs()
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		body := lr.Body().SetEmptyMap()
+		nested := lr.Attributes().PutEmptySlice("list").AppendEmpty().SetEmptyMap()
+		for _, k := range keys {
+			rl.Resource().Attributes().PutStr(k, k)
+			nested.PutStr(k, k)
+			body.PutStr(k, k)
+		}
+		return rl
+	}
+	rl := newResourceLogs("a", "b", "c")
+	assert.Equal(t, rl.MarshalCanonical(), newResourceLogs("c", "b", "a").MarshalCanonical())
+	assert.Equal(t, rl.Hash(), newResourceLogs("c", "b", "a").Hash())
+
+	different := newResourceLogs("a", "b", "c")
+	different.ScopeLogs().At(0).LogRecords().At(0).Body().Map().PutStr("a", "other")
+	assert.NotEqual(t, rl.Hash(), different.Hash())
+}
@@ -71,6 +71,26 @@ func TestJSONMarshal(t *testing.T) {
 	assert.Equal(t, logsJSON, string(jsonBuf))
 }
 
+func TestJSONMarshalOptions(t *testing.T) {
+	encoder := &JSONMarshaler{Indent: "  ", EnumsAsNames: true}
+	jsonBuf, err := encoder.MarshalLogs(logsOTLP)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), "\n  ")
+	assert.Contains(t, string(jsonBuf), `"severityNumber": "SEVERITY_NUMBER_ERROR"`)
+	decoder := &JSONUnmarshaler{}
+	got, err := decoder.UnmarshalLogs(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, logsOTLP, got)
+
+	encoder = &JSONMarshaler{EmitDefaults: true}
+	jsonBuf, err = encoder.MarshalLogs(logsOTLP)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `"attributes":[]`)
+	got, err = decoder.UnmarshalLogs(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, logsOTLP, got)
+}
+
 func TestJSONUnmarshalInvalid(t *testing.T) {
 	jsonStr := `{"extra":"", "resourceLogs": "extra"}`
 	decoder := &JSONUnmarshaler{}
@@ -171,3 +171,42 @@ func BenchmarkLogsUsage(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkLogRecordSliceAppend(b *testing.B) {
+	const numRecords = 1000
+	fill := func(lr LogRecord) {
+		lr.SetSeverityNumber(SeverityNumberInfo)
+		lr.Body().SetStr("log record")
+	}
+
+	b.Run("AppendEmpty", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			lrs := NewLogRecordSlice()
+			for i := 0; i < numRecords; i++ {
+				fill(lrs.AppendEmpty())
+			}
+		}
+	})
+
+	b.Run("AppendEmptyN", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			lrs := NewLogRecordSlice()
+			lrs.AppendEmptyN(numRecords)
+			for i := 0; i < lrs.Len(); i++ {
+				fill(lrs.At(i))
+			}
+		}
+	})
+
+	b.Run("Builder", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			builder := NewLogRecordSliceBuilder(NewLogRecordSlice(), 256)
+			for i := 0; i < numRecords; i++ {
+				fill(builder.AppendEmpty())
+			}
+		}
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceMetricsHash(t *testing.T) {
+	newResourceMetrics := func(keys ...string) ResourceMetrics {
+		rm := NewResourceMetrics()
+		dps := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(1)
+		ex := dp.Exemplars().AppendEmpty()
+		for _, k := range keys {
+			rm.Resource().Attributes().PutStr(k, k)
+			dp.Attributes().PutStr(k, k)
+			ex.FilteredAttributes().PutInt(k, 1)
+		}
+		return rm
+	}
+	rm := newResourceMetrics("a", "b", "c")
+	assert.Equal(t, rm.MarshalCanonical(), newResourceMetrics("b", "c", "a").MarshalCanonical())
+	assert.Equal(t, rm.Hash(), newResourceMetrics("b", "c", "a").Hash())
+
+	different := newResourceMetrics("a", "b", "c")
+	different.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetIntValue(2)
+	assert.NotEqual(t, rm.Hash(), different.Hash())
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+// ConvertGaugeToSum converts a gauge metric to a sum with the given aggregation temporality and monotonicity.
+// The data points are moved as is: their values are exact, but the points of a gauge usually have no start time,
+// which is expected to be set on the points of a cumulative sum.
+// Returns an error, and leaves the metric unchanged, if the metric is not a gauge.
+func (ms Metric) ConvertGaugeToSum(temporality AggregationTemporality, isMonotonic bool) error {
+	ms.state.AssertMutable()
+	gauge := ms.orig.GetGauge()
+	if gauge == nil {
+		return fmt.Errorf("cannot convert a %s metric to a Sum, it is not a Gauge", ms.Type())
+	}
+	ms.orig.Data = &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{
+		DataPoints:             gauge.DataPoints,
+		AggregationTemporality: otlpmetrics.AggregationTemporality(temporality),
+		IsMonotonic:            isMonotonic,
+	}}
+	return nil
+}
+
+// ConvertSumToGauge converts a sum metric to a gauge. The data points are moved as is, their aggregation
+// temporality and monotonicity are dropped: the points of a cumulative sum become gauges of the running totals,
+// and the points of a delta sum become gauges of the increments.
+// Returns an error, and leaves the metric unchanged, if the metric is not a sum.
+func (ms Metric) ConvertSumToGauge() error {
+	ms.state.AssertMutable()
+	sum := ms.orig.GetSum()
+	if sum == nil {
+		return fmt.Errorf("cannot convert a %s metric to a Gauge, it is not a Sum", ms.Type())
+	}
+	ms.orig.Data = &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{DataPoints: sum.DataPoints}}
+	return nil
+}
+
+// Rebucket changes the explicit bounds of the buckets of the point to the given bounds, which must be sorted in
+// increasing order. The count of each bucket is added to the new bucket containing its upper bound:
+//   - The new bucket counts are exact when every new bound is one of the current bounds, e.g. when merging
+//     adjacent buckets.
+//   - Otherwise, the count of a current bucket spanning a new bound is entirely attributed to the new bucket
+//     above the bound, so the number of values lower than or equal to each new bound is underestimated by at
+//     most the count of the current bucket spanning it.
+//
+// The count, sum, min and max of the point are not changed. The points without bucket counts are left unchanged.
+func (ms HistogramDataPoint) Rebucket(bounds []float64) {
+	ms.state.AssertMutable()
+	from := ms.orig.ExplicitBounds
+	if len(ms.orig.BucketCounts) == 0 || slices.Equal(from, bounds) {
+		return
+	}
+	counts := make([]uint64, len(bounds)+1)
+	for i, count := range ms.orig.BucketCounts {
+		upper := math.Inf(1)
+		if i < len(from) {
+			upper = from[i]
+		}
+		counts[sort.SearchFloat64s(bounds, upper)] += count
+	}
+	ms.orig.ExplicitBounds = slices.Clone(bounds)
+	ms.orig.BucketCounts = counts
+}
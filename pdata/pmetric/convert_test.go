@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestConvertGaugeToSum(t *testing.T) {
+	m := NewMetric()
+	m.SetName("queue_size")
+	dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(5)
+	dp.SetTimestamp(pcommon.Timestamp(100))
+	dp.Attributes().PutStr("queue", "a")
+	expected := NewNumberDataPoint()
+	dp.CopyTo(expected)
+
+	require.NoError(t, m.ConvertGaugeToSum(AggregationTemporalityCumulative, true))
+	assert.Equal(t, MetricTypeSum, m.Type())
+	assert.Equal(t, "queue_size", m.Name())
+	assert.Equal(t, AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	assert.True(t, m.Sum().IsMonotonic())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	assert.Equal(t, expected, m.Sum().DataPoints().At(0))
+
+	require.NoError(t, m.ConvertSumToGauge())
+	assert.Equal(t, MetricTypeGauge, m.Type())
+	require.Equal(t, 1, m.Gauge().DataPoints().Len())
+	assert.Equal(t, expected, m.Gauge().DataPoints().At(0))
+}
+
+func TestConvertWrongType(t *testing.T) {
+	m := NewMetric()
+	m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	assert.EqualError(t, m.ConvertGaugeToSum(AggregationTemporalityDelta, false), "cannot convert a Histogram metric to a Sum, it is not a Gauge")
+	assert.EqualError(t, m.ConvertSumToGauge(), "cannot convert a Histogram metric to a Gauge, it is not a Sum")
+	assert.Equal(t, MetricTypeHistogram, m.Type())
+	assert.Equal(t, 1, m.Histogram().DataPoints().Len())
+
+	assert.Error(t, NewMetric().ConvertSumToGauge())
+}
+
+func TestHistogramDataPointRebucket(t *testing.T) {
+	tests := []struct {
+		name           string
+		bounds         []float64
+		counts         []uint64
+		newBounds      []float64
+		expectedCounts []uint64
+	}{
+		{
+			name:           "merge buckets",
+			bounds:         []float64{1, 2, 5, 10},
+			counts:         []uint64{1, 2, 3, 4, 5},
+			newBounds:      []float64{2, 10},
+			expectedCounts: []uint64{3, 7, 5},
+		},
+		{
+			name:           "spanned bounds",
+			bounds:         []float64{1, 5, 10},
+			counts:         []uint64{1, 2, 3, 4},
+			newBounds:      []float64{2, 7},
+			expectedCounts: []uint64{1, 2, 7},
+		},
+		{
+			name:           "no bounds",
+			bounds:         []float64{1, 5},
+			counts:         []uint64{1, 2, 3},
+			newBounds:      nil,
+			expectedCounts: []uint64{6},
+		},
+		{
+			name:           "split bucket",
+			bounds:         []float64{},
+			counts:         []uint64{6},
+			newBounds:      []float64{1, 5},
+			expectedCounts: []uint64{0, 0, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := NewHistogramDataPoint()
+			dp.SetCount(15)
+			dp.SetSum(42)
+			dp.ExplicitBounds().FromRaw(tt.bounds)
+			dp.BucketCounts().FromRaw(tt.counts)
+
+			dp.Rebucket(tt.newBounds)
+			assert.Equal(t, tt.newBounds, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, tt.expectedCounts, dp.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(15), dp.Count())
+			assert.InDelta(t, 42, dp.Sum(), 0)
+		})
+	}
+}
+
+func TestHistogramDataPointRebucketNoBuckets(t *testing.T) {
+	dp := NewHistogramDataPoint()
+	dp.SetCount(3)
+	dp.Rebucket([]float64{1, 2})
+	assert.Equal(t, 0, dp.ExplicitBounds().Len())
+	assert.Equal(t, 0, dp.BucketCounts().Len())
+}
+
+func TestHistogramDataPointRebucketCopiesBounds(t *testing.T) {
+	dp := NewHistogramDataPoint()
+	dp.ExplicitBounds().FromRaw([]float64{1})
+	dp.BucketCounts().FromRaw([]uint64{1, 1})
+	bounds := []float64{2}
+	dp.Rebucket(bounds)
+	bounds[0] = 3
+	assert.Equal(t, []float64{2}, dp.ExplicitBounds().AsRaw())
+}
@@ -18,13 +18,26 @@ import (
 var _ Marshaler = (*JSONMarshaler)(nil)
 
 // JSONMarshaler marshals pdata.Metrics to JSON bytes using the OTLP/JSON format.
-type JSONMarshaler struct{}
+// The zero value marshals to the compact OTLP/JSON format, the options change it to match other tools.
+type JSONMarshaler struct {
+	// Indent, if not empty, pretty prints the output, indenting the nested values with it.
+	Indent string
+	// EnumsAsNames encodes the enums with their names, e.g. "AGGREGATION_TEMPORALITY_DELTA", instead of their integer values
+	// required by OTLP/JSON.
+	EnumsAsNames bool
+	// EmitDefaults encodes the fields with zero values, which are omitted by default.
+	EmitDefaults bool
+}
 
 // MarshalMetrics to the OTLP/JSON format.
-func (*JSONMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+func (m *JSONMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
 	buf := bytes.Buffer{}
 	pb := internal.MetricsToProto(internal.Metrics(md))
-	err := json.Marshal(&buf, &pb)
+	err := json.MarshalWithOptions(&buf, &pb, json.MarshalOptions{
+		Indent:       m.Indent,
+		EnumsAsNames: m.EnumsAsNames,
+		EmitDefaults: m.EmitDefaults,
+	})
 	return buf.Bytes(), err
 }
 
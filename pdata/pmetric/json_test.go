@@ -9,6 +9,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -41,6 +42,27 @@ func TestMetricsJSON(t *testing.T) {
 	assert.EqualValues(t, metricsOTLP, got)
 }
 
+func TestJSONMarshalOptions(t *testing.T) {
+	md := metricsSumOTLPFull()
+	encoder := &JSONMarshaler{Indent: "  ", EnumsAsNames: true}
+	jsonBuf, err := encoder.MarshalMetrics(md)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), "\n  ")
+	assert.Contains(t, string(jsonBuf), `"aggregationTemporality": "AGGREGATION_TEMPORALITY_CUMULATIVE"`)
+	decoder := &JSONUnmarshaler{}
+	got, err := decoder.UnmarshalMetrics(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, md, got)
+
+	encoder = &JSONMarshaler{EmitDefaults: true}
+	jsonBuf, err = encoder.MarshalMetrics(md)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `"flags":0`)
+	got, err = decoder.UnmarshalMetrics(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, md, got)
+}
+
 func TestMetricsJSON_Marshal(t *testing.T) {
 	encoder := &JSONMarshaler{}
 	jsonBuf, err := encoder.MarshalMetrics(metricsOTLP)
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestResourceSpansHash(t *testing.T) {
+	newResourceSpans := func(keys ...string) ResourceSpans {
+		rs := NewResourceSpans()
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("span")
+		nested := span.Attributes().PutEmptyMap("nested")
+		event := span.Events().AppendEmpty()
+		for _, k := range keys {
+			rs.Resource().Attributes().PutStr(k, k)
+			nested.PutInt(k, 1)
+			event.Attributes().PutBool(k, true)
+		}
+		return rs
+	}
+	rs := newResourceSpans("a", "b", "c")
+	reordered := newResourceSpans("c", "a", "b")
+	assert.Equal(t, rs.MarshalCanonical(), reordered.MarshalCanonical())
+	assert.Equal(t, rs.Hash(), reordered.Hash())
+	// The ResourceSpans are not modified.
+	var keys []string
+	reordered.Resource().Attributes().Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []string{"c", "a", "b"}, keys)
+
+	different := newResourceSpans("a", "b", "c")
+	different.ScopeSpans().At(0).Spans().At(0).SetName("other")
+	assert.NotEqual(t, rs.Hash(), different.Hash())
+	assert.NotEqual(t, rs.Hash(), newResourceSpans("a", "b").Hash())
+}
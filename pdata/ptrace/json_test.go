@@ -8,6 +8,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
@@ -104,6 +105,26 @@ func TestJSONMarshal(t *testing.T) {
 	assert.Equal(t, tracesJSON, string(jsonBuf))
 }
 
+func TestJSONMarshalOptions(t *testing.T) {
+	encoder := &JSONMarshaler{Indent: "  ", EnumsAsNames: true}
+	jsonBuf, err := encoder.MarshalTraces(tracesOTLP)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), "\n  ")
+	assert.Contains(t, string(jsonBuf), `"kind": "SPAN_KIND_CLIENT"`)
+	decoder := &JSONUnmarshaler{}
+	got, err := decoder.UnmarshalTraces(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, tracesOTLP, got)
+
+	encoder = &JSONMarshaler{EmitDefaults: true}
+	jsonBuf, err = encoder.MarshalTraces(tracesOTLP)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `"droppedEventsCount":0`)
+	got, err = decoder.UnmarshalTraces(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, tracesOTLP, got)
+}
+
 func TestJSONUnmarshalInvalid(t *testing.T) {
 	jsonStr := `{"extra":"", "resourceSpans": "extra"}`
 	decoder := &JSONUnmarshaler{}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package plugin runs receivers, processors and exporters out of the collector process, so they can be added
+// to a collector without rebuilding it. A plugin is an executable serving the component created by a factory
+// with Serve. The collector starts the plugin with the plugin receiver, processor or exporter, and exchanges
+// the data of the pipelines with it over OTLP gRPC, authenticated by secrets generated for the session.
+package plugin // import "go.opentelemetry.io/collector/plugin"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/plugin/protocol"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// orphanShutdownTimeout is the maximum time to shut the component down when the collector exits without
+// shutting the plugin down.
+const orphanShutdownTimeout = 5 * time.Second
+
+// Serve serves the component created by the factory, which must be a receiver.Factory, a processor.Factory
+// or an exporter.Factory, until the collector shuts the plugin down or exits. It is meant to be called by the
+// main function of the plugin executables, which must not write to their standard output.
+func Serve(factory component.Factory) error {
+	return serve(factory, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// serve serves the component, reading the end of the collector from stdin, writing the handshake to stdout
+// and the logs to stderr. The handshake holds the secret of the server, only read by the collector.
+func serve(factory component.Factory, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	kind, err := kindOf(factory)
+	if err != nil {
+		return err
+	}
+	secret, err := protocol.NewSecret()
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen for the collector: %w", err)
+	}
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(stderr), zap.InfoLevel)
+	s := &server{
+		factory: factory,
+		kind:    kind,
+		telemetry: component.TelemetrySettings{
+			Logger:         zap.New(core),
+			TracerProvider: nooptrace.NewTracerProvider(),
+			MeterProvider:  noopmetric.NewMeterProvider(),
+			MetricsLevel:   configtelemetry.LevelNone,
+			Resource:       pcommon.NewResource(),
+		},
+		done: make(chan struct{}),
+	}
+	srv := grpc.NewServer(protocol.ServerOptions(secret)...)
+	protocol.RegisterComponentServer(srv, s)
+	protocol.RegisterConsumer(srv, s.getConsumer)
+
+	go func() {
+		// The collector closes the standard input of the plugin when it exits.
+		_, _ = io.Copy(io.Discard, stdin)
+		ctx, cancel := context.WithTimeout(context.Background(), orphanShutdownTimeout)
+		defer cancel()
+		_ = s.Shutdown(ctx)
+	}()
+	go func() {
+		<-s.done
+		srv.GracefulStop()
+	}()
+
+	if _, err = fmt.Fprintln(stdout, protocol.Handshake(lis.Addr().String(), secret)); err != nil {
+		srv.Stop()
+		return fmt.Errorf("failed to write the handshake: %w", err)
+	}
+	if err = srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+func kindOf(factory component.Factory) (component.Kind, error) {
+	switch factory.(type) {
+	case receiver.Factory:
+		return component.KindReceiver, nil
+	case processor.Factory:
+		return component.KindProcessor, nil
+	case exporter.Factory:
+		return component.KindExporter, nil
+	}
+	return 0, fmt.Errorf("unsupported factory %T, the plugins serve receivers, processors and exporters", factory)
+}
+
+// server runs the component of the plugin.
+type server struct {
+	factory   component.Factory
+	kind      component.Kind
+	telemetry component.TelemetrySettings
+
+	mu        sync.Mutex
+	started   bool
+	component component.Component
+	// consumer is the component receiving the data of the collector, for processors and exporters.
+	consumer any
+	// nextConn is the connection to the collector, receiving the data emitted by receivers and processors.
+	nextConn *grpc.ClientConn
+
+	shutdownOnce sync.Once
+	shutdownErr  error
+	done         chan struct{}
+}
+
+func (s *server) getConsumer() any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.consumer
+}
+
+func (s *server) Start(ctx context.Context, req protocol.StartRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return status.Error(codes.FailedPrecondition, "the plugin component is already started")
+	}
+	s.started = true
+	if req.Kind != s.kind {
+		return status.Errorf(codes.InvalidArgument, "the plugin component kind is %q, not %q",
+			protocol.KindName(s.kind), protocol.KindName(req.Kind))
+	}
+
+	cfg := s.factory.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(req.Config).Unmarshal(cfg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unmarshal the configuration: %v", err)
+	}
+	if err := component.ValidateConfig(cfg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid configuration: %v", err)
+	}
+
+	var err error
+	if req.Kind != component.KindExporter {
+		if s.nextConn, err = grpc.NewClient(req.NextEndpoint, protocol.DialOptions(req.NextSecret)...); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid next endpoint: %v", err)
+		}
+	}
+	comp, err := s.create(ctx, req, cfg)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create the component: %v", err)
+	}
+	if err = comp.Start(ctx, host{}); err != nil {
+		return status.Errorf(codes.Internal, "failed to start the component: %v", err)
+	}
+	s.component = comp
+	if req.Kind != component.KindReceiver {
+		s.consumer = comp
+	}
+	return nil
+}
+
+// create creates the component of the plugin, for the data type of the pipeline.
+func (s *server) create(ctx context.Context, req protocol.StartRequest, cfg component.Config) (component.Component, error) {
+	switch f := s.factory.(type) {
+	case receiver.Factory:
+		set := receiver.Settings{ID: req.ID, TelemetrySettings: s.telemetry, BuildInfo: component.NewDefaultBuildInfo()}
+		switch req.DataType {
+		case component.DataTypeTraces:
+			return f.CreateTracesReceiver(ctx, set, cfg, protocol.NewTraces(s.nextConn))
+		case component.DataTypeMetrics:
+			return f.CreateMetricsReceiver(ctx, set, cfg, protocol.NewMetrics(s.nextConn))
+		case component.DataTypeLogs:
+			return f.CreateLogsReceiver(ctx, set, cfg, protocol.NewLogs(s.nextConn))
+		}
+	case processor.Factory:
+		set := processor.Settings{ID: req.ID, TelemetrySettings: s.telemetry, BuildInfo: component.NewDefaultBuildInfo()}
+		switch req.DataType {
+		case component.DataTypeTraces:
+			return f.CreateTracesProcessor(ctx, set, cfg, protocol.NewTraces(s.nextConn))
+		case component.DataTypeMetrics:
+			return f.CreateMetricsProcessor(ctx, set, cfg, protocol.NewMetrics(s.nextConn))
+		case component.DataTypeLogs:
+			return f.CreateLogsProcessor(ctx, set, cfg, protocol.NewLogs(s.nextConn))
+		}
+	case exporter.Factory:
+		set := exporter.Settings{ID: req.ID, TelemetrySettings: s.telemetry, BuildInfo: component.NewDefaultBuildInfo()}
+		switch req.DataType {
+		case component.DataTypeTraces:
+			return f.CreateTracesExporter(ctx, set, cfg)
+		case component.DataTypeMetrics:
+			return f.CreateMetricsExporter(ctx, set, cfg)
+		case component.DataTypeLogs:
+			return f.CreateLogsExporter(ctx, set, cfg)
+		}
+	}
+	return nil, fmt.Errorf("data type %q is not supported", req.DataType)
+}
+
+// Shutdown shuts the component down and stops the plugin once the call returns.
+func (s *server) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.consumer = nil
+		if s.component != nil {
+			s.shutdownErr = s.component.Shutdown(ctx)
+		}
+		if s.nextConn != nil {
+			_ = s.nextConn.Close()
+		}
+		close(s.done)
+	})
+	return s.shutdownErr
+}
+
+// host is the component.Host of the component of the plugin, which has no access to the extensions of the collector.
+type host struct{}
+
+func (host) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	pluginhost "go.opentelemetry.io/collector/internal/plugin/host"
+	"go.opentelemetry.io/collector/internal/plugin/protocol"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// factoryEnv selects the factory served by the test binary when it runs as a plugin.
+const factoryEnv = "OTELCOL_PLUGIN_TEST_FACTORY"
+
+var testType = component.MustNewType("test")
+
+type testConfig struct {
+	Value string `mapstructure:"value"`
+}
+
+func (cfg *testConfig) Validate() error {
+	if cfg.Value == "" {
+		return errors.New("'value' must be specified")
+	}
+	return nil
+}
+
+func createTestConfig() component.Config {
+	return &testConfig{}
+}
+
+var testFactories = map[string]component.Factory{
+	// The receiver emits a span named after its value when it starts.
+	"receiver": receiver.NewFactory(testType, createTestConfig,
+		receiver.WithTraces(func(_ context.Context, _ receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+			return &testReceiver{value: cfg.(*testConfig).Value, next: next}, nil
+		}, component.StabilityLevelDevelopment)),
+	// The processor sets the value as an attribute of the resources.
+	"processor": processor.NewFactory(testType, createTestConfig,
+		processor.WithTraces(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			return processorhelper.NewTracesProcessor(ctx, set, cfg, next, func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+				for i := 0; i < td.ResourceSpans().Len(); i++ {
+					td.ResourceSpans().At(i).Resource().Attributes().PutStr("value", cfg.(*testConfig).Value)
+				}
+				return td, nil
+			})
+		}, component.StabilityLevelDevelopment)),
+	// The exporter rejects the spans named "reject" and exits on the spans named "exit".
+	"exporter": exporter.NewFactory(testType, createTestConfig,
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			tc, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+				switch td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name() {
+				case "reject":
+					return consumererror.NewPermanent(errors.New("rejected"))
+				case "exit":
+					os.Exit(3)
+				}
+				return nil
+			})
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+				consumer.Traces
+			}{Traces: tc}, err
+		}, component.StabilityLevelDevelopment)),
+}
+
+type testReceiver struct {
+	component.ShutdownFunc
+	value string
+	next  consumer.Traces
+}
+
+func (r *testReceiver) Start(ctx context.Context, _ component.Host) error {
+	return r.next.ConsumeTraces(ctx, newTraces(r.value))
+}
+
+func newTraces(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+// newConfig returns the configuration running the test binary as a plugin serving the named factory.
+func newConfig(t *testing.T, factory string, cfg map[string]any) *pluginhost.Config {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	pcfg := pluginhost.NewDefaultConfig()
+	pcfg.Command = exe
+	pcfg.Env = map[string]string{factoryEnv: factory}
+	pcfg.Config = cfg
+	return &pcfg
+}
+
+// statusHost records the status reported by the components.
+type statusHost struct {
+	component.Host
+	events chan *componentstatus.Event
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.events <- ev
+}
+
+func newStatusHost() *statusHost {
+	return &statusHost{Host: componenttest.NewNopHost(), events: make(chan *componentstatus.Event, 1)}
+}
+
+func TestReceiver(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := pluginhost.New(newConfig(t, "receiver", map[string]any{"value": "hello"}), componenttest.NewNopTelemetrySettings(),
+		component.MustNewID("plugin"), component.KindReceiver, component.DataTypeTraces, sink)
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, c.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, "hello", sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestProcessor(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := pluginhost.New(newConfig(t, "processor", map[string]any{"value": "processed"}), componenttest.NewNopTelemetrySettings(),
+		component.MustNewID("plugin"), component.KindProcessor, component.DataTypeTraces, sink)
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, c.ConsumeTraces(context.Background(), newTraces("span")))
+	require.Len(t, sink.AllTraces(), 1)
+	value, ok := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("value")
+	require.True(t, ok)
+	assert.Equal(t, "processed", value.Str())
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestExporter(t *testing.T) {
+	h := newStatusHost()
+	c := pluginhost.New(newConfig(t, "exporter", map[string]any{"value": "exported"}), componenttest.NewNopTelemetrySettings(),
+		component.MustNewID("plugin"), component.KindExporter, component.DataTypeTraces, nil)
+	require.NoError(t, c.Start(context.Background(), h))
+
+	require.NoError(t, c.ConsumeTraces(context.Background(), newTraces("span")))
+	err := c.ConsumeTraces(context.Background(), newTraces("reject"))
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.EqualError(t, err, "Permanent error: rejected")
+
+	// The plugin exiting unexpectedly is a fatal error.
+	assert.Error(t, c.ConsumeTraces(context.Background(), newTraces("exit")))
+	select {
+	case ev := <-h.events:
+		assert.Equal(t, componentstatus.StatusFatalError, ev.Status())
+		assert.ErrorContains(t, ev.Err(), "plugin exited unexpectedly: exit status 3")
+	case <-time.After(10 * time.Second):
+		t.Fatal("the plugin exit was not reported")
+	}
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestStartErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *pluginhost.Config
+		kind   component.Kind
+		errMsg string
+	}{
+		{
+			name:   "invalid config",
+			cfg:    newConfig(t, "exporter", nil),
+			kind:   component.KindExporter,
+			errMsg: "failed to start the plugin component: rpc error: code = InvalidArgument desc = invalid configuration: 'value' must be specified",
+		},
+		{
+			name:   "kind mismatch",
+			cfg:    newConfig(t, "exporter", map[string]any{"value": "exported"}),
+			kind:   component.KindProcessor,
+			errMsg: "failed to start the plugin component: rpc error: code = InvalidArgument desc = the plugin component kind is \"exporter\", not \"processor\"",
+		},
+		{
+			name:   "no handshake",
+			cfg:    &pluginhost.Config{Command: "true", StartTimeout: time.Second, ShutdownTimeout: time.Second},
+			kind:   component.KindExporter,
+			errMsg: "plugin exited before the handshake",
+		},
+		{
+			name:   "unknown command",
+			cfg:    &pluginhost.Config{Command: "otelcol-unknown-plugin", StartTimeout: time.Second, ShutdownTimeout: time.Second},
+			kind:   component.KindExporter,
+			errMsg: `failed to start the plugin: exec: "otelcol-unknown-plugin": executable file not found in $PATH`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var next consumer.Traces
+			if tt.kind != component.KindExporter {
+				next = consumertest.NewNop()
+			}
+			c := pluginhost.New(tt.cfg, componenttest.NewNopTelemetrySettings(), component.MustNewID("plugin"), tt.kind, component.DataTypeTraces, next)
+			assert.EqualError(t, c.Start(context.Background(), componenttest.NewNopHost()), tt.errMsg)
+			assert.NoError(t, c.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestServeUnsupportedFactory(t *testing.T) {
+	assert.EqualError(t, Serve(nil), "unsupported factory <nil>, the plugins serve receivers, processors and exporters")
+}
+
+func TestServeSecret(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	cmd := exec.Command(exe)
+	cmd.Env = append(os.Environ(), factoryEnv+"=exporter")
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = stdin.Close()
+		_ = cmd.Wait()
+	})
+
+	buf := make([]byte, 256)
+	n, err := stdout.Read(buf)
+	require.NoError(t, err)
+	addr, secret, err := protocol.ParseHandshake(strings.TrimSpace(string(buf[:n])))
+	require.NoError(t, err)
+
+	// The calls without the secret of the plugin are refused.
+	call := func(secret string) error {
+		conn, err := grpc.NewClient(addr, protocol.DialOptions(secret)...)
+		require.NoError(t, err)
+		defer conn.Close()
+		return protocol.NewComponentClient(conn).Start(context.Background(), protocol.StartRequest{
+			ID:       component.MustNewID("plugin"),
+			Kind:     component.KindExporter,
+			DataType: component.DataTypeTraces,
+			Config:   map[string]any{"value": "exported"},
+		})
+	}
+	assert.Equal(t, codes.Unauthenticated, status.Code(call("")))
+	assert.NoError(t, call(secret))
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationprocessor // import "go.opentelemetry.io/collector/processor/aggregationprocessor"
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// rule is a compiled RuleConfig.
+type rule struct {
+	include          *regexp.Regexp
+	action           Action
+	attributes       map[string]struct{}
+	gaugeAggregation GaugeAggregation
+	bounds           []float64
+}
+
+type aggregationProcessor struct {
+	rules []rule
+}
+
+func newAggregationProcessor(cfg *Config) (*aggregationProcessor, error) {
+	ap := &aggregationProcessor{rules: make([]rule, 0, len(cfg.Rules))}
+	for _, rc := range cfg.Rules {
+		expr := rc.Include
+		if expr == "" {
+			expr = ".*"
+		}
+		include, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, err
+		}
+		r := rule{
+			include:          include,
+			action:           rc.Action,
+			attributes:       make(map[string]struct{}, len(rc.Attributes)),
+			gaugeAggregation: rc.GaugeAggregation,
+			bounds:           rc.HistogramBoundaries,
+		}
+		if r.action == "" {
+			r.action = ActionAggregate
+		}
+		if r.gaugeAggregation == "" {
+			r.gaugeAggregation = GaugeAggregationLast
+		}
+		for _, attr := range rc.Attributes {
+			r.attributes[attr] = struct{}{}
+		}
+		ap.rules = append(ap.rules, r)
+	}
+	return ap, nil
+}
+
+func (ap *aggregationProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	hasher := newPointHasher()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if rm.ScopeMetrics().Len() == 0 {
+			return false
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			if sm.Metrics().Len() == 0 {
+				return false
+			}
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				r := ap.match(m.Name())
+				if r == nil {
+					return false
+				}
+				if r.action == ActionDrop {
+					return true
+				}
+				r.aggregate(m, hasher)
+				return false
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// match returns the first rule matching the name of a metric, or nil if there is none.
+func (ap *aggregationProcessor) match(name string) *rule {
+	for i := range ap.rules {
+		if ap.rules[i].include.MatchString(name) {
+			return &ap.rules[i]
+		}
+	}
+	return nil
+}
+
+// aggregate removes the attributes of the rule from the points of the metric, and merges
+// the points left with the same attributes into the first one of them.
+// Summaries are left untouched, since their quantiles cannot be merged.
+func (r *rule) aggregate(m pmetric.Metric, hasher *pointHasher) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		r.mergeNumberDataPoints(m.Gauge().DataPoints(), r.gaugeAggregation, hasher)
+	case pmetric.MetricTypeSum:
+		r.mergeNumberDataPoints(m.Sum().DataPoints(), GaugeAggregationSum, hasher)
+	case pmetric.MetricTypeHistogram:
+		r.mergeHistogramDataPoints(m.Histogram().DataPoints(), hasher)
+	case pmetric.MetricTypeExponentialHistogram:
+		r.mergeExponentialHistogramDataPoints(m.ExponentialHistogram().DataPoints(), hasher)
+	}
+}
+
+func (r *rule) removeAttributes(attrs pcommon.Map) {
+	attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+		_, ok := r.attributes[k]
+		return ok
+	})
+}
+
+func (r *rule) mergeNumberDataPoints(dps pmetric.NumberDataPointSlice, agg GaugeAggregation, hasher *pointHasher) {
+	merged := make(map[pointKey]pmetric.NumberDataPoint, dps.Len())
+	dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+		r.removeAttributes(dp.Attributes())
+		key := hasher.key(dp.Attributes(), dp.Flags())
+		into, ok := merged[key]
+		if !ok {
+			merged[key] = dp
+			return false
+		}
+		if !dp.Flags().NoRecordedValue() {
+			mergeNumberValue(into, dp, agg)
+		}
+		mergeTimestamps(into, dp)
+		dp.Exemplars().MoveAndAppendTo(into.Exemplars())
+		return true
+	})
+}
+
+func (r *rule) mergeHistogramDataPoints(dps pmetric.HistogramDataPointSlice, hasher *pointHasher) {
+	merged := make(map[pointKey]pmetric.HistogramDataPoint, dps.Len())
+	dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+		r.removeAttributes(dp.Attributes())
+		if len(r.bounds) > 0 {
+			dp.Rebucket(r.bounds)
+		}
+		key := hasher.key(dp.Attributes(), dp.Flags())
+		into, ok := merged[key]
+		if !ok {
+			merged[key] = dp
+			return false
+		}
+		if !dp.Flags().NoRecordedValue() {
+			mergeHistogram(into, dp)
+		}
+		mergeTimestamps(into, dp)
+		dp.Exemplars().MoveAndAppendTo(into.Exemplars())
+		return true
+	})
+}
+
+func (r *rule) mergeExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, hasher *pointHasher) {
+	merged := make(map[pointKey]pmetric.ExponentialHistogramDataPoint, dps.Len())
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		r.removeAttributes(dp.Attributes())
+		key := hasher.key(dp.Attributes(), dp.Flags())
+		into, ok := merged[key]
+		if !ok {
+			merged[key] = dp
+			return false
+		}
+		if !dp.Flags().NoRecordedValue() {
+			mergeExponentialHistogram(into, dp)
+		}
+		mergeTimestamps(into, dp)
+		dp.Exemplars().MoveAndAppendTo(into.Exemplars())
+		return true
+	})
+}
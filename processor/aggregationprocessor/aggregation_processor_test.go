@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestProcessor(t *testing.T, rules ...RuleConfig) *aggregationProcessor {
+	cfg := &Config{Rules: rules}
+	require.NoError(t, cfg.Validate())
+	ap, err := newAggregationProcessor(cfg)
+	require.NoError(t, err)
+	return ap
+}
+
+func process(t *testing.T, ap *aggregationProcessor, md pmetric.Metrics) pmetric.Metrics {
+	out, err := ap.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return out
+}
+
+func newMetric(md pmetric.Metrics, name string) pmetric.Metric {
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	return m
+}
+
+type number struct {
+	pod, route string
+	start, ts  pcommon.Timestamp
+	value      any
+}
+
+func appendNumbers(dps pmetric.NumberDataPointSlice, numbers ...number) {
+	for _, n := range numbers {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("k8s.pod.name", n.pod)
+		dp.Attributes().PutStr("http.route", n.route)
+		dp.SetStartTimestamp(n.start)
+		dp.SetTimestamp(n.ts)
+		switch v := n.value.(type) {
+		case int:
+			dp.SetIntValue(int64(v))
+		case float64:
+			dp.SetDoubleValue(v)
+		}
+	}
+}
+
+func numbers(dps pmetric.NumberDataPointSlice) []number {
+	var got []number
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		n := number{start: dp.StartTimestamp(), ts: dp.Timestamp()}
+		if route, ok := dp.Attributes().Get("http.route"); ok {
+			n.route = route.Str()
+		}
+		if pod, ok := dp.Attributes().Get("k8s.pod.name"); ok {
+			n.pod = pod.Str()
+		}
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			n.value = int(dp.IntValue())
+		} else {
+			n.value = dp.DoubleValue()
+		}
+		got = append(got, n)
+	}
+	return got
+}
+
+func TestAggregateSum(t *testing.T) {
+	ap := newTestProcessor(t, RuleConfig{Include: "http\\..*", Attributes: []string{"k8s.pod.name"}})
+	md := pmetric.NewMetrics()
+	sum := newMetric(md, "http.requests").SetEmptySum()
+	appendNumbers(sum.DataPoints(),
+		number{pod: "a", route: "/x", start: 10, ts: 20, value: 1},
+		number{pod: "b", route: "/x", start: 5, ts: 15, value: 2},
+		number{pod: "c", route: "/y", start: 10, ts: 20, value: 4},
+		number{pod: "d", route: "/x", start: 10, ts: 30, value: 0.5},
+	)
+	process(t, ap, md)
+	assert.Equal(t, []number{
+		{route: "/x", start: 5, ts: 30, value: 3.5},
+		{route: "/y", start: 10, ts: 20, value: 4},
+	}, numbers(sum.DataPoints()))
+}
+
+func TestAggregateGauge(t *testing.T) {
+	tests := []struct {
+		agg      GaugeAggregation
+		expected any
+	}{
+		{agg: "", expected: 2},
+		{agg: GaugeAggregationSum, expected: 6},
+		{agg: GaugeAggregationMin, expected: 1},
+		{agg: GaugeAggregationMax, expected: 3},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.agg), func(t *testing.T) {
+			ap := newTestProcessor(t, RuleConfig{Attributes: []string{"k8s.pod.name"}, GaugeAggregation: tt.agg})
+			md := pmetric.NewMetrics()
+			gauge := newMetric(md, "memory").SetEmptyGauge()
+			appendNumbers(gauge.DataPoints(),
+				number{pod: "a", route: "/x", ts: 20, value: 1},
+				number{pod: "b", route: "/x", ts: 30, value: 2},
+				number{pod: "c", route: "/x", ts: 10, value: 3},
+			)
+			process(t, ap, md)
+			assert.Equal(t, []number{{route: "/x", ts: 30, value: tt.expected}}, numbers(gauge.DataPoints()))
+		})
+	}
+}
+
+func TestAggregateNoRecordedValue(t *testing.T) {
+	ap := newTestProcessor(t, RuleConfig{Attributes: []string{"k8s.pod.name"}})
+	md := pmetric.NewMetrics()
+	sum := newMetric(md, "requests").SetEmptySum()
+	appendNumbers(sum.DataPoints(),
+		number{pod: "a", route: "/x", ts: 10, value: 1},
+		number{pod: "b", route: "/x", ts: 20},
+		number{pod: "c", route: "/x", ts: 30, value: 2},
+	)
+	sum.DataPoints().At(1).SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	process(t, ap, md)
+	require.Equal(t, 2, sum.DataPoints().Len())
+	assert.EqualValues(t, 3, sum.DataPoints().At(0).IntValue())
+	assert.True(t, sum.DataPoints().At(1).Flags().NoRecordedValue())
+}
+
+func appendHistogram(dps pmetric.HistogramDataPointSlice, pod string, bounds []float64, counts []uint64, sum, minimum, maximum float64) pmetric.HistogramDataPoint {
+	dp := dps.AppendEmpty()
+	dp.Attributes().PutStr("k8s.pod.name", pod)
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	var count uint64
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(minimum)
+	dp.SetMax(maximum)
+	return dp
+}
+
+func TestAggregateHistogram(t *testing.T) {
+	ap := newTestProcessor(t, RuleConfig{Attributes: []string{"k8s.pod.name"}})
+	md := pmetric.NewMetrics()
+	histogram := newMetric(md, "latency").SetEmptyHistogram()
+	appendHistogram(histogram.DataPoints(), "a", []float64{10, 100}, []uint64{1, 2, 3}, 500, 1, 300)
+	appendHistogram(histogram.DataPoints(), "b", []float64{10, 100}, []uint64{1, 0, 1}, 200, 5, 150)
+	// The buckets are re-bucketed to the bounds of the first point.
+	appendHistogram(histogram.DataPoints(), "c", []float64{5, 10, 50, 100, 500}, []uint64{1, 1, 1, 1, 1, 1}, 1000, 0.5, 600).RemoveMax()
+	process(t, ap, md)
+
+	require.Equal(t, 1, histogram.DataPoints().Len())
+	dp := histogram.DataPoints().At(0)
+	assert.Equal(t, 0, dp.Attributes().Len())
+	assert.Equal(t, []float64{10, 100}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{4, 4, 6}, dp.BucketCounts().AsRaw())
+	assert.EqualValues(t, 14, dp.Count())
+	assert.InDelta(t, 1700, dp.Sum(), 0)
+	assert.InDelta(t, 0.5, dp.Min(), 0)
+	assert.False(t, dp.HasMax())
+}
+
+func TestAggregateHistogramBoundaries(t *testing.T) {
+	ap := newTestProcessor(t, RuleConfig{Attributes: []string{"k8s.pod.name"}, HistogramBoundaries: []float64{100}})
+	md := pmetric.NewMetrics()
+	histogram := newMetric(md, "latency").SetEmptyHistogram()
+	appendHistogram(histogram.DataPoints(), "a", []float64{10, 100}, []uint64{1, 2, 3}, 500, 1, 300)
+	appendHistogram(histogram.DataPoints(), "b", []float64{50, 100, 200}, []uint64{1, 1, 1, 1}, 500, 1, 300)
+	process(t, ap, md)
+
+	require.Equal(t, 1, histogram.DataPoints().Len())
+	dp := histogram.DataPoints().At(0)
+	assert.Equal(t, []float64{100}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{5, 5}, dp.BucketCounts().AsRaw())
+}
+
+func TestAggregateExponentialHistogram(t *testing.T) {
+	ap := newTestProcessor(t, RuleConfig{Attributes: []string{"k8s.pod.name"}})
+	md := pmetric.NewMetrics()
+	histogram := newMetric(md, "latency").SetEmptyExponentialHistogram()
+	a := histogram.DataPoints().AppendEmpty()
+	a.Attributes().PutStr("k8s.pod.name", "a")
+	a.SetScale(2)
+	a.SetCount(10)
+	a.SetZeroCount(1)
+	a.Positive().SetOffset(-1)
+	a.Positive().BucketCounts().FromRaw([]uint64{1, 2, 3, 3})
+	b := histogram.DataPoints().AppendEmpty()
+	b.Attributes().PutStr("k8s.pod.name", "b")
+	b.SetScale(1)
+	b.SetCount(4)
+	b.SetZeroCount(2)
+	b.SetZeroThreshold(0.5)
+	b.Positive().SetOffset(3)
+	b.Positive().BucketCounts().FromRaw([]uint64{1})
+	b.Negative().BucketCounts().FromRaw([]uint64{1})
+	process(t, ap, md)
+
+	require.Equal(t, 1, histogram.DataPoints().Len())
+	dp := histogram.DataPoints().At(0)
+	assert.EqualValues(t, 1, dp.Scale())
+	assert.EqualValues(t, 14, dp.Count())
+	assert.EqualValues(t, 3, dp.ZeroCount())
+	assert.InDelta(t, 0.5, dp.ZeroThreshold(), 0)
+	// The buckets -1 to 2 at scale 2 are the buckets -1 to 1 at scale 1.
+	assert.EqualValues(t, -1, dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 5, 3, 0, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.EqualValues(t, 0, dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestProcessRules(t *testing.T) {
+	ap := newTestProcessor(t,
+		RuleConfig{Include: "debug\\..*", Action: ActionDrop},
+		RuleConfig{Include: "http\\..*", Attributes: []string{"k8s.pod.name"}},
+	)
+	md := pmetric.NewMetrics()
+	appendNumbers(newMetric(md, "debug.queue").SetEmptyGauge().DataPoints(), number{pod: "a", value: 1})
+	untouched := newMetric(md, "queue").SetEmptyGauge()
+	appendNumbers(untouched.DataPoints(), number{pod: "a", value: 1}, number{pod: "b", value: 2})
+	summary := newMetric(md, "http.duration").SetEmptySummary()
+	summary.DataPoints().AppendEmpty().Attributes().PutStr("k8s.pod.name", "a")
+	summary.DataPoints().AppendEmpty().Attributes().PutStr("k8s.pod.name", "b")
+	md.ResourceMetrics().AppendEmpty()
+
+	out := process(t, ap, md)
+	// The resource left without metrics is dropped, unlike the one received empty.
+	require.Equal(t, 3, out.ResourceMetrics().Len())
+	assert.Equal(t, "queue", out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	assert.Equal(t, []number{{pod: "a", value: 1}, {pod: "b", value: 2}}, numbers(untouched.DataPoints()))
+	assert.Equal(t, 2, summary.DataPoints().Len())
+	assert.Equal(t, 0, out.ResourceMetrics().At(2).ScopeMetrics().Len())
+}
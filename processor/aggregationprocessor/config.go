@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationprocessor // import "go.opentelemetry.io/collector/processor/aggregationprocessor"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoRules            = errors.New("at least one rule must be specified in 'metrics'")
+	errNoAttributes       = errors.New("'attributes' must be specified with the aggregate action")
+	errDropWithAttributes = errors.New("'attributes' cannot be specified with the drop action")
+	errUnsortedBounds     = errors.New("'histogram_boundaries' must be sorted in increasing order")
+)
+
+// Action is what the processor does with the streams of the metrics matching a rule.
+type Action string
+
+const (
+	// ActionAggregate removes attributes from the points of the metrics and merges the points left with the same attributes.
+	ActionAggregate Action = "aggregate"
+	// ActionDrop drops the metrics.
+	ActionDrop Action = "drop"
+)
+
+var _ encoding.TextUnmarshaler = (*Action)(nil)
+
+// UnmarshalText unmarshalls text to an Action.
+func (a *Action) UnmarshalText(text []byte) error {
+	switch str := Action(text); str {
+	case ActionAggregate, ActionDrop:
+		*a = str
+		return nil
+	default:
+		return fmt.Errorf("invalid action: %q", str)
+	}
+}
+
+// GaugeAggregation is how the values of the merged gauge points are combined.
+type GaugeAggregation string
+
+const (
+	// GaugeAggregationLast keeps the value of the most recent point.
+	GaugeAggregationLast GaugeAggregation = "last"
+	// GaugeAggregationSum adds the values of the points.
+	GaugeAggregationSum GaugeAggregation = "sum"
+	// GaugeAggregationMin keeps the minimum value of the points.
+	GaugeAggregationMin GaugeAggregation = "min"
+	// GaugeAggregationMax keeps the maximum value of the points.
+	GaugeAggregationMax GaugeAggregation = "max"
+)
+
+var _ encoding.TextUnmarshaler = (*GaugeAggregation)(nil)
+
+// UnmarshalText unmarshalls text to a GaugeAggregation.
+func (g *GaugeAggregation) UnmarshalText(text []byte) error {
+	switch str := GaugeAggregation(text); str {
+	case GaugeAggregationLast, GaugeAggregationSum, GaugeAggregationMin, GaugeAggregationMax:
+		*g = str
+		return nil
+	default:
+		return fmt.Errorf("invalid gauge aggregation: %q", str)
+	}
+}
+
+// RuleConfig selects metrics by name and configures how their streams are reduced.
+type RuleConfig struct {
+	// Include is a regular expression matching the whole names of the metrics the rule applies to.
+	// If empty, the rule applies to every metric.
+	Include string `mapstructure:"include"`
+
+	// Action is what is done with the matching metrics, aggregate by default.
+	Action Action `mapstructure:"action"`
+
+	// Attributes are the attributes removed from the points of the matching metrics by the aggregate action.
+	Attributes []string `mapstructure:"attributes"`
+
+	// GaugeAggregation is how the values of the merged gauge points are combined, last by default.
+	GaugeAggregation GaugeAggregation `mapstructure:"gauge_aggregation"`
+
+	// HistogramBoundaries are the explicit bucket boundaries of the merged histogram points.
+	// If empty, the merged points have the boundaries of the first point merged.
+	HistogramBoundaries []float64 `mapstructure:"histogram_boundaries"`
+}
+
+// Config defines the configuration for the Aggregation processor.
+type Config struct {
+	// Rules are applied to the metrics in order, the first one matching the name of a metric is used.
+	Rules []RuleConfig `mapstructure:"metrics"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Rules) == 0 {
+		return errNoRules
+	}
+	var errs error
+	for i, rule := range cfg.Rules {
+		if err := rule.validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("metrics[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
+func (rule *RuleConfig) validate() error {
+	if _, err := regexp.Compile(rule.Include); err != nil {
+		return fmt.Errorf("invalid 'include': %w", err)
+	}
+	switch rule.Action {
+	case ActionDrop:
+		if len(rule.Attributes) > 0 {
+			return errDropWithAttributes
+		}
+	default:
+		if len(rule.Attributes) == 0 {
+			return errNoAttributes
+		}
+	}
+	for i := 1; i < len(rule.HistogramBoundaries); i++ {
+		if rule.HistogramBoundaries[i] <= rule.HistogramBoundaries[i-1] {
+			return errUnsortedBounds
+		}
+	}
+	return nil
+}
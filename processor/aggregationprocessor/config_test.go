@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.Equal(t, errNoRules, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Rules: []RuleConfig{
+				{
+					Include:             `http\..*`,
+					Attributes:          []string{"k8s.pod.name", "url.full"},
+					GaugeAggregation:    GaugeAggregationMax,
+					HistogramBoundaries: []float64{10, 100, 1000},
+				},
+				{
+					Include: `debug\..*`,
+					Action:  ActionDrop,
+				},
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalInvalidConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	err := confmap.NewFromStringMap(map[string]any{"metrics": []any{map[string]any{"action": "merge"}}}).Unmarshal(&cfg)
+	assert.ErrorContains(t, err, `invalid action: "merge"`)
+
+	cfg = NewFactory().CreateDefaultConfig()
+	err = confmap.NewFromStringMap(map[string]any{"metrics": []any{map[string]any{"gauge_aggregation": "avg"}}}).Unmarshal(&cfg)
+	assert.ErrorContains(t, err, `invalid gauge aggregation: "avg"`)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		rule RuleConfig
+		err  string
+	}{
+		{
+			name: "invalid include",
+			rule: RuleConfig{Include: "(", Attributes: []string{"a"}},
+			err:  "metrics[0]: invalid 'include': error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "no attributes",
+			rule: RuleConfig{Include: "a"},
+			err:  "metrics[0]: " + errNoAttributes.Error(),
+		},
+		{
+			name: "drop with attributes",
+			rule: RuleConfig{Action: ActionDrop, Attributes: []string{"a"}},
+			err:  "metrics[0]: " + errDropWithAttributes.Error(),
+		},
+		{
+			name: "unsorted boundaries",
+			rule: RuleConfig{Attributes: []string{"a"}, HistogramBoundaries: []float64{1, 1}},
+			err:  "metrics[0]: " + errUnsortedBounds.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Rules: []RuleConfig{tt.rule}}
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationprocessor // import "go.opentelemetry.io/collector/processor/aggregationprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/internal/streamhash"
+)
+
+// pointKey identifies the points of a metric merged together: the points
+// with the same attributes and flags.
+type pointKey = streamhash.Key
+
+// pointHasher computes the keys of the points.
+type pointHasher struct {
+	h *streamhash.Hasher
+}
+
+func newPointHasher() *pointHasher {
+	return &pointHasher{h: streamhash.New()}
+}
+
+// key returns the key of a point with the given attributes and flags.
+func (ph *pointHasher) key(attrs pcommon.Map, flags pmetric.DataPointFlags) pointKey {
+	ph.h.Reset()
+	ph.h.WriteUint64(uint64(flags))
+	ph.h.WriteMap(attrs, nil)
+	return ph.h.Key()
+}
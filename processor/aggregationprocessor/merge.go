@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationprocessor // import "go.opentelemetry.io/collector/processor/aggregationprocessor"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type timestamped interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// mergeTimestamps extends the interval of the merged point to cover the interval of dp.
+func mergeTimestamps[T timestamped](into, dp T) {
+	if start := dp.StartTimestamp(); start != 0 && (into.StartTimestamp() == 0 || start < into.StartTimestamp()) {
+		into.SetStartTimestamp(start)
+	}
+	if dp.Timestamp() > into.Timestamp() {
+		into.SetTimestamp(dp.Timestamp())
+	}
+}
+
+// mergeNumberValue combines the value of dp into the value of the merged point.
+// The values are doubles unless both are integers.
+func mergeNumberValue(into, dp pmetric.NumberDataPoint, agg GaugeAggregation) {
+	if agg == GaugeAggregationLast {
+		if dp.Timestamp() < into.Timestamp() {
+			return
+		}
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			into.SetIntValue(dp.IntValue())
+		} else {
+			into.SetDoubleValue(dp.DoubleValue())
+		}
+		return
+	}
+	if into.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		a, b := into.IntValue(), dp.IntValue()
+		switch agg {
+		case GaugeAggregationSum:
+			into.SetIntValue(a + b)
+		case GaugeAggregationMin:
+			into.SetIntValue(min(a, b))
+		case GaugeAggregationMax:
+			into.SetIntValue(max(a, b))
+		}
+		return
+	}
+	a, b := doubleValue(into), doubleValue(dp)
+	switch agg {
+	case GaugeAggregationSum:
+		into.SetDoubleValue(a + b)
+	case GaugeAggregationMin:
+		into.SetDoubleValue(math.Min(a, b))
+	case GaugeAggregationMax:
+		into.SetDoubleValue(math.Max(a, b))
+	}
+}
+
+func doubleValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+type histogramStats interface {
+	Count() uint64
+	SetCount(uint64)
+	HasSum() bool
+	Sum() float64
+	SetSum(float64)
+	RemoveSum()
+	HasMin() bool
+	Min() float64
+	SetMin(float64)
+	RemoveMin()
+	HasMax() bool
+	Max() float64
+	SetMax(float64)
+	RemoveMax()
+}
+
+// mergeStats merges the count, sum, minimum and maximum of dp into the merged point.
+// The sum, minimum and maximum are removed unless both points have them.
+func mergeStats[T histogramStats](into, dp T) {
+	into.SetCount(into.Count() + dp.Count())
+	if into.HasSum() && dp.HasSum() {
+		into.SetSum(into.Sum() + dp.Sum())
+	} else {
+		into.RemoveSum()
+	}
+	if into.HasMin() && dp.HasMin() {
+		into.SetMin(math.Min(into.Min(), dp.Min()))
+	} else {
+		into.RemoveMin()
+	}
+	if into.HasMax() && dp.HasMax() {
+		into.SetMax(math.Max(into.Max(), dp.Max()))
+	} else {
+		into.RemoveMax()
+	}
+}
+
+// mergeHistogram merges dp into the merged point, re-bucketing dp to the bounds of the merged point if needed.
+func mergeHistogram(into, dp pmetric.HistogramDataPoint) {
+	mergeStats(into, dp)
+	if dp.BucketCounts().Len() == 0 {
+		return
+	}
+	if into.BucketCounts().Len() == 0 {
+		dp.ExplicitBounds().CopyTo(into.ExplicitBounds())
+		dp.BucketCounts().CopyTo(into.BucketCounts())
+		return
+	}
+	dp.Rebucket(into.ExplicitBounds().AsRaw())
+	for i := 0; i < into.BucketCounts().Len(); i++ {
+		into.BucketCounts().SetAt(i, into.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+}
+
+// mergeExponentialHistogram merges dp into the merged point, downscaling both to the lowest of their scales.
+// The zero threshold of the merged point is the highest of their thresholds.
+func mergeExponentialHistogram(into, dp pmetric.ExponentialHistogramDataPoint) {
+	mergeStats(into, dp)
+	scale := min(into.Scale(), dp.Scale())
+	downscale(into.Positive(), into.Scale()-scale)
+	downscale(into.Negative(), into.Scale()-scale)
+	downscale(dp.Positive(), dp.Scale()-scale)
+	downscale(dp.Negative(), dp.Scale()-scale)
+	into.SetScale(scale)
+	into.SetZeroCount(into.ZeroCount() + dp.ZeroCount())
+	into.SetZeroThreshold(math.Max(into.ZeroThreshold(), dp.ZeroThreshold()))
+	addBuckets(into.Positive(), dp.Positive())
+	addBuckets(into.Negative(), dp.Negative())
+}
+
+// downscale merges the buckets to a scale lower by shift, each bucket being merged into the bucket of the lower
+// scale containing it.
+func downscale(b pmetric.ExponentialHistogramDataPointBuckets, shift int32) {
+	if shift == 0 || b.BucketCounts().Len() == 0 {
+		return
+	}
+	offset := b.Offset()
+	last := offset + int32(b.BucketCounts().Len()) - 1
+	counts := make([]uint64, (last>>shift)-(offset>>shift)+1)
+	for i, count := range b.BucketCounts().AsRaw() {
+		counts[((offset+int32(i))>>shift)-(offset>>shift)] += count
+	}
+	b.SetOffset(offset >> shift)
+	b.BucketCounts().FromRaw(counts)
+}
+
+// addBuckets adds the counts of the buckets of from, at the same scale, to the buckets of into.
+func addBuckets(into, from pmetric.ExponentialHistogramDataPointBuckets) {
+	if from.BucketCounts().Len() == 0 {
+		return
+	}
+	if into.BucketCounts().Len() == 0 {
+		from.CopyTo(into)
+		return
+	}
+	offset := min(into.Offset(), from.Offset())
+	end := max(into.Offset()+int32(into.BucketCounts().Len()), from.Offset()+int32(from.BucketCounts().Len()))
+	counts := make([]uint64, end-offset)
+	for i, count := range into.BucketCounts().AsRaw() {
+		counts[into.Offset()-offset+int32(i)] += count
+	}
+	for i, count := range from.BucketCounts().AsRaw() {
+		counts[from.Offset()-offset+int32(i)] += count
+	}
+	into.SetOffset(offset)
+	into.BucketCounts().FromRaw(counts)
+}
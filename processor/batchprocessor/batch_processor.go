@@ -14,21 +14,41 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/batchprocessor/internal/metadata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
 // errTooManyBatchers is returned when the MetadataCardinalityLimit has been reached.
+// maxBatchLinks is the maximum number of links from the span of a batch to the requests of its data.
+const maxBatchLinks = 128
+
 var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher metadata-value combinations"))
 
+var (
+	errNoMemoryLimiter         = errors.New("memory limiter extension not found")
+	errNotMemoryPressureSource = errors.New("requested extension does not signal memory pressure")
+)
+
+// pressureSource is implemented by the memory limiter extension, to signal when the memory usage goes above and
+// back within its limits.
+type pressureSource interface {
+	OnPressureChange(f func(pressure bool)) (unregister func())
+}
+
 // batch_processor is a component that accepts spans and metrics, places them
 // into batches and sends downstream.
 //
@@ -37,6 +57,7 @@ var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
+// - the memory limiter extension cfg.MemoryLimiter signals memory pressure.
 type batchProcessor struct {
 	logger           *zap.Logger
 	timeout          time.Duration
@@ -59,6 +80,21 @@ type batchProcessor struct {
 	shutdownC  chan struct{}
 	goroutines sync.WaitGroup
 
+	// shutdownTimeout bounds the time spent flushing the pending batches on shutdown.
+	shutdownTimeout time.Duration
+	// exportsCtx is the parent of the contexts of the exports, canceled when the shutdown deadline is exceeded.
+	exportsCtx    context.Context
+	cancelExports context.CancelFunc
+
+	// memoryLimiter is the ID of the extension signaling memory pressure, if any.
+	memoryLimiter *component.ID
+	// unregisterPressure stops listening to the memory pressure signals.
+	unregisterPressure func()
+
+	// tracer starts the spans of the batches sent, linked to the requests of their data.
+	tracer   trace.Tracer
+	spanName string
+
 	telemetry *batchProcessorTelemetry
 
 	//  batcher will be either *singletonBatcher or *multiBatcher
@@ -68,6 +104,8 @@ type batchProcessor struct {
 type batcher interface {
 	consume(ctx context.Context, data any) error
 	currentMetadataCardinality() int
+	// flush asks all the shards to send their pending batches.
+	flush()
 }
 
 // shard is a single instance of the batch logic.  When metadata
@@ -86,13 +124,35 @@ type shard struct {
 	timer *time.Timer
 
 	// newItem is used to receive data items from producers.
-	newItem chan any
+	newItem chan batchItem
+
+	// flushC is used to ask the shard to send its pending batch.
+	flushC chan struct{}
+
+	// links are the links to the requests of the data in the current batch.
+	links []trace.Link
+
+	// acks are the acks of the data in the current batch, held until the batch is sent.
+	acks []*ack.Ack
 
 	// batch is an in-flight data item containing one of the
 	// underlying data types.
 	batch batch
 }
 
+// batchItem is a data item received from a producer, with the span context of the request it was received in
+// and its acks.
+type batchItem struct {
+	data        any
+	spanContext trace.SpanContext
+	acks        []*ack.Ack
+}
+
+// newBatchItem returns the batchItem of the data. Its acks are held by processorhelper until it is sent.
+func newBatchItem(ctx context.Context, data any) batchItem {
+	return batchItem{data: data, spanContext: trace.SpanContextFromContext(ctx), acks: ack.FromContext(ctx)}
+}
+
 // batch is an interface generalizing the individual signal types.
 type batch interface {
 	// export the current batch
@@ -105,10 +165,6 @@ type batch interface {
 	add(item any)
 }
 
-var _ consumer.Traces = (*batchProcessor)(nil)
-var _ consumer.Metrics = (*batchProcessor)(nil)
-var _ consumer.Logs = (*batchProcessor)(nil)
-
 // newBatchProcessor returns a new batch processor component.
 func newBatchProcessor(set processor.Settings, cfg *Config, batchFunc func() batch) (*batchProcessor, error) {
 	// use lower-case, to be consistent with http/2 headers.
@@ -117,6 +173,7 @@ func newBatchProcessor(set processor.Settings, cfg *Config, batchFunc func() bat
 		mks[i] = strings.ToLower(k)
 	}
 	sort.Strings(mks)
+	exportsCtx, cancelExports := context.WithCancel(context.Background())
 	bp := &batchProcessor{
 		logger: set.Logger,
 
@@ -127,6 +184,12 @@ func newBatchProcessor(set processor.Settings, cfg *Config, batchFunc func() bat
 		shutdownC:        make(chan struct{}, 1),
 		metadataKeys:     mks,
 		metadataLimit:    int(cfg.MetadataCardinalityLimit),
+		tracer:           metadata.Tracer(set.TelemetrySettings),
+		spanName:         obsmetrics.ProcessorPrefix + set.ID.String() + obsmetrics.SpanNameSep + "batch",
+		shutdownTimeout:  cfg.ShutdownTimeout,
+		exportsCtx:       exportsCtx,
+		cancelExports:    cancelExports,
+		memoryLimiter:    cfg.MemoryLimiter,
 	}
 	if len(bp.metadataKeys) == 0 {
 		s := bp.newShard(nil)
@@ -149,33 +212,81 @@ func newBatchProcessor(set processor.Settings, cfg *Config, batchFunc func() bat
 
 // newShard gets or creates a batcher corresponding with attrs.
 func (bp *batchProcessor) newShard(md map[string][]string) *shard {
-	exportCtx := client.NewContext(context.Background(), client.Info{
+	exportCtx := client.NewContext(bp.exportsCtx, client.Info{
 		Metadata: client.NewMetadata(md),
 	})
 	b := &shard{
 		processor: bp,
-		newItem:   make(chan any, runtime.NumCPU()),
+		newItem:   make(chan batchItem, runtime.NumCPU()),
+		flushC:    make(chan struct{}, 1),
 		exportCtx: exportCtx,
 		batch:     bp.batchFunc(),
 	}
 	return b
 }
 
-func (bp *batchProcessor) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: true}
+// options returns the options of the processorhelper processor wrapping the batch processor.
+func (bp *batchProcessor) options() []processorhelper.Option {
+	return []processorhelper.Option{
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		processorhelper.WithStart(bp.Start),
+		processorhelper.WithShutdown(bp.shutdown),
+	}
 }
 
 // Start is invoked during service startup.
-func (bp *batchProcessor) Start(context.Context, component.Host) error {
+func (bp *batchProcessor) Start(_ context.Context, host component.Host) error {
+	if bp.memoryLimiter == nil {
+		return nil
+	}
+	ext, found := host.GetExtensions()[*bp.memoryLimiter]
+	if !found {
+		return fmt.Errorf("%w: %s", errNoMemoryLimiter, bp.memoryLimiter)
+	}
+	source, ok := ext.(pressureSource)
+	if !ok {
+		return fmt.Errorf("%w: %s", errNotMemoryPressureSource, bp.memoryLimiter)
+	}
+	bp.unregisterPressure = source.OnPressureChange(func(pressure bool) {
+		if pressure {
+			bp.batcher.flush()
+		}
+	})
 	return nil
 }
 
-// Shutdown is invoked during service shutdown.
-func (bp *batchProcessor) Shutdown(context.Context) error {
+// flush is invoked during service shutdown, once no data is received anymore. It flushes the pending batches until
+// the deadline of ctx or the shutdown timeout is exceeded, after which the exports in progress are canceled and it
+// returns without waiting for them: the shards drop and count the data left once their exports return.
+func (bp *batchProcessor) flush(ctx context.Context) error {
+	if bp.unregisterPressure != nil {
+		bp.unregisterPressure()
+	}
 	close(bp.shutdownC)
 
-	// Wait until all goroutines are done.
-	bp.goroutines.Wait()
+	if bp.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bp.shutdownTimeout)
+		defer cancel()
+	}
+	done := make(chan struct{})
+	go func() {
+		// Wait until all goroutines are done.
+		bp.goroutines.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		bp.cancelExports()
+		return fmt.Errorf("failed to flush the pending batches before the shutdown deadline: %w", ctx.Err())
+	}
+}
+
+// shutdown is invoked during service shutdown, once the pending batches are flushed.
+func (bp *batchProcessor) shutdown(context.Context) error {
+	bp.cancelExports()
 	return nil
 }
 
@@ -197,24 +308,22 @@ func (b *shard) startLoop() {
 	for {
 		select {
 		case <-b.processor.shutdownC:
-		DONE:
-			for {
-				select {
-				case item := <-b.newItem:
-					b.processItem(item)
-				default:
-					break DONE
+			b.drainItems()
+			// This is the close of the channel. The pending batch is sent, unless the exports
+			// have been canceled by the shutdown deadline.
+			for b.batch.itemCount() > 0 {
+				if b.processor.exportsCtx.Err() != nil {
+					b.processor.logger.Warn("Dropped the pending batch on shutdown",
+						zap.Int("items", b.batch.itemCount()))
+					b.processor.telemetry.recordDropped(int64(b.batch.itemCount()))
+					ack.Release(ack.ErrDropped, b.acks...)
+					break
 				}
-			}
-			// This is the close of the channel
-			if b.batch.itemCount() > 0 {
-				// TODO: Set a timeout on sendTraces or
-				// make it cancellable using the context that Shutdown gets as a parameter
 				b.sendItems(triggerTimeout)
 			}
 			return
 		case item := <-b.newItem:
-			if item == nil {
+			if item.data == nil {
 				continue
 			}
 			b.processItem(item)
@@ -223,12 +332,39 @@ func (b *shard) startLoop() {
 				b.sendItems(triggerTimeout)
 			}
 			b.resetTimer()
+		case <-b.flushC:
+			// The data already received is flushed too.
+			b.drainItems()
+			if b.batch.itemCount() == 0 {
+				continue
+			}
+			for b.batch.itemCount() > 0 {
+				b.sendItems(triggerMemoryPressure)
+			}
+			b.stopTimer()
+			b.resetTimer()
 		}
 	}
 }
 
-func (b *shard) processItem(item any) {
-	b.batch.add(item)
+// drainItems adds the data items waiting to be received to the batch.
+func (b *shard) drainItems() {
+	for {
+		select {
+		case item := <-b.newItem:
+			b.processItem(item)
+		default:
+			return
+		}
+	}
+}
+
+func (b *shard) processItem(item batchItem) {
+	b.batch.add(item.data)
+	if item.spanContext.IsValid() && len(b.links) < maxBatchLinks {
+		b.links = append(b.links, trace.Link{SpanContext: item.spanContext})
+	}
+	b.acks = append(b.acks, item.acks...)
 	sent := false
 	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
 		sent = true
@@ -241,6 +377,14 @@ func (b *shard) processItem(item any) {
 	}
 }
 
+// flush asks the shard to send its pending batch, unless it has already been asked to.
+func (b *shard) flush() {
+	select {
+	case b.flushC <- struct{}{}:
+	default:
+	}
+}
+
 func (b *shard) hasTimer() bool {
 	return b.timer != nil
 }
@@ -257,13 +401,30 @@ func (b *shard) resetTimer() {
 	}
 }
 
+// sendItems sends the current batch in a new trace, whose span is linked to the requests of the data of the batch.
+// The emitter logs the errors of the next consumer.
 func (b *shard) sendItems(trigger trigger) {
-	sent, bytes, err := b.batch.export(b.exportCtx, b.processor.sendBatchMaxSize, b.processor.telemetry.detailed)
+	ctx, span := b.processor.tracer.Start(b.exportCtx, b.processor.spanName,
+		trace.WithLinks(b.links...),
+		trace.WithAttributes(attribute.String("trigger", trigger.String())))
+	// The emitter releases the acks of the batch. When the batch is split, the data left in it is sent with the
+	// next batch: the acks are held until then, with the error of the sent part recorded.
+	if b.processor.sendBatchMaxSize > 0 && b.batch.itemCount() > b.processor.sendBatchMaxSize {
+		ack.Retain(b.acks...)
+	}
+	sent, bytes, err := b.batch.export(ack.NewContext(ctx, b.acks...), b.processor.sendBatchMaxSize, b.processor.telemetry.detailed)
+	span.SetAttributes(attribute.Int("batch_size", sent))
 	if err != nil {
-		b.processor.logger.Warn("Sender failed", zap.Error(err))
+		span.SetStatus(codes.Error, err.Error())
 	} else {
 		b.processor.telemetry.record(trigger, int64(sent), int64(bytes))
 	}
+	span.End()
+	if b.batch.itemCount() == 0 {
+		b.links = b.links[:0]
+		// The acks may be kept by the next consumers with the context of the batch, so they are not reused.
+		b.acks = nil
+	}
 }
 
 // singleShardBatcher is used when metadataKeys is empty, to avoid the
@@ -272,8 +433,8 @@ type singleShardBatcher struct {
 	batcher *shard
 }
 
-func (sb *singleShardBatcher) consume(_ context.Context, data any) error {
-	sb.batcher.newItem <- data
+func (sb *singleShardBatcher) consume(ctx context.Context, data any) error {
+	sb.batcher.newItem <- newBatchItem(ctx, data)
 	return nil
 }
 
@@ -281,6 +442,10 @@ func (sb *singleShardBatcher) currentMetadataCardinality() int {
 	return 1
 }
 
+func (sb *singleShardBatcher) flush() {
+	sb.batcher.flush()
+}
+
 // multiBatcher is used when metadataKeys is not empty.
 type multiShardBatcher struct {
 	*batchProcessor
@@ -331,7 +496,7 @@ func (mb *multiShardBatcher) consume(ctx context.Context, data any) error {
 		}
 		mb.lock.Unlock()
 	}
-	b.(*shard).newItem <- data
+	b.(*shard).newItem <- newBatchItem(ctx, data)
 	return nil
 }
 
@@ -341,45 +506,67 @@ func (mb *multiShardBatcher) currentMetadataCardinality() int {
 	return mb.size
 }
 
-// ConsumeTraces implements TracesProcessor
-func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	return bp.batcher.consume(ctx, td)
-}
-
-// ConsumeMetrics implements MetricsProcessor
-func (bp *batchProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	return bp.batcher.consume(ctx, md)
-}
-
-// ConsumeLogs implements LogsProcessor
-func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	return bp.batcher.consume(ctx, ld)
+func (mb *multiShardBatcher) flush() {
+	mb.batchers.Range(func(_, b any) bool {
+		b.(*shard).flush()
+		return true
+	})
 }
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
-func newBatchTracesProcessor(set processor.Settings, next consumer.Traces, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchTraces(next) })
+func newBatchTracesProcessor(set processor.Settings, next consumer.Traces, cfg *Config) (processor.Traces, error) {
+	emitter, err := processorhelper.NewTracesEmitter(set, next)
+	if err != nil {
+		return nil, err
+	}
+	bp, err := newBatchProcessor(set, cfg, func() batch { return newBatchTraces(emitter) })
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewAsyncTracesProcessor(context.Background(), set, cfg, emitter,
+		func(ctx context.Context, td ptrace.Traces) error { return bp.batcher.consume(ctx, td) },
+		bp.flush, bp.options()...)
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
-func newBatchMetricsProcessor(set processor.Settings, next consumer.Metrics, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchMetrics(next) })
+func newBatchMetricsProcessor(set processor.Settings, next consumer.Metrics, cfg *Config) (processor.Metrics, error) {
+	emitter, err := processorhelper.NewMetricsEmitter(set, next)
+	if err != nil {
+		return nil, err
+	}
+	bp, err := newBatchProcessor(set, cfg, func() batch { return newBatchMetrics(emitter) })
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewAsyncMetricsProcessor(context.Background(), set, cfg, emitter,
+		func(ctx context.Context, md pmetric.Metrics) error { return bp.batcher.consume(ctx, md) },
+		bp.flush, bp.options()...)
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
-func newBatchLogsProcessor(set processor.Settings, next consumer.Logs, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchLogs(next) })
+func newBatchLogsProcessor(set processor.Settings, next consumer.Logs, cfg *Config) (processor.Logs, error) {
+	emitter, err := processorhelper.NewLogsEmitter(set, next)
+	if err != nil {
+		return nil, err
+	}
+	bp, err := newBatchProcessor(set, cfg, func() batch { return newBatchLogs(emitter) })
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewAsyncLogsProcessor(context.Background(), set, cfg, emitter,
+		func(ctx context.Context, ld plog.Logs) error { return bp.batcher.consume(ctx, ld) },
+		bp.flush, bp.options()...)
 }
 
 type batchTraces struct {
-	nextConsumer consumer.Traces
-	traceData    ptrace.Traces
-	spanCount    int
-	sizer        ptrace.Sizer
+	emitter   *processorhelper.TracesEmitter
+	traceData ptrace.Traces
+	spanCount int
+	sizer     ptrace.Sizer
 }
 
-func newBatchTraces(nextConsumer consumer.Traces) *batchTraces {
-	return &batchTraces{nextConsumer: nextConsumer, traceData: ptrace.NewTraces(), sizer: &ptrace.ProtoMarshaler{}}
+func newBatchTraces(emitter *processorhelper.TracesEmitter) *batchTraces {
+	return &batchTraces{emitter: emitter, traceData: ptrace.NewTraces(), sizer: &ptrace.ProtoMarshaler{}}
 }
 
 // add updates current batchTraces by adding new TraceData object
@@ -411,7 +598,7 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 	if returnBytes {
 		bytes = bt.sizer.TracesSize(req)
 	}
-	return sent, bytes, bt.nextConsumer.ConsumeTraces(ctx, req)
+	return sent, bytes, bt.emitter.EmitTraces(ctx, req)
 }
 
 func (bt *batchTraces) itemCount() int {
@@ -419,14 +606,14 @@ func (bt *batchTraces) itemCount() int {
 }
 
 type batchMetrics struct {
-	nextConsumer   consumer.Metrics
+	emitter        *processorhelper.MetricsEmitter
 	metricData     pmetric.Metrics
 	dataPointCount int
 	sizer          pmetric.Sizer
 }
 
-func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
-	return &batchMetrics{nextConsumer: nextConsumer, metricData: pmetric.NewMetrics(), sizer: &pmetric.ProtoMarshaler{}}
+func newBatchMetrics(emitter *processorhelper.MetricsEmitter) *batchMetrics {
+	return &batchMetrics{emitter: emitter, metricData: pmetric.NewMetrics(), sizer: &pmetric.ProtoMarshaler{}}
 }
 
 func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
@@ -446,7 +633,7 @@ func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, return
 	if returnBytes {
 		bytes = bm.sizer.MetricsSize(req)
 	}
-	return sent, bytes, bm.nextConsumer.ConsumeMetrics(ctx, req)
+	return sent, bytes, bm.emitter.EmitMetrics(ctx, req)
 }
 
 func (bm *batchMetrics) itemCount() int {
@@ -465,14 +652,14 @@ func (bm *batchMetrics) add(item any) {
 }
 
 type batchLogs struct {
-	nextConsumer consumer.Logs
-	logData      plog.Logs
-	logCount     int
-	sizer        plog.Sizer
+	emitter  *processorhelper.LogsEmitter
+	logData  plog.Logs
+	logCount int
+	sizer    plog.Sizer
 }
 
-func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
-	return &batchLogs{nextConsumer: nextConsumer, logData: plog.NewLogs(), sizer: &plog.ProtoMarshaler{}}
+func newBatchLogs(emitter *processorhelper.LogsEmitter) *batchLogs {
+	return &batchLogs{emitter: emitter, logData: plog.NewLogs(), sizer: &plog.ProtoMarshaler{}}
 }
 
 func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
@@ -493,7 +680,7 @@ func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnByt
 	if returnBytes {
 		bytes = bl.sizer.LogsSize(req)
 	}
-	return sent, bytes, bl.nextConsumer.ConsumeLogs(ctx, req)
+	return sent, bytes, bl.emitter.EmitLogs(ctx, req)
 }
 
 func (bl *batchLogs) itemCount() int {
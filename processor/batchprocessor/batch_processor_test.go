@@ -5,6 +5,7 @@ package batchprocessor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -15,17 +16,23 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -122,6 +129,98 @@ func TestBatchProcessorSpansDelivered(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorBatchSpanLinks(t *testing.T) {
+	tt, err := componenttest.SetupTelemetry(component.MustNewID("batch"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 2
+	creationSet := processortest.NewNopSettings()
+	creationSet.ID = component.MustNewID("batch")
+	creationSet.TelemetrySettings = tt.TelemetrySettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	tracer := tt.TelemetrySettings().TracerProvider.Tracer("test")
+	var parents []trace.SpanContext
+	for i := 0; i < 2; i++ {
+		ctx, span := tracer.Start(context.Background(), "receive")
+		parents = append(parents, span.SpanContext())
+		require.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+		span.End()
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	var batchSpans []sdktrace.ReadOnlySpan
+	processSpans := map[trace.SpanID]trace.SpanContext{}
+	for _, span := range tt.SpanRecorder.Ended() {
+		switch span.Name() {
+		case "processor/batch/batch":
+			batchSpans = append(batchSpans, span)
+		case "processor/batch/traces":
+			processSpans[span.Parent().SpanID()] = span.SpanContext()
+		}
+	}
+	require.Len(t, batchSpans, 1)
+	span := batchSpans[0]
+	assert.False(t, span.Parent().IsValid())
+	// The batch span is linked to the spans processing the requests, started by processorhelper.
+	require.Len(t, span.Links(), 2)
+	for i, link := range span.Links() {
+		assert.Equal(t, processSpans[parents[i].SpanID()], link.SpanContext)
+	}
+	assert.Contains(t, span.Attributes(), attribute.String("trigger", "batch_size"))
+	assert.Contains(t, span.Attributes(), attribute.Int("batch_size", 2))
+}
+
+func TestBatchProcessorAcks(t *testing.T) {
+	errExport := errors.New("export failed")
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 2
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), consumertest.NewErr(errExport), cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	first := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, batcher.ConsumeTraces(ack.NewContext(context.Background(), first), testdata.GenerateTraces(1)))
+	ack.Release(nil, first)
+	select {
+	case <-first.Done():
+		t.Fatal("ack completed before the batch was sent")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	second := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, batcher.ConsumeTraces(ack.NewContext(context.Background(), second), testdata.GenerateTraces(1)))
+	ack.Release(nil, second)
+	<-first.Done()
+	<-second.Done()
+	assert.Equal(t, errExport, first.Err())
+	assert.Equal(t, errExport, second.Err())
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorAcksDroppedOnShutdown(t *testing.T) {
+	cfg := Config{
+		Timeout:         time.Hour,
+		SendBatchSize:   1000,
+		ShutdownTimeout: 50 * time.Millisecond,
+	}
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), &blockingTracesSink{}, &cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	a := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, batcher.ConsumeTraces(ack.NewContext(context.Background(), a), testdata.GenerateTraces(10)))
+	ack.Release(nil, a)
+
+	require.Error(t, batcher.Shutdown(context.Background()))
+	<-a.Done()
+	assert.Error(t, a.Err())
+}
+
 func TestBatchProcessorSpansDeliveredEnforceBatchSize(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -166,6 +265,58 @@ func TestBatchProcessorSpansDeliveredEnforceBatchSize(t *testing.T) {
 	assert.Equal(t, (requestCount*spansPerRequest)%int(cfg.SendBatchMaxSize), sink.AllTraces()[len(sink.AllTraces())-1].SpanCount())
 }
 
+// emitterMetrics returns the metrics recorded by the emitter when all the items sent are accepted.
+func emitterMetrics(items string, accepted int64) []metricdata.Metrics {
+	description := map[string]string{
+		"spans":         "spans",
+		"metric_points": "metric points",
+		"log_records":   "log records",
+	}[items]
+	unit := map[string]string{
+		"spans":         "{spans}",
+		"metric_points": "{datapoints}",
+		"log_records":   "{records}",
+	}[items]
+	sum := func(value int64) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Value:      value,
+					Attributes: attribute.NewSet(attribute.String("processor", "batch")),
+				},
+			},
+		}
+	}
+	return []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_accepted_" + items,
+			Description: "Number of " + description + " successfully pushed into the next component in the pipeline.",
+			Unit:        unit,
+			Data:        sum(accepted),
+		},
+		{
+			Name:        "otelcol_processor_refused_" + items,
+			Description: "Number of " + description + " that were rejected by the next component in the pipeline.",
+			Unit:        unit,
+			Data:        sum(0),
+		},
+		{
+			Name:        "otelcol_processor_dropped_" + items,
+			Description: "Number of " + description + " that were dropped.",
+			Unit:        unit,
+			Data:        sum(0),
+		},
+		{
+			Name:        "otelcol_processor_inserted_" + items,
+			Description: "Number of " + description + " that were inserted.",
+			Unit:        unit,
+			Data:        sum(0),
+		},
+	}
+}
+
 func TestBatchProcessorSentBySize(t *testing.T) {
 	tel := setupTestTelemetry()
 	sizer := &ptrace.ProtoMarshaler{}
@@ -211,7 +362,7 @@ func TestBatchProcessorSentBySize(t *testing.T) {
 		}
 	}
 
-	tel.assertMetrics(t, []metricdata.Metrics{
+	tel.assertMetrics(t, append(emitterMetrics("spans", int64(requestCount*spansPerRequest)), []metricdata.Metrics{
 		{
 			Name:        "otelcol_processor_batch_batch_send_size_bytes",
 			Description: "Number of bytes in batch that was sent",
@@ -282,7 +433,7 @@ func TestBatchProcessorSentBySize(t *testing.T) {
 				},
 			},
 		},
-	})
+	}...))
 }
 
 func TestBatchProcessorSentBySizeWithMaxSize(t *testing.T) {
@@ -337,7 +488,7 @@ func TestBatchProcessorSentBySizeWithMaxSize(t *testing.T) {
 		sizeSum += sizer.TracesSize(td)
 	}
 
-	tel.assertMetrics(t, []metricdata.Metrics{
+	tel.assertMetrics(t, append(emitterMetrics("spans", int64(totalSpans)), []metricdata.Metrics{
 		{
 			Name:        "otelcol_processor_batch_batch_send_size_bytes",
 			Description: "Number of bytes in batch that was sent",
@@ -423,7 +574,7 @@ func TestBatchProcessorSentBySizeWithMaxSize(t *testing.T) {
 				},
 			},
 		},
-	})
+	}...))
 }
 
 func TestBatchProcessorSentByTimeout(t *testing.T) {
@@ -503,6 +654,173 @@ func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	require.Equal(t, 1, len(sink.AllTraces()))
 }
 
+// blockingTracesSink blocks the exports until their context is canceled.
+type blockingTracesSink struct {
+	consumertest.TracesSink
+}
+
+func (bts *blockingTracesSink) ConsumeTraces(ctx context.Context, _ ptrace.Traces) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// stuckTracesSink blocks the exports until it is released, ignoring the cancellation of their context.
+type stuckTracesSink struct {
+	consumertest.TracesSink
+	release chan struct{}
+}
+
+func (sts *stuckTracesSink) ConsumeTraces(context.Context, ptrace.Traces) error {
+	<-sts.release
+	return nil
+}
+
+func TestBatchProcessorShutdownTimeout(t *testing.T) {
+	cfg := Config{
+		Timeout:          time.Hour,
+		SendBatchSize:    1000,
+		SendBatchMaxSize: 5,
+		ShutdownTimeout:  50 * time.Millisecond,
+	}
+	tel := setupTestTelemetry()
+	sink := &stuckTracesSink{release: make(chan struct{})}
+	creationSet := tel.NewSettings()
+	creationSet.MetricsLevel = configtelemetry.LevelNormal
+	batcher, err := newBatchTracesProcessor(creationSet, sink, &cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+
+	// The shutdown returns at the deadline, even though the export in progress ignores the cancellation.
+	err = batcher.Shutdown(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failed to flush the pending batches before the shutdown deadline")
+
+	// The data left once the export returns is dropped.
+	close(sink.release)
+	assert.Eventually(t, func() bool {
+		var md metricdata.ResourceMetrics
+		require.NoError(t, tel.reader.Collect(context.Background(), &md))
+		sum, ok := tel.getMetric("otelcol_processor_batch_dropped_items", md).Data.(metricdata.Sum[int64])
+		return ok && len(sum.DataPoints) == 1 && sum.DataPoints[0].Value == 5
+	}, time.Second, 5*time.Millisecond)
+}
+
+// pressureExtension is a memory limiter extension signaling memory pressure on demand.
+type pressureExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	lock     sync.Mutex
+	listener func(bool)
+}
+
+func (pe *pressureExtension) OnPressureChange(f func(pressure bool)) func() {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	pe.listener = f
+	return func() {
+		pe.lock.Lock()
+		defer pe.lock.Unlock()
+		pe.listener = nil
+	}
+}
+
+func (pe *pressureExtension) signal(pressure bool) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	if pe.listener != nil {
+		pe.listener(pressure)
+	}
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestBatchProcessorFlushOnMemoryPressure(t *testing.T) {
+	for _, metadataKeys := range [][]string{nil, {"token"}} {
+		t.Run(fmt.Sprintf("metadata_keys=%v", metadataKeys), func(t *testing.T) {
+			cfg := Config{
+				Timeout:       time.Hour,
+				SendBatchSize: 1000,
+				MetadataKeys:  metadataKeys,
+				MemoryLimiter: &memoryLimiterID,
+			}
+			tel := setupTestTelemetry()
+			sink := new(consumertest.TracesSink)
+			creationSet := tel.NewSettings()
+			creationSet.MetricsLevel = configtelemetry.LevelDetailed
+			batcher, err := newBatchTracesProcessor(creationSet, sink, &cfg)
+			require.NoError(t, err)
+			ext := &pressureExtension{}
+			host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{memoryLimiterID: ext}}
+			require.NoError(t, batcher.Start(context.Background(), host))
+
+			for _, token := range []string{"a", "b"} {
+				ctx := client.NewContext(context.Background(), client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"token": {token}}),
+				})
+				assert.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(10)))
+			}
+
+			// Only the signals of memory pressure flush the pending batches.
+			ext.signal(false)
+			ext.signal(true)
+			require.Eventually(t, func() bool {
+				return sink.SpanCount() == 20
+			}, time.Second, 5*time.Millisecond)
+
+			require.NoError(t, batcher.Shutdown(context.Background()))
+			ext.lock.Lock()
+			assert.Nil(t, ext.listener)
+			ext.lock.Unlock()
+
+			var md metricdata.ResourceMetrics
+			require.NoError(t, tel.reader.Collect(context.Background(), &md))
+			metricdatatest.AssertEqual(t, metricdata.Metrics{
+				Name:        "otelcol_processor_batch_memory_pressure_trigger_send",
+				Description: "Number of times the batch was sent due to a memory pressure signal",
+				Unit:        "{times}",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{
+							Value:      int64(len(sink.AllTraces())),
+							Attributes: attribute.NewSet(attribute.String("processor", "batch")),
+						},
+					},
+				},
+			}, tel.getMetric("otelcol_processor_batch_memory_pressure_trigger_send", md), metricdatatest.IgnoreTimestamp())
+		})
+	}
+}
+
+func TestBatchProcessorMemoryLimiterErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MemoryLimiter = &memoryLimiterID
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+
+	err = batcher.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, errNoMemoryLimiter)
+	host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{
+		memoryLimiterID: struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{},
+	}}
+	err = batcher.Start(context.Background(), host)
+	assert.ErrorIs(t, err, errNotMemoryPressureSource)
+	assert.NoError(t, batcher.Shutdown(context.Background()))
+}
+
 func TestBatchMetricProcessor_ReceivingData(t *testing.T) {
 	// Instantiate the batch processor with low config values to test data
 	// gets sent through the processor.
@@ -600,7 +918,7 @@ func TestBatchMetricProcessorBatchSize(t *testing.T) {
 		}
 	}
 
-	tel.assertMetrics(t, []metricdata.Metrics{
+	tel.assertMetrics(t, append(emitterMetrics("metric_points", int64(requestCount*dataPointsPerRequest)), []metricdata.Metrics{
 		{
 			Name:        "otelcol_processor_batch_batch_send_size_bytes",
 			Description: "Number of bytes in batch that was sent",
@@ -671,7 +989,7 @@ func TestBatchMetricProcessorBatchSize(t *testing.T) {
 				},
 			},
 		},
-	})
+	}...))
 }
 
 func TestBatchMetrics_UnevenBatchMaxSize(t *testing.T) {
@@ -681,7 +999,9 @@ func TestBatchMetrics_UnevenBatchMaxSize(t *testing.T) {
 	dataPointsPerMetric := 2
 	sendBatchMaxSize := 99
 
-	batchMetrics := newBatchMetrics(sink)
+	emitter, err := processorhelper.NewMetricsEmitter(processortest.NewNopSettings(), sink)
+	require.NoError(t, err)
+	batchMetrics := newBatchMetrics(emitter)
 	md := testdata.GenerateMetrics(metricsCount)
 
 	batchMetrics.add(md)
@@ -980,7 +1300,7 @@ func TestBatchLogProcessor_BatchSize(t *testing.T) {
 		}
 	}
 
-	tel.assertMetrics(t, []metricdata.Metrics{
+	tel.assertMetrics(t, append(emitterMetrics("log_records", int64(requestCount*logsPerRequest)), []metricdata.Metrics{
 		{
 			Name:        "otelcol_processor_batch_batch_send_size_bytes",
 			Description: "Number of bytes in batch that was sent",
@@ -1051,7 +1371,7 @@ func TestBatchLogProcessor_BatchSize(t *testing.T) {
 				},
 			},
 		},
-	})
+	}...))
 }
 
 func TestBatchLogsProcessor_Timeout(t *testing.T) {
@@ -44,6 +44,15 @@ type Config struct {
 	// batcher instances that will be created through a distinct
 	// combination of MetadataKeys.
 	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+
+	// ShutdownTimeout is the maximum time spent flushing the pending batches on shutdown,
+	// after which the batches not sent yet are dropped. When this is set to zero, only the
+	// deadline of the collector shutdown applies.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	// MemoryLimiter is the ID of a memory limiter extension. When set, the pending batches
+	// are flushed as soon as the extension signals that the memory usage is above its limits.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -64,5 +73,8 @@ func (cfg *Config) Validate() error {
 	if cfg.Timeout < 0 {
 		return errors.New("timeout must be greater or equal to 0")
 	}
+	if cfg.ShutdownTimeout < 0 {
+		return errors.New("shutdown_timeout must be greater or equal to 0")
+	}
 	return nil
 }
@@ -11,10 +11,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
+var memoryLimiterID = component.MustNewID("memory_limiter")
+
 func TestUnmarshalDefaultConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -34,6 +37,8 @@ func TestUnmarshalConfig(t *testing.T) {
 			SendBatchMaxSize:         uint32(11000),
 			Timeout:                  time.Second * 10,
 			MetadataCardinalityLimit: 1000,
+			ShutdownTimeout:          time.Second * 5,
+			MemoryLimiter:            &memoryLimiterID,
 		}, cfg)
 }
 
@@ -69,6 +74,13 @@ func TestValidateConfig_InvalidTimeout(t *testing.T) {
 	assert.Error(t, cfg.Validate())
 }
 
+func TestValidateConfig_InvalidShutdownTimeout(t *testing.T) {
+	cfg := &Config{
+		ShutdownTimeout: -time.Second,
+	}
+	assert.Error(t, cfg.Validate())
+}
+
 func TestValidateConfig_ValidZero(t *testing.T) {
 	cfg := &Config{}
 	assert.NoError(t, cfg.Validate())
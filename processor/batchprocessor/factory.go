@@ -19,6 +19,8 @@ const (
 	defaultSendBatchSize = uint32(8192)
 	defaultTimeout       = 200 * time.Millisecond
 
+	defaultShutdownTimeout = 10 * time.Second
+
 	// defaultMetadataCardinalityLimit should be set to the number
 	// of metadata configurations the user expects to submit to
 	// the collector.
@@ -40,6 +42,7 @@ func createDefaultConfig() component.Config {
 		SendBatchSize:            defaultSendBatchSize,
 		Timeout:                  defaultTimeout,
 		MetadataCardinalityLimit: defaultMetadataCardinalityLimit,
+		ShutdownTimeout:          defaultShutdownTimeout,
 	}
 }
 
@@ -34,6 +34,8 @@ type TelemetryBuilder struct {
 	ProcessorBatchBatchSendSize              metric.Int64Histogram
 	ProcessorBatchBatchSendSizeBytes         metric.Int64Histogram
 	ProcessorBatchBatchSizeTriggerSend       metric.Int64Counter
+	ProcessorBatchDroppedItems               metric.Int64Counter
+	ProcessorBatchMemoryPressureTriggerSend  metric.Int64Counter
 	ProcessorBatchMetadataCardinality        metric.Int64ObservableUpDownCounter
 	observeProcessorBatchMetadataCardinality func(context.Context, metric.Observer) error
 	ProcessorBatchTimeoutTriggerSend         metric.Int64Counter
@@ -91,6 +93,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{times}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorBatchDroppedItems, err = builder.meter.Int64Counter(
+		"otelcol_processor_batch_dropped_items",
+		metric.WithDescription("Number of items dropped because they were not sent before the shutdown deadline"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorBatchMemoryPressureTriggerSend, err = builder.meter.Int64Counter(
+		"otelcol_processor_batch_memory_pressure_trigger_send",
+		metric.WithDescription("Number of times the batch was sent due to a memory pressure signal"),
+		metric.WithUnit("{times}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorBatchMetadataCardinality, err = builder.meter.Int64ObservableUpDownCounter(
 		"otelcol_processor_batch_metadata_cardinality",
 		metric.WithDescription("Number of distinct metadata value combinations being processed"),
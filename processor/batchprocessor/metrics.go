@@ -21,8 +21,19 @@ const (
 	typeStr                = "batch"
 	triggerTimeout trigger = iota
 	triggerBatchSize
+	triggerMemoryPressure
 )
 
+func (t trigger) String() string {
+	switch t {
+	case triggerBatchSize:
+		return "batch_size"
+	case triggerMemoryPressure:
+		return "memory_pressure"
+	}
+	return "timeout"
+}
+
 type batchProcessorTelemetry struct {
 	detailed bool
 
@@ -60,6 +71,8 @@ func (bpt *batchProcessorTelemetry) record(trigger trigger, sent, bytes int64) {
 		bpt.telemetryBuilder.ProcessorBatchBatchSizeTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
 	case triggerTimeout:
 		bpt.telemetryBuilder.ProcessorBatchTimeoutTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
+	case triggerMemoryPressure:
+		bpt.telemetryBuilder.ProcessorBatchMemoryPressureTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
 	}
 
 	bpt.telemetryBuilder.ProcessorBatchBatchSendSize.Record(bpt.exportCtx, sent, metric.WithAttributeSet(bpt.processorAttr))
@@ -67,3 +80,8 @@ func (bpt *batchProcessorTelemetry) record(trigger trigger, sent, bytes int64) {
 		bpt.telemetryBuilder.ProcessorBatchBatchSendSizeBytes.Record(bpt.exportCtx, bytes, metric.WithAttributeSet(bpt.processorAttr))
 	}
 }
+
+// recordDropped records the items dropped because they were not sent before the shutdown deadline.
+func (bpt *batchProcessorTelemetry) recordDropped(items int64) {
+	bpt.telemetryBuilder.ProcessorBatchDroppedItems.Add(bpt.exportCtx, items, metric.WithAttributeSet(bpt.processorAttr))
+}
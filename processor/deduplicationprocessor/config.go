@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deduplicationprocessor // import "go.opentelemetry.io/collector/processor/deduplicationprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errNonPositiveWindow = errors.New("'window' must be positive")
+
+// Config defines the configuration for the Deduplication processor.
+type Config struct {
+	// Window is how long a point is remembered: the copies of the point received within
+	// the window after it are dropped.
+	Window time.Duration `mapstructure:"window"`
+
+	// IgnoredResourceAttributes are the resource attributes left out of the identity of the
+	// streams, such as the attributes identifying the agent which sent the points.
+	IgnoredResourceAttributes []string `mapstructure:"ignored_resource_attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Window <= 0 {
+		return errNonPositiveWindow
+	}
+	return nil
+}
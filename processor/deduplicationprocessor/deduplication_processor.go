@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deduplicationprocessor // import "go.opentelemetry.io/collector/processor/deduplicationprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type deduplicationProcessor struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	hasher *streamHasher
+	// seen holds when the points received within the window were first received.
+	seen   map[pointKey]time.Time
+	nextGC time.Time
+}
+
+func newDeduplicationProcessor(cfg *Config) *deduplicationProcessor {
+	return &deduplicationProcessor{
+		window: cfg.Window,
+		now:    time.Now,
+		hasher: newStreamHasher(cfg.IgnoredResourceAttributes),
+		seen:   make(map[pointKey]time.Time),
+		nextGC: time.Now().Add(cfg.Window / 2),
+	}
+}
+
+func (dp *deduplicationProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+
+	now := dp.now()
+	dp.gc(now)
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if rm.ScopeMetrics().Len() == 0 {
+			return false
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			if sm.Metrics().Len() == 0 {
+				return false
+			}
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return dp.processMetric(rm.Resource(), sm.Scope(), m, now)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// gc forgets the points received before the window.
+// It runs at most every half of the window to keep the cost of the scans low.
+func (dp *deduplicationProcessor) gc(now time.Time) {
+	if now.Before(dp.nextGC) {
+		return
+	}
+	dp.nextGC = now.Add(dp.window / 2)
+	for key, seen := range dp.seen {
+		if now.Sub(seen) >= dp.window {
+			delete(dp.seen, key)
+		}
+	}
+}
+
+// processMetric drops the duplicate points of the metric, and returns whether the metric
+// must be removed because all its points were dropped.
+func (dp *deduplicationProcessor) processMetric(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, now time.Time) bool {
+	dp.hasher.setMetric(res, scope, m)
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return removeDuplicates(m.Gauge().DataPoints(), dp, now)
+	case pmetric.MetricTypeSum:
+		return removeDuplicates(m.Sum().DataPoints(), dp, now)
+	case pmetric.MetricTypeHistogram:
+		return removeDuplicates(m.Histogram().DataPoints(), dp, now)
+	case pmetric.MetricTypeExponentialHistogram:
+		return removeDuplicates(m.ExponentialHistogram().DataPoints(), dp, now)
+	case pmetric.MetricTypeSummary:
+		return removeDuplicates(m.Summary().DataPoints(), dp, now)
+	default:
+		return false
+	}
+}
+
+// dataPoint is the part of the points identifying them.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	Timestamp() pcommon.Timestamp
+}
+
+// dataPointSlice is the part of the slices of points used to drop the duplicates.
+type dataPointSlice[T dataPoint] interface {
+	Len() int
+	RemoveIf(func(T) bool)
+}
+
+// removeDuplicates drops the points already received within the window, and returns whether
+// the slice was emptied. Empty slices are left untouched.
+func removeDuplicates[T dataPoint](points dataPointSlice[T], dp *deduplicationProcessor, now time.Time) bool {
+	if points.Len() == 0 {
+		return false
+	}
+	points.RemoveIf(func(p T) bool {
+		return dp.isDuplicate(p, now)
+	})
+	return points.Len() == 0
+}
+
+// isDuplicate returns whether the point was already received within the window, and
+// remembers it otherwise.
+func (dp *deduplicationProcessor) isDuplicate(p dataPoint, now time.Time) bool {
+	key := dp.hasher.key(p.Attributes(), p.Timestamp())
+	if seen, ok := dp.seen[key]; ok && now.Sub(seen) < dp.window {
+		return true
+	}
+	dp.seen[key] = now
+	return false
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deduplicationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// processorStart is the start time of the processors under test, the timestamps
+// of the points are in seconds relatively to it.
+var processorStart = time.Unix(1000, 0)
+
+func newTestProcessor(cfg *Config) (*deduplicationProcessor, *time.Time) {
+	now := processorStart
+	dp := newDeduplicationProcessor(cfg)
+	dp.nextGC = processorStart.Add(cfg.Window / 2)
+	dp.now = func() time.Time { return now }
+	return dp, &now
+}
+
+func ts(sec int) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(processorStart.Add(time.Duration(sec) * time.Second))
+}
+
+type point struct {
+	ts    int
+	host  string
+	value int64
+}
+
+// newGauge returns a gauge sent by the given agent.
+func newGauge(agent string, points ...point) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	rm.Resource().Attributes().PutStr("agent", agent)
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("temperature")
+	gauge := m.SetEmptyGauge()
+	for _, p := range points {
+		dp := gauge.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("host", p.host)
+		dp.SetTimestamp(ts(p.ts))
+		dp.SetIntValue(p.value)
+	}
+	return md
+}
+
+func gaugePoints(md pmetric.Metrics) []point {
+	var points []point
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				dps := sm.Metrics().At(k).Gauge().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dp := dps.At(l)
+					host, _ := dp.Attributes().Get("host")
+					points = append(points, point{
+						ts:    int(dp.Timestamp().AsTime().Sub(processorStart) / time.Second),
+						host:  host.Str(),
+						value: dp.IntValue(),
+					})
+				}
+			}
+		}
+	}
+	return points
+}
+
+func process(t *testing.T, dp *deduplicationProcessor, md pmetric.Metrics) pmetric.Metrics {
+	out, err := dp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return out
+}
+
+func TestDeduplicate(t *testing.T) {
+	dp, _ := newTestProcessor(&Config{Window: time.Minute, IgnoredResourceAttributes: []string{"agent"}})
+
+	out := process(t, dp, newGauge("a", point{ts: 0, host: "h1", value: 1}, point{ts: 0, host: "h2", value: 2}))
+	assert.Equal(t, []point{{ts: 0, host: "h1", value: 1}, {ts: 0, host: "h2", value: 2}}, gaugePoints(out))
+
+	// The second agent sends the same points, and a point the first one missed.
+	out = process(t, dp, newGauge("b", point{ts: 0, host: "h1", value: 1}, point{ts: 0, host: "h2", value: 2}, point{ts: 0, host: "h3", value: 3}))
+	assert.Equal(t, []point{{ts: 0, host: "h3", value: 3}}, gaugePoints(out))
+
+	// A new timestamp is a new point.
+	out = process(t, dp, newGauge("b", point{ts: 10, host: "h1", value: 4}))
+	assert.Equal(t, []point{{ts: 10, host: "h1", value: 4}}, gaugePoints(out))
+
+	// The metrics left without points are removed.
+	out = process(t, dp, newGauge("a", point{ts: 10, host: "h1", value: 4}))
+	assert.Equal(t, 0, out.ResourceMetrics().Len())
+}
+
+func TestDeduplicateResourceIdentity(t *testing.T) {
+	dp, _ := newTestProcessor(&Config{Window: time.Minute})
+
+	process(t, dp, newGauge("a", point{ts: 0, host: "h1", value: 1}))
+	// Without ignoring the agent attribute, the streams of the agents are different.
+	out := process(t, dp, newGauge("b", point{ts: 0, host: "h1", value: 1}))
+	assert.Equal(t, []point{{ts: 0, host: "h1", value: 1}}, gaugePoints(out))
+	out = process(t, dp, newGauge("b", point{ts: 0, host: "h1", value: 1}))
+	assert.Empty(t, gaugePoints(out))
+}
+
+func TestDeduplicateWindow(t *testing.T) {
+	dp, now := newTestProcessor(&Config{Window: time.Minute})
+
+	process(t, dp, newGauge("a", point{ts: 0, host: "h1", value: 1}))
+	*now = processorStart.Add(59 * time.Second)
+	out := process(t, dp, newGauge("a", point{ts: 0, host: "h1", value: 1}))
+	assert.Empty(t, gaugePoints(out))
+
+	// Past the window, the point is forwarded again.
+	*now = processorStart.Add(time.Minute)
+	out = process(t, dp, newGauge("a", point{ts: 0, host: "h1", value: 1}))
+	assert.Equal(t, []point{{ts: 0, host: "h1", value: 1}}, gaugePoints(out))
+}
+
+func TestDeduplicateGC(t *testing.T) {
+	dp, now := newTestProcessor(&Config{Window: time.Minute})
+
+	process(t, dp, newGauge("a", point{ts: 0, host: "h1", value: 1}))
+	*now = processorStart.Add(40 * time.Second)
+	process(t, dp, newGauge("a", point{ts: 40, host: "h1", value: 1}))
+	assert.Len(t, dp.seen, 2)
+
+	*now = processorStart.Add(80 * time.Second)
+	process(t, dp, newGauge("a"))
+	assert.Len(t, dp.seen, 1)
+}
+
+func TestDeduplicateMetricIdentity(t *testing.T) {
+	dp, _ := newTestProcessor(&Config{Window: time.Minute})
+
+	newSum := func(temporality pmetric.AggregationTemporality) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(temporality)
+		sum.DataPoints().AppendEmpty().SetTimestamp(ts(0))
+		return md
+	}
+
+	assert.Equal(t, 1, process(t, dp, newSum(pmetric.AggregationTemporalityCumulative)).DataPointCount())
+	assert.Equal(t, 1, process(t, dp, newSum(pmetric.AggregationTemporalityDelta)).DataPointCount())
+	assert.Equal(t, 0, process(t, dp, newSum(pmetric.AggregationTemporalityDelta)).DataPointCount())
+}
+
+func TestDeduplicateAllTypes(t *testing.T) {
+	dp, _ := newTestProcessor(&Config{Window: time.Minute})
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("histogram")
+	m.SetEmptyHistogram().DataPoints().AppendEmpty().SetTimestamp(ts(0))
+	m = ms.AppendEmpty()
+	m.SetName("exponential_histogram")
+	m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetTimestamp(ts(0))
+	m = ms.AppendEmpty()
+	m.SetName("summary")
+	m.SetEmptySummary().DataPoints().AppendEmpty().SetTimestamp(ts(0))
+	m = ms.AppendEmpty()
+	m.SetName("empty")
+	m.SetEmptyGauge()
+
+	dup := pmetric.NewMetrics()
+	md.CopyTo(dup)
+	out := process(t, dp, md)
+	assert.Equal(t, 3, out.DataPointCount())
+	assert.Equal(t, 4, out.MetricCount())
+	out = process(t, dp, dup)
+	assert.Equal(t, 0, out.DataPointCount())
+	// Metrics without points are left untouched.
+	assert.Equal(t, 1, out.MetricCount())
+}
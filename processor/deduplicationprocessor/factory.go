@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package deduplicationprocessor // import "go.opentelemetry.io/collector/processor/deduplicationprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/deduplicationprocessor/internal/metadata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const defaultWindow = time.Minute
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the Deduplication processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability))
+}
+
+// createDefaultConfig creates the default configuration for the processor, which remembers
+// the points for one minute.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Window: defaultWindow,
+	}
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	dp := newDeduplicationProcessor(cfg.(*Config))
+	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer,
+		dp.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deduplicationprocessor // import "go.opentelemetry.io/collector/processor/deduplicationprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/internal/streamhash"
+)
+
+// pointKey identifies a point: the stream it belongs to, the points of a metric sharing
+// the same resource, scope and attributes, and its timestamp.
+type pointKey = streamhash.Key
+
+// streamHasher computes the keys of the points, hashing the identity of the resource,
+// the scope and the metric once for all their points.
+type streamHasher struct {
+	h       *streamhash.Hasher
+	ignored map[string]struct{}
+	prefix  []byte
+}
+
+func newStreamHasher(ignoredResourceAttributes []string) *streamHasher {
+	ignored := make(map[string]struct{}, len(ignoredResourceAttributes))
+	for _, k := range ignoredResourceAttributes {
+		ignored[k] = struct{}{}
+	}
+	return &streamHasher{h: streamhash.New(), ignored: ignored}
+}
+
+// setMetric sets the identity shared by the streams of the metric.
+func (sh *streamHasher) setMetric(resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric) {
+	sh.h.Reset()
+	sh.h.WriteMap(resource.Attributes(), sh.ignored)
+	sh.h.WriteString(scope.Name())
+	sh.h.WriteString(scope.Version())
+	sh.h.WriteMap(scope.Attributes(), nil)
+	sh.h.WriteString(metric.Name())
+	sh.h.WriteString(metric.Unit())
+	sh.h.WriteUint64(uint64(metric.Type()))
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		sh.h.WriteUint64(uint64(metric.Sum().AggregationTemporality()))
+		sh.h.WriteBool(metric.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		sh.h.WriteUint64(uint64(metric.Histogram().AggregationTemporality()))
+	case pmetric.MetricTypeExponentialHistogram:
+		sh.h.WriteUint64(uint64(metric.ExponentialHistogram().AggregationTemporality()))
+	}
+	sh.prefix = sh.h.Sum(sh.prefix[:0])
+}
+
+// key returns the key of the point of the metric with the given attributes and timestamp.
+func (sh *streamHasher) key(attrs pcommon.Map, ts pcommon.Timestamp) pointKey {
+	sh.h.Reset()
+	sh.h.Write(sh.prefix)
+	sh.h.WriteMap(attrs, nil)
+	sh.h.WriteUint64(uint64(ts))
+	return sh.h.Key()
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "go.opentelemetry.io/collector/processor/filterprocessor"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter/filterexpr"
+	"go.opentelemetry.io/collector/internal/pdataconfig"
+)
+
+// MatchType defines how names, values and bodies are compared to the configured patterns.
+type MatchType string
+
+const (
+	// MatchTypeStrict compares the values for equality.
+	MatchTypeStrict MatchType = "strict"
+	// MatchTypeRegexp matches the values with a regular expression.
+	MatchTypeRegexp MatchType = "regexp"
+)
+
+var _ encoding.TextUnmarshaler = (*MatchType)(nil)
+
+// UnmarshalText unmarshalls text to a MatchType.
+func (mt *MatchType) UnmarshalText(text []byte) error {
+	switch str := MatchType(text); str {
+	case MatchTypeStrict, MatchTypeRegexp:
+		*mt = str
+		return nil
+	default:
+		return fmt.Errorf("invalid match type: %q", str)
+	}
+}
+
+// Severity is a log severity, configured by its short name, e.g. "WARN" or "error".
+type Severity = pdataconfig.Severity
+
+// Attribute is a key/value pair an attribute must match. The value is compared to the string
+// representation of the attribute value.
+type Attribute struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
+// MatchProperties are the properties shared by the predicates of all the signals.
+// An item matches if it satisfies all the configured properties.
+type MatchProperties struct {
+	// MatchType defines how the patterns are compared to the values, "strict" (default) or "regexp".
+	MatchType MatchType `mapstructure:"match_type"`
+
+	// Attributes the item must have. For metrics these are the data point attributes.
+	Attributes []Attribute `mapstructure:"attributes"`
+
+	// ResourceAttributes the resource of the item must have.
+	ResourceAttributes []Attribute `mapstructure:"resource_attributes"`
+
+	// Expression is a condition of the filterexpr language the item must satisfy, e.g.
+	// `attributes["http.route"] == "/checkout" or resource.attributes["env"] != "prod"`.
+	// For metrics it is evaluated on each data point.
+	Expression string `mapstructure:"expression"`
+}
+
+// SpanMatchProperties is the predicate spans are matched against.
+type SpanMatchProperties struct {
+	MatchProperties `mapstructure:",squash"`
+
+	// SpanNames matches spans having any of these names.
+	SpanNames []string `mapstructure:"span_names"`
+}
+
+// MetricMatchProperties is the predicate metrics are matched against.
+type MetricMatchProperties struct {
+	MatchProperties `mapstructure:",squash"`
+
+	// MetricNames matches metrics having any of these names.
+	MetricNames []string `mapstructure:"metric_names"`
+}
+
+// LogMatchProperties is the predicate log records are matched against.
+type LogMatchProperties struct {
+	MatchProperties `mapstructure:",squash"`
+
+	// Bodies matches log records having a body equal to or, for "regexp", containing a match of any of these patterns.
+	Bodies []string `mapstructure:"bodies"`
+
+	// MinSeverity matches log records having at least this severity. Records without severity do not match.
+	MinSeverity Severity `mapstructure:"min_severity"`
+}
+
+// SpanFilterConfig configures which spans are kept. If Include is set, only matching spans are kept.
+// Spans matching Exclude are dropped.
+type SpanFilterConfig struct {
+	Include *SpanMatchProperties `mapstructure:"include"`
+	Exclude *SpanMatchProperties `mapstructure:"exclude"`
+}
+
+// MetricFilterConfig configures which metrics are kept. If Include is set, only matching metrics are kept.
+// Metrics matching Exclude are dropped. Predicates on attributes apply to individual data points.
+type MetricFilterConfig struct {
+	Include *MetricMatchProperties `mapstructure:"include"`
+	Exclude *MetricMatchProperties `mapstructure:"exclude"`
+}
+
+// LogFilterConfig configures which log records are kept. If Include is set, only matching records are kept.
+// Log records matching Exclude are dropped.
+type LogFilterConfig struct {
+	Include *LogMatchProperties `mapstructure:"include"`
+	Exclude *LogMatchProperties `mapstructure:"exclude"`
+}
+
+// Config defines configuration for the filter processor.
+type Config struct {
+	Spans   SpanFilterConfig   `mapstructure:"spans"`
+	Metrics MetricFilterConfig `mapstructure:"metrics"`
+	Logs    LogFilterConfig    `mapstructure:"logs"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the span predicate is valid.
+func (mp *SpanMatchProperties) Validate() error {
+	return errors.Join(mp.validate(mp.SpanNames), validateExpression(mp.Expression, filterexpr.ParseSpanCondition))
+}
+
+// Validate checks if the metric predicate is valid.
+func (mp *MetricMatchProperties) Validate() error {
+	return errors.Join(mp.validate(mp.MetricNames), validateExpression(mp.Expression, filterexpr.ParseDataPointCondition))
+}
+
+// Validate checks if the log predicate is valid.
+func (mp *LogMatchProperties) Validate() error {
+	return errors.Join(mp.validate(mp.Bodies), validateExpression(mp.Expression, filterexpr.ParseLogCondition))
+}
+
+// validateExpression checks that the expression, if any, is a valid condition for its signal.
+func validateExpression[K any](expr string, parse func(string) (*filterexpr.Condition[K], error)) error {
+	if expr == "" {
+		return nil
+	}
+	if _, err := parse(expr); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	return nil
+}
+
+// validate checks that the given patterns and the attribute values are valid regular expressions
+// when the match type is "regexp".
+func (mp *MatchProperties) validate(patterns []string) error {
+	if mp.MatchType != MatchTypeRegexp {
+		return nil
+	}
+	var errs error
+	for _, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid regexp %q: %w", p, err))
+		}
+	}
+	for _, attr := range append(slices.Clone(mp.Attributes), mp.ResourceAttributes...) {
+		if _, err := regexp.Compile(attr.Value); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid regexp %q for attribute %q: %w", attr.Value, attr.Key, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Spans: SpanFilterConfig{
+				Exclude: &SpanMatchProperties{
+					MatchProperties: MatchProperties{
+						MatchType:  MatchTypeRegexp,
+						Attributes: []Attribute{{Key: "http.method", Value: "GET"}},
+					},
+					SpanNames: []string{"^/health"},
+				},
+			},
+			Metrics: MetricFilterConfig{
+				Include: &MetricMatchProperties{
+					MatchProperties: MatchProperties{
+						ResourceAttributes: []Attribute{{Key: "deployment.environment", Value: "production"}},
+					},
+					MetricNames: []string{"requests", "latency"},
+				},
+			},
+			Logs: LogFilterConfig{
+				Include: &LogMatchProperties{
+					MinSeverity: Severity(plog.SeverityNumberWarn),
+				},
+				Exclude: &LogMatchProperties{
+					Bodies: []string{"connection reset by peer"},
+				},
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalInvalidConfig(t *testing.T) {
+	for _, file := range []string{"invalid_match_type.yaml", "invalid_severity.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", file))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			assert.Error(t, cm.Unmarshal(&cfg))
+		})
+	}
+}
+
+func TestValidateInvalidRegexp(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "invalid_regexp.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.ErrorContains(t, component.ValidateConfig(cfg), `invalid regexp "(unclosed"`)
+}
+
+func TestValidateInvalidExpression(t *testing.T) {
+	cfg := &Config{
+		Spans: SpanFilterConfig{
+			Include: &SpanMatchProperties{MatchProperties: MatchProperties{Expression: `name ==`}},
+		},
+		Logs: LogFilterConfig{
+			// The paths of the other signals are not valid.
+			Exclude: &LogMatchProperties{MatchProperties: MatchProperties{Expression: `metric.name == "requests"`}},
+		},
+	}
+	err := component.ValidateConfig(cfg)
+	assert.ErrorContains(t, err, "invalid expression: unexpected end of expression")
+	assert.ErrorContains(t, err, "invalid expression: unknown path metric.name")
+}
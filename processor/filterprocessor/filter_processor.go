@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "go.opentelemetry.io/collector/processor/filterprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/filter/filterexpr"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type filterProcessor struct {
+	spansInclude   *propertiesMatcher
+	spansExclude   *propertiesMatcher
+	metricsInclude *propertiesMatcher
+	metricsExclude *propertiesMatcher
+	logsInclude    *propertiesMatcher
+	logsExclude    *propertiesMatcher
+}
+
+func newFilterProcessor(cfg *Config) (*filterProcessor, error) {
+	fp := &filterProcessor{}
+	var err error
+	if fp.spansInclude, err = newSpanMatcher(cfg.Spans.Include); err != nil {
+		return nil, err
+	}
+	if fp.spansExclude, err = newSpanMatcher(cfg.Spans.Exclude); err != nil {
+		return nil, err
+	}
+	if fp.metricsInclude, err = newMetricMatcher(cfg.Metrics.Include); err != nil {
+		return nil, err
+	}
+	if fp.metricsExclude, err = newMetricMatcher(cfg.Metrics.Exclude); err != nil {
+		return nil, err
+	}
+	if fp.logsInclude, err = newLogMatcher(cfg.Logs.Include); err != nil {
+		return nil, err
+	}
+	if fp.logsExclude, err = newLogMatcher(cfg.Logs.Exclude); err != nil {
+		return nil, err
+	}
+	return fp, nil
+}
+
+func newSpanMatcher(mp *SpanMatchProperties) (*propertiesMatcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+	pm, err := newPropertiesMatcher(&mp.MatchProperties, mp.SpanNames)
+	if err != nil {
+		return nil, err
+	}
+	pm.spanExpr, err = parseExpression(mp.Expression, filterexpr.ParseSpanCondition)
+	return pm, err
+}
+
+func newMetricMatcher(mp *MetricMatchProperties) (*propertiesMatcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+	pm, err := newPropertiesMatcher(&mp.MatchProperties, mp.MetricNames)
+	if err != nil {
+		return nil, err
+	}
+	pm.dataPointExpr, err = parseExpression(mp.Expression, filterexpr.ParseDataPointCondition)
+	return pm, err
+}
+
+func newLogMatcher(mp *LogMatchProperties) (*propertiesMatcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+	pm, err := newPropertiesMatcher(&mp.MatchProperties, mp.Bodies)
+	if err != nil {
+		return nil, err
+	}
+	pm.minSeverity = plog.SeverityNumber(mp.MinSeverity)
+	pm.logExpr, err = parseExpression(mp.Expression, filterexpr.ParseLogCondition)
+	return pm, err
+}
+
+// parseExpression parses the expression of a predicate, it returns nil if the expression is not configured.
+func parseExpression[K any](expr string, parse func(string) (*filterexpr.Condition[K], error)) (*filterexpr.Condition[K], error) {
+	if expr == "" {
+		return nil, nil
+	}
+	cond, err := parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	return cond, nil
+}
+
+func (fp *filterProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	if fp.spansInclude == nil && fp.spansExclude == nil {
+		return td, nil
+	}
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		res := rs.Resource()
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !keep(fp.spansInclude, fp.spansExclude, func(pm *propertiesMatcher) bool {
+					return pm.matchesResource(res) && pm.matchesValue(span.Name()) && pm.matchesAttributes(span.Attributes()) &&
+						pm.matchesSpan(filterexpr.SpanContext{Resource: res, Scope: ss.Scope(), Span: span})
+				})
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (fp *filterProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if fp.metricsInclude == nil && fp.metricsExclude == nil {
+		return md, nil
+	}
+	// Without predicates on the data points, whole metrics are kept or dropped.
+	byDataPoint := hasDataPointPredicates(fp.metricsInclude) || hasDataPointPredicates(fp.metricsExclude)
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		res := rm.Resource()
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				if !byDataPoint {
+					return !keep(fp.metricsInclude, fp.metricsExclude, func(pm *propertiesMatcher) bool {
+						return pm.matchesResource(res) && pm.matchesValue(m.Name())
+					})
+				}
+				return removeDataPointsIf(m, func(attrs pcommon.Map) bool {
+					return !keep(fp.metricsInclude, fp.metricsExclude, func(pm *propertiesMatcher) bool {
+						return pm.matchesResource(res) && pm.matchesValue(m.Name()) && pm.matchesAttributes(attrs) &&
+							pm.matchesDataPoint(filterexpr.DataPointContext{Resource: res, Scope: sm.Scope(), Metric: m, Attributes: attrs})
+					})
+				})
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// hasDataPointPredicates returns whether the predicate is evaluated on the individual data points.
+func hasDataPointPredicates(pm *propertiesMatcher) bool {
+	return pm != nil && (len(pm.attributes) > 0 || pm.dataPointExpr != nil)
+}
+
+// removeDataPointsIf removes the data points of the metric whose attributes satisfy f,
+// and reports whether it removed all of them. Metrics without data points are left alone.
+func removeDataPointsIf(m pmetric.Metric, f func(pcommon.Map) bool) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return f(dp.Attributes()) })
+		return n > 0 && dps.Len() == 0
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return f(dp.Attributes()) })
+		return n > 0 && dps.Len() == 0
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return f(dp.Attributes()) })
+		return n > 0 && dps.Len() == 0
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return f(dp.Attributes()) })
+		return n > 0 && dps.Len() == 0
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return f(dp.Attributes()) })
+		return n > 0 && dps.Len() == 0
+	}
+	return false
+}
+
+func (fp *filterProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	if fp.logsInclude == nil && fp.logsExclude == nil {
+		return ld, nil
+	}
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		res := rl.Resource()
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !keep(fp.logsInclude, fp.logsExclude, func(pm *propertiesMatcher) bool {
+					return pm.matchesResource(res) && pm.matchesSeverity(lr.SeverityNumber()) &&
+						pm.matchesAttributes(lr.Attributes()) && pm.matchesValue(lr.Body().AsString()) &&
+						pm.matchesLog(filterexpr.LogContext{Resource: res, Scope: sl.Scope(), LogRecord: lr})
+				})
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func newTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, env := range []string{"production", "staging"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("deployment.environment", env)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, name := range []string{"/health", "/api/users", "/api/orders"} {
+			span := spans.AppendEmpty()
+			span.SetName(name)
+			span.Attributes().PutInt("http.status_code", 200)
+		}
+	}
+	return td
+}
+
+func spanNames(td ptrace.Traces) []string {
+	var names []string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				names = append(names, spans.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func TestFilterTraces(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      SpanFilterConfig
+		expected []string
+	}{
+		{
+			name:     "no predicate",
+			expected: []string{"/health", "/api/users", "/api/orders", "/health", "/api/users", "/api/orders"},
+		},
+		{
+			name: "include strict names",
+			cfg: SpanFilterConfig{
+				Include: &SpanMatchProperties{SpanNames: []string{"/api/users"}},
+			},
+			expected: []string{"/api/users", "/api/users"},
+		},
+		{
+			name: "exclude regexp names",
+			cfg: SpanFilterConfig{
+				Exclude: &SpanMatchProperties{
+					MatchProperties: MatchProperties{MatchType: MatchTypeRegexp},
+					SpanNames:       []string{"^/api/"},
+				},
+			},
+			expected: []string{"/health", "/health"},
+		},
+		{
+			name: "include resource and exclude name",
+			cfg: SpanFilterConfig{
+				Include: &SpanMatchProperties{
+					MatchProperties: MatchProperties{
+						ResourceAttributes: []Attribute{{Key: "deployment.environment", Value: "production"}},
+					},
+				},
+				Exclude: &SpanMatchProperties{SpanNames: []string{"/health"}},
+			},
+			expected: []string{"/api/users", "/api/orders"},
+		},
+		{
+			name: "attributes compared as string",
+			cfg: SpanFilterConfig{
+				Exclude: &SpanMatchProperties{
+					MatchProperties: MatchProperties{
+						Attributes: []Attribute{{Key: "http.status_code", Value: "200"}},
+					},
+				},
+			},
+		},
+		{
+			name: "include expression",
+			cfg: SpanFilterConfig{
+				Include: &SpanMatchProperties{
+					MatchProperties: MatchProperties{
+						Expression: `resource.attributes["deployment.environment"] == "staging" and name matches "^/api/"`,
+					},
+				},
+			},
+			expected: []string{"/api/users", "/api/orders"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			cfg := &Config{Spans: tt.cfg}
+			tp, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces()))
+			if tt.expected == nil {
+				// Nothing is sent when all the data is dropped.
+				assert.Empty(t, sink.AllTraces())
+				return
+			}
+			require.Len(t, sink.AllTraces(), 1)
+			assert.Equal(t, tt.expected, spanNames(sink.AllTraces()[0]))
+		})
+	}
+}
+
+func newMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "shop")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	requests := ms.AppendEmpty()
+	requests.SetName("requests")
+	sum := requests.SetEmptySum()
+	for _, code := range []string{"200", "500"} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("code", code)
+		dp.SetIntValue(1)
+	}
+
+	latency := ms.AppendEmpty()
+	latency.SetName("latency")
+	dp := latency.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("code", "200")
+
+	gc := ms.AppendEmpty()
+	gc.SetName("go_gc_duration")
+	gc.SetEmptySummary().DataPoints().AppendEmpty()
+
+	// A metric without data points is only dropped by the predicates on whole metrics.
+	ms.AppendEmpty().SetName("up")
+	return md
+}
+
+func TestFilterMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        MetricFilterConfig
+		expected   []string
+		dataPoints int
+	}{
+		{
+			name: "exclude regexp names",
+			cfg: MetricFilterConfig{
+				Exclude: &MetricMatchProperties{
+					MatchProperties: MatchProperties{MatchType: MatchTypeRegexp},
+					MetricNames:     []string{"^go_"},
+				},
+			},
+			expected:   []string{"requests", "latency", "up"},
+			dataPoints: 3,
+		},
+		{
+			name: "include resource",
+			cfg: MetricFilterConfig{
+				Include: &MetricMatchProperties{
+					MatchProperties: MatchProperties{
+						ResourceAttributes: []Attribute{{Key: "service.name", Value: "shop"}},
+					},
+				},
+			},
+			expected:   []string{"requests", "latency", "go_gc_duration", "up"},
+			dataPoints: 4,
+		},
+		{
+			name: "include data point attributes",
+			cfg: MetricFilterConfig{
+				Include: &MetricMatchProperties{
+					MatchProperties: MatchProperties{
+						Attributes: []Attribute{{Key: "code", Value: "500"}},
+					},
+				},
+			},
+			expected:   []string{"requests", "up"},
+			dataPoints: 1,
+		},
+		{
+			name: "exclude data point attributes for a metric",
+			cfg: MetricFilterConfig{
+				Exclude: &MetricMatchProperties{
+					MatchProperties: MatchProperties{
+						Attributes: []Attribute{{Key: "code", Value: "200"}},
+					},
+					MetricNames: []string{"requests"},
+				},
+			},
+			expected:   []string{"requests", "latency", "go_gc_duration", "up"},
+			dataPoints: 3,
+		},
+		{
+			name: "exclude data point expression",
+			cfg: MetricFilterConfig{
+				Exclude: &MetricMatchProperties{
+					MatchProperties: MatchProperties{
+						Expression: `metric.name == "requests" and attributes["code"] != "500"`,
+					},
+				},
+			},
+			expected:   []string{"requests", "latency", "go_gc_duration", "up"},
+			dataPoints: 3,
+		},
+		{
+			name: "exclude resource",
+			cfg: MetricFilterConfig{
+				Exclude: &MetricMatchProperties{
+					MatchProperties: MatchProperties{
+						ResourceAttributes: []Attribute{{Key: "service.name", Value: "shop"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			cfg := &Config{Metrics: tt.cfg}
+			mp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics()))
+			if tt.expected == nil {
+				assert.Empty(t, sink.AllMetrics())
+				return
+			}
+			require.Len(t, sink.AllMetrics(), 1)
+			md := sink.AllMetrics()[0]
+			var names []string
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < ms.Len(); i++ {
+				names = append(names, ms.At(i).Name())
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.dataPoints, md.DataPointCount())
+		})
+	}
+}
+
+func newLogs() plog.Logs {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, rec := range []struct {
+		severity plog.SeverityNumber
+		body     string
+	}{
+		{plog.SeverityNumberUnspecified, "no severity"},
+		{plog.SeverityNumberDebug, "debugging"},
+		{plog.SeverityNumberWarn2, "connection reset by peer"},
+		{plog.SeverityNumberError, "request failed"},
+	} {
+		lr := records.AppendEmpty()
+		lr.SetSeverityNumber(rec.severity)
+		lr.Body().SetStr(rec.body)
+	}
+	return ld
+}
+
+func TestFilterLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      LogFilterConfig
+		expected []string
+	}{
+		{
+			name: "include min severity",
+			cfg: LogFilterConfig{
+				Include: &LogMatchProperties{MinSeverity: Severity(plog.SeverityNumberWarn)},
+			},
+			expected: []string{"connection reset by peer", "request failed"},
+		},
+		{
+			name: "exclude min severity",
+			cfg: LogFilterConfig{
+				Exclude: &LogMatchProperties{MinSeverity: Severity(plog.SeverityNumberWarn)},
+			},
+			expected: []string{"no severity", "debugging"},
+		},
+		{
+			name: "include severity and exclude body",
+			cfg: LogFilterConfig{
+				Include: &LogMatchProperties{MinSeverity: Severity(plog.SeverityNumberWarn)},
+				Exclude: &LogMatchProperties{
+					MatchProperties: MatchProperties{MatchType: MatchTypeRegexp},
+					Bodies:          []string{"reset"},
+				},
+			},
+			expected: []string{"request failed"},
+		},
+		{
+			name: "exclude expression",
+			cfg: LogFilterConfig{
+				Exclude: &LogMatchProperties{
+					MatchProperties: MatchProperties{Expression: `severity_number < WARN or body matches "reset"`},
+				},
+			},
+			expected: []string{"request failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			cfg := &Config{Logs: tt.cfg}
+			lp, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			require.NoError(t, lp.ConsumeLogs(context.Background(), newLogs()))
+			require.Len(t, sink.AllLogs(), 1)
+			var bodies []string
+			records := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			for i := 0; i < records.Len(); i++ {
+				bodies = append(bodies, records.At(i).Body().AsString())
+			}
+			assert.Equal(t, tt.expected, bodies)
+		})
+	}
+}
+
+func TestSeverityUnmarshalText(t *testing.T) {
+	var s Severity
+	require.NoError(t, s.UnmarshalText([]byte("ERROR")))
+	assert.Equal(t, Severity(plog.SeverityNumberError), s)
+	require.NoError(t, s.UnmarshalText([]byte("warn3")))
+	assert.Equal(t, Severity(plog.SeverityNumberWarn3), s)
+	assert.EqualError(t, s.UnmarshalText([]byte("unspecified")), `invalid severity: "unspecified"`)
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "go.opentelemetry.io/collector/processor/filterprocessor"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/filter/filterexpr"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// valueMatcher matches a string against a single pattern.
+type valueMatcher struct {
+	strict string
+	re     *regexp.Regexp
+}
+
+func newValueMatcher(mt MatchType, pattern string) (valueMatcher, error) {
+	if mt != MatchTypeRegexp {
+		return valueMatcher{strict: pattern}, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return valueMatcher{}, err
+	}
+	return valueMatcher{re: re}, nil
+}
+
+func (vm valueMatcher) matches(v string) bool {
+	if vm.re != nil {
+		return vm.re.MatchString(v)
+	}
+	return vm.strict == v
+}
+
+type attributeMatcher struct {
+	key   string
+	value valueMatcher
+}
+
+// propertiesMatcher is the compiled form of a predicate.
+type propertiesMatcher struct {
+	// values are the names or bodies the item must match one of, any value matches if empty.
+	values             []valueMatcher
+	attributes         []attributeMatcher
+	resourceAttributes []attributeMatcher
+	minSeverity        plog.SeverityNumber
+
+	// The expression of the predicate, parsed for the signal of the matcher.
+	spanExpr      *filterexpr.Condition[filterexpr.SpanContext]
+	dataPointExpr *filterexpr.Condition[filterexpr.DataPointContext]
+	logExpr       *filterexpr.Condition[filterexpr.LogContext]
+}
+
+// newPropertiesMatcher compiles the predicate, it returns nil if the predicate is not configured.
+func newPropertiesMatcher(mp *MatchProperties, values []string) (*propertiesMatcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+	pm := &propertiesMatcher{}
+	for _, v := range values {
+		vm, err := newValueMatcher(mp.MatchType, v)
+		if err != nil {
+			return nil, err
+		}
+		pm.values = append(pm.values, vm)
+	}
+	var err error
+	if pm.attributes, err = newAttributeMatchers(mp.MatchType, mp.Attributes); err != nil {
+		return nil, err
+	}
+	if pm.resourceAttributes, err = newAttributeMatchers(mp.MatchType, mp.ResourceAttributes); err != nil {
+		return nil, err
+	}
+	return pm, nil
+}
+
+func newAttributeMatchers(mt MatchType, attrs []Attribute) ([]attributeMatcher, error) {
+	var ams []attributeMatcher
+	for _, attr := range attrs {
+		vm, err := newValueMatcher(mt, attr.Value)
+		if err != nil {
+			return nil, err
+		}
+		ams = append(ams, attributeMatcher{key: attr.Key, value: vm})
+	}
+	return ams, nil
+}
+
+func (pm *propertiesMatcher) matchesValue(v string) bool {
+	if len(pm.values) == 0 {
+		return true
+	}
+	for _, vm := range pm.values {
+		if vm.matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pm *propertiesMatcher) matchesAttributes(attrs pcommon.Map) bool {
+	return matchAttributes(pm.attributes, attrs)
+}
+
+func (pm *propertiesMatcher) matchesResource(res pcommon.Resource) bool {
+	return matchAttributes(pm.resourceAttributes, res.Attributes())
+}
+
+func (pm *propertiesMatcher) matchesSeverity(sn plog.SeverityNumber) bool {
+	return pm.minSeverity == plog.SeverityNumberUnspecified || (sn != plog.SeverityNumberUnspecified && sn >= pm.minSeverity)
+}
+
+func (pm *propertiesMatcher) matchesSpan(ctx filterexpr.SpanContext) bool {
+	return pm.spanExpr == nil || pm.spanExpr.Eval(ctx)
+}
+
+func (pm *propertiesMatcher) matchesDataPoint(ctx filterexpr.DataPointContext) bool {
+	return pm.dataPointExpr == nil || pm.dataPointExpr.Eval(ctx)
+}
+
+func (pm *propertiesMatcher) matchesLog(ctx filterexpr.LogContext) bool {
+	return pm.logExpr == nil || pm.logExpr.Eval(ctx)
+}
+
+// matchAttributes returns true if all the attribute matchers match an attribute of attrs.
+func matchAttributes(ams []attributeMatcher, attrs pcommon.Map) bool {
+	for _, am := range ams {
+		v, ok := attrs.Get(am.key)
+		if !ok || !am.value.matches(v.AsString()) {
+			return false
+		}
+	}
+	return true
+}
+
+// keep returns true if the item must be kept according to the include and exclude predicates,
+// match returns whether the item matches the given predicate.
+func keep(include, exclude *propertiesMatcher, match func(*propertiesMatcher) bool) bool {
+	if include != nil && !match(include) {
+		return false
+	}
+	return exclude == nil || !match(exclude)
+}
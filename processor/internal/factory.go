@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/consumer/consumerprofiles"
 )
 
@@ -53,6 +54,14 @@ type Factory interface {
 	// ProfilesProcessorStability gets the stability level of the ProfilesProcessor.
 	ProfilesProcessorStability() component.StabilityLevel
 
+	// CreateEntitiesProcessor creates an EntitiesProcessor based on this config.
+	// If the processor type does not support entities or if the config is not valid,
+	// an error will be returned instead.
+	CreateEntitiesProcessor(ctx context.Context, set Settings, cfg component.Config, nextConsumer consumerentities.Entities) (Entities, error)
+
+	// EntitiesProcessorStability gets the stability level of the EntitiesProcessor.
+	EntitiesProcessorStability() component.StabilityLevel
+
 	unexportedFactoryFunc()
 }
 
@@ -121,6 +130,9 @@ func (f CreateLogsFunc) CreateLogsProcessor(
 // CreateProfilesFunc is the equivalent of Factory.CreateProfiles().
 type CreateProfilesFunc func(context.Context, Settings, component.Config, consumerprofiles.Profiles) (Profiles, error)
 
+// CreateEntitiesFunc is the equivalent of Factory.CreateEntities().
+type CreateEntitiesFunc func(context.Context, Settings, component.Config, consumerentities.Entities) (Entities, error)
+
 // CreateProfilesProcessor implements Factory.CreateProfilesProcessor().
 func (f CreateProfilesFunc) CreateProfilesProcessor(
 	ctx context.Context,
@@ -133,6 +145,18 @@ func (f CreateProfilesFunc) CreateProfilesProcessor(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// CreateEntitiesProcessor implements Factory.CreateEntitiesProcessor().
+func (f CreateEntitiesFunc) CreateEntitiesProcessor(
+	ctx context.Context,
+	set Settings,
+	cfg component.Config,
+	nextConsumer consumerentities.Entities) (Entities, error) {
+	if f == nil {
+		return nil, component.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
 type factory struct {
 	cfgType component.Type
 	component.CreateDefaultConfigFunc
@@ -144,6 +168,8 @@ type factory struct {
 	logsStabilityLevel component.StabilityLevel
 	CreateProfilesFunc
 	profilesStabilityLevel component.StabilityLevel
+	CreateEntitiesFunc
+	entitiesStabilityLevel component.StabilityLevel
 }
 
 func (f *factory) Type() component.Type {
@@ -168,6 +194,10 @@ func (f factory) ProfilesProcessorStability() component.StabilityLevel {
 	return f.profilesStabilityLevel
 }
 
+func (f factory) EntitiesProcessorStability() component.StabilityLevel {
+	return f.entitiesStabilityLevel
+}
+
 // WithTraces overrides the default "error not supported" implementation for CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -200,6 +230,14 @@ func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel
 	})
 }
 
+// WithEntities overrides the default "error not supported" implementation for CreateEntities and the default "undefined" stability level.
+func WithEntities(createEntities CreateEntitiesFunc, sl component.StabilityLevel) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.entitiesStabilityLevel = sl
+		o.CreateEntitiesFunc = createEntities
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
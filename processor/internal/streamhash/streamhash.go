@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package streamhash computes the keys identifying the metric streams and their points, from the 128-bit FNV-1a
+// hash of their identity. The attributes are hashed with pcommon.Map.Hash.
+package streamhash // import "go.opentelemetry.io/collector/processor/internal/streamhash"
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Key is the hash of an identity.
+type Key [16]byte
+
+// Hasher hashes the identities, it is not safe for concurrent use.
+type Hasher struct {
+	h   hash.Hash
+	buf []byte
+	// filtered holds the attributes which are not ignored by WriteMap.
+	filtered pcommon.Map
+}
+
+func New() *Hasher {
+	return &Hasher{h: fnv.New128a(), buf: make([]byte, 0, 64), filtered: pcommon.NewMap()}
+}
+
+// Reset starts hashing a new identity.
+func (h *Hasher) Reset() {
+	h.h.Reset()
+}
+
+// Sum appends the hash of the identity written so far to b, to be written as the prefix of other identities.
+func (h *Hasher) Sum(b []byte) []byte {
+	return h.h.Sum(b)
+}
+
+// Key returns the key of the identity written so far.
+func (h *Hasher) Key() Key {
+	var key Key
+	h.buf = h.h.Sum(h.buf[:0])
+	copy(key[:], h.buf)
+	return key
+}
+
+// Write writes raw bytes, e.g. a prefix returned by Sum.
+func (h *Hasher) Write(b []byte) {
+	_, _ = h.h.Write(b)
+}
+
+func (h *Hasher) WriteUint64(v uint64) {
+	h.buf = binary.LittleEndian.AppendUint64(h.buf[:0], v)
+	_, _ = h.h.Write(h.buf)
+}
+
+func (h *Hasher) WriteBool(v bool) {
+	if v {
+		h.WriteUint64(1)
+	} else {
+		h.WriteUint64(0)
+	}
+}
+
+// WriteString writes the string through a buffer reused across the calls, so that it does not allocate.
+func (h *Hasher) WriteString(s string) {
+	h.WriteUint64(uint64(len(s)))
+	h.buf = append(h.buf[:0], s...)
+	_, _ = h.h.Write(h.buf)
+}
+
+// WriteMap writes the hash of the attributes which are not ignored, see pcommon.Map.Hash: their order does not
+// matter. The ignored keys only apply to the attributes of m, not to the nested maps.
+func (h *Hasher) WriteMap(m pcommon.Map, ignored map[string]struct{}) {
+	if len(ignored) > 0 && hasAny(m, ignored) {
+		// The attributes are copied to a map reused across the calls, so that the ignored ones are not hashed.
+		// Unlike the hashing, the copy allocates.
+		h.filtered.RemoveIf(func(string, pcommon.Value) bool { return true })
+		m.Range(func(k string, v pcommon.Value) bool {
+			if _, ok := ignored[k]; !ok {
+				v.CopyTo(h.filtered.PutEmpty(k))
+			}
+			return true
+		})
+		m = h.filtered
+	}
+	sum := m.Hash()
+	h.buf = append(h.buf[:0], sum[:]...)
+	_, _ = h.h.Write(h.buf)
+}
+
+// hasAny returns whether m has one of the keys.
+func hasAny(m pcommon.Map, keys map[string]struct{}) bool {
+	found := false
+	m.Range(func(k string, _ pcommon.Value) bool {
+		_, found = keys[k]
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func mapKey(h *Hasher, m pcommon.Map, ignored map[string]struct{}) Key {
+	h.Reset()
+	h.WriteMap(m, ignored)
+	return h.Key()
+}
+
+func TestWriteMap(t *testing.T) {
+	h := New()
+	m1 := pcommon.NewMap()
+	m1.PutStr("a", "1")
+	m1.PutInt("b", 2)
+	m1.PutEmptyMap("c").PutStr("d", "3")
+	m2 := pcommon.NewMap()
+	m2.PutEmptyMap("c").PutStr("d", "3")
+	m2.PutInt("b", 2)
+	m2.PutStr("a", "1")
+	assert.Equal(t, mapKey(h, m1, nil), mapKey(h, m2, nil))
+
+	// The types of the values are part of the identity.
+	m2.PutStr("b", "2")
+	assert.NotEqual(t, mapKey(h, m1, nil), mapKey(h, m2, nil))
+	assert.Equal(t, mapKey(h, m1, map[string]struct{}{"b": {}}), mapKey(h, m2, map[string]struct{}{"b": {}}))
+}
+
+func TestPrefix(t *testing.T) {
+	h := New()
+	h.WriteString("metric")
+	prefix := h.Sum(nil)
+
+	pointKey := func(v uint64) Key {
+		h.Reset()
+		h.Write(prefix)
+		h.WriteUint64(v)
+		return h.Key()
+	}
+	assert.Equal(t, pointKey(1), pointKey(1))
+	assert.NotEqual(t, pointKey(1), pointKey(2))
+}
+
+func TestWriteString(t *testing.T) {
+	h := New()
+	h.WriteString("ab")
+	h.WriteString("c")
+	key := h.Key()
+	h.Reset()
+	h.WriteString("a")
+	h.WriteString("bc")
+	assert.NotEqual(t, key, h.Key())
+}
+
+func TestAllocations(t *testing.T) {
+	h := New()
+	m := pcommon.NewMap()
+	m.PutStr("a", "1")
+	m.PutEmptyMap("b").PutInt("c", 2)
+	ignored := map[string]struct{}{"d": {}}
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		h.Reset()
+		h.WriteString("metric")
+		h.WriteMap(m, nil)
+		h.WriteMap(m, ignored)
+		h.Key()
+	}))
+}
@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginprocessor // import "go.opentelemetry.io/collector/processor/pluginprocessor"
+
+import (
+	"go.opentelemetry.io/collector/internal/plugin/host"
+)
+
+// Config configures the plugin processor: the process of the plugin and the configuration of the component it runs.
+type Config = host.Config
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package pluginprocessor runs a processor served by a plugin executable, out of the collector process.
+package pluginprocessor // import "go.opentelemetry.io/collector/processor/pluginprocessor"
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginprocessor // import "go.opentelemetry.io/collector/processor/pluginprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/plugin/host"
+	"go.opentelemetry.io/collector/processor/pluginprocessor/internal/metadata"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// NewFactory returns a processor.Factory for the plugin processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTraces, metadata.TracesStability),
+		processor.WithMetrics(createMetrics, metadata.MetricsStability),
+		processor.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	cfg := host.NewDefaultConfig()
+	return &cfg
+}
+
+// createTraces creates a processor running the plugin in a traces pipeline.
+func createTraces(_ context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindProcessor, component.DataTypeTraces, next), nil
+}
+
+// createMetrics creates a processor running the plugin in a metrics pipeline.
+func createMetrics(_ context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindProcessor, component.DataTypeMetrics, next), nil
+}
+
+// createLogs creates a processor running the plugin in a logs pipeline.
+func createLogs(_ context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindProcessor, component.DataTypeLogs, next), nil
+}
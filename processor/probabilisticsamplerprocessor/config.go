@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errInvalidSamplingPercentage  = errors.New("'sampling_percentage' must be between 0 and 100")
+	errTooSmallSamplingPercentage = fmt.Errorf("'sampling_percentage' must be 0 or at least %g", minSamplingProbability*100)
+	errInvalidSamplingPrecision   = fmt.Errorf("'sampling_precision' must be between 1 and %d", numHexDigits)
+)
+
+// Mode is how the sampling probability of the processor is combined with the one of the items
+// sampled upstream.
+type Mode string
+
+const (
+	// ModeProportional multiplies the sampling probability of the items by the configured one,
+	// so that the processor samples the configured proportion of the items it receives.
+	ModeProportional Mode = "proportional"
+	// ModeEqualizing samples the items at the configured probability, unless they were already
+	// sampled at a lower probability, so that all the items end up with the same probability.
+	ModeEqualizing Mode = "equalizing"
+)
+
+var _ encoding.TextUnmarshaler = (*Mode)(nil)
+
+// UnmarshalText unmarshalls text to a Mode.
+func (m *Mode) UnmarshalText(text []byte) error {
+	switch str := Mode(text); str {
+	case ModeProportional, ModeEqualizing:
+		*m = str
+		return nil
+	default:
+		return fmt.Errorf("invalid mode: %q", str)
+	}
+}
+
+// Config defines the configuration for the Probabilistic Sampler processor.
+type Config struct {
+	// SamplingPercentage is the percentage at which the items are sampled, between 0 and 100.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+
+	// Mode is how the sampling probability is combined with the one of the items sampled upstream.
+	Mode Mode `mapstructure:"mode"`
+
+	// SamplingPrecision is the number of hexadecimal digits of the sampling thresholds recorded on the items.
+	SamplingPrecision int `mapstructure:"sampling_precision"`
+
+	// FromAttribute is the attribute of the log records whose value is hashed into the randomness
+	// of the records without trace ID nor explicit randomness.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// FailClosed drops the items whose randomness cannot be determined. They are kept otherwise.
+	FailClosed bool `mapstructure:"fail_closed"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	pct := cfg.SamplingPercentage
+	if math.IsNaN(pct) || pct < 0 || pct > 100 {
+		return errInvalidSamplingPercentage
+	}
+	if pct != 0 && pct/100 < minSamplingProbability {
+		return errTooSmallSamplingPercentage
+	}
+	if cfg.SamplingPrecision < 1 || cfg.SamplingPrecision > numHexDigits {
+		return errInvalidSamplingPrecision
+	}
+	return nil
+}
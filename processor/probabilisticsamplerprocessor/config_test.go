@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			SamplingPercentage: 12.5,
+			Mode:               ModeEqualizing,
+			SamplingPrecision:  6,
+			FromAttribute:      "session.id",
+			FailClosed:         false,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalInvalidMode(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	err := confmap.NewFromStringMap(map[string]any{"mode": "hash_seed"}).Unmarshal(&cfg)
+	assert.ErrorContains(t, err, `invalid mode: "hash_seed"`)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float64
+		precision  int
+		expected   error
+	}{
+		{name: "never", percentage: 0, precision: 4},
+		{name: "always", percentage: 100, precision: 14},
+		{name: "negative", percentage: -1, precision: 4, expected: errInvalidSamplingPercentage},
+		{name: "above 100", percentage: 101, precision: 4, expected: errInvalidSamplingPercentage},
+		{name: "NaN", percentage: math.NaN(), precision: 4, expected: errInvalidSamplingPercentage},
+		{name: "too small", percentage: 1e-16, precision: 4, expected: errTooSmallSamplingPercentage},
+		{name: "no precision", percentage: 10, precision: 0, expected: errInvalidSamplingPrecision},
+		{name: "too precise", percentage: 10, precision: 15, expected: errInvalidSamplingPrecision},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{SamplingPercentage: tt.percentage, Mode: ModeProportional, SamplingPrecision: tt.precision}
+			assert.Equal(t, tt.expected, cfg.Validate())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor/internal/metadata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const defaultSamplingPrecision = 4
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the Probabilistic Sampler processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, metadata.TracesStability),
+		processor.WithLogs(createLogsProcessor, metadata.LogsStability))
+}
+
+// createDefaultConfig creates the default configuration for the processor, which samples all the items.
+func createDefaultConfig() component.Config {
+	return &Config{
+		SamplingPercentage: 100,
+		Mode:               ModeProportional,
+		SamplingPrecision:  defaultSamplingPrecision,
+		FailClosed:         true,
+	}
+}
+
+func createTracesProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (processor.Traces, error) {
+	sp := newSamplerProcessor(cfg.(*Config), set.Logger)
+	return processorhelper.NewTracesProcessor(ctx, set, cfg, nextConsumer,
+		sp.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	sp := newSamplerProcessor(cfg.(*Config), set.Logger)
+	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer,
+		sp.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// The log records have no tracestate: their explicit randomness and their threshold are attributes.
+const (
+	randomnessAttribute = "sampling.randomness"
+	thresholdAttribute  = "sampling.threshold"
+)
+
+type samplerProcessor struct {
+	logger        *zap.Logger
+	mode          Mode
+	probability   float64
+	threshold     threshold
+	precision     int
+	fromAttribute string
+	failClosed    bool
+}
+
+func newSamplerProcessor(cfg *Config, logger *zap.Logger) *samplerProcessor {
+	probability := cfg.SamplingPercentage / 100
+	return &samplerProcessor{
+		logger:        logger,
+		mode:          cfg.Mode,
+		probability:   probability,
+		threshold:     thresholdFromProbability(probability, cfg.SamplingPrecision),
+		precision:     cfg.SamplingPrecision,
+		fromAttribute: cfg.FromAttribute,
+		failClosed:    cfg.FailClosed,
+	}
+}
+
+// decide returns the threshold of an item, given the incoming threshold it was sampled with upstream if known.
+// The item is sampled if its randomness is not lower than the returned threshold.
+func (sp *samplerProcessor) decide(incoming threshold, known bool) threshold {
+	if !known {
+		return sp.threshold
+	}
+	t := sp.threshold
+	if sp.mode == ModeProportional {
+		t = thresholdFromProbability(incoming.probability()*sp.probability, sp.precision)
+	}
+	// The sampling probability of an item never increases, whatever the rounding of the thresholds.
+	return max(t, incoming)
+}
+
+// consistent returns whether the incoming threshold of an item sampled upstream is consistent with its
+// randomness. The inconsistent thresholds are erased, since the adjusted count they convey is wrong.
+func (sp *samplerProcessor) consistent(r randomness, incoming threshold) bool {
+	if incoming.shouldSample(r) {
+		return true
+	}
+	sp.logger.Debug("Erasing inconsistent sampling threshold", zap.Stringer("threshold", incoming))
+	return false
+}
+
+func (sp *samplerProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !sp.sampleSpan(span)
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// sampleSpan returns whether the span is sampled, recording its new threshold in its tracestate.
+// The randomness of a span is the explicit one of its tracestate, or the one of its trace ID.
+func (sp *samplerProcessor) sampleSpan(span ptrace.Span) bool {
+	ts := parseTraceState(span.TraceState().AsRaw())
+	r, ok := sp.spanRandomness(span, &ts)
+	if !ok {
+		return !sp.failClosed
+	}
+
+	incoming, known, modified := alwaysSample, false, false
+	if th, ok := ts.get(thKey); ok {
+		var err error
+		if incoming, err = parseThreshold(th); err != nil {
+			sp.logger.Debug("Erasing invalid sampling threshold", zap.Error(err))
+		} else {
+			known = sp.consistent(r, incoming)
+		}
+		if !known {
+			ts.remove(thKey)
+			modified = true
+		}
+	}
+
+	t := sp.decide(incoming, known)
+	if !t.shouldSample(r) {
+		return false
+	}
+	if (known && t != incoming) || (!known && t != alwaysSample) {
+		ts.set(thKey, t.String())
+		modified = true
+	}
+	if modified {
+		span.TraceState().FromRaw(ts.String())
+	}
+	return true
+}
+
+func (sp *samplerProcessor) spanRandomness(span ptrace.Span, ts *traceState) (randomness, bool) {
+	if rv, ok := ts.get(rvKey); ok {
+		r, err := parseRandomness(rv)
+		if err == nil {
+			return r, true
+		}
+		sp.logger.Debug("Ignoring invalid explicit randomness", zap.Error(err))
+	}
+	if span.TraceID().IsEmpty() {
+		sp.logger.Debug("Missing randomness of the span")
+		return 0, false
+	}
+	return randomnessFromTraceID(span.TraceID()), true
+}
+
+func (sp *samplerProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !sp.sampleLogRecord(lr)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// sampleLogRecord returns whether the log record is sampled, recording its new threshold in its attributes.
+func (sp *samplerProcessor) sampleLogRecord(lr plog.LogRecord) bool {
+	r, ok := sp.logRecordRandomness(lr)
+	if !ok {
+		return !sp.failClosed
+	}
+
+	incoming, known := alwaysSample, false
+	if th, ok := lr.Attributes().Get(thresholdAttribute); ok {
+		var err error
+		if incoming, err = parseThreshold(th.AsString()); err != nil {
+			sp.logger.Debug("Erasing invalid sampling threshold", zap.Error(err))
+		} else {
+			known = sp.consistent(r, incoming)
+		}
+		if !known {
+			lr.Attributes().Remove(thresholdAttribute)
+		}
+	}
+
+	t := sp.decide(incoming, known)
+	if !t.shouldSample(r) {
+		return false
+	}
+	if (known && t != incoming) || (!known && t != alwaysSample) {
+		lr.Attributes().PutStr(thresholdAttribute, t.String())
+	}
+	return true
+}
+
+// logRecordRandomness returns the randomness of a log record: its explicit randomness, the one of its
+// trace ID, or the hash of the configured attribute, in that order.
+func (sp *samplerProcessor) logRecordRandomness(lr plog.LogRecord) (randomness, bool) {
+	if rv, ok := lr.Attributes().Get(randomnessAttribute); ok {
+		r, err := parseRandomness(rv.AsString())
+		if err == nil {
+			return r, true
+		}
+		sp.logger.Debug("Ignoring invalid explicit randomness", zap.Error(err))
+	}
+	if !lr.TraceID().IsEmpty() {
+		return randomnessFromTraceID(lr.TraceID()), true
+	}
+	if sp.fromAttribute != "" {
+		if v, ok := lr.Attributes().Get(sp.fromAttribute); ok {
+			return randomnessFromString(v.AsString()), true
+		}
+	}
+	sp.logger.Debug("Missing randomness of the log record")
+	return 0, false
+}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestProcessor(percentage float64, mode Mode) *samplerProcessor {
+	return newSamplerProcessor(&Config{
+		SamplingPercentage: percentage,
+		Mode:               mode,
+		SamplingPrecision:  defaultSamplingPrecision,
+		FromAttribute:      "session.id",
+		FailClosed:         true,
+	}, zap.NewNop())
+}
+
+// traceID returns a trace ID whose randomness is the given value.
+func traceID(r uint64) pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[8:], r)
+	id[0] = 1
+	return id
+}
+
+func TestSampleSpans(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float64
+		mode       Mode
+		traceID    pcommon.TraceID
+		traceState string
+		sampled    bool
+		expected   string
+	}{
+		{
+			name:       "sampled",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0x80000000000000),
+			traceState: "vendor=value",
+			sampled:    true,
+			expected:   "ot=th:8,vendor=value",
+		},
+		{
+			name:       "not sampled",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0x7fffffffffffff),
+		},
+		{
+			name:       "all sampled",
+			percentage: 100,
+			mode:       ModeProportional,
+			traceID:    traceID(0),
+			traceState: "vendor=value",
+			sampled:    true,
+			expected:   "vendor=value",
+		},
+		{
+			name:       "none sampled",
+			percentage: 0,
+			mode:       ModeProportional,
+			traceID:    traceID(0xffffffffffffff),
+		},
+		{
+			name:       "explicit randomness",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0),
+			traceState: "ot=rv:c0000000000000",
+			sampled:    true,
+			expected:   "ot=rv:c0000000000000;th:8",
+		},
+		{
+			name:       "invalid explicit randomness",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0),
+			traceState: "ot=rv:c",
+		},
+		{
+			name:       "proportional",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0xe0000000000000),
+			traceState: "ot=th:8",
+			sampled:    true,
+			expected:   "ot=th:c",
+		},
+		{
+			name:       "proportional not sampled",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0xa0000000000000),
+			traceState: "ot=th:8",
+		},
+		{
+			name:       "equalizing",
+			percentage: 25,
+			mode:       ModeEqualizing,
+			traceID:    traceID(0xe0000000000000),
+			traceState: "ot=th:8",
+			sampled:    true,
+			expected:   "ot=th:c",
+		},
+		{
+			name:       "equalizing already sampled at a lower probability",
+			percentage: 50,
+			mode:       ModeEqualizing,
+			traceID:    traceID(0xe0000000000000),
+			traceState: "ot=th:c;p:x,vendor=value",
+			sampled:    true,
+			expected:   "ot=th:c;p:x,vendor=value",
+		},
+		{
+			name:       "inconsistent threshold",
+			percentage: 100,
+			mode:       ModeEqualizing,
+			traceID:    traceID(0x10000000000000),
+			traceState: "vendor=value,ot=th:8",
+			sampled:    true,
+			expected:   "vendor=value",
+		},
+		{
+			name:       "invalid threshold",
+			percentage: 50,
+			mode:       ModeProportional,
+			traceID:    traceID(0x90000000000000),
+			traceState: "ot=th:x",
+			sampled:    true,
+			expected:   "ot=th:8",
+		},
+		{
+			name:       "missing randomness",
+			percentage: 100,
+			mode:       ModeProportional,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(tt.traceID)
+			span.TraceState().FromRaw(tt.traceState)
+
+			out, err := newTestProcessor(tt.percentage, tt.mode).processTraces(context.Background(), td)
+			if !tt.sampled {
+				assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+				assert.Equal(t, 0, out.SpanCount())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, out.SpanCount())
+			assert.Equal(t, tt.expected, out.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().AsRaw())
+		})
+	}
+}
+
+func TestSampleSpansFailOpen(t *testing.T) {
+	sp := newTestProcessor(50, ModeProportional)
+	sp.failClosed = false
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	out, err := sp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.SpanCount())
+}
+
+func TestSampleSpansConsistently(t *testing.T) {
+	// The spans of a trace are all sampled or all dropped, and about the configured proportion of the
+	// traces is sampled.
+	sp := newTestProcessor(10, ModeProportional)
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	const numTraces = 10000
+	for i := uint64(0); i < numTraces; i++ {
+		// Spread the randomness evenly.
+		id := traceID(i * (maxAdjustedCount / numTraces))
+		for j := 0; j < 3; j++ {
+			spans.AppendEmpty().SetTraceID(id)
+		}
+	}
+	out, err := sp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	perTrace := map[pcommon.TraceID]int{}
+	spans = out.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		perTrace[spans.At(i).TraceID()]++
+		assert.Equal(t, "ot=th:e666", spans.At(i).TraceState().AsRaw())
+	}
+	for _, n := range perTrace {
+		assert.Equal(t, 3, n)
+	}
+	assert.InDelta(t, numTraces/10, len(perTrace), 2)
+}
+
+func TestSampleLogs(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       Mode
+		traceID    pcommon.TraceID
+		attributes map[string]any
+		sampled    bool
+		expected   map[string]any
+	}{
+		{
+			name:     "trace ID",
+			mode:     ModeProportional,
+			traceID:  traceID(0x80000000000000),
+			sampled:  true,
+			expected: map[string]any{"sampling.threshold": "8"},
+		},
+		{
+			name:    "trace ID not sampled",
+			mode:    ModeProportional,
+			traceID: traceID(0x10000000000000),
+		},
+		{
+			name:       "explicit randomness",
+			mode:       ModeProportional,
+			traceID:    traceID(0),
+			attributes: map[string]any{"sampling.randomness": "80000000000000"},
+			sampled:    true,
+			expected:   map[string]any{"sampling.randomness": "80000000000000", "sampling.threshold": "8"},
+		},
+		{
+			name:       "proportional",
+			mode:       ModeProportional,
+			traceID:    traceID(0xf0000000000000),
+			attributes: map[string]any{"sampling.threshold": "8"},
+			sampled:    true,
+			expected:   map[string]any{"sampling.threshold": "c"},
+		},
+		{
+			name:       "equalizing",
+			mode:       ModeEqualizing,
+			traceID:    traceID(0xf0000000000000),
+			attributes: map[string]any{"sampling.threshold": "8"},
+			sampled:    true,
+			expected:   map[string]any{"sampling.threshold": "8"},
+		},
+		{
+			name:       "attribute",
+			mode:       ModeProportional,
+			attributes: map[string]any{"session.id": "a"},
+			sampled:    true,
+			expected:   map[string]any{"session.id": "a", "sampling.threshold": "8"},
+		},
+		{
+			name:       "attribute not sampled",
+			mode:       ModeProportional,
+			attributes: map[string]any{"session.id": "b"},
+		},
+		{
+			name: "missing randomness",
+			mode: ModeProportional,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ld := plog.NewLogs()
+			lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.SetTraceID(tt.traceID)
+			require.NoError(t, lr.Attributes().FromRaw(tt.attributes))
+
+			out, err := newTestProcessor(50, tt.mode).processLogs(context.Background(), ld)
+			if !tt.sampled {
+				assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+				assert.Equal(t, 0, out.LogRecordCount())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, out.LogRecordCount())
+			assert.Equal(t, tt.expected, out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// The randomness and the thresholds of the consistent probability sampling are 56 bits values,
+// encoded with up to 14 hexadecimal digits, as specified by
+// https://opentelemetry.io/docs/specs/otel/trace/tracestate-probability-sampling/.
+const (
+	numHexDigits = 14
+	numBits      = numHexDigits * 4
+	// maxAdjustedCount is the adjusted count of the items sampled at the minimum probability.
+	maxAdjustedCount = 1 << numBits
+)
+
+// minSamplingProbability is the lowest sampling probability which can be expressed by a threshold.
+const minSamplingProbability = 1.0 / maxAdjustedCount
+
+// randomness is the 56 bits random value of an item, compared to the thresholds.
+type randomness uint64
+
+// randomnessFromTraceID returns the randomness of the items of a trace: the 56 least
+// significant bits of its ID.
+func randomnessFromTraceID(id pcommon.TraceID) randomness {
+	return randomness(binary.BigEndian.Uint64(id[8:]) & (maxAdjustedCount - 1))
+}
+
+// randomnessFromString returns the randomness of the items identified by a string value. The FNV-1a hash of
+// short values varies little in its high bits, so it is mixed with the finalizer of MurmurHash3.
+func randomnessFromString(s string) randomness {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return randomness(x & (maxAdjustedCount - 1))
+}
+
+// parseRandomness parses an explicit randomness value, which has exactly 14 hexadecimal digits.
+func parseRandomness(s string) (randomness, error) {
+	if len(s) != numHexDigits {
+		return 0, fmt.Errorf("invalid randomness %q", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid randomness %q", s)
+	}
+	return randomness(v), nil
+}
+
+// threshold is the rejection threshold of an item: it is sampled if its randomness is not lower
+// than the threshold. Its sampling probability is the proportion of the values not lower than it.
+type threshold uint64
+
+const (
+	alwaysSample threshold = 0
+	neverSample  threshold = maxAdjustedCount
+)
+
+// parseThreshold parses a threshold encoded with 1 to 14 hexadecimal digits, the digits left out
+// being trailing zeros.
+func parseThreshold(s string) (threshold, error) {
+	if s == "" || len(s) > numHexDigits {
+		return 0, fmt.Errorf("invalid threshold %q", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid threshold %q", s)
+	}
+	return threshold(v << (4 * (numHexDigits - len(s)))), nil
+}
+
+// String encodes the threshold without its trailing zeros. The never sampled threshold has no encoding.
+func (t threshold) String() string {
+	if t == alwaysSample {
+		return "0"
+	}
+	return strings.TrimRight(fmt.Sprintf("%014x", uint64(t)), "0")
+}
+
+// shouldSample returns whether the items with the given randomness are sampled.
+func (t threshold) shouldSample(r randomness) bool {
+	return uint64(r) >= uint64(t)
+}
+
+// probability returns the sampling probability of the threshold.
+func (t threshold) probability() float64 {
+	return float64(maxAdjustedCount-uint64(t)) / maxAdjustedCount
+}
+
+// thresholdFromProbability returns the threshold of the sampling probability, rounded to the given
+// number of significant hexadecimal digits. The probabilities lower than the minimum are never sampled.
+func thresholdFromProbability(p float64, precision int) threshold {
+	switch {
+	case p >= 1:
+		return alwaysSample
+	case p < minSamplingProbability:
+		return neverSample
+	}
+	// The leading zeros of the threshold are not significant digits.
+	for reject := 1 - p; reject*16 < 1 && precision < numHexDigits; reject *= 16 {
+		precision++
+	}
+	t := maxAdjustedCount - uint64(math.Round(p*maxAdjustedCount))
+	if shift := 4 * (numHexDigits - precision); shift > 0 {
+		rounded := (t + 1<<(shift-1)) >> shift << shift
+		if rounded >= maxAdjustedCount {
+			// Rounding up would not sample at all: round down instead.
+			rounded -= 1 << shift
+		}
+		t = rounded
+	}
+	return threshold(t)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestThresholdFromProbability(t *testing.T) {
+	// The examples of the specification.
+	tests := []struct {
+		probability float64
+		precision   int
+		expected    string
+	}{
+		{probability: 1, precision: 4, expected: "0"},
+		{probability: 0.5, precision: 4, expected: "8"},
+		{probability: 0.25, precision: 4, expected: "c"},
+		{probability: 1.0 / 3, precision: 4, expected: "aaab"},
+		{probability: 0.1, precision: 4, expected: "e666"},
+		{probability: 0.1, precision: 2, expected: "e6"},
+		{probability: 0.01, precision: 4, expected: "fd71"},
+		{probability: 0.999, precision: 3, expected: "00419"},
+		{probability: 1.0 / (1 << 52), precision: 14, expected: "fffffffffffff"},
+	}
+	for _, tt := range tests {
+		th := thresholdFromProbability(tt.probability, tt.precision)
+		assert.Equal(t, tt.expected, th.String(), "probability %g", tt.probability)
+	}
+
+	// Rounding up to the never sampled threshold rounds down instead.
+	assert.Equal(t, "ff", thresholdFromProbability(0.001, 2).String())
+	assert.Equal(t, neverSample, thresholdFromProbability(0, 4))
+	assert.Equal(t, neverSample, thresholdFromProbability(minSamplingProbability/2, 4))
+}
+
+func TestParseThreshold(t *testing.T) {
+	th, err := parseThreshold("8")
+	require.NoError(t, err)
+	assert.Equal(t, threshold(1<<55), th)
+	assert.InDelta(t, 0.5, th.probability(), 1e-12)
+	assert.Equal(t, "8", th.String())
+
+	th, err = parseThreshold("0")
+	require.NoError(t, err)
+	assert.Equal(t, alwaysSample, th)
+	assert.InDelta(t, 1, th.probability(), 1e-12)
+
+	th, err = parseThreshold("fffffffffffff")
+	require.NoError(t, err)
+	assert.Equal(t, "fffffffffffff", th.String())
+
+	for _, s := range []string{"", "g", "-1", "123456789012345"} {
+		_, err = parseThreshold(s)
+		assert.Errorf(t, err, "threshold %q", s)
+	}
+}
+
+func TestShouldSample(t *testing.T) {
+	th, err := parseThreshold("8")
+	require.NoError(t, err)
+	assert.True(t, th.shouldSample(randomness(1<<55)))
+	assert.True(t, th.shouldSample(randomness(maxAdjustedCount-1)))
+	assert.False(t, th.shouldSample(randomness(1<<55-1)))
+	assert.True(t, alwaysSample.shouldSample(0))
+	assert.False(t, neverSample.shouldSample(randomness(maxAdjustedCount-1)))
+}
+
+func TestRandomness(t *testing.T) {
+	id := pcommon.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde}
+	assert.Equal(t, randomness(0x123456789abcde), randomnessFromTraceID(id))
+
+	r, err := parseRandomness("123456789abcde")
+	require.NoError(t, err)
+	assert.Equal(t, randomness(0x123456789abcde), r)
+
+	for _, s := range []string{"", "123456789abcd", "123456789abcdef", "123456789abcdg"} {
+		_, err = parseRandomness(s)
+		assert.Errorf(t, err, "randomness %q", s)
+	}
+
+	// The randomness of short strings is evenly distributed.
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		if randomnessFromString(strconv.Itoa(i)) >= 1<<55 {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 5000, sampled, 200)
+}
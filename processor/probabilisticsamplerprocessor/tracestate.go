@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"strings"
+)
+
+const (
+	// otKey is the key of the OpenTelemetry entry of the W3C tracestate.
+	otKey = "ot"
+	// thKey and rvKey are the keys of the threshold and the randomness in the OpenTelemetry entry.
+	thKey = "th"
+	rvKey = "rv"
+)
+
+// traceState is a W3C tracestate whose OpenTelemetry entry is parsed.
+type traceState struct {
+	// entries are the entries of the tracestate other than the OpenTelemetry one.
+	entries []string
+	// otValues are the sub-keys and values of the OpenTelemetry entry, in order.
+	otValues [][2]string
+}
+
+// parseTraceState parses a W3C tracestate. The entries are kept as is, except for the OpenTelemetry one.
+func parseTraceState(raw string) traceState {
+	var ts traceState
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		value, ok := strings.CutPrefix(entry, otKey+"=")
+		if !ok {
+			ts.entries = append(ts.entries, entry)
+			continue
+		}
+		for _, kv := range strings.Split(value, ";") {
+			if k, v, ok := strings.Cut(kv, ":"); ok {
+				ts.otValues = append(ts.otValues, [2]string{k, v})
+			}
+		}
+	}
+	return ts
+}
+
+// get returns the value of a sub-key of the OpenTelemetry entry.
+func (ts *traceState) get(key string) (string, bool) {
+	for _, kv := range ts.otValues {
+		if kv[0] == key {
+			return kv[1], true
+		}
+	}
+	return "", false
+}
+
+// set sets the value of a sub-key of the OpenTelemetry entry.
+func (ts *traceState) set(key, value string) {
+	for i, kv := range ts.otValues {
+		if kv[0] == key {
+			ts.otValues[i][1] = value
+			return
+		}
+	}
+	ts.otValues = append(ts.otValues, [2]string{key, value})
+}
+
+// remove removes a sub-key of the OpenTelemetry entry.
+func (ts *traceState) remove(key string) {
+	for i, kv := range ts.otValues {
+		if kv[0] == key {
+			ts.otValues = append(ts.otValues[:i], ts.otValues[i+1:]...)
+			return
+		}
+	}
+}
+
+// String encodes the tracestate. The modified OpenTelemetry entry is the first one, as required by the
+// W3C specification.
+func (ts *traceState) String() string {
+	entries := make([]string, 0, len(ts.entries)+1)
+	if len(ts.otValues) > 0 {
+		values := make([]string, len(ts.otValues))
+		for i, kv := range ts.otValues {
+			values[i] = kv[0] + ":" + kv[1]
+		}
+		entries = append(entries, otKey+"="+strings.Join(values, ";"))
+	}
+	return strings.Join(append(entries, ts.entries...), ",")
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceState(t *testing.T) {
+	ts := parseTraceState("vendor=value, ot=rv:123456789abcde;th:8;p:x ,other=1")
+	rv, ok := ts.get(rvKey)
+	assert.True(t, ok)
+	assert.Equal(t, "123456789abcde", rv)
+	th, ok := ts.get(thKey)
+	assert.True(t, ok)
+	assert.Equal(t, "8", th)
+
+	// The OpenTelemetry entry moves to the front when it is modified.
+	ts.set(thKey, "c")
+	assert.Equal(t, "ot=rv:123456789abcde;th:c;p:x,vendor=value,other=1", ts.String())
+	ts.remove(thKey)
+	assert.Equal(t, "ot=rv:123456789abcde;p:x,vendor=value,other=1", ts.String())
+
+	ts = parseTraceState("vendor=value")
+	_, ok = ts.get(thKey)
+	assert.False(t, ok)
+	ts.set(thKey, "8")
+	assert.Equal(t, "ot=th:8,vendor=value", ts.String())
+	ts.remove(thKey)
+	assert.Equal(t, "vendor=value", ts.String())
+
+	ts = parseTraceState("")
+	assert.Equal(t, "", ts.String())
+}
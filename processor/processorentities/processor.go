@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorentities // import "go.opentelemetry.io/collector/processor/processorentities"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/internal"
+)
+
+// Entities is a processor that can consume entity events.
+type Entities = internal.Entities
+
+// CreateEntitiesFunc is the equivalent of Factory.CreateEntities().
+type CreateEntitiesFunc = internal.CreateEntitiesFunc
+
+// WithEntities overrides the default "error not supported" implementation for CreateEntities and the default "undefined" stability level.
+func WithEntities(createEntities CreateEntitiesFunc, sl component.StabilityLevel) processor.FactoryOption {
+	return internal.WithEntities(createEntities, sl)
+}
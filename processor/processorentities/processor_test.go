@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorentities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func TestNewFactoryWithEntities(t *testing.T) {
+	var testType = component.MustNewType("test")
+	defaultCfg := struct{}{}
+	factory := processor.NewFactory(
+		testType,
+		func() component.Config { return &defaultCfg },
+		WithEntities(createEntities, component.StabilityLevelAlpha),
+	)
+	assert.EqualValues(t, testType, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+
+	assert.Equal(t, component.StabilityLevelAlpha, factory.EntitiesProcessorStability())
+	_, err := factory.CreateEntitiesProcessor(context.Background(), processor.Settings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+}
+
+var nopInstance = &nopProcessor{
+	Consumer: consumertest.NewNop(),
+}
+
+// nopProcessor stores consumed traces and metrics for testing purposes.
+type nopProcessor struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+}
+
+func createEntities(context.Context, processor.Settings, component.Config, consumerentities.Entities) (Entities, error) {
+	return nopInstance, nil
+}
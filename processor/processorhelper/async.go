@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// ErrAsyncProcessorShutdown is returned when data is sent to an asynchronous processor being shut down.
+var ErrAsyncProcessorShutdown = errors.New("processor is shut down")
+
+// FlushFunc emits all the data buffered by an asynchronous processor through its emitter.
+// It is called on shutdown, once no data is buffered anymore, to drain the processor.
+type FlushFunc func(context.Context) error
+
+// asyncProcessor implements the lifecycle shared by the asynchronous processors: the data is buffered
+// until the shutdown begins, the buffered data is then flushed before the processor is shut down.
+type asyncProcessor struct {
+	start    component.StartFunc
+	shutdown component.ShutdownFunc
+	flush    FlushFunc
+
+	// mu is held for reading while data is buffered, so that the flush waits for the data being buffered.
+	mu       sync.RWMutex
+	stopping bool
+}
+
+func newAsyncProcessor(bs *baseSettings, flushFunc FlushFunc) *asyncProcessor {
+	return &asyncProcessor{
+		start:    bs.StartFunc,
+		shutdown: bs.ShutdownFunc,
+		flush:    flushFunc,
+	}
+}
+
+func (ap *asyncProcessor) Start(ctx context.Context, host component.Host) error {
+	return ap.start.Start(ctx, host)
+}
+
+// buffer calls bufferFunc unless the processor is being shut down. The acks of the data are held until the data is
+// emitted, or released right away if it is not buffered.
+func (ap *asyncProcessor) buffer(ctx context.Context, bufferFunc func() error) error {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	if ap.stopping {
+		return ErrAsyncProcessorShutdown
+	}
+	acks := ack.FromContext(ctx)
+	ack.Retain(acks...)
+	err := bufferFunc()
+	switch {
+	case errors.Is(err, ErrSkipProcessingData):
+		// The skipped data is handled as if it was emitted.
+		ack.Release(nil, acks...)
+	case err != nil:
+		ack.Release(err, acks...)
+	}
+	return err
+}
+
+// Shutdown stops buffering data, flushes the buffered data, then shuts down the processor.
+// The errors of the flush are returned along with the ones of the shutdown function.
+func (ap *asyncProcessor) Shutdown(ctx context.Context) error {
+	ap.mu.Lock()
+	wasStopping := ap.stopping
+	ap.stopping = true
+	ap.mu.Unlock()
+
+	var err error
+	if !wasStopping {
+		err = ap.flush(ctx)
+	}
+	return errors.Join(err, ap.shutdown.Shutdown(ctx))
+}
+
+// emitter records the data emitted by an asynchronous processor, and logs the errors of the
+// next consumer, which cannot be returned to the senders of the data anymore. Once the data is
+// emitted, it releases the hold of the acks of the emit context, see releaseAcks.
+type emitter struct {
+	obsrep *ObsReport
+	logger *zap.Logger
+}
+
+func newEmitter(set processor.Settings) (emitter, error) {
+	obsrep, err := newObsReport(ObsReportSettings{
+		ProcessorID:             set.ID,
+		ProcessorCreateSettings: set,
+	})
+	if err != nil {
+		return emitter{}, err
+	}
+	return emitter{obsrep: obsrep, logger: set.Logger}, nil
+}
+
+// releaseAcks releases a hold of the acks of the emitted data with the result of the next consumer.
+func (e *emitter) releaseAcks(ctx context.Context, err error) {
+	ack.Release(err, ack.FromContext(ctx)...)
+}
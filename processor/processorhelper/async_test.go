@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// bufferingTracesProcessor buffers the traces until they are emitted explicitly or flushed.
+type bufferingTracesProcessor struct {
+	emitter *TracesEmitter
+	mu      sync.Mutex
+	buffer  []ptrace.Traces
+}
+
+func (bp *bufferingTracesProcessor) bufferTraces(_ context.Context, td ptrace.Traces) error {
+	if td.SpanCount() == 0 {
+		return ErrSkipProcessingData
+	}
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	bp.buffer = append(bp.buffer, td)
+	return nil
+}
+
+func (bp *bufferingTracesProcessor) flush(ctx context.Context) error {
+	bp.mu.Lock()
+	buffer := bp.buffer
+	bp.buffer = nil
+	bp.mu.Unlock()
+	var errs error
+	for _, td := range buffer {
+		errs = errors.Join(errs, bp.emitter.EmitTraces(ctx, td))
+	}
+	return errs
+}
+
+func TestNewAsyncTracesProcessor(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	emitter, err := NewTracesEmitter(processortest.NewNopSettings(), sink)
+	require.NoError(t, err)
+	bp := &bufferingTracesProcessor{emitter: emitter}
+	tp, err := NewAsyncTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, emitter, bp.bufferTraces, bp.flush,
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+
+	assert.False(t, tp.Capabilities().MutatesData)
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, bp.flush(context.Background()))
+	assert.Equal(t, 2, sink.SpanCount())
+
+	// The traces buffered when the shutdown begins are drained, the next ones are refused.
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.Equal(t, 5, sink.SpanCount())
+	assert.ErrorIs(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), ErrAsyncProcessorShutdown)
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestNewAsyncTracesProcessor_NilRequiredFields(t *testing.T) {
+	flush := func(context.Context) error { return nil }
+	emitter, err := NewTracesEmitter(processortest.NewNopSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	_, err = NewAsyncTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, nil, newTestBufferTraces(nil), flush)
+	assert.Error(t, err)
+	_, err = NewAsyncTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, emitter, nil, flush)
+	assert.Error(t, err)
+	_, err = NewAsyncTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, emitter, newTestBufferTraces(nil), nil)
+	assert.Error(t, err)
+}
+
+func TestAsyncTracesProcessorAcks(t *testing.T) {
+	errExport := errors.New("export failed")
+	next := &failingTracesConsumer{err: errExport}
+	emitter, err := NewTracesEmitter(processortest.NewNopSettings(), next)
+	require.NoError(t, err)
+	var buffered []ptrace.Traces
+	var acks []*ack.Ack
+	tp, err := NewAsyncTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, emitter,
+		func(ctx context.Context, td ptrace.Traces) error {
+			if td.SpanCount() == 0 {
+				return ErrSkipProcessingData
+			}
+			buffered = append(buffered, td)
+			acks = append(acks, ack.FromContext(ctx)...)
+			return nil
+		},
+		func(ctx context.Context) error {
+			td := ptrace.NewTraces()
+			for _, b := range buffered {
+				b.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+			}
+			return emitter.EmitTraces(ack.NewContext(ctx, acks...), td)
+		})
+	require.NoError(t, err)
+
+	// The acks of the buffered traces are held until the traces are emitted.
+	buffered1 := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, tp.ConsumeTraces(ack.NewContext(context.Background(), buffered1), testdata.GenerateTraces(1)))
+	ack.Release(nil, buffered1)
+	skipped := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, tp.ConsumeTraces(ack.NewContext(context.Background(), skipped), ptrace.NewTraces()))
+	ack.Release(nil, skipped)
+	<-skipped.Done()
+	require.NoError(t, skipped.Err())
+	select {
+	case <-buffered1.Done():
+		t.Fatal("ack completed before the traces were emitted")
+	default:
+	}
+
+	assert.ErrorIs(t, tp.Shutdown(context.Background()), errExport)
+	<-buffered1.Done()
+	assert.Equal(t, errExport, buffered1.Err())
+}
+
+func TestNewAsyncTracesProcessor_ShutdownErrors(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	errShutdown := errors.New("shutdown failed")
+	emitter, err := NewTracesEmitter(processortest.NewNopSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	tp, err := NewAsyncTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, emitter, newTestBufferTraces(nil),
+		func(context.Context) error { return errFlush },
+		WithShutdown(func(context.Context) error { return errShutdown }))
+	require.NoError(t, err)
+
+	err = tp.Shutdown(context.Background())
+	assert.ErrorIs(t, err, errFlush)
+	assert.ErrorIs(t, err, errShutdown)
+}
+
+func TestAsyncTracesProcessorTelemetry(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt componenttest.TestTelemetry) {
+		set := processortest.NewNopSettings()
+		set.ID = processorID
+		set.TelemetrySettings = tt.TelemetrySettings()
+
+		next := &failingTracesConsumer{err: errors.New("my_error")}
+		emitter, err := NewTracesEmitter(set, next)
+		require.NoError(t, err)
+		bp := &bufferingTracesProcessor{emitter: emitter}
+		tp, err := NewAsyncTracesProcessor(context.Background(), set, &testTracesCfg, emitter, bp.bufferTraces, bp.flush)
+		require.NoError(t, err)
+
+		require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+		require.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+		assert.Error(t, bp.flush(context.Background()))
+		next.err = nil
+		require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+		require.NoError(t, tp.Shutdown(context.Background()))
+
+		require.NoError(t, tt.CheckProcessorTraces(3, 2, 0, 0))
+	})
+}
+
+func TestAsyncMetricsProcessor(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	emitter, err := NewMetricsEmitter(processortest.NewNopSettings(), sink)
+	require.NoError(t, err)
+	var buffered []pmetric.Metrics
+	mp, err := NewAsyncMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, emitter,
+		func(_ context.Context, md pmetric.Metrics) error {
+			buffered = append(buffered, md)
+			return nil
+		},
+		func(ctx context.Context) error {
+			for _, md := range buffered {
+				if err := emitter.EmitMetrics(ctx, md); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	assert.Equal(t, 0, sink.DataPointCount())
+	require.NoError(t, mp.Shutdown(context.Background()))
+	assert.Equal(t, 4, sink.DataPointCount())
+	assert.ErrorIs(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), ErrAsyncProcessorShutdown)
+}
+
+func TestAsyncLogsProcessor(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	emitter, err := NewLogsEmitter(processortest.NewNopSettings(), sink)
+	require.NoError(t, err)
+	var buffered []plog.Logs
+	lp, err := NewAsyncLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, emitter,
+		func(_ context.Context, ld plog.Logs) error {
+			buffered = append(buffered, ld)
+			return nil
+		},
+		func(ctx context.Context) error {
+			for _, ld := range buffered {
+				if err := emitter.EmitLogs(ctx, ld); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	assert.Equal(t, 0, sink.LogRecordCount())
+	require.NoError(t, lp.Shutdown(context.Background()))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.ErrorIs(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), ErrAsyncProcessorShutdown)
+}
+
+func newTestBufferTraces(retError error) BufferTracesFunc {
+	return func(context.Context, ptrace.Traces) error {
+		return retError
+	}
+}
+
+type failingTracesConsumer struct {
+	err error
+}
+
+func (fc *failingTracesConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (fc *failingTracesConsumer) ConsumeTraces(context.Context, ptrace.Traces) error {
+	return fc.err
+}
@@ -7,10 +7,11 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -39,14 +40,13 @@ func NewLogsProcessor(
 		return nil, errors.New("nil logsFunc")
 	}
 
-	eventOptions := spanAttributes(set.ID)
+	ps := newProcessSpan(set, obsmetrics.ProcessLogsOperationSuffix)
 	bs := fromOptions(options)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
-		span := trace.SpanFromContext(ctx)
-		span.AddEvent("Start processing.", eventOptions)
+		ctx, span := ps.start(ctx)
 		var err error
 		ld, err = logsFunc(ctx, ld)
-		span.AddEvent("End processing.", eventOptions)
+		ps.end(span, err)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
@@ -65,3 +65,94 @@ func NewLogsProcessor(
 		Logs:         logsConsumer,
 	}, nil
 }
+
+// BufferLogsFunc buffers the incoming logs of an asynchronous processor, which emits them later on its own schedule
+// through a LogsEmitter. If error is returned then the logs are refused. It MUST not call the next component.
+// The acks of the context, see ack.FromContext, are held until the logs are emitted: the logs must be emitted with a
+// context carrying them, see ack.NewContext, or the acks released with ack.ErrDropped if the logs are dropped.
+type BufferLogsFunc func(context.Context, plog.Logs) error
+
+// LogsEmitter sends the logs emitted by an asynchronous processor to the next component.
+type LogsEmitter struct {
+	emitter
+	next consumer.Logs
+}
+
+// NewLogsEmitter creates a LogsEmitter sending the logs to nextConsumer.
+func NewLogsEmitter(set processor.Settings, nextConsumer consumer.Logs) (*LogsEmitter, error) {
+	e, err := newEmitter(set)
+	if err != nil {
+		return nil, err
+	}
+	return &LogsEmitter{emitter: e, next: nextConsumer}, nil
+}
+
+// EmitLogs sends the logs to the next component, and records the log records as accepted or refused.
+// The error of the next component is logged, then returned to let the processor retry or drop the logs.
+// The acks of ctx are released with the result: to emit buffered logs in several parts, the acks must be retained
+// with ack.Retain before emitting each part but the last.
+func (e *LogsEmitter) EmitLogs(ctx context.Context, ld plog.Logs) error {
+	count := ld.LogRecordCount()
+	err := e.next.ConsumeLogs(ctx, ld)
+	e.releaseAcks(ctx, err)
+	if err != nil {
+		e.obsrep.LogsRefused(ctx, count)
+		e.logger.Warn("Failed to emit logs", zap.Error(err), zap.Int("log_records", count))
+		return err
+	}
+	e.obsrep.LogsAccepted(ctx, count)
+	return nil
+}
+
+type asyncLogsProcessor struct {
+	*asyncProcessor
+	consumer.Logs
+}
+
+// NewAsyncLogsProcessor creates a processor.Logs for a processor buffering the logs with bufferFunc and emitting
+// them on its own schedule through the LogsEmitter. On shutdown, the processor stops accepting logs, flushes the
+// buffered logs with flushFunc, then calls the shutdown function set with WithShutdown.
+// The log records of the logs skipped with ErrSkipProcessingData are recorded as dropped.
+func NewAsyncLogsProcessor(
+	_ context.Context,
+	set processor.Settings,
+	_ component.Config,
+	emitter *LogsEmitter,
+	bufferFunc BufferLogsFunc,
+	flushFunc FlushFunc,
+	options ...Option,
+) (processor.Logs, error) {
+	if emitter == nil {
+		return nil, errors.New("nil emitter")
+	}
+	if bufferFunc == nil {
+		return nil, errors.New("nil bufferFunc")
+	}
+	if flushFunc == nil {
+		return nil, errors.New("nil flushFunc")
+	}
+	ps := newProcessSpan(set, obsmetrics.ProcessLogsOperationSuffix)
+	bs := fromOptions(options)
+	ap := newAsyncProcessor(bs, flushFunc)
+	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		count := ld.LogRecordCount()
+		ctx, span := ps.start(ctx)
+		err := ap.buffer(ctx, func() error {
+			return bufferFunc(ctx, ld)
+		})
+		ps.end(span, err)
+		if errors.Is(err, ErrSkipProcessingData) {
+			emitter.obsrep.LogsDropped(ctx, count)
+			return nil
+		}
+		return err
+	}, bs.consumerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &asyncLogsProcessor{
+		asyncProcessor: ap,
+		Logs:           logsConsumer,
+	}, nil
+}
@@ -7,10 +7,11 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -39,14 +40,13 @@ func NewMetricsProcessor(
 		return nil, errors.New("nil metricsFunc")
 	}
 
-	eventOptions := spanAttributes(set.ID)
+	ps := newProcessSpan(set, obsmetrics.ProcessMetricsOperationSuffix)
 	bs := fromOptions(options)
 	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
-		span := trace.SpanFromContext(ctx)
-		span.AddEvent("Start processing.", eventOptions)
+		ctx, span := ps.start(ctx)
 		var err error
 		md, err = metricsFunc(ctx, md)
-		span.AddEvent("End processing.", eventOptions)
+		ps.end(span, err)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
@@ -65,3 +65,94 @@ func NewMetricsProcessor(
 		Metrics:      metricsConsumer,
 	}, nil
 }
+
+// BufferMetricsFunc buffers the incoming metrics of an asynchronous processor, which emits them later on its own schedule
+// through a MetricsEmitter. If error is returned then the metrics are refused. It MUST not call the next component.
+// The acks of the context, see ack.FromContext, are held until the metrics are emitted: the metrics must be emitted with a
+// context carrying them, see ack.NewContext, or the acks released with ack.ErrDropped if the metrics are dropped.
+type BufferMetricsFunc func(context.Context, pmetric.Metrics) error
+
+// MetricsEmitter sends the metrics emitted by an asynchronous processor to the next component.
+type MetricsEmitter struct {
+	emitter
+	next consumer.Metrics
+}
+
+// NewMetricsEmitter creates a MetricsEmitter sending the metrics to nextConsumer.
+func NewMetricsEmitter(set processor.Settings, nextConsumer consumer.Metrics) (*MetricsEmitter, error) {
+	e, err := newEmitter(set)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsEmitter{emitter: e, next: nextConsumer}, nil
+}
+
+// EmitMetrics sends the metrics to the next component, and records the metric points as accepted or refused.
+// The error of the next component is logged, then returned to let the processor retry or drop the metrics.
+// The acks of ctx are released with the result: to emit buffered metrics in several parts, the acks must be retained
+// with ack.Retain before emitting each part but the last.
+func (e *MetricsEmitter) EmitMetrics(ctx context.Context, md pmetric.Metrics) error {
+	count := md.DataPointCount()
+	err := e.next.ConsumeMetrics(ctx, md)
+	e.releaseAcks(ctx, err)
+	if err != nil {
+		e.obsrep.MetricsRefused(ctx, count)
+		e.logger.Warn("Failed to emit metrics", zap.Error(err), zap.Int("metric_points", count))
+		return err
+	}
+	e.obsrep.MetricsAccepted(ctx, count)
+	return nil
+}
+
+type asyncMetricsProcessor struct {
+	*asyncProcessor
+	consumer.Metrics
+}
+
+// NewAsyncMetricsProcessor creates a processor.Metrics for a processor buffering the metrics with bufferFunc and emitting
+// them on its own schedule through the MetricsEmitter. On shutdown, the processor stops accepting metrics, flushes the
+// buffered metrics with flushFunc, then calls the shutdown function set with WithShutdown.
+// The metric points of the metrics skipped with ErrSkipProcessingData are recorded as dropped.
+func NewAsyncMetricsProcessor(
+	_ context.Context,
+	set processor.Settings,
+	_ component.Config,
+	emitter *MetricsEmitter,
+	bufferFunc BufferMetricsFunc,
+	flushFunc FlushFunc,
+	options ...Option,
+) (processor.Metrics, error) {
+	if emitter == nil {
+		return nil, errors.New("nil emitter")
+	}
+	if bufferFunc == nil {
+		return nil, errors.New("nil bufferFunc")
+	}
+	if flushFunc == nil {
+		return nil, errors.New("nil flushFunc")
+	}
+	ps := newProcessSpan(set, obsmetrics.ProcessMetricsOperationSuffix)
+	bs := fromOptions(options)
+	ap := newAsyncProcessor(bs, flushFunc)
+	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		count := md.DataPointCount()
+		ctx, span := ps.start(ctx)
+		err := ap.buffer(ctx, func() error {
+			return bufferFunc(ctx, md)
+		})
+		ps.end(span, err)
+		if errors.Is(err, ErrSkipProcessingData) {
+			emitter.obsrep.MetricsDropped(ctx, count)
+			return nil
+		}
+		return err
+	}, bs.consumerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &asyncMetricsProcessor{
+		asyncProcessor: ap,
+		Metrics:        metricsConsumer,
+	}, nil
+}
@@ -6,14 +6,19 @@
 package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
 
 import (
+	"context"
 	"errors"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper/internal/metadata"
 )
 
 // ErrSkipProcessingData is a sentinel value to indicate when traces or metrics should intentionally be dropped
@@ -68,6 +73,36 @@ func fromOptions(options []Option) *baseSettings {
 	return opts
 }
 
-func spanAttributes(id component.ID) trace.EventOption {
-	return trace.WithAttributes(attribute.String(obsmetrics.ProcessorKey, id.String()))
+// processSpan traces the processing of the data by a processor, as a hop of the pipeline.
+type processSpan struct {
+	tracer     trace.Tracer
+	name       string
+	attributes trace.SpanStartEventOption
+}
+
+func newProcessSpan(set processor.Settings, operationSuffix string) processSpan {
+	telemetry := set.TelemetrySettings
+	if telemetry.TracerProvider == nil {
+		// The processors created with partial settings, e.g. in tests, are not traced.
+		telemetry.TracerProvider = noop.NewTracerProvider()
+	}
+	return processSpan{
+		tracer:     metadata.Tracer(telemetry),
+		name:       obsmetrics.ProcessorPrefix + set.ID.String() + operationSuffix,
+		attributes: trace.WithAttributes(attribute.String(obsmetrics.ProcessorKey, set.ID.String())),
+	}
+}
+
+// start starts the span as a child of the span of the previous hop. The returned context
+// is passed to the next consumer, so that the span is the parent of the next hop.
+func (ps processSpan) start(ctx context.Context) (context.Context, trace.Span) {
+	return ps.tracer.Start(ctx, ps.name, ps.attributes)
+}
+
+// end ends the span once the data is processed, before it is passed to the next consumer.
+func (ps processSpan) end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrSkipProcessingData) {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
 }
@@ -7,10 +7,11 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -39,14 +40,13 @@ func NewTracesProcessor(
 		return nil, errors.New("nil tracesFunc")
 	}
 
-	eventOptions := spanAttributes(set.ID)
+	ps := newProcessSpan(set, obsmetrics.ProcessTraceDataOperationSuffix)
 	bs := fromOptions(options)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
-		span := trace.SpanFromContext(ctx)
-		span.AddEvent("Start processing.", eventOptions)
+		ctx, span := ps.start(ctx)
 		var err error
 		td, err = tracesFunc(ctx, td)
-		span.AddEvent("End processing.", eventOptions)
+		ps.end(span, err)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
@@ -66,3 +66,94 @@ func NewTracesProcessor(
 		Traces:       traceConsumer,
 	}, nil
 }
+
+// BufferTracesFunc buffers the incoming traces of an asynchronous processor, which emits them later on its own schedule
+// through a TracesEmitter. If error is returned then the traces are refused. It MUST not call the next component.
+// The acks of the context, see ack.FromContext, are held until the traces are emitted: the traces must be emitted with a
+// context carrying them, see ack.NewContext, or the acks released with ack.ErrDropped if the traces are dropped.
+type BufferTracesFunc func(context.Context, ptrace.Traces) error
+
+// TracesEmitter sends the traces emitted by an asynchronous processor to the next component.
+type TracesEmitter struct {
+	emitter
+	next consumer.Traces
+}
+
+// NewTracesEmitter creates a TracesEmitter sending the traces to nextConsumer.
+func NewTracesEmitter(set processor.Settings, nextConsumer consumer.Traces) (*TracesEmitter, error) {
+	e, err := newEmitter(set)
+	if err != nil {
+		return nil, err
+	}
+	return &TracesEmitter{emitter: e, next: nextConsumer}, nil
+}
+
+// EmitTraces sends the traces to the next component, and records the spans as accepted or refused.
+// The error of the next component is logged, then returned to let the processor retry or drop the traces.
+// The acks of ctx are released with the result: to emit buffered traces in several parts, the acks must be retained
+// with ack.Retain before emitting each part but the last.
+func (e *TracesEmitter) EmitTraces(ctx context.Context, td ptrace.Traces) error {
+	count := td.SpanCount()
+	err := e.next.ConsumeTraces(ctx, td)
+	e.releaseAcks(ctx, err)
+	if err != nil {
+		e.obsrep.TracesRefused(ctx, count)
+		e.logger.Warn("Failed to emit traces", zap.Error(err), zap.Int("spans", count))
+		return err
+	}
+	e.obsrep.TracesAccepted(ctx, count)
+	return nil
+}
+
+type asyncTracesProcessor struct {
+	*asyncProcessor
+	consumer.Traces
+}
+
+// NewAsyncTracesProcessor creates a processor.Traces for a processor buffering the traces with bufferFunc and emitting
+// them on its own schedule through the TracesEmitter. On shutdown, the processor stops accepting traces, flushes the
+// buffered traces with flushFunc, then calls the shutdown function set with WithShutdown.
+// The spans of the traces skipped with ErrSkipProcessingData are recorded as dropped.
+func NewAsyncTracesProcessor(
+	_ context.Context,
+	set processor.Settings,
+	_ component.Config,
+	emitter *TracesEmitter,
+	bufferFunc BufferTracesFunc,
+	flushFunc FlushFunc,
+	options ...Option,
+) (processor.Traces, error) {
+	if emitter == nil {
+		return nil, errors.New("nil emitter")
+	}
+	if bufferFunc == nil {
+		return nil, errors.New("nil bufferFunc")
+	}
+	if flushFunc == nil {
+		return nil, errors.New("nil flushFunc")
+	}
+	ps := newProcessSpan(set, obsmetrics.ProcessTraceDataOperationSuffix)
+	bs := fromOptions(options)
+	ap := newAsyncProcessor(bs, flushFunc)
+	tracesConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		count := td.SpanCount()
+		ctx, span := ps.start(ctx)
+		err := ap.buffer(ctx, func() error {
+			return bufferFunc(ctx, td)
+		})
+		ps.end(span, err)
+		if errors.Is(err, ErrSkipProcessingData) {
+			emitter.obsrep.TracesDropped(ctx, count)
+			return nil
+		}
+		return err
+	}, bs.consumerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &asyncTracesProcessor{
+		asyncProcessor: ap,
+		Traces:         tracesConsumer,
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -67,3 +68,29 @@ func newTestTProcessor(retError error) ProcessTracesFunc {
 		return td, retError
 	}
 }
+
+func TestTracesProcessorSpans(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt componenttest.TestTelemetry) {
+		set := processortest.NewNopSettings()
+		set.ID = processorID
+		set.TelemetrySettings = tt.TelemetrySettings()
+		last, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, consumertest.NewNop(), newTestTProcessor(errors.New("my_error")))
+		require.NoError(t, err)
+		first, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, last, newTestTProcessor(nil))
+		require.NoError(t, err)
+
+		ctx, parent := tt.TelemetrySettings().TracerProvider.Tracer("test").Start(context.Background(), "receive")
+		assert.Error(t, first.ConsumeTraces(ctx, ptrace.NewTraces()))
+		parent.End()
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 3)
+		// The processing spans end before the data is passed to the next hop.
+		assert.Equal(t, "processor/fakeProcessor/traces", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, "processor/fakeProcessor/traces", spans[1].Name())
+		assert.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Parent().SpanID())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	})
+}
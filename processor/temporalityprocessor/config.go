@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package temporalityprocessor // import "go.opentelemetry.io/collector/processor/temporalityprocessor"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errNonPositiveMaxStaleness = errors.New("'max_staleness' must be positive")
+
+// Temporality is the aggregation temporality of the sums and histograms emitted by the processor.
+type Temporality string
+
+const (
+	// TemporalityCumulative emits cumulative sums and histograms, detecting the resets of the cumulative streams.
+	TemporalityCumulative Temporality = "cumulative"
+	// TemporalityDelta emits delta sums and histograms.
+	TemporalityDelta Temporality = "delta"
+)
+
+var _ encoding.TextUnmarshaler = (*Temporality)(nil)
+
+// UnmarshalText unmarshalls text to a Temporality.
+func (t *Temporality) UnmarshalText(text []byte) error {
+	switch str := Temporality(text); str {
+	case TemporalityCumulative, TemporalityDelta:
+		*t = str
+		return nil
+	default:
+		return fmt.Errorf("invalid temporality: %q", str)
+	}
+}
+
+// Config defines the configuration for the Temporality processor.
+type Config struct {
+	// Temporality is the aggregation temporality of the emitted sums and histograms.
+	Temporality Temporality `mapstructure:"temporality"`
+
+	// MaxStaleness is how long the state of a stream is kept without receiving points.
+	// A stream receiving points after its state was dropped starts again as a new stream.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxStaleness <= 0 {
+		return errNonPositiveMaxStaleness
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package temporalityprocessor // import "go.opentelemetry.io/collector/processor/temporalityprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/temporalityprocessor/internal/metadata"
+)
+
+const defaultMaxStaleness = 5 * time.Minute
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the Temporality processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability))
+}
+
+// createDefaultConfig creates the default configuration for the processor, which emits cumulative metrics.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Temporality:  TemporalityCumulative,
+		MaxStaleness: defaultMaxStaleness,
+	}
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	tp := newTemporalityProcessor(cfg.(*Config))
+	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer,
+		tp.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
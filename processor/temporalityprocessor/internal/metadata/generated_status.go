@@ -0,0 +1,16 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("temporality")
+	ScopeName = "go.opentelemetry.io/collector/processor/temporalityprocessor"
+)
+
+const (
+	MetricsStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package temporalityprocessor // import "go.opentelemetry.io/collector/processor/temporalityprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/exporter/exportertemporality"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// converter converts the temporality of the sums and histograms, keeping the state of their streams.
+type converter interface {
+	ConvertMetrics(md pmetric.Metrics)
+}
+
+type temporalityProcessor struct {
+	converter converter
+}
+
+// newTemporalityProcessor returns a processor converting the delta streams to cumulative and normalizing the
+// cumulative streams, or converting the cumulative streams to delta, depending on the target temporality.
+func newTemporalityProcessor(cfg *Config) *temporalityProcessor {
+	convCfg := exportertemporality.Config{MaxStale: cfg.MaxStaleness}
+	if cfg.Temporality == TemporalityDelta {
+		return &temporalityProcessor{converter: exportertemporality.NewCumulativeToDelta(convCfg)}
+	}
+	return &temporalityProcessor{
+		converter: exportertemporality.NewDeltaToCumulative(convCfg, exportertemporality.WithNormalizeCumulative()),
+	}
+}
+
+func (tp *temporalityProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	tp.converter.ConvertMetrics(md)
+	return md, nil
+}
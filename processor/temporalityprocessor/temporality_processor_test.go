@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package temporalityprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// processorStart is the start time of the processor under test, the timestamps
+// of the points are in seconds relatively to it.
+var processorStart time.Time
+
+func newTestProcessor(temporality Temporality) *temporalityProcessor {
+	processorStart = time.Now()
+	return newTemporalityProcessor(&Config{Temporality: temporality, MaxStaleness: time.Minute})
+}
+
+func ts(sec int) pcommon.Timestamp {
+	if sec < 0 {
+		return 0
+	}
+	return pcommon.NewTimestampFromTime(processorStart.Add(time.Duration(sec) * time.Second))
+}
+
+type point struct {
+	start, ts int
+	value     int64
+}
+
+func newSum(temporality pmetric.AggregationTemporality, monotonic bool, points ...point) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(temporality)
+	sum.SetIsMonotonic(monotonic)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(p.start))
+		dp.SetTimestamp(ts(p.ts))
+		dp.SetIntValue(p.value)
+	}
+	return md
+}
+
+func process(t *testing.T, tp *temporalityProcessor, md pmetric.Metrics) pmetric.Metrics {
+	out, err := tp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return out
+}
+
+func TestProcessSum(t *testing.T) {
+	tests := []struct {
+		name        string
+		temporality Temporality
+		in          pmetric.AggregationTemporality
+		out         pmetric.AggregationTemporality
+		monotonic   bool
+		points      []point
+		expected    []point
+	}{
+		{
+			name:        "delta to cumulative",
+			temporality: TemporalityCumulative,
+			in:          pmetric.AggregationTemporalityDelta,
+			out:         pmetric.AggregationTemporalityCumulative,
+			monotonic:   true,
+			points:      []point{{0, 10, 3}, {10, 20, 2}, {20, 15, 7}, {20, 30, 5}},
+			expected:    []point{{0, 10, 3}, {0, 20, 5}, {0, 30, 10}},
+		},
+		{
+			name:        "delta to cumulative without start timestamp",
+			temporality: TemporalityCumulative,
+			in:          pmetric.AggregationTemporalityDelta,
+			out:         pmetric.AggregationTemporalityCumulative,
+			points:      []point{{-1, 10, 3}, {-1, 20, -2}},
+			expected:    []point{{10, 10, 3}, {10, 20, 1}},
+		},
+		{
+			name:        "cumulative to delta",
+			temporality: TemporalityDelta,
+			in:          pmetric.AggregationTemporalityCumulative,
+			out:         pmetric.AggregationTemporalityDelta,
+			monotonic:   true,
+			points:      []point{{-100, 10, 3}, {-100, 20, 5}, {-100, 30, 10}, {-100, 30, 10}},
+			expected:    []point{{10, 20, 2}, {20, 30, 5}},
+		},
+		{
+			name:        "cumulative to delta of stream started after the processor",
+			temporality: TemporalityDelta,
+			in:          pmetric.AggregationTemporalityCumulative,
+			out:         pmetric.AggregationTemporalityDelta,
+			monotonic:   true,
+			points:      []point{{5, 10, 3}, {5, 20, 5}},
+			expected:    []point{{5, 10, 3}, {10, 20, 2}},
+		},
+		{
+			name:        "cumulative to delta with signaled reset",
+			temporality: TemporalityDelta,
+			in:          pmetric.AggregationTemporalityCumulative,
+			out:         pmetric.AggregationTemporalityDelta,
+			monotonic:   true,
+			points:      []point{{-100, 10, 3}, {-100, 20, 5}, {25, 30, 4}},
+			expected:    []point{{10, 20, 2}, {25, 30, 4}},
+		},
+		{
+			name:        "cumulative to delta with unsignaled reset",
+			temporality: TemporalityDelta,
+			in:          pmetric.AggregationTemporalityCumulative,
+			out:         pmetric.AggregationTemporalityDelta,
+			monotonic:   true,
+			points:      []point{{-100, 10, 3}, {-100, 20, 5}, {-100, 30, 1}},
+			expected:    []point{{10, 20, 2}, {20, 30, 1}},
+		},
+		{
+			name:        "cumulative to delta of non monotonic sum",
+			temporality: TemporalityDelta,
+			in:          pmetric.AggregationTemporalityCumulative,
+			out:         pmetric.AggregationTemporalityDelta,
+			points:      []point{{-100, 10, 3}, {-100, 20, 5}, {-100, 30, 1}},
+			expected:    []point{{10, 20, 2}, {20, 30, -4}},
+		},
+		{
+			name:        "cumulative normalization",
+			temporality: TemporalityCumulative,
+			in:          pmetric.AggregationTemporalityCumulative,
+			out:         pmetric.AggregationTemporalityCumulative,
+			monotonic:   true,
+			points:      []point{{-1, 10, 3}, {-1, 20, 5}, {-1, 30, 1}, {-1, 25, 1}, {-1, 40, 2}, {35, 50, 1}},
+			expected:    []point{{10, 10, 3}, {10, 20, 5}, {20, 30, 1}, {20, 40, 2}, {35, 50, 1}},
+		},
+		{
+			name:        "delta passthrough",
+			temporality: TemporalityDelta,
+			in:          pmetric.AggregationTemporalityDelta,
+			out:         pmetric.AggregationTemporalityDelta,
+			monotonic:   true,
+			points:      []point{{0, 10, 3}, {10, 20, 2}, {10, 20, 2}},
+			expected:    []point{{0, 10, 3}, {10, 20, 2}, {10, 20, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp := newTestProcessor(tt.temporality)
+			var got []point
+			for _, p := range tt.points {
+				out := process(t, tp, newSum(tt.in, tt.monotonic, p))
+				if out.ResourceMetrics().Len() == 0 {
+					continue
+				}
+				sum := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+				assert.Equal(t, tt.out, sum.AggregationTemporality())
+				require.Equal(t, 1, sum.DataPoints().Len())
+				dp := sum.DataPoints().At(0)
+				got = append(got, point{
+					start: int(dp.StartTimestamp().AsTime().Sub(processorStart) / time.Second),
+					ts:    int(dp.Timestamp().AsTime().Sub(processorStart) / time.Second),
+					value: dp.IntValue(),
+				})
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestProcessSumStreams(t *testing.T) {
+	tp := newTestProcessor(TemporalityCumulative)
+
+	md := newSum(pmetric.AggregationTemporalityDelta, true, point{0, 10, 1}, point{0, 10, 2})
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().PutStr("code", "500")
+	md = process(t, tp, md)
+
+	md = newSum(pmetric.AggregationTemporalityDelta, true, point{10, 20, 1}, point{10, 20, 2})
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().PutStr("code", "500")
+	md = process(t, tp, md)
+
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(2), dps.At(0).IntValue())
+	assert.Equal(t, int64(4), dps.At(1).IntValue())
+}
+
+func TestProcessUntouchedMetrics(t *testing.T) {
+	tp := newTestProcessor(TemporalityDelta)
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().SetCount(1)
+	eh := ms.AppendEmpty().SetEmptyExponentialHistogram()
+	eh.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	eh.DataPoints().AppendEmpty().SetCount(1)
+	expected := pmetric.NewMetrics()
+	md.CopyTo(expected)
+
+	assert.Equal(t, expected, process(t, tp, md))
+}
+
+type histogramPoint struct {
+	start, ts int
+	buckets   []uint64
+	sum       float64
+	min, max  float64
+}
+
+func newHistogram(temporality pmetric.AggregationTemporality, p histogramPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	hist := m.SetEmptyHistogram()
+	hist.SetAggregationTemporality(temporality)
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(ts(p.start))
+	dp.SetTimestamp(ts(p.ts))
+	dp.ExplicitBounds().FromRaw([]float64{1, 10})
+	dp.BucketCounts().FromRaw(p.buckets)
+	var count uint64
+	for _, b := range p.buckets {
+		count += b
+	}
+	dp.SetCount(count)
+	dp.SetSum(p.sum)
+	dp.SetMin(p.min)
+	dp.SetMax(p.max)
+	return md
+}
+
+func histogramDataPoints(md pmetric.Metrics) pmetric.HistogramDataPointSlice {
+	if md.ResourceMetrics().Len() == 0 {
+		return pmetric.NewHistogramDataPointSlice()
+	}
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+}
+
+func TestProcessHistogramDeltaToCumulative(t *testing.T) {
+	tp := newTestProcessor(TemporalityCumulative)
+
+	process(t, tp, newHistogram(pmetric.AggregationTemporalityDelta, histogramPoint{0, 10, []uint64{1, 2, 0}, 6, 0.5, 5}))
+	md := process(t, tp, newHistogram(pmetric.AggregationTemporalityDelta, histogramPoint{10, 20, []uint64{0, 1, 1}, 14, 2, 12}))
+
+	dps := histogramDataPoints(md)
+	require.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts().AsRaw())
+	assert.InDelta(t, 20, dp.Sum(), 0)
+	assert.InDelta(t, 0.5, dp.Min(), 0)
+	assert.InDelta(t, 12, dp.Max(), 0)
+
+	// A change of the buckets starts a new stream.
+	md = newHistogram(pmetric.AggregationTemporalityDelta, histogramPoint{20, 30, []uint64{1, 0, 0}, 0.5, 0.5, 0.5})
+	histogramDataPoints(md).At(0).ExplicitBounds().FromRaw([]float64{1, 5})
+	md = process(t, tp, md)
+	dp = histogramDataPoints(md).At(0)
+	assert.Equal(t, ts(20), dp.StartTimestamp())
+	assert.Equal(t, uint64(1), dp.Count())
+}
+
+func TestProcessHistogramCumulativeToDelta(t *testing.T) {
+	tp := newTestProcessor(TemporalityDelta)
+
+	md := process(t, tp, newHistogram(pmetric.AggregationTemporalityCumulative, histogramPoint{-100, 10, []uint64{1, 2, 0}, 6, 0.5, 5}))
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = process(t, tp, newHistogram(pmetric.AggregationTemporalityCumulative, histogramPoint{-100, 20, []uint64{1, 3, 1}, 20, 0.5, 12}))
+	dps := histogramDataPoints(md)
+	require.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().AggregationTemporality())
+	assert.Equal(t, ts(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, []uint64{0, 1, 1}, dp.BucketCounts().AsRaw())
+	assert.InDelta(t, 14, dp.Sum(), 0)
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+
+	// A decrease of a bucket is an unsignaled reset.
+	md = process(t, tp, newHistogram(pmetric.AggregationTemporalityCumulative, histogramPoint{-100, 30, []uint64{2, 0, 0}, 1, 0.5, 0.5}))
+	dp = histogramDataPoints(md).At(0)
+	assert.Equal(t, ts(20), dp.StartTimestamp())
+	assert.Equal(t, []uint64{2, 0, 0}, dp.BucketCounts().AsRaw())
+	assert.True(t, dp.HasMin())
+}
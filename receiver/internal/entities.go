@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/receiver/internal"
+
+import "go.opentelemetry.io/collector/component"
+
+// Entities receiver receives entity events.
+// Its purpose is to translate data from any format to the collector's internal entity events format.
+// EntitiesReceiver feeds a consumerentities.Entities with data.
+//
+// For example, it could be a Kubernetes watcher which translates the changes of the pods into pentity.Entities.
+type Entities interface {
+	component.Component
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/consumer/consumerprofiles"
 )
 
@@ -53,6 +54,14 @@ type Factory interface {
 	// ProfilesReceiverStability gets the stability level of the ProfilesReceiver.
 	ProfilesReceiverStability() component.StabilityLevel
 
+	// CreateEntitiesReceiver creates an EntitiesReceiver based on this config.
+	// If the receiver type does not support entities or if the config is not valid
+	// an error will be returned instead. `nextConsumer` is never nil.
+	CreateEntitiesReceiver(ctx context.Context, set Settings, cfg component.Config, nextConsumer consumerentities.Entities) (Entities, error)
+
+	// EntitiesReceiverStability gets the stability level of the EntitiesReceiver.
+	EntitiesReceiverStability() component.StabilityLevel
+
 	unexportedFactoryFunc()
 }
 
@@ -119,6 +128,9 @@ func (f CreateLogsFunc) CreateLogsReceiver(
 // CreateProfilesFunc is the equivalent of Factory.CreateProfiles.
 type CreateProfilesFunc func(context.Context, Settings, component.Config, consumerprofiles.Profiles) (Profiles, error)
 
+// CreateEntitiesFunc is the equivalent of Factory.CreateEntities.
+type CreateEntitiesFunc func(context.Context, Settings, component.Config, consumerentities.Entities) (Entities, error)
+
 // CreateProfilesReceiver implements Factory.CreateProfilesReceiver().
 func (f CreateProfilesFunc) CreateProfilesReceiver(
 	ctx context.Context,
@@ -131,6 +143,18 @@ func (f CreateProfilesFunc) CreateProfilesReceiver(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// CreateEntitiesReceiver implements Factory.CreateEntitiesReceiver().
+func (f CreateEntitiesFunc) CreateEntitiesReceiver(
+	ctx context.Context,
+	set Settings,
+	cfg component.Config,
+	nextConsumer consumerentities.Entities) (Entities, error) {
+	if f == nil {
+		return nil, component.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
 type factory struct {
 	cfgType component.Type
 	component.CreateDefaultConfigFunc
@@ -142,6 +166,8 @@ type factory struct {
 	logsStabilityLevel component.StabilityLevel
 	CreateProfilesFunc
 	profilesStabilityLevel component.StabilityLevel
+	CreateEntitiesFunc
+	entitiesStabilityLevel component.StabilityLevel
 }
 
 func (f *factory) Type() component.Type {
@@ -166,6 +192,10 @@ func (f *factory) ProfilesReceiverStability() component.StabilityLevel {
 	return f.profilesStabilityLevel
 }
 
+func (f *factory) EntitiesReceiverStability() component.StabilityLevel {
+	return f.entitiesStabilityLevel
+}
+
 // WithTraces overrides the default "error not supported" implementation for CreateTracesReceiver and the default "undefined" stability level.
 func WithTraces(createTracesReceiver CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -198,6 +228,14 @@ func WithProfiles(createProfilesReceiver CreateProfilesFunc, sl component.Stabil
 	})
 }
 
+// WithEntities overrides the default "error not supported" implementation for CreateEntitiesReceiver and the default "undefined" stability level.
+func WithEntities(createEntitiesReceiver CreateEntitiesFunc, sl component.StabilityLevel) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.entitiesStabilityLevel = sl
+		o.CreateEntitiesFunc = createEntitiesReceiver
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver // import "go.opentelemetry.io/collector/receiver/loadgenreceiver"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// AttributesConfig configures the attributes of the generated items.
+type AttributesConfig struct {
+	// Count is the number of attributes of each item.
+	Count int `mapstructure:"count"`
+
+	// Cardinality is the number of distinct values of each attribute.
+	Cardinality int `mapstructure:"cardinality"`
+
+	// ValueSize is the size in bytes of the attribute values.
+	ValueSize int `mapstructure:"value_size"`
+}
+
+// Config defines the configuration of the load generator receiver.
+type Config struct {
+	// Rate is the number of items, i.e. spans, data points or log records, generated per second for each signal.
+	// It is a maximum, the generation is slowed down when the pipeline does not keep up.
+	Rate int `mapstructure:"rate"`
+
+	// BatchSize is the number of items sent to the pipeline at once.
+	BatchSize int `mapstructure:"batch_size"`
+
+	// Duration stops the generation after the given time, zero generates items until the receiver is shut down.
+	Duration time.Duration `mapstructure:"duration"`
+
+	// Resources is the number of distinct resources the items are generated for.
+	Resources int `mapstructure:"resources"`
+
+	// Attributes configures the attributes of the generated items.
+	Attributes AttributesConfig `mapstructure:"attributes"`
+
+	// BodySize is the size in bytes of the body of the generated log records.
+	BodySize int `mapstructure:"body_size"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Rate <= 0 {
+		return errors.New("rate must be positive")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.New("batch_size must be positive")
+	}
+	if cfg.Duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+	if cfg.Resources <= 0 {
+		return errors.New("resources must be positive")
+	}
+	if cfg.Attributes.Count < 0 {
+		return errors.New("attributes::count must not be negative")
+	}
+	if cfg.Attributes.Count > 0 && cfg.Attributes.Cardinality <= 0 {
+		return errors.New("attributes::cardinality must be positive")
+	}
+	if cfg.Attributes.ValueSize < 0 {
+		return errors.New("attributes::value_size must not be negative")
+	}
+	if cfg.BodySize < 0 {
+		return errors.New("body_size must not be negative")
+	}
+	return nil
+}
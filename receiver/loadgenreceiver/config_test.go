@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Rate:      5000,
+			BatchSize: 500,
+			Duration:  10 * time.Minute,
+			Resources: 10,
+			Attributes: AttributesConfig{
+				Count:       8,
+				Cardinality: 1000,
+				ValueSize:   32,
+			},
+			BodySize: 1024,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		errMsg string
+	}{
+		{name: "no rate", modify: func(cfg *Config) { cfg.Rate = 0 }, errMsg: "rate must be positive"},
+		{name: "no batch size", modify: func(cfg *Config) { cfg.BatchSize = 0 }, errMsg: "batch_size must be positive"},
+		{name: "negative duration", modify: func(cfg *Config) { cfg.Duration = -time.Second }, errMsg: "duration must not be negative"},
+		{name: "no resources", modify: func(cfg *Config) { cfg.Resources = 0 }, errMsg: "resources must be positive"},
+		{name: "negative attributes", modify: func(cfg *Config) { cfg.Attributes.Count = -1 }, errMsg: "attributes::count must not be negative"},
+		{name: "no cardinality", modify: func(cfg *Config) { cfg.Attributes.Cardinality = 0 }, errMsg: "attributes::cardinality must be positive"},
+		{name: "negative value size", modify: func(cfg *Config) { cfg.Attributes.ValueSize = -1 }, errMsg: "attributes::value_size must not be negative"},
+		{name: "negative body size", modify: func(cfg *Config) { cfg.BodySize = -1 }, errMsg: "body_size must not be negative"},
+		{name: "no attributes", modify: func(cfg *Config) { cfg.Attributes = AttributesConfig{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := component.ValidateConfig(cfg)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package loadgenreceiver generates synthetic traces, metrics and logs at a configured rate,
+// to load test the pipelines and exporters of a collector.
+package loadgenreceiver // import "go.opentelemetry.io/collector/receiver/loadgenreceiver"
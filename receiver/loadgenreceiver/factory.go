@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver // import "go.opentelemetry.io/collector/receiver/loadgenreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/loadgenreceiver/internal/metadata"
+)
+
+// NewFactory returns a receiver.Factory for the load generator receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, metadata.TracesStability),
+		receiver.WithMetrics(createMetrics, metadata.MetricsStability),
+		receiver.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Rate:      1000,
+		BatchSize: 100,
+		Resources: 1,
+		Attributes: AttributesConfig{
+			Count:       5,
+			Cardinality: 10,
+			ValueSize:   16,
+		},
+		BodySize: 256,
+	}
+}
+
+// createTraces creates a receiver generating traces.
+func createTraces(_ context.Context, set receiver.Settings, cfg component.Config, nextConsumer consumer.Traces) (receiver.Traces, error) {
+	g := newGenerator(cfg.(*Config))
+	return newLoadgenReceiver(cfg.(*Config), set, component.DataTypeTraces, func(ctx context.Context, n int) (int, error) {
+		td := g.traces(n)
+		return td.SpanCount(), nextConsumer.ConsumeTraces(ctx, td)
+	})
+}
+
+// createMetrics creates a receiver generating metrics.
+func createMetrics(_ context.Context, set receiver.Settings, cfg component.Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
+	g := newGenerator(cfg.(*Config))
+	return newLoadgenReceiver(cfg.(*Config), set, component.DataTypeMetrics, func(ctx context.Context, n int) (int, error) {
+		md := g.metrics(n)
+		return md.DataPointCount(), nextConsumer.ConsumeMetrics(ctx, md)
+	})
+}
+
+// createLogs creates a receiver generating logs.
+func createLogs(_ context.Context, set receiver.Settings, cfg component.Config, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	g := newGenerator(cfg.(*Config))
+	return newLoadgenReceiver(cfg.(*Config), set, component.DataTypeLogs, func(ctx context.Context, n int) (int, error) {
+		ld := g.logs(n)
+		return ld.LogRecordCount(), nextConsumer.ConsumeLogs(ctx, ld)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver // import "go.opentelemetry.io/collector/receiver/loadgenreceiver"
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	scopeName  = "go.opentelemetry.io/collector/receiver/loadgenreceiver"
+	itemName   = "loadgen"
+	metricName = "loadgen.value"
+)
+
+// generator generates the batches of items. The items are spread over the resources and their attribute values
+// cycle through the configured cardinality, so the number of distinct series is deterministic.
+// A generator is used by a single goroutine.
+type generator struct {
+	cfg *Config
+	// seq is the number of items generated so far.
+	seq uint64
+	// attrValues are the distinct values of the attributes.
+	attrValues []string
+	body       string
+	now        func() time.Time
+}
+
+func newGenerator(cfg *Config) *generator {
+	g := &generator{
+		cfg:  cfg,
+		body: strings.Repeat("x", cfg.BodySize),
+		now:  time.Now,
+	}
+	if cfg.Attributes.Count > 0 {
+		g.attrValues = make([]string, cfg.Attributes.Cardinality)
+		for i := range g.attrValues {
+			g.attrValues[i] = padValue("value-"+strconv.Itoa(i), cfg.Attributes.ValueSize)
+		}
+	}
+	return g
+}
+
+// padValue pads or truncates a value to the given size.
+func padValue(value string, size int) string {
+	if len(value) >= size {
+		return value[:size]
+	}
+	return value + strings.Repeat("x", size-len(value))
+}
+
+// batch distributes n items over the resources, calling newResource for each resource with items
+// and add for each item.
+func (g *generator) batch(n int, newResource func(pcommon.Resource), add func(uint64)) {
+	resources := min(n, g.cfg.Resources)
+	for r := 0; r < resources; r++ {
+		res := pcommon.NewResource()
+		res.Attributes().PutStr("service.name", itemName)
+		res.Attributes().PutStr("service.instance.id", strconv.Itoa(int((g.seq+uint64(r))%uint64(g.cfg.Resources))))
+		newResource(res)
+		for i := r; i < n; i += resources {
+			add(g.seq + uint64(i))
+		}
+	}
+	g.seq += uint64(n)
+}
+
+// putAttributes adds the configured attributes of the item with the given sequence number.
+func (g *generator) putAttributes(attrs pcommon.Map, seq uint64) {
+	attrs.EnsureCapacity(g.cfg.Attributes.Count)
+	for i := 0; i < g.cfg.Attributes.Count; i++ {
+		attrs.PutStr("attr."+strconv.Itoa(i), g.attrValues[(seq+uint64(i))%uint64(len(g.attrValues))])
+	}
+}
+
+func (g *generator) traces(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	now := pcommon.NewTimestampFromTime(g.now())
+	var spans ptrace.SpanSlice
+	g.batch(n, func(res pcommon.Resource) {
+		rs := td.ResourceSpans().AppendEmpty()
+		res.MoveTo(rs.Resource())
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(scopeName)
+		spans = ss.Spans()
+	}, func(seq uint64) {
+		span := spans.AppendEmpty()
+		var traceID [16]byte
+		binary.BigEndian.PutUint64(traceID[8:], seq+1)
+		var spanID [8]byte
+		binary.BigEndian.PutUint64(spanID[:], seq+1)
+		span.SetTraceID(traceID)
+		span.SetSpanID(spanID)
+		span.SetName(itemName)
+		span.SetKind(ptrace.SpanKindInternal)
+		span.SetStartTimestamp(now)
+		span.SetEndTimestamp(now)
+		g.putAttributes(span.Attributes(), seq)
+	})
+	return td
+}
+
+func (g *generator) metrics(n int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := pcommon.NewTimestampFromTime(g.now())
+	var points pmetric.NumberDataPointSlice
+	g.batch(n, func(res pcommon.Resource) {
+		rm := md.ResourceMetrics().AppendEmpty()
+		res.MoveTo(rm.Resource())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(metricName)
+		points = m.SetEmptyGauge().DataPoints()
+	}, func(seq uint64) {
+		dp := points.AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntValue(int64(seq))
+		g.putAttributes(dp.Attributes(), seq)
+	})
+	return md
+}
+
+func (g *generator) logs(n int) plog.Logs {
+	ld := plog.NewLogs()
+	now := pcommon.NewTimestampFromTime(g.now())
+	var records plog.LogRecordSlice
+	g.batch(n, func(res pcommon.Resource) {
+		rl := ld.ResourceLogs().AppendEmpty()
+		res.MoveTo(rl.Resource())
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+		records = sl.LogRecords()
+	}, func(seq uint64) {
+		lr := records.AppendEmpty()
+		lr.SetTimestamp(now)
+		lr.SetObservedTimestamp(now)
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.Body().SetStr(g.body)
+		g.putAttributes(lr.Attributes(), seq)
+	})
+	return ld
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newTestGenerator() *generator {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Resources = 3
+	cfg.Attributes = AttributesConfig{Count: 2, Cardinality: 4, ValueSize: 10}
+	cfg.BodySize = 5
+	g := newGenerator(cfg)
+	g.now = func() time.Time { return time.Unix(100, 0) }
+	return g
+}
+
+func TestGeneratorTraces(t *testing.T) {
+	g := newTestGenerator()
+	series := map[string]bool{}
+	spanIDs := map[pcommon.SpanID]bool{}
+	for _, n := range []int{10, 20} {
+		td := g.traces(n)
+		assert.Equal(t, n, td.SpanCount())
+		require.Equal(t, 3, td.ResourceSpans().Len())
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			instance, ok := rs.Resource().Attributes().Get("service.instance.id")
+			require.True(t, ok)
+			spans := rs.ScopeSpans().At(0).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				span := spans.At(j)
+				assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(100, 0)), span.StartTimestamp())
+				spanIDs[span.SpanID()] = true
+				attr, ok := span.Attributes().Get("attr.0")
+				require.True(t, ok)
+				assert.Len(t, attr.Str(), 10)
+				series[instance.Str()+"/"+attr.Str()] = true
+			}
+		}
+	}
+	// The spans are unique, while the number of series is bounded by the resources and the cardinality.
+	assert.Len(t, spanIDs, 30)
+	assert.LessOrEqual(t, len(series), 3*4)
+}
+
+func TestGeneratorMetrics(t *testing.T) {
+	g := newTestGenerator()
+	md := g.metrics(2)
+	assert.Equal(t, 2, md.DataPointCount())
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	m := md.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, metricName, m.Name())
+	dp := m.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntValue())
+	assert.Equal(t, map[string]any{"attr.0": "value-1xxx", "attr.1": "value-2xxx"}, dp.Attributes().AsRaw())
+}
+
+func TestGeneratorLogs(t *testing.T) {
+	g := newTestGenerator()
+	g.cfg.Attributes.Count = 0
+	ld := g.logs(4)
+	assert.Equal(t, 4, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "xxxxx", lr.Body().Str())
+	assert.Equal(t, 0, lr.Attributes().Len())
+}
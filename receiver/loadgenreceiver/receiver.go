@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver // import "go.opentelemetry.io/collector/receiver/loadgenreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// format is the format reported by the observability of the receiver for the generated data.
+const format = "loadgen"
+
+// consumeFunc generates a batch of n items and sends it to the pipeline, returning the number of items sent.
+type consumeFunc func(ctx context.Context, n int) (int, error)
+
+// loadgenReceiver sends the batches generated by a consumeFunc to the pipeline at the configured rate.
+type loadgenReceiver struct {
+	cfg      *Config
+	logger   *zap.Logger
+	dataType component.DataType
+	obsrecv  *receiverhelper.ObsReport
+	consume  consumeFunc
+
+	cancel     context.CancelFunc
+	shutdownWG sync.WaitGroup
+}
+
+func newLoadgenReceiver(cfg *Config, set receiver.Settings, dataType component.DataType, consume consumeFunc) (*loadgenReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &loadgenReceiver{
+		cfg:      cfg,
+		logger:   set.Logger,
+		dataType: dataType,
+		obsrecv:  obsrecv,
+		consume:  consume,
+	}, nil
+}
+
+// Start starts generating the items.
+func (r *loadgenReceiver) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	if r.cfg.Duration > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), r.cfg.Duration)
+	}
+	r.cancel = cancel
+	r.logger.Info("Starting the load generation",
+		zap.Stringer("data_type", r.dataType), zap.Int("rate", r.cfg.Rate), zap.Int("batch_size", r.cfg.BatchSize))
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		r.generate(ctx)
+	}()
+	return nil
+}
+
+// generate sends a batch at each tick of the interval matching the rate, until the context is done.
+// The ticks missed while the pipeline is busy are dropped, lowering the rate.
+func (r *loadgenReceiver) generate(ctx context.Context) {
+	interval := max(time.Duration(r.cfg.BatchSize)*time.Second/time.Duration(r.cfg.Rate), 1)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.send(ctx)
+		}
+	}
+}
+
+func (r *loadgenReceiver) send(ctx context.Context) {
+	var opCtx context.Context
+	switch r.dataType {
+	case component.DataTypeTraces:
+		opCtx = r.obsrecv.StartTracesOp(ctx)
+	case component.DataTypeMetrics:
+		opCtx = r.obsrecv.StartMetricsOp(ctx)
+	default:
+		opCtx = r.obsrecv.StartLogsOp(ctx)
+	}
+
+	n, err := r.consume(opCtx, r.cfg.BatchSize)
+	if err != nil {
+		r.logger.Debug("Failed to send the generated data", zap.Error(err))
+	}
+
+	switch r.dataType {
+	case component.DataTypeTraces:
+		r.obsrecv.EndTracesOp(opCtx, format, n, err)
+	case component.DataTypeMetrics:
+		r.obsrecv.EndMetricsOp(opCtx, format, n, err)
+	default:
+		r.obsrecv.EndLogsOp(opCtx, format, n, err)
+	}
+}
+
+// Shutdown stops generating the items.
+func (r *loadgenReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.shutdownWG.Wait()
+	return nil
+}
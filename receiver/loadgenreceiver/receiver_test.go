@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadgenreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestReceiverRate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rate = 1000
+	cfg.BatchSize = 10
+	sink := new(consumertest.LogsSink)
+	r, err := NewFactory().CreateLogsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() >= 50 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+	for _, ld := range sink.AllLogs() {
+		assert.Equal(t, 10, ld.LogRecordCount())
+	}
+}
+
+func TestReceiverDuration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rate = 1000
+	cfg.BatchSize = 10
+	cfg.Duration = 50 * time.Millisecond
+	sink := new(consumertest.TracesSink)
+	r, err := NewFactory().CreateTracesReceiver(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	// The generation stops at the end of the duration.
+	time.Sleep(100 * time.Millisecond)
+	count := sink.SpanCount()
+	assert.Positive(t, count)
+	assert.LessOrEqual(t, count, 100)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, count, sink.SpanCount())
+}
+
+func TestReceiverShutdownNotStarted(t *testing.T) {
+	r, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopSettings(), createDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
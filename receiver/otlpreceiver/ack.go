@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// startAck wraps the next consumers to reply to the requests only once their data is acknowledged,
+// if the receiver is configured to wait for the acknowledgments.
+func (r *otlpReceiver) startAck(host component.Host) error {
+	if r.cfg.Ack == nil {
+		return nil
+	}
+	w, err := r.cfg.Ack.ToWaiter(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	if next := r.nextTraces; next != nil {
+		r.nextTraces, err = consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+			return w.Consume(ctx, func(ctx context.Context) error {
+				return next.ConsumeTraces(ctx, td)
+			})
+		}, consumer.WithCapabilities(next.Capabilities()))
+		if err != nil {
+			return err
+		}
+	}
+	if next := r.nextMetrics; next != nil {
+		r.nextMetrics, err = consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+			return w.Consume(ctx, func(ctx context.Context) error {
+				return next.ConsumeMetrics(ctx, md)
+			})
+		}, consumer.WithCapabilities(next.Capabilities()))
+		if err != nil {
+			return err
+		}
+	}
+	if next := r.nextLogs; next != nil {
+		r.nextLogs, err = consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+			return w.Consume(ctx, func(ctx context.Context) error {
+				return next.ConsumeLogs(ctx, ld)
+			})
+		}, consumer.WithCapabilities(next.Capabilities()))
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+type testAckExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (testAckExtension) NewAck(_ context.Context, mode ack.Mode) (*ack.Ack, error) {
+	return ack.New(mode, nil), nil
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+var ackID = component.MustNewID("ack")
+
+func TestAck(t *testing.T) {
+	t.Run("direct", func(t *testing.T) { testAck(t, false) })
+	// The data of the coalesced requests is acknowledged with their batch.
+	t.Run("coalesce", func(t *testing.T) { testAck(t, true) })
+}
+
+func testAck(t *testing.T, coalesce bool) {
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.Ack = &ack.ReceiverConfig{Extension: ackID, Mode: ack.ModeExported}
+	cfg.Coalesce.Enabled = coalesce
+	cfg.Coalesce.MaxLatency = time.Millisecond
+
+	// The consumer hands the traces over asynchronously, acknowledging them after a delay.
+	var ackErr error
+	next, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		release := ack.Hold(ctx)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			release(ackErr)
+		}()
+		return nil
+	})
+	require.NoError(t, err)
+	set := receivertest.NewNopSettings()
+	r, err := newOtlpReceiver(cfg, &set)
+	require.NoError(t, err)
+	r.registerTraceConsumer(next)
+	host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{ackID: testAckExtension{}}}
+	require.NoError(t, r.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cc.Close()) })
+	client := ptraceotlp.NewGRPCClient(cc)
+
+	doHTTPRequest(t, "http://"+httpAddr+defaultTracesURLPath, "", pbContentType, buf, http.StatusOK)
+	_, err = client.Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+
+	// The requests whose data is not acknowledged are retried by the clients.
+	ackErr = errors.New("export failed")
+	doHTTPRequest(t, "http://"+httpAddr+defaultTracesURLPath, "", pbContentType, buf, http.StatusServiceUnavailable)
+	_, err = client.Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestAckExtensionNotFound(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.HTTP = nil
+	cfg.Ack = &ack.ReceiverConfig{Extension: ackID}
+	set := receivertest.NewNopSettings()
+	r, err := newOtlpReceiver(cfg, &set)
+	require.NoError(t, err)
+	r.registerTraceConsumer(consumertest.NewNop())
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), `ack extension "ack" not found`)
+}
@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+const (
+	defaultCaptureMaxPayloads   = 10
+	defaultCaptureSamplingRatio = 1.0
+
+	// redactedValue replaces the values of the redacted attributes.
+	redactedValue = "<redacted>"
+)
+
+// RedactFunc redacts a captured payload of the given signal, encoded with the given content type, before it is
+// written. It returns false to skip the payload.
+type RedactFunc func(dataType component.DataType, contentType string, payload []byte) ([]byte, bool)
+
+// CaptureConfig configures the capture of the raw payloads of the received requests, to diagnose the clients
+// sending malformed or unexpected data. It is meant for debugging only: the payloads may hold sensitive data.
+type CaptureConfig struct {
+	// Enabled captures the payloads of the requests.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxPayloads is the number of payloads captured, the capture stops once it is reached.
+	MaxPayloads int `mapstructure:"max_payloads"`
+
+	// SamplingRatio is the ratio of the requests captured, in (0, 1].
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+
+	// InvalidOnly only captures the HTTP requests whose payload cannot be decoded.
+	InvalidOnly bool `mapstructure:"invalid_only"`
+
+	// Directory is the directory the payloads are written to, one file per payload. The payloads are logged at the
+	// debug level if empty.
+	Directory string `mapstructure:"directory"`
+
+	// RedactedAttributes are the keys of the attributes of the resources, scopes and items whose values are
+	// replaced before the payloads are written. The payloads which cannot be decoded are not captured then.
+	RedactedAttributes []string `mapstructure:"redacted_attributes"`
+
+	// Redact, if set, redacts the payloads after the attributes, e.g. in the distributions embedding the receiver.
+	Redact RedactFunc `mapstructure:"-"`
+}
+
+func (cfg *CaptureConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MaxPayloads <= 0 {
+		return errors.New("capture max_payloads must be positive")
+	}
+	if cfg.SamplingRatio <= 0 || cfg.SamplingRatio > 1 {
+		return errors.New("capture sampling_ratio must be in (0, 1]")
+	}
+	return nil
+}
+
+// capturer captures the payloads of the requests. A nil capturer captures nothing.
+type capturer struct {
+	cfg      *CaptureConfig
+	logger   *zap.Logger
+	captured atomic.Int64
+	redacted map[string]struct{}
+}
+
+func newCapturer(cfg *CaptureConfig, logger *zap.Logger) *capturer {
+	if !cfg.Enabled {
+		return nil
+	}
+	c := &capturer{cfg: cfg, logger: logger}
+	if len(cfg.RedactedAttributes) > 0 {
+		c.redacted = make(map[string]struct{}, len(cfg.RedactedAttributes))
+		for _, key := range cfg.RedactedAttributes {
+			c.redacted[key] = struct{}{}
+		}
+	}
+	return c
+}
+
+// start creates the directory the payloads are written to.
+func (c *capturer) start() error {
+	if c == nil || c.cfg.Directory == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.cfg.Directory, 0o700); err != nil {
+		return fmt.Errorf("failed to create the capture directory: %w", err)
+	}
+	return nil
+}
+
+// capture captures the payload of a request if it is sampled. The payload is only computed for the sampled
+// requests, valid reports whether it could be decoded.
+func (c *capturer) capture(dataType component.DataType, transport, contentType string, valid bool, payload func() ([]byte, error)) {
+	if c == nil || (valid && c.cfg.InvalidOnly) {
+		return
+	}
+	if c.captured.Load() >= int64(c.cfg.MaxPayloads) || rand.Float64() >= c.cfg.SamplingRatio {
+		return
+	}
+	n := c.captured.Add(1)
+	if n > int64(c.cfg.MaxPayloads) {
+		return
+	}
+	buf, err := payload()
+	if err != nil {
+		c.logger.Warn("Failed to encode the captured payload", zap.Error(err))
+		return
+	}
+	if c.redacted != nil {
+		if !valid {
+			return
+		}
+		if buf, err = c.redactAttributes(dataType, contentType, buf); err != nil {
+			c.logger.Warn("Failed to redact the captured payload", zap.Error(err))
+			return
+		}
+	}
+	if c.cfg.Redact != nil {
+		var ok bool
+		if buf, ok = c.cfg.Redact(dataType, contentType, buf); !ok {
+			return
+		}
+	}
+	c.write(dataType, transport, contentType, valid, n, buf)
+}
+
+// captureBody captures the body of an HTTP request.
+func (c *capturer) captureBody(dataType component.DataType, contentType string, valid bool, body []byte) {
+	if c == nil {
+		return
+	}
+	c.capture(dataType, transportHTTP, contentType, valid, func() ([]byte, error) { return body, nil })
+}
+
+func (c *capturer) write(dataType component.DataType, transport, contentType string, valid bool, n int64, buf []byte) {
+	fields := []zap.Field{
+		zap.Stringer("data_type", dataType),
+		zap.String("transport", transport),
+		zap.String("content_type", contentType),
+		zap.Bool("valid", valid),
+	}
+	if c.cfg.Directory == "" {
+		if contentType == jsonContentType {
+			fields = append(fields, zap.ByteString("payload", buf))
+		} else {
+			fields = append(fields, zap.Binary("payload", buf))
+		}
+		c.logger.Debug("Captured request payload", fields...)
+		return
+	}
+
+	ext := ".pb"
+	if contentType == jsonContentType {
+		ext = ".json"
+	}
+	name := filepath.Join(c.cfg.Directory, fmt.Sprintf("%s-%s-%d-%d%s", dataType, transport, time.Now().UnixNano(), n, ext))
+	if err := os.WriteFile(name, buf, 0o600); err != nil {
+		c.logger.Warn("Failed to write the captured payload", zap.Error(err))
+		return
+	}
+	c.logger.Info("Captured request payload", append(fields, zap.String("file", name))...)
+}
+
+// redactAttributes replaces the values of the redacted attributes of a payload, which is decoded and encoded again.
+func (c *capturer) redactAttributes(dataType component.DataType, contentType string, buf []byte) ([]byte, error) {
+	isJSON := contentType == jsonContentType
+	switch dataType {
+	case component.DataTypeTraces:
+		req := ptraceotlp.NewExportRequest()
+		if err := unmarshalRequest(req.UnmarshalProto, req.UnmarshalJSON, isJSON, buf); err != nil {
+			return nil, err
+		}
+		c.redactTraces(req.Traces())
+		return marshalRequest(req.MarshalProto, req.MarshalJSON, isJSON)
+	case component.DataTypeMetrics:
+		req := pmetricotlp.NewExportRequest()
+		if err := unmarshalRequest(req.UnmarshalProto, req.UnmarshalJSON, isJSON, buf); err != nil {
+			return nil, err
+		}
+		c.redactMetrics(req.Metrics())
+		return marshalRequest(req.MarshalProto, req.MarshalJSON, isJSON)
+	case component.DataTypeLogs:
+		req := plogotlp.NewExportRequest()
+		if err := unmarshalRequest(req.UnmarshalProto, req.UnmarshalJSON, isJSON, buf); err != nil {
+			return nil, err
+		}
+		c.redactLogs(req.Logs())
+		return marshalRequest(req.MarshalProto, req.MarshalJSON, isJSON)
+	}
+	return nil, fmt.Errorf("unsupported data type %q", dataType)
+}
+
+func unmarshalRequest(fromProto, fromJSON func([]byte) error, isJSON bool, buf []byte) error {
+	if isJSON {
+		return fromJSON(buf)
+	}
+	return fromProto(buf)
+}
+
+func marshalRequest(toProto, toJSON func() ([]byte, error), isJSON bool) ([]byte, error) {
+	if isJSON {
+		return toJSON()
+	}
+	return toProto()
+}
+
+func (c *capturer) redactMap(m pcommon.Map) {
+	m.Range(func(k string, v pcommon.Value) bool {
+		if _, ok := c.redacted[k]; ok {
+			v.SetStr(redactedValue)
+		}
+		return true
+	})
+}
+
+func (c *capturer) redactTraces(td ptrace.Traces) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		c.redactMap(rs.Resource().Attributes())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			c.redactMap(ss.Scope().Attributes())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				c.redactMap(span.Attributes())
+				for l := 0; l < span.Events().Len(); l++ {
+					c.redactMap(span.Events().At(l).Attributes())
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					c.redactMap(span.Links().At(l).Attributes())
+				}
+			}
+		}
+	}
+}
+
+func (c *capturer) redactMetrics(md pmetric.Metrics) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		c.redactMap(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			c.redactMap(sm.Scope().Attributes())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				c.redactMetric(sm.Metrics().At(k))
+			}
+		}
+	}
+}
+
+func (c *capturer) redactMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		c.redactNumberDataPoints(m.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		c.redactNumberDataPoints(m.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			dp := m.Histogram().DataPoints().At(i)
+			c.redactMap(dp.Attributes())
+			c.redactExemplars(dp.Exemplars())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			dp := m.ExponentialHistogram().DataPoints().At(i)
+			c.redactMap(dp.Attributes())
+			c.redactExemplars(dp.Exemplars())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			c.redactMap(m.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
+
+func (c *capturer) redactNumberDataPoints(dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		c.redactMap(dps.At(i).Attributes())
+		c.redactExemplars(dps.At(i).Exemplars())
+	}
+}
+
+func (c *capturer) redactExemplars(exemplars pmetric.ExemplarSlice) {
+	for i := 0; i < exemplars.Len(); i++ {
+		c.redactMap(exemplars.At(i).FilteredAttributes())
+	}
+}
+
+func (c *capturer) redactLogs(ld plog.Logs) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		c.redactMap(rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			c.redactMap(sl.Scope().Attributes())
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				c.redactMap(sl.LogRecords().At(k).Attributes())
+			}
+		}
+	}
+}
+
+// tracesCapturer captures the payloads of the gRPC traces requests, encoded again in protobuf.
+type tracesCapturer struct {
+	ptraceotlp.GRPCServer
+	capturer *capturer
+}
+
+func (c *tracesCapturer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	c.capturer.capture(component.DataTypeTraces, transportGRPC, pbContentType, true, req.MarshalProto)
+	return c.GRPCServer.Export(ctx, req)
+}
+
+// metricsCapturer captures the payloads of the gRPC metrics requests, encoded again in protobuf.
+type metricsCapturer struct {
+	pmetricotlp.GRPCServer
+	capturer *capturer
+}
+
+func (c *metricsCapturer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	c.capturer.capture(component.DataTypeMetrics, transportGRPC, pbContentType, true, req.MarshalProto)
+	return c.GRPCServer.Export(ctx, req)
+}
+
+// logsCapturer captures the payloads of the gRPC logs requests, encoded again in protobuf.
+type logsCapturer struct {
+	plogotlp.GRPCServer
+	capturer *capturer
+}
+
+func (c *logsCapturer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	c.capturer.capture(component.DataTypeLogs, transportGRPC, pbContentType, true, req.MarshalProto)
+	return c.GRPCServer.Export(ctx, req)
+}
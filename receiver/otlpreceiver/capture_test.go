@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestCaptureHTTPInvalidOnly(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	dir := filepath.Join(t.TempDir(), "capture")
+	sink := newErrOrSinkConsumer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.Capture.Enabled = true
+	cfg.Capture.InvalidOnly = true
+	cfg.Capture.Directory = dir
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	tr := generateTracesRequest(t)
+	doHTTPRequest(t, "http://"+addr+tr.path, "", jsonContentType, tr.jsonBytes, http.StatusOK)
+	invalid := []byte(`{"resourceSpans": [`)
+	doHTTPRequest(t, "http://"+addr+tr.path, "", jsonContentType, invalid, http.StatusBadRequest)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Regexp(t, `^traces-http-\d+-1\.json$`, entries[0].Name())
+	got, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, invalid, got)
+}
+
+func TestCaptureGRPC(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	core, logs := observer.New(zapcore.DebugLevel)
+	sink := newErrOrSinkConsumer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP = nil
+	cfg.Capture.Enabled = true
+	cfg.Capture.MaxPayloads = 1
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = zap.New(core)
+	recv := newReceiver(t, set, cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+
+	req := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2))
+	expected, err := req.MarshalProto()
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = plogotlp.NewGRPCClient(cc).Export(context.Background(), req)
+		require.NoError(t, err)
+	}
+	assert.Len(t, sink.AllLogs(), 2)
+
+	// Only the first request is captured.
+	captured := logs.FilterMessage("Captured request payload").All()
+	require.Len(t, captured, 1)
+	fields := captured[0].ContextMap()
+	assert.Equal(t, "logs", fields["data_type"])
+	assert.Equal(t, transportGRPC, fields["transport"])
+	assert.Equal(t, pbContentType, fields["content_type"])
+	assert.True(t, fields["valid"].(bool))
+	assert.Equal(t, expected, fields["payload"])
+}
+
+func TestCapturerSampling(t *testing.T) {
+	cfg := &CaptureConfig{Enabled: true, MaxPayloads: 100, SamplingRatio: 0.5}
+	c := newCapturer(cfg, zap.NewNop())
+	payloads := 0
+	for i := 0; i < 1000; i++ {
+		c.capture(component.DataTypeTraces, transportHTTP, pbContentType, true, func() ([]byte, error) {
+			payloads++
+			return nil, nil
+		})
+	}
+	// The payloads are only computed for the captured requests.
+	assert.Equal(t, 100, payloads)
+	assert.Nil(t, newCapturer(&CaptureConfig{}, zap.NewNop()))
+}
+
+func TestCapturerRedactAttributes(t *testing.T) {
+	tests := []struct {
+		dataType component.DataType
+		payload  func(t *testing.T, isJSON bool) []byte
+		check    func(t *testing.T, buf []byte, isJSON bool)
+	}{
+		{
+			dataType: component.DataTypeTraces,
+			payload: func(t *testing.T, isJSON bool) []byte {
+				td := testdata.GenerateTraces(1)
+				td.ResourceSpans().At(0).Resource().Attributes().PutStr("secret", "a")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("secret", "b")
+				return marshalTestRequest(t, ptraceotlp.NewExportRequestFromTraces(td), isJSON)
+			},
+			check: func(t *testing.T, buf []byte, isJSON bool) {
+				req := ptraceotlp.NewExportRequest()
+				require.NoError(t, unmarshalRequest(req.UnmarshalProto, req.UnmarshalJSON, isJSON, buf))
+				rs := req.Traces().ResourceSpans().At(0)
+				assertRedacted(t, rs.Resource().Attributes().AsRaw())
+				assertRedacted(t, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+			},
+		},
+		{
+			dataType: component.DataTypeMetrics,
+			payload: func(t *testing.T, isJSON bool) []byte {
+				md := pmetric.NewMetrics()
+				rm := md.ResourceMetrics().AppendEmpty()
+				rm.Resource().Attributes().PutStr("secret", "a")
+				dp := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("secret", "b")
+				return marshalTestRequest(t, pmetricotlp.NewExportRequestFromMetrics(md), isJSON)
+			},
+			check: func(t *testing.T, buf []byte, isJSON bool) {
+				req := pmetricotlp.NewExportRequest()
+				require.NoError(t, unmarshalRequest(req.UnmarshalProto, req.UnmarshalJSON, isJSON, buf))
+				rm := req.Metrics().ResourceMetrics().At(0)
+				assertRedacted(t, rm.Resource().Attributes().AsRaw())
+				assertRedacted(t, rm.ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+			},
+		},
+		{
+			dataType: component.DataTypeLogs,
+			payload: func(t *testing.T, isJSON bool) []byte {
+				ld := plog.NewLogs()
+				rl := ld.ResourceLogs().AppendEmpty()
+				rl.Resource().Attributes().PutStr("secret", "a")
+				rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("secret", "b")
+				return marshalTestRequest(t, plogotlp.NewExportRequestFromLogs(ld), isJSON)
+			},
+			check: func(t *testing.T, buf []byte, isJSON bool) {
+				req := plogotlp.NewExportRequest()
+				require.NoError(t, unmarshalRequest(req.UnmarshalProto, req.UnmarshalJSON, isJSON, buf))
+				rl := req.Logs().ResourceLogs().At(0)
+				assertRedacted(t, rl.Resource().Attributes().AsRaw())
+				assertRedacted(t, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, contentType := range []string{pbContentType, jsonContentType} {
+			t.Run(tt.dataType.String()+" "+contentType, func(t *testing.T) {
+				isJSON := contentType == jsonContentType
+				core, logs := observer.New(zapcore.DebugLevel)
+				c := newCapturer(&CaptureConfig{
+					Enabled:            true,
+					MaxPayloads:        10,
+					SamplingRatio:      1,
+					RedactedAttributes: []string{"secret"},
+				}, zap.New(core))
+
+				c.captureBody(tt.dataType, contentType, true, tt.payload(t, isJSON))
+				// The invalid payloads cannot be redacted, they are not captured.
+				c.captureBody(tt.dataType, contentType, false, []byte("invalid"))
+
+				captured := logs.FilterMessage("Captured request payload").All()
+				require.Len(t, captured, 1)
+				var buf []byte
+				if isJSON {
+					buf = []byte(captured[0].ContextMap()["payload"].(string))
+				} else {
+					buf = captured[0].ContextMap()["payload"].([]byte)
+				}
+				tt.check(t, buf, isJSON)
+			})
+		}
+	}
+}
+
+func TestCapturerRedactFunc(t *testing.T) {
+	dir := t.TempDir()
+	c := newCapturer(&CaptureConfig{
+		Enabled:       true,
+		MaxPayloads:   10,
+		SamplingRatio: 1,
+		Directory:     dir,
+		Redact: func(_ component.DataType, _ string, payload []byte) ([]byte, bool) {
+			if string(payload) == "skip" {
+				return nil, false
+			}
+			return []byte("redacted"), true
+		},
+	}, zap.NewNop())
+	require.NoError(t, c.start())
+
+	c.captureBody(component.DataTypeLogs, pbContentType, true, []byte("skip"))
+	c.captureBody(component.DataTypeLogs, pbContentType, true, []byte("payload"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Regexp(t, `^logs-http-\d+-2\.pb$`, entries[0].Name())
+	got, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, "redacted", string(got))
+}
+
+func assertRedacted(t *testing.T, attrs map[string]any) {
+	assert.Equal(t, redactedValue, attrs["secret"])
+}
+
+type testRequest interface {
+	MarshalProto() ([]byte, error)
+	MarshalJSON() ([]byte, error)
+}
+
+func marshalTestRequest(t *testing.T, req testRequest, isJSON bool) []byte {
+	buf, err := marshalRequest(req.MarshalProto, req.MarshalJSON, isJSON)
+	require.NoError(t, err)
+	return buf
+}
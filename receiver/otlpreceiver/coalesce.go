@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	defaultCoalesceMaxLatency = 10 * time.Millisecond
+	defaultCoalesceMaxSize    = 8192
+)
+
+// CoalesceConfig configures the coalescing of the small requests into larger batches before they are handed to
+// the pipelines, cutting the per-request overhead of the pipelines when many clients send small payloads.
+type CoalesceConfig struct {
+	// Enabled coalesces the requests of each signal.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxLatency is the maximum time the first request of a batch waits for other requests before the batch is
+	// handed to the pipelines.
+	MaxLatency time.Duration `mapstructure:"max_latency"`
+
+	// MaxSize is the number of spans, data points or log records from which a batch is handed to the pipelines
+	// without waiting. The requests larger than it are not coalesced.
+	MaxSize int `mapstructure:"max_size"`
+
+	// MetadataKeys is a list of client.Metadata keys forming distinct batches: only the requests with the same
+	// values for these keys are coalesced together, and their metadata is propagated to the pipelines. The other
+	// metadata of the requests is not propagated. Entries are case-insensitive.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+}
+
+func (cfg *CoalesceConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MaxLatency <= 0 {
+		return errors.New("coalesce max_latency must be positive")
+	}
+	if cfg.MaxSize <= 0 {
+		return errors.New("coalesce max_size must be positive")
+	}
+	uniq := map[string]bool{}
+	for _, k := range cfg.MetadataKeys {
+		l := strings.ToLower(k)
+		if uniq[l] {
+			return fmt.Errorf("duplicate entry in coalesce metadata_keys: %q (case-insensitive)", l)
+		}
+		uniq[l] = true
+	}
+	return nil
+}
+
+// errCoalescerShutdown is returned by the requests received while the receiver shuts down.
+var errCoalescerShutdown = errors.New("the receiver is shutting down")
+
+// coalescedBatch is the data of the requests coalesced together, handed to the pipelines at once.
+type coalescedBatch[T any] struct {
+	// ctx is derived from the context of the first request of the batch, with the metadata shared by the requests.
+	ctx    context.Context
+	cancel context.CancelFunc
+	key    attribute.Distinct
+	data   T
+	size   int
+	timer  *time.Timer
+	// done is closed once the batch is consumed, with err the result of the consumption.
+	done chan struct{}
+	err  error
+}
+
+// coalescer merges the data of the concurrent requests with the same metadata into batches. Every request waits
+// for the consumption of the batch holding its data, and returns its result.
+type coalescer[T any] struct {
+	cfg *CoalesceConfig
+	// ack is set when the requests are replied to once their data is acknowledged: a request whose context is done
+	// before its batch is consumed then fails, so that its client sends the data again.
+	ack     bool
+	newData func() T
+	size    func(T) int
+	// moveTo moves the data of the source to the destination.
+	moveTo  func(src, dest T)
+	consume func(context.Context, T) error
+
+	mu      sync.Mutex
+	pending map[attribute.Distinct]*coalescedBatch[T]
+	// closed is set once the coalescer is shut down, the requests are rejected.
+	closed bool
+	// flushes tracks the batches not consumed yet.
+	flushes sync.WaitGroup
+}
+
+// metadata returns the values of the metadata keys of the request, with the key of its batch.
+func (c *coalescer[T]) metadata(ctx context.Context) (map[string][]string, attribute.Distinct) {
+	if len(c.cfg.MetadataKeys) == 0 {
+		return nil, attribute.EmptySet().Equivalent()
+	}
+	info := client.FromContext(ctx)
+	md := make(map[string][]string, len(c.cfg.MetadataKeys))
+	attrs := make([]attribute.KeyValue, 0, len(c.cfg.MetadataKeys))
+	for _, k := range c.cfg.MetadataKeys {
+		k = strings.ToLower(k)
+		vs := info.Metadata.Get(k)
+		md[k] = vs
+		attrs = append(attrs, attribute.StringSlice(k, vs))
+	}
+	set := attribute.NewSet(attrs...)
+	return md, set.Equivalent()
+}
+
+// batchContext returns the context of a batch started by a request: the context of the request, keeping its client
+// info, span context and deadline, but not its cancellation, since the batch holds the data of the other requests.
+// As the exporters' batching, the metadata of the request other than the metadata keys is not attributed to the batch.
+func batchContext(ctx context.Context, md map[string][]string) (context.Context, context.CancelFunc) {
+	info := client.FromContext(ctx)
+	info.Metadata = client.NewMetadata(md)
+	batchCtx := client.NewContext(context.WithoutCancel(ctx), info)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(batchCtx, deadline)
+	}
+	return context.WithCancel(batchCtx)
+}
+
+// add hands the data of a request to the pipelines within a batch. Without ack, the data is accepted once moved to
+// the batch: the request is not failed if its context is done before the batch is consumed, as its data is still
+// handed to the pipelines.
+func (c *coalescer[T]) add(ctx context.Context, data T) error {
+	size := c.size(data)
+	md, key := c.metadata(ctx)
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return errCoalescerShutdown
+	}
+	b := c.pending[key]
+	if b == nil && size >= c.cfg.MaxSize {
+		c.mu.Unlock()
+		return c.consume(ctx, data)
+	}
+	if b == nil {
+		b = &coalescedBatch[T]{
+			key:  key,
+			data: c.newData(),
+			done: make(chan struct{}),
+		}
+		b.ctx, b.cancel = batchContext(ctx, md)
+		b.timer = time.AfterFunc(c.cfg.MaxLatency, func() { c.flush(b) })
+		if c.pending == nil {
+			c.pending = map[attribute.Distinct]*coalescedBatch[T]{}
+		}
+		c.pending[key] = b
+		c.flushes.Add(1)
+	}
+	c.moveTo(data, b.data)
+	b.size += size
+	full := b.size >= c.cfg.MaxSize
+	if full {
+		// The next requests start a new batch.
+		delete(c.pending, key)
+	}
+	c.mu.Unlock()
+
+	// The batch is flushed by its timer if it already fired.
+	if full && b.timer.Stop() {
+		c.flush(b)
+	}
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		if c.ack {
+			return ctx.Err()
+		}
+		return nil
+	}
+}
+
+// flush hands the batch to the pipelines, once.
+func (c *coalescer[T]) flush(b *coalescedBatch[T]) {
+	defer c.flushes.Done()
+	c.mu.Lock()
+	if c.pending[b.key] == b {
+		delete(c.pending, b.key)
+	}
+	c.mu.Unlock()
+	b.err = c.consume(b.ctx, b.data)
+	b.cancel()
+	close(b.done)
+}
+
+// shutdown rejects the next requests and hands the pending batches to the pipelines without waiting for their
+// timers. It returns once all the batches are consumed.
+func (c *coalescer[T]) shutdown() {
+	c.mu.Lock()
+	c.closed = true
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+	for _, b := range pending {
+		if b.timer.Stop() {
+			c.flush(b)
+		}
+	}
+	c.flushes.Wait()
+}
+
+// startCoalesce wraps the next consumers to coalesce the requests, if the receiver is configured to.
+func (r *otlpReceiver) startCoalesce() error {
+	if !r.cfg.Coalesce.Enabled {
+		return nil
+	}
+	var err error
+	if next := r.nextTraces; next != nil {
+		c := &coalescer[ptrace.Traces]{
+			cfg:     &r.cfg.Coalesce,
+			ack:     r.cfg.Ack != nil,
+			newData: ptrace.NewTraces,
+			size:    ptrace.Traces.SpanCount,
+			moveTo: func(src, dest ptrace.Traces) {
+				src.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+			},
+			consume: next.ConsumeTraces,
+		}
+		r.coalescers = append(r.coalescers, c)
+		if r.nextTraces, err = consumer.NewTraces(c.add, consumer.WithCapabilities(next.Capabilities())); err != nil {
+			return err
+		}
+	}
+	if next := r.nextMetrics; next != nil {
+		c := &coalescer[pmetric.Metrics]{
+			cfg:     &r.cfg.Coalesce,
+			ack:     r.cfg.Ack != nil,
+			newData: pmetric.NewMetrics,
+			size:    pmetric.Metrics.DataPointCount,
+			moveTo: func(src, dest pmetric.Metrics) {
+				src.ResourceMetrics().MoveAndAppendTo(dest.ResourceMetrics())
+			},
+			consume: next.ConsumeMetrics,
+		}
+		r.coalescers = append(r.coalescers, c)
+		if r.nextMetrics, err = consumer.NewMetrics(c.add, consumer.WithCapabilities(next.Capabilities())); err != nil {
+			return err
+		}
+	}
+	if next := r.nextLogs; next != nil {
+		c := &coalescer[plog.Logs]{
+			cfg:     &r.cfg.Coalesce,
+			ack:     r.cfg.Ack != nil,
+			newData: plog.NewLogs,
+			size:    plog.Logs.LogRecordCount,
+			moveTo: func(src, dest plog.Logs) {
+				src.ResourceLogs().MoveAndAppendTo(dest.ResourceLogs())
+			},
+			consume: next.ConsumeLogs,
+		}
+		r.coalescers = append(r.coalescers, c)
+		r.nextLogs, err = consumer.NewLogs(c.add, consumer.WithCapabilities(next.Capabilities()))
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newTestTracesCoalescer(cfg *CoalesceConfig, consume func(context.Context, ptrace.Traces) error) *coalescer[ptrace.Traces] {
+	return &coalescer[ptrace.Traces]{
+		cfg:     cfg,
+		newData: ptrace.NewTraces,
+		size:    ptrace.Traces.SpanCount,
+		moveTo: func(src, dest ptrace.Traces) {
+			src.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+		},
+		consume: consume,
+	}
+}
+
+func TestCoalescerMaxLatency(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: 50 * time.Millisecond, MaxSize: 100}, sink.ConsumeTraces)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.add(context.Background(), testdata.GenerateTraces(2)))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, sink.SpanCount())
+	assert.Less(t, len(sink.AllTraces()), 5)
+}
+
+func TestCoalescerMaxSize(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Hour, MaxSize: 4}, sink.ConsumeTraces)
+
+	// The full batch is handed to the pipelines without waiting for the timer.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.add(context.Background(), testdata.GenerateTraces(2)))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 4, sink.SpanCount())
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestCoalescerLargeRequest(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Hour, MaxSize: 4}, sink.ConsumeTraces)
+
+	td := testdata.GenerateTraces(5)
+	require.NoError(t, c.add(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+}
+
+func TestCoalescerError(t *testing.T) {
+	consumeErr := errors.New("consume failed")
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Millisecond, MaxSize: 100}, func(context.Context, ptrace.Traces) error {
+		return consumeErr
+	})
+	assert.ErrorIs(t, c.add(context.Background(), testdata.GenerateTraces(1)), consumeErr)
+}
+
+func TestCoalescerContextDone(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: 50 * time.Millisecond, MaxSize: 100}, sink.ConsumeTraces)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// The data of the canceled request is accepted, and still handed to the pipelines.
+	assert.NoError(t, c.add(ctx, testdata.GenerateTraces(1)))
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestCoalescerContextDoneAck(t *testing.T) {
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Hour, MaxSize: 100}, consumertest.NewNop().ConsumeTraces)
+	c.ack = true
+
+	// The data of the canceled request may not be acknowledged, its client must send it again.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, c.add(ctx, testdata.GenerateTraces(1)), context.Canceled)
+	c.shutdown()
+}
+
+func TestCoalescerBatchContext(t *testing.T) {
+	traceID := [16]byte{1}
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: [8]byte{1}})
+	deadline := time.Now().Add(time.Hour)
+	addr := &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)}
+	done := make(chan struct{})
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Millisecond, MaxSize: 100, MetadataKeys: []string{"tenant"}},
+		func(ctx context.Context, _ ptrace.Traces) error {
+			defer close(done)
+			// The batch keeps the client info, span context and deadline of its first request, but not its cancellation.
+			info := client.FromContext(ctx)
+			assert.Equal(t, addr, info.Addr)
+			assert.Equal(t, []string{"a"}, info.Metadata.Get("tenant"))
+			assert.Empty(t, info.Metadata.Get("other"))
+			assert.Equal(t, traceID, [16]byte(trace.SpanContextFromContext(ctx).TraceID()))
+			got, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.Equal(t, deadline, got)
+			assert.NoError(t, ctx.Err())
+			return nil
+		})
+
+	ctx, cancel := context.WithDeadline(trace.ContextWithSpanContext(context.Background(), spanCtx), deadline)
+	ctx = client.NewContext(ctx, client.Info{
+		Addr:     addr,
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"a"}, "other": {"value"}}),
+	})
+	cancel()
+	assert.NoError(t, c.add(ctx, testdata.GenerateTraces(1)))
+	<-done
+}
+
+func TestCoalescerMetadataKeys(t *testing.T) {
+	var mu sync.Mutex
+	tenants := map[string]int{}
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Hour, MaxSize: 4, MetadataKeys: []string{"Tenant"}},
+		func(ctx context.Context, td ptrace.Traces) error {
+			mu.Lock()
+			defer mu.Unlock()
+			info := client.FromContext(ctx)
+			tenants[strings.Join(info.Metadata.Get("tenant"), ",")] += td.SpanCount()
+			assert.Empty(t, info.Metadata.Get("other"))
+			return nil
+		})
+
+	// The requests of each tenant fill their own batch.
+	var wg sync.WaitGroup
+	for _, tenant := range []string{"a", "b", "a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := client.NewContext(context.Background(), client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}, "other": {"value"}}),
+			})
+			assert.NoError(t, c.add(ctx, testdata.GenerateTraces(2)))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, map[string]int{"a": 4, "b": 4}, tenants)
+}
+
+func TestCoalescerShutdown(t *testing.T) {
+	var mu sync.Mutex
+	shutdown := false
+	sink := new(consumertest.TracesSink)
+	c := newTestTracesCoalescer(&CoalesceConfig{Enabled: true, MaxLatency: time.Hour, MaxSize: 100}, func(ctx context.Context, td ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.False(t, shutdown, "consumed after shutdown")
+		return sink.ConsumeTraces(ctx, td)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, c.add(ctx, testdata.GenerateTraces(1)))
+	assert.Zero(t, sink.SpanCount())
+
+	// The pending batch is handed to the pipelines without waiting for its timer, and the next requests are rejected.
+	c.shutdown()
+	mu.Lock()
+	shutdown = true
+	mu.Unlock()
+	assert.Equal(t, 1, sink.SpanCount())
+	assert.ErrorIs(t, c.add(context.Background(), testdata.GenerateTraces(1)), errCoalescerShutdown)
+}
+
+func TestCoalesce(t *testing.T) {
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.GRPC = nil
+	cfg.Coalesce.Enabled = true
+
+	sink := new(consumertest.TracesSink)
+	set := receivertest.NewNopSettings()
+	r, err := newOtlpReceiver(cfg, &set)
+	require.NoError(t, err)
+	r.registerTraceConsumer(sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(1))
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doHTTPRequest(t, "http://"+httpAddr+defaultTracesURLPath, "", pbContentType, buf, http.StatusOK)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, sink.SpanCount())
+}
@@ -13,12 +13,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
-)
-
-const (
-	// Protocol values.
-	protoGRPC = "protocols::grpc"
-	protoHTTP = "protocols::http"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
 )
 
 type HTTPConfig struct {
@@ -40,51 +35,203 @@ type Protocols struct {
 	HTTP *HTTPConfig              `mapstructure:"http"`
 }
 
+// SignalLimits overrides the maximum request size of the protocols for a single signal.
+// A zero value keeps the limit of the protocol.
+type SignalLimits struct {
+	// MaxRecvMsgSizeMiB overrides protocols::grpc::max_recv_msg_size_mib for this signal.
+	MaxRecvMsgSizeMiB uint64 `mapstructure:"max_recv_msg_size_mib"`
+
+	// MaxRequestBodySize overrides protocols::http::max_request_body_size for this signal.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+}
+
+// Limits is the configuration of the per-signal overrides of the maximum request size.
+type Limits struct {
+	Traces  SignalLimits `mapstructure:"traces"`
+	Metrics SignalLimits `mapstructure:"metrics"`
+	Logs    SignalLimits `mapstructure:"logs"`
+}
+
+// SignalConfig is the configuration of the reception of a single signal.
+type SignalConfig struct {
+	// Disabled stops accepting the signal. The pipelines of the signal cannot use the receiver then.
+	Disabled bool `mapstructure:"disabled"`
+
+	// Protocols, if set, serves the signal on its own servers rather than on the servers of the receiver's protocols,
+	// e.g. to listen on a different port or to authenticate the requests differently.
+	Protocols *Protocols `mapstructure:"protocols"`
+}
+
+// Signals is the configuration of the reception of each signal.
+type Signals struct {
+	Traces  SignalConfig `mapstructure:"traces"`
+	Metrics SignalConfig `mapstructure:"metrics"`
+	Logs    SignalConfig `mapstructure:"logs"`
+}
+
+// LogsConfig configures the processing of the received log records.
+type LogsConfig struct {
+	// ParseJSONBody replaces the string bodies of the log records which are JSON objects by the parsed maps,
+	// so the processors can access their fields. The bodies which are not valid JSON objects are left unchanged.
+	ParseJSONBody bool `mapstructure:"parse_json_body"`
+
+	// MaxJSONBodySize is the maximum size in bytes of the bodies parsed, the larger bodies are left unchanged.
+	MaxJSONBodySize int `mapstructure:"max_json_body_size"`
+}
+
+// jsonBodyMaxSize returns the maximum size of the bodies parsed, zero if the parsing is disabled.
+func (cfg *LogsConfig) jsonBodyMaxSize() int {
+	if !cfg.ParseJSONBody {
+		return 0
+	}
+	return cfg.MaxJSONBodySize
+}
+
 // Config defines configuration for OTLP receiver.
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// Limits overrides the maximum request size of the protocols per signal.
+	Limits Limits `mapstructure:"limits"`
+
+	// Signals configures the reception of each signal, sharing the servers of the protocols by default.
+	Signals Signals `mapstructure:"signals"`
+
+	// Logs configures the processing of the received log records.
+	Logs LogsConfig `mapstructure:"logs"`
+
+	// Capture configures the capture of the payloads of the received requests, for debugging.
+	Capture CaptureConfig `mapstructure:"capture"`
+
+	// Coalesce configures the coalescing of the small requests into larger batches before they are handed to the
+	// pipelines.
+	Coalesce CoalesceConfig `mapstructure:"coalesce"`
+
+	// Ack, if set, replies to the requests only once their data is acknowledged by the ack extension,
+	// i.e. durably enqueued or exported, for at-least-once delivery.
+	Ack *ack.ReceiverConfig `mapstructure:"ack"`
 }
 
 var _ component.Config = (*Config)(nil)
 var _ confmap.Unmarshaler = (*Config)(nil)
 
+// signalConfig associates the configuration of a signal with its name.
+type signalConfig struct {
+	name string
+	*SignalConfig
+}
+
+// signals returns the configuration of each signal.
+func (cfg *Config) signals() []signalConfig {
+	return []signalConfig{
+		{"traces", &cfg.Signals.Traces},
+		{"metrics", &cfg.Signals.Metrics},
+		{"logs", &cfg.Signals.Logs},
+	}
+}
+
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.GRPC == nil && cfg.HTTP == nil {
+	sharedProtocols := false
+	for _, s := range cfg.signals() {
+		if s.Disabled {
+			continue
+		}
+		if s.Protocols == nil {
+			sharedProtocols = true
+			continue
+		}
+		if s.Protocols.GRPC == nil && s.Protocols.HTTP == nil {
+			return fmt.Errorf("must specify at least one protocol for the %s signal", s.name)
+		}
+		if s.Protocols.GRPC != nil && s.Protocols.GRPC.NetAddr.Endpoint == "" {
+			return fmt.Errorf("must specify the grpc endpoint of the %s signal", s.name)
+		}
+		if s.Protocols.HTTP != nil && s.Protocols.HTTP.Endpoint == "" {
+			return fmt.Errorf("must specify the http endpoint of the %s signal", s.name)
+		}
+	}
+	if sharedProtocols && cfg.GRPC == nil && cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
-	return nil
+	for _, l := range []struct {
+		signal string
+		limits SignalLimits
+	}{
+		{"traces", cfg.Limits.Traces},
+		{"metrics", cfg.Limits.Metrics},
+		{"logs", cfg.Limits.Logs},
+	} {
+		if l.limits.MaxRequestBodySize < 0 {
+			return fmt.Errorf("%s max_request_body_size must not be negative", l.signal)
+		}
+	}
+	if cfg.Logs.ParseJSONBody && cfg.Logs.MaxJSONBodySize <= 0 {
+		return errors.New("logs max_json_body_size must be positive")
+	}
+	if err := cfg.Capture.validate(); err != nil {
+		return err
+	}
+	return cfg.Coalesce.validate()
 }
 
 // Unmarshal a confmap.Conf into the config struct.
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	// The protocols of the signals served on their own servers start from the default protocols.
+	for _, s := range cfg.signals() {
+		if s.Protocols == nil && conf.IsSet(signalProtocols(s.name)) {
+			s.Protocols = newDefaultProtocols("", "")
+		}
+	}
+
 	// first load the config normally
 	err := conf.Unmarshal(cfg)
 	if err != nil {
 		return err
 	}
 
-	if !conf.IsSet(protoGRPC) {
-		cfg.GRPC = nil
+	if err = unmarshalProtocols(conf, "protocols", &cfg.Protocols); err != nil {
+		return err
 	}
-
-	if !conf.IsSet(protoHTTP) {
-		cfg.HTTP = nil
-	} else {
-		var err error
-
-		if cfg.HTTP.TracesURLPath, err = sanitizeURLPath(cfg.HTTP.TracesURLPath); err != nil {
-			return err
+	for _, s := range cfg.signals() {
+		if s.Protocols == nil {
+			continue
 		}
-		if cfg.HTTP.MetricsURLPath, err = sanitizeURLPath(cfg.HTTP.MetricsURLPath); err != nil {
-			return err
-		}
-		if cfg.HTTP.LogsURLPath, err = sanitizeURLPath(cfg.HTTP.LogsURLPath); err != nil {
+		if err = unmarshalProtocols(conf, signalProtocols(s.name), s.Protocols); err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+// signalProtocols returns the key of the protocols of a signal served on its own servers.
+func signalProtocols(signal string) string {
+	return "signals" + confmap.KeyDelimiter + signal + confmap.KeyDelimiter + "protocols"
+}
+
+// unmarshalProtocols removes the protocols which are not set under the given key,
+// and sanitizes the URL paths of the HTTP protocol.
+func unmarshalProtocols(conf *confmap.Conf, key string, protocols *Protocols) error {
+	if !conf.IsSet(key + confmap.KeyDelimiter + "grpc") {
+		protocols.GRPC = nil
+	}
 
+	if !conf.IsSet(key + confmap.KeyDelimiter + "http") {
+		protocols.HTTP = nil
+		return nil
+	}
+
+	var err error
+	if protocols.HTTP.TracesURLPath, err = sanitizeURLPath(protocols.HTTP.TracesURLPath); err != nil {
+		return err
+	}
+	if protocols.HTTP.MetricsURLPath, err = sanitizeURLPath(protocols.HTTP.MetricsURLPath); err != nil {
+		return err
+	}
+	if protocols.HTTP.LogsURLPath, err = sanitizeURLPath(protocols.HTTP.LogsURLPath); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -140,6 +141,31 @@ func TestUnmarshalConfig(t *testing.T) {
 					LogsURLPath:    "/log/ingest",
 				},
 			},
+			Limits: Limits{
+				Metrics: SignalLimits{MaxRequestBodySize: 1048576},
+				Logs:    SignalLimits{MaxRecvMsgSizeMiB: 64, MaxRequestBodySize: 67108864},
+			},
+			Logs: LogsConfig{
+				ParseJSONBody:   true,
+				MaxJSONBodySize: 16384,
+			},
+			Capture: CaptureConfig{
+				Enabled:            true,
+				MaxPayloads:        5,
+				SamplingRatio:      0.5,
+				InvalidOnly:        true,
+				Directory:          "/tmp/otlp-capture",
+				RedactedAttributes: []string{"user.email"},
+			},
+			Coalesce: CoalesceConfig{
+				MaxLatency: defaultCoalesceMaxLatency,
+				MaxSize:    defaultCoalesceMaxSize,
+			},
+			Ack: &ack.ReceiverConfig{
+				Extension: component.MustNewID("ack"),
+				Mode:      ack.ModeExported,
+				Timeout:   30 * time.Second,
+			},
 		}, cfg)
 
 }
@@ -169,9 +195,147 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 					LogsURLPath:    defaultLogsURLPath,
 				},
 			},
+			Logs: LogsConfig{
+				MaxJSONBodySize: defaultMaxJSONBodySize,
+			},
+			Capture: CaptureConfig{
+				MaxPayloads:   defaultCaptureMaxPayloads,
+				SamplingRatio: defaultCaptureSamplingRatio,
+			},
+			Coalesce: CoalesceConfig{
+				MaxLatency: defaultCoalesceMaxLatency,
+				MaxSize:    defaultCoalesceMaxSize,
+			},
 		}, cfg)
 }
 
+func TestUnmarshalConfigSignals(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "signals.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, cm.Unmarshal(&cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t,
+		&Config{
+			Protocols: Protocols{
+				GRPC: &configgrpc.ServerConfig{
+					NetAddr: confignet.AddrConfig{
+						Endpoint:  "localhost:4317",
+						Transport: confignet.TransportTypeTCP,
+					},
+					ReadBufferSize: 512 * 1024,
+				},
+			},
+			Signals: Signals{
+				Metrics: SignalConfig{Disabled: true},
+				Logs: SignalConfig{
+					Protocols: &Protocols{
+						HTTP: &HTTPConfig{
+							ServerConfig: &confighttp.ServerConfig{
+								Endpoint: "0.0.0.0:4320",
+								Auth: &confighttp.AuthConfig{
+									Authentication: configauth.Authentication{
+										AuthenticatorID: component.MustNewID("logs"),
+									},
+								},
+							},
+							TracesURLPath:  defaultTracesURLPath,
+							MetricsURLPath: defaultMetricsURLPath,
+							LogsURLPath:    defaultLogsURLPath,
+						},
+					},
+				},
+			},
+			Logs: LogsConfig{
+				MaxJSONBodySize: defaultMaxJSONBodySize,
+			},
+			Capture: CaptureConfig{
+				MaxPayloads:   defaultCaptureMaxPayloads,
+				SamplingRatio: defaultCaptureSamplingRatio,
+			},
+			Coalesce: CoalesceConfig{
+				MaxLatency: defaultCoalesceMaxLatency,
+				MaxSize:    defaultCoalesceMaxSize,
+			},
+		}, cfg)
+}
+
+func TestUnmarshalConfigCoalesce(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "coalesce.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, cm.Unmarshal(&cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t,
+		CoalesceConfig{
+			Enabled:      true,
+			MaxLatency:   5 * time.Millisecond,
+			MaxSize:      1000,
+			MetadataKeys: []string{"tenant"},
+		}, cfg.(*Config).Coalesce)
+}
+
+func TestValidateConfigSignals(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name: "no protocols for signal",
+			modify: func(cfg *Config) {
+				cfg.Signals.Logs.Protocols = &Protocols{}
+			},
+			err: "must specify at least one protocol for the logs signal",
+		},
+		{
+			name: "no grpc endpoint",
+			modify: func(cfg *Config) {
+				cfg.Signals.Traces.Protocols = newDefaultProtocols("", "localhost:4320")
+			},
+			err: "must specify the grpc endpoint of the traces signal",
+		},
+		{
+			name: "no http endpoint",
+			modify: func(cfg *Config) {
+				cfg.Signals.Metrics.Protocols = newDefaultProtocols("localhost:4319", "")
+			},
+			err: "must specify the http endpoint of the metrics signal",
+		},
+		{
+			name: "no shared protocols",
+			modify: func(cfg *Config) {
+				cfg.GRPC, cfg.HTTP = nil, nil
+				cfg.Signals.Traces.Protocols = newDefaultProtocols("localhost:4319", "localhost:4320")
+			},
+			err: "must specify at least one protocol when using the OTLP receiver",
+		},
+		{
+			name: "all signals on their own protocols",
+			modify: func(cfg *Config) {
+				cfg.GRPC, cfg.HTTP = nil, nil
+				cfg.Signals.Traces.Protocols = newDefaultProtocols("localhost:4319", "localhost:4320")
+				cfg.Signals.Metrics.Disabled = true
+				cfg.Signals.Logs.Protocols = newDefaultProtocols("localhost:4321", "localhost:4322")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := component.ValidateConfig(cfg)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
 func TestUnmarshalConfigTypoDefaultProtocol(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "typo_default_proto_config.yaml"))
 	require.NoError(t, err)
@@ -227,6 +391,57 @@ func TestUnmarshalConfigInvalidSignalPath(t *testing.T) {
 	}
 }
 
+func TestValidateConfigNegativeLimit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Limits.Metrics.MaxRequestBodySize = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "metrics max_request_body_size must not be negative")
+}
+
+func TestValidateConfigJSONBodySize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.MaxJSONBodySize = 0
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.Logs.ParseJSONBody = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "logs max_json_body_size must be positive")
+}
+
+func TestValidateConfigCapture(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Capture.MaxPayloads = 0
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.Capture.Enabled = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "capture max_payloads must be positive")
+	cfg.Capture.MaxPayloads = 1
+	cfg.Capture.SamplingRatio = 1.5
+	assert.EqualError(t, component.ValidateConfig(cfg), "capture sampling_ratio must be in (0, 1]")
+	cfg.Capture.SamplingRatio = 0.1
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfigAck(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Ack = &ack.ReceiverConfig{}
+	assert.EqualError(t, component.ValidateConfig(cfg), "the ack extension must be set")
+}
+
+func TestValidateConfigCoalesce(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Coalesce.MaxSize = 0
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.Coalesce.Enabled = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "coalesce max_size must be positive")
+	cfg.Coalesce.MaxSize = 1
+	cfg.Coalesce.MaxLatency = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "coalesce max_latency must be positive")
+	cfg.Coalesce.MaxLatency = time.Millisecond
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.Coalesce.MetadataKeys = []string{"tenant", "Tenant"}
+	assert.EqualError(t, component.ValidateConfig(cfg), `duplicate entry in coalesce metadata_keys: "tenant" (case-insensitive)`)
+	cfg.Coalesce.MetadataKeys = nil
+	cfg.Ack = &ack.ReceiverConfig{Extension: component.MustNewID("ack")}
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
 func TestUnmarshalConfigEmpty(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -5,6 +5,8 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -24,8 +26,13 @@ const (
 	defaultTracesURLPath  = "/v1/traces"
 	defaultMetricsURLPath = "/v1/metrics"
 	defaultLogsURLPath    = "/v1/logs"
+
+	defaultMaxJSONBodySize = 64 * 1024
 )
 
+// errSignalDisabled is returned when a pipeline uses the receiver for a disabled signal.
+var errSignalDisabled = errors.New("signal is disabled in the OTLP receiver configuration")
+
 // NewFactory creates a new OTLP receiver factory.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
@@ -40,23 +47,39 @@ func NewFactory() receiver.Factory {
 // createDefaultConfig creates the default configuration for receiver.
 func createDefaultConfig() component.Config {
 	return &Config{
-		Protocols: Protocols{
-			GRPC: &configgrpc.ServerConfig{
-				NetAddr: confignet.AddrConfig{
-					Endpoint:  localhostgate.EndpointForPort(grpcPort),
-					Transport: confignet.TransportTypeTCP,
-				},
-				// We almost write 0 bytes, so no need to tune WriteBufferSize.
-				ReadBufferSize: 512 * 1024,
+		Protocols: *newDefaultProtocols(localhostgate.EndpointForPort(grpcPort), localhostgate.EndpointForPort(httpPort)),
+		Logs: LogsConfig{
+			MaxJSONBodySize: defaultMaxJSONBodySize,
+		},
+		Capture: CaptureConfig{
+			MaxPayloads:   defaultCaptureMaxPayloads,
+			SamplingRatio: defaultCaptureSamplingRatio,
+		},
+		Coalesce: CoalesceConfig{
+			MaxLatency: defaultCoalesceMaxLatency,
+			MaxSize:    defaultCoalesceMaxSize,
+		},
+	}
+}
+
+// newDefaultProtocols returns the default configuration of the protocols listening on the given endpoints.
+func newDefaultProtocols(grpcEndpoint, httpEndpoint string) *Protocols {
+	return &Protocols{
+		GRPC: &configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  grpcEndpoint,
+				Transport: confignet.TransportTypeTCP,
 			},
-			HTTP: &HTTPConfig{
-				ServerConfig: &confighttp.ServerConfig{
-					Endpoint: localhostgate.EndpointForPort(httpPort),
-				},
-				TracesURLPath:  defaultTracesURLPath,
-				MetricsURLPath: defaultMetricsURLPath,
-				LogsURLPath:    defaultLogsURLPath,
+			// We almost write 0 bytes, so no need to tune WriteBufferSize.
+			ReadBufferSize: 512 * 1024,
+		},
+		HTTP: &HTTPConfig{
+			ServerConfig: &confighttp.ServerConfig{
+				Endpoint: httpEndpoint,
 			},
+			TracesURLPath:  defaultTracesURLPath,
+			MetricsURLPath: defaultMetricsURLPath,
+			LogsURLPath:    defaultLogsURLPath,
 		},
 	}
 }
@@ -69,6 +92,9 @@ func createTraces(
 	nextConsumer consumer.Traces,
 ) (receiver.Traces, error) {
 	oCfg := cfg.(*Config)
+	if oCfg.Signals.Traces.Disabled {
+		return nil, fmt.Errorf("%w: %s", errSignalDisabled, component.DataTypeTraces)
+	}
 	r, err := receivers.LoadOrStore(
 		oCfg,
 		func() (*otlpReceiver, error) {
@@ -92,6 +118,9 @@ func createMetrics(
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
 	oCfg := cfg.(*Config)
+	if oCfg.Signals.Metrics.Disabled {
+		return nil, fmt.Errorf("%w: %s", errSignalDisabled, component.DataTypeMetrics)
+	}
 	r, err := receivers.LoadOrStore(
 		oCfg,
 		func() (*otlpReceiver, error) {
@@ -115,6 +144,9 @@ func createLog(
 	consumer consumer.Logs,
 ) (receiver.Logs, error) {
 	oCfg := cfg.(*Config)
+	if oCfg.Signals.Logs.Disabled {
+		return nil, fmt.Errorf("%w: %s", errSignalDisabled, component.DataTypeLogs)
+	}
 	r, err := receivers.LoadOrStore(
 		oCfg,
 		func() (*otlpReceiver, error) {
@@ -50,6 +50,19 @@ func TestCreateSameReceiver(t *testing.T) {
 	assert.Same(t, tReceiver, lReceiver)
 }
 
+func TestCreateDisabledSignalReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Signals.Metrics.Disabled = true
+	creationSet := receivertest.NewNopSettings()
+
+	tr, err := factory.CreateTracesReceiver(context.Background(), creationSet, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, tr)
+	_, err = factory.CreateMetricsReceiver(context.Background(), creationSet, cfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, errSignalDisabled)
+}
+
 func TestCreateTracesReceiver(t *testing.T) {
 	factory := NewFactory()
 	defaultGRPCSettings := &configgrpc.ServerConfig{
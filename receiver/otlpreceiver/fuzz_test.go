@@ -39,13 +39,13 @@ func FuzzReceiverHandlers(f *testing.F) {
 		switch handler % 3 {
 		case 0:
 			httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
-			handleTraces(resp, req, httpTracesReceiver)
+			handleTraces(resp, req, httpTracesReceiver, httpSignalSettings{})
 		case 1:
 			httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
-			handleMetrics(resp, req, httpMetricsReceiver)
+			handleMetrics(resp, req, httpMetricsReceiver, httpSignalSettings{})
 		case 2:
-			httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
-			handleLogs(resp, req, httpLogsReceiver)
+			httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP, 0)
+			handleLogs(resp, req, httpLogsReceiver, httpSignalSettings{})
 		}
 
 	})
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logs // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// parseJSONBodies replaces the string bodies of the log records which are JSON objects of up to maxSize bytes
+// by the parsed maps. The bodies which are not valid JSON objects are left unchanged.
+func parseJSONBodies(ld plog.Logs, maxSize int) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				parseJSONBody(lrs.At(k).Body(), maxSize)
+			}
+		}
+	}
+}
+
+func parseJSONBody(body pcommon.Value, maxSize int) {
+	if body.Type() != pcommon.ValueTypeStr {
+		return
+	}
+	str := body.Str()
+	if len(str) > maxSize {
+		return
+	}
+	raw := bytes.TrimSpace([]byte(str))
+	if len(raw) < 2 || raw[0] != '{' || raw[len(raw)-1] != '}' {
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	// The numbers are decoded as json.Number to keep the integers as such.
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return
+	}
+	// Reject the trailing data, e.g. two concatenated objects.
+	if _, err := dec.Token(); err != io.EOF {
+		return
+	}
+	m := body.SetEmptyMap()
+	// The values decoded from JSON are all supported by FromRaw once the numbers are converted.
+	_ = m.FromRaw(convertNumbers(obj).(map[string]any))
+}
+
+// convertNumbers replaces the json.Number values by int64 values, or float64 values if they are not integers.
+func convertNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for k, e := range val {
+			val[k] = convertNumbers(e)
+		}
+		return val
+	case []any:
+		for i, e := range val {
+			val[i] = convertNumbers(e)
+		}
+		return val
+	}
+	return v
+}
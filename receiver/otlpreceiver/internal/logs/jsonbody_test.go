@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestParseJSONBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     func(pcommon.Value)
+		expected map[string]any
+	}{
+		{
+			name: "object",
+			body: func(v pcommon.Value) {
+				v.SetStr(` {"msg": "cart updated", "count": 3, "ratio": 0.5, "ok": true, "tags": ["a", 1], "user": {"id": 42}, "none": null} `)
+			},
+			expected: map[string]any{
+				"msg": "cart updated", "count": int64(3), "ratio": 0.5, "ok": true,
+				"tags": []any{"a", int64(1)}, "user": map[string]any{"id": int64(42)}, "none": nil,
+			},
+		},
+		{name: "not an object", body: func(v pcommon.Value) { v.SetStr(`["a"]`) }},
+		{name: "plain string", body: func(v pcommon.Value) { v.SetStr("cart updated") }},
+		{name: "invalid", body: func(v pcommon.Value) { v.SetStr(`{"msg": }`) }},
+		{name: "trailing data", body: func(v pcommon.Value) { v.SetStr(`{"a": 1} {"b": 2}`) }},
+		{name: "too large", body: func(v pcommon.Value) { v.SetStr(`{"msg": "` + strings.Repeat("x", 200) + `"}`) }},
+		{name: "not a string", body: func(v pcommon.Value) { v.SetInt(3) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ld := plog.NewLogs()
+			body := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body()
+			tt.body(body)
+			original := pcommon.NewValueEmpty()
+			body.CopyTo(original)
+
+			parseJSONBodies(ld, 200)
+			if tt.expected == nil {
+				assert.Equal(t, original, body)
+				return
+			}
+			assert.Equal(t, pcommon.ValueTypeMap, body.Type())
+			assert.Equal(t, tt.expected, body.Map().AsRaw())
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -17,15 +18,18 @@ const dataFormatProtobuf = "protobuf"
 // Receiver is the type used to handle logs from OpenTelemetry exporters.
 type Receiver struct {
 	plogotlp.UnimplementedGRPCServer
-	nextConsumer consumer.Logs
-	obsreport    *receiverhelper.ObsReport
+	nextConsumer    consumer.Logs
+	obsreport       *receiverhelper.ObsReport
+	jsonBodyMaxSize int
 }
 
-// New creates a new Receiver reference.
-func New(nextConsumer consumer.Logs, obsreport *receiverhelper.ObsReport) *Receiver {
+// New creates a new Receiver reference. The string bodies of the log records which are JSON objects
+// of up to jsonBodyMaxSize bytes are parsed into maps, zero disables the parsing.
+func New(nextConsumer consumer.Logs, obsreport *receiverhelper.ObsReport, jsonBodyMaxSize int) *Receiver {
 	return &Receiver{
-		nextConsumer: nextConsumer,
-		obsreport:    obsreport,
+		nextConsumer:    nextConsumer,
+		obsreport:       obsreport,
+		jsonBodyMaxSize: jsonBodyMaxSize,
 	}
 }
 
@@ -38,6 +42,9 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	}
 
 	ctx = r.obsreport.StartLogsOp(ctx)
+	if r.jsonBodyMaxSize > 0 {
+		parseJSONBodies(ld, r.jsonBodyMaxSize)
+	}
 	err := r.nextConsumer.ConsumeLogs(ctx, ld)
 	r.obsreport.EndLogsOp(ctx, dataFormatProtobuf, numSpans, err)
 
@@ -48,6 +55,14 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// Permanent errors rejecting only a part of the data are reported as a partial success,
+		// the client must not retry the request.
+		if rejected, ok := consumererror.Rejected(err); ok && consumererror.IsPermanent(err) {
+			resp := plogotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedLogRecords(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return plogotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
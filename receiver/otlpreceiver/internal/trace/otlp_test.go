@@ -69,6 +69,17 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	ld := testdata.GenerateTraces(2)
+	req := ptraceotlp.NewExportRequestFromTraces(ld)
+
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewPartial(errors.New("my error"), 1))))
+	resp, err := traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
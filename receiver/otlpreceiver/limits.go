@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+const (
+	mib = 1024 * 1024
+	// defaultGRPCMaxRecvMsgSize is the maximum message size of a gRPC server when none is configured.
+	defaultGRPCMaxRecvMsgSize = 4 * mib
+	// defaultHTTPMaxRequestBodySize is the maximum request body size of an HTTP server when none is configured.
+	defaultHTTPMaxRequestBodySize = 20 * mib
+)
+
+var (
+	tracesSizer  = &ptrace.ProtoMarshaler{}
+	metricsSizer = &pmetric.ProtoMarshaler{}
+	logsSizer    = &plog.ProtoMarshaler{}
+)
+
+// sizeLimits holds the maximum request size of each signal for a protocol.
+type sizeLimits struct {
+	traces  int64
+	metrics int64
+	logs    int64
+}
+
+// newSizeLimits returns the limits of the signals given the limit of the protocol and the per-signal overrides,
+// where zero keeps the limit of the protocol.
+func newSizeLimits(protocol, traces, metrics, logs int64) sizeLimits {
+	override := func(limit int64) int64 {
+		if limit > 0 {
+			return limit
+		}
+		return protocol
+	}
+	return sizeLimits{traces: override(traces), metrics: override(metrics), logs: override(logs)}
+}
+
+// server returns the limit of the server, which must accept the requests of all signals.
+func (sl sizeLimits) server() int64 {
+	return max(sl.traces, sl.metrics, sl.logs)
+}
+
+// grpcSizeLimits returns the maximum message size of each signal for the given gRPC server.
+func (cfg *Config) grpcSizeLimits(grpcCfg *configgrpc.ServerConfig) sizeLimits {
+	protocol := int64(defaultGRPCMaxRecvMsgSize)
+	if grpcCfg.MaxRecvMsgSizeMiB > 0 {
+		protocol = int64(grpcCfg.MaxRecvMsgSizeMiB) * mib
+	}
+	return newSizeLimits(protocol,
+		int64(cfg.Limits.Traces.MaxRecvMsgSizeMiB)*mib,
+		int64(cfg.Limits.Metrics.MaxRecvMsgSizeMiB)*mib,
+		int64(cfg.Limits.Logs.MaxRecvMsgSizeMiB)*mib)
+}
+
+// httpSizeLimits returns the maximum request body size of each signal for the given HTTP server.
+func (cfg *Config) httpSizeLimits(httpCfg *HTTPConfig) sizeLimits {
+	protocol := int64(defaultHTTPMaxRequestBodySize)
+	if httpCfg.MaxRequestBodySize > 0 {
+		protocol = httpCfg.MaxRequestBodySize
+	}
+	return newSizeLimits(protocol,
+		cfg.Limits.Traces.MaxRequestBodySize,
+		cfg.Limits.Metrics.MaxRequestBodySize,
+		cfg.Limits.Logs.MaxRequestBodySize)
+}
+
+// newTooLargeStatus returns the status of a request rejected because it exceeds the maximum size of its signal.
+// RESOURCE_EXHAUSTED without retry information tells the clients not to retry the same request,
+// which they should split instead.
+func newTooLargeStatus(dataType component.DataType, limit int64) *status.Status {
+	return status.New(codes.ResourceExhausted, fmt.Sprintf("%s request exceeds the maximum size of %d bytes", dataType, limit))
+}
+
+// tracesSizeLimiter rejects the gRPC requests larger than the maximum message size of traces,
+// when it is lower than the maximum message size of the server.
+type tracesSizeLimiter struct {
+	ptraceotlp.GRPCServer
+	limit      int64
+	onTooLarge func(context.Context)
+}
+
+func (l *tracesSizeLimiter) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	if int64(tracesSizer.TracesSize(req.Traces())) > l.limit {
+		l.onTooLarge(ctx)
+		return ptraceotlp.NewExportResponse(), newTooLargeStatus(component.DataTypeTraces, l.limit).Err()
+	}
+	return l.GRPCServer.Export(ctx, req)
+}
+
+// metricsSizeLimiter rejects the gRPC requests larger than the maximum message size of metrics,
+// when it is lower than the maximum message size of the server.
+type metricsSizeLimiter struct {
+	pmetricotlp.GRPCServer
+	limit      int64
+	onTooLarge func(context.Context)
+}
+
+func (l *metricsSizeLimiter) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	if int64(metricsSizer.MetricsSize(req.Metrics())) > l.limit {
+		l.onTooLarge(ctx)
+		return pmetricotlp.NewExportResponse(), newTooLargeStatus(component.DataTypeMetrics, l.limit).Err()
+	}
+	return l.GRPCServer.Export(ctx, req)
+}
+
+// logsSizeLimiter rejects the gRPC requests larger than the maximum message size of logs,
+// when it is lower than the maximum message size of the server.
+type logsSizeLimiter struct {
+	plogotlp.GRPCServer
+	limit      int64
+	onTooLarge func(context.Context)
+}
+
+func (l *logsSizeLimiter) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	if int64(logsSizer.LogsSize(req.Logs())) > l.limit {
+		l.onTooLarge(ctx)
+		return plogotlp.NewExportResponse(), newTooLargeStatus(component.DataTypeLogs, l.limit).Err()
+	}
+	return l.GRPCServer.Export(ctx, req)
+}
@@ -10,28 +10,38 @@ import (
 	"net/http"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
+const (
+	transportGRPC = "grpc"
+	transportHTTP = "http"
+)
+
 // otlpReceiver is the type that exposes Trace and Metrics reception.
 type otlpReceiver struct {
-	cfg        *Config
-	serverGRPC *grpc.Server
-	serverHTTP *http.Server
+	cfg         *Config
+	serversGRPC []*grpc.Server
+	serversHTTP []httpServer
 
 	nextTraces  consumer.Traces
 	nextMetrics consumer.Metrics
@@ -41,9 +51,34 @@ type otlpReceiver struct {
 	obsrepGRPC *receiverhelper.ObsReport
 	obsrepHTTP *receiverhelper.ObsReport
 
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	// capturer captures the payloads of the requests, nil if the capture is disabled.
+	capturer *capturer
+
+	// coalescers coalesce the requests of each signal, empty if the coalescing is disabled.
+	coalescers []interface{ shutdown() }
+
 	settings *receiver.Settings
 }
 
+// httpServer is an HTTP server of the receiver with its configuration, used to shut it down.
+type httpServer struct {
+	cfg    *confighttp.ServerConfig
+	server *http.Server
+}
+
+// signalSet selects the signals served by a server.
+type signalSet struct {
+	traces  bool
+	metrics bool
+	logs    bool
+}
+
+func (ss signalSet) any() bool {
+	return ss.traces || ss.metrics || ss.logs
+}
+
 // newOtlpReceiver just creates the OpenTelemetry receiver services. It is the caller's
 // responsibility to invoke the respective Start*Reception methods as well
 // as the various Stop*Reception methods to end it.
@@ -59,7 +94,7 @@ func newOtlpReceiver(cfg *Config, set *receiver.Settings) (*otlpReceiver, error)
 	var err error
 	r.obsrepGRPC, err = receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
 		ReceiverID:             set.ID,
-		Transport:              "grpc",
+		Transport:              transportGRPC,
 		ReceiverCreateSettings: *set,
 	})
 	if err != nil {
@@ -67,42 +102,89 @@ func newOtlpReceiver(cfg *Config, set *receiver.Settings) (*otlpReceiver, error)
 	}
 	r.obsrepHTTP, err = receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
 		ReceiverID:             set.ID,
-		Transport:              "http",
+		Transport:              transportHTTP,
 		ReceiverCreateSettings: *set,
 	})
 	if err != nil {
 		return nil, err
 	}
+	r.telemetryBuilder, err = metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	r.capturer = newCapturer(&cfg.Capture, set.Logger)
 
 	return r, nil
 }
 
-func (r *otlpReceiver) startGRPCServer(host component.Host) error {
+// tooLargeRecorder returns a function counting the requests of the given transport and signal
+// rejected because of their size.
+func (r *otlpReceiver) tooLargeRecorder(transport string, dataType component.DataType) func(context.Context) {
+	attrs := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String(obsmetrics.ReceiverKey, r.settings.ID.String()),
+		attribute.String(obsmetrics.TransportKey, transport),
+		attribute.String(obsmetrics.DataTypeKey, dataType.String()),
+	))
+	return func(ctx context.Context) {
+		r.telemetryBuilder.ReceiverOtlpRequestsTooLarge.Add(ctx, 1, attrs)
+	}
+}
+
+func (r *otlpReceiver) startGRPCServer(host component.Host, cfg *configgrpc.ServerConfig, signals signalSet) error {
 	// If GRPC is not enabled, nothing to start.
-	if r.cfg.GRPC == nil {
+	if cfg == nil {
 		return nil
 	}
 
-	var err error
-	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings); err != nil {
+	// The server accepts the largest message of all signals, the signals with a lower limit check the size
+	// of their messages.
+	limits := r.cfg.grpcSizeLimits(cfg)
+	serverLimit := limits.server()
+	grpcCfg := *cfg
+	grpcCfg.MaxRecvMsgSizeMiB = uint64(serverLimit / mib)
+
+	serverGRPC, err := grpcCfg.ToServer(context.Background(), host, r.settings.TelemetrySettings)
+	if err != nil {
 		return err
 	}
+	r.serversGRPC = append(r.serversGRPC, serverGRPC)
 
-	if r.nextTraces != nil {
-		ptraceotlp.RegisterGRPCServer(r.serverGRPC, trace.New(r.nextTraces, r.obsrepGRPC))
+	if signals.traces && r.nextTraces != nil {
+		var srv ptraceotlp.GRPCServer = trace.New(r.nextTraces, r.obsrepGRPC)
+		if r.capturer != nil {
+			srv = &tracesCapturer{GRPCServer: srv, capturer: r.capturer}
+		}
+		if limits.traces < serverLimit {
+			srv = &tracesSizeLimiter{GRPCServer: srv, limit: limits.traces, onTooLarge: r.tooLargeRecorder(transportGRPC, component.DataTypeTraces)}
+		}
+		ptraceotlp.RegisterGRPCServer(serverGRPC, srv)
 	}
 
-	if r.nextMetrics != nil {
-		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metrics.New(r.nextMetrics, r.obsrepGRPC))
+	if signals.metrics && r.nextMetrics != nil {
+		var srv pmetricotlp.GRPCServer = metrics.New(r.nextMetrics, r.obsrepGRPC)
+		if r.capturer != nil {
+			srv = &metricsCapturer{GRPCServer: srv, capturer: r.capturer}
+		}
+		if limits.metrics < serverLimit {
+			srv = &metricsSizeLimiter{GRPCServer: srv, limit: limits.metrics, onTooLarge: r.tooLargeRecorder(transportGRPC, component.DataTypeMetrics)}
+		}
+		pmetricotlp.RegisterGRPCServer(serverGRPC, srv)
 	}
 
-	if r.nextLogs != nil {
-		plogotlp.RegisterGRPCServer(r.serverGRPC, logs.New(r.nextLogs, r.obsrepGRPC))
+	if signals.logs && r.nextLogs != nil {
+		var srv plogotlp.GRPCServer = logs.New(r.nextLogs, r.obsrepGRPC, r.cfg.Logs.jsonBodyMaxSize())
+		if r.capturer != nil {
+			srv = &logsCapturer{GRPCServer: srv, capturer: r.capturer}
+		}
+		if limits.logs < serverLimit {
+			srv = &logsSizeLimiter{GRPCServer: srv, limit: limits.logs, onTooLarge: r.tooLargeRecorder(transportGRPC, component.DataTypeLogs)}
+		}
+		plogotlp.RegisterGRPCServer(serverGRPC, srv)
 	}
 
-	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", r.cfg.GRPC.NetAddr.Endpoint))
+	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", cfg.NetAddr.Endpoint))
 	var gln net.Listener
-	if gln, err = r.cfg.GRPC.NetAddr.Listen(context.Background()); err != nil {
+	if gln, err = cfg.NetAddr.Listen(context.Background()); err != nil {
 		return err
 	}
 
@@ -110,49 +192,73 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 	go func() {
 		defer r.shutdownWG.Done()
 
-		if errGrpc := r.serverGRPC.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+		if errGrpc := serverGRPC.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errGrpc))
 		}
 	}()
 	return nil
 }
 
-func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host) error {
+func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host, cfg *HTTPConfig, signals signalSet) error {
 	// If HTTP is not enabled, nothing to start.
-	if r.cfg.HTTP == nil {
+	if cfg == nil {
 		return nil
 	}
 
+	limits := r.cfg.httpSizeLimits(cfg)
 	httpMux := http.NewServeMux()
-	if r.nextTraces != nil {
+	if signals.traces && r.nextTraces != nil {
 		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
-		httpMux.HandleFunc(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, httpTracesReceiver)
+		tracesSet := httpSignalSettings{
+			dataType:           component.DataTypeTraces,
+			maxRequestBodySize: limits.traces,
+			onTooLarge:         r.tooLargeRecorder(transportHTTP, component.DataTypeTraces),
+			capturer:           r.capturer,
+		}
+		httpMux.HandleFunc(cfg.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
+			handleTraces(resp, req, httpTracesReceiver, tracesSet)
 		})
 	}
 
-	if r.nextMetrics != nil {
+	if signals.metrics && r.nextMetrics != nil {
 		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
-		httpMux.HandleFunc(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, httpMetricsReceiver)
+		metricsSet := httpSignalSettings{
+			dataType:           component.DataTypeMetrics,
+			maxRequestBodySize: limits.metrics,
+			onTooLarge:         r.tooLargeRecorder(transportHTTP, component.DataTypeMetrics),
+			capturer:           r.capturer,
+		}
+		httpMux.HandleFunc(cfg.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
+			handleMetrics(resp, req, httpMetricsReceiver, metricsSet)
 		})
 	}
 
-	if r.nextLogs != nil {
-		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
-		httpMux.HandleFunc(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleLogs(resp, req, httpLogsReceiver)
+	if signals.logs && r.nextLogs != nil {
+		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP, r.cfg.Logs.jsonBodyMaxSize())
+		logsSet := httpSignalSettings{
+			dataType:           component.DataTypeLogs,
+			maxRequestBodySize: limits.logs,
+			onTooLarge:         r.tooLargeRecorder(transportHTTP, component.DataTypeLogs),
+			capturer:           r.capturer,
+		}
+		httpMux.HandleFunc(cfg.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
+			handleLogs(resp, req, httpLogsReceiver, logsSet)
 		})
 	}
 
-	var err error
-	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, httpMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
+	// The server accepts the largest request body of all signals, each signal then limits its own requests.
+	httpCfg := *cfg.ServerConfig
+	httpCfg.MaxRequestBodySize = limits.server()
+
+	serverHTTP, err := httpCfg.ToServer(ctx, host, r.settings.TelemetrySettings, withTraceContext(httpMux), confighttp.WithErrorHandler(errorHandler))
+	if err != nil {
 		return err
 	}
+	r.serversHTTP = append(r.serversHTTP, httpServer{cfg: cfg.ServerConfig, server: serverHTTP})
 
-	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", r.cfg.HTTP.ServerConfig.Endpoint))
+	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", cfg.ServerConfig.Endpoint))
 	var hln net.Listener
-	if hln, err = r.cfg.HTTP.ServerConfig.ToListener(ctx); err != nil {
+	if hln, err = cfg.ServerConfig.ToListener(ctx); err != nil {
 		return err
 	}
 
@@ -160,26 +266,71 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	go func() {
 		defer r.shutdownWG.Done()
 
-		if errHTTP := r.serverHTTP.Serve(hln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+		if errHTTP := serverHTTP.Serve(hln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
 		}
 	}()
 	return nil
 }
 
+// startProtocols starts the servers of the given protocols, serving the given signals.
+func (r *otlpReceiver) startProtocols(ctx context.Context, host component.Host, protocols *Protocols, signals signalSet) error {
+	if err := r.startGRPCServer(host, protocols.GRPC, signals); err != nil {
+		return err
+	}
+	return r.startHTTPServer(ctx, host, protocols.HTTP, signals)
+}
+
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
-	if err := r.startGRPCServer(host); err != nil {
+	if err := r.startServers(ctx, host); err != nil {
+		// It's possible that some servers were started before one failed to start,
+		// they must be shutdown to ensure no goroutines are leaked.
+		return errors.Join(err, r.Shutdown(ctx))
+	}
+	return nil
+}
+
+func (r *otlpReceiver) startServers(ctx context.Context, host component.Host) error {
+	if err := r.capturer.start(); err != nil {
 		return err
 	}
-	if err := r.startHTTPServer(ctx, host); err != nil {
-		// It's possible that a valid GRPC server configuration was specified,
-		// but an invalid HTTP configuration. If that's the case, the successfully
-		// started GRPC server must be shutdown to ensure no goroutines are leaked.
-		return errors.Join(err, r.Shutdown(ctx))
+	if err := r.startAck(host); err != nil {
+		return err
+	}
+	if err := r.startCoalesce(); err != nil {
+		return err
 	}
 
+	// The signals without their own protocols are served on the servers of the receiver's protocols.
+	shared := signalSet{
+		traces:  !r.cfg.Signals.Traces.Disabled && r.cfg.Signals.Traces.Protocols == nil,
+		metrics: !r.cfg.Signals.Metrics.Disabled && r.cfg.Signals.Metrics.Protocols == nil,
+		logs:    !r.cfg.Signals.Logs.Disabled && r.cfg.Signals.Logs.Protocols == nil,
+	}
+	if shared.any() {
+		if err := r.startProtocols(ctx, host, &r.cfg.Protocols, shared); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range []struct {
+		cfg      SignalConfig
+		consumed bool
+		signals  signalSet
+	}{
+		{r.cfg.Signals.Traces, r.nextTraces != nil, signalSet{traces: true}},
+		{r.cfg.Signals.Metrics, r.nextMetrics != nil, signalSet{metrics: true}},
+		{r.cfg.Signals.Logs, r.nextLogs != nil, signalSet{logs: true}},
+	} {
+		if s.cfg.Disabled || s.cfg.Protocols == nil || !s.consumed {
+			continue
+		}
+		if err := r.startProtocols(ctx, host, s.cfg.Protocols, s.signals); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -187,15 +338,20 @@ func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
 func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 	var err error
 
-	if r.serverHTTP != nil {
-		err = r.serverHTTP.Shutdown(ctx)
+	for _, s := range r.serversHTTP {
+		err = errors.Join(err, s.cfg.Shutdown(ctx, s.server))
 	}
 
-	if r.serverGRPC != nil {
-		r.serverGRPC.GracefulStop()
+	for _, s := range r.serversGRPC {
+		s.GracefulStop()
 	}
 
 	r.shutdownWG.Wait()
+
+	// The batches of the requests replied to before their consumption are still pending.
+	for _, c := range r.coalescers {
+		c.shutdown()
+	}
 	return err
 }
 
@@ -13,6 +13,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -21,6 +22,12 @@ import (
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -39,7 +46,9 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
@@ -748,6 +757,171 @@ func TestGRPCMaxRecvSize(t *testing.T) {
 	assert.Equal(t, td, sink.AllTraces()[0])
 }
 
+func TestGRPCSignalMaxRecvSize(t *testing.T) {
+	tt := setupTestTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.GRPC.MaxRecvMsgSizeMiB = 1
+	cfg.HTTP = nil
+	cfg.Limits.Logs.MaxRecvMsgSizeMiB = 2
+	recv := newReceiver(t, tt.NewSettings().TelemetrySettings, cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+
+	// The traces are larger than the limit of the protocol but accepted by the server.
+	err = exportTraces(cc, testdata.GenerateTraces(10000))
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, "traces request exceeds the maximum size of 1048576 bytes", st.Message())
+	assert.Empty(t, sink.AllTraces())
+
+	// The logs are allowed to be larger than the limit of the protocol.
+	_, err = plogotlp.NewGRPCClient(cc).Export(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(15000)))
+	require.NoError(t, err)
+	assert.Len(t, sink.AllLogs(), 1)
+
+	tt.assertMetrics(t, []metricdata.Metrics{requestsTooLargeMetric(transportGRPC, "traces", 1)})
+}
+
+func TestParseJSONBody(t *testing.T) {
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.Logs.ParseJSONBody = true
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr(`{"msg": "cart updated", "count": 3}`)
+	lrs.AppendEmpty().Body().SetStr("cart updated")
+	buf, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	doHTTPRequest(t, "http://"+httpAddr+defaultLogsURLPath, "", pbContentType, buf, http.StatusOK)
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cc.Close()) })
+	_, err = plogotlp.NewGRPCClient(cc).Export(context.Background(), plogotlp.NewExportRequestFromLogs(ld))
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllLogs(), 2)
+	for _, got := range sink.AllLogs() {
+		records := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		assert.Equal(t, map[string]any{"msg": "cart updated", "count": int64(3)}, records.At(0).Body().Map().AsRaw())
+		assert.Equal(t, "cart updated", records.At(1).Body().Str())
+	}
+}
+
+func TestHTTPSignalMaxRequestBodySize(t *testing.T) {
+	tt := setupTestTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.MaxRequestBodySize = 10
+	cfg.Limits.Logs.MaxRequestBodySize = 1024 * 1024
+	recv := newReceiver(t, tt.NewSettings().TelemetrySettings, cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	tr := generateTracesRequest(t)
+	respBytes := doHTTPRequest(t, "http://"+addr+tr.path, "", pbContentType, tr.protoBytes, http.StatusRequestEntityTooLarge)
+	errStatus := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+	assert.Equal(t, int32(codes.ResourceExhausted), errStatus.Code)
+	assert.Equal(t, "traces request exceeds the maximum size of 10 bytes", errStatus.Message)
+	assert.Empty(t, sink.AllTraces())
+
+	lr := generateLogsRequest(t)
+	doHTTPRequest(t, "http://"+addr+lr.path, "", pbContentType, lr.protoBytes, http.StatusOK)
+	assert.Len(t, sink.AllLogs(), 1)
+
+	tt.assertMetrics(t, []metricdata.Metrics{requestsTooLargeMetric(transportHTTP, "traces", 1)})
+}
+
+func TestSignalProtocols(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	logsAddr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP = nil
+	cfg.Signals.Metrics.Disabled = true
+	cfg.Signals.Logs.Protocols = newDefaultProtocols("", logsAddr)
+	cfg.Signals.Logs.Protocols.GRPC = nil
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+
+	require.NoError(t, exportTraces(cc, testdata.GenerateTraces(1)))
+	assert.Len(t, sink.AllTraces(), 1)
+
+	// The disabled metrics and the logs served on their own server are not served on the shared server.
+	_, err = pmetricotlp.NewGRPCClient(cc).Export(context.Background(), pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(1)))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = plogotlp.NewGRPCClient(cc).Export(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	lr := generateLogsRequest(t)
+	doHTTPRequest(t, "http://"+logsAddr+lr.path, "", pbContentType, lr.protoBytes, http.StatusOK)
+	assert.Len(t, sink.AllLogs(), 1)
+	tr := generateTracesRequest(t)
+	resp, err := http.DefaultClient.Do(createHTTPRequest(t, "http://"+logsAddr+tr.path, "", pbContentType, tr.protoBytes))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func requestsTooLargeMetric(transport string, dataType string, count int64) metricdata.Metrics {
+	return metricdata.Metrics{
+		Name:        "otelcol_receiver_otlp_requests_too_large",
+		Description: "Number of requests rejected because they exceed the maximum request size of their signal.",
+		Unit:        "{requests}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String("receiver", otlpReceiverID.String()),
+						attribute.String("transport", transport),
+						attribute.String("data_type", dataType),
+					),
+					Value: count,
+				},
+			},
+		},
+	}
+}
+
 func TestHTTPInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		Protocols: Protocols{
@@ -814,10 +988,10 @@ func TestHTTPMaxRequestBodySize(t *testing.T) {
 
 	for _, dr := range dataReqs {
 		testHTTPMaxRequestBodySize(t, dr.path, "application/json", dr.jsonBytes, len(dr.jsonBytes), 200)
-		testHTTPMaxRequestBodySize(t, dr.path, "application/json", dr.jsonBytes, len(dr.jsonBytes)-1, 400)
+		testHTTPMaxRequestBodySize(t, dr.path, "application/json", dr.jsonBytes, len(dr.jsonBytes)-1, 413)
 
 		testHTTPMaxRequestBodySize(t, dr.path, "application/x-protobuf", dr.protoBytes, len(dr.protoBytes), 200)
-		testHTTPMaxRequestBodySize(t, dr.path, "application/x-protobuf", dr.protoBytes, len(dr.protoBytes)-1, 400)
+		testHTTPMaxRequestBodySize(t, dr.path, "application/x-protobuf", dr.protoBytes, len(dr.protoBytes)-1, 413)
 	}
 }
 
@@ -1194,3 +1368,128 @@ func (esc *errOrSinkConsumer) checkData(t *testing.T, data any, len int) {
 		}
 	}
 }
+
+func TestHTTPTraceContextPropagation(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	set := receivertest.NewNopSettings()
+	set.ID = otlpReceiverID
+	r, err := newOtlpReceiver(cfg, &set)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var gotSpanContext oteltrace.SpanContext
+	var gotBaggage baggage.Baggage
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		gotSpanContext = oteltrace.SpanContextFromContext(ctx)
+		gotBaggage = baggage.FromContext(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	r.registerTraceConsumer(tc)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	traceProto := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(1))
+	body, err := traceProto.MarshalProto()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	req.Header.Set("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+	req.Header.Set("tracestate", "vendor=value")
+	req.Header.Set("baggage", "tenant=acme,region=eu")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", gotSpanContext.TraceID().String())
+	assert.Equal(t, "0102030405060708", gotSpanContext.SpanID().String())
+	assert.Equal(t, "vendor=value", gotSpanContext.TraceState().String())
+	assert.True(t, gotSpanContext.IsRemote())
+	assert.Equal(t, "acme", gotBaggage.Member("tenant").Value())
+	assert.Equal(t, "eu", gotBaggage.Member("region").Value())
+}
+
+func TestBaggageAttributes(t *testing.T) {
+	var members []baggage.Member
+	for i := maxBaggageAttributes + 3; i >= 0; i-- {
+		m, err := baggage.NewMemberRaw(fmt.Sprintf("key%02d", i), "value")
+		require.NoError(t, err)
+		members = append(members, m)
+	}
+	long, err := baggage.NewMemberRaw("key00", strings.Repeat("é", maxBaggageAttributeLength))
+	require.NoError(t, err)
+	members[len(members)-1] = long
+	bag, err := baggage.New(members...)
+	require.NoError(t, err)
+
+	attrs := baggageAttributes(bag)
+	require.Len(t, attrs, maxBaggageAttributes)
+	assert.Equal(t, attribute.Key("baggage.key00"), attrs[0].Key)
+	assert.Equal(t, attribute.Key(fmt.Sprintf("baggage.key%02d", maxBaggageAttributes-1)), attrs[maxBaggageAttributes-1].Key)
+	assert.Equal(t, strings.Repeat("é", maxBaggageAttributeLength/2), attrs[0].Value.AsString())
+	assert.Equal(t, "value", attrs[1].Value.AsString())
+}
+
+func TestWithTraceContextRecordsOnSpan(t *testing.T) {
+	sr := new(tracetest.SpanRecorder)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	tests := []struct {
+		name      string
+		parent    string
+		wantLinks int
+	}{
+		{
+			name:      "unrelated local span",
+			wantLinks: 1,
+		},
+		{
+			name:      "local span in the incoming trace",
+			parent:    "0102030405060708090a0b0c0d0e0f10",
+			wantLinks: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.parent != "" {
+				traceID, err := oteltrace.TraceIDFromHex(tt.parent)
+				require.NoError(t, err)
+				ctx = oteltrace.ContextWithRemoteSpanContext(ctx, oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+					TraceID:    traceID,
+					SpanID:     oteltrace.SpanID{1},
+					TraceFlags: oteltrace.FlagsSampled,
+				}))
+			}
+			ctx, span := tp.Tracer("test").Start(ctx, tt.name)
+
+			var gotSpanContext oteltrace.SpanContext
+			handler := withTraceContext(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+				gotSpanContext = oteltrace.SpanContextFromContext(req.Context())
+			}))
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/v1/traces", nil)
+			require.NoError(t, err)
+			req.Header.Set("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+			req.Header.Set("baggage", "tenant=acme")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			span.End()
+
+			// The local span stays the parent of the spans started while handling the request.
+			assert.Equal(t, span.SpanContext(), gotSpanContext)
+			ended := sr.Ended()
+			recorded := ended[len(ended)-1]
+			assert.Len(t, recorded.Links(), tt.wantLinks)
+			assert.Contains(t, recorded.Attributes(), attribute.String("baggage.tenant", "acme"))
+		})
+	}
+}
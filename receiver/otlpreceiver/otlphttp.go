@@ -4,19 +4,27 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"sort"
+	"unicode/utf8"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/internal/httphelper"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
-	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	tracereceiver "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
 )
 
 // Pre-computed status with code=Internal to be used in case of a marshaling error.
@@ -24,18 +32,101 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
+const (
+	// baggageAttributePrefix is the prefix of the span attributes recording the incoming baggage members.
+	baggageAttributePrefix = "baggage."
+	// maxBaggageAttributes is the maximum number of baggage members recorded as span attributes.
+	maxBaggageAttributes = 16
+	// maxBaggageAttributeLength is the maximum length of the values of the baggage members recorded as span
+	// attributes, longer values are truncated.
+	maxBaggageAttributeLength = 256
+)
+
+// traceContextPropagator extracts the W3C traceparent, tracestate and baggage headers.
+var traceContextPropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// withTraceContext extracts the W3C trace context and baggage of incoming requests, independently of the
+// propagators configured for the collector's own telemetry, so the data received can be correlated with the
+// client's trace.
+//
+// If the request context has no span yet, the incoming trace context becomes the remote parent of the spans
+// started while handling the request. Otherwise the span is linked to the incoming trace context, unless it
+// is already part of the same trace. The first baggage members in the order of their keys are recorded as attributes
+// of the span, up to maxBaggageAttributes, with their values truncated to maxBaggageAttributeLength.
+func withTraceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		extracted := traceContextPropagator.Extract(context.Background(), propagation.HeaderCarrier(req.Header))
+
+		remote := trace.SpanContextFromContext(extracted)
+		span := trace.SpanFromContext(ctx)
+		switch local := span.SpanContext(); {
+		case !remote.IsValid():
+		case !local.IsValid():
+			ctx = trace.ContextWithRemoteSpanContext(ctx, remote)
+		case local.TraceID() != remote.TraceID():
+			span.AddLink(trace.Link{SpanContext: remote})
+		}
+
+		if bag := baggage.FromContext(extracted); bag.Len() > 0 {
+			if baggage.FromContext(ctx).Len() == 0 {
+				ctx = baggage.ContextWithBaggage(ctx, bag)
+			}
+			if span.IsRecording() {
+				span.SetAttributes(baggageAttributes(bag)...)
+			}
+		}
+		next.ServeHTTP(resp, req.WithContext(ctx))
+	})
+}
+
+// baggageAttributes returns the span attributes recording the baggage members, see withTraceContext.
+func baggageAttributes(bag baggage.Baggage) []attribute.KeyValue {
+	members := bag.Members()
+	sort.Slice(members, func(i, j int) bool { return members[i].Key() < members[j].Key() })
+	if len(members) > maxBaggageAttributes {
+		members = members[:maxBaggageAttributes]
+	}
+	attrs := make([]attribute.KeyValue, 0, len(members))
+	for _, m := range members {
+		value := m.Value()
+		if len(value) > maxBaggageAttributeLength {
+			// Do not split a multi-byte character.
+			end := maxBaggageAttributeLength
+			for end > 0 && !utf8.RuneStart(value[end]) {
+				end--
+			}
+			value = value[:end]
+		}
+		attrs = append(attrs, attribute.String(baggageAttributePrefix+m.Key(), value))
+	}
+	return attrs
+}
+
+// httpSignalSettings holds the settings of the HTTP handler of a signal.
+type httpSignalSettings struct {
+	dataType component.DataType
+	// maxRequestBodySize is the maximum request body size of the signal, no limit is applied if not positive.
+	maxRequestBodySize int64
+	// onTooLarge, if set, is called for every request rejected because of its size.
+	onTooLarge func(context.Context)
+	// capturer captures the request bodies, nil if the capture is disabled.
+	capturer *capturer
+}
+
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *tracereceiver.Receiver, set httpSignalSettings) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	body, ok := readAndCloseBody(resp, req, enc, set)
 	if !ok {
 		return
 	}
 
 	otlpReq, err := enc.unmarshalTracesRequest(body)
+	set.capturer.captureBody(set.dataType, enc.contentType(), err == nil, body)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusBadRequest)
 		return
@@ -55,18 +146,19 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, set httpSignalSettings) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	body, ok := readAndCloseBody(resp, req, enc, set)
 	if !ok {
 		return
 	}
 
 	otlpReq, err := enc.unmarshalMetricsRequest(body)
+	set.capturer.captureBody(set.dataType, enc.contentType(), err == nil, body)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusBadRequest)
 		return
@@ -86,18 +178,19 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, set httpSignalSettings) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	body, ok := readAndCloseBody(resp, req, enc, set)
 	if !ok {
 		return
 	}
 
 	otlpReq, err := enc.unmarshalLogsRequest(body)
+	set.capturer.captureBody(set.dataType, enc.contentType(), err == nil, body)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusBadRequest)
 		return
@@ -134,13 +227,26 @@ func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool
 	}
 }
 
-func readAndCloseBody(resp http.ResponseWriter, req *http.Request, enc encoder) ([]byte, bool) {
-	body, err := io.ReadAll(req.Body)
+// readAndCloseBody reads the request body, rejecting it with 413 Payload Too Large if it exceeds the maximum
+// request body size of the signal or of the server.
+func readAndCloseBody(resp http.ResponseWriter, req *http.Request, enc encoder, set httpSignalSettings) ([]byte, bool) {
+	reqBody := req.Body
+	if set.maxRequestBodySize > 0 {
+		reqBody = http.MaxBytesReader(resp, reqBody, set.maxRequestBodySize)
+	}
+	body, err := io.ReadAll(reqBody)
 	if err != nil {
+		if maxBytesErr, ok := err.(*http.MaxBytesError); ok {
+			if set.onTooLarge != nil {
+				set.onTooLarge(req.Context())
+			}
+			writeStatusResponse(resp, enc, http.StatusRequestEntityTooLarge, newTooLargeStatus(set.dataType, maxBytesErr.Limit).Proto())
+			return nil, false
+		}
 		writeError(resp, enc, err, http.StatusBadRequest)
 		return nil, false
 	}
-	if err = req.Body.Close(); err != nil {
+	if err = reqBody.Close(); err != nil {
 		writeError(resp, enc, err, http.StatusBadRequest)
 		return nil, false
 	}
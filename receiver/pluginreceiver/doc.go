@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package pluginreceiver runs a receiver served by a plugin executable, out of the collector process.
+package pluginreceiver // import "go.opentelemetry.io/collector/receiver/pluginreceiver"
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginreceiver // import "go.opentelemetry.io/collector/receiver/pluginreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/plugin/host"
+	"go.opentelemetry.io/collector/receiver/pluginreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// NewFactory returns a receiver.Factory for the plugin receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, metadata.TracesStability),
+		receiver.WithMetrics(createMetrics, metadata.MetricsStability),
+		receiver.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	cfg := host.NewDefaultConfig()
+	return &cfg
+}
+
+// createTraces creates a receiver running the plugin in a traces pipeline.
+func createTraces(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindReceiver, component.DataTypeTraces, next), nil
+}
+
+// createMetrics creates a receiver running the plugin in a metrics pipeline.
+func createMetrics(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindReceiver, component.DataTypeMetrics, next), nil
+}
+
+// createLogs creates a receiver running the plugin in a logs pipeline.
+func createLogs(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	return host.New(cfg.(*Config), set.TelemetrySettings, set.ID, component.KindReceiver, component.DataTypeLogs, next), nil
+}
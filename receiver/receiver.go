@@ -4,6 +4,7 @@
 package receiver // import "go.opentelemetry.io/collector/receiver"
 
 import (
+	"context"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -31,6 +32,16 @@ type Metrics = internal.Metrics
 // For example, it could be a receiver that reads syslogs and convert them into plog.Logs.
 type Logs = internal.Logs
 
+// Pausable is an optional interface implemented by the receivers which can stop receiving data while paused,
+// e.g. stop scraping, and resume without being restarted. The data received by the receivers not implementing
+// it while they are paused is refused by the collector.
+type Pausable interface {
+	// Pause stops receiving data until Resume is called. It is a no-op if the receiver is already paused.
+	Pause(ctx context.Context) error
+	// Resume starts receiving data again. It is a no-op if the receiver is not paused.
+	Resume(ctx context.Context) error
+}
+
 // Settings configures Receiver creators.
 type Settings = internal.Settings
 
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverentities // import "go.opentelemetry.io/collector/receiver/receiverentities"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/internal"
+)
+
+// Entities receiver receives entity events.
+// Its purpose is to translate data from any format to the collector's internal entity events format.
+// EntitiesReceiver feeds a consumerentities.Entities with data.
+//
+// For example, it could be a Kubernetes watcher which translates the changes of the pods into pentity.Entities.
+type Entities = internal.Entities
+
+// CreateEntitiesFunc is the equivalent of Factory.CreateEntities.
+type CreateEntitiesFunc = internal.CreateEntitiesFunc
+
+// WithEntities overrides the default "error not supported" implementation for CreateEntitiesReceiver and the default "undefined" stability level.
+func WithEntities(createEntitiesReceiver CreateEntitiesFunc, sl component.StabilityLevel) receiver.FactoryOption {
+	return internal.WithEntities(createEntitiesReceiver, sl)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverentities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func TestNewFactoryWithEntities(t *testing.T) {
+	var testType = component.MustNewType("test")
+	defaultCfg := struct{}{}
+	factory := receiver.NewFactory(
+		testType,
+		func() component.Config { return &defaultCfg },
+		WithEntities(createEntities, component.StabilityLevelAlpha),
+	)
+	assert.EqualValues(t, testType, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+
+	assert.Equal(t, component.StabilityLevelAlpha, factory.EntitiesReceiverStability())
+	_, err := factory.CreateEntitiesReceiver(context.Background(), receiver.Settings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+}
+
+var nopInstance = &nopReceiver{
+	Consumer: consumertest.NewNop(),
+}
+
+// nopReceiver stores consumed traces and metrics for testing purposes.
+type nopReceiver struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+}
+
+func createEntities(context.Context, receiver.Settings, component.Config, consumerentities.Entities) (Entities, error) {
+	return nopInstance, nil
+}
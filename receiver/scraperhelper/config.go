@@ -26,8 +26,14 @@ type ControllerConfig struct {
 	// InitialDelay sets the initial start delay for the scraper,
 	// any non positive value is assumed to be immediately.
 	InitialDelay time.Duration `mapstructure:"initial_delay"`
-	// Timeout is an optional value used to set scraper's context deadline.
+	// Timeout is an optional value used to set the context deadline of each
+	// scraper, so that a slow scraper cannot hold the others and the next
+	// collections. When zero, the collection interval is used.
 	Timeout time.Duration `mapstructure:"timeout"`
+	// Jitter is the upper bound of a random delay added to the initial delay,
+	// so that the collectors started at the same instant spread their scrapes
+	// over the collection interval. Zero disables the jitter.
+	Jitter time.Duration `mapstructure:"jitter"`
 }
 
 // NewDefaultControllerConfig returns default scraper controller
@@ -37,6 +43,7 @@ func NewDefaultControllerConfig() ControllerConfig {
 		CollectionInterval: time.Minute,
 		InitialDelay:       time.Second,
 		Timeout:            0,
+		Jitter:             0,
 	}
 }
 
@@ -47,5 +54,8 @@ func (set *ControllerConfig) Validate() (errs error) {
 	if set.Timeout < 0 {
 		errs = multierr.Append(errs, fmt.Errorf(`"timeout": %w`, errNonPositiveInterval))
 	}
+	if set.Jitter < 0 {
+		errs = multierr.Append(errs, fmt.Errorf(`"jitter": %w`, errNonPositiveInterval))
+	}
 	return errs
 }
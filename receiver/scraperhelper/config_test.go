@@ -36,6 +36,14 @@ func TestScrapeControllerSettings(t *testing.T) {
 			},
 			errVal: `"timeout": requires positive value`,
 		},
+		{
+			name: "invalid jitter",
+			set: ControllerConfig{
+				CollectionInterval: time.Minute,
+				Jitter:             -1 * time.Second,
+			},
+			errVal: `"jitter": requires positive value`,
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
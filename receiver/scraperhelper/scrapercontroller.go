@@ -6,6 +6,8 @@ package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperh
 import (
 	"context"
 	"errors"
+	"math/rand/v2"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/multierr"
@@ -19,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
+var _ receiver.Pausable = (*controller)(nil)
+
 // ScraperControllerOption apply changes to internal options.
 type ScraperControllerOption func(*controller)
 
@@ -47,14 +51,21 @@ type controller struct {
 	logger             *zap.Logger
 	collectionInterval time.Duration
 	initialDelay       time.Duration
+	jitter             time.Duration
 	timeout            time.Duration
 	nextConsumer       consumer.Metrics
 
+	// randDuration returns a random duration in [0, d), overridden by tests.
+	randDuration func(d time.Duration) time.Duration
+
 	scrapers    []Scraper
 	obsScrapers []*ObsReport
 
 	tickerCh <-chan time.Time
 
+	// The scrapes are skipped while the receiver is paused.
+	paused atomic.Bool
+
 	initialized bool
 	done        chan struct{}
 	terminated  chan struct{}
@@ -84,13 +95,20 @@ func NewScraperControllerReceiver(
 		return nil, err
 	}
 
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = cfg.CollectionInterval
+	}
+
 	sc := &controller{
 		id:                 set.ID,
 		logger:             set.Logger,
 		collectionInterval: cfg.CollectionInterval,
 		initialDelay:       cfg.InitialDelay,
-		timeout:            cfg.Timeout,
+		jitter:             cfg.Jitter,
+		timeout:            timeout,
 		nextConsumer:       nextConsumer,
+		randDuration:       rand.N[time.Duration],
 		done:               make(chan struct{}),
 		terminated:         make(chan struct{}),
 		obsrecv:            obsrecv,
@@ -149,12 +167,33 @@ func (sc *controller) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// Pause the scraping, invoked when the receiver is paused at runtime.
+func (sc *controller) Pause(context.Context) error {
+	sc.paused.Store(true)
+	return nil
+}
+
+// Resume the scraping, invoked when the receiver is resumed at runtime.
+func (sc *controller) Resume(context.Context) error {
+	sc.paused.Store(false)
+	return nil
+}
+
 // startScraping initiates a ticker that calls Scrape based on the configured
-// collection interval.
+// collection interval, after the initial delay and the jitter.
 func (sc *controller) startScraping() {
 	go func() {
-		if sc.initialDelay > 0 {
-			<-time.After(sc.initialDelay)
+		delay := sc.initialDelay
+		if sc.jitter > 0 {
+			delay += sc.randDuration(sc.jitter)
+		}
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-sc.done:
+				sc.terminated <- struct{}{}
+				return
+			}
 		}
 
 		if sc.tickerCh == nil {
@@ -183,33 +222,44 @@ func (sc *controller) startScraping() {
 // Scrapers, records observability information, and passes the scraped metrics
 // to the next component.
 func (sc *controller) scrapeMetricsAndReport() {
-	ctx, done := withScrapeContext(sc.timeout)
-	defer done()
-
+	if sc.paused.Load() {
+		return
+	}
 	metrics := pmetric.NewMetrics()
 
 	for i, scraper := range sc.scrapers {
-		scrp := sc.obsScrapers[i]
-		ctx = scrp.StartMetricsOp(ctx)
-		md, err := scraper.Scrape(ctx)
-
-		if err != nil {
-			sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
-			if !scrapererror.IsPartialScrapeError(err) {
-				scrp.EndMetricsOp(ctx, 0, err)
-				continue
-			}
+		md, err := sc.scrape(scraper, sc.obsScrapers[i])
+		if err != nil && !scrapererror.IsPartialScrapeError(err) {
+			continue
 		}
-		scrp.EndMetricsOp(ctx, md.MetricCount(), err)
 		md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
 	}
 
 	dataPointCount := metrics.DataPointCount()
-	ctx = sc.obsrecv.StartMetricsOp(ctx)
+	ctx := sc.obsrecv.StartMetricsOp(context.Background())
 	err := sc.nextConsumer.ConsumeMetrics(ctx, metrics)
 	sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
 }
 
+// scrape calls the Scrape function of the scraper with its own deadline,
+// and records observability information.
+func (sc *controller) scrape(scraper Scraper, scrp *ObsReport) (pmetric.Metrics, error) {
+	ctx, done := withScrapeContext(sc.timeout)
+	defer done()
+
+	ctx = scrp.StartMetricsOp(ctx)
+	md, err := scraper.Scrape(ctx)
+	if err != nil {
+		sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
+		if !scrapererror.IsPartialScrapeError(err) {
+			scrp.EndMetricsOp(ctx, 0, err)
+			return md, err
+		}
+	}
+	scrp.EndMetricsOp(ctx, md.MetricCount(), err)
+	return md, err
+}
+
 // stopScraping stops the ticker
 func (sc *controller) stopScraping() {
 	close(sc.done)
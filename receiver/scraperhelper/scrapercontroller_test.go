@@ -425,3 +425,161 @@ func TestScrapeControllerInitialDelay(t *testing.T) {
 
 	assert.NoError(t, r.Shutdown(context.Background()), "Must not error closing down")
 }
+
+func TestScrapeControllerJitter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("This requires real time to pass, skipping")
+		return
+	}
+
+	t.Parallel()
+
+	elapsed := make(chan time.Time, 1)
+	scp, err := NewScraper("timed", func(context.Context) (pmetric.Metrics, error) {
+		elapsed <- time.Now()
+		return pmetric.NewMetrics(), nil
+	})
+	require.NoError(t, err, "Must not error when creating scraper")
+
+	r, err := NewScraperControllerReceiver(
+		&ControllerConfig{
+			CollectionInterval: time.Second,
+			InitialDelay:       100 * time.Millisecond,
+			Jitter:             time.Second,
+		},
+		receivertest.NewNopSettings(),
+		new(consumertest.MetricsSink),
+		AddScraper(scp),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	var jitter time.Duration
+	r.(*controller).randDuration = func(d time.Duration) time.Duration {
+		jitter = d
+		return 200 * time.Millisecond
+	}
+
+	t0 := time.Now()
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Must not error when starting")
+	t1 := <-elapsed
+
+	assert.Equal(t, time.Second, jitter, "Must have drawn the delay within the configured jitter")
+	assert.GreaterOrEqual(t, t1.Sub(t0), 300*time.Millisecond, "Must have waited for the initial delay and the jitter")
+
+	assert.NoError(t, r.Shutdown(context.Background()), "Must not error closing down")
+}
+
+func TestScrapeControllerShutdownDuringInitialDelay(t *testing.T) {
+	t.Parallel()
+
+	scp, err := NewScraper("scraper", func(context.Context) (pmetric.Metrics, error) {
+		return pmetric.NewMetrics(), nil
+	})
+	require.NoError(t, err, "Must not error when creating scraper")
+
+	r, err := NewScraperControllerReceiver(
+		&ControllerConfig{
+			CollectionInterval: time.Second,
+			InitialDelay:       time.Hour,
+		},
+		receivertest.NewNopSettings(),
+		new(consumertest.MetricsSink),
+		AddScraper(scp),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Must not error when starting")
+	assert.NoError(t, r.Shutdown(context.Background()), "Must not wait for the initial delay to shut down")
+}
+
+func TestScrapeControllerTimeout(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name             string
+		timeout          time.Duration
+		expectedDeadline time.Duration
+	}{
+		{
+			name:             "configured timeout",
+			timeout:          time.Minute,
+			expectedDeadline: time.Minute,
+		},
+		{
+			name:             "collection interval",
+			expectedDeadline: time.Hour,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var deadlines []time.Duration
+			newScraper := func(name string) Scraper {
+				scp, err := NewScraper(name, func(ctx context.Context) (pmetric.Metrics, error) {
+					deadline, ok := ctx.Deadline()
+					assert.True(t, ok, "Must have a deadline")
+					deadlines = append(deadlines, time.Until(deadline))
+					return pmetric.NewMetrics(), nil
+				})
+				require.NoError(t, err, "Must not error when creating scraper")
+				return scp
+			}
+
+			tickerCh := make(chan time.Time)
+			r, err := NewScraperControllerReceiver(
+				&ControllerConfig{
+					CollectionInterval: time.Hour,
+					Timeout:            tc.timeout,
+				},
+				receivertest.NewNopSettings(),
+				new(consumertest.MetricsSink),
+				AddScraper(newScraper("first")),
+				AddScraper(newScraper("second")),
+				WithTickerChannel(tickerCh),
+			)
+			require.NoError(t, err, "Must not error when creating receiver")
+
+			require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Must not error when starting")
+			// The scrape on start is done when the ticker channel is read.
+			tickerCh <- time.Now()
+			require.NoError(t, r.Shutdown(context.Background()), "Must not error closing down")
+
+			require.Len(t, deadlines, 4)
+			for _, deadline := range deadlines {
+				assert.InDelta(t, tc.expectedDeadline, deadline, float64(time.Second), "Must have a deadline per scraper")
+			}
+		})
+	}
+}
+
+func TestScrapeControllerPause(t *testing.T) {
+	tsm := &testScrapeMetrics{ch: make(chan int, 10)}
+	scp, err := NewScraper("scraper", tsm.scrape)
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	r, err := NewScraperControllerReceiver(
+		newTestNoDelaySettings(),
+		receivertest.NewNopSettings(),
+		new(consumertest.MetricsSink),
+		AddScraper(scp),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	pausable, ok := r.(receiver.Pausable)
+	require.True(t, ok, "Must be pausable")
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+	assert.Equal(t, 1, <-tsm.ch, "Must have scraped on start")
+
+	require.NoError(t, pausable.Pause(context.Background()))
+	// The second tick is only received once the first one was handled.
+	tickerCh <- time.Now()
+	tickerCh <- time.Now()
+	assert.Empty(t, tsm.ch, "Must not scrape while paused")
+
+	require.NoError(t, pausable.Resume(context.Background()))
+	tickerCh <- time.Now()
+	assert.Equal(t, 2, <-tsm.ch, "Must scrape once resumed")
+}
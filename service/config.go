@@ -4,9 +4,15 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/discovery"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/limitsconsumer"
+	"go.opentelemetry.io/collector/service/internal/watchdog"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -21,6 +27,139 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// Discovery configures the receivers instantiated only for the endpoints discovered by the observer extensions.
+	Discovery discovery.Config `mapstructure:"discovery"`
+
+	// Memory is the configuration of the soft memory limit of the Go runtime.
+	Memory MemoryConfig `mapstructure:"memory"`
+
+	// ResourceDetection configures the resource attributes describing the collector set on the exported data.
+	ResourceDetection ResourceDetectionConfig `mapstructure:"resource_detection"`
+
+	// Limits configures the limits enforced on the data received by the receivers.
+	Limits LimitsConfig `mapstructure:"limits"`
+
+	// Watchdog configures the detection of the components of the pipelines which stopped making progress.
+	Watchdog WatchdogConfig `mapstructure:"watchdog"`
+}
+
+// LimitsConfig defines the limits enforced on the data received by the receivers, before it enters the pipelines.
+// The attributes, span events and span links exceeding the limits are dropped and the attribute values exceeding
+// the limits are truncated, and the dropped counts of the data are incremented accordingly.
+type LimitsConfig struct {
+	// ReceiverLimits are the limits enforced on the data received by all the receivers.
+	ReceiverLimits `mapstructure:",squash"`
+
+	// Receivers override the limits for specific receivers. The limits not set for a receiver are the ones
+	// of all the receivers.
+	Receivers map[component.ID]ReceiverLimits `mapstructure:"receivers"`
+}
+
+// ReceiverLimits are the limits enforced on the data received by a receiver. Zero, the default, disables a limit.
+type ReceiverLimits struct {
+	// MaxAttributes is the maximum number of attributes of a resource, a scope, a span, a span event, a span link
+	// or a log record. The attributes of the metric data points are not limited.
+	MaxAttributes int `mapstructure:"max_attributes"`
+
+	// MaxAttributeValueLength is the maximum length, in bytes, of the string attribute values, including the
+	// strings nested in arrays and maps.
+	MaxAttributeValueLength int `mapstructure:"max_attribute_value_length"`
+
+	// MaxSpanEvents is the maximum number of events of a span.
+	MaxSpanEvents int `mapstructure:"max_span_events"`
+
+	// MaxSpanLinks is the maximum number of links of a span.
+	MaxSpanLinks int `mapstructure:"max_span_links"`
+}
+
+func (l ReceiverLimits) validate() error {
+	if l.MaxAttributes < 0 {
+		return errors.New("'max_attributes' must be non-negative")
+	}
+	if l.MaxAttributeValueLength < 0 {
+		return errors.New("'max_attribute_value_length' must be non-negative")
+	}
+	if l.MaxSpanEvents < 0 {
+		return errors.New("'max_span_events' must be non-negative")
+	}
+	if l.MaxSpanLinks < 0 {
+		return errors.New("'max_span_links' must be non-negative")
+	}
+	return nil
+}
+
+func (l ReceiverLimits) limits() limitsconsumer.Limits {
+	return limitsconsumer.Limits{
+		MaxAttributes:           l.MaxAttributes,
+		MaxAttributeValueLength: l.MaxAttributeValueLength,
+		MaxSpanEvents:           l.MaxSpanEvents,
+		MaxSpanLinks:            l.MaxSpanLinks,
+	}
+}
+
+// receiverLimits returns the limits overridden for specific receivers.
+func (cfg LimitsConfig) receiverLimits() map[component.ID]limitsconsumer.Limits {
+	if len(cfg.Receivers) == 0 {
+		return nil
+	}
+	limits := make(map[component.ID]limitsconsumer.Limits, len(cfg.Receivers))
+	for id, l := range cfg.Receivers {
+		limits[id] = l.limits()
+	}
+	return limits
+}
+
+// WatchdogConfig defines how the processors, exporters and connectors which stopped making progress are detected
+// and reported. A component stalls when the data sent to it is neither consumed successfully nor rejected for
+// longer than the stall threshold, because a call to the component is wedged or keeps failing. The components
+// waiting on a stalled component downstream are not stalled themselves.
+type WatchdogConfig struct {
+	// StallThreshold is the duration after which a component with pending data and no progress is stalled.
+	// Zero, the default, disables the watchdog.
+	StallThreshold time.Duration `mapstructure:"stall_threshold"`
+}
+
+func (cfg WatchdogConfig) validate() error {
+	if cfg.StallThreshold < 0 {
+		return errors.New("'stall_threshold' must be non-negative")
+	}
+	return nil
+}
+
+func (cfg WatchdogConfig) settings() watchdog.Settings {
+	return watchdog.Settings{
+		StallThreshold: cfg.StallThreshold,
+	}
+}
+
+// ResourceDetectionConfig defines how the data exported by the pipelines is stamped with resource attributes
+// describing the collector: its service name, version and instance ID, the name of its host and the type of
+// its operating system.
+type ResourceDetectionConfig struct {
+	// Enabled sets the attributes on the resources of the data at the end of every pipeline, before the
+	// exporters and connectors.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Override replaces the attributes already set on the resources. By default, only the missing attributes are set.
+	Override bool `mapstructure:"override"`
+
+	// Prefix is prepended to the names of the attributes, so they do not conflict with the attributes describing
+	// the sources of the data, e.g. "otelcol." sets "otelcol.service.name".
+	Prefix string `mapstructure:"prefix"`
+
+	// Kubernetes adds the attributes describing the pod of the collector, read from the K8S_POD_NAME, K8S_POD_UID,
+	// K8S_NAMESPACE_NAME and K8S_NODE_NAME environment variables set with the Kubernetes downward API.
+	Kubernetes bool `mapstructure:"kubernetes"`
+}
+
+// MemoryConfig defines how the soft memory limit of the Go runtime (GOMEMLIMIT) is managed by the service.
+type MemoryConfig struct {
+	// LimitPercentage is the soft memory limit of the Go runtime, as a percentage of the total memory available
+	// to the process, detected from the cgroup limits or the physical memory. The soft memory limit is lowered
+	// to the soft limit of the memory limiters, if any. Zero, the default, leaves the soft memory limit unchanged.
+	// The GOMEMLIMIT environment variable, if set, takes precedence.
+	LimitPercentage uint32 `mapstructure:"limit_percentage"`
 }
 
 func (cfg *Config) Validate() error {
@@ -28,9 +167,53 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if err := cfg.Discovery.Validate(); err != nil {
+		return fmt.Errorf("service::discovery config validation failed: %w", err)
+	}
+	for receiverID := range cfg.Discovery {
+		if !cfg.usesReceiver(receiverID) {
+			return fmt.Errorf("service::discovery config validation failed: receiver %q is not used in any pipeline", receiverID)
+		}
+	}
+
+	if err := cfg.Limits.validate(); err != nil {
+		return fmt.Errorf("service::limits config validation failed: %w", err)
+	}
+	for receiverID, limits := range cfg.Limits.Receivers {
+		if err := limits.validate(); err != nil {
+			return fmt.Errorf("service::limits config validation failed: receiver %q: %w", receiverID, err)
+		}
+		if !cfg.usesReceiver(receiverID) {
+			return fmt.Errorf("service::limits config validation failed: receiver %q is not used in any pipeline", receiverID)
+		}
+	}
+
+	if err := cfg.Watchdog.validate(); err != nil {
+		return fmt.Errorf("service::watchdog config validation failed: %w", err)
+	}
+
+	if err := cfg.Telemetry.Diagnostics.Validate(); err != nil {
+		return fmt.Errorf("service::telemetry::diagnostics config validation failed: %w", err)
+	}
+
+	if cfg.Memory.LimitPercentage > 100 {
+		return errors.New("service::memory config validation failed: 'limit_percentage' must be less than or equal to hundred")
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
 
 	return nil
 }
+
+func (cfg *Config) usesReceiver(receiverID component.ID) bool {
+	for _, pipeline := range cfg.Pipelines {
+		for _, id := range pipeline.Receivers {
+			if id == receiverID {
+				return true
+			}
+		}
+	}
+	return false
+}
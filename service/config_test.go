@@ -7,12 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/discovery"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -71,6 +73,134 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "valid-discovery",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Discovery = discovery.Config{
+					component.MustNewID("nop"): {WatchObservers: []component.ID{component.MustNewID("observer")}},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-discovery-config",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Discovery = discovery.Config{component.MustNewID("nop"): {}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::discovery config validation failed: %w`, fmt.Errorf(`receiver "nop": %w`, errors.New("must watch at least one observer"))),
+		},
+		{
+			name: "discovery-receiver-not-in-pipelines",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Discovery = discovery.Config{
+					component.MustNewID("unused"): {WatchObservers: []component.ID{component.MustNewID("observer")}},
+				}
+				return cfg
+			},
+			expected: errors.New(`service::discovery config validation failed: receiver "unused" is not used in any pipeline`),
+		},
+		{
+			name: "valid-limits",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Limits = LimitsConfig{
+					ReceiverLimits: ReceiverLimits{MaxAttributes: 128, MaxAttributeValueLength: 4096},
+					Receivers:      map[component.ID]ReceiverLimits{component.MustNewID("nop"): {MaxSpanEvents: 64}},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-limits",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Limits.MaxSpanLinks = -1
+				return cfg
+			},
+			expected: fmt.Errorf(`service::limits config validation failed: %w`, errors.New("'max_span_links' must be non-negative")),
+		},
+		{
+			name: "invalid-receiver-limits",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Limits.Receivers = map[component.ID]ReceiverLimits{component.MustNewID("nop"): {MaxAttributes: -1}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::limits config validation failed: receiver "nop": %w`, errors.New("'max_attributes' must be non-negative")),
+		},
+		{
+			name: "limits-receiver-not-in-pipelines",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Limits.Receivers = map[component.ID]ReceiverLimits{component.MustNewID("unused"): {MaxAttributes: 16}}
+				return cfg
+			},
+			expected: errors.New(`service::limits config validation failed: receiver "unused" is not used in any pipeline`),
+		},
+		{
+			name: "valid-watchdog",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Watchdog = WatchdogConfig{StallThreshold: time.Minute}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-watchdog-stall-threshold",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Watchdog.StallThreshold = -time.Second
+				return cfg
+			},
+			expected: fmt.Errorf(`service::watchdog config validation failed: %w`, errors.New("'stall_threshold' must be non-negative")),
+		},
+		{
+			name: "invalid-memory-limit-percentage",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Memory.LimitPercentage = 101
+				return cfg
+			},
+			expected: errors.New("service::memory config validation failed: 'limit_percentage' must be less than or equal to hundred"),
+		},
+		{
+			name: "valid-diagnostics",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Diagnostics.Interval = time.Minute
+				cfg.Telemetry.Diagnostics.ProfilesDirectory = "/var/lib/otelcol/profiles"
+				cfg.Telemetry.Diagnostics.HeapThresholdMiB = 512
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-diagnostics-interval",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Diagnostics.Interval = -time.Second
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::diagnostics config validation failed: %w`, errors.New("'interval' must be non-negative")),
+		},
+		{
+			name: "diagnostics-profiles-without-threshold",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Diagnostics.Interval = time.Minute
+				cfg.Telemetry.Diagnostics.ProfilesDirectory = "/var/lib/otelcol/profiles"
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::diagnostics config validation failed: %w`,
+				errors.New("'profiles_directory' requires 'heap_threshold_mib' or 'goroutines_threshold'")),
+		},
 	}
 
 	for _, test := range testCases {
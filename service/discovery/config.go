@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery // import "go.opentelemetry.io/collector/service/discovery"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errMissingWatchObservers = errors.New("must watch at least one observer")
+	errUnbalancedBackticks   = errors.New("unbalanced backticks")
+)
+
+// Config defines the receivers started only for the endpoints discovered by the observer extensions,
+// by receiver ID.
+type Config map[component.ID]*ReceiverConfig
+
+func (cfg Config) Validate() error {
+	for receiverID, rcvCfg := range cfg {
+		if err := rcvCfg.Validate(); err != nil {
+			return fmt.Errorf("receiver %q: %w", receiverID, err)
+		}
+	}
+	return nil
+}
+
+// ReceiverConfig defines when a receiver is started and how it is configured for a discovered endpoint.
+//
+// The variables of an endpoint are its "id", its "target" and its details. They are referenced by their
+// name between backticks in the values of Config, e.g. "`target`".
+type ReceiverConfig struct {
+	// WatchObservers are the observer extensions notifying the endpoints.
+	WatchObservers []component.ID `mapstructure:"watch_observers"`
+
+	// Match selects the endpoints for which the receiver is started: the keys are names of variables of the
+	// endpoint, and the values regular expressions the variables must fully match. An empty Match selects
+	// all the endpoints.
+	Match map[string]string `mapstructure:"match"`
+
+	// Config is merged over the configuration of the receiver for every endpoint, after replacing the
+	// references to the variables of the endpoint by their values.
+	Config map[string]any `mapstructure:"config"`
+}
+
+func (cfg *ReceiverConfig) Validate() error {
+	if len(cfg.WatchObservers) == 0 {
+		return errMissingWatchObservers
+	}
+	for name, expr := range cfg.Match {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("match %q: %w", name, err)
+		}
+	}
+	return validateTemplate(cfg.Config)
+}
+
+func validateTemplate(value any) error {
+	switch v := value.(type) {
+	case string:
+		if strings.Count(v, "`")%2 != 0 {
+			return fmt.Errorf("config value %q: %w", v, errUnbalancedBackticks)
+		}
+	case map[string]any:
+		for _, val := range v {
+			if err := validateTemplate(val); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, val := range v {
+			if err := validateTemplate(val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestConfigValidate(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		cfg      *ReceiverConfig
+		expected string
+	}{
+		{
+			name: "valid",
+			cfg: &ReceiverConfig{
+				WatchObservers: []component.ID{component.MustNewID("observer")},
+				Match:          map[string]string{"port": "6379|6380"},
+				Config:         map[string]any{"endpoint": "`target`", "tags": []any{"`pod.name`"}},
+			},
+		},
+		{
+			name:     "missing-watch-observers",
+			cfg:      &ReceiverConfig{},
+			expected: `receiver "redis": must watch at least one observer`,
+		},
+		{
+			name: "invalid-match",
+			cfg: &ReceiverConfig{
+				WatchObservers: []component.ID{component.MustNewID("observer")},
+				Match:          map[string]string{"port": "("},
+			},
+			expected: "receiver \"redis\": match \"port\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "unbalanced-backticks",
+			cfg: &ReceiverConfig{
+				WatchObservers: []component.ID{component.MustNewID("observer")},
+				Config:         map[string]any{"nested": map[string]any{"endpoint": "`target"}},
+			},
+			expected: "receiver \"redis\": config value \"`target\": unbalanced backticks",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{component.MustNewID("redis"): tt.cfg}.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
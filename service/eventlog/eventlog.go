@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package eventlog keeps the recent events of the collector, the lifecycle of its components,
+// the reloads of its configuration and the failures of its exporters, in memory so they can be
+// inspected after an incident without scraping the logs of the collector.
+package eventlog // import "go.opentelemetry.io/collector/service/eventlog"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Kind is the kind of an Event.
+type Kind string
+
+const (
+	// KindLifecycle is the kind of the events reporting a component starting, running or stopping.
+	KindLifecycle Kind = "lifecycle"
+	// KindError is the kind of the events reporting a component failing.
+	KindError Kind = "error"
+	// KindExportFailure is the kind of the events reporting an exporter failing to export data.
+	KindExportFailure Kind = "export_failure"
+	// KindConfigReload is the kind of the events reporting the configuration of the collector being reloaded.
+	KindConfigReload Kind = "config_reload"
+	// KindWatchdog is the kind of the events reporting a component stalling or making progress again, as detected
+	// by the watchdog of the pipelines.
+	KindWatchdog Kind = "watchdog"
+)
+
+// Event is an event recorded in a Log.
+type Event struct {
+	// Timestamp is the time the event was recorded.
+	Timestamp time.Time `json:"timestamp"`
+	// Kind is the kind of the event.
+	Kind Kind `json:"kind"`
+	// Component is the kind and ID of the component the event is about, e.g. "exporter otlp", empty for
+	// the events about the collector itself.
+	Component string `json:"component,omitempty"`
+	// Message describes the event.
+	Message string `json:"message"`
+}
+
+const (
+	defaultSize  = 1000
+	defaultRate  = 10
+	defaultBurst = 100
+)
+
+// Settings configures a Log.
+type Settings struct {
+	// Size is the maximum number of events kept, the oldest events are evicted first.
+	Size int
+	// Rate is the number of events per second recorded on average, the events exceeding it are dropped.
+	Rate float64
+	// Burst is the number of events recorded at once before the rate limit applies.
+	Burst int
+}
+
+// NewDefaultSettings returns the default Settings of a Log.
+func NewDefaultSettings() Settings {
+	return Settings{
+		Size:  defaultSize,
+		Rate:  defaultRate,
+		Burst: defaultBurst,
+	}
+}
+
+// Log is a bounded, rate limited, log of the recent events of the collector.
+// The events are kept in a ring buffer, so the oldest events are evicted once the log is full,
+// and the events recorded faster than the rate limit are dropped, so a component failing in a loop
+// neither evicts the other events nor slows down the collector.
+//
+// It is safe to call the methods of a Log concurrently.
+type Log struct {
+	mu      sync.Mutex
+	events  []Event
+	size    int
+	next    int
+	rate    float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	dropped uint64
+	now     func() time.Time
+}
+
+// NewLog returns a new empty Log.
+func NewLog(set Settings) *Log {
+	if set.Size <= 0 {
+		set.Size = defaultSize
+	}
+	if set.Burst <= 0 {
+		set.Burst = defaultBurst
+	}
+	return &Log{
+		size:   set.Size,
+		rate:   set.Rate,
+		burst:  float64(set.Burst),
+		tokens: float64(set.Burst),
+		now:    time.Now,
+	}
+}
+
+// Record records an event, unless the rate limit is exceeded.
+func (l *Log) Record(kind Kind, comp string, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.allow(now) {
+		l.dropped++
+		return
+	}
+	ev := Event{Timestamp: now, Kind: kind, Component: comp, Message: msg}
+	// The buffer grows up to the size of the log, so a log recording few events stays small.
+	if len(l.events) < l.size {
+		l.events = append(l.events, ev)
+		return
+	}
+	l.events[l.next] = ev
+	l.next = (l.next + 1) % l.size
+}
+
+// allow takes a token from the bucket refilled at the rate of the log, a non-positive rate disables the limit.
+func (l *Log) allow(now time.Time) bool {
+	if l.rate <= 0 {
+		return true
+	}
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// Events returns the events kept in the log, from the oldest to the most recent.
+func (l *Log) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := make([]Event, 0, len(l.events))
+	events = append(events, l.events[l.next:]...)
+	return append(events, l.events[:l.next]...)
+}
+
+// Dropped returns the number of events dropped because the rate limit was exceeded.
+func (l *Log) Dropped() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropped
+}
+
+// Provider is implemented by the component.Host of the collector, so the extensions can query its Log.
+type Provider interface {
+	// GetEventLog returns the Log of the collector.
+	GetEventLog() *Log
+}
+
+// FromHost returns the Log of the collector, or false if the host does not provide one.
+func FromHost(host component.Host) (*Log, bool) {
+	p, ok := host.(Provider)
+	if !ok {
+		return nil, false
+	}
+	l := p.GetEventLog()
+	return l, l != nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package eventlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func messages(events []Event) []string {
+	var msgs []string
+	for _, ev := range events {
+		msgs = append(msgs, ev.Message)
+	}
+	return msgs
+}
+
+func TestLogRingBuffer(t *testing.T) {
+	l := NewLog(Settings{Size: 3})
+	assert.Empty(t, l.Events())
+
+	l.Record(KindLifecycle, "receiver otlp", "a")
+	l.Record(KindError, "receiver otlp", "b")
+	assert.Equal(t, []string{"a", "b"}, messages(l.Events()))
+	assert.Equal(t, Kind("error"), l.Events()[1].Kind)
+	assert.Equal(t, "receiver otlp", l.Events()[1].Component)
+
+	// The oldest events are evicted once the log is full.
+	l.Record(KindLifecycle, "", "c")
+	l.Record(KindLifecycle, "", "d")
+	l.Record(KindLifecycle, "", "e")
+	assert.Equal(t, []string{"c", "d", "e"}, messages(l.Events()))
+	l.Record(KindLifecycle, "", "f")
+	assert.Equal(t, []string{"d", "e", "f"}, messages(l.Events()))
+	assert.Zero(t, l.Dropped())
+}
+
+func TestLogRateLimit(t *testing.T) {
+	l := NewLog(Settings{Size: 10, Rate: 2, Burst: 3})
+	now := time.Unix(100, 0)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		l.Record(KindExportFailure, "exporter otlp", "failed")
+	}
+	assert.Len(t, l.Events(), 3)
+	assert.Equal(t, uint64(2), l.Dropped())
+
+	// The bucket is refilled at the rate of the log.
+	now = now.Add(time.Second)
+	for i := 0; i < 5; i++ {
+		l.Record(KindExportFailure, "exporter otlp", "failed")
+	}
+	assert.Len(t, l.Events(), 5)
+	assert.Equal(t, uint64(5), l.Dropped())
+	assert.Equal(t, now, l.Events()[4].Timestamp)
+}
+
+func TestNewDefaultSettings(t *testing.T) {
+	l := NewLog(NewDefaultSettings())
+	for i := 0; i < defaultBurst+1; i++ {
+		l.Record(KindLifecycle, "", "event")
+	}
+	assert.Len(t, l.Events(), defaultBurst)
+	assert.Equal(t, uint64(1), l.Dropped())
+}
+
+type providerHost struct {
+	component.Host
+	log *Log
+}
+
+func (h *providerHost) GetEventLog() *Log {
+	return h.log
+}
+
+func TestFromHost(t *testing.T) {
+	_, ok := FromHost(componenttest.NewNopHost())
+	assert.False(t, ok)
+	_, ok = FromHost(&providerHost{Host: componenttest.NewNopHost()})
+	assert.False(t, ok)
+
+	l := NewLog(NewDefaultSettings())
+	got, ok := FromHost(&providerHost{Host: componenttest.NewNopHost(), log: l})
+	require.True(t, ok)
+	assert.Same(t, l, got)
+}
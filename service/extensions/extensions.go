@@ -118,6 +118,7 @@ func (bes *Extensions) NotifyConfig(ctx context.Context, conf *confmap.Conf) err
 		ext := bes.extMap[extID]
 		if cw, ok := ext.(extension.ConfigWatcher); ok {
 			clonedConf := confmap.NewFromStringMap(conf.ToStringMap())
+			clonedConf.SetProvenance(conf.Provenance())
 			errs = multierr.Append(errs, cw.NotifyConfig(ctx, clonedConf))
 		}
 	}
@@ -237,3 +238,7 @@ func (r *nopReporter) Ready() {}
 func (r *nopReporter) ReportStatus(*componentstatus.InstanceID, *componentstatus.Event) {}
 
 func (r *nopReporter) ReportOKIfStarting(*componentstatus.InstanceID) {}
+
+func (r *nopReporter) CurrentStatus(*componentstatus.InstanceID) *componentstatus.Event {
+	return componentstatus.NewEvent(componentstatus.StatusNone)
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asyncconsumer implements the consumers handing the data over to a pool of
+// goroutines through a bounded channel, decoupling the stages of a pipeline.
+package asyncconsumer // import "go.opentelemetry.io/collector/service/internal/asyncconsumer"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errShutdown = errors.New("the pipeline is shutting down")
+
+type item[T any] struct {
+	ctx  context.Context
+	data T
+	// release releases the hold of the acks of the data once it is consumed.
+	release func(error)
+}
+
+// queue hands the data over to numConsumers goroutines calling consume,
+// blocking the callers while the channel is full.
+type queue[T any] struct {
+	consume      func(context.Context, T) error
+	logger       *zap.Logger
+	numConsumers int
+	items        chan item[T]
+
+	// mu guards the closing of items against the concurrent sends.
+	mu      sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+func newQueue[T any](consume func(context.Context, T) error, numConsumers, bufferSize int, logger *zap.Logger) *queue[T] {
+	return &queue[T]{
+		consume:      consume,
+		logger:       logger,
+		numConsumers: numConsumers,
+		items:        make(chan item[T], bufferSize),
+	}
+}
+
+// Start starts the goroutines consuming the data.
+func (q *queue[T]) Start() {
+	for i := 0; i < q.numConsumers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for it := range q.items {
+				err := q.consume(it.ctx, it.data)
+				it.release(err)
+				if err != nil {
+					q.logger.Error("Failed to consume the data handed over by the receivers", zap.Error(err))
+				}
+			}
+		}()
+	}
+}
+
+// Shutdown rejects the new data, and waits for the goroutines to consume the pending data
+// until the context is done.
+func (q *queue[T]) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return nil
+	}
+	q.stopped = true
+	close(q.items)
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *queue[T]) push(ctx context.Context, data T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.stopped {
+		return errShutdown
+	}
+	// The data outlives the call, so it must not be canceled with the request of the receiver.
+	it := item[T]{ctx: context.WithoutCancel(ctx), data: data, release: ack.Hold(ctx)}
+	select {
+	case q.items <- it:
+		return nil
+	case <-ctx.Done():
+		it.release(ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// Traces is a consumer.Traces handing the traces over to the goroutines calling the next consumer.
+// The errors of the next consumer are logged, since they cannot be returned to the caller.
+type Traces struct {
+	*queue[ptrace.Traces]
+	next consumer.Traces
+}
+
+// NewTraces returns a Traces calling the next consumer from numConsumers goroutines,
+// through a channel holding up to bufferSize traces.
+func NewTraces(next consumer.Traces, numConsumers, bufferSize int, logger *zap.Logger) *Traces {
+	return &Traces{queue: newQueue(next.ConsumeTraces, numConsumers, bufferSize, logger), next: next}
+}
+
+func (t *Traces) Capabilities() consumer.Capabilities {
+	return t.next.Capabilities()
+}
+
+func (t *Traces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return t.push(ctx, td)
+}
+
+// Metrics is a consumer.Metrics handing the metrics over to the goroutines calling the next consumer.
+// The errors of the next consumer are logged, since they cannot be returned to the caller.
+type Metrics struct {
+	*queue[pmetric.Metrics]
+	next consumer.Metrics
+}
+
+// NewMetrics returns a Metrics calling the next consumer from numConsumers goroutines,
+// through a channel holding up to bufferSize metrics.
+func NewMetrics(next consumer.Metrics, numConsumers, bufferSize int, logger *zap.Logger) *Metrics {
+	return &Metrics{queue: newQueue(next.ConsumeMetrics, numConsumers, bufferSize, logger), next: next}
+}
+
+func (m *Metrics) Capabilities() consumer.Capabilities {
+	return m.next.Capabilities()
+}
+
+func (m *Metrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return m.push(ctx, md)
+}
+
+// Logs is a consumer.Logs handing the logs over to the goroutines calling the next consumer.
+// The errors of the next consumer are logged, since they cannot be returned to the caller.
+type Logs struct {
+	*queue[plog.Logs]
+	next consumer.Logs
+}
+
+// NewLogs returns a Logs calling the next consumer from numConsumers goroutines,
+// through a channel holding up to bufferSize logs.
+func NewLogs(next consumer.Logs, numConsumers, bufferSize int, logger *zap.Logger) *Logs {
+	return &Logs{queue: newQueue(next.ConsumeLogs, numConsumers, bufferSize, logger), next: next}
+}
+
+func (l *Logs) Capabilities() consumer.Capabilities {
+	return l.next.Capabilities()
+}
+
+func (l *Logs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return l.push(ctx, ld)
+}
+
+// Entities is a consumerentities.Entities handing the entity events over to the goroutines calling the next consumer.
+// The errors of the next consumer are logged, since they cannot be returned to the caller.
+type Entities struct {
+	*queue[pentity.Entities]
+	next consumerentities.Entities
+}
+
+// NewEntities returns an Entities calling the next consumer from numConsumers goroutines,
+// through a channel holding up to bufferSize entities.
+func NewEntities(next consumerentities.Entities, numConsumers, bufferSize int, logger *zap.Logger) *Entities {
+	return &Entities{queue: newQueue(next.ConsumeEntities, numConsumers, bufferSize, logger), next: next}
+}
+
+func (e *Entities) Capabilities() consumer.Capabilities {
+	return e.next.Capabilities()
+}
+
+func (e *Entities) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	return e.push(ctx, ed)
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package asyncconsumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/ack"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tc := NewTraces(sink, 4, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, tc.Capabilities())
+	tc.Start()
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	require.NoError(t, tc.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 100)
+
+	assert.ErrorIs(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), errShutdown)
+	assert.NoError(t, tc.Shutdown(context.Background()))
+}
+
+func TestMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mc := NewMetrics(sink, 4, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, mc.Capabilities())
+	mc.Start()
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, mc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	}
+	require.NoError(t, mc.Shutdown(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 100)
+
+	assert.ErrorIs(t, mc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), errShutdown)
+}
+
+func TestLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lc := NewLogs(sink, 4, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, lc.Capabilities())
+	lc.Start()
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, lc.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	require.NoError(t, lc.Shutdown(context.Background()))
+	assert.Len(t, sink.AllLogs(), 100)
+
+	assert.ErrorIs(t, lc.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), errShutdown)
+}
+
+func TestEntities(t *testing.T) {
+	sink := new(consumertest.EntitiesSink)
+	ec := NewEntities(sink, 4, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, ec.Capabilities())
+	ec.Start()
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, ec.ConsumeEntities(context.Background(), pentity.NewEntities()))
+	}
+	require.NoError(t, ec.Shutdown(context.Background()))
+	assert.Len(t, sink.AllEntities(), 100)
+
+	assert.ErrorIs(t, ec.ConsumeEntities(context.Background(), pentity.NewEntities()), errShutdown)
+}
+
+func TestCapabilities(t *testing.T) {
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error { return nil }, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, NewLogs(next, 1, 0, zap.NewNop()).Capabilities())
+}
+
+func TestConsumeErrorLogged(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	tc := NewTraces(consumertest.NewErr(errors.New("failed")), 1, 0, zap.New(core))
+	tc.Start()
+
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, tc.Shutdown(context.Background()))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "failed", logs.All()[0].ContextMap()["error"])
+}
+
+func TestConsumeAcked(t *testing.T) {
+	errConsume := errors.New("failed")
+	tc := NewTraces(consumertest.NewErr(errConsume), 1, 1, zap.NewNop())
+
+	a := ack.New(ack.ModeEnqueued, nil)
+	require.NoError(t, tc.ConsumeTraces(ack.NewContext(context.Background(), a), testdata.GenerateTraces(1)))
+	// The receiver's hold is released, the ack is held until the traces are consumed.
+	ack.Release(nil, a)
+	select {
+	case <-a.Done():
+		t.Fatal("ack completed before the traces were consumed")
+	default:
+	}
+
+	tc.Start()
+	<-a.Done()
+	assert.Equal(t, errConsume, a.Err())
+	require.NoError(t, tc.Shutdown(context.Background()))
+}
+
+type ctxKey struct{}
+
+func TestConsumeNotCanceled(t *testing.T) {
+	var got context.Context
+	next, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error {
+		got = ctx
+		return nil
+	})
+	require.NoError(t, err)
+	mc := NewMetrics(next, 1, 1, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+	require.NoError(t, mc.ConsumeMetrics(ctx, testdata.GenerateMetrics(1)))
+	cancel()
+
+	mc.Start()
+	require.NoError(t, mc.Shutdown(context.Background()))
+	require.NotNil(t, got)
+	assert.NoError(t, got.Err())
+	assert.Equal(t, "value", got.Value(ctxKey{}))
+}
+
+func TestConsumeBlockedWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		wg.Done()
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	tc := NewTraces(next, 1, 1, zap.NewNop())
+	tc.Start()
+
+	// The first traces are taken over by the goroutine, the second ones fill the channel.
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	wg.Wait()
+	wg.Add(1)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, tc.ConsumeTraces(ctx, testdata.GenerateTraces(1)), context.DeadlineExceeded)
+
+	// The shutdown waits for the pending traces until its context is done.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, tc.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(release)
+	wg.Wait()
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterentities"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 )
 
@@ -18,6 +19,7 @@ type Exporter interface {
 	CreateTraces(context.Context, exporter.Settings) (exporter.Traces, error)
 	CreateMetrics(context.Context, exporter.Settings) (exporter.Metrics, error)
 	CreateLogs(context.Context, exporter.Settings) (exporter.Logs, error)
+	CreateEntities(context.Context, exporter.Settings) (exporterentities.Entities, error)
 	Factory(component.Type) component.Factory
 }
 
@@ -80,6 +82,22 @@ func (b *ExporterBuilder) CreateLogs(ctx context.Context, set exporter.Settings)
 	return f.CreateLogsExporter(ctx, set, cfg)
 }
 
+// CreateEntities creates an Entities exporter based on the settings and config.
+func (b *ExporterBuilder) CreateEntities(ctx context.Context, set exporter.Settings) (exporterentities.Entities, error) {
+	cfg, existsCfg := b.cfgs[set.ID]
+	if !existsCfg {
+		return nil, fmt.Errorf("exporter %q is not configured", set.ID)
+	}
+
+	f, existsFactory := b.factories[set.ID.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("exporter factory not available for: %q", set.ID)
+	}
+
+	logStabilityLevel(set.Logger, f.EntitiesExporterStability())
+	return f.CreateEntitiesExporter(ctx, set, cfg)
+}
+
 func (b *ExporterBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterentities"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 )
 
@@ -191,3 +192,34 @@ func createExporterSettings(id component.ID) exporter.Settings {
 		BuildInfo:         component.NewDefaultBuildInfo(),
 	}
 }
+
+func TestExporterBuilderEntities(t *testing.T) {
+	defaultCfg := struct{}{}
+	factories, err := exporter.MakeFactoryMap([]exporter.Factory{
+		exporter.NewFactory(component.MustNewType("err"), nil),
+		exporter.NewFactory(
+			component.MustNewType("all"),
+			func() component.Config { return &defaultCfg },
+			exporterentities.WithEntities(createExporterEntities, component.StabilityLevelDevelopment),
+		),
+	}...)
+	require.NoError(t, err)
+
+	cfgs := map[component.ID]component.Config{
+		component.MustNewID("err"): defaultCfg,
+		component.MustNewID("all"): defaultCfg,
+	}
+	b := NewExporter(cfgs, factories)
+
+	ee, err := b.CreateEntities(context.Background(), createExporterSettings(component.MustNewID("all")))
+	require.NoError(t, err)
+	assert.Equal(t, nopExporterInstance, ee)
+
+	ee, err = b.CreateEntities(context.Background(), createExporterSettings(component.MustNewID("err")))
+	assert.EqualError(t, err, "telemetry type is not supported")
+	assert.Nil(t, ee)
+}
+
+func createExporterEntities(context.Context, exporter.Settings, component.Config) (exporterentities.Entities, error) {
+	return nopExporterInstance, nil
+}
@@ -9,7 +9,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorentities"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -19,6 +21,7 @@ type Processor interface {
 	CreateTraces(context.Context, processor.Settings, consumer.Traces) (processor.Traces, error)
 	CreateMetrics(context.Context, processor.Settings, consumer.Metrics) (processor.Metrics, error)
 	CreateLogs(context.Context, processor.Settings, consumer.Logs) (processor.Logs, error)
+	CreateEntities(context.Context, processor.Settings, consumerentities.Entities) (processorentities.Entities, error)
 	Factory(component.Type) component.Factory
 }
 
@@ -91,6 +94,25 @@ func (b *ProcessorBuilder) CreateLogs(ctx context.Context, set processor.Setting
 	return f.CreateLogsProcessor(ctx, set, cfg, next)
 }
 
+// CreateEntities creates an Entities processor based on the settings and config.
+func (b *ProcessorBuilder) CreateEntities(ctx context.Context, set processor.Settings, next consumerentities.Entities) (processorentities.Entities, error) {
+	if next == nil {
+		return nil, errNilNextConsumer
+	}
+	cfg, existsCfg := b.cfgs[set.ID]
+	if !existsCfg {
+		return nil, fmt.Errorf("processor %q is not configured", set.ID)
+	}
+
+	f, existsFactory := b.factories[set.ID.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("processor factory not available for: %q", set.ID)
+	}
+
+	logStabilityLevel(set.Logger, f.EntitiesProcessorStability())
+	return f.CreateEntitiesProcessor(ctx, set, cfg, next)
+}
+
 func (b *ProcessorBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
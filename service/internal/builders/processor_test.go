@@ -13,8 +13,10 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorentities"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -215,3 +217,38 @@ func createProcessorSettings(id component.ID) processor.Settings {
 		BuildInfo:         component.NewDefaultBuildInfo(),
 	}
 }
+
+func TestProcessorBuilderEntities(t *testing.T) {
+	defaultCfg := struct{}{}
+	factories, err := processor.MakeFactoryMap([]processor.Factory{
+		processor.NewFactory(component.MustNewType("err"), nil),
+		processor.NewFactory(
+			component.MustNewType("all"),
+			func() component.Config { return &defaultCfg },
+			processorentities.WithEntities(createProcessorEntities, component.StabilityLevelDevelopment),
+		),
+	}...)
+	require.NoError(t, err)
+
+	cfgs := map[component.ID]component.Config{
+		component.MustNewID("err"): defaultCfg,
+		component.MustNewID("all"): defaultCfg,
+	}
+	b := NewProcessor(cfgs, factories)
+
+	ee, err := b.CreateEntities(context.Background(), createProcessorSettings(component.MustNewID("all")), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, nopProcessorInstance, ee)
+
+	ee, err = b.CreateEntities(context.Background(), createProcessorSettings(component.MustNewID("err")), consumertest.NewNop())
+	assert.EqualError(t, err, "telemetry type is not supported")
+	assert.Nil(t, ee)
+
+	ee, err = b.CreateEntities(context.Background(), createProcessorSettings(component.MustNewID("all")), nil)
+	assert.EqualError(t, err, "nil next Consumer")
+	assert.Nil(t, ee)
+}
+
+func createProcessorEntities(context.Context, processor.Settings, component.Config, consumerentities.Entities) (processorentities.Entities, error) {
+	return nopProcessorInstance, nil
+}
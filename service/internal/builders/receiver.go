@@ -9,7 +9,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverentities"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
 
@@ -19,7 +21,9 @@ type Receiver interface {
 	CreateTraces(context.Context, receiver.Settings, consumer.Traces) (receiver.Traces, error)
 	CreateMetrics(context.Context, receiver.Settings, consumer.Metrics) (receiver.Metrics, error)
 	CreateLogs(context.Context, receiver.Settings, consumer.Logs) (receiver.Logs, error)
+	CreateEntities(context.Context, receiver.Settings, consumerentities.Entities) (receiverentities.Entities, error)
 	Factory(component.Type) component.Factory
+	Config(component.ID) component.Config
 }
 
 // ReceiverBuilder receiver is a helper struct that given a set of Configs and
@@ -92,10 +96,34 @@ func (b *ReceiverBuilder) CreateLogs(ctx context.Context, set receiver.Settings,
 	return f.CreateLogsReceiver(ctx, set, cfg, next)
 }
 
+// CreateEntities creates an Entities receiver based on the settings and config.
+func (b *ReceiverBuilder) CreateEntities(ctx context.Context, set receiver.Settings, next consumerentities.Entities) (receiverentities.Entities, error) {
+	if next == nil {
+		return nil, errNilNextConsumer
+	}
+	cfg, existsCfg := b.cfgs[set.ID]
+	if !existsCfg {
+		return nil, fmt.Errorf("receiver %q is not configured", set.ID)
+	}
+
+	f, existsFactory := b.factories[set.ID.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("receiver factory not available for: %q", set.ID)
+	}
+
+	logStabilityLevel(set.Logger, f.EntitiesReceiverStability())
+	return f.CreateEntitiesReceiver(ctx, set, cfg, next)
+}
+
 func (b *ReceiverBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
 
+// Config returns the configuration of the receiver with the given ID, or nil if it is not configured.
+func (b *ReceiverBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 // NewNopReceiverConfigsAndFactories returns a configuration and factories that allows building a new nop receiver.
 func NewNopReceiverConfigsAndFactories() (map[component.ID]component.Config, map[component.Type]receiver.Factory) {
 	nopFactory := receivertest.NewNopFactory()
@@ -13,8 +13,10 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverentities"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
 
@@ -156,6 +158,14 @@ func TestReceiverBuilderFactory(t *testing.T) {
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
 }
 
+func TestReceiverBuilderConfig(t *testing.T) {
+	cfgs := map[component.ID]component.Config{component.MustNewID("foo"): struct{}{}}
+	b := NewReceiver(cfgs, nil)
+
+	assert.Equal(t, struct{}{}, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
+}
+
 func TestNewNopReceiverConfigsAndFactories(t *testing.T) {
 	configs, factories := NewNopReceiverConfigsAndFactories()
 	builder := NewReceiver(configs, factories)
@@ -215,3 +225,38 @@ func createReceiverMetrics(context.Context, receiver.Settings, component.Config,
 func createReceiverLogs(context.Context, receiver.Settings, component.Config, consumer.Logs) (receiver.Logs, error) {
 	return nopReceiverInstance, nil
 }
+
+func TestReceiverBuilderEntities(t *testing.T) {
+	defaultCfg := struct{}{}
+	factories, err := receiver.MakeFactoryMap([]receiver.Factory{
+		receiver.NewFactory(component.MustNewType("err"), nil),
+		receiver.NewFactory(
+			component.MustNewType("all"),
+			func() component.Config { return &defaultCfg },
+			receiverentities.WithEntities(createReceiverEntities, component.StabilityLevelDevelopment),
+		),
+	}...)
+	require.NoError(t, err)
+
+	cfgs := map[component.ID]component.Config{
+		component.MustNewID("err"): defaultCfg,
+		component.MustNewID("all"): defaultCfg,
+	}
+	b := NewReceiver(cfgs, factories)
+
+	ee, err := b.CreateEntities(context.Background(), settings(component.MustNewID("all")), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, nopReceiverInstance, ee)
+
+	ee, err = b.CreateEntities(context.Background(), settings(component.MustNewID("err")), consumertest.NewNop())
+	assert.EqualError(t, err, "telemetry type is not supported")
+	assert.Nil(t, ee)
+
+	ee, err = b.CreateEntities(context.Background(), settings(component.MustNewID("all")), nil)
+	assert.EqualError(t, err, "nil next Consumer")
+	assert.Nil(t, ee)
+}
+
+func createReceiverEntities(context.Context, receiver.Settings, component.Config, consumerentities.Entities) (receiverentities.Entities, error) {
+	return nopReceiverInstance, nil
+}
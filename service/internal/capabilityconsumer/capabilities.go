@@ -5,6 +5,7 @@ package capabilityconsumer // import "go.opentelemetry.io/collector/service/inte
 
 import (
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 )
 
 func NewLogs(logs consumer.Logs, cap consumer.Capabilities) consumer.Logs {
@@ -54,3 +55,19 @@ type capTraces struct {
 func (mts capTraces) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
+
+func NewEntities(entities consumerentities.Entities, cap consumer.Capabilities) consumerentities.Entities {
+	if entities.Capabilities() == cap {
+		return entities
+	}
+	return capEntities{Entities: entities, cap: cap}
+}
+
+type capEntities struct {
+	consumerentities.Entities
+	cap consumer.Capabilities
+}
+
+func (mts capEntities) Capabilities() consumer.Capabilities {
+	return mts.cap
+}
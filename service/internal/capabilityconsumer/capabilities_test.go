@@ -12,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -59,3 +60,20 @@ func TestTraces(t *testing.T) {
 	assert.Len(t, sink.AllTraces(), 1)
 	assert.Equal(t, testdata.GenerateTraces(1), sink.AllTraces()[0])
 }
+
+func TestEntities(t *testing.T) {
+	sink := &consumertest.EntitiesSink{}
+	require.Equal(t, consumer.Capabilities{MutatesData: false}, sink.Capabilities())
+
+	same := NewEntities(sink, consumer.Capabilities{MutatesData: false})
+	assert.Same(t, sink, same)
+
+	wrap := NewEntities(sink, consumer.Capabilities{MutatesData: true})
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, wrap.Capabilities())
+
+	ed := pentity.NewEntities()
+	ed.ResourceEntities().AppendEmpty().ScopeEntities().AppendEmpty().EntityEvents().AppendEmpty().SetEntityType("host")
+	assert.NoError(t, wrap.ConsumeEntities(context.Background(), ed))
+	assert.Len(t, sink.AllEntities(), 1)
+	assert.Equal(t, ed, sink.AllEntities()[0])
+}
@@ -21,6 +21,10 @@ const (
 	zapReceiverInPipeline = "receiver_in_pipeline"
 )
 
+func PipelineLogger(logger *zap.Logger, pipelineID component.ID) *zap.Logger {
+	return logger.With(zap.String(zapPipelineKey, pipelineID.String()))
+}
+
 func ReceiverLogger(logger *zap.Logger, id component.ID, dt component.DataType) *zap.Logger {
 	return logger.With(
 		zap.String(zapKindKey, strings.ToLower(component.KindReceiver.String())),
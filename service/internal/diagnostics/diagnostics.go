@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diagnostics periodically logs a compact summary of the state of the collector, and writes
+// profiles when thresholds are breached, to debug the incidents after the fact.
+package diagnostics // import "go.opentelemetry.io/collector/service/internal/diagnostics"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+const (
+	queueSizeMetric     = "otelcol_exporter_queue_size"
+	queueCapacityMetric = "otelcol_exporter_queue_capacity"
+	receivedPrefix      = "otelcol_receiver_accepted_"
+	sentPrefix          = "otelcol_exporter_sent_"
+	// exporterKey is the attribute of the queue metrics identifying the exporter.
+	exporterKey = "exporter"
+)
+
+// Settings configures a Reporter.
+type Settings struct {
+	// Interval is the interval between two reports.
+	Interval time.Duration
+	// ProfilesDirectory is the directory the profiles are written to, no profile is written if empty.
+	ProfilesDirectory string
+	// HeapThreshold is the heap size, in bytes, above which a heap profile is written. Zero disables it.
+	HeapThreshold uint64
+	// GoroutinesThreshold is the number of goroutines above which a goroutine profile is written. Zero disables it.
+	GoroutinesThreshold int
+	// Reader reads the internal metrics of the collector for the queue depths and the throughput of the
+	// pipelines, which are not reported if nil.
+	Reader *sdkmetric.ManualReader
+}
+
+// Reporter periodically logs the diagnostics report.
+type Reporter struct {
+	set    Settings
+	logger *zap.Logger
+
+	// The state of the previous report, to report the changes since then.
+	lastReport time.Time
+	lastNumGC  uint32
+	lastTotals map[string]int64
+	// The thresholds breached at the previous report, a profile is written once per breach.
+	heapBreached       bool
+	goroutinesBreached bool
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New returns a Reporter logging the reports with logger.
+func New(set Settings, logger *zap.Logger) *Reporter {
+	return &Reporter{
+		set:        set,
+		logger:     logger,
+		lastTotals: map[string]int64{},
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start starts reporting every interval.
+func (r *Reporter) Start() {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	r.lastNumGC = ms.NumGC
+	r.lastReport = time.Now()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.set.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stopCh:
+				return
+			case <-ticker.C:
+				r.report()
+			}
+		}
+	}()
+}
+
+// Shutdown stops reporting.
+func (r *Reporter) Shutdown() {
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+func (r *Reporter) report() {
+	now := time.Now()
+	elapsed := now.Sub(r.lastReport)
+	r.lastReport = now
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	goroutines := runtime.NumGoroutine()
+	gcCount, maxPause, totalPause := r.gcPauses(&ms)
+
+	fields := []zap.Field{
+		zap.Uint64("heap_alloc_bytes", ms.HeapAlloc),
+		zap.Uint64("heap_sys_bytes", ms.HeapSys),
+		zap.Int("goroutines", goroutines),
+		zap.Uint32("gc_count", gcCount),
+		zap.Duration("gc_pause_max", maxPause),
+		zap.Duration("gc_pause_total", totalPause),
+	}
+	if r.set.Reader != nil {
+		fields = append(fields, r.metricsFields(elapsed)...)
+	}
+	r.logger.Info("Diagnostics report", fields...)
+
+	r.heapBreached = r.checkThreshold(r.heapBreached, r.set.HeapThreshold > 0 && ms.HeapAlloc > r.set.HeapThreshold, "heap")
+	r.goroutinesBreached = r.checkThreshold(r.goroutinesBreached,
+		r.set.GoroutinesThreshold > 0 && goroutines > r.set.GoroutinesThreshold, "goroutine")
+}
+
+// gcPauses returns the number of garbage collections since the previous report, and the longest and total
+// duration of their pauses. Only the pauses of the last 256 collections are kept by the runtime.
+func (r *Reporter) gcPauses(ms *runtime.MemStats) (count uint32, maxPause, totalPause time.Duration) {
+	count = ms.NumGC - r.lastNumGC
+	r.lastNumGC = ms.NumGC
+	for i := uint32(0); i < count && i < uint32(len(ms.PauseNs)); i++ {
+		pause := time.Duration(ms.PauseNs[(ms.NumGC-i+uint32(len(ms.PauseNs))-1)%uint32(len(ms.PauseNs))])
+		maxPause = max(maxPause, pause)
+		totalPause += pause
+	}
+	return count, maxPause, totalPause
+}
+
+// metricsFields returns the depths of the exporter queues, and the number of items received and sent
+// per second by signal since the previous report.
+func (r *Reporter) metricsFields(elapsed time.Duration) []zap.Field {
+	var rm metricdata.ResourceMetrics
+	if err := r.set.Reader.Collect(context.Background(), &rm); err != nil {
+		r.logger.Warn("Failed to collect the internal metrics for the diagnostics report", zap.Error(err))
+		return nil
+	}
+
+	sizes := map[string]int64{}
+	capacities := map[string]int64{}
+	totals := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch {
+			case m.Name == queueSizeMetric:
+				gaugeByExporter(m, sizes)
+			case m.Name == queueCapacityMetric:
+				gaugeByExporter(m, capacities)
+			case strings.HasPrefix(m.Name, receivedPrefix):
+				totals["received_"+strings.TrimPrefix(m.Name, receivedPrefix)] += sum(m)
+			case strings.HasPrefix(m.Name, sentPrefix):
+				totals["sent_"+strings.TrimPrefix(m.Name, sentPrefix)] += sum(m)
+			}
+		}
+	}
+
+	var fields []zap.Field
+	if len(sizes) > 0 {
+		queues := make([]string, 0, len(sizes))
+		for exporter, size := range sizes {
+			queues = append(queues, fmt.Sprintf("%s=%d/%d", exporter, size, capacities[exporter]))
+		}
+		sort.Strings(queues)
+		fields = append(fields, zap.Strings("exporter_queues", queues))
+	}
+	names := make([]string, 0, len(totals))
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rate := float64(totals[name]-r.lastTotals[name]) / elapsed.Seconds()
+		fields = append(fields, zap.Float64(name+"_per_second", rate))
+	}
+	r.lastTotals = totals
+	return fields
+}
+
+func gaugeByExporter(m metricdata.Metrics, values map[string]int64) {
+	g, ok := m.Data.(metricdata.Gauge[int64])
+	if !ok {
+		return
+	}
+	for _, dp := range g.DataPoints {
+		exporter, _ := dp.Attributes.Value(exporterKey)
+		values[exporter.AsString()] += dp.Value
+	}
+}
+
+func sum(m metricdata.Metrics) int64 {
+	s, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		return 0
+	}
+	var total int64
+	for _, dp := range s.DataPoints {
+		total += dp.Value
+	}
+	return total
+}
+
+// checkThreshold writes the profile when the threshold is breached, unless it was already breached at the
+// previous report, and returns whether it is breached.
+func (r *Reporter) checkThreshold(wasBreached, breached bool, profile string) bool {
+	if breached && !wasBreached && r.set.ProfilesDirectory != "" {
+		if path, err := r.writeProfile(profile); err != nil {
+			r.logger.Warn("Failed to write the profile", zap.String("profile", profile), zap.Error(err))
+		} else {
+			r.logger.Warn("Diagnostics threshold breached, profile written", zap.String("profile", profile), zap.String("path", path))
+		}
+	}
+	return breached
+}
+
+func (r *Reporter) writeProfile(profile string) (string, error) {
+	path := filepath.Join(r.set.ProfilesDirectory, fmt.Sprintf("%s-%s.pprof", profile, time.Now().UTC().Format("20060102T150405.000Z")))
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	if err = pprof.Lookup(profile).WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestReport(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	r := New(Settings{Interval: time.Minute}, zap.New(core))
+	r.lastReport = time.Now()
+	r.report()
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Diagnostics report", entry.Message)
+	fields := entry.ContextMap()
+	for _, key := range []string{"heap_alloc_bytes", "heap_sys_bytes", "goroutines", "gc_count", "gc_pause_max", "gc_pause_total"} {
+		assert.Contains(t, fields, key)
+	}
+	assert.NotContains(t, fields, "exporter_queues")
+}
+
+func TestReportMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+	meter := mp.Meter("test")
+
+	queueAttrs := metric.WithAttributes(attribute.String(exporterKey, "otlp"))
+	_, err := meter.Int64ObservableGauge(queueSizeMetric, metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(3, queueAttrs)
+		return nil
+	}))
+	require.NoError(t, err)
+	_, err = meter.Int64ObservableGauge(queueCapacityMetric, metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(10, queueAttrs)
+		return nil
+	}))
+	require.NoError(t, err)
+	accepted, err := meter.Int64Counter(receivedPrefix + "spans")
+	require.NoError(t, err)
+	accepted.Add(context.Background(), 100)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	r := New(Settings{Interval: time.Minute, Reader: reader}, zap.New(core))
+	r.lastReport = time.Now().Add(-10 * time.Second)
+	r.report()
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, []any{"otlp=3/10"}, fields["exporter_queues"])
+	rate, ok := fields["received_spans_per_second"].(float64)
+	require.True(t, ok)
+	assert.InDelta(t, 10, rate, 1)
+
+	// Only the items received since the previous report are counted.
+	r.lastReport = time.Now().Add(-10 * time.Second)
+	r.report()
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, float64(0), logs.All()[1].ContextMap()["received_spans_per_second"])
+}
+
+func TestProfileWrittenOncePerBreach(t *testing.T) {
+	dir := t.TempDir()
+	core, logs := observer.New(zapcore.WarnLevel)
+	r := New(Settings{Interval: time.Minute, ProfilesDirectory: dir, GoroutinesThreshold: 1}, zap.New(core))
+	r.lastReport = time.Now()
+
+	r.report()
+	r.report()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Regexp(t, `^goroutine-.*\.pprof$`, entries[0].Name())
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, filepath.Join(dir, entries[0].Name()), logs.All()[0].ContextMap()["path"])
+
+	// The threshold is breached again once it was not at a report.
+	r.set.GoroutinesThreshold = 1 << 20
+	r.report()
+	r.set.GoroutinesThreshold = 1
+	time.Sleep(time.Millisecond)
+	r.report()
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestStartShutdown(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	r := New(Settings{Interval: time.Millisecond}, zap.New(core))
+	r.Start()
+	assert.Eventually(t, func() bool { return logs.Len() > 0 }, time.Second, time.Millisecond)
+	r.Shutdown()
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package discoveryreceiver implements the receivers started only for the endpoints discovered by
+// the observer extensions, see the discovery package.
+package discoveryreceiver // import "go.opentelemetry.io/collector/service/internal/discoveryreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/discovery"
+)
+
+// CreateFunc creates a receiver with the given configuration, consuming the data of the pipelines.
+type CreateFunc func(ctx context.Context, set receiver.Settings, cfg component.Config) (component.Component, error)
+
+// endpointKey identifies an endpoint among the endpoints of all the watched observers.
+type endpointKey struct {
+	observerID component.ID
+	endpointID string
+}
+
+type subscription struct {
+	observer observer.Observer
+	notify   *notify
+}
+
+// Receiver creates an instance of a receiver for every discovered endpoint matching its configuration,
+// and shuts it down when the endpoint is removed.
+type Receiver struct {
+	set     receiver.Settings
+	cfg     *discovery.ReceiverConfig
+	match   map[string]*regexp.Regexp
+	baseCfg component.Config
+	create  CreateFunc
+
+	subscriptions []subscription
+
+	mu        sync.Mutex
+	host      component.Host
+	receivers map[endpointKey]component.Component
+	stopped   bool
+}
+
+// New returns a Receiver creating instances of the receiver configured with baseCfg, merged with the configuration
+// of the discovered endpoints.
+func New(set receiver.Settings, cfg *discovery.ReceiverConfig, baseCfg component.Config, create CreateFunc) (*Receiver, error) {
+	if baseCfg == nil {
+		return nil, fmt.Errorf("receiver %q is not configured", set.ID)
+	}
+	match := make(map[string]*regexp.Regexp, len(cfg.Match))
+	for name, expr := range cfg.Match {
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("receiver %q: match %q: %w", set.ID, name, err)
+		}
+		match[name] = re
+	}
+	return &Receiver{
+		set:       set,
+		cfg:       cfg,
+		match:     match,
+		baseCfg:   baseCfg,
+		create:    create,
+		receivers: make(map[endpointKey]component.Component),
+	}, nil
+}
+
+// Start subscribes to the watched observers, which must be enabled extensions.
+func (r *Receiver) Start(_ context.Context, host component.Host) error {
+	r.mu.Lock()
+	r.host = host
+	r.mu.Unlock()
+
+	exts := host.GetExtensions()
+	for _, id := range r.cfg.WatchObservers {
+		ext, ok := exts[id]
+		if !ok {
+			return fmt.Errorf("observer %q is not an enabled extension", id)
+		}
+		obs, ok := ext.(observer.Observer)
+		if !ok {
+			return fmt.Errorf("extension %q is not an observer", id)
+		}
+		n := &notify{receiver: r, observerID: id}
+		r.subscriptions = append(r.subscriptions, subscription{observer: obs, notify: n})
+		obs.ListAndWatch(n)
+	}
+	return nil
+}
+
+// Shutdown unsubscribes from the observers, then shuts down the receivers of the endpoints.
+func (r *Receiver) Shutdown(ctx context.Context) error {
+	// The observers may be waiting for the lock to notify a change, so unsubscribe before taking it.
+	for _, s := range r.subscriptions {
+		s.observer.Unsubscribe(s.notify)
+	}
+	r.subscriptions = nil
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	var errs error
+	for key, rcv := range r.receivers {
+		errs = errors.Join(errs, rcv.Shutdown(ctx))
+		delete(r.receivers, key)
+	}
+	return errs
+}
+
+func (r *Receiver) matches(vars map[string]string) bool {
+	for name, re := range r.match {
+		val, ok := vars[name]
+		if !ok || !re.MatchString(val) {
+			return false
+		}
+	}
+	return true
+}
+
+// endpointConfig returns the configuration of the receiver for an endpoint.
+func (r *Receiver) endpointConfig(vars map[string]string) (component.Config, error) {
+	expanded, err := expand(r.cfg.Config, vars)
+	if err != nil {
+		return nil, err
+	}
+	cfg := copyConfig(r.baseCfg)
+	if m, ok := expanded.(map[string]any); ok {
+		if err = confmap.NewFromStringMap(m).Unmarshal(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, component.ValidateConfig(cfg)
+}
+
+// startEndpoint creates and starts the receiver of an endpoint if it matches. It must be called with the lock held.
+func (r *Receiver) startEndpoint(key endpointKey, e observer.Endpoint) {
+	vars := endpointVariables(e)
+	if r.stopped || !r.matches(vars) {
+		return
+	}
+	set := r.set
+	set.Logger = r.set.Logger.With(zap.String("observer", key.observerID.String()), zap.String("endpoint", e.ID))
+	cfg, err := r.endpointConfig(vars)
+	if err != nil {
+		set.Logger.Error("Invalid receiver configuration for the discovered endpoint", zap.Error(err))
+		return
+	}
+	rcv, err := r.create(context.Background(), set, cfg)
+	if err != nil {
+		set.Logger.Error("Failed to create the receiver for the discovered endpoint", zap.Error(err))
+		return
+	}
+	if err = rcv.Start(context.Background(), r.host); err != nil {
+		set.Logger.Error("Failed to start the receiver for the discovered endpoint", zap.Error(err))
+		return
+	}
+	set.Logger.Info("Started the receiver for the discovered endpoint", zap.String("target", e.Target))
+	r.receivers[key] = rcv
+}
+
+// stopEndpoint shuts down the receiver of an endpoint, if any. It must be called with the lock held.
+func (r *Receiver) stopEndpoint(key endpointKey) {
+	rcv, ok := r.receivers[key]
+	if !ok {
+		return
+	}
+	delete(r.receivers, key)
+	logger := r.set.Logger.With(zap.String("observer", key.observerID.String()), zap.String("endpoint", key.endpointID))
+	if err := rcv.Shutdown(context.Background()); err != nil {
+		logger.Error("Failed to shut down the receiver for the removed endpoint", zap.Error(err))
+		return
+	}
+	logger.Info("Stopped the receiver for the removed endpoint")
+}
+
+// notify receives the notifications of one observer.
+type notify struct {
+	receiver   *Receiver
+	observerID component.ID
+}
+
+func (n *notify) OnAdd(added []observer.Endpoint) {
+	n.receiver.mu.Lock()
+	defer n.receiver.mu.Unlock()
+	for _, e := range added {
+		n.receiver.startEndpoint(endpointKey{observerID: n.observerID, endpointID: e.ID}, e)
+	}
+}
+
+func (n *notify) OnRemove(removed []observer.Endpoint) {
+	n.receiver.mu.Lock()
+	defer n.receiver.mu.Unlock()
+	for _, e := range removed {
+		n.receiver.stopEndpoint(endpointKey{observerID: n.observerID, endpointID: e.ID})
+	}
+}
+
+// OnChange restarts the receivers of the changed endpoints, since their configuration may depend on the
+// changed target or details.
+func (n *notify) OnChange(changed []observer.Endpoint) {
+	n.receiver.mu.Lock()
+	defer n.receiver.mu.Unlock()
+	for _, e := range changed {
+		key := endpointKey{observerID: n.observerID, endpointID: e.ID}
+		n.receiver.stopEndpoint(key)
+		n.receiver.startEndpoint(key, e)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package discoveryreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/discovery"
+)
+
+type testConfig struct {
+	Endpoint string            `mapstructure:"endpoint"`
+	Port     int               `mapstructure:"port"`
+	Labels   map[string]string `mapstructure:"labels"`
+}
+
+func (cfg *testConfig) Validate() error {
+	if cfg.Port == 0 {
+		return errors.New("port is required")
+	}
+	return nil
+}
+
+type testObserver struct {
+	component.StartFunc
+	component.ShutdownFunc
+	notify observer.Notify
+}
+
+func (o *testObserver) ListAndWatch(notify observer.Notify) {
+	o.notify = notify
+}
+
+func (o *testObserver) Unsubscribe(observer.Notify) {
+	o.notify = nil
+}
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type testReceiver struct {
+	cfg     *testConfig
+	started bool
+	stopped bool
+}
+
+func (r *testReceiver) Start(context.Context, component.Host) error {
+	r.started = true
+	return nil
+}
+
+func (r *testReceiver) Shutdown(context.Context) error {
+	r.stopped = true
+	return nil
+}
+
+type testCreator struct {
+	mu        sync.Mutex
+	receivers map[string]*testReceiver
+}
+
+func (c *testCreator) create(_ context.Context, _ receiver.Settings, cfg component.Config) (component.Component, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rcv := &testReceiver{cfg: cfg.(*testConfig)}
+	c.receivers[rcv.cfg.Endpoint] = rcv
+	return rcv, nil
+}
+
+func TestReceiver(t *testing.T) {
+	baseCfg := &testConfig{Port: 1, Labels: map[string]string{"env": "prod"}}
+	creator := &testCreator{receivers: make(map[string]*testReceiver)}
+	r, err := New(receivertest.NewNopSettings(), &discovery.ReceiverConfig{
+		WatchObservers: []component.ID{component.MustNewID("observer")},
+		Match:          map[string]string{"app": "redis"},
+		Config: map[string]any{
+			"endpoint": "`target`",
+			"port":     "`port`",
+			"labels":   map[string]any{"pod": "pod-`pod`"},
+		},
+	}, baseCfg, creator.create)
+	require.NoError(t, err)
+
+	obs := &testObserver{}
+	host := &testHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{component.MustNewID("observer"): obs}}
+	require.NoError(t, r.Start(context.Background(), host))
+	require.NotNil(t, obs.notify)
+
+	obs.notify.OnAdd([]observer.Endpoint{
+		{ID: "a", Target: "10.0.0.1:6379", Details: map[string]string{"app": "redis", "port": "6379", "pod": "a"}},
+		{ID: "b", Target: "10.0.0.2:80", Details: map[string]string{"app": "nginx", "port": "80", "pod": "b"}},
+		{ID: "c", Target: "10.0.0.3:6379", Details: map[string]string{"app": "redis", "pod": "c"}},
+	})
+	// The endpoint "b" does not match, and the configuration of "c" is invalid.
+	require.Len(t, creator.receivers, 1)
+	a := creator.receivers["10.0.0.1:6379"]
+	assert.True(t, a.started)
+	assert.Equal(t, &testConfig{Endpoint: "10.0.0.1:6379", Port: 6379, Labels: map[string]string{"env": "prod", "pod": "pod-a"}}, a.cfg)
+	// The configuration of the receiver is not modified.
+	assert.Equal(t, &testConfig{Port: 1, Labels: map[string]string{"env": "prod"}}, baseCfg)
+
+	obs.notify.OnChange([]observer.Endpoint{
+		{ID: "a", Target: "10.0.0.4:6379", Details: map[string]string{"app": "redis", "port": "6379", "pod": "a"}},
+	})
+	assert.True(t, a.stopped)
+	require.Contains(t, creator.receivers, "10.0.0.4:6379")
+	changed := creator.receivers["10.0.0.4:6379"]
+	assert.True(t, changed.started)
+
+	obs.notify.OnRemove([]observer.Endpoint{{ID: "a"}})
+	assert.True(t, changed.stopped)
+
+	obs.notify.OnAdd([]observer.Endpoint{
+		{ID: "d", Target: "10.0.0.5:6379", Details: map[string]string{"app": "redis", "port": "6379", "pod": "d"}},
+	})
+	d := creator.receivers["10.0.0.5:6379"]
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.True(t, d.stopped)
+	assert.Nil(t, obs.notify)
+}
+
+func TestReceiverStartErrors(t *testing.T) {
+	cfg := &discovery.ReceiverConfig{WatchObservers: []component.ID{component.MustNewID("observer")}}
+	_, err := New(receivertest.NewNopSettings(), cfg, nil, nil)
+	assert.ErrorContains(t, err, "is not configured")
+
+	r, err := New(receivertest.NewNopSettings(), cfg, &testConfig{}, nil)
+	require.NoError(t, err)
+	host := &testHost{Host: componenttest.NewNopHost()}
+	assert.EqualError(t, r.Start(context.Background(), host), `observer "observer" is not an enabled extension`)
+
+	host.extensions = map[component.ID]component.Component{component.MustNewID("observer"): &testReceiver{}}
+	assert.EqualError(t, r.Start(context.Background(), host), `extension "observer" is not an observer`)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package discoveryreceiver // import "go.opentelemetry.io/collector/service/internal/discoveryreceiver"
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+)
+
+// referenceRegexp matches the references to the variables of an endpoint.
+var referenceRegexp = regexp.MustCompile("`([^`]*)`")
+
+// endpointVariables returns the variables of an endpoint, which are its details, its "id", its "type" and its
+// "target".
+func endpointVariables(e observer.Endpoint) map[string]string {
+	vars := make(map[string]string, len(e.Details)+3)
+	for k, v := range e.Details {
+		vars[k] = v
+	}
+	vars["id"] = e.ID
+	vars["type"] = string(e.Type)
+	vars["target"] = e.Target
+	return vars
+}
+
+// expand replaces the references to the variables in the strings of the value. A string made of a single
+// reference is replaced by an integer, a float or a boolean if the value of the variable is one.
+func expand(value any, vars map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if loc := referenceRegexp.FindStringSubmatchIndex(v); loc != nil && loc[0] == 0 && loc[1] == len(v) {
+			val, ok := vars[v[loc[2]:loc[3]]]
+			if !ok {
+				return nil, fmt.Errorf("unknown endpoint variable %q", v[loc[2]:loc[3]])
+			}
+			return typedValue(val), nil
+		}
+		var err error
+		expanded := referenceRegexp.ReplaceAllStringFunc(v, func(ref string) string {
+			val, ok := vars[ref[1:len(ref)-1]]
+			if !ok && err == nil {
+				err = fmt.Errorf("unknown endpoint variable %q", ref[1:len(ref)-1])
+			}
+			return val
+		})
+		return expanded, err
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, val := range v {
+			var err error
+			if expanded[key], err = expand(val, vars); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case []any:
+		expanded := make([]any, len(v))
+		for i, val := range v {
+			var err error
+			if expanded[i], err = expand(val, vars); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	}
+	return value, nil
+}
+
+func typedValue(val string) any {
+	if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(val, 64); err == nil {
+		return f
+	}
+	switch val {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return val
+}
+
+// copyConfig returns a deep copy of the configuration, so the configurations of the endpoints do not share the
+// maps, slices and pointers of the configuration of the receiver. Unexported fields are copied shallowly.
+func copyConfig(cfg any) any {
+	return copyValue(reflect.ValueOf(cfg)).Interface()
+}
+
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(copyValue(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(copyValue(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(copyValue(v.Elem()))
+		return c
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package discoveryreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/experimental/observer"
+)
+
+func TestExpand(t *testing.T) {
+	vars := endpointVariables(observer.Endpoint{
+		ID:      "pod/a",
+		Type:    observer.EndpointTypePort,
+		Target:  "10.0.0.1:6379",
+		Details: map[string]string{"port": "6379", "ratio": "0.5", "tls": "true", "name": "redis"},
+	})
+	expanded, err := expand(map[string]any{
+		"endpoint": "`target`",
+		"port":     "`port`",
+		"ratio":    "`ratio`",
+		"tls":      "`tls`",
+		"url":      "redis://`target`/`name`",
+		"tags":     []any{"`id`", "`type`", 1},
+	}, vars)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"endpoint": "10.0.0.1:6379",
+		"port":     int64(6379),
+		"ratio":    0.5,
+		"tls":      true,
+		"url":      "redis://10.0.0.1:6379/redis",
+		"tags":     []any{"pod/a", "port", 1},
+	}, expanded)
+
+	_, err = expand(map[string]any{"endpoint": "`unknown`"}, vars)
+	assert.EqualError(t, err, `unknown endpoint variable "unknown"`)
+	_, err = expand([]any{"http://`unknown`"}, vars)
+	assert.EqualError(t, err, `unknown endpoint variable "unknown"`)
+}
+
+type nestedConfig struct {
+	Name string
+}
+
+type copiedConfig struct {
+	Nested   *nestedConfig
+	Tags     []string
+	Labels   map[string]string
+	Value    any
+	internal *nestedConfig
+}
+
+func TestCopyConfig(t *testing.T) {
+	orig := &copiedConfig{
+		Nested:   &nestedConfig{Name: "nested"},
+		Tags:     []string{"a"},
+		Labels:   map[string]string{"k": "v"},
+		Value:    &nestedConfig{Name: "value"},
+		internal: &nestedConfig{Name: "internal"},
+	}
+	c := copyConfig(orig).(*copiedConfig)
+	assert.Equal(t, orig, c)
+	assert.NotSame(t, orig, c)
+	assert.NotSame(t, orig.Nested, c.Nested)
+	assert.NotSame(t, orig.Value, c.Value)
+	// Unexported fields are shallow copies.
+	assert.Same(t, orig.internal, c.internal)
+
+	c.Tags[0] = "b"
+	c.Labels["k"] = "w"
+	assert.Equal(t, []string{"a"}, orig.Tags)
+	assert.Equal(t, map[string]string{"k": "v"}, orig.Labels)
+}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componententities"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/watchdog"
+)
+
+// edgeKey identifies an edge of the graph by the IDs of its nodes.
+type edgeKey struct {
+	from, to int64
+}
+
+// edgeStats counts the items, i.e. spans, data points, log records or entity events, which went through an edge of the graph.
+type edgeStats struct {
+	items       atomic.Int64
+	failedItems atomic.Int64
+	// progress tracks the calls to the component the edge leads to, if watched.
+	progress *watchdog.Progress
+}
+
+func (s *edgeStats) start() {
+	if s.progress != nil {
+		s.progress.Begin()
+	}
+}
+
+func (s *edgeStats) record(items int, err error) {
+	s.items.Add(int64(items))
+	if err != nil {
+		s.failedItems.Add(int64(items))
+	}
+	if s.progress != nil {
+		s.progress.End(err)
+	}
+}
+
+// edgeRates computes the rates of the edges between two loads of the zPages.
+type edgeRates struct {
+	mu       sync.Mutex
+	lastTime time.Time
+	last     map[edgeKey][2]int64
+}
+
+// edgeSnapshot is the state of an edge at the time of a load of the zPages.
+type edgeSnapshot struct {
+	from, to    graph.Node
+	items       int64
+	failedItems int64
+	// itemsRate and failedItemsRate are the rates per second since the previous load, or since the graph was built.
+	itemsRate       float64
+	failedItemsRate float64
+}
+
+// countEdge returns the next consumer wrapped to count the items consumed through the edge between the two nodes.
+func (g *Graph) countEdge(from, to graph.Node, next baseConsumer) baseConsumer {
+	stats := &edgeStats{progress: g.progressOf(to)}
+	g.edges[edgeKey{from: from.ID(), to: to.ID()}] = stats
+	switch edgeDataType(to) {
+	case component.DataTypeTraces:
+		return &tracesEdge{Traces: next.(consumer.Traces), stats: stats}
+	case component.DataTypeMetrics:
+		return &metricsEdge{Metrics: next.(consumer.Metrics), stats: stats}
+	case component.DataTypeLogs:
+		return &logsEdge{Logs: next.(consumer.Logs), stats: stats}
+	case componententities.DataTypeEntities:
+		return &entitiesEdge{Entities: next.(consumerentities.Entities), stats: stats}
+	}
+	return next
+}
+
+// edgeDataType returns the type of the data consumed by a node.
+func edgeDataType(to graph.Node) component.DataType {
+	switch n := to.(type) {
+	case *processorNode:
+		return n.pipelineID.Type()
+	case *exporterNode:
+		return n.pipelineType
+	case *connectorNode:
+		return n.exprPipelineType
+	case *capabilitiesNode:
+		return n.pipelineID.Type()
+	case *fanOutNode:
+		return n.pipelineID.Type()
+	}
+	return component.DataType{}
+}
+
+// edgePipeline returns the pipeline an edge belongs to. The edges towards a pipeline, from its receivers or from
+// connectors, belong to this pipeline, and the other edges belong to the pipeline of their source.
+func edgePipeline(from, to graph.Node) component.ID {
+	if n, ok := to.(*capabilitiesNode); ok {
+		return n.pipelineID
+	}
+	switch n := from.(type) {
+	case *capabilitiesNode:
+		return n.pipelineID
+	case *processorNode:
+		return n.pipelineID
+	case *fanOutNode:
+		return n.pipelineID
+	}
+	return component.ID{}
+}
+
+// snapshotEdges returns the state of every edge of the graph, sorted by pipeline then in the order of the data flow.
+func (g *Graph) snapshotEdges(now time.Time) []edgeSnapshot {
+	g.rates.mu.Lock()
+	defer g.rates.mu.Unlock()
+	elapsed := now.Sub(g.rates.lastTime).Seconds()
+	current := make(map[edgeKey][2]int64, len(g.edges))
+	snapshots := make([]edgeSnapshot, 0, len(g.edges))
+	for key, stats := range g.edges {
+		counts := [2]int64{stats.items.Load(), stats.failedItems.Load()}
+		current[key] = counts
+		s := edgeSnapshot{
+			from:        g.componentGraph.Node(key.from),
+			to:          g.componentGraph.Node(key.to),
+			items:       counts[0],
+			failedItems: counts[1],
+		}
+		if elapsed > 0 {
+			last := g.rates.last[key]
+			s.itemsRate = float64(counts[0]-last[0]) / elapsed
+			s.failedItemsRate = float64(counts[1]-last[1]) / elapsed
+		}
+		snapshots = append(snapshots, s)
+	}
+	g.rates.lastTime = now
+	g.rates.last = current
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		pi, pj := edgePipeline(snapshots[i].from, snapshots[i].to), edgePipeline(snapshots[j].from, snapshots[j].to)
+		if pi != pj {
+			return pi.String() < pj.String()
+		}
+		ri, rj := g.edgeRank(snapshots[i].from), g.edgeRank(snapshots[j].from)
+		if ri != rj {
+			return ri < rj
+		}
+		return nodeLabel(snapshots[i].from)+nodeLabel(snapshots[i].to) < nodeLabel(snapshots[j].from)+nodeLabel(snapshots[j].to)
+	})
+	return snapshots
+}
+
+// edgeRank orders the edges of a pipeline by the position of their source in the data flow.
+func (g *Graph) edgeRank(from graph.Node) int {
+	switch n := from.(type) {
+	case *receiverNode, *connectorNode:
+		return 0
+	case *capabilitiesNode:
+		return 1
+	case *processorNode:
+		for i, proc := range g.pipelines[n.pipelineID].processors {
+			if proc == n {
+				return 2 + i
+			}
+		}
+	case *fanOutNode:
+		return 2 + len(g.pipelines[n.pipelineID].processors)
+	}
+	return 0
+}
+
+// nodeLabel returns the name of a node as displayed in the zPages.
+func nodeLabel(node graph.Node) string {
+	switch n := node.(type) {
+	case *receiverNode:
+		return "receiver " + n.componentID.String()
+	case *processorNode:
+		return "processor " + n.componentID.String()
+	case *exporterNode:
+		return "exporter " + n.componentID.String()
+	case *connectorNode:
+		return "connector " + n.componentID.String()
+	case *capabilitiesNode:
+		return "pipeline " + n.pipelineID.String()
+	case *fanOutNode:
+		return "pipeline " + n.pipelineID.String() + " (fanout)"
+	}
+	return ""
+}
+
+type tracesEdge struct {
+	consumer.Traces
+	stats *edgeStats
+}
+
+func (e *tracesEdge) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	items := td.SpanCount()
+	e.stats.start()
+	err := e.Traces.ConsumeTraces(ctx, td)
+	e.stats.record(items, err)
+	return err
+}
+
+// ClonesOnDemand implements fanoutconsumer.CloneOnDemand.
+func (e *tracesEdge) ClonesOnDemand() bool {
+	return clonesOnDemand(e.Traces)
+}
+
+type metricsEdge struct {
+	consumer.Metrics
+	stats *edgeStats
+}
+
+func (e *metricsEdge) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	items := md.DataPointCount()
+	e.stats.start()
+	err := e.Metrics.ConsumeMetrics(ctx, md)
+	e.stats.record(items, err)
+	return err
+}
+
+// ClonesOnDemand implements fanoutconsumer.CloneOnDemand.
+func (e *metricsEdge) ClonesOnDemand() bool {
+	return clonesOnDemand(e.Metrics)
+}
+
+type logsEdge struct {
+	consumer.Logs
+	stats *edgeStats
+}
+
+func (e *logsEdge) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	items := ld.LogRecordCount()
+	e.stats.start()
+	err := e.Logs.ConsumeLogs(ctx, ld)
+	e.stats.record(items, err)
+	return err
+}
+
+// ClonesOnDemand implements fanoutconsumer.CloneOnDemand.
+func (e *logsEdge) ClonesOnDemand() bool {
+	return clonesOnDemand(e.Logs)
+}
+
+type entitiesEdge struct {
+	consumerentities.Entities
+	stats *edgeStats
+}
+
+func (e *entitiesEdge) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	items := ed.EventCount()
+	e.stats.start()
+	err := e.Entities.ConsumeEntities(ctx, ed)
+	e.stats.record(items, err)
+	return err
+}
+
+// ClonesOnDemand implements fanoutconsumer.CloneOnDemand.
+func (e *entitiesEdge) ClonesOnDemand() bool {
+	return clonesOnDemand(e.Entities)
+}
+
+func clonesOnDemand(c any) bool {
+	cod, ok := c.(fanoutconsumer.CloneOnDemand)
+	return ok && cod.ClonesOnDemand()
+}
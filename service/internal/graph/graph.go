@@ -17,6 +17,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -25,13 +27,20 @@ import (
 	"gonum.org/v1/gonum/graph/topo"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componententities"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/service/discovery"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/internal/limitsconsumer"
+	"go.opentelemetry.io/collector/service/internal/resourceconsumer"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/watchdog"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -48,6 +57,22 @@ type Settings struct {
 	// PipelineConfigs is a map of component.ID to PipelineConfig.
 	PipelineConfigs pipelines.Config
 
+	// DiscoveryConfigs configures the receivers only instantiated for the endpoints discovered by the observers.
+	DiscoveryConfigs discovery.Config
+
+	// Limits are enforced on the data received by all the receivers, before it enters the pipelines. ReceiverLimits
+	// override them for specific receivers.
+	Limits         limitsconsumer.Limits
+	ReceiverLimits map[component.ID]limitsconsumer.Limits
+
+	// ResourceAttributes are set on the resources of the data at the end of every pipeline, before the exporters
+	// and connectors. The attributes already set are only replaced if OverrideResourceAttributes is true.
+	ResourceAttributes         map[string]string
+	OverrideResourceAttributes bool
+
+	// Watchdog configures the detection of the processors, exporters and connectors which stopped making progress.
+	Watchdog watchdog.Settings
+
 	ReportStatus status.ServiceStatusFunc
 }
 
@@ -61,6 +86,17 @@ type Graph struct {
 	// Keep track of status source per node
 	instanceIDs map[int64]*componentstatus.InstanceID
 
+	// Count the items going through each edge, to expose the data rates in the zPages.
+	edges map[edgeKey]*edgeStats
+	rates edgeRates
+
+	// Track the progress of the processors, exporters and connectors if the watchdog is enabled.
+	watchdog *watchdog.Watchdog
+	progress map[int64]*watchdog.Progress
+
+	// Serialize the receivers being paused and resumed.
+	pauseMu sync.Mutex
+
 	telemetry component.TelemetrySettings
 }
 
@@ -71,6 +107,8 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[component.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
+		edges:          make(map[edgeKey]*edgeStats),
+		rates:          edgeRates{lastTime: time.Now()},
 		telemetry:      set.Telemetry,
 	}
 	for pipelineID := range set.PipelineConfigs {
@@ -79,6 +117,13 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 			exporters: make(map[int64]graph.Node),
 		}
 	}
+	if set.Watchdog.Enabled() {
+		var err error
+		if pipelines.watchdog, err = watchdog.New(set.Watchdog, set.Telemetry); err != nil {
+			return nil, err
+		}
+		pipelines.progress = make(map[int64]*watchdog.Progress)
+	}
 	if err := pipelines.createNodes(set); err != nil {
 		return nil, err
 	}
@@ -284,14 +329,15 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, set.DiscoveryConfigs[n.componentID],
+				set.Limits.Merge(set.ReceiverLimits[n.componentID]), g.nextConsumers(n.ID()))
 		case *processorNode:
 			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextPipelineConsumer(n.ID()))
 		case *exporterNode:
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
 		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextPipelines(n.ID()))
 		case *capabilitiesNode:
 			capability := consumer.Capabilities{
 				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
@@ -300,7 +346,10 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 			for _, proc := range g.pipelines[n.pipelineID].processors {
 				capability.MutatesData = capability.MutatesData || proc.getConsumer().Capabilities().MutatesData
 			}
-			next := g.nextConsumers(n.ID())[0]
+			next := g.nextPipelineConsumer(n.ID())
+			if concurrency := set.PipelineConfigs[n.pipelineID].Concurrency; concurrency.NumConsumers > 0 {
+				next = n.buildQueue(next, concurrency, components.PipelineLogger(set.Telemetry.Logger, n.pipelineID))
+			}
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
@@ -314,6 +363,10 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
 				n.baseConsumer = cc
 				n.ConsumeLogsFunc = cc.ConsumeLogs
+			case componententities.DataTypeEntities:
+				cc := capabilityconsumer.NewEntities(next.(consumerentities.Entities), capability)
+				n.baseConsumer = cc
+				n.ConsumeEntitiesFunc = cc.ConsumeEntities
 			}
 		case *fanOutNode:
 			nexts := g.nextConsumers(n.ID())
@@ -324,18 +377,36 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 					consumers = append(consumers, next.(consumer.Traces))
 				}
 				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
+				if len(set.ResourceAttributes) > 0 {
+					n.baseConsumer = resourceconsumer.NewTraces(n.baseConsumer.(consumer.Traces), set.ResourceAttributes, set.OverrideResourceAttributes)
+				}
 			case component.DataTypeMetrics:
 				consumers := make([]consumer.Metrics, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Metrics))
 				}
 				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
+				if len(set.ResourceAttributes) > 0 {
+					n.baseConsumer = resourceconsumer.NewMetrics(n.baseConsumer.(consumer.Metrics), set.ResourceAttributes, set.OverrideResourceAttributes)
+				}
 			case component.DataTypeLogs:
 				consumers := make([]consumer.Logs, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Logs))
 				}
 				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
+				if len(set.ResourceAttributes) > 0 {
+					n.baseConsumer = resourceconsumer.NewLogs(n.baseConsumer.(consumer.Logs), set.ResourceAttributes, set.OverrideResourceAttributes)
+				}
+			case componententities.DataTypeEntities:
+				consumers := make([]consumerentities.Entities, 0, len(nexts))
+				for _, next := range nexts {
+					consumers = append(consumers, next.(consumerentities.Entities))
+				}
+				n.baseConsumer = fanoutconsumer.NewEntities(consumers)
+				if len(set.ResourceAttributes) > 0 {
+					n.baseConsumer = resourceconsumer.NewEntities(n.baseConsumer.(consumerentities.Entities), set.ResourceAttributes, set.OverrideResourceAttributes)
+				}
 			}
 		}
 		if err != nil {
@@ -347,14 +418,51 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 // Find all nodes
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
+	from := g.componentGraph.Node(nodeID)
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		nexts = append(nexts, g.countEdge(from, nextNodes.Node(), nextNodes.Node().(consumerNode).getConsumer()))
+	}
+	return nexts
+}
+
+// nextPipelines returns the next consumers of a connector node, which are the pipelines it emits to, by pipeline ID.
+func (g *Graph) nextPipelines(nodeID int64) map[component.ID]baseConsumer {
+	from := g.componentGraph.Node(nodeID)
+	nextNodes := g.componentGraph.From(nodeID)
+	nexts := make(map[component.ID]baseConsumer, nextNodes.Len())
+	for nextNodes.Next() {
+		capNode := nextNodes.Node().(*capabilitiesNode)
+		nexts[capNode.pipelineID] = g.countEdge(from, capNode, capNode.getConsumer())
 	}
 	return nexts
 }
 
+// nextPipelineConsumer returns the next consumer of a capabilities or processor node, which is guaranteed to be unique.
+// Pipelines accept shared read-only data and clone it on demand, see capabilitiesNode. A mutating processor is
+// wrapped so that it clones the data before mutating it, if the data is still shared when reaching the processor.
+// The fanout node for the exporters already clones shared data for the mutating exporters.
+func (g *Graph) nextPipelineConsumer(nodeID int64) baseConsumer {
+	nextNodes := g.componentGraph.From(nodeID)
+	nextNodes.Next()
+	next := nextNodes.Node().(consumerNode).getConsumer()
+	procNode, ok := nextNodes.Node().(*processorNode)
+	if ok && next.Capabilities().MutatesData {
+		switch procNode.pipelineID.Type() {
+		case component.DataTypeTraces:
+			next = fanoutconsumer.NewCloneOnDemandTraces(next.(consumer.Traces))
+		case component.DataTypeMetrics:
+			next = fanoutconsumer.NewCloneOnDemandMetrics(next.(consumer.Metrics))
+		case component.DataTypeLogs:
+			next = fanoutconsumer.NewCloneOnDemandLogs(next.(consumer.Logs))
+		case componententities.DataTypeEntities:
+			next = fanoutconsumer.NewCloneOnDemandEntities(next.(consumerentities.Entities))
+		}
+	}
+	return g.countEdge(g.componentGraph.Node(nodeID), nextNodes.Node(), next)
+}
+
 // A node-based representation of a pipeline configuration.
 type pipelineNodes struct {
 	// Use map to assist with deduplication of connector instances.
@@ -389,6 +497,11 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 	// component's consumer is ready to consume.
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
+		if capNode, ok := node.(*capabilitiesNode); ok && capNode.queue != nil {
+			// The processors of the pipeline are started, the receivers are not yet.
+			capNode.queue.Start()
+			continue
+		}
 		comp, ok := node.(component.Component)
 
 		if !ok {
@@ -419,6 +532,10 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 
 		host.Reporter.ReportOKIfStarting(instanceID)
 	}
+
+	if g.watchdog != nil {
+		g.startWatchdog(host)
+	}
 	return nil
 }
 
@@ -428,6 +545,11 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 		return err
 	}
 
+	// The components are not reported stalled while they are stopped.
+	if g.watchdog != nil {
+		g.watchdog.Shutdown()
+	}
+
 	// Stop in topological order so that upstream components
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
@@ -435,6 +557,11 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 	var errs error
 	for i := 0; i < len(nodes); i++ {
 		node := nodes[i]
+		if capNode, ok := node.(*capabilitiesNode); ok && capNode.queue != nil {
+			// The receivers of the pipeline are stopped, drain the pending data before stopping the processors.
+			errs = multierr.Append(errs, capNode.queue.Shutdown(ctx))
+			continue
+		}
 		comp, ok := node.(component.Component)
 
 		if !ok {
@@ -481,6 +608,10 @@ func (g *Graph) GetExporters() map[component.DataType]map[component.ID]component
 		for _, expNode := range pg.exporters {
 			// Skip connectors, otherwise individual components can introduce cycles
 			if expNode, ok := g.componentGraph.Node(expNode.ID()).(*exporterNode); ok {
+				// The entities exporters are only listed when there is an entities pipeline.
+				if exportersMap[expNode.pipelineType] == nil {
+					exportersMap[expNode.pipelineType] = make(map[component.ID]component.Component)
+				}
 				exportersMap[expNode.pipelineType][expNode.componentID] = expNode.Component
 			}
 		}
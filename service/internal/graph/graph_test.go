@@ -10,12 +10,14 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gonum.org/v1/gonum/graph/simple"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componententities"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
@@ -24,15 +26,21 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/discovery"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/discoveryreceiver"
+	"go.opentelemetry.io/collector/service/internal/limitsconsumer"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/status/statustest"
 	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/internal/watchdog"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -381,6 +389,65 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 			},
 			expectedPerExporter: 2,
 		},
+		{
+			name: "pipelines_concurrency.yaml",
+			pipelineConfigs: pipelines.Config{
+				component.MustNewID("traces"): {
+					Receivers:   []component.ID{component.MustNewID("examplereceiver")},
+					Processors:  []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+					Exporters:   []component.ID{component.MustNewID("exampleexporter")},
+					Concurrency: pipelines.ConcurrencyConfig{NumConsumers: 2, BufferSize: 10},
+				},
+				component.MustNewID("metrics"): {
+					Receivers:   []component.ID{component.MustNewID("examplereceiver")},
+					Processors:  []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+					Exporters:   []component.ID{component.MustNewID("exampleexporter")},
+					Concurrency: pipelines.ConcurrencyConfig{NumConsumers: 2, BufferSize: 10},
+				},
+				component.MustNewID("logs"): {
+					Receivers:   []component.ID{component.MustNewID("examplereceiver")},
+					Processors:  []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+					Exporters:   []component.ID{component.MustNewID("exampleexporter")},
+					Concurrency: pipelines.ConcurrencyConfig{NumConsumers: 2, BufferSize: 10},
+				},
+			},
+			expectedPerExporter: 1,
+		},
+		{
+			name: "pipelines_concurrency_shared_receiver.yaml",
+			pipelineConfigs: pipelines.Config{
+				component.MustNewIDWithName("traces", "sync"): {
+					Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+					Processors: []component.ID{component.MustNewID("exampleprocessor")},
+					Exporters:  []component.ID{component.MustNewID("exampleexporter")},
+				},
+				component.MustNewIDWithName("traces", "async"): {
+					Receivers:   []component.ID{component.MustNewID("examplereceiver")},
+					Processors:  []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+					Exporters:   []component.ID{component.MustNewIDWithName("exampleexporter", "1")},
+					Concurrency: pipelines.ConcurrencyConfig{NumConsumers: 1},
+				},
+			},
+			expectedPerExporter: 1,
+		},
+		{
+			name: "pipelines_conn_concurrency_traces.yaml",
+			pipelineConfigs: pipelines.Config{
+				component.MustNewIDWithName("traces", "in"): {
+					Receivers:   []component.ID{component.MustNewID("examplereceiver")},
+					Processors:  []component.ID{component.MustNewID("exampleprocessor")},
+					Exporters:   []component.ID{component.MustNewIDWithName("exampleconnector", "inherit_mutate")},
+					Concurrency: pipelines.ConcurrencyConfig{NumConsumers: 1},
+				},
+				component.MustNewIDWithName("traces", "out"): {
+					Receivers:   []component.ID{component.MustNewIDWithName("exampleconnector", "inherit_mutate")},
+					Processors:  []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+					Exporters:   []component.ID{component.MustNewID("exampleexporter")},
+					Concurrency: pipelines.ConcurrencyConfig{NumConsumers: 4, BufferSize: 100},
+				},
+			},
+			expectedPerExporter: 1,
+		},
 		{
 			name: "pipelines_conn_simple_traces.yaml",
 			pipelineConfigs: pipelines.Config{
@@ -1212,6 +1279,334 @@ func TestConnectorRouter(t *testing.T) {
 
 }
 
+func TestGraphBuildDiscoveryReceiver(t *testing.T) {
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(nil, nil),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{component.MustNewID("examplereceiver")},
+				Exporters: []component.ID{component.MustNewID("exampleexporter")},
+			},
+			component.MustNewID("metrics"): {
+				Receivers: []component.ID{component.MustNewID("examplereceiver")},
+				Exporters: []component.ID{component.MustNewID("exampleexporter")},
+			},
+		},
+		DiscoveryConfigs: discovery.Config{
+			component.MustNewID("examplereceiver"): {WatchObservers: []component.ID{component.MustNewID("observer")}},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	// The receiver is only instantiated for the discovered endpoints, once per data type.
+	receivers := pg.getReceivers()
+	for _, dt := range []component.DataType{component.DataTypeTraces, component.DataTypeMetrics} {
+		require.Len(t, receivers[dt], 1)
+		assert.IsType(t, &discoveryreceiver.Receiver{}, receivers[dt][component.MustNewID("examplereceiver")])
+	}
+}
+
+func TestGraphResourceAttributes(t *testing.T) {
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(nil, nil),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{component.MustNewID("examplereceiver")},
+				Exporters: []component.ID{component.MustNewID("exampleexporter")},
+			},
+		},
+		ResourceAttributes: map[string]string{"host.name": "collector-host"},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	// The pipeline mutates the data, so the receivers do not share it with other pipelines.
+	capabilities := pg.pipelines[component.MustNewID("traces")].capabilitiesNode
+	assert.True(t, capabilities.Capabilities().MutatesData)
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, capabilities.ConsumeTraces(context.Background(), td))
+	exp := pg.GetExporters()[component.DataTypeTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+	require.Len(t, exp.Traces, 1)
+	hostName, ok := exp.Traces[0].ResourceSpans().At(0).Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, "collector-host", hostName.Str())
+}
+
+func TestGraphReceiverLimits(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(nil, nil),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			component.NewID(component.DataTypeTraces): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		Limits:         limitsconsumer.Limits{MaxAttributes: 1, MaxSpanEvents: 1},
+		ReceiverLimits: map[component.ID]limitsconsumer.Limits{rcvrID: {MaxAttributes: 2}},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("a", "a")
+	span.Attributes().PutStr("b", "b")
+	span.Attributes().PutStr("c", "c")
+	span.Events().AppendEmpty()
+	span.Events().AppendEmpty()
+	rcvr := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), td))
+
+	traces := pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter).Traces
+	require.Len(t, traces, 1)
+	got := traces[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	// The receiver limits override the limit on the number of attributes, the limit on the number of events applies.
+	assert.Equal(t, 2, got.Attributes().Len())
+	assert.Equal(t, uint32(1), got.DroppedAttributesCount())
+	assert.Equal(t, 1, got.Events().Len())
+	assert.Equal(t, uint32(1), got.DroppedEventsCount())
+}
+
+func TestGraphWatchdog(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("exampleprocessor")
+	expID := component.MustNewID("exampleexporter")
+	pipelineID := component.NewID(component.DataTypeTraces)
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				procID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			pipelineID: {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+		Watchdog: watchdog.Settings{StallThreshold: time.Minute},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	// The processors and exporters are watched, the processor waits on the exporter.
+	procNode := newProcessorNode(pipelineID, procID)
+	expNode := newExporterNode(component.DataTypeTraces, expID)
+	require.Len(t, pg.progress, 2)
+	require.Contains(t, pg.progress, procNode.ID())
+	require.Contains(t, pg.progress, expNode.ID())
+	assert.Equal(t, []*watchdog.Progress{pg.progress[expNode.ID()]}, pg.downstreamProgress(procNode.ID()))
+	assert.Empty(t, pg.downstreamProgress(expNode.ID()))
+
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+	rcvr := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+}
+
+func TestGraphPauseReceiver(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(nil, nil),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			component.NewID(component.DataTypeTraces): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			component.NewID(component.DataTypeLogs): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	tracesRcvr := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	logsRcvr := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+	assert.Equal(t, map[component.ID]bool{rcvrID: false}, pg.GetReceivers())
+
+	// The data is refused for all the data types while the receiver is paused.
+	require.NoError(t, pg.PauseReceiver(context.Background(), rcvrID))
+	require.NoError(t, pg.PauseReceiver(context.Background(), rcvrID))
+	assert.Equal(t, map[component.ID]bool{rcvrID: true}, pg.GetReceivers())
+	assert.ErrorIs(t, tracesRcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), errReceiverPaused)
+	assert.ErrorIs(t, logsRcvr.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), errReceiverPaused)
+
+	require.NoError(t, pg.ResumeReceiver(context.Background(), rcvrID))
+	assert.Equal(t, map[component.ID]bool{rcvrID: false}, pg.GetReceivers())
+	require.NoError(t, tracesRcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, logsRcvr.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Len(t, pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter).Traces, 1)
+
+	assert.EqualError(t, pg.PauseReceiver(context.Background(), component.MustNewID("unknown")), `receiver "unknown" is not used in any pipeline`)
+}
+
+func TestGraphEntitiesPipeline(t *testing.T) {
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				component.MustNewIDWithName("exampleprocessor", "mutate"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"):                testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+				component.MustNewIDWithName("exampleexporter", "two"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			component.NewID(componententities.DataTypeEntities): {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+				Exporters:  []component.ID{component.MustNewID("exampleexporter"), component.MustNewIDWithName("exampleexporter", "two")},
+			},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+
+	capabilities := pg.pipelines[component.NewID(componententities.DataTypeEntities)].capabilitiesNode
+	assert.True(t, capabilities.Capabilities().MutatesData)
+
+	ed := pentity.NewEntities()
+	event := ed.ResourceEntities().AppendEmpty().ScopeEntities().AppendEmpty().EntityEvents().AppendEmpty()
+	event.SetEntityType("k8s.pod")
+	event.SetType(pentity.EventTypeDelete)
+	require.NoError(t, capabilities.ConsumeEntities(context.Background(), ed))
+
+	exporters := pg.GetExporters()[componententities.DataTypeEntities]
+	require.Len(t, exporters, 2)
+	for _, exp := range exporters {
+		entities := exp.(*testcomponents.ExampleExporter).Entities
+		require.Len(t, entities, 1)
+		assert.Equal(t, 1, entities[0].EventCount())
+		assert.Equal(t, pentity.EventTypeDelete, entities[0].ResourceEntities().At(0).ScopeEntities().At(0).EntityEvents().At(0).Type())
+	}
+	assert.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+}
+
 func TestGraphBuildErrors(t *testing.T) {
 	nopReceiverFactory := receivertest.NewNopFactory()
 	nopProcessorFactory := processortest.NewNopFactory()
@@ -1248,6 +1643,45 @@ func TestGraphBuildErrors(t *testing.T) {
 			},
 			expected: "failed to create \"bf\" exporter for data type \"logs\": telemetry type is not supported",
 		},
+		{
+			name: "not_supported_exporter_entities",
+			receiverCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				component.MustNewID("bf"): badExporterFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.MustNewID("entities"): {
+					Receivers: []component.ID{component.MustNewID("nop")},
+					Exporters: []component.ID{component.MustNewID("bf")},
+				},
+			},
+			expected: "failed to create \"bf\" exporter for data type \"entities\": telemetry type is not supported",
+		},
+		{
+			name: "connector_entities",
+			receiverCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopExporterFactory.CreateDefaultConfig(),
+			},
+			connectorCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopConnectorFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.MustNewIDWithName("entities", "in"): {
+					Receivers: []component.ID{component.MustNewID("nop")},
+					Exporters: []component.ID{component.MustNewID("nop")},
+				},
+				component.MustNewIDWithName("entities", "out"): {
+					Receivers: []component.ID{component.MustNewID("nop")},
+					Exporters: []component.ID{component.MustNewID("nop")},
+				},
+			},
+			expected: "connector \"nop\" used as exporter in entities pipeline but not used in any supported receiver pipeline",
+		},
 		{
 			name: "not_supported_exporter_metrics",
 			receiverCfgs: map[component.ID]component.Config{
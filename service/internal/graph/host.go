@@ -4,15 +4,27 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"path"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/eventlog"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
@@ -24,8 +36,17 @@ type getExporters interface {
 	GetExporters() map[component.DataType]map[component.ID]component.Component
 }
 
+// receiverController is implemented by the Host, so the extensions can pause and resume the receivers.
+type receiverController interface {
+	GetReceivers() map[component.ID]bool
+	PauseReceiver(ctx context.Context, id component.ID) error
+	ResumeReceiver(ctx context.Context, id component.ID) error
+}
+
 var _ getExporters = (*Host)(nil)
+var _ receiverController = (*Host)(nil)
 var _ component.Host = (*Host)(nil)
+var _ eventlog.Provider = (*Host)(nil)
 
 type Host struct {
 	AsyncErrorChannel chan error
@@ -42,6 +63,15 @@ type Host struct {
 	ServiceExtensions *extensions.Extensions
 
 	Reporter status.Reporter
+
+	// StatusAggregator keeps the latest status of every component, it may be nil.
+	StatusAggregator *componentstatus.Aggregator
+
+	// EventLog keeps the recent events of the collector, it may be nil.
+	EventLog *eventlog.Log
+
+	// CollectorConf is the effective configuration of the collector, it may be nil.
+	CollectorConf *confmap.Conf
 }
 
 func (host *Host) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
@@ -74,7 +104,45 @@ func (host *Host) GetExporters() map[component.DataType]map[component.ID]compone
 	return host.Pipelines.GetExporters()
 }
 
+// GetReceivers returns the IDs of the receivers of the pipelines, with whether they are paused.
+func (host *Host) GetReceivers() map[component.ID]bool {
+	return host.Pipelines.GetReceivers()
+}
+
+// PauseReceiver pauses the receiver with the given ID until ResumeReceiver is called, see Graph.PauseReceiver.
+func (host *Host) PauseReceiver(ctx context.Context, id component.ID) error {
+	if err := host.Pipelines.PauseReceiver(ctx, id); err != nil {
+		return err
+	}
+	if host.EventLog != nil {
+		host.EventLog.Record(eventlog.KindLifecycle, component.KindReceiver.String()+" "+id.String(), "Paused")
+	}
+	return nil
+}
+
+// ResumeReceiver resumes the receiver with the given ID, paused by PauseReceiver.
+func (host *Host) ResumeReceiver(ctx context.Context, id component.ID) error {
+	if err := host.Pipelines.ResumeReceiver(ctx, id); err != nil {
+		return err
+	}
+	if host.EventLog != nil {
+		host.EventLog.Record(eventlog.KindLifecycle, component.KindReceiver.String()+" "+id.String(), "Resumed")
+	}
+	return nil
+}
+
+// GetEventLog implements eventlog.Provider.
+func (host *Host) GetEventLog() *eventlog.Log {
+	return host.EventLog
+}
+
 func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	if host.StatusAggregator != nil {
+		host.StatusAggregator.ComponentStatusChanged(source, event)
+	}
+	if host.EventLog != nil {
+		recordStatusEvent(host.EventLog, source, event)
+	}
 	host.ServiceExtensions.NotifyComponentStatusChange(source, event)
 	if event.Status() == componentstatus.StatusFatalError {
 		host.AsyncErrorChannel <- event.Err()
@@ -85,10 +153,19 @@ const (
 	// Paths
 	zServicePath   = "servicez"
 	zPipelinePath  = "pipelinez"
+	zGraphPath     = "graphz"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zStatusPath    = "statusz"
+	zHealthPath    = "healthz"
+	zEventPath     = "eventz"
+	zConfigPath    = "configz"
 )
 
+// defaultSource is the source of the configuration values not set by the resolved configuration,
+// e.g. the default values of the components.
+const defaultSource = "default"
+
 var (
 	// InfoVar is a singleton instance of the Info struct.
 	runtimeInfoVar [][2]string
@@ -107,8 +184,13 @@ func init() {
 func (host *Host) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.Pipelines.HandleZPages)
+	mux.HandleFunc(path.Join(pathPrefix, zGraphPath), host.Pipelines.HandleGraphZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.ServiceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zStatusPath), host.handleStatuszRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zHealthPath), host.handleHealthzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zEventPath), host.handleEventzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zConfigPath), host.handleConfigzRequest)
 }
 
 func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -121,6 +203,11 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Pipeline Graph",
+		ComponentEndpoint: zGraphPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
@@ -131,10 +218,249 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zFeaturePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Component Status",
+		ComponentEndpoint: zStatusPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Events",
+		ComponentEndpoint: zEventPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Configuration",
+		ComponentEndpoint: zConfigPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// componentStatus is the status of a single component instance, as exposed by the health endpoint.
+type componentStatus struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Pipelines []string  `json:"pipelines,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// healthResponse is the body returned by the health endpoint.
+type healthResponse struct {
+	Status     string            `json:"status"`
+	Components []componentStatus `json:"components"`
+}
+
+// getComponentStatuses returns the aggregated status and the status of every component instance,
+// sorted by kind, ID and pipelines.
+func (host *Host) getComponentStatuses() (componentstatus.Status, []componentStatus) {
+	if host.StatusAggregator == nil {
+		return componentstatus.StatusNone, nil
+	}
+	var statuses []componentStatus
+	host.StatusAggregator.Events(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		cs := componentStatus{
+			Kind:      id.Kind().String(),
+			ID:        id.ComponentID().String(),
+			Status:    ev.Status().String(),
+			Timestamp: ev.Timestamp(),
+		}
+		id.AllPipelineIDs(func(pipelineID component.ID) bool {
+			cs.Pipelines = append(cs.Pipelines, pipelineID.String())
+			return true
+		})
+		sort.Strings(cs.Pipelines)
+		if ev.Err() != nil {
+			cs.Error = ev.Err().Error()
+		}
+		statuses = append(statuses, cs)
+	})
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Kind != statuses[j].Kind {
+			return statuses[i].Kind < statuses[j].Kind
+		}
+		if statuses[i].ID != statuses[j].ID {
+			return statuses[i].ID < statuses[j].ID
+		}
+		return strings.Join(statuses[i].Pipelines, ",") < strings.Join(statuses[j].Pipelines, ",")
+	})
+	return host.StatusAggregator.Status(), statuses
+}
+
+func (host *Host) handleStatuszRequest(w http.ResponseWriter, _ *http.Request) {
+	aggregated, statuses := host.getComponentStatuses()
+	properties := make([][2]string, 0, len(statuses))
+	for _, cs := range statuses {
+		name := cs.Kind + " " + cs.ID
+		if len(cs.Pipelines) > 0 {
+			name += " [" + strings.Join(cs.Pipelines, ", ") + "]"
+		}
+		value := cs.Status
+		if cs.Error != "" {
+			value += ": " + cs.Error
+		}
+		properties = append(properties, [2]string{name, value})
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Component Status"})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Collector", Properties: [][2]string{{"Status", aggregated.String()}}})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Components", Properties: properties})
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
+// handleHealthzRequest returns the aggregated status of the components as JSON. The response
+// status code is 200 if all the components are running, possibly with recoverable errors, and 503 otherwise.
+func (host *Host) handleHealthzRequest(w http.ResponseWriter, _ *http.Request) {
+	aggregated, statuses := host.getComponentStatuses()
+	w.Header().Set("Content-Type", "application/json")
+	switch aggregated {
+	case componentstatus.StatusOK, componentstatus.StatusRecoverableError:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(healthResponse{Status: aggregated.String(), Components: statuses})
+}
+
+// recordStatusEvent records a status change of a component in the event log. The recoverable errors
+// reported by the exporters are recorded as export failures.
+func recordStatusEvent(log *eventlog.Log, source *componentstatus.InstanceID, event *componentstatus.Event) {
+	kind := eventlog.KindLifecycle
+	msg := event.Status().String()
+	if event.Err() != nil {
+		kind = eventlog.KindError
+		if source.Kind() == component.KindExporter && event.Status() == componentstatus.StatusRecoverableError {
+			kind = eventlog.KindExportFailure
+		}
+		msg += ": " + event.Err().Error()
+	}
+	log.Record(kind, source.Kind().String()+" "+source.ComponentID().String(), msg)
+}
+
+// eventsResponse is the body returned by the events endpoint in the JSON format.
+type eventsResponse struct {
+	Dropped uint64           `json:"dropped"`
+	Events  []eventlog.Event `json:"events"`
+}
+
+// handleEventzRequest lists the events of the event log, the most recent first. The events are returned
+// as JSON if the format query parameter is "json", and can be filtered by kind with the kind query parameter.
+func (host *Host) handleEventzRequest(w http.ResponseWriter, r *http.Request) {
+	var events []eventlog.Event
+	var dropped uint64
+	if host.EventLog != nil {
+		kind := eventlog.Kind(r.URL.Query().Get("kind"))
+		all := host.EventLog.Events()
+		for i := len(all) - 1; i >= 0; i-- {
+			if kind == "" || all[i].Kind == kind {
+				events = append(events, all[i])
+			}
+		}
+		dropped = host.EventLog.Dropped()
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(eventsResponse{Dropped: dropped, Events: events})
+		return
+	}
+	properties := make([][2]string, 0, len(events))
+	for _, ev := range events {
+		value := string(ev.Kind)
+		if ev.Component != "" {
+			value += " " + ev.Component
+		}
+		properties = append(properties, [2]string{ev.Timestamp.Format(time.RFC3339Nano), value + ": " + ev.Message})
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Events"})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Event Log", Properties: [][2]string{{"Dropped", strconv.FormatUint(dropped, 10)}}})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Events", Properties: properties})
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// configEntry is a value of the effective configuration, as exposed by the configuration endpoint.
+type configEntry struct {
+	Key     string   `json:"key"`
+	Value   any      `json:"value"`
+	Sources []string `json:"sources"`
+}
+
+// configResponse is the body returned by the configuration endpoint in the JSON format.
+type configResponse struct {
+	Entries []configEntry `json:"entries"`
+}
+
+// getConfigEntries returns the values of the effective configuration sorted by key, with the URIs they come from.
+func (host *Host) getConfigEntries() []configEntry {
+	if host.CollectorConf == nil {
+		return []configEntry{}
+	}
+	keys := host.CollectorConf.AllKeys()
+	sort.Strings(keys)
+	provenance := host.CollectorConf.Provenance()
+	entries := make([]configEntry, 0, len(keys))
+	for _, key := range keys {
+		sources := provenance.Sources(key)
+		if len(sources) == 0 {
+			sources = []string{defaultSource}
+		}
+		entries = append(entries, configEntry{Key: key, Value: host.CollectorConf.Get(key), Sources: sources})
+	}
+	return entries
+}
+
+// handleConfigzRequest lists the values of the effective configuration, annotated with the URIs they come from,
+// e.g. a file or an environment variable, or "default" if they were not set by the configuration. The values are
+// returned as JSON if the format query parameter is "json".
+func (host *Host) handleConfigzRequest(w http.ResponseWriter, r *http.Request) {
+	entries := host.getConfigEntries()
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(configResponse{Entries: entries})
+		return
+	}
+	properties := make([][2]string, 0, len(entries))
+	for _, e := range entries {
+		properties = append(properties, [2]string{e.Key, fmt.Sprintf("%v (%s)", e.Value, strings.Join(e.Sources, ", "))})
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Effective Configuration"})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Configuration", Properties: properties})
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// featurezToken protects the feature gates from cross-site requests: it is only served with the list of
+// the feature gates, which other sites cannot read.
+var featurezToken = sync.OnceValue(func() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+})
+
+// handleFeaturezRequest lists the feature gates. A POST request with the gate and enabled form values
+// enables or disables a dynamic gate, then redirects to the list. The request must hold the token
+// of the list in the token form value.
+func handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		if subtle.ConstantTimeCompare([]byte(r.PostFormValue("token")), []byte(featurezToken())) != 1 {
+			http.Error(w, "invalid or missing token", http.StatusForbidden)
+			return
+		}
+		enabled, err := strconv.ParseBool(r.PostFormValue("enabled"))
+		if err == nil {
+			err = featuregate.GlobalRegistry().SetAtRuntime(r.PostFormValue("gate"), enabled)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
 	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
@@ -142,7 +468,7 @@ func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
 }
 
 func getFeaturesTableData() zpages.FeatureGateTableData {
-	data := zpages.FeatureGateTableData{}
+	data := zpages.FeatureGateTableData{Token: featurezToken()}
 	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {
 		data.Rows = append(data.Rows, zpages.FeatureGateTableRowData{
 			ID:           gate.ID(),
@@ -152,6 +478,7 @@ func getFeaturesTableData() zpages.FeatureGateTableData {
 			FromVersion:  gate.FromVersion(),
 			ToVersion:    gate.ToVersion(),
 			ReferenceURL: gate.ReferenceURL(),
+			Dynamic:      gate.IsDynamic(),
 		})
 	})
 	return data
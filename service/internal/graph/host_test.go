@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/eventlog"
+	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/builders"
+)
+
+func TestHostComponentStatus(t *testing.T) {
+	exts, err := extensions.New(context.Background(), extensions.Settings{
+		Telemetry:  componenttest.NewNopTelemetrySettings(),
+		BuildInfo:  component.NewDefaultBuildInfo(),
+		Extensions: builders.NewExtension(nil, nil),
+	}, nil)
+	require.NoError(t, err)
+	host := &Host{
+		AsyncErrorChannel: make(chan error, 1),
+		ServiceExtensions: exts,
+		StatusAggregator:  componentstatus.NewAggregator(),
+	}
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+
+	getHealth := func() (int, healthResponse) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/healthz", nil))
+		var resp healthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec.Code, resp
+	}
+
+	code, resp := getHealth()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "StatusNone", resp.Status)
+
+	receiver := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver,
+		component.MustNewID("traces"), component.MustNewID("metrics"))
+	exporter := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter, component.MustNewID("traces"))
+	host.NotifyComponentStatusChange(receiver, componentstatus.NewEvent(componentstatus.StatusStarting))
+	host.NotifyComponentStatusChange(exporter, componentstatus.NewEvent(componentstatus.StatusStarting))
+	code, resp = getHealth()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "StatusStarting", resp.Status)
+
+	host.NotifyComponentStatusChange(receiver, componentstatus.NewEvent(componentstatus.StatusOK))
+	host.NotifyComponentStatusChange(exporter, componentstatus.NewRecoverableErrorEvent(errors.New("connection refused")))
+	code, resp = getHealth()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "StatusRecoverableError", resp.Status)
+	require.Len(t, resp.Components, 2)
+	assert.Equal(t, "Exporter", resp.Components[0].Kind)
+	assert.Equal(t, "otlp", resp.Components[0].ID)
+	assert.Equal(t, []string{"traces"}, resp.Components[0].Pipelines)
+	assert.Equal(t, "connection refused", resp.Components[0].Error)
+	assert.Equal(t, "Receiver", resp.Components[1].Kind)
+	assert.Equal(t, []string{"metrics", "traces"}, resp.Components[1].Pipelines)
+	assert.Equal(t, "StatusOK", resp.Components[1].Status)
+
+	host.NotifyComponentStatusChange(exporter, componentstatus.NewPermanentErrorEvent(errors.New("invalid credentials")))
+	code, resp = getHealth()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "StatusPermanentError", resp.Status)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/statusz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "StatusPermanentError: invalid credentials")
+	assert.Contains(t, rec.Body.String(), "Receiver otlp [metrics, traces]")
+}
+
+func TestHostEventLog(t *testing.T) {
+	exts, err := extensions.New(context.Background(), extensions.Settings{
+		Telemetry:  componenttest.NewNopTelemetrySettings(),
+		BuildInfo:  component.NewDefaultBuildInfo(),
+		Extensions: builders.NewExtension(nil, nil),
+	}, nil)
+	require.NoError(t, err)
+	host := &Host{
+		AsyncErrorChannel: make(chan error, 1),
+		ServiceExtensions: exts,
+		EventLog:          eventlog.NewLog(eventlog.NewDefaultSettings()),
+	}
+	log, ok := eventlog.FromHost(host)
+	require.True(t, ok)
+	assert.Same(t, host.EventLog, log)
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+
+	receiver := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver, component.MustNewID("traces"))
+	exporter := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter, component.MustNewID("traces"))
+	host.NotifyComponentStatusChange(receiver, componentstatus.NewEvent(componentstatus.StatusStarting))
+	host.NotifyComponentStatusChange(exporter, componentstatus.NewRecoverableErrorEvent(errors.New("connection refused")))
+	host.NotifyComponentStatusChange(receiver, componentstatus.NewPermanentErrorEvent(errors.New("address in use")))
+
+	getEvents := func(query string) eventsResponse {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/eventz?format=json"+query, nil))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var resp eventsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	// The most recent events are listed first.
+	resp := getEvents("")
+	require.Len(t, resp.Events, 3)
+	assert.Equal(t, eventlog.KindError, resp.Events[0].Kind)
+	assert.Equal(t, "Receiver otlp", resp.Events[0].Component)
+	assert.Equal(t, "StatusPermanentError: address in use", resp.Events[0].Message)
+	assert.Equal(t, eventlog.KindExportFailure, resp.Events[1].Kind)
+	assert.Equal(t, "Exporter otlp", resp.Events[1].Component)
+	assert.Equal(t, eventlog.KindLifecycle, resp.Events[2].Kind)
+	assert.Equal(t, "StatusStarting", resp.Events[2].Message)
+
+	resp = getEvents("&kind=export_failure")
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, "StatusRecoverableError: connection refused", resp.Events[0].Message)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/eventz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "export_failure Exporter otlp: StatusRecoverableError: connection refused")
+}
+
+var testDynamicGate = featuregate.GlobalRegistry().MustRegister("service.test.dynamic", featuregate.StageAlpha,
+	featuregate.WithRegisterDynamic())
+
+func TestHostFeaturezSetAtRuntime(t *testing.T) {
+	host := &Host{}
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+	post := func(gate, enabled string) *httptest.ResponseRecorder {
+		form := url.Values{"token": {featurezToken()}, "gate": {gate}, "enabled": {enabled}}
+		req := httptest.NewRequest(http.MethodPost, "/debug/featurez", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(testDynamicGate.ID(), "true")
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/debug/featurez", rec.Header().Get("Location"))
+	assert.True(t, testDynamicGate.IsEnabled())
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/featurez", nil))
+	assert.Contains(t, rec.Body.String(), "Disable")
+	assert.Contains(t, rec.Body.String(), featurezToken())
+
+	// A request without the token of the list is rejected, e.g. a form of another site.
+	form := url.Values{"gate": {testDynamicGate.ID()}, "enabled": {"false"}}
+	req := httptest.NewRequest(http.MethodPost, "/debug/featurez", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.True(t, testDynamicGate.IsEnabled())
+
+	assert.Equal(t, http.StatusBadRequest, post(testDynamicGate.ID(), "maybe").Code)
+	assert.Equal(t, http.StatusBadRequest, post("service.test.unknown", "false").Code)
+
+	assert.Equal(t, http.StatusSeeOther, post(testDynamicGate.ID(), "false").Code)
+	assert.False(t, testDynamicGate.IsEnabled())
+}
+
+type testConfigProvider struct {
+	values map[string]any
+}
+
+func (p *testConfigProvider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	return confmap.NewRetrieved(p.values[strings.TrimPrefix(uri, "test:")])
+}
+
+func (p *testConfigProvider) Scheme() string {
+	return "test"
+}
+
+func (p *testConfigProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestHostConfigz(t *testing.T) {
+	provider := &testConfigProvider{values: map[string]any{
+		"config": map[string]any{
+			"receivers": map[string]any{
+				"otlp": map[string]any{
+					"endpoint": "${test:endpoint}",
+				},
+			},
+		},
+		"endpoint": "localhost:4317",
+	}}
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:              []string{"test:config"},
+		ProviderFactories: []confmap.ProviderFactory{confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider { return provider })},
+	})
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	// The effective configuration holds the default values of the components.
+	conf := confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "localhost:4317",
+				"timeout":  "5s",
+			},
+		},
+	})
+	conf.SetProvenance(resolved.Provenance())
+	host := &Host{CollectorConf: conf}
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/configz?format=json", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp configResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []configEntry{
+		{Key: "receivers::otlp::endpoint", Value: "localhost:4317", Sources: []string{"test:config", "test:endpoint"}},
+		{Key: "receivers::otlp::timeout", Value: "5s", Sources: []string{"default"}},
+	}, resp.Entries)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/configz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "localhost:4317 (test:config, test:endpoint)")
+
+	// Without configuration, the list is empty.
+	mux = http.NewServeMux()
+	(&Host{}).RegisterZPages(mux, "/debug")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/configz?format=json", nil))
+	assert.JSONEq(t, `{"entries":[]}`, rec.Body.String())
+}
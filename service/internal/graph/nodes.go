@@ -8,17 +8,27 @@ import (
 	"fmt"
 	"hash/fnv"
 	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componententities"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/discovery"
+	"go.opentelemetry.io/collector/service/internal/asyncconsumer"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/internal/discoveryreceiver"
+	"go.opentelemetry.io/collector/service/internal/limitsconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 const (
@@ -58,6 +68,8 @@ type receiverNode struct {
 	componentID  component.ID
 	pipelineType component.DataType
 	component.Component
+	// The data passed by the receiver to the pipelines is refused while it is paused.
+	paused *atomic.Bool
 }
 
 func newReceiverNode(pipelineType component.DataType, recvID component.ID) *receiverNode {
@@ -65,13 +77,18 @@ func newReceiverNode(pipelineType component.DataType, recvID component.ID) *rece
 		nodeID:       newNodeID(receiverSeed, pipelineType.String(), recvID.String()),
 		componentID:  recvID,
 		pipelineType: pipelineType,
+		paused:       new(atomic.Bool),
 	}
 }
 
+// buildComponent builds the receiver. When discoveryCfg is set, the receiver is only instantiated for the endpoints
+// discovered by the observers, see discoveryreceiver.
 func (n *receiverNode) buildComponent(ctx context.Context,
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder builders.Receiver,
+	discoveryCfg *discovery.ReceiverConfig,
+	limits limitsconsumer.Limits,
 	nexts []baseConsumer,
 ) error {
 	tel.Logger = components.ReceiverLogger(tel.Logger, n.componentID, n.pipelineType)
@@ -83,19 +100,75 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set, fanoutconsumer.NewTraces(consumers))
+		var next consumer.Traces = fanoutconsumer.NewTraces(consumers)
+		if limits.Enabled() {
+			if next, err = limitsconsumer.NewTraces(next, limits, tel, n.componentID); err != nil {
+				break
+			}
+		}
+		next = pausedTraces{Traces: next, paused: n.paused}
+		if discoveryCfg == nil {
+			n.Component, err = builder.CreateTraces(ctx, set, next)
+			break
+		}
+		n.Component, err = n.buildDiscoveryReceiver(set, builder, discoveryCfg,
+			func(ctx context.Context, set receiver.Settings, f receiver.Factory, cfg component.Config) (component.Component, error) {
+				return f.CreateTracesReceiver(ctx, set, cfg, next)
+			})
 	case component.DataTypeMetrics:
 		var consumers []consumer.Metrics
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set, fanoutconsumer.NewMetrics(consumers))
+		var next consumer.Metrics = fanoutconsumer.NewMetrics(consumers)
+		if limits.Enabled() {
+			if next, err = limitsconsumer.NewMetrics(next, limits, tel, n.componentID); err != nil {
+				break
+			}
+		}
+		next = pausedMetrics{Metrics: next, paused: n.paused}
+		if discoveryCfg == nil {
+			n.Component, err = builder.CreateMetrics(ctx, set, next)
+			break
+		}
+		n.Component, err = n.buildDiscoveryReceiver(set, builder, discoveryCfg,
+			func(ctx context.Context, set receiver.Settings, f receiver.Factory, cfg component.Config) (component.Component, error) {
+				return f.CreateMetricsReceiver(ctx, set, cfg, next)
+			})
 	case component.DataTypeLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set, fanoutconsumer.NewLogs(consumers))
+		var next consumer.Logs = fanoutconsumer.NewLogs(consumers)
+		if limits.Enabled() {
+			if next, err = limitsconsumer.NewLogs(next, limits, tel, n.componentID); err != nil {
+				break
+			}
+		}
+		next = pausedLogs{Logs: next, paused: n.paused}
+		if discoveryCfg == nil {
+			n.Component, err = builder.CreateLogs(ctx, set, next)
+			break
+		}
+		n.Component, err = n.buildDiscoveryReceiver(set, builder, discoveryCfg,
+			func(ctx context.Context, set receiver.Settings, f receiver.Factory, cfg component.Config) (component.Component, error) {
+				return f.CreateLogsReceiver(ctx, set, cfg, next)
+			})
+	case componententities.DataTypeEntities:
+		var consumers []consumerentities.Entities
+		for _, next := range nexts {
+			consumers = append(consumers, next.(consumerentities.Entities))
+		}
+		var next consumerentities.Entities = pausedEntities{Entities: fanoutconsumer.NewEntities(consumers), paused: n.paused}
+		if discoveryCfg == nil {
+			n.Component, err = builder.CreateEntities(ctx, set, next)
+			break
+		}
+		n.Component, err = n.buildDiscoveryReceiver(set, builder, discoveryCfg,
+			func(ctx context.Context, set receiver.Settings, f receiver.Factory, cfg component.Config) (component.Component, error) {
+				return f.CreateEntitiesReceiver(ctx, set, cfg, next)
+			})
 	default:
 		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
@@ -144,6 +217,8 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		n.Component, err = builder.CreateMetrics(ctx, set, next.(consumer.Metrics))
 	case component.DataTypeLogs:
 		n.Component, err = builder.CreateLogs(ctx, set, next.(consumer.Logs))
+	case componententities.DataTypeEntities:
+		n.Component, err = builder.CreateEntities(ctx, set, next.(consumerentities.Entities))
 	default:
 		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID, n.pipelineID.Type())
 	}
@@ -192,6 +267,8 @@ func (n *exporterNode) buildComponent(
 		n.Component, err = builder.CreateMetrics(ctx, set)
 	case component.DataTypeLogs:
 		n.Component, err = builder.CreateLogs(ctx, set)
+	case componententities.DataTypeEntities:
+		n.Component, err = builder.CreateEntities(ctx, set)
 	default:
 		return fmt.Errorf("error creating exporter %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
@@ -232,7 +309,7 @@ func (n *connectorNode) buildComponent(
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder builders.Connector,
-	nexts []baseConsumer,
+	nexts map[component.ID]baseConsumer,
 ) error {
 	tel.Logger = components.ConnectorLogger(tel.Logger, n.componentID, n.exprPipelineType, n.rcvrPipelineType)
 	set := connector.Settings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
@@ -241,8 +318,8 @@ func (n *connectorNode) buildComponent(
 	case component.DataTypeTraces:
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Traces, len(nexts))
-		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Traces)
+		for pipelineID, next := range nexts {
+			consumers[pipelineID] = next.(consumer.Traces)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewTracesRouter(consumers)
@@ -277,8 +354,8 @@ func (n *connectorNode) buildComponent(
 	case component.DataTypeMetrics:
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Metrics, len(nexts))
-		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Metrics)
+		for pipelineID, next := range nexts {
+			consumers[pipelineID] = next.(consumer.Metrics)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewMetricsRouter(consumers)
@@ -312,8 +389,8 @@ func (n *connectorNode) buildComponent(
 	case component.DataTypeLogs:
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Logs, len(nexts))
-		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Logs)
+		for pipelineID, next := range nexts {
+			consumers[pipelineID] = next.(consumer.Logs)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewLogsRouter(consumers)
@@ -348,12 +425,31 @@ func (n *connectorNode) buildComponent(
 	return nil
 }
 
+// buildDiscoveryReceiver returns a receiver creating an instance of the receiver, with the given create function,
+// for every endpoint discovered by the observers.
+func (n *receiverNode) buildDiscoveryReceiver(
+	set receiver.Settings,
+	builder builders.Receiver,
+	cfg *discovery.ReceiverConfig,
+	create func(context.Context, receiver.Settings, receiver.Factory, component.Config) (component.Component, error),
+) (component.Component, error) {
+	f, ok := builder.Factory(n.componentID.Type()).(receiver.Factory)
+	if !ok {
+		return nil, fmt.Errorf("receiver factory not available for: %q", n.componentID)
+	}
+	return discoveryreceiver.New(set, cfg, builder.Config(n.componentID),
+		func(ctx context.Context, set receiver.Settings, cfg component.Config) (component.Component, error) {
+			return create(ctx, set, f, cfg)
+		})
+}
+
 var _ consumerNode = (*capabilitiesNode)(nil)
 
 // Every pipeline has a "virtual" capabilities node immediately after the receiver(s).
-// There are two purposes for this node:
+// There are three purposes for this node:
 // 1. Present aggregated capabilities to receivers, such as whether the pipeline mutates data.
 // 2. Present a consistent "first consumer" for each pipeline.
+// 3. Accept data shared with other pipelines, which is cloned on demand before the first mutating component.
 // The nodeID is derived from "pipeline ID".
 type capabilitiesNode struct {
 	nodeID
@@ -362,6 +458,15 @@ type capabilitiesNode struct {
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
+	consumerentities.ConsumeEntitiesFunc
+	// queue hands the data over to the processors when the pipeline concurrency is configured.
+	queue asyncQueue
+}
+
+// asyncQueue is implemented by the consumers of the asyncconsumer package.
+type asyncQueue interface {
+	Start()
+	Shutdown(ctx context.Context) error
 }
 
 func newCapabilitiesNode(pipelineID component.ID) *capabilitiesNode {
@@ -375,6 +480,34 @@ func (n *capabilitiesNode) getConsumer() baseConsumer {
 	return n
 }
 
+// buildQueue returns the consumer handing the data over to the next consumer through the queue of the node.
+func (n *capabilitiesNode) buildQueue(next baseConsumer, cfg pipelines.ConcurrencyConfig, logger *zap.Logger) baseConsumer {
+	switch n.pipelineID.Type() {
+	case component.DataTypeTraces:
+		q := asyncconsumer.NewTraces(next.(consumer.Traces), cfg.NumConsumers, cfg.BufferSize, logger)
+		n.queue = q
+		return q
+	case component.DataTypeMetrics:
+		q := asyncconsumer.NewMetrics(next.(consumer.Metrics), cfg.NumConsumers, cfg.BufferSize, logger)
+		n.queue = q
+		return q
+	case component.DataTypeLogs:
+		q := asyncconsumer.NewLogs(next.(consumer.Logs), cfg.NumConsumers, cfg.BufferSize, logger)
+		n.queue = q
+		return q
+	case componententities.DataTypeEntities:
+		q := asyncconsumer.NewEntities(next.(consumerentities.Entities), cfg.NumConsumers, cfg.BufferSize, logger)
+		n.queue = q
+		return q
+	}
+	return next
+}
+
+// ClonesOnDemand implements fanoutconsumer.CloneOnDemand.
+func (n *capabilitiesNode) ClonesOnDemand() bool {
+	return true
+}
+
 var _ consumerNode = (*fanOutNode)(nil)
 
 // Each pipeline has one fan-out node before exporters.
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// errReceiverPaused is returned to the receivers passing data to the pipelines while they are paused. It is not
+// permanent, so the senders retry once the receiver is resumed.
+var errReceiverPaused = errors.New("the receiver is paused")
+
+type pausedTraces struct {
+	consumer.Traces
+	paused *atomic.Bool
+}
+
+func (pt pausedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if pt.paused.Load() {
+		return errReceiverPaused
+	}
+	return pt.Traces.ConsumeTraces(ctx, td)
+}
+
+type pausedMetrics struct {
+	consumer.Metrics
+	paused *atomic.Bool
+}
+
+func (pm pausedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if pm.paused.Load() {
+		return errReceiverPaused
+	}
+	return pm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type pausedLogs struct {
+	consumer.Logs
+	paused *atomic.Bool
+}
+
+func (pl pausedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if pl.paused.Load() {
+		return errReceiverPaused
+	}
+	return pl.Logs.ConsumeLogs(ctx, ld)
+}
+
+type pausedEntities struct {
+	consumerentities.Entities
+	paused *atomic.Bool
+}
+
+func (pe pausedEntities) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	if pe.paused.Load() {
+		return errReceiverPaused
+	}
+	return pe.Entities.ConsumeEntities(ctx, ed)
+}
+
+// receiverNodes returns the nodes of the receiver with the given ID, one per pipeline type it is used in.
+func (g *Graph) receiverNodes(id component.ID) []*receiverNode {
+	var nodes []*receiverNode
+	seen := make(map[int64]bool)
+	for _, pg := range g.pipelines {
+		for nodeID := range pg.receivers {
+			n, ok := g.componentGraph.Node(nodeID).(*receiverNode)
+			if !ok || n.componentID != id || seen[nodeID] {
+				continue
+			}
+			seen[nodeID] = true
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// GetReceivers returns the IDs of the receivers of the pipelines, with whether they are paused.
+func (g *Graph) GetReceivers() map[component.ID]bool {
+	receivers := make(map[component.ID]bool)
+	for _, pg := range g.pipelines {
+		for nodeID := range pg.receivers {
+			if n, ok := g.componentGraph.Node(nodeID).(*receiverNode); ok {
+				receivers[n.componentID] = n.paused.Load()
+			}
+		}
+	}
+	return receivers
+}
+
+// PauseReceiver pauses the receiver with the given ID: the receiver is paused if it implements receiver.Pausable,
+// and the data it passes to the pipelines is refused until it is resumed.
+func (g *Graph) PauseReceiver(ctx context.Context, id component.ID) error {
+	return g.setReceiverPaused(ctx, id, true)
+}
+
+// ResumeReceiver resumes the receiver with the given ID, paused by PauseReceiver.
+func (g *Graph) ResumeReceiver(ctx context.Context, id component.ID) error {
+	return g.setReceiverPaused(ctx, id, false)
+}
+
+func (g *Graph) setReceiverPaused(ctx context.Context, id component.ID, paused bool) error {
+	g.pauseMu.Lock()
+	defer g.pauseMu.Unlock()
+
+	nodes := g.receiverNodes(id)
+	if len(nodes) == 0 {
+		return fmt.Errorf("receiver %q is not used in any pipeline", id)
+	}
+	var errs error
+	for _, n := range nodes {
+		if n.paused.Load() == paused {
+			continue
+		}
+		// The data is refused before the receiver is paused, and accepted before it is resumed.
+		n.paused.Store(paused)
+		p, ok := n.Component.(receiver.Pausable)
+		if !ok {
+			continue
+		}
+		var err error
+		if paused {
+			err = p.Pause(ctx)
+		} else {
+			err = p.Resume(ctx)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to change the state of receiver %q for data type %q: %w", id, n.pipelineType, err))
+		}
+	}
+	return errs
+}
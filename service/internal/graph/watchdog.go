@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/service/internal/watchdog"
+)
+
+// progressOf returns the progress of the component a node represents, nil if the watchdog is disabled or the
+// node is not a processor, an exporter or a connector. The receivers push the data into the pipelines, their
+// progress is the one of the pipelines.
+func (g *Graph) progressOf(node graph.Node) *watchdog.Progress {
+	if g.watchdog == nil {
+		return nil
+	}
+	switch node.(type) {
+	case *processorNode, *exporterNode, *connectorNode:
+	default:
+		return nil
+	}
+	p, ok := g.progress[node.ID()]
+	if !ok {
+		p = watchdog.NewProgress()
+		g.progress[node.ID()] = p
+	}
+	return p
+}
+
+// startWatchdog starts watching the progress of the components, once they are all started.
+func (g *Graph) startWatchdog(host *Host) {
+	for id, p := range g.progress {
+		g.watchdog.Add(watchdog.Target{
+			ID:         g.instanceIDs[id],
+			Progress:   p,
+			Downstream: g.downstreamProgress(id),
+		})
+	}
+	g.watchdog.Start(host.Reporter, host.EventLog)
+}
+
+// downstreamProgress returns the progress of the components the data flows to from a node, directly or not.
+func (g *Graph) downstreamProgress(id int64) []*watchdog.Progress {
+	var downstream []*watchdog.Progress
+	visited := map[int64]bool{id: true}
+	stack := []int64{id}
+	for len(stack) > 0 {
+		nodes := g.componentGraph.From(stack[len(stack)-1])
+		stack = stack[:len(stack)-1]
+		for nodes.Next() {
+			next := nodes.Node().ID()
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if p, ok := g.progress[next]; ok {
+				downstream = append(downstream, p)
+			}
+			stack = append(stack, next)
+		}
+	}
+	return downstream
+}
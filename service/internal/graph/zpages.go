@@ -4,8 +4,11 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"fmt"
 	"net/http"
 	"sort"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
@@ -16,6 +19,7 @@ const (
 	zPipelineName  = "pipelinenamez"
 	zComponentName = "componentnamez"
 	zComponentKind = "componentkindz"
+	zGraphFormat   = "format"
 )
 
 func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
@@ -79,3 +83,72 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+// HandleGraphZPages renders the graph of the pipelines, with the number of items which went through each edge
+// and the data rates since the previous load of the page. The graph is rendered in the DOT language when the
+// format query parameter is "dot".
+func (g *Graph) HandleGraphZPages(w http.ResponseWriter, r *http.Request) {
+	edges := g.snapshotEdges(time.Now())
+	if r.URL.Query().Get(zGraphFormat) == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_, _ = w.Write([]byte(g.dot(edges)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipeline Graph"})
+	data := zpages.GraphTableData{Rows: make([]zpages.GraphTableRowData, 0, len(edges))}
+	for _, e := range edges {
+		data.Rows = append(data.Rows, zpages.GraphTableRowData{
+			Pipeline:        edgePipeline(e.from, e.to).String(),
+			From:            nodeLabel(e.from),
+			To:              nodeLabel(e.to),
+			Items:           e.items,
+			ItemsRate:       fmt.Sprintf("%.2f", e.itemsRate),
+			FailedItems:     e.failedItems,
+			FailedItemsRate: fmt.Sprintf("%.2f", e.failedItemsRate),
+		})
+	}
+	zpages.WriteHTMLGraphTable(w, data)
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "DOT",
+		ComponentEndpoint: "?" + zGraphFormat + "=dot",
+		Link:              true,
+	})
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// dot returns the graph in the DOT language, with the edges labelled with their data rates.
+// The nodes are identified by their ID, since the receivers and exporters have one node per data type.
+func (g *Graph) dot(edges []edgeSnapshot) string {
+	var sb strings.Builder
+	sb.WriteString("digraph pipelines {\n\trankdir=LR;\n")
+	nodes := g.componentGraph.Nodes()
+	ids := make([]int64, 0, nodes.Len())
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		node := g.componentGraph.Node(id)
+		shape := "box"
+		switch node.(type) {
+		case *capabilitiesNode, *fanOutNode:
+			shape = "point"
+		case *connectorNode:
+			shape = "diamond"
+		}
+		fmt.Fprintf(&sb, "\t\"%d\" [label=%q, shape=%s];\n", id, nodeLabel(node), shape)
+	}
+	for _, e := range edges {
+		label := fmt.Sprintf("%.2f items/s", e.itemsRate)
+		color := "black"
+		if e.failedItemsRate > 0 {
+			label += fmt.Sprintf(", %.2f failed/s", e.failedItemsRate)
+			color = "red"
+		}
+		fmt.Fprintf(&sb, "\t\"%d\" -> \"%d\" [label=%q, color=%s];\n", e.from.ID(), e.to.ID(), label, color)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
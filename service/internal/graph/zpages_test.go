@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/status/statustest"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestGraphZPages(t *testing.T) {
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleprocessor"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"):              testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+				component.MustNewIDWithName("exampleexporter", "1"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			},
+		),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewIDWithName("traces", "in"): {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewID("exampleprocessor")},
+				Exporters:  []component.ID{component.MustNewID("exampleexporter"), component.MustNewID("exampleconnector")},
+			},
+			component.MustNewIDWithName("traces", "out"): {
+				Receivers: []component.ID{component.MustNewID("exampleconnector")},
+				Exporters: []component.ID{component.MustNewIDWithName("exampleexporter", "1")},
+			},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+
+	for _, c := range pg.getReceivers()[component.DataTypeTraces] {
+		require.NoError(t, c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+
+	edges := pg.snapshotEdges(time.Now().Add(time.Second))
+	var got [][3]string
+	for _, e := range edges {
+		assert.EqualValues(t, 2, e.items)
+		assert.EqualValues(t, 0, e.failedItems)
+		assert.Greater(t, e.itemsRate, 0.0)
+		got = append(got, [3]string{edgePipeline(e.from, e.to).String(), nodeLabel(e.from), nodeLabel(e.to)})
+	}
+	assert.Equal(t, [][3]string{
+		{"traces/in", "receiver examplereceiver", "pipeline traces/in"},
+		{"traces/in", "pipeline traces/in", "processor exampleprocessor"},
+		{"traces/in", "processor exampleprocessor", "pipeline traces/in (fanout)"},
+		{"traces/in", "pipeline traces/in (fanout)", "connector exampleconnector"},
+		{"traces/in", "pipeline traces/in (fanout)", "exporter exampleexporter"},
+		{"traces/out", "connector exampleconnector", "pipeline traces/out"},
+		{"traces/out", "pipeline traces/out", "pipeline traces/out (fanout)"},
+		{"traces/out", "pipeline traces/out (fanout)", "exporter exampleexporter/1"},
+	}, got)
+
+	// The rates are computed since the previous load of the page.
+	for _, e := range pg.snapshotEdges(time.Now().Add(2 * time.Second)) {
+		assert.EqualValues(t, 2, e.items)
+		assert.Zero(t, e.itemsRate)
+	}
+
+	rec := httptest.NewRecorder()
+	pg.HandleGraphZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/graphz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "connector exampleconnector")
+
+	rec = httptest.NewRecorder()
+	pg.HandleGraphZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/graphz?format=dot", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "digraph pipelines {")
+	assert.Contains(t, rec.Body.String(), `[label="connector exampleconnector", shape=diamond];`)
+
+	assert.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+}
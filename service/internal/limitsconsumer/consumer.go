@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package limitsconsumer // import "go.opentelemetry.io/collector/service/internal/limitsconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/limitsconsumer/internal/metadata"
+)
+
+// receiverKey is the attribute of the internal metrics identifying the receiver of the truncated data.
+const receiverKey = "receiver"
+
+// telemetry counts the items dropped or truncated from the data received by a receiver.
+type telemetry struct {
+	builder *metadata.TelemetryBuilder
+	attrs   metric.MeasurementOption
+}
+
+func newTelemetry(set component.TelemetrySettings, receiverID component.ID) (*telemetry, error) {
+	builder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	return &telemetry{
+		builder: builder,
+		attrs:   metric.WithAttributes(attribute.String(receiverKey, receiverID.String())),
+	}, nil
+}
+
+func (t *telemetry) record(ctx context.Context, c counts) {
+	if c.attributes > 0 {
+		t.builder.ReceiverDroppedAttributes.Add(ctx, c.attributes, t.attrs)
+	}
+	if c.values > 0 {
+		t.builder.ReceiverTruncatedAttributeValues.Add(ctx, c.values, t.attrs)
+	}
+	if c.events > 0 {
+		t.builder.ReceiverDroppedSpanEvents.Add(ctx, c.events, t.attrs)
+	}
+	if c.links > 0 {
+		t.builder.ReceiverDroppedSpanLinks.Add(ctx, c.links, t.attrs)
+	}
+}
+
+// mustCopy returns whether the data must be copied before being truncated: the data is only modified in place
+// when it is exclusive to the consumers and the next consumer mutates it anyway. Otherwise, the consumers have
+// the capabilities of the next consumer and leave the data as is.
+func mustCopy(readOnly bool, next consumer.Capabilities) bool {
+	return readOnly || !next.MutatesData
+}
+
+// NewTraces returns a consumer.Traces truncating the traces exceeding the limits, received by the given receiver.
+func NewTraces(next consumer.Traces, limits Limits, set component.TelemetrySettings, receiverID component.ID) (consumer.Traces, error) {
+	tel, err := newTelemetry(set, receiverID)
+	if err != nil {
+		return nil, err
+	}
+	return limitTraces{Traces: next, limits: limits, telemetry: tel}, nil
+}
+
+type limitTraces struct {
+	consumer.Traces
+	limits    Limits
+	telemetry *telemetry
+}
+
+func (lt limitTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	c := checker{Limits: lt.limits}
+	visitTraces(td, &c)
+	if c.exceeded {
+		if mustCopy(td.IsReadOnly(), lt.Capabilities()) {
+			cp := ptrace.NewTraces()
+			td.CopyTo(cp)
+			td = cp
+		}
+		e := enforcer{Limits: lt.limits}
+		visitTraces(td, &e)
+		lt.telemetry.record(ctx, e.counts)
+	}
+	return lt.Traces.ConsumeTraces(ctx, td)
+}
+
+// NewMetrics returns a consumer.Metrics truncating the metrics exceeding the limits, received by the given receiver.
+func NewMetrics(next consumer.Metrics, limits Limits, set component.TelemetrySettings, receiverID component.ID) (consumer.Metrics, error) {
+	tel, err := newTelemetry(set, receiverID)
+	if err != nil {
+		return nil, err
+	}
+	return limitMetrics{Metrics: next, limits: limits, telemetry: tel}, nil
+}
+
+type limitMetrics struct {
+	consumer.Metrics
+	limits    Limits
+	telemetry *telemetry
+}
+
+func (lm limitMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c := checker{Limits: lm.limits}
+	visitMetrics(md, &c)
+	if c.exceeded {
+		if mustCopy(md.IsReadOnly(), lm.Capabilities()) {
+			cp := pmetric.NewMetrics()
+			md.CopyTo(cp)
+			md = cp
+		}
+		e := enforcer{Limits: lm.limits}
+		visitMetrics(md, &e)
+		lm.telemetry.record(ctx, e.counts)
+	}
+	return lm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// NewLogs returns a consumer.Logs truncating the logs exceeding the limits, received by the given receiver.
+func NewLogs(next consumer.Logs, limits Limits, set component.TelemetrySettings, receiverID component.ID) (consumer.Logs, error) {
+	tel, err := newTelemetry(set, receiverID)
+	if err != nil {
+		return nil, err
+	}
+	return limitLogs{Logs: next, limits: limits, telemetry: tel}, nil
+}
+
+type limitLogs struct {
+	consumer.Logs
+	limits    Limits
+	telemetry *telemetry
+}
+
+func (ll limitLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c := checker{Limits: ll.limits}
+	visitLogs(ld, &c)
+	if c.exceeded {
+		if mustCopy(ld.IsReadOnly(), ll.Capabilities()) {
+			cp := plog.NewLogs()
+			ld.CopyTo(cp)
+			ld = cp
+		}
+		e := enforcer{Limits: ll.limits}
+		visitLogs(ld, &e)
+		ll.telemetry.record(ctx, e.counts)
+	}
+	return ll.Logs.ConsumeLogs(ctx, ld)
+}
+
+func visitResource(res pcommon.Resource, v visitor) {
+	if n := v.attributes(res.Attributes()); n > 0 {
+		res.SetDroppedAttributesCount(res.DroppedAttributesCount() + uint32(n))
+	}
+}
+
+func visitScope(scope pcommon.InstrumentationScope, v visitor) {
+	if n := v.attributes(scope.Attributes()); n > 0 {
+		scope.SetDroppedAttributesCount(scope.DroppedAttributesCount() + uint32(n))
+	}
+}
+
+func visitTraces(td ptrace.Traces, v visitor) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		visitResource(rs.Resource(), v)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			visitScope(ss.Scope(), v)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				visitSpan(ss.Spans().At(k), v)
+			}
+		}
+	}
+}
+
+func visitSpan(span ptrace.Span, v visitor) {
+	if n := v.attributes(span.Attributes()); n > 0 {
+		span.SetDroppedAttributesCount(span.DroppedAttributesCount() + uint32(n))
+	}
+	if n := v.events(span.Events()); n > 0 {
+		span.SetDroppedEventsCount(span.DroppedEventsCount() + uint32(n))
+	}
+	if n := v.links(span.Links()); n > 0 {
+		span.SetDroppedLinksCount(span.DroppedLinksCount() + uint32(n))
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		if n := v.attributes(event.Attributes()); n > 0 {
+			event.SetDroppedAttributesCount(event.DroppedAttributesCount() + uint32(n))
+		}
+	}
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		if n := v.attributes(link.Attributes()); n > 0 {
+			link.SetDroppedAttributesCount(link.DroppedAttributesCount() + uint32(n))
+		}
+	}
+}
+
+// visitMetrics visits the resources and the scopes of the metrics. The attributes of the data points identify
+// their time series: dropping or truncating them would merge distinct series, so they are not limited.
+func visitMetrics(md pmetric.Metrics, v visitor) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		visitResource(rm.Resource(), v)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			visitScope(rm.ScopeMetrics().At(j).Scope(), v)
+		}
+	}
+}
+
+func visitLogs(ld plog.Logs, v visitor) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		visitResource(rl.Resource(), v)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			visitScope(sl.Scope(), v)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				if n := v.attributes(lr.Attributes()); n > 0 {
+					lr.SetDroppedAttributesCount(lr.DroppedAttributesCount() + uint32(n))
+				}
+			}
+		}
+	}
+}
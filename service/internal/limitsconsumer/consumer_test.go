@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package limitsconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	receiverID = component.MustNewID("otlp")
+	limits     = Limits{MaxAttributes: 2, MaxAttributeValueLength: 4, MaxSpanEvents: 2, MaxSpanLinks: 1}
+)
+
+func telemetrySettings(tt componentTestTelemetry) component.TelemetrySettings {
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = tt.meterProvider
+	return set
+}
+
+func putAttributes(m pcommon.Map) {
+	m.PutStr("a", "short")
+	m.PutStr("b", "ok")
+	m.PutInt("c", 3)
+}
+
+func generateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	putAttributes(rs.Resource().Attributes())
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("span")
+	putAttributes(span.Attributes())
+	for i := 0; i < 3; i++ {
+		span.Events().AppendEmpty().Attributes().PutStr("event", "attr")
+	}
+	for i := 0; i < 2; i++ {
+		span.Links().AppendEmpty().Attributes().PutStr("link", "attr")
+	}
+	return td
+}
+
+func counter(name string, value int64) metricdata.Metrics {
+	return metricdata.Metrics{
+		Name: "otelcol_" + name,
+		Unit: map[string]string{
+			"receiver_dropped_attributes":         "{attributes}",
+			"receiver_truncated_attribute_values": "{values}",
+			"receiver_dropped_span_events":        "{events}",
+			"receiver_dropped_span_links":         "{links}",
+		}[name],
+		Description: map[string]string{
+			"receiver_dropped_attributes":         "Number of attributes dropped from the received data because of the limit on the number of attributes.",
+			"receiver_truncated_attribute_values": "Number of attribute values of the received data truncated because of the limit on their length.",
+			"receiver_dropped_span_events":        "Number of span events dropped from the received spans because of the limit on the number of events.",
+			"receiver_dropped_span_links":         "Number of span links dropped from the received spans because of the limit on the number of links.",
+		}[name],
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(attribute.String(receiverKey, receiverID.String())),
+					Value:      value,
+				},
+			},
+		},
+	}
+}
+
+func TestTraces(t *testing.T) {
+	tt := setupTestTelemetry()
+	sink := new(consumertest.TracesSink)
+	tc, err := NewTraces(sink, limits, telemetrySettings(tt), receiverID)
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, tc.Capabilities())
+
+	td := generateTraces()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	// The next consumer does not mutate the data, so it is copied before being truncated.
+	assert.Equal(t, generateTraces(), td)
+
+	rs := sink.AllTraces()[0].ResourceSpans().At(0)
+	assert.Equal(t, 2, rs.Resource().Attributes().Len())
+	assert.Equal(t, uint32(1), rs.Resource().DroppedAttributesCount())
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, 2, span.Attributes().Len())
+	assert.Equal(t, uint32(1), span.DroppedAttributesCount())
+	a, ok := span.Attributes().Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "shor", a.Str())
+	assert.Equal(t, 2, span.Events().Len())
+	assert.Equal(t, uint32(1), span.DroppedEventsCount())
+	assert.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, uint32(1), span.DroppedLinksCount())
+
+	tt.assertMetrics(t, []metricdata.Metrics{
+		counter("receiver_dropped_attributes", 2),
+		counter("receiver_truncated_attribute_values", 2),
+		counter("receiver_dropped_span_events", 1),
+		counter("receiver_dropped_span_links", 1),
+	})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestTracesWithinLimits(t *testing.T) {
+	tt := setupTestTelemetry()
+	sink := new(consumertest.TracesSink)
+	tc, err := NewTraces(sink, Limits{MaxAttributes: 10, MaxSpanEvents: 10}, telemetrySettings(tt), receiverID)
+	require.NoError(t, err)
+
+	td := generateTraces()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.True(t, td == sink.AllTraces()[0])
+
+	tt.assertMetrics(t, []metricdata.Metrics{})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestTracesMutatingNext(t *testing.T) {
+	tt := setupTestTelemetry()
+	var received ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = td
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	tc, err := NewTraces(next, limits, telemetrySettings(tt), receiverID)
+	require.NoError(t, err)
+
+	// The data exclusive to the consumers of a mutating next consumer is truncated in place.
+	td := generateTraces()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.True(t, td == received)
+	assert.Equal(t, 2, td.ResourceSpans().At(0).Resource().Attributes().Len())
+
+	// The read-only data is copied.
+	td = generateTraces()
+	td.MarkReadOnly()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.False(t, td == received)
+	assert.False(t, received.IsReadOnly())
+	assert.Equal(t, 3, td.ResourceSpans().At(0).Resource().Attributes().Len())
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestMetrics(t *testing.T) {
+	tt := setupTestTelemetry()
+	sink := new(consumertest.MetricsSink)
+	mc, err := NewMetrics(sink, Limits{MaxAttributes: 1, MaxAttributeValueLength: 2}, telemetrySettings(tt), receiverID)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	putAttributes(rm.Resource().Attributes())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	putAttributes(sm.Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes())
+	putAttributes(sm.Metrics().AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	// The attributes of the data points identify their time series, they are left as is.
+	rm = sink.AllMetrics()[0].ResourceMetrics().At(0)
+	assert.Equal(t, 1, rm.Resource().Attributes().Len())
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	expected := pcommon.NewMap()
+	putAttributes(expected)
+	assert.Equal(t, expected, metrics.At(0).Gauge().DataPoints().At(0).Attributes())
+	assert.Equal(t, expected, metrics.At(1).Histogram().DataPoints().At(0).Attributes())
+
+	tt.assertMetrics(t, []metricdata.Metrics{
+		counter("receiver_dropped_attributes", 2),
+		counter("receiver_truncated_attribute_values", 1),
+	})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestMetricsDataPointsWithinLimits(t *testing.T) {
+	tt := setupTestTelemetry()
+	sink := new(consumertest.MetricsSink)
+	mc, err := NewMetrics(sink, Limits{MaxAttributes: 1}, telemetrySettings(tt), receiverID)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+	putAttributes(dps.AppendEmpty().Attributes())
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.True(t, md == sink.AllMetrics()[0])
+
+	tt.assertMetrics(t, []metricdata.Metrics{})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestLogs(t *testing.T) {
+	tt := setupTestTelemetry()
+	sink := new(consumertest.LogsSink)
+	lc, err := NewLogs(sink, Limits{MaxAttributeValueLength: 3}, telemetrySettings(tt), receiverID)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutEmptySlice("list").AppendEmpty().SetStr("truncated")
+	lr.Attributes().PutEmptyMap("map").PutStr("key", "truncated")
+	require.NoError(t, lc.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllLogs(), 1)
+
+	attrs := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	list, ok := attrs.Get("list")
+	require.True(t, ok)
+	assert.Equal(t, "tru", list.Slice().At(0).Str())
+	m, ok := attrs.Get("map")
+	require.True(t, ok)
+	v, ok := m.Map().Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "tru", v.Str())
+
+	tt.assertMetrics(t, []metricdata.Metrics{counter("receiver_truncated_attribute_values", 2)})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
@@ -0,0 +1,88 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+// Deprecated: [v0.108.0] use LeveledMeter instead.
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("go.opentelemetry.io/collector/service/internal/limitsconsumer")
+}
+
+func LeveledMeter(settings component.TelemetrySettings, level configtelemetry.Level) metric.Meter {
+	return settings.LeveledMeterProvider(level).Meter("go.opentelemetry.io/collector/service/internal/limitsconsumer")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("go.opentelemetry.io/collector/service/internal/limitsconsumer")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                            metric.Meter
+	ReceiverDroppedAttributes        metric.Int64Counter
+	ReceiverDroppedSpanEvents        metric.Int64Counter
+	ReceiverDroppedSpanLinks         metric.Int64Counter
+	ReceiverTruncatedAttributeValues metric.Int64Counter
+	level                            configtelemetry.Level
+}
+
+// telemetryBuilderOption applies changes to default builder.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// WithLevel sets the current telemetry level for the component.
+func WithLevel(lvl configtelemetry.Level) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.level = lvl
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{level: configtelemetry.LevelBasic}
+	for _, op := range options {
+		op(&builder)
+	}
+	var err, errs error
+	if builder.level >= configtelemetry.LevelBasic {
+		builder.meter = Meter(settings)
+	} else {
+		builder.meter = noop.Meter{}
+	}
+	builder.ReceiverDroppedAttributes, err = builder.meter.Int64Counter(
+		"otelcol_receiver_dropped_attributes",
+		metric.WithDescription("Number of attributes dropped from the received data because of the limit on the number of attributes."),
+		metric.WithUnit("{attributes}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ReceiverDroppedSpanEvents, err = builder.meter.Int64Counter(
+		"otelcol_receiver_dropped_span_events",
+		metric.WithDescription("Number of span events dropped from the received spans because of the limit on the number of events."),
+		metric.WithUnit("{events}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ReceiverDroppedSpanLinks, err = builder.meter.Int64Counter(
+		"otelcol_receiver_dropped_span_links",
+		metric.WithDescription("Number of span links dropped from the received spans because of the limit on the number of links."),
+		metric.WithUnit("{links}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ReceiverTruncatedAttributeValues, err = builder.meter.Int64Counter(
+		"otelcol_receiver_truncated_attribute_values",
+		metric.WithDescription("Number of attribute values of the received data truncated because of the limit on their length."),
+		metric.WithUnit("{values}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package limitsconsumer truncates the data exceeding limits on the number and the length of the attributes,
+// and on the number of span events and links, before passing it to the next consumer. The attributes of the metric
+// data points are left as is.
+package limitsconsumer // import "go.opentelemetry.io/collector/service/internal/limitsconsumer"
+
+import (
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Limits are the limits enforced on the data. Zero disables a limit.
+type Limits struct {
+	// MaxAttributes is the maximum number of attributes of a resource, a scope, a span, a span event, a span link
+	// or a log record. The attributes exceeding the limit are dropped. The attributes of the data points, which
+	// identify their time series, are not limited.
+	MaxAttributes int
+	// MaxAttributeValueLength is the maximum length, in bytes, of the string attribute values, including the
+	// strings nested in arrays and maps. The values exceeding the limit are truncated.
+	MaxAttributeValueLength int
+	// MaxSpanEvents is the maximum number of events of a span. The events exceeding the limit are dropped.
+	MaxSpanEvents int
+	// MaxSpanLinks is the maximum number of links of a span. The links exceeding the limit are dropped.
+	MaxSpanLinks int
+}
+
+// Enabled returns whether at least one limit is set.
+func (l Limits) Enabled() bool {
+	return l.MaxAttributes > 0 || l.MaxAttributeValueLength > 0 || l.MaxSpanEvents > 0 || l.MaxSpanLinks > 0
+}
+
+// Merge returns the limits with the limits set in override replacing the ones of l.
+func (l Limits) Merge(override Limits) Limits {
+	if override.MaxAttributes > 0 {
+		l.MaxAttributes = override.MaxAttributes
+	}
+	if override.MaxAttributeValueLength > 0 {
+		l.MaxAttributeValueLength = override.MaxAttributeValueLength
+	}
+	if override.MaxSpanEvents > 0 {
+		l.MaxSpanEvents = override.MaxSpanEvents
+	}
+	if override.MaxSpanLinks > 0 {
+		l.MaxSpanLinks = override.MaxSpanLinks
+	}
+	return l
+}
+
+// visitor is called for the attributes, the events and the links of the data, either to check the limits
+// or to enforce them. The methods return the number of items dropped.
+type visitor interface {
+	attributes(m pcommon.Map) int
+	events(es ptrace.SpanEventSlice) int
+	links(ls ptrace.SpanLinkSlice) int
+}
+
+// checker is a visitor recording whether the data exceeds the limits, without modifying it.
+type checker struct {
+	Limits
+	exceeded bool
+}
+
+func (c *checker) attributes(m pcommon.Map) int {
+	if c.exceeded {
+		return 0
+	}
+	if c.MaxAttributes > 0 && m.Len() > c.MaxAttributes {
+		c.exceeded = true
+		return 0
+	}
+	if c.MaxAttributeValueLength > 0 {
+		m.Range(func(_ string, v pcommon.Value) bool {
+			c.exceeded = c.valueExceeds(v)
+			return !c.exceeded
+		})
+	}
+	return 0
+}
+
+func (c *checker) valueExceeds(v pcommon.Value) bool {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		return len(v.Str()) > c.MaxAttributeValueLength
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < v.Slice().Len(); i++ {
+			if c.valueExceeds(v.Slice().At(i)) {
+				return true
+			}
+		}
+	case pcommon.ValueTypeMap:
+		exceeds := false
+		v.Map().Range(func(_ string, v pcommon.Value) bool {
+			exceeds = c.valueExceeds(v)
+			return !exceeds
+		})
+		return exceeds
+	}
+	return false
+}
+
+func (c *checker) events(es ptrace.SpanEventSlice) int {
+	c.exceeded = c.exceeded || (c.MaxSpanEvents > 0 && es.Len() > c.MaxSpanEvents)
+	return 0
+}
+
+func (c *checker) links(ls ptrace.SpanLinkSlice) int {
+	c.exceeded = c.exceeded || (c.MaxSpanLinks > 0 && ls.Len() > c.MaxSpanLinks)
+	return 0
+}
+
+// counts are the numbers of items dropped or truncated by an enforcer.
+type counts struct {
+	attributes int64
+	values     int64
+	events     int64
+	links      int64
+}
+
+// enforcer is a visitor truncating the data exceeding the limits.
+type enforcer struct {
+	Limits
+	counts
+}
+
+func (e *enforcer) attributes(m pcommon.Map) int {
+	dropped := 0
+	if e.MaxAttributes > 0 && m.Len() > e.MaxAttributes {
+		dropped = m.Len() - e.MaxAttributes
+		kept := 0
+		m.RemoveIf(func(string, pcommon.Value) bool {
+			kept++
+			return kept > e.MaxAttributes
+		})
+		e.counts.attributes += int64(dropped)
+	}
+	if e.MaxAttributeValueLength > 0 {
+		m.Range(func(_ string, v pcommon.Value) bool {
+			e.truncate(v)
+			return true
+		})
+	}
+	return dropped
+}
+
+func (e *enforcer) truncate(v pcommon.Value) {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		if s := v.Str(); len(s) > e.MaxAttributeValueLength {
+			v.SetStr(truncateString(s, e.MaxAttributeValueLength))
+			e.counts.values++
+		}
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < v.Slice().Len(); i++ {
+			e.truncate(v.Slice().At(i))
+		}
+	case pcommon.ValueTypeMap:
+		v.Map().Range(func(_ string, v pcommon.Value) bool {
+			e.truncate(v)
+			return true
+		})
+	}
+}
+
+// truncateString returns the longest prefix of s of at most n bytes which does not split a UTF-8 character.
+func truncateString(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func (e *enforcer) events(es ptrace.SpanEventSlice) int {
+	if e.MaxSpanEvents <= 0 || es.Len() <= e.MaxSpanEvents {
+		return 0
+	}
+	dropped := es.Len() - e.MaxSpanEvents
+	kept := 0
+	es.RemoveIf(func(ptrace.SpanEvent) bool {
+		kept++
+		return kept > e.MaxSpanEvents
+	})
+	e.counts.events += int64(dropped)
+	return dropped
+}
+
+func (e *enforcer) links(ls ptrace.SpanLinkSlice) int {
+	if e.MaxSpanLinks <= 0 || ls.Len() <= e.MaxSpanLinks {
+		return 0
+	}
+	dropped := ls.Len() - e.MaxSpanLinks
+	kept := 0
+	ls.RemoveIf(func(ptrace.SpanLink) bool {
+		kept++
+		return kept > e.MaxSpanLinks
+	})
+	e.counts.links += int64(dropped)
+	return dropped
+}
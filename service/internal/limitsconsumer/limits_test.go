@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package limitsconsumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsEnabled(t *testing.T) {
+	assert.False(t, Limits{}.Enabled())
+	assert.True(t, Limits{MaxAttributes: 1}.Enabled())
+	assert.True(t, Limits{MaxAttributeValueLength: 1}.Enabled())
+	assert.True(t, Limits{MaxSpanEvents: 1}.Enabled())
+	assert.True(t, Limits{MaxSpanLinks: 1}.Enabled())
+}
+
+func TestLimitsMerge(t *testing.T) {
+	limits := Limits{MaxAttributes: 128, MaxAttributeValueLength: 1024, MaxSpanEvents: 64}
+	assert.Equal(t, limits, limits.Merge(Limits{}))
+	assert.Equal(t,
+		Limits{MaxAttributes: 16, MaxAttributeValueLength: 1024, MaxSpanEvents: 64, MaxSpanLinks: 8},
+		limits.Merge(Limits{MaxAttributes: 16, MaxSpanLinks: 8}))
+}
+
+func TestTruncateString(t *testing.T) {
+	assert.Equal(t, "abc", truncateString("abcdef", 3))
+	// "é" is encoded on 2 bytes and is not split.
+	assert.Equal(t, "ab", truncateString("abéd", 3))
+	assert.Equal(t, "abé", truncateString("abéd", 4))
+	assert.Equal(t, "", truncateString("éa", 1))
+}
@@ -35,8 +35,14 @@ type TelemetryBuilder struct {
 	observeProcessCPUSeconds                 func(context.Context, metric.Observer) error
 	ProcessMemoryRss                         metric.Int64ObservableGauge
 	observeProcessMemoryRss                  func(context.Context, metric.Observer) error
+	ProcessRuntimeGcCycles                   metric.Int64ObservableCounter
+	observeProcessRuntimeGcCycles            func(context.Context, metric.Observer) error
 	ProcessRuntimeHeapAllocBytes             metric.Int64ObservableGauge
 	observeProcessRuntimeHeapAllocBytes      func(context.Context, metric.Observer) error
+	ProcessRuntimeHeapGoalBytes              metric.Int64ObservableGauge
+	observeProcessRuntimeHeapGoalBytes       func(context.Context, metric.Observer) error
+	ProcessRuntimeMemoryLimitBytes           metric.Int64ObservableGauge
+	observeProcessRuntimeMemoryLimitBytes    func(context.Context, metric.Observer) error
 	ProcessRuntimeTotalAllocBytes            metric.Int64ObservableCounter
 	observeProcessRuntimeTotalAllocBytes     func(context.Context, metric.Observer) error
 	ProcessRuntimeTotalSysMemoryBytes        metric.Int64ObservableGauge
@@ -76,6 +82,16 @@ func WithProcessMemoryRssCallback(cb func() int64, opts ...metric.ObserveOption)
 	}
 }
 
+// WithProcessRuntimeGcCyclesCallback sets callback for observable ProcessRuntimeGcCycles metric.
+func WithProcessRuntimeGcCyclesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.observeProcessRuntimeGcCycles = func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(builder.ProcessRuntimeGcCycles, cb(), opts...)
+			return nil
+		}
+	}
+}
+
 // WithProcessRuntimeHeapAllocBytesCallback sets callback for observable ProcessRuntimeHeapAllocBytes metric.
 func WithProcessRuntimeHeapAllocBytesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
 	return func(builder *TelemetryBuilder) {
@@ -86,6 +102,26 @@ func WithProcessRuntimeHeapAllocBytesCallback(cb func() int64, opts ...metric.Ob
 	}
 }
 
+// WithProcessRuntimeHeapGoalBytesCallback sets callback for observable ProcessRuntimeHeapGoalBytes metric.
+func WithProcessRuntimeHeapGoalBytesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.observeProcessRuntimeHeapGoalBytes = func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(builder.ProcessRuntimeHeapGoalBytes, cb(), opts...)
+			return nil
+		}
+	}
+}
+
+// WithProcessRuntimeMemoryLimitBytesCallback sets callback for observable ProcessRuntimeMemoryLimitBytes metric.
+func WithProcessRuntimeMemoryLimitBytesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.observeProcessRuntimeMemoryLimitBytes = func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(builder.ProcessRuntimeMemoryLimitBytes, cb(), opts...)
+			return nil
+		}
+	}
+}
+
 // WithProcessRuntimeTotalAllocBytesCallback sets callback for observable ProcessRuntimeTotalAllocBytes metric.
 func WithProcessRuntimeTotalAllocBytesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
 	return func(builder *TelemetryBuilder) {
@@ -145,6 +181,14 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 	errs = errors.Join(errs, err)
 	_, err = builder.meter.RegisterCallback(builder.observeProcessMemoryRss, builder.ProcessMemoryRss)
 	errs = errors.Join(errs, err)
+	builder.ProcessRuntimeGcCycles, err = builder.meter.Int64ObservableCounter(
+		"otelcol_process_runtime_gc_cycles",
+		metric.WithDescription("Cumulative number of completed garbage collection cycles"),
+		metric.WithUnit("{cycles}"),
+	)
+	errs = errors.Join(errs, err)
+	_, err = builder.meter.RegisterCallback(builder.observeProcessRuntimeGcCycles, builder.ProcessRuntimeGcCycles)
+	errs = errors.Join(errs, err)
 	builder.ProcessRuntimeHeapAllocBytes, err = builder.meter.Int64ObservableGauge(
 		"otelcol_process_runtime_heap_alloc_bytes",
 		metric.WithDescription("Bytes of allocated heap objects (see 'go doc runtime.MemStats.HeapAlloc')"),
@@ -153,6 +197,22 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 	errs = errors.Join(errs, err)
 	_, err = builder.meter.RegisterCallback(builder.observeProcessRuntimeHeapAllocBytes, builder.ProcessRuntimeHeapAllocBytes)
 	errs = errors.Join(errs, err)
+	builder.ProcessRuntimeHeapGoalBytes, err = builder.meter.Int64ObservableGauge(
+		"otelcol_process_runtime_heap_goal_bytes",
+		metric.WithDescription("Heap size target of the garbage collector for the end of the current cycle"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	_, err = builder.meter.RegisterCallback(builder.observeProcessRuntimeHeapGoalBytes, builder.ProcessRuntimeHeapGoalBytes)
+	errs = errors.Join(errs, err)
+	builder.ProcessRuntimeMemoryLimitBytes, err = builder.meter.Int64ObservableGauge(
+		"otelcol_process_runtime_memory_limit_bytes",
+		metric.WithDescription("Soft memory limit of the Go runtime (see 'go doc runtime/debug.SetMemoryLimit')"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	_, err = builder.meter.RegisterCallback(builder.observeProcessRuntimeMemoryLimitBytes, builder.ProcessRuntimeMemoryLimitBytes)
+	errs = errors.Join(errs, err)
 	builder.ProcessRuntimeTotalAllocBytes, err = builder.meter.Int64ObservableCounter(
 		"otelcol_process_runtime_total_alloc_bytes",
 		metric.WithDescription("Cumulative bytes allocated for heap objects (see 'go doc runtime.MemStats.TotalAlloc')"),
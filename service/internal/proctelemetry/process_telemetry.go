@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"runtime"
+	"runtime/metrics"
 	"sync"
 	"time"
 
@@ -80,6 +81,9 @@ func RegisterProcessMetrics(cfg component.TelemetrySettings, opts ...RegisterOpt
 		metadata.WithProcessRuntimeTotalSysMemoryBytesCallback(pm.updateSysMem),
 		metadata.WithProcessCPUSecondsCallback(pm.updateCPUSeconds),
 		metadata.WithProcessMemoryRssCallback(pm.updateRSSMemory),
+		metadata.WithProcessRuntimeMemoryLimitBytesCallback(readRuntimeMetric("/gc/gomemlimit:bytes")),
+		metadata.WithProcessRuntimeHeapGoalBytesCallback(readRuntimeMetric("/gc/heap/goal:bytes")),
+		metadata.WithProcessRuntimeGcCyclesCallback(readRuntimeMetric("/gc/cycles/total:gc-cycles")),
 	)
 	return err
 }
@@ -128,6 +132,18 @@ func (pm *processMetrics) updateRSSMemory() int64 {
 	return int64(mem.RSS)
 }
 
+// readRuntimeMetric returns a callback reading the given uint64 metric of the Go runtime, see 'go doc runtime/metrics'.
+func readRuntimeMetric(name string) func() int64 {
+	return func() int64 {
+		sample := []metrics.Sample{{Name: name}}
+		metrics.Read(sample)
+		if sample[0].Value.Kind() != metrics.KindUint64 {
+			return 0
+		}
+		return int64(sample[0].Value.Uint64())
+	}
+}
+
 func (pm *processMetrics) readMemStatsIfNeeded() {
 	now := time.Now()
 	// If last time we read was less than one second ago just reuse the values
@@ -39,6 +39,9 @@ var expectedMetrics = []string{
 	"otelcol_process_runtime_total_sys_memory_bytes",
 	"otelcol_process_cpu_seconds",
 	"otelcol_process_memory_rss",
+	"otelcol_process_runtime_memory_limit_bytes",
+	"otelcol_process_runtime_heap_goal_bytes",
+	"otelcol_process_runtime_gc_cycles",
 }
 
 func setupTelemetry(t *testing.T) testTelemetry {
@@ -99,7 +102,8 @@ func TestProcessTelemetry(t *testing.T) {
 		} else {
 			metricValue = metric.Metric[0].GetGauge().GetValue()
 		}
-		if strings.HasPrefix(metricName, "otelcol_process_uptime") || strings.HasPrefix(metricName, "otelcol_process_cpu_seconds") {
+		if strings.HasPrefix(metricName, "otelcol_process_uptime") || strings.HasPrefix(metricName, "otelcol_process_cpu_seconds") ||
+			strings.HasPrefix(metricName, "otelcol_process_runtime_gc_cycles") {
 			// This likely will still be zero when running the test.
 			assert.GreaterOrEqual(t, metricValue, float64(0), metricName)
 			continue
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resourceconsumer sets attributes on the resources of the data before passing it to the next consumer.
+package resourceconsumer // import "go.opentelemetry.io/collector/service/internal/resourceconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// mutatesData is the capabilities of the consumers, which modify the resources.
+var mutatesData = consumer.Capabilities{MutatesData: true}
+
+// stamp sets the attributes on the resources. Unless override is set, the attributes already set are kept.
+type stamp struct {
+	attrs    map[string]string
+	override bool
+}
+
+func (s stamp) apply(res pcommon.Resource) {
+	for k, v := range s.attrs {
+		if !s.override {
+			if _, ok := res.Attributes().Get(k); ok {
+				continue
+			}
+		}
+		res.Attributes().PutStr(k, v)
+	}
+}
+
+func NewTraces(traces consumer.Traces, attrs map[string]string, override bool) consumer.Traces {
+	return resTraces{Traces: traces, stamp: stamp{attrs: attrs, override: override}}
+}
+
+type resTraces struct {
+	consumer.Traces
+	stamp
+}
+
+func (rt resTraces) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (rt resTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rt.apply(td.ResourceSpans().At(i).Resource())
+	}
+	return rt.Traces.ConsumeTraces(ctx, td)
+}
+
+func NewMetrics(metrics consumer.Metrics, attrs map[string]string, override bool) consumer.Metrics {
+	return resMetrics{Metrics: metrics, stamp: stamp{attrs: attrs, override: override}}
+}
+
+type resMetrics struct {
+	consumer.Metrics
+	stamp
+}
+
+func (rm resMetrics) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (rm resMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm.apply(md.ResourceMetrics().At(i).Resource())
+	}
+	return rm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func NewLogs(logs consumer.Logs, attrs map[string]string, override bool) consumer.Logs {
+	return resLogs{Logs: logs, stamp: stamp{attrs: attrs, override: override}}
+}
+
+type resLogs struct {
+	consumer.Logs
+	stamp
+}
+
+func (rl resLogs) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (rl resLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl.apply(ld.ResourceLogs().At(i).Resource())
+	}
+	return rl.Logs.ConsumeLogs(ctx, ld)
+}
+
+func NewEntities(entities consumerentities.Entities, attrs map[string]string, override bool) consumerentities.Entities {
+	return resEntities{Entities: entities, stamp: stamp{attrs: attrs, override: override}}
+}
+
+type resEntities struct {
+	consumerentities.Entities
+	stamp
+}
+
+func (re resEntities) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (re resEntities) ConsumeEntities(ctx context.Context, ed pentity.Entities) error {
+	for i := 0; i < ed.ResourceEntities().Len(); i++ {
+		re.apply(ed.ResourceEntities().At(i).Resource())
+	}
+	return re.Entities.ConsumeEntities(ctx, ed)
+}
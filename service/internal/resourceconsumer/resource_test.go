@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourceconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pentity"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+var attrs = map[string]string{"host.name": "collector-host", "resource-attr": "collector-value"}
+
+func assertStamped(t *testing.T, res pcommon.Resource, override bool) {
+	hostName, ok := res.Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, "collector-host", hostName.Str())
+	// The attribute set by testdata is only replaced with override.
+	expected := "resource-attr-val-1"
+	if override {
+		expected = "collector-value"
+	}
+	attr, ok := res.Attributes().Get("resource-attr")
+	require.True(t, ok)
+	assert.Equal(t, expected, attr.Str())
+}
+
+func TestTraces(t *testing.T) {
+	for _, override := range []bool{false, true} {
+		sink := &consumertest.TracesSink{}
+		c := NewTraces(sink, attrs, override)
+		assert.Equal(t, consumer.Capabilities{MutatesData: true}, c.Capabilities())
+		require.NoError(t, c.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		require.Len(t, sink.AllTraces(), 1)
+		assertStamped(t, sink.AllTraces()[0].ResourceSpans().At(0).Resource(), override)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	for _, override := range []bool{false, true} {
+		sink := &consumertest.MetricsSink{}
+		c := NewMetrics(sink, attrs, override)
+		assert.Equal(t, consumer.Capabilities{MutatesData: true}, c.Capabilities())
+		require.NoError(t, c.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+		require.Len(t, sink.AllMetrics(), 1)
+		assertStamped(t, sink.AllMetrics()[0].ResourceMetrics().At(0).Resource(), override)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	for _, override := range []bool{false, true} {
+		sink := &consumertest.LogsSink{}
+		c := NewLogs(sink, attrs, override)
+		assert.Equal(t, consumer.Capabilities{MutatesData: true}, c.Capabilities())
+		require.NoError(t, c.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+		require.Len(t, sink.AllLogs(), 1)
+		assertStamped(t, sink.AllLogs()[0].ResourceLogs().At(0).Resource(), override)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	for _, override := range []bool{false, true} {
+		sink := &consumertest.EntitiesSink{}
+		c := NewEntities(sink, attrs, override)
+		assert.Equal(t, consumer.Capabilities{MutatesData: true}, c.Capabilities())
+		ed := pentity.NewEntities()
+		ed.ResourceEntities().AppendEmpty().Resource().Attributes().PutStr("resource-attr", "resource-attr-val-1")
+		require.NoError(t, c.ConsumeEntities(context.Background(), ed))
+		require.Len(t, sink.AllEntities(), 1)
+		assertStamped(t, sink.AllEntities()[0].ResourceEntities().At(0).Resource(), override)
+	}
+}
@@ -100,6 +100,7 @@ type Reporter interface {
 	Ready()
 	ReportStatus(id *componentstatus.InstanceID, ev *componentstatus.Event)
 	ReportOKIfStarting(id *componentstatus.InstanceID)
+	CurrentStatus(id *componentstatus.InstanceID) *componentstatus.Event
 }
 
 type reporter struct {
@@ -157,6 +158,13 @@ func (r *reporter) ReportOKIfStarting(id *componentstatus.InstanceID) {
 	}
 }
 
+// CurrentStatus returns the last status reported for the given InstanceID, StatusNone if none was.
+func (r *reporter) CurrentStatus(id *componentstatus.InstanceID) *componentstatus.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.componentFSM(id).current
+}
+
 // Note: a lock must be acquired before calling this method.
 func (r *reporter) componentFSM(id *componentstatus.InstanceID) *fsm {
 	fsm, ok := r.fsmMap[id]
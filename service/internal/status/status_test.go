@@ -277,6 +277,17 @@ func TestReporterReady(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReporterCurrentStatus(t *testing.T) {
+	rep := NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})
+	rep.Ready()
+	id := &componentstatus.InstanceID{}
+	require.Equal(t, componentstatus.StatusNone, rep.CurrentStatus(id).Status())
+
+	ev := componentstatus.NewEvent(componentstatus.StatusStarting)
+	rep.ReportStatus(id, ev)
+	require.Same(t, ev, rep.CurrentStatus(id))
+}
+
 func TestReportComponentOKIfStarting(t *testing.T) {
 	for _, tc := range []struct {
 		name             string
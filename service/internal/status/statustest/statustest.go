@@ -19,3 +19,7 @@ func (r *nopStatusReporter) Ready() {}
 func (r *nopStatusReporter) ReportStatus(*componentstatus.InstanceID, *componentstatus.Event) {}
 
 func (r *nopStatusReporter) ReportOKIfStarting(*componentstatus.InstanceID) {}
+
+func (r *nopStatusReporter) CurrentStatus(*componentstatus.InstanceID) *componentstatus.Event {
+	return componentstatus.NewEvent(componentstatus.StatusNone)
+}
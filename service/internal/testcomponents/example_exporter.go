@@ -9,6 +9,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterentities"
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -25,6 +27,7 @@ var ExampleExporterFactory = exporter.NewFactory(
 	exporter.WithTraces(createTracesExporter, stability),
 	exporter.WithMetrics(createMetricsExporter, stability),
 	exporter.WithLogs(createLogsExporter, stability),
+	exporterentities.WithEntities(createEntitiesExporter, stability),
 )
 
 func createExporterDefaultConfig() component.Config {
@@ -43,12 +46,17 @@ func createLogsExporter(context.Context, exporter.Settings, component.Config) (e
 	return &ExampleExporter{}, nil
 }
 
+func createEntitiesExporter(context.Context, exporter.Settings, component.Config) (exporterentities.Entities, error) {
+	return &ExampleExporter{}, nil
+}
+
 // ExampleExporter stores consumed traces and metrics for testing purposes.
 type ExampleExporter struct {
 	componentState
-	Traces  []ptrace.Traces
-	Metrics []pmetric.Metrics
-	Logs    []plog.Logs
+	Traces   []ptrace.Traces
+	Metrics  []pmetric.Metrics
+	Logs     []plog.Logs
+	Entities []pentity.Entities
 }
 
 // ConsumeTraces receives ptrace.Traces for processing by the consumer.Traces.
@@ -69,6 +77,12 @@ func (exp *ExampleExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
 	return nil
 }
 
+// ConsumeEntities receives pentity.Entities for processing by the Entities.
+func (exp *ExampleExporter) ConsumeEntities(_ context.Context, ed pentity.Entities) error {
+	exp.Entities = append(exp.Entities, ed)
+	return nil
+}
+
 func (exp *ExampleExporter) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
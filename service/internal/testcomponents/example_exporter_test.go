@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pentity"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -34,6 +35,10 @@ func TestExampleExporter(t *testing.T) {
 	assert.NoError(t, exp.ConsumeLogs(context.Background(), plog.Logs{}))
 	assert.Equal(t, 1, len(exp.Logs))
 
+	assert.Equal(t, 0, len(exp.Entities))
+	assert.NoError(t, exp.ConsumeEntities(context.Background(), pentity.Entities{}))
+	assert.Equal(t, 1, len(exp.Entities))
+
 	assert.False(t, exp.Stopped())
 	assert.NoError(t, exp.Shutdown(context.Background()))
 	assert.True(t, exp.Stopped())
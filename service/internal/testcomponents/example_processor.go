@@ -8,7 +8,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorentities"
 )
 
 var procType = component.MustNewType("exampleprocessor")
@@ -19,7 +21,8 @@ var ExampleProcessorFactory = processor.NewFactory(
 	createDefaultConfig,
 	processor.WithTraces(createTracesProcessor, component.StabilityLevelDevelopment),
 	processor.WithMetrics(createMetricsProcessor, component.StabilityLevelDevelopment),
-	processor.WithLogs(createLogsProcessor, component.StabilityLevelDevelopment))
+	processor.WithLogs(createLogsProcessor, component.StabilityLevelDevelopment),
+	processorentities.WithEntities(createEntitiesProcessor, component.StabilityLevelDevelopment))
 
 // CreateDefaultConfig creates the default configuration for the Processor.
 func createDefaultConfig() component.Config {
@@ -47,11 +50,19 @@ func createLogsProcessor(_ context.Context, set processor.Settings, _ component.
 	}, nil
 }
 
+func createEntitiesProcessor(_ context.Context, set processor.Settings, _ component.Config, nextConsumer consumerentities.Entities) (processorentities.Entities, error) {
+	return &ExampleProcessor{
+		ConsumeEntitiesFunc: nextConsumer.ConsumeEntities,
+		mutatesData:         set.ID.Name() == "mutate",
+	}, nil
+}
+
 type ExampleProcessor struct {
 	componentState
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
+	consumerentities.ConsumeEntitiesFunc
 	mutatesData bool
 }
 
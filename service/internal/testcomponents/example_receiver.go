@@ -8,7 +8,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerentities"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverentities"
 )
 
 var receiverType = component.MustNewType("examplereceiver")
@@ -19,7 +21,8 @@ var ExampleReceiverFactory = receiver.NewFactory(
 	createReceiverDefaultConfig,
 	receiver.WithTraces(createTracesReceiver, component.StabilityLevelDevelopment),
 	receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelDevelopment),
-	receiver.WithLogs(createLogsReceiver, component.StabilityLevelDevelopment))
+	receiver.WithLogs(createLogsReceiver, component.StabilityLevelDevelopment),
+	receiverentities.WithEntities(createEntitiesReceiver, component.StabilityLevelDevelopment))
 
 func createReceiverDefaultConfig() component.Config {
 	return &struct{}{}
@@ -60,6 +63,17 @@ func createLogsReceiver(
 	return lr, nil
 }
 
+func createEntitiesReceiver(
+	_ context.Context,
+	_ receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumerentities.Entities,
+) (receiverentities.Entities, error) {
+	er := createReceiver(cfg)
+	er.ConsumeEntitiesFunc = nextConsumer.ConsumeEntities
+	return er, nil
+}
+
 func createReceiver(cfg component.Config) *ExampleReceiver {
 	// There must be one receiver for all data types. We maintain a map of
 	// receivers per config.
@@ -81,6 +95,7 @@ type ExampleReceiver struct {
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
+	consumerentities.ConsumeEntitiesFunc
 }
 
 // This is the map of already created example receivers for particular configurations.
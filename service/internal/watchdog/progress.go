@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package watchdog // import "go.opentelemetry.io/collector/service/internal/watchdog"
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress tracks the calls made to a component to consume data. The component makes progress when a call
+// succeeds, has pending work since the first call made after its last success as long as a call does not
+// return, and is failing since the first failure after its last success.
+//
+// It is safe to call the methods of a Progress concurrently.
+type Progress struct {
+	mu           sync.Mutex
+	inFlight     int
+	pendingSince time.Time
+	failingSince time.Time
+	lastFailure  time.Time
+	lastSuccess  time.Time
+	now          func() time.Time
+}
+
+// NewProgress returns a new Progress of a component without pending work.
+func NewProgress() *Progress {
+	return &Progress{now: time.Now}
+}
+
+// Begin records the start of a call to the component.
+func (p *Progress) Begin() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pendingSince.IsZero() {
+		p.pendingSince = p.now()
+	}
+	p.inFlight++
+}
+
+// End records the end of a call to the component, which made progress if err is nil.
+func (p *Progress) End(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	now := p.now()
+	if err != nil {
+		p.lastFailure = now
+		if p.failingSince.IsZero() {
+			p.failingSince = now
+		}
+		if p.inFlight == 0 {
+			// The failures are tracked separately, the component is idle until it is called again.
+			p.pendingSince = time.Time{}
+		}
+		return
+	}
+	p.lastSuccess = now
+	p.failingSince = time.Time{}
+	p.pendingSince = time.Time{}
+	if p.inFlight > 0 {
+		// The other calls still have to complete.
+		p.pendingSince = p.lastSuccess
+	}
+}
+
+// stalledFor returns for how long the component has not made progress at the given time, if it is stalled
+// for the given threshold: if it has pending work since then, or keeps failing since then and failed within
+// the threshold. A component which failed and was not called since is not stalled.
+func (p *Progress) stalledFor(now time.Time, threshold time.Duration) (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.pendingSince.IsZero() && now.Sub(p.pendingSince) >= threshold {
+		return now.Sub(p.pendingSince), true
+	}
+	if !p.failingSince.IsZero() && now.Sub(p.failingSince) >= threshold && now.Sub(p.lastFailure) < threshold {
+		return now.Sub(p.failingSince), true
+	}
+	return 0, false
+}
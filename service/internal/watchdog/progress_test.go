@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package watchdog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is the time of the progress of the tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) time.Time {
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+func newTestProgress(clock *fakeClock) *Progress {
+	p := NewProgress()
+	p.now = func() time.Time { return clock.now }
+	return p
+}
+
+func TestProgress(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	p := newTestProgress(clock)
+	_, stalled := p.stalledFor(clock.advance(time.Hour), threshold)
+	assert.False(t, stalled)
+
+	// A call not returning is pending work.
+	p.Begin()
+	_, stalled = p.stalledFor(clock.advance(threshold-time.Second), threshold)
+	assert.False(t, stalled)
+	stalledFor, stalled := p.stalledFor(clock.advance(time.Second), threshold)
+	assert.True(t, stalled)
+	assert.Equal(t, threshold, stalledFor)
+
+	clock.advance(time.Second)
+	p.End(nil)
+	_, stalled = p.stalledFor(clock.now, threshold)
+	assert.False(t, stalled)
+}
+
+func TestProgressFailures(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	p := newTestProgress(clock)
+
+	// The failures are not progress, the component keeps failing.
+	start := clock.now
+	for clock.now.Sub(start) < threshold {
+		p.Begin()
+		clock.advance(time.Second)
+		p.End(errors.New("failed"))
+		clock.advance(10 * time.Second)
+	}
+	stalledFor, stalled := p.stalledFor(clock.now, threshold)
+	assert.True(t, stalled)
+	assert.Equal(t, clock.now.Sub(start.Add(time.Second)), stalledFor)
+
+	// A component idle since its last failure is not stalled.
+	_, stalled = p.stalledFor(clock.advance(threshold), threshold)
+	assert.False(t, stalled)
+
+	p.Begin()
+	clock.advance(time.Second)
+	p.End(nil)
+	_, stalled = p.stalledFor(clock.advance(threshold), threshold)
+	assert.False(t, stalled)
+}
+
+func TestProgressConcurrentCalls(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	p := newTestProgress(clock)
+	p.Begin()
+	clock.advance(time.Second)
+	p.Begin()
+	clock.advance(time.Second)
+
+	// The call still in flight has to complete from the last success.
+	p.End(nil)
+	_, stalled := p.stalledFor(clock.advance(threshold-time.Second), threshold)
+	assert.False(t, stalled)
+	stalledFor, stalled := p.stalledFor(clock.advance(time.Second), threshold)
+	assert.True(t, stalled)
+	assert.Equal(t, threshold, stalledFor)
+
+	p.End(nil)
+	_, stalled = p.stalledFor(clock.advance(threshold), threshold)
+	assert.False(t, stalled)
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package watchdog detects the components of the pipelines which stopped making progress, because they are
+// wedged or keep failing, and reports them unhealthy until they make progress again.
+package watchdog // import "go.opentelemetry.io/collector/service/internal/watchdog"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/service/eventlog"
+	"go.opentelemetry.io/collector/service/internal/watchdog/internal/metadata"
+)
+
+// componentKey is the attribute of the internal metrics identifying the stalled component.
+const componentKey = "component"
+
+// Settings configures a Watchdog.
+type Settings struct {
+	// StallThreshold is the duration after which a component with pending work and no progress is stalled.
+	// Zero disables the watchdog.
+	StallThreshold time.Duration
+	// CheckInterval is the interval between two checks of the components, a quarter of StallThreshold if zero.
+	CheckInterval time.Duration
+}
+
+// Enabled returns whether the components are watched.
+func (s Settings) Enabled() bool {
+	return s.StallThreshold > 0
+}
+
+// Target is a component watched by a Watchdog.
+type Target struct {
+	// ID identifies the component in the status reports.
+	ID *componentstatus.InstanceID
+	// Progress tracks the calls made to the component.
+	Progress *Progress
+	// Downstream tracks the calls made to the components the target sends data to, directly or not. The target
+	// is not stalled while it waits on a stalled downstream component.
+	Downstream []*Progress
+}
+
+// target is the state of a watched component.
+type target struct {
+	Target
+	attrs metric.MeasurementOption
+	// unhealthy is set when the component is stalled.
+	unhealthy bool
+	// reported is the status reported when the component stalled, nil if it was not reported because the
+	// component was not healthy then.
+	reported *componentstatus.Event
+}
+
+func (t *target) name() string {
+	return t.ID.Kind().String() + " " + t.ID.ComponentID().String()
+}
+
+// StatusReporter reports the status of the components.
+type StatusReporter interface {
+	// ReportStatus reports the status of a component.
+	ReportStatus(*componentstatus.InstanceID, *componentstatus.Event)
+	// CurrentStatus returns the last status reported for a component.
+	CurrentStatus(*componentstatus.InstanceID) *componentstatus.Event
+}
+
+// Watchdog periodically checks the progress of the components and handles the stalled ones.
+type Watchdog struct {
+	set     Settings
+	logger  *zap.Logger
+	builder *metadata.TelemetryBuilder
+	targets []*target
+
+	reporter StatusReporter
+	events   *eventlog.Log
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New returns a new Watchdog without targets.
+func New(set Settings, tel component.TelemetrySettings) (*Watchdog, error) {
+	builder, err := metadata.NewTelemetryBuilder(tel)
+	if err != nil {
+		return nil, err
+	}
+	if set.CheckInterval <= 0 {
+		set.CheckInterval = set.StallThreshold / 4
+	}
+	return &Watchdog{
+		set:     set,
+		logger:  tel.Logger,
+		builder: builder,
+	}, nil
+}
+
+// Add watches a component. It must be called before Start.
+func (w *Watchdog) Add(t Target) {
+	tgt := &target{Target: t}
+	tgt.attrs = metric.WithAttributes(attribute.String(componentKey, tgt.name()))
+	w.targets = append(w.targets, tgt)
+}
+
+// Start starts checking the components, which are reported with reporter and recorded in events, if not nil.
+func (w *Watchdog) Start(reporter StatusReporter, events *eventlog.Log) {
+	w.reporter = reporter
+	w.events = events
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(w.set.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.ctx.Done():
+				return
+			case now := <-ticker.C:
+				w.check(w.ctx, now)
+			}
+		}
+	}()
+}
+
+// Shutdown stops checking the components.
+func (w *Watchdog) Shutdown() {
+	if w.done == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+}
+
+// check handles the components stalled at the given time. The components waiting on a stalled downstream
+// component are not stalled themselves, only the most downstream stalled components are.
+func (w *Watchdog) check(ctx context.Context, now time.Time) {
+	stalled := make(map[*Progress]time.Duration, len(w.targets))
+	for _, t := range w.targets {
+		if stalledFor, ok := t.Progress.stalledFor(now, w.set.StallThreshold); ok {
+			stalled[t.Progress] = stalledFor
+		}
+	}
+	for _, t := range w.targets {
+		stalledFor, ok := stalled[t.Progress]
+		if !ok || waitsOn(t, stalled) {
+			w.resume(t)
+			continue
+		}
+		w.stall(ctx, t, stalledFor)
+	}
+}
+
+func waitsOn(t *target, stalled map[*Progress]time.Duration) bool {
+	for _, p := range t.Downstream {
+		if _, ok := stalled[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resume restores the healthy status of a component which made progress after being stalled, unless the
+// component reported another status since.
+func (w *Watchdog) resume(t *target) {
+	if !t.unhealthy {
+		return
+	}
+	t.unhealthy = false
+	w.logger.Info("Component making progress again", zap.String("component", t.name()))
+	w.record(t, "Making progress again")
+	if t.reported != nil && w.reporter.CurrentStatus(t.ID) == t.reported {
+		w.reporter.ReportStatus(t.ID, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	t.reported = nil
+}
+
+// stall reports a stalled component unhealthy, once until it makes progress again. The status of the component
+// is left as is if it is not healthy, it already reported why.
+func (w *Watchdog) stall(ctx context.Context, t *target, stalledFor time.Duration) {
+	if t.unhealthy {
+		return
+	}
+	w.builder.WatchdogStalls.Add(ctx, 1, t.attrs)
+	t.unhealthy = true
+	w.logger.Warn("Component stalled", zap.String("component", t.name()), zap.Duration("stalled_for", stalledFor))
+	w.record(t, fmt.Sprintf("Stalled, no progress for %v", stalledFor))
+	if w.reporter.CurrentStatus(t.ID).Status() == componentstatus.StatusOK {
+		t.reported = componentstatus.NewRecoverableErrorEvent(fmt.Errorf("stalled: no progress for %v", stalledFor))
+		w.reporter.ReportStatus(t.ID, t.reported)
+	}
+}
+
+func (w *Watchdog) record(t *target, msg string) {
+	if w.events != nil {
+		w.events.Record(eventlog.KindWatchdog, t.name(), msg)
+	}
+}
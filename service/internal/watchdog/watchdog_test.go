@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/service/eventlog"
+)
+
+const threshold = time.Minute
+
+var (
+	exporterID  = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter)
+	processorID = componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor,
+		component.MustNewID("traces"))
+)
+
+type statusReport struct {
+	id     *componentstatus.InstanceID
+	status componentstatus.Status
+}
+
+// reporter records the status reported by a watchdog, the components are initially healthy.
+type reporter struct {
+	mu      sync.Mutex
+	reports []statusReport
+	current map[*componentstatus.InstanceID]*componentstatus.Event
+}
+
+func (r *reporter) ReportStatus(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, statusReport{id: id, status: ev.Status()})
+	if r.current == nil {
+		r.current = map[*componentstatus.InstanceID]*componentstatus.Event{}
+	}
+	r.current[id] = ev
+}
+
+func (r *reporter) CurrentStatus(id *componentstatus.InstanceID) *componentstatus.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ev, ok := r.current[id]; ok {
+		return ev
+	}
+	return componentstatus.NewEvent(componentstatus.StatusOK)
+}
+
+func (r *reporter) get() []statusReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]statusReport(nil), r.reports...)
+}
+
+func newTestWatchdog(t *testing.T, set Settings, tt componentTestTelemetry) (*Watchdog, *reporter, *eventlog.Log) {
+	tel := componenttest.NewNopTelemetrySettings()
+	tel.MeterProvider = tt.meterProvider
+	w, err := New(set, tel)
+	require.NoError(t, err)
+	rep := &reporter{}
+	w.reporter = rep
+	w.events = eventlog.NewLog(eventlog.Settings{})
+	return w, rep, w.events
+}
+
+func counter(name, comp string, value int64) metricdata.Metrics {
+	return metricdata.Metrics{
+		Name: "otelcol_" + name,
+		Unit: map[string]string{
+			"watchdog_stalls": "{stalls}",
+		}[name],
+		Description: map[string]string{
+			"watchdog_stalls": "Number of times the components of the pipelines were detected stalled by the watchdog.",
+		}[name],
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(attribute.String(componentKey, comp)),
+					Value:      value,
+				},
+			},
+		},
+	}
+}
+
+func eventMessages(log *eventlog.Log) []string {
+	var msgs []string
+	for _, ev := range log.Events() {
+		msgs = append(msgs, ev.Component+": "+ev.Message)
+	}
+	return msgs
+}
+
+func TestWatchdogUnhealthy(t *testing.T) {
+	tt := setupTestTelemetry()
+	w, rep, events := newTestWatchdog(t, Settings{StallThreshold: threshold}, tt)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	p := newTestProgress(clock)
+	w.Add(Target{ID: exporterID, Progress: p})
+
+	p.Begin()
+	w.check(context.Background(), clock.advance(threshold-time.Second))
+	assert.Empty(t, rep.get())
+
+	// The stalled component is reported once.
+	w.check(context.Background(), clock.advance(time.Second))
+	w.check(context.Background(), clock.advance(time.Second))
+	assert.Equal(t, []statusReport{{id: exporterID, status: componentstatus.StatusRecoverableError}}, rep.get())
+
+	p.End(nil)
+	w.check(context.Background(), clock.advance(time.Second))
+	assert.Equal(t, []statusReport{
+		{id: exporterID, status: componentstatus.StatusRecoverableError},
+		{id: exporterID, status: componentstatus.StatusOK},
+	}, rep.get())
+	assert.Equal(t, []string{"Exporter otlp: Stalled, no progress for 1m0s", "Exporter otlp: Making progress again"}, eventMessages(events))
+
+	tt.assertMetrics(t, []metricdata.Metrics{counter("watchdog_stalls", "Exporter otlp", 1)})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestWatchdogRestoresStatus(t *testing.T) {
+	tt := setupTestTelemetry()
+	w, rep, _ := newTestWatchdog(t, Settings{StallThreshold: threshold}, tt)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	p := newTestProgress(clock)
+	w.Add(Target{ID: exporterID, Progress: p})
+
+	// The component reported its own status after it was stalled, it is left as is when it resumes.
+	p.Begin()
+	w.check(context.Background(), clock.advance(threshold))
+	rep.ReportStatus(exporterID, componentstatus.NewPermanentErrorEvent(errors.New("invalid credentials")))
+	p.End(nil)
+	w.check(context.Background(), clock.advance(time.Second))
+	assert.Equal(t, []statusReport{
+		{id: exporterID, status: componentstatus.StatusRecoverableError},
+		{id: exporterID, status: componentstatus.StatusPermanentError},
+	}, rep.get())
+
+	// The status of an unhealthy component is not overridden.
+	p.Begin()
+	w.check(context.Background(), clock.advance(threshold))
+	p.End(nil)
+	w.check(context.Background(), clock.advance(time.Second))
+	assert.Len(t, rep.get(), 2)
+	tt.assertMetrics(t, []metricdata.Metrics{counter("watchdog_stalls", "Exporter otlp", 2)})
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestWatchdogDownstream(t *testing.T) {
+	tt := setupTestTelemetry()
+	w, rep, _ := newTestWatchdog(t, Settings{StallThreshold: threshold}, tt)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	proc, exp := newTestProgress(clock), newTestProgress(clock)
+	w.Add(Target{ID: processorID, Progress: proc, Downstream: []*Progress{exp}})
+	w.Add(Target{ID: exporterID, Progress: exp})
+
+	// The processor waits on the stalled exporter.
+	proc.Begin()
+	exp.Begin()
+	w.check(context.Background(), clock.advance(threshold))
+	assert.Equal(t, []statusReport{{id: exporterID, status: componentstatus.StatusRecoverableError}}, rep.get())
+	require.NoError(t, tt.Shutdown(context.Background()))
+}
+
+func TestWatchdogStartShutdown(t *testing.T) {
+	w, err := New(Settings{StallThreshold: 10 * time.Millisecond}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	p := NewProgress()
+	w.Add(Target{ID: exporterID, Progress: p})
+	p.Begin()
+
+	rep := &reporter{}
+	w.Start(rep, nil)
+	assert.Eventually(t, func() bool {
+		return len(rep.get()) == 1
+	}, time.Second, time.Millisecond)
+	w.Shutdown()
+}
+
+func TestWatchdogShutdownNotStarted(t *testing.T) {
+	w, err := New(Settings{StallThreshold: threshold}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	w.Shutdown()
+}
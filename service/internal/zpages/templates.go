@@ -41,6 +41,10 @@ var (
 	propertiesTableBytes    []byte
 	propertiesTableTemplate = parseTemplate("properties_table", propertiesTableBytes)
 
+	//go:embed templates/graph_table.html
+	graphTableBytes    []byte
+	graphTableTemplate = parseTemplate("graph_table", graphTableBytes)
+
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
@@ -153,6 +157,8 @@ func getValue(row [2]string) string {
 // FeatureGateTableData contains data for feature gate table template.
 type FeatureGateTableData struct {
 	Rows []FeatureGateTableRowData
+	// Token is sent with the requests enabling or disabling the dynamic gates.
+	Token string
 }
 
 // FeatureGateTableRowData contains data for one row in feature gate table template.
@@ -164,6 +170,7 @@ type FeatureGateTableRowData struct {
 	FromVersion  string
 	ToVersion    string
 	ReferenceURL string
+	Dynamic      bool
 }
 
 // WriteHTMLFeaturesTable writes a table summarizing registered feature gates.
@@ -172,3 +179,26 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// GraphTableData contains data for the pipeline graph table template.
+type GraphTableData struct {
+	Rows []GraphTableRowData
+}
+
+// GraphTableRowData contains data for one edge of the pipeline graph table template.
+type GraphTableRowData struct {
+	Pipeline        string
+	From            string
+	To              string
+	Items           int64
+	ItemsRate       string
+	FailedItems     int64
+	FailedItemsRate string
+}
+
+// WriteHTMLGraphTable writes a table listing the edges of the pipeline graph along with their data rates.
+func WriteHTMLGraphTable(w io.Writer, gtd GraphTableData) {
+	if err := graphTableTemplate.Execute(w, gtd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
@@ -69,6 +69,15 @@ func TestNoCrash(t *testing.T) {
 	assert.NotPanics(t, func() {
 		WriteHTMLPropertiesTable(buf, PropertiesTableData{Name: "Bar", Properties: [][2]string{{"key", "value"}}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLGraphTable(buf, GraphTableData{Rows: []GraphTableRowData{{
+			Pipeline:  "traces",
+			From:      "receiver otlp",
+			To:        "pipeline traces",
+			Items:     10,
+			ItemsRate: "1.00",
+		}}})
+	})
 	assert.NotPanics(t, func() {
 		WriteHTMLFeaturesTable(buf, FeatureGateTableData{Rows: []FeatureGateTableRowData{
 			{
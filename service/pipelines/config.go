@@ -8,12 +8,16 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componententities"
 )
 
 var (
 	errMissingServicePipelines         = errors.New("service must have at least one pipeline")
 	errMissingServicePipelineReceivers = errors.New("must have at least one receiver")
 	errMissingServicePipelineExporters = errors.New("must have at least one exporter")
+	errNegativeNumConsumers            = errors.New("concurrency::num_consumers must not be negative")
+	errNegativeBufferSize              = errors.New("concurrency::buffer_size must not be negative")
+	errBufferSizeWithoutConsumers      = errors.New("concurrency::buffer_size requires concurrency::num_consumers")
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -28,7 +32,9 @@ func (cfg Config) Validate() error {
 	// Check that all pipelines have at least one receiver and one exporter, and they reference
 	// only configured components.
 	for pipelineID, pipeline := range cfg {
-		if pipelineID.Type() != component.DataTypeTraces && pipelineID.Type() != component.DataTypeMetrics && pipelineID.Type() != component.DataTypeLogs {
+		switch pipelineID.Type() {
+		case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs, componententities.DataTypeEntities:
+		default:
 			return fmt.Errorf("pipeline %q: unknown datatype %q", pipelineID, pipelineID.Type())
 		}
 
@@ -46,6 +52,36 @@ type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+
+	// Concurrency configures how the data is handed over from the receivers to the processors.
+	Concurrency ConcurrencyConfig `mapstructure:"concurrency"`
+}
+
+// ConcurrencyConfig defines how a pipeline hands the data over from its receivers to its processors.
+// By default, the processors are called synchronously by the receivers, returning to them the
+// errors of the pipeline. When NumConsumers is set, the receivers hand the data over through a
+// channel to goroutines calling the processors, and return once the data is in the channel.
+type ConcurrencyConfig struct {
+	// NumConsumers is the number of goroutines calling the processors of the pipeline.
+	// Zero, the default, calls the processors synchronously.
+	NumConsumers int `mapstructure:"num_consumers"`
+
+	// BufferSize is the number of pending requests held by the channel before the receivers are blocked.
+	// Zero, the default, blocks the receivers until a goroutine takes over the data.
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+func (cfg *ConcurrencyConfig) Validate() error {
+	if cfg.NumConsumers < 0 {
+		return errNegativeNumConsumers
+	}
+	if cfg.BufferSize < 0 {
+		return errNegativeBufferSize
+	}
+	if cfg.BufferSize > 0 && cfg.NumConsumers == 0 {
+		return errBufferSizeWithoutConsumers
+	}
+	return nil
 }
 
 func (cfg *PipelineConfig) Validate() error {
@@ -69,5 +105,5 @@ func (cfg *PipelineConfig) Validate() error {
 		procSet[ref] = struct{}{}
 	}
 
-	return nil
+	return cfg.Concurrency.Validate()
 }
@@ -52,6 +52,54 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`pipeline "traces": %w`, errMissingServicePipelineExporters),
 		},
+		{
+			name: "valid-concurrency",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Concurrency = ConcurrencyConfig{NumConsumers: 4, BufferSize: 100}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "negative-num-consumers",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Concurrency = ConcurrencyConfig{NumConsumers: -1}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errNegativeNumConsumers),
+		},
+		{
+			name: "negative-buffer-size",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Concurrency = ConcurrencyConfig{NumConsumers: 1, BufferSize: -1}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errNegativeBufferSize),
+		},
+		{
+			name: "buffer-size-without-consumers",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Concurrency = ConcurrencyConfig{BufferSize: 100}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errBufferSizeWithoutConsumers),
+		},
+		{
+			name: "valid-entities-pipeline",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("entities")] = &PipelineConfig{
+					Receivers: []component.ID{component.MustNewID("nop")},
+					Exporters: []component.ID{component.MustNewID("nop")},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
 		{
 			name: "missing-pipelines",
 			cfgFn: func() Config {
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"os"
+	"runtime"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+)
+
+// kubernetesEnvVars maps the attributes describing the pod of the collector to the environment variables
+// they are read from.
+var kubernetesEnvVars = map[string]string{
+	semconv.AttributeK8SPodName:       "K8S_POD_NAME",
+	semconv.AttributeK8SPodUID:        "K8S_POD_UID",
+	semconv.AttributeK8SNamespaceName: "K8S_NAMESPACE_NAME",
+	semconv.AttributeK8SNodeName:      "K8S_NODE_NAME",
+}
+
+// detectResourceAttributes returns the attributes describing the collector set on the exported data, or nil if
+// the resource detection is disabled. The service attributes are the ones of the collector's own telemetry.
+func detectResourceAttributes(cfg ResourceDetectionConfig, res pcommon.Resource) map[string]string {
+	if !cfg.Enabled {
+		return nil
+	}
+	attrs := map[string]string{
+		semconv.AttributeOSType: runtime.GOOS,
+	}
+	for _, name := range []string{semconv.AttributeServiceName, semconv.AttributeServiceVersion, semconv.AttributeServiceInstanceID} {
+		if v, ok := res.Attributes().Get(name); ok {
+			attrs[name] = v.AsString()
+		}
+	}
+	if hostName, err := os.Hostname(); err == nil {
+		attrs[semconv.AttributeHostName] = hostName
+	}
+	if cfg.Kubernetes {
+		for name, env := range kubernetesEnvVars {
+			if v := os.Getenv(env); v != "" {
+				attrs[name] = v
+			}
+		}
+	}
+	if cfg.Prefix == "" {
+		return attrs
+	}
+	prefixed := make(map[string]string, len(attrs))
+	for name, v := range attrs {
+		prefixed[cfg.Prefix+name] = v
+	}
+	return prefixed
+}
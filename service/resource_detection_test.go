@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDetectResourceAttributes(t *testing.T) {
+	hostName, err := os.Hostname()
+	require.NoError(t, err)
+	t.Setenv("K8S_POD_NAME", "collector-0")
+	t.Setenv("K8S_NAMESPACE_NAME", "observability")
+	t.Setenv("K8S_POD_UID", "")
+
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "otelcol")
+	res.Attributes().PutStr("service.version", "1.2.3")
+	res.Attributes().PutStr("service.instance.id", "instance")
+	res.Attributes().PutStr("other", "value")
+
+	assert.Nil(t, detectResourceAttributes(ResourceDetectionConfig{}, res))
+	assert.Equal(t, map[string]string{
+		"service.name":        "otelcol",
+		"service.version":     "1.2.3",
+		"service.instance.id": "instance",
+		"host.name":           hostName,
+		"os.type":             runtime.GOOS,
+	}, detectResourceAttributes(ResourceDetectionConfig{Enabled: true}, res))
+	assert.Equal(t, map[string]string{
+		"otelcol.service.name":        "otelcol",
+		"otelcol.service.version":     "1.2.3",
+		"otelcol.service.instance.id": "instance",
+		"otelcol.host.name":           hostName,
+		"otelcol.os.type":             runtime.GOOS,
+		"otelcol.k8s.pod.name":        "collector-0",
+		"otelcol.k8s.namespace.name":  "observability",
+	}, detectResourceAttributes(ResourceDetectionConfig{Enabled: true, Prefix: "otelcol.", Kubernetes: true}, res))
+}
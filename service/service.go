@@ -13,23 +13,29 @@ import (
 
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/internal/localhostgate"
+	"go.opentelemetry.io/collector/internal/memorygovernor"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/eventlog"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/diagnostics"
 	"go.opentelemetry.io/collector/service/internal/graph"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/internal/resource"
@@ -100,6 +106,10 @@ type Settings struct {
 
 	// LoggingOptions provides a way to change behavior of zap logging.
 	LoggingOptions []zap.Option
+
+	// EventLog keeps the recent events of the collector. It is shared by the successive services
+	// created when the configuration is reloaded, a new Log is created if nil.
+	EventLog *eventlog.Log
 }
 
 // Service represents the implementation of a component.Host.
@@ -108,8 +118,13 @@ type Service struct {
 	telemetrySettings component.TelemetrySettings
 	host              *graph.Host
 	collectorConf     *confmap.Conf
+	memoryGovernor    *memorygovernor.Governor
+	diagnostics       *diagnostics.Reporter
 }
 
+// agentEventLogSize is the number of events kept by the event log of the collector running with the agent runtime profile.
+const agentEventLogSize = 100
+
 // New creates a new Service, its telemetry, and Components.
 func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	disableHighCard := obsreportconfig.DisableHighCardinalityMetricsfeatureGate.IsEnabled()
@@ -140,6 +155,15 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		extensions = builders.NewExtension(set.ExtensionsConfigs, set.ExtensionsFactories)
 	}
 
+	eventLog := set.EventLog
+	if eventLog == nil {
+		evset := eventlog.NewDefaultSettings()
+		if runtimeprofile.IsAgent() {
+			evset.Size = agentEventLogSize
+		}
+		eventLog = eventlog.NewLog(evset)
+	}
+
 	srv := &Service{
 		buildInfo: set.BuildInfo,
 		host: &graph.Host{
@@ -151,6 +175,9 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			ModuleInfo:        set.ModuleInfo,
 			BuildInfo:         set.BuildInfo,
 			AsyncErrorChannel: set.AsyncErrorChannel,
+			StatusAggregator:  componentstatus.NewAggregator(),
+			EventLog:          eventLog,
+			CollectorConf:     set.CollectorConf,
 		},
 		collectorConf: set.CollectorConf,
 	}
@@ -177,11 +204,24 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 
 	logger.Info("Setting up own telemetry...")
 
+	if runtimeprofile.IsAgent() && cfg.Telemetry.Metrics.Level > configtelemetry.LevelBasic {
+		logger.Info("Agent runtime profile, capping the level of the internal metrics",
+			zap.Stringer("configured", cfg.Telemetry.Metrics.Level), zap.Stringer("level", configtelemetry.LevelBasic))
+		cfg.Telemetry.Metrics.Level = configtelemetry.LevelBasic
+	}
+
+	var diagnosticsReader *sdkmetric.ManualReader
+	var readers []sdkmetric.Reader
+	if cfg.Telemetry.Diagnostics.Interval > 0 && cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone {
+		diagnosticsReader = sdkmetric.NewManualReader()
+		readers = append(readers, diagnosticsReader)
+	}
 	mp, err := newMeterProvider(
 		meterProviderSettings{
 			res:               res,
 			cfg:               cfg.Telemetry.Metrics,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			readers:           readers,
 		},
 		disableHighCard,
 	)
@@ -222,6 +262,23 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		return nil, err
 	}
 
+	if diag := cfg.Telemetry.Diagnostics; diag.Interval > 0 {
+		srv.diagnostics = diagnostics.New(diagnostics.Settings{
+			Interval:            diag.Interval,
+			ProfilesDirectory:   diag.ProfilesDirectory,
+			HeapThreshold:       diag.HeapThresholdMiB * 1024 * 1024,
+			GoroutinesThreshold: diag.GoroutinesThreshold,
+			Reader:              diagnosticsReader,
+		}, logger)
+	}
+
+	if cfg.Memory.LimitPercentage > 0 {
+		if srv.memoryGovernor, err = memorygovernor.New(logger, cfg.Memory.LimitPercentage); err != nil {
+			err = multierr.Append(fmt.Errorf("failed to create memory governor: %w", err), srv.shutdownTelemetry(ctx))
+			return nil, err
+		}
+	}
+
 	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && cfg.Telemetry.Metrics.Address != "" {
 		// The process telemetry initialization requires the ballast size, which is available after the extensions are initialized.
 		if err = proctelemetry.RegisterProcessMetrics(srv.telemetrySettings); err != nil {
@@ -255,16 +312,24 @@ func logsAboutMeterProvider(logger *zap.Logger, cfg telemetry.MetricsConfig, mp
 
 // Start starts the extensions and pipelines. If Start fails Shutdown should be called to ensure a clean state.
 // Start does the following steps in order:
-// 1. Start all extensions.
-// 2. Notify extensions about Collector configuration
-// 3. Start all pipelines.
-// 4. Notify extensions that the pipeline is ready.
+// 1. Apply the soft memory limit of the Go runtime, if configured.
+// 2. Start all extensions.
+// 3. Notify extensions about Collector configuration
+// 4. Start all pipelines.
+// 5. Notify extensions that the pipeline is ready.
+// 6. Start the periodic diagnostics report, if configured.
 func (srv *Service) Start(ctx context.Context) error {
 	srv.telemetrySettings.Logger.Info("Starting "+srv.buildInfo.Command+"...",
 		zap.String("Version", srv.buildInfo.Version),
 		zap.Int("NumCPU", runtime.NumCPU()),
 	)
 
+	if srv.memoryGovernor != nil {
+		if err := srv.memoryGovernor.Start(); err != nil {
+			return fmt.Errorf("failed to start memory governor: %w", err)
+		}
+	}
+
 	// enable status reporting
 	srv.host.Reporter.Ready()
 
@@ -286,6 +351,11 @@ func (srv *Service) Start(ctx context.Context) error {
 		return err
 	}
 
+	if srv.diagnostics != nil {
+		srv.diagnostics.Start()
+	}
+
+	srv.host.EventLog.Record(eventlog.KindLifecycle, "", "Service started")
 	srv.telemetrySettings.Logger.Info("Everything is ready. Begin running and processing data.")
 	localhostgate.LogAboutUseLocalHostAsDefault(srv.telemetrySettings.Logger)
 	return nil
@@ -317,13 +387,16 @@ func (srv *Service) shutdownTelemetry(ctx context.Context) error {
 // 1. Notify extensions that the pipeline is shutting down.
 // 2. Shutdown all pipelines.
 // 3. Shutdown all extensions.
-// 4. Shutdown telemetry.
+// 4. Restore the soft memory limit of the Go runtime.
+// 5. Stop the periodic diagnostics report.
+// 6. Shutdown telemetry.
 func (srv *Service) Shutdown(ctx context.Context) error {
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs error
 
 	// Begin shutdown sequence.
 	srv.telemetrySettings.Logger.Info("Starting shutdown...")
+	srv.host.EventLog.Record(eventlog.KindLifecycle, "", "Service stopping")
 
 	if err := srv.host.ServiceExtensions.NotifyPipelineNotReady(); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
@@ -337,7 +410,16 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	if srv.memoryGovernor != nil {
+		srv.memoryGovernor.Shutdown()
+	}
+
+	if srv.diagnostics != nil {
+		srv.diagnostics.Shutdown()
+	}
+
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
+	srv.host.EventLog.Record(eventlog.KindLifecycle, "", "Service stopped")
 
 	errs = multierr.Append(errs, srv.shutdownTelemetry(ctx))
 
@@ -370,7 +452,16 @@ func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
 		ExporterBuilder:  srv.host.Exporters,
 		ConnectorBuilder: srv.host.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
+		DiscoveryConfigs: cfg.Discovery,
 		ReportStatus:     srv.host.Reporter.ReportStatus,
+
+		Limits:         cfg.Limits.limits(),
+		ReceiverLimits: cfg.Limits.receiverLimits(),
+
+		ResourceAttributes:         detectResourceAttributes(cfg.ResourceDetection, srv.telemetrySettings.Resource),
+		OverrideResourceAttributes: cfg.ResourceDetection.Override,
+
+		Watchdog: cfg.Watchdog.settings(),
 	}); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
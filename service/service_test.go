@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"runtime/debug"
 	"strings"
 	"sync"
 	"testing"
@@ -27,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/internal/runtimeprofile"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/service/extensions"
@@ -417,6 +420,53 @@ func TestNilCollectorEffectiveConfig(t *testing.T) {
 	require.NoError(t, srv.Shutdown(context.Background()))
 }
 
+func TestServiceMemoryGovernor(t *testing.T) {
+	t.Setenv("GOMEMLIMIT", "")
+	os.Unsetenv("GOMEMLIMIT")
+	previous := debug.SetMemoryLimit(-1)
+
+	cfg := newNopConfig()
+	cfg.Memory.LimitPercentage = 100
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, srv.memoryGovernor)
+
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NotEqual(t, previous, debug.SetMemoryLimit(-1))
+
+	require.NoError(t, srv.Shutdown(context.Background()))
+	assert.Equal(t, previous, debug.SetMemoryLimit(-1))
+}
+
+func TestServiceDiagnostics(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.Address = ""
+	cfg.Telemetry.Diagnostics.Interval = time.Hour
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, srv.diagnostics)
+	// The diagnostics reader is enough for the internal metrics to be recorded.
+	assert.IsType(t, &meterProvider{}, srv.telemetrySettings.MeterProvider)
+
+	require.NoError(t, srv.Start(context.Background()))
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceAgentProfile(t *testing.T) {
+	require.NoError(t, runtimeprofile.Set(runtimeprofile.Agent))
+	t.Cleanup(func() { require.NoError(t, runtimeprofile.Set(runtimeprofile.Default)) })
+
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.Level = configtelemetry.LevelDetailed
+	cfg.Telemetry.Metrics.Address = ""
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, configtelemetry.LevelBasic, srv.telemetrySettings.MetricsLevel)
+
+	require.NoError(t, srv.Start(context.Background()))
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
+
 func TestServiceTelemetryLogger(t *testing.T) {
 	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
 	require.NoError(t, err)
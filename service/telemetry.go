@@ -38,10 +38,12 @@ type meterProviderSettings struct {
 	res               *resource.Resource
 	cfg               telemetry.MetricsConfig
 	asyncErrorChannel chan error
+	// readers are the readers of the metrics used by the service itself, in addition to the configured ones.
+	readers []sdkmetric.Reader
 }
 
 func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (metric.MeterProvider, error) {
-	if set.cfg.Level == configtelemetry.LevelNone || (set.cfg.Address == "" && len(set.cfg.Readers) == 0) {
+	if set.cfg.Level == configtelemetry.LevelNone || (set.cfg.Address == "" && len(set.cfg.Readers) == 0 && len(set.readers) == 0) {
 		return noop.NewMeterProvider(), nil
 	}
 
@@ -83,6 +85,9 @@ func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (m
 		}
 		opts = append(opts, sdkmetric.WithReader(r))
 	}
+	for _, r := range set.readers {
+		opts = append(opts, sdkmetric.WithReader(r))
+	}
 
 	var err error
 	mp.MeterProvider, err = proctelemetry.InitOpenTelemetry(set.res, opts, disableHighCardinality)
@@ -4,6 +4,7 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,6 +25,48 @@ type Config struct {
 	// if they are not specified here. In order to suppress such attributes the
 	// attribute must be specified in this map with null YAML value (nil string pointer).
 	Resource map[string]*string `mapstructure:"resource"`
+
+	// Diagnostics configures the periodic self-diagnostics report of the collector.
+	Diagnostics DiagnosticsConfig `mapstructure:"diagnostics"`
+}
+
+// DiagnosticsConfig defines the self-diagnostics report periodically logged by the collector, to debug the
+// incidents after the fact: the heap, the goroutines, the pauses of the garbage collections, the depths of the
+// exporter queues and the throughput of the pipelines. The queue depths and the throughput are only reported
+// when the metrics level is not "none".
+type DiagnosticsConfig struct {
+	// Interval is the interval between two reports. Zero, the default, disables the reports.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// ProfilesDirectory is the directory the pprof profiles are written to when a threshold is breached,
+	// once per breach. Empty, the default, disables the profiles.
+	ProfilesDirectory string `mapstructure:"profiles_directory"`
+
+	// HeapThresholdMiB is the size of the heap, in MiB, above which a heap profile is written.
+	HeapThresholdMiB uint64 `mapstructure:"heap_threshold_mib"`
+
+	// GoroutinesThreshold is the number of goroutines above which a goroutine profile is written.
+	GoroutinesThreshold int `mapstructure:"goroutines_threshold"`
+}
+
+// Validate checks whether the diagnostics configuration is valid.
+func (c *DiagnosticsConfig) Validate() error {
+	if c.Interval < 0 {
+		return errors.New("'interval' must be non-negative")
+	}
+	if c.GoroutinesThreshold < 0 {
+		return errors.New("'goroutines_threshold' must be non-negative")
+	}
+	if c.ProfilesDirectory == "" {
+		return nil
+	}
+	if c.Interval == 0 {
+		return errors.New("'profiles_directory' requires an 'interval'")
+	}
+	if c.HeapThresholdMiB == 0 && c.GoroutinesThreshold == 0 {
+		return errors.New("'profiles_directory' requires 'heap_threshold_mib' or 'goroutines_threshold'")
+	}
+	return nil
 }
 
 // LogsConfig defines the configurable settings for service telemetry logs.
@@ -107,6 +150,35 @@ type LogsSamplingConfig struct {
 	// Thereafter represents the sampling rate, every Nth message will be sampled after Initial messages are logged during each Tick.
 	// If Thereafter is zero, the logger will drop all the messages after the Initial each Tick.
 	Thereafter int `mapstructure:"thereafter"`
+	// Policies overrides the sampling of the messages they match, e.g. to log less often the
+	// errors an exporter retrying against an unavailable backend logs repeatedly.
+	// Each message is sampled by the first policy matching it, or by the settings above if there is none.
+	// Like the settings above, a policy samples each distinct message and level separately.
+	// Example:
+	//
+	// 		policies:
+	//	   		- message: "Exporting failed.*"
+	//	   		  tick: 1m
+	//	   		  initial: 1
+	//	   		  thereafter: 0
+	Policies []LogsSamplingPolicyConfig `mapstructure:"policies"`
+}
+
+// LogsSamplingPolicyConfig sets the sampling of the messages matching a pattern.
+type LogsSamplingPolicyConfig struct {
+	// Message is a regular expression matched against the whole message of the logs.
+	// An empty expression matches all the messages.
+	Message string `mapstructure:"message"`
+	// Logger is the name of the logger the policy applies to, including its children.
+	// An empty name matches all the loggers.
+	Logger string `mapstructure:"logger"`
+	// Tick represents the interval that the policy applies each sampling.
+	Tick time.Duration `mapstructure:"tick"`
+	// Initial represents the first M messages logged each Tick.
+	Initial int `mapstructure:"initial"`
+	// Thereafter represents the sampling rate, every Nth message will be sampled after Initial messages are logged during each Tick.
+	// If Thereafter is zero, the logger will drop all the messages after the Initial each Tick.
+	Thereafter int `mapstructure:"thereafter"`
 }
 
 // MetricsConfig exposes the common Telemetry configuration for one component.
@@ -146,5 +218,13 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("collector telemetry metric address or reader should exist when metric level is not none")
 	}
 
+	if c.Logs.Sampling != nil {
+		for i, p := range c.Logs.Sampling.Policies {
+			if _, err := p.compile(); err != nil {
+				return fmt.Errorf("logs sampling policy %d: %w", i, err)
+			}
+		}
+	}
+
 	return nil
 }
@@ -4,6 +4,11 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -32,22 +37,101 @@ func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
 		return nil, err
 	}
 	if cfg.Sampling != nil && cfg.Sampling.Enabled {
-		logger = newSampledLogger(logger, cfg.Sampling)
+		logger, err = newSampledLogger(logger, cfg.Sampling)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return logger, nil
 }
 
-func newSampledLogger(logger *zap.Logger, sc *LogsSamplingConfig) *zap.Logger {
+func newSampledLogger(logger *zap.Logger, sc *LogsSamplingConfig) (*zap.Logger, error) {
+	policies := make([]samplingPolicy, 0, len(sc.Policies))
+	for _, pc := range sc.Policies {
+		p, err := pc.compile()
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
 	// Create a logger that samples every Nth message after the first M messages every S seconds
 	// where N = sc.Thereafter, M = sc.Initial, S = sc.Tick.
 	opts := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
-		return zapcore.NewSamplerWithOptions(
+		sampled := zapcore.NewSamplerWithOptions(
 			core,
 			sc.Tick,
 			sc.Initial,
 			sc.Thereafter,
 		)
+		if len(policies) == 0 {
+			return sampled
+		}
+		pc := &policyCore{Core: sampled, policies: make([]zapcore.Core, len(policies)), matches: policies}
+		for i, p := range policies {
+			pc.policies[i] = zapcore.NewSamplerWithOptions(core, p.tick, p.initial, p.thereafter)
+		}
+		return pc
 	})
-	return logger.WithOptions(opts)
+	return logger.WithOptions(opts), nil
+}
+
+// samplingPolicy is a compiled LogsSamplingPolicyConfig.
+type samplingPolicy struct {
+	message    *regexp.Regexp
+	logger     string
+	tick       time.Duration
+	initial    int
+	thereafter int
+}
+
+func (pc LogsSamplingPolicyConfig) compile() (samplingPolicy, error) {
+	if pc.Tick <= 0 {
+		return samplingPolicy{}, errors.New("tick must be positive")
+	}
+	if pc.Initial < 0 || pc.Thereafter < 0 {
+		return samplingPolicy{}, errors.New("initial and thereafter must be non-negative")
+	}
+	p := samplingPolicy{logger: pc.Logger, tick: pc.Tick, initial: pc.Initial, thereafter: pc.Thereafter}
+	if pc.Message != "" {
+		re, err := regexp.Compile("^(?:" + pc.Message + ")$")
+		if err != nil {
+			return samplingPolicy{}, err
+		}
+		p.message = re
+	}
+	return p, nil
+}
+
+func (p samplingPolicy) match(ent zapcore.Entry) bool {
+	if p.logger != "" && ent.LoggerName != p.logger && !strings.HasPrefix(ent.LoggerName, p.logger+".") {
+		return false
+	}
+	return p.message == nil || p.message.MatchString(ent.Message)
+}
+
+// policyCore samples each entry with the sampler of the first policy matching it,
+// or with the default sampler embedded if there is none.
+type policyCore struct {
+	zapcore.Core
+	policies []zapcore.Core
+	matches  []samplingPolicy
+}
+
+func (c *policyCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &policyCore{Core: c.Core.With(fields), policies: make([]zapcore.Core, len(c.policies)), matches: c.matches}
+	for i, p := range c.policies {
+		// The samplers returned by With share the counters of their parent.
+		clone.policies[i] = p.With(fields)
+	}
+	return clone
+}
+
+func (c *policyCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	for i, p := range c.matches {
+		if p.match(ent) {
+			return c.policies[i].Check(ent, ce)
+		}
+	}
+	return c.Core.Check(ent, ce)
 }
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
@@ -119,3 +121,47 @@ func TestSampledLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestSampledLoggerPolicies(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	logger, err := newLogger(LogsConfig{
+		Level:    zapcore.DebugLevel,
+		Encoding: "console",
+		Sampling: &LogsSamplingConfig{
+			Enabled:    true,
+			Tick:       time.Hour,
+			Initial:    5,
+			Thereafter: 0,
+			Policies: []LogsSamplingPolicyConfig{
+				{Message: "Exporting failed.*", Tick: time.Hour, Initial: 1},
+				{Logger: "quiet", Tick: time.Hour, Initial: 2},
+			},
+		},
+	}, []zap.Option{zap.WrapCore(func(zapcore.Core) zapcore.Core { return core })})
+	require.NoError(t, err)
+
+	exporter := logger.With(zap.String("kind", "exporter"))
+	for i := 0; i < 10; i++ {
+		exporter.Error("Exporting failed. Will retry the request after interval.")
+		logger.Named("quiet").Info("Starting")
+		logger.Named("quiet.child").Info("Stopping")
+		logger.Info("Everything is ready.")
+	}
+	assert.Equal(t, 1, observed.FilterMessageSnippet("Exporting failed").Len())
+	assert.Equal(t, 2, observed.FilterMessage("Starting").Len())
+	assert.Equal(t, 2, observed.FilterMessage("Stopping").Len())
+	assert.Equal(t, 5, observed.FilterMessage("Everything is ready.").Len())
+}
+
+func TestSampledLoggerInvalidPolicy(t *testing.T) {
+	for _, pc := range []LogsSamplingPolicyConfig{
+		{Message: "(", Tick: time.Second},
+		{Message: "failed"},
+		{Tick: time.Second, Initial: -1},
+	} {
+		cfg := LogsConfig{Sampling: &LogsSamplingConfig{Enabled: true, Tick: time.Second, Policies: []LogsSamplingPolicyConfig{pc}}}
+		_, err := newLogger(cfg, nil)
+		assert.Error(t, err)
+		assert.Error(t, (&Config{Logs: cfg, Metrics: MetricsConfig{Level: configtelemetry.LevelNone}}).Validate())
+	}
+}